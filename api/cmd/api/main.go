@@ -4,20 +4,26 @@ import (
 	"flag"
 	"fmt"
 	"log"
+	"net"
 	"net/http"
 	"path/filepath"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/uniris/uniris-core/api/pkg/adding"
 	"github.com/uniris/uniris-core/api/pkg/crypto"
+	"github.com/uniris/uniris-core/api/pkg/limiting"
 	"github.com/uniris/uniris-core/api/pkg/listing"
 	"github.com/uniris/uniris-core/api/pkg/system"
 	"github.com/uniris/uniris-core/api/pkg/transport/rest"
 	"github.com/uniris/uniris-core/api/pkg/transport/rpc"
+	"github.com/uniris/uniris-core/api/pkg/webhook"
+	"github.com/uniris/uniris-core/shared/pkg/connpool"
 )
 
 const (
 	defaultConfFile = "../../../conf.yaml"
+	callbackTimeout = 10 * time.Second
 )
 
 func main() {
@@ -36,16 +42,55 @@ func main() {
 	swaggerFile, _ := filepath.Abs("../../api/swagger-spec/swagger.yaml")
 	r.StaticFile("/swagger.yaml", swaggerFile)
 
-	signer := crypto.NewSigner()
-	client := rpc.NewRobotClient(config)
-	lister := listing.NewService(client, signer)
-	adder := adding.NewService(lister, client, signer)
+	trustedProxies, err := parseTrustedProxies(config.Services.API.TrustedProxies)
+	if err != nil {
+		log.Fatal(err)
+	}
+	r.Use(rest.RateLimit(newLimiter(config.Services.API.RateLimits), trustedProxies))
+
+	//The responses are checked against the specification only in test mode, as they are buffered
+	spec, err := rest.NewSpecValidator(swaggerFile)
+	if err != nil {
+		log.Fatal(err)
+	}
+	r.Use(rest.ValidateSpec(spec, gin.Mode() == gin.TestMode))
+
+	pool := connpool.NewPool(connpool.DefaultMaxCalls, connpool.DefaultIdleTimeout)
+	defer pool.Close()
 
-	rest.Handler(r, lister, adder)
+	client := rpc.NewRobotClient(config, pool)
+	signer := crypto.NewSigner(client)
+	guard := listing.NewReplayGuard()
+	lister := listing.NewService(client, signer, guard)
+	adder := adding.NewService(lister, client, signer, guard, adding.NewIdempotencyStore())
+	hooks := webhook.NewService(client, signer, guard, webhook.NewRegistry(), rest.NewCallbackSender(callbackTimeout))
+
+	rest.Handler(r, lister, adder, hooks)
+	rest.HandlerV2(r, lister, adder, hooks)
 
 	r.Run(fmt.Sprintf(":%d", config.Services.API.Port))
 }
 
+func newLimiter(conf system.RateLimitConfiguration) limiting.Limiter {
+	endpoints := make(map[string]limiting.Rule)
+	for route, l := range conf.Endpoints {
+		endpoints[route] = limiting.NewRule(l.Requests, l.Period, l.Burst)
+	}
+	return limiting.NewLimiter(limiting.NewMemoryStore(), limiting.NewRule(conf.Default.Requests, conf.Default.Period, conf.Default.Burst), endpoints)
+}
+
+func parseTrustedProxies(cidrs []string) ([]*net.IPNet, error) {
+	proxies := make([]*net.IPNet, 0)
+	for _, cidr := range cidrs {
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, err
+		}
+		proxies = append(proxies, ipNet)
+	}
+	return proxies, nil
+}
+
 func loadConfiguration() (conf system.UnirisConfig, err error) {
 	confFile := flag.String("config", defaultConfFile, "Configuration file")
 	flag.Parse()
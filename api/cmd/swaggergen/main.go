@@ -0,0 +1,30 @@
+package main
+
+import (
+	"flag"
+	"io/ioutil"
+	"log"
+
+	"github.com/uniris/uniris-core/api/pkg/transport/rest/swaggergen"
+)
+
+func main() {
+	specFile := flag.String("spec", "api/swagger-spec/swagger.yaml", "Swagger specification")
+	version := flag.String("version", "v2", "Version of the API to generate")
+	out := flag.String("out", "", "Generated Go file")
+	flag.Parse()
+
+	b, err := ioutil.ReadFile(*specFile)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	src, err := swaggergen.Generate(b, *version)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := ioutil.WriteFile(*out, src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -1,5 +1,7 @@
 package adding
 
+import "time"
+
 //AccountCreationResult represents the result of the account creation
 type AccountCreationResult interface {
 
@@ -28,6 +30,34 @@ func (r accCreateRes) Signature() string {
 	return r.sig
 }
 
+//AccountCreationBatchResult represents the outcome of an account creation of a batch
+type AccountCreationBatchResult interface {
+
+	//Result returns the result of the account creation, nil when it failed
+	Result() AccountCreationResult
+
+	//Err returns the error preventing the account creation
+	Err() error
+}
+
+type accCreateBatchRes struct {
+	res AccountCreationResult
+	err error
+}
+
+//NewAccountCreationBatchResult creates a new account creation batch result
+func NewAccountCreationBatchResult(res AccountCreationResult, err error) AccountCreationBatchResult {
+	return accCreateBatchRes{res, err}
+}
+
+func (r accCreateBatchRes) Result() AccountCreationResult {
+	return r.res
+}
+
+func (r accCreateBatchRes) Err() error {
+	return r.err
+}
+
 //AccountCreationTransactionResult represents the transactions for the account creation
 type AccountCreationTransactionResult interface {
 
@@ -65,6 +95,9 @@ type TransactionResult interface {
 	//MasterPeerIP returns the IP of the peer leading the transaction
 	MasterPeerIP() string
 
+	//EncryptedAddress returns the address of the transaction encrypted with the robot key, used to follow its status
+	EncryptedAddress() string
+
 	//Signature returns the signature of the transaction processing
 	Signature() string
 }
@@ -72,12 +105,13 @@ type TransactionResult interface {
 type txRes struct {
 	txHash   string
 	masterIP string
+	encAddr  string
 	sig      string
 }
 
 //NewTransactionResult creates a new transaction result
-func NewTransactionResult(txHash string, masterIP string, sig string) TransactionResult {
-	return txRes{txHash, masterIP, sig}
+func NewTransactionResult(txHash string, masterIP string, encAddr string, sig string) TransactionResult {
+	return txRes{txHash, masterIP, encAddr, sig}
 }
 
 func (r txRes) TransactionHash() string {
@@ -88,6 +122,10 @@ func (r txRes) MasterPeerIP() string {
 	return r.masterIP
 }
 
+func (r txRes) EncryptedAddress() string {
+	return r.encAddr
+}
+
 func (r txRes) Signature() string {
 	return r.sig
 }
@@ -101,19 +139,35 @@ type AccountCreationRequest interface {
 	//EncryptedKeychain returns the encrypted Keychain data to create
 	EncryptedKeychain() string
 
-	//Signature returns the signature of the
+	//Timestamp returns the time when the request has been signed
+	Timestamp() time.Time
+
+	//Nonce returns the unique value identifying the request
+	Nonce() string
+
+	//EmitterPublicKey returns the public key of the emitter to notify through its callback, empty when no callback is expected
+	EmitterPublicKey() string
+
+	//EmitterSignature returns the signature of the request by the emitter private key, proving the ownership of the emitter public key
+	EmitterSignature() string
+
+	//Signature returns the signature of the request
 	Signature() string
 }
 
 type accCreateReq struct {
 	encID       string
 	encKeychain string
+	timestamp   time.Time
+	nonce       string
+	emPubKey    string
+	emSig       string
 	sig         string
 }
 
 //NewAccountCreationRequest creates a new account creation request
-func NewAccountCreationRequest(encID, encKeychain, sig string) AccountCreationRequest {
-	return accCreateReq{encID, encKeychain, sig}
+func NewAccountCreationRequest(encID, encKeychain string, timestamp time.Time, nonce string, emPubKey string, emSig string, sig string) AccountCreationRequest {
+	return accCreateReq{encID, encKeychain, timestamp, nonce, emPubKey, emSig, sig}
 }
 
 func (r accCreateReq) EncryptedID() string {
@@ -124,6 +178,74 @@ func (r accCreateReq) EncryptedKeychain() string {
 	return r.encKeychain
 }
 
+func (r accCreateReq) Timestamp() time.Time {
+	return r.timestamp
+}
+
+func (r accCreateReq) Nonce() string {
+	return r.nonce
+}
+
+func (r accCreateReq) EmitterPublicKey() string {
+	return r.emPubKey
+}
+
+func (r accCreateReq) EmitterSignature() string {
+	return r.emSig
+}
+
 func (r accCreateReq) Signature() string {
 	return r.sig
 }
+
+//KeychainUpdateRequest represents the required data to submit a new keychain version for an existing account
+type KeychainUpdateRequest interface {
+
+	//EncryptedIDHash returns the hash of the ID encrypted with the robot key
+	EncryptedIDHash() string
+
+	//EncryptedKeychain returns the encrypted new keychain version
+	EncryptedKeychain() string
+
+	//Timestamp returns the time when the request has been signed
+	Timestamp() time.Time
+
+	//Nonce returns the unique value identifying the request
+	Nonce() string
+
+	//Signature returns the signature of the request made with the ID key
+	Signature() string
+}
+
+type keychainUpdateReq struct {
+	encIDHash   string
+	encKeychain string
+	timestamp   time.Time
+	nonce       string
+	sig         string
+}
+
+//NewKeychainUpdateRequest creates a new keychain update request
+func NewKeychainUpdateRequest(encIDHash, encKeychain string, timestamp time.Time, nonce string, sig string) KeychainUpdateRequest {
+	return keychainUpdateReq{encIDHash, encKeychain, timestamp, nonce, sig}
+}
+
+func (r keychainUpdateReq) EncryptedIDHash() string {
+	return r.encIDHash
+}
+
+func (r keychainUpdateReq) EncryptedKeychain() string {
+	return r.encKeychain
+}
+
+func (r keychainUpdateReq) Timestamp() time.Time {
+	return r.timestamp
+}
+
+func (r keychainUpdateReq) Nonce() string {
+	return r.nonce
+}
+
+func (r keychainUpdateReq) Signature() string {
+	return r.sig
+}
@@ -0,0 +1,140 @@
+package adding
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"sync"
+	"time"
+)
+
+//ErrIdempotencyKeyReused is returned when an idempotency key is sent again with another account creation
+var ErrIdempotencyKeyReused = errors.New("Idempotency key already used for another request")
+
+//ErrIdempotencyWithoutEmitter is returned when an idempotency key is sent with an account creation not signed by an emitter
+var ErrIdempotencyWithoutEmitter = errors.New("Idempotency key requires the emitter public key and signature")
+
+//ErrRequestInProgress is returned when an account creation with the same idempotency key is still processed
+var ErrRequestInProgress = errors.New("Request with the same idempotency key in progress")
+
+//idempotencyRetention is the duration the result of an account creation is kept for its idempotency key
+const idempotencyRetention = 24 * time.Hour
+
+//idempotencySweepInterval is the interval between two removals of the expired results
+const idempotencySweepInterval = time.Minute
+
+//IdempotencyStore defines methods to keep the results of the account creations by emitter and idempotency key
+type IdempotencyStore interface {
+
+	//Reserve registers the idempotency key of an emitter for a request fingerprint
+	//
+	//It returns the stored result when a request with the same key has already been processed
+	Reserve(emitter string, key string, fingerprint string) (AccountCreationResult, error)
+
+	//Complete stores the result of the request which reserved the key
+	Complete(emitter string, key string, res AccountCreationResult)
+
+	//Release removes the reservation of a failed request, so it can be retried with the same key
+	Release(emitter string, key string)
+}
+
+type idempotencyEntry struct {
+	fingerprint string
+	res         AccountCreationResult
+	expiration  time.Time
+}
+
+type idempotencyStore struct {
+	mu      sync.Mutex
+	entries map[string]map[string]*idempotencyEntry
+}
+
+//NewIdempotencyStore creates an idempotency store keeping the results of each emitter in memory during the retention
+//
+//The expired results are removed on a schedule, not while reserving the keys
+func NewIdempotencyStore() IdempotencyStore {
+	s := &idempotencyStore{
+		entries: make(map[string]map[string]*idempotencyEntry),
+	}
+	go s.expire(time.NewTicker(idempotencySweepInterval))
+	return s
+}
+
+func (s *idempotencyStore) Reserve(emitter string, key string, fingerprint string) (AccountCreationResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entries, exist := s.entries[emitter]
+	if !exist {
+		entries = make(map[string]*idempotencyEntry)
+		s.entries[emitter] = entries
+	}
+
+	e, exist := entries[key]
+	if !exist || (e.res != nil && e.expiration.Before(time.Now())) {
+		entries[key] = &idempotencyEntry{fingerprint: fingerprint}
+		return nil, nil
+	}
+	if e.fingerprint != fingerprint {
+		return nil, ErrIdempotencyKeyReused
+	}
+	if e.res == nil {
+		return nil, ErrRequestInProgress
+	}
+	return e.res, nil
+}
+
+func (s *idempotencyStore) Complete(emitter string, key string, res AccountCreationResult) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if e, exist := s.entries[emitter][key]; exist {
+		e.res = res
+		e.expiration = time.Now().Add(idempotencyRetention)
+	}
+}
+
+func (s *idempotencyStore) Release(emitter string, key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entries := s.entries[emitter]
+	if e, exist := entries[key]; exist && e.res == nil {
+		delete(entries, key)
+		if len(entries) == 0 {
+			delete(s.entries, emitter)
+		}
+	}
+}
+
+func (s *idempotencyStore) expire(ticker *time.Ticker) {
+	for now := range ticker.C {
+		s.sweep(now)
+	}
+}
+
+//sweep removes the expired results and the emitters without entries
+//
+//The reservations still processed have no expiration and are kept
+func (s *idempotencyStore) sweep(now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for emitter, entries := range s.entries {
+		for k, e := range entries {
+			if e.res != nil && e.expiration.Before(now) {
+				delete(entries, k)
+			}
+		}
+		if len(entries) == 0 {
+			delete(s.entries, emitter)
+		}
+	}
+}
+
+//fingerprint identifies the account creation of a request regardless of its signature, so a retry can be signed again
+func fingerprint(req AccountCreationRequest) string {
+	h := sha256.New()
+	h.Write([]byte(req.EncryptedID()))
+	h.Write([]byte{0})
+	h.Write([]byte(req.EncryptedKeychain()))
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -1,17 +1,63 @@
 package adding
 
 import (
+	"errors"
+
 	"github.com/uniris/uniris-core/api/pkg/listing"
+	"github.com/uniris/uniris-core/shared/pkg/parallel"
+)
+
+const (
+
+	//MaxBatchSize is the maximum number of account creations of a batch
+	//
+	//A batch is created synchronously, so its size is kept small enough to be answered before the client timeout
+	MaxBatchSize = 100
+
+	//batchConcurrency is the maximum number of account creations of a batch checked at the same time
+	batchConcurrency = 8
 )
 
+//ErrEmptyBatch is returned when a batch does not contain any account creation
+var ErrEmptyBatch = errors.New("Empty batch")
+
+//ErrBatchTooLarge is returned when a batch contains more account creations than allowed
+var ErrBatchTooLarge = errors.New("Batch too large")
+
+//ErrBatchMismatch is returned when the robot does not return a result for each account creation of a batch
+var ErrBatchMismatch = errors.New("Batch results do not match the requests")
+
+//ErrKeychainNotOwned is returned when a keychain update does not target the address of the account
+var ErrKeychainNotOwned = errors.New("Keychain does not belong to the account")
+
 //Service defines methods to adding to the blockchain
 type Service interface {
 	AddAccount(AccountCreationRequest) (AccountCreationResult, error)
+
+	//AddAccounts creates the accounts of a batch
+	//
+	//The results are returned in the order of the requests, an invalid request only fails its own account creation
+	AddAccounts([]AccountCreationRequest) ([]AccountCreationBatchResult, error)
+
+	//AddIdempotentAccount creates an account once for an idempotency key
+	//
+	//A request retried with the same key returns the original result without launching the transactions again,
+	//the returned flag reports whether the result was already stored
+	//
+	//The keys are scoped by emitter, so the request must be signed by an emitter or ErrIdempotencyWithoutEmitter is returned
+	AddIdempotentAccount(key string, req AccountCreationRequest) (AccountCreationResult, bool, error)
+
+	//UpdateKeychain submits a new keychain version for an existing account
+	//
+	//The request signature, its freshness and the keychain ownership are checked by the datamining service as it holds the ID public key
+	UpdateKeychain(KeychainUpdateRequest) (TransactionResult, error)
 }
 
 //RobotClient define methods to interfact with the robot
 type RobotClient interface {
 	AddAccount(AccountCreationRequest) (AccountCreationResult, error)
+	AddAccounts([]AccountCreationRequest) ([]AccountCreationBatchResult, error)
+	UpdateKeychain(KeychainUpdateRequest) (TransactionResult, error)
 }
 
 //Signer defines methods to handle signature
@@ -25,25 +71,30 @@ type signatureVerifier interface {
 	//VerifyAccountCreationRequestSignature checks the signature of the account creation request
 	VerifyAccountCreationRequestSignature(req AccountCreationRequest, key string) error
 
+	//VerifyAccountCreationEmitterSignature checks the signature of the account creation request by the emitter expecting the callback
+	VerifyAccountCreationEmitterSignature(req AccountCreationRequest) error
+
 	//VerifyCreationTransactionResultSignature checks the signature of a creation transaction result
 	VerifyCreationTransactionResultSignature(res TransactionResult, pubKey string) error
 }
 
 type signatureBuilder interface {
 
-	//SignAccountCreationResult signs the account creation result
-	SignAccountCreationResult(data AccountCreationResult, key string) (AccountCreationResult, error)
+	//SignAccountCreationResult signs the account creation result with the shared robot key
+	SignAccountCreationResult(data AccountCreationResult) (AccountCreationResult, error)
 }
 
 type service struct {
 	lister listing.Service
 	client RobotClient
 	sig    Signer
+	guard  listing.ReplayGuard
+	store  IdempotencyStore
 }
 
 //NewService creates a new adding service
-func NewService(lister listing.Service, client RobotClient, sig Signer) Service {
-	return service{lister, client, sig}
+func NewService(lister listing.Service, client RobotClient, sig Signer, guard listing.ReplayGuard, store IdempotencyStore) Service {
+	return service{lister, client, sig, guard, store}
 }
 
 func (s service) AddAccount(req AccountCreationRequest) (AccountCreationResult, error) {
@@ -51,26 +102,175 @@ func (s service) AddAccount(req AccountCreationRequest) (AccountCreationResult,
 	if err != nil {
 		return nil, err
 	}
-	if err := s.sig.VerifyAccountCreationRequestSignature(req, keys.RequestPublicKey()); err != nil {
+	signer, err := s.verifyAccountCreationRequest(req, keys)
+	if err != nil {
 		return nil, err
 	}
 
-	res, err := s.client.AddAccount(req)
+	return s.createAccount(req, signer, keys)
+}
+
+func (s service) AddIdempotentAccount(key string, req AccountCreationRequest) (AccountCreationResult, bool, error) {
+	if req.EmitterPublicKey() == "" {
+		return nil, false, ErrIdempotencyWithoutEmitter
+	}
+
+	keys, err := s.lister.GetSafeSharedKeys()
+	if err != nil {
+		return nil, false, err
+	}
+
+	//The signatures are checked before the lookup, so only the emitter of a request can retrieve its stored result
+	signer, err := s.verifyAccountCreationRequest(req, keys)
+	if err != nil {
+		return nil, false, err
+	}
+
+	stored, err := s.store.Reserve(req.EmitterPublicKey(), key, fingerprint(req))
+	if err != nil {
+		return nil, false, err
+	}
+	if stored != nil {
+
+		//A retry can reuse the nonce of the original request, so the replay guard is not checked again
+		return stored, true, nil
+	}
+
+	res, err := s.createAccount(req, signer, keys)
+	if err != nil {
+		s.store.Release(req.EmitterPublicKey(), key)
+		return nil, false, err
+	}
+	s.store.Complete(req.EmitterPublicKey(), key, res)
+	return res, false, nil
+}
+
+func (s service) AddAccounts(reqs []AccountCreationRequest) ([]AccountCreationBatchResult, error) {
+	if len(reqs) == 0 {
+		return nil, ErrEmptyBatch
+	}
+	if len(reqs) > MaxBatchSize {
+		return nil, ErrBatchTooLarge
+	}
+
+	keys, err := s.lister.GetSafeSharedKeys()
 	if err != nil {
 		return nil, err
 	}
 
-	if err := s.sig.VerifyCreationTransactionResultSignature(res.ResultTransactions().ID(), keys.RobotPublicKey()); err != nil {
+	results := make([]AccountCreationBatchResult, len(reqs))
+	parallel.Each(len(reqs), batchConcurrency, func(i int) {
+		if err := s.checkAccountCreationRequest(reqs[i], keys); err != nil {
+			results[i] = NewAccountCreationBatchResult(nil, err)
+		}
+	})
+
+	//The valid requests are sent together, so the datamining service launches them in a single call
+	accepted := make([]AccountCreationRequest, 0)
+	indexes := make([]int, 0)
+	for i, req := range reqs {
+		if results[i] == nil {
+			accepted = append(accepted, req)
+			indexes = append(indexes, i)
+		}
+	}
+	if len(accepted) == 0 {
+		return results, nil
+	}
+
+	created, err := s.client.AddAccounts(accepted)
+	if err != nil {
 		return nil, err
 	}
+	if len(created) != len(accepted) {
+		return nil, ErrBatchMismatch
+	}
+
+	parallel.Each(len(created), batchConcurrency, func(i int) {
+		if created[i].Err() != nil {
+			results[indexes[i]] = created[i]
+			return
+		}
+		res, err := s.signAccountCreationResult(created[i].Result(), keys)
+		results[indexes[i]] = NewAccountCreationBatchResult(res, err)
+	})
 
-	if err := s.sig.VerifyCreationTransactionResultSignature(res.ResultTransactions().Keychain(), keys.RobotPublicKey()); err != nil {
+	return results, nil
+}
+
+func (s service) UpdateKeychain(req KeychainUpdateRequest) (TransactionResult, error) {
+	keys, err := s.lister.GetSafeSharedKeys()
+	if err != nil {
 		return nil, err
 	}
 
-	res, err = s.sig.SignAccountCreationResult(res, keys.RobotPrivateKey())
+	res, err := s.client.UpdateKeychain(req)
 	if err != nil {
 		return nil, err
 	}
+
+	if err := s.verifyTransactionResult(res, keys); err != nil {
+		return nil, err
+	}
 	return res, nil
 }
+
+//createAccount checks the freshness of a request verified with the signer key and asks the robot to create the account
+func (s service) createAccount(req AccountCreationRequest, signer string, keys listing.SharedKeys) (AccountCreationResult, error) {
+	if err := s.guard.CheckRequest(signer, req); err != nil {
+		return nil, err
+	}
+
+	res, err := s.client.AddAccount(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.signAccountCreationResult(res, keys)
+}
+
+//checkAccountCreationRequest checks the signature and the freshness of an account creation request
+func (s service) checkAccountCreationRequest(req AccountCreationRequest, keys listing.SharedKeys) error {
+	signer, err := s.verifyAccountCreationRequest(req, keys)
+	if err != nil {
+		return err
+	}
+	return s.guard.CheckRequest(signer, req)
+}
+
+//verifyAccountCreationRequest checks the signature of an account creation request and returns the shared emitter key which signed it
+//
+//The emitter expecting a callback must sign the request too, so no one can subscribe the callback of another emitter
+func (s service) verifyAccountCreationRequest(req AccountCreationRequest, keys listing.SharedKeys) (string, error) {
+	signer, err := listing.VerifyEmitterRequest(keys, func(pubKey string) error {
+		return s.sig.VerifyAccountCreationRequestSignature(req, pubKey)
+	})
+	if err != nil {
+		return "", err
+	}
+	if req.EmitterPublicKey() == "" {
+		return signer, nil
+	}
+	return signer, s.sig.VerifyAccountCreationEmitterSignature(req)
+}
+
+//signAccountCreationResult checks the transaction results returned by the robot and signs the account creation result
+func (s service) signAccountCreationResult(res AccountCreationResult, keys listing.SharedKeys) (AccountCreationResult, error) {
+	if err := s.verifyTransactionResult(res.ResultTransactions().ID(), keys); err != nil {
+		return nil, err
+	}
+	if err := s.verifyTransactionResult(res.ResultTransactions().Keychain(), keys); err != nil {
+		return nil, err
+	}
+	return s.sig.SignAccountCreationResult(res)
+}
+
+//verifyTransactionResult checks the result signature with the robot key versions as it can be signed by a newer or a previous one during a switch-over
+func (s service) verifyTransactionResult(res TransactionResult, keys listing.SharedKeys) (err error) {
+	for _, pub := range keys.RobotPublicKeys() {
+		if err = s.sig.VerifyCreationTransactionResultSignature(res, pub); err == nil {
+			return nil
+		}
+	}
+	return err
+}
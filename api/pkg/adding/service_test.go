@@ -1,8 +1,10 @@
 package adding
 
 import (
+	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/uniris/uniris-core/api/pkg/listing"
@@ -17,9 +19,10 @@ Scenario: Enroll an user
 func TestAddAccount(t *testing.T) {
 	c := mockClient{}
 	sig := mockSigVerifier{}
-	l := listing.NewService(c, sig)
-	s := NewService(l, c, sig)
-	req := NewAccountCreationRequest("encrypted ID", "encrypted keychain", "sig")
+	guard := listing.NewReplayGuard()
+	l := listing.NewService(c, sig, guard)
+	s := NewService(l, c, sig, guard, NewIdempotencyStore())
+	req := NewAccountCreationRequest("encrypted ID", "encrypted keychain", time.Now(), "nonce", "", "", "sig")
 
 	res, err := s.AddAccount(req)
 	assert.Nil(t, err)
@@ -37,25 +40,337 @@ Scenario: Catch invalid signature when get account's details from the robot
 func TestAddAccountInvalidSig(t *testing.T) {
 	c := mockClient{}
 	sig := mockSigVerifier{isInvalid: true}
-	l := listing.NewService(c, sig)
-	s := NewService(l, c, sig)
+	guard := listing.NewReplayGuard()
+	l := listing.NewService(c, sig, guard)
+	s := NewService(l, c, sig, guard, NewIdempotencyStore())
 
-	req := NewAccountCreationRequest("encrypted ID", "encrypted keychain", "sig")
+	req := NewAccountCreationRequest("encrypted ID", "encrypted keychain", time.Now(), "nonce", "", "", "sig")
 
 	_, err := s.AddAccount(req)
 	assert.Equal(t, err, errors.New("Invalid signature"))
 }
 
+/*
+Scenario: Add an account expecting the callback of an emitter
+	Given an account creation request naming an emitter public key
+	When the request is not signed by the emitter
+	Then the account is not created
+*/
+func TestAddAccountWithEmitter(t *testing.T) {
+	c := mockClient{}
+	sig := mockSigVerifier{}
+	guard := listing.NewReplayGuard()
+	l := listing.NewService(c, sig, guard)
+	s := NewService(l, c, sig, guard, NewIdempotencyStore())
+
+	_, err := s.AddAccount(NewAccountCreationRequest("encrypted ID", "encrypted keychain", time.Now(), "nonce1", "em key", "", "sig"))
+	assert.Equal(t, errors.New("Invalid signature"), err)
+
+	_, err = s.AddAccount(NewAccountCreationRequest("encrypted ID", "encrypted keychain", time.Now(), "nonce2", "em key", "emitter sig", "sig"))
+	assert.Nil(t, err)
+}
+
+/*
+Scenario: Replay an account creation request
+	Given an account creation request already received
+	When I send the same request again
+	Then I get a replay error
+*/
+func TestAddAccountReplayed(t *testing.T) {
+	c := mockClient{}
+	sig := mockSigVerifier{}
+	guard := listing.NewReplayGuard()
+	l := listing.NewService(c, sig, guard)
+	s := NewService(l, c, sig, guard, NewIdempotencyStore())
+	req := NewAccountCreationRequest("encrypted ID", "encrypted keychain", time.Now(), "nonce", "", "", "sig")
+
+	_, err := s.AddAccount(req)
+	assert.Nil(t, err)
+
+	_, err = s.AddAccount(req)
+	assert.Equal(t, listing.ErrReplayedRequest, err)
+}
+
+/*
+Scenario: Retry an account creation with an idempotency key
+	Given an account creation request already processed with an idempotency key
+	When I send it again with the same key and the same nonce
+	Then I get the original result without creating the account again
+*/
+func TestAddIdempotentAccount(t *testing.T) {
+	c := &mockCountingClient{}
+	sig := mockSigVerifier{}
+	guard := listing.NewReplayGuard()
+	l := listing.NewService(c, sig, guard)
+	s := NewService(l, c, sig, guard, NewIdempotencyStore())
+	req := NewAccountCreationRequest("encrypted ID", "encrypted keychain", time.Now(), "nonce", "em pub key", "emitter sig", "sig")
+
+	res, replayed, err := s.AddIdempotentAccount("key", req)
+	assert.Nil(t, err)
+	assert.False(t, replayed)
+
+	retried, replayed, err := s.AddIdempotentAccount("key", req)
+	assert.Nil(t, err)
+	assert.True(t, replayed)
+	assert.Equal(t, res, retried)
+	assert.Equal(t, 1, c.creations)
+}
+
+/*
+Scenario: Reuse an idempotency key for another account creation
+	Given an account creation request processed with an idempotency key
+	When I send another account creation with the same key
+	Then I get an error
+*/
+func TestAddIdempotentAccountKeyReused(t *testing.T) {
+	c := &mockCountingClient{}
+	sig := mockSigVerifier{}
+	guard := listing.NewReplayGuard()
+	l := listing.NewService(c, sig, guard)
+	s := NewService(l, c, sig, guard, NewIdempotencyStore())
+
+	_, _, err := s.AddIdempotentAccount("key", NewAccountCreationRequest("encrypted ID", "encrypted keychain", time.Now(), "nonce1", "em pub key", "emitter sig", "sig"))
+	assert.Nil(t, err)
+
+	_, _, err = s.AddIdempotentAccount("key", NewAccountCreationRequest("other ID", "encrypted keychain", time.Now(), "nonce2", "em pub key", "emitter sig", "sig"))
+	assert.Equal(t, ErrIdempotencyKeyReused, err)
+	assert.Equal(t, 1, c.creations)
+}
+
+/*
+Scenario: Retry a failed account creation with an idempotency key
+	Given an account creation request failing with an idempotency key
+	When I send it again with the same key
+	Then the account creation is processed again
+*/
+func TestAddIdempotentAccountRetryAfterFailure(t *testing.T) {
+	c := &mockCountingClient{fail: true}
+	sig := mockSigVerifier{}
+	guard := listing.NewReplayGuard()
+	l := listing.NewService(c, sig, guard)
+	s := NewService(l, c, sig, guard, NewIdempotencyStore())
+
+	_, _, err := s.AddIdempotentAccount("key", NewAccountCreationRequest("encrypted ID", "encrypted keychain", time.Now(), "nonce1", "em pub key", "emitter sig", "sig"))
+	assert.Equal(t, errors.New("Unreachable robot"), err)
+
+	c.fail = false
+	_, replayed, err := s.AddIdempotentAccount("key", NewAccountCreationRequest("encrypted ID", "encrypted keychain", time.Now(), "nonce2", "em pub key", "emitter sig", "sig"))
+	assert.Nil(t, err)
+	assert.False(t, replayed)
+	assert.Equal(t, 2, c.creations)
+}
+
+/*
+Scenario: Send an idempotency key without emitter
+	Given an account creation request without emitter public key
+	When I send it with an idempotency key
+	Then I get an error and the account is not created
+*/
+func TestAddIdempotentAccountWithoutEmitter(t *testing.T) {
+	c := &mockCountingClient{}
+	sig := mockSigVerifier{}
+	guard := listing.NewReplayGuard()
+	l := listing.NewService(c, sig, guard)
+	s := NewService(l, c, sig, guard, NewIdempotencyStore())
+
+	_, _, err := s.AddIdempotentAccount("key", NewAccountCreationRequest("encrypted ID", "encrypted keychain", time.Now(), "nonce", "", "", "sig"))
+	assert.Equal(t, ErrIdempotencyWithoutEmitter, err)
+	assert.Equal(t, 0, c.creations)
+}
+
+/*
+Scenario: Reserve an idempotency key still processed
+	Given an idempotency key reserved by a request not completed
+	When I reserve it again for the same request
+	Then I get an error
+*/
+func TestIdempotencyStoreInProgress(t *testing.T) {
+	store := NewIdempotencyStore()
+
+	res, err := store.Reserve("emitter", "key", "fingerprint")
+	assert.Nil(t, err)
+	assert.Nil(t, res)
+
+	_, err = store.Reserve("emitter", "key", "fingerprint")
+	assert.Equal(t, ErrRequestInProgress, err)
+
+	store.Release("emitter", "key")
+	_, err = store.Reserve("emitter", "key", "fingerprint")
+	assert.Nil(t, err)
+}
+
+/*
+Scenario: Reserve the same idempotency key from two emitters
+	Given an idempotency key reserved by an emitter
+	When another emitter reserves the same key for another request
+	Then the reservations do not collide
+*/
+func TestIdempotencyStoreEmitters(t *testing.T) {
+	store := NewIdempotencyStore()
+
+	_, err := store.Reserve("emitter1", "key", "fingerprint1")
+	assert.Nil(t, err)
+	res, err := store.Reserve("emitter2", "key", "fingerprint2")
+	assert.Nil(t, err)
+	assert.Nil(t, res)
+}
+
+/*
+Scenario: Remove the expired results on schedule
+	Given results stored for several emitters, one out of the retention, and a reservation still processed
+	When the expired results are swept
+	Then only the valid results, the reservation and their emitters are kept
+*/
+func TestIdempotencyStoreSweep(t *testing.T) {
+	s := &idempotencyStore{entries: map[string]map[string]*idempotencyEntry{
+		"emitter1": map[string]*idempotencyEntry{
+			"key": &idempotencyEntry{fingerprint: "fingerprint", res: accCreateRes{}, expiration: time.Now().Add(-time.Second)},
+		},
+		"emitter2": map[string]*idempotencyEntry{
+			"key":        &idempotencyEntry{fingerprint: "fingerprint", res: accCreateRes{}, expiration: time.Now().Add(time.Hour)},
+			"processing": &idempotencyEntry{fingerprint: "fingerprint"},
+		},
+	}}
+	s.sweep(time.Now())
+	assert.Len(t, s.entries, 1)
+	assert.Len(t, s.entries["emitter2"], 2)
+}
+
+/*
+Scenario: Create a batch of accounts
+	Given several account creation requests with one rejected by the robot
+	When I want to create the accounts
+	Then I get the results in the order of the requests and only the rejected account fails
+*/
+func TestAddAccounts(t *testing.T) {
+	c := mockClient{}
+	sig := mockSigVerifier{}
+	guard := listing.NewReplayGuard()
+	l := listing.NewService(c, sig, guard)
+	s := NewService(l, c, sig, guard, NewIdempotencyStore())
+
+	res, err := s.AddAccounts([]AccountCreationRequest{
+		NewAccountCreationRequest("encrypted ID", "encrypted keychain", time.Now(), "nonce1", "", "", "sig"),
+		NewAccountCreationRequest("rejected ID", "encrypted keychain", time.Now(), "nonce2", "", "", "sig"),
+		NewAccountCreationRequest("encrypted ID", "encrypted keychain", time.Now(), "nonce3", "", "", "sig"),
+	})
+	assert.Nil(t, err)
+	assert.Len(t, res, 3)
+	assert.Nil(t, res[0].Err())
+	assert.Equal(t, "transaction hash", res[0].Result().ResultTransactions().ID().TransactionHash())
+	assert.Equal(t, errors.New("Invalid ID"), res[1].Err())
+	assert.Nil(t, res[1].Result())
+	assert.Nil(t, res[2].Err())
+	assert.Equal(t, "sig", res[2].Result().Signature())
+}
+
+/*
+Scenario: Create a batch of accounts with a replayed request
+	Given a batch containing an account creation request already received
+	When I want to create the accounts
+	Then only the replayed request fails
+*/
+func TestAddAccountsReplayed(t *testing.T) {
+	c := mockClient{}
+	sig := mockSigVerifier{}
+	guard := listing.NewReplayGuard()
+	l := listing.NewService(c, sig, guard)
+	s := NewService(l, c, sig, guard, NewIdempotencyStore())
+
+	_, err := s.AddAccount(NewAccountCreationRequest("encrypted ID", "encrypted keychain", time.Now(), "nonce1", "", "", "sig"))
+	assert.Nil(t, err)
+
+	res, err := s.AddAccounts([]AccountCreationRequest{
+		NewAccountCreationRequest("encrypted ID", "encrypted keychain", time.Now(), "nonce1", "", "", "sig"),
+		NewAccountCreationRequest("encrypted ID", "encrypted keychain", time.Now(), "nonce2", "", "", "sig"),
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, listing.ErrReplayedRequest, res[0].Err())
+	assert.Nil(t, res[1].Err())
+}
+
+/*
+Scenario: Create an empty or too large batch of accounts
+	Given a batch without request and a batch exceeding the maximum size
+	When I want to create the accounts
+	Then I get an error
+*/
+func TestAddAccountsInvalidBatchSize(t *testing.T) {
+	c := mockClient{}
+	sig := mockSigVerifier{}
+	guard := listing.NewReplayGuard()
+	l := listing.NewService(c, sig, guard)
+	s := NewService(l, c, sig, guard, NewIdempotencyStore())
+
+	_, err := s.AddAccounts([]AccountCreationRequest{})
+	assert.Equal(t, ErrEmptyBatch, err)
+
+	_, err = s.AddAccounts(make([]AccountCreationRequest, MaxBatchSize+1))
+	assert.Equal(t, ErrBatchTooLarge, err)
+}
+
+/*
+Scenario: Update the keychain of an account
+	Given a keychain update request signed by the ID key
+	When I want to update the keychain
+	Then I get the transaction result signed by the robot
+*/
+func TestUpdateKeychain(t *testing.T) {
+	c := mockClient{}
+	sig := mockSigVerifier{}
+	guard := listing.NewReplayGuard()
+	l := listing.NewService(c, sig, guard)
+	s := NewService(l, c, sig, guard, NewIdempotencyStore())
+
+	res, err := s.UpdateKeychain(NewKeychainUpdateRequest("enc id hash", "encrypted keychain", time.Now(), "nonce", "sig"))
+	assert.Nil(t, err)
+	assert.Equal(t, "transaction hash", res.TransactionHash())
+}
+
+/*
+Scenario: Update the keychain with a result not signed by the robot
+	Given a keychain update result with an invalid signature
+	When I want to update the keychain
+	Then I get an error
+*/
+func TestUpdateKeychainInvalidResult(t *testing.T) {
+	c := mockClient{}
+	sig := mockSigVerifier{isInvalid: true}
+	guard := listing.NewReplayGuard()
+	l := listing.NewService(c, sig, guard)
+	s := NewService(l, c, sig, guard, NewIdempotencyStore())
+
+	_, err := s.UpdateKeychain(NewKeychainUpdateRequest("enc id hash", "encrypted keychain", time.Now(), "nonce", "sig"))
+	assert.Equal(t, errors.New("Invalid signature"), err)
+}
+
 type mockClient struct{}
 
 func (c mockClient) AddAccount(AccountCreationRequest) (AccountCreationResult, error) {
-	txID := NewTransactionResult("transaction hash", "", "")
-	txKeychain := NewTransactionResult("transaction hash", "", "")
+	txID := NewTransactionResult("transaction hash", "", "enc addr", "")
+	txKeychain := NewTransactionResult("transaction hash", "", "enc addr", "")
 
 	res := NewAccountCreationTransactionResult(txID, txKeychain)
 	return NewAccountCreationResult(res, "sig"), nil
 }
 
+func (c mockClient) AddAccounts(reqs []AccountCreationRequest) ([]AccountCreationBatchResult, error) {
+	results := make([]AccountCreationBatchResult, 0)
+	for _, req := range reqs {
+		if req.EncryptedID() == "rejected ID" {
+			results = append(results, NewAccountCreationBatchResult(nil, errors.New("Invalid ID")))
+			continue
+		}
+		res, _ := c.AddAccount(req)
+		results = append(results, NewAccountCreationBatchResult(res, nil))
+	}
+	return results, nil
+}
+
+func (c mockClient) UpdateKeychain(KeychainUpdateRequest) (TransactionResult, error) {
+	return NewTransactionResult("transaction hash", "", "enc addr", ""), nil
+}
+
 func (c mockClient) GetAccount(encIDHash string) (listing.AccountResult, error) {
 	return listing.NewAccountResult("encrypted_aes_key", "encrypted_wallet", "encrypted_address", "sig"), nil
 }
@@ -69,17 +384,56 @@ func (c mockClient) IsEmitterAuthorized(emPubKey string) error {
 
 func (c mockClient) GetSharedKeys() (listing.SharedKeys, error) {
 	return listing.NewSharedKeys(
-		"robot pv key",
 		"robot pub key",
+		[]listing.RobotKeyPair{},
 		[]listing.SharedKeyPair{
 			listing.NewSharedKeyPair("enc pv key", "pub key"),
-		}), nil
+		},
+		[]listing.PreviousSharedKeyPair{}), nil
 }
 
 func (c mockClient) GetTransactionStatus(addr string, txHash string) (listing.TransactionStatus, error) {
 	return listing.TransactionSuccess, nil
 }
 
+func (c mockClient) WatchTransactionStatus(ctx context.Context, addr string, txHash string) (<-chan listing.TransactionStatus, error) {
+	return nil, nil
+}
+
+func (c mockClient) GetIDDetails(encHash string) (listing.IDDetails, error) {
+	return nil, nil
+}
+
+func (c mockClient) GetKeychainDetails(encHash string) (listing.KeychainDetails, error) {
+	return nil, nil
+}
+
+func (c mockClient) GetAccountProof(encHash string) (listing.AccountProof, error) {
+	return nil, nil
+}
+
+func (c mockClient) GetAccountCreationStatus(idTxHash string) (listing.AccountCreationState, error) {
+	return listing.NewAccountCreationState(listing.AccountCreationSuccess, listing.TransactionSuccess, listing.TransactionSuccess, "keychain hash"), nil
+}
+
+func (c mockClient) GetStoragePeers(addr string) ([]listing.Peer, error) {
+	return nil, nil
+}
+
+type mockCountingClient struct {
+	mockClient
+	fail      bool
+	creations int
+}
+
+func (c *mockCountingClient) AddAccount(req AccountCreationRequest) (AccountCreationResult, error) {
+	c.creations++
+	if c.fail {
+		return nil, errors.New("Unreachable robot")
+	}
+	return c.mockClient.AddAccount(req)
+}
+
 type mockSigVerifier struct {
 	isInvalid bool
 }
@@ -91,6 +445,13 @@ func (v mockSigVerifier) VerifyAccountCreationRequestSignature(req AccountCreati
 	return nil
 }
 
+func (v mockSigVerifier) VerifyAccountCreationEmitterSignature(req AccountCreationRequest) error {
+	if req.EmitterSignature() != "emitter sig" {
+		return errors.New("Invalid signature")
+	}
+	return nil
+}
+
 func (v mockSigVerifier) VerifyAccountCreationResultSignature(req AccountCreationResult, pubKey string) error {
 	if v.isInvalid {
 		return errors.New("Invalid signature")
@@ -105,16 +466,23 @@ func (v mockSigVerifier) VerifyAccountResultSignature(res listing.AccountResult,
 	return nil
 }
 
-func (v mockSigVerifier) SignAccountCreationResult(res AccountCreationResult, pvKey string) (AccountCreationResult, error) {
+func (v mockSigVerifier) SignAccountCreationResult(res AccountCreationResult) (AccountCreationResult, error) {
 	return NewAccountCreationResult(
 		NewAccountCreationTransactionResult(
-			NewTransactionResult("transaction hash", "ip", "sig"),
-			NewTransactionResult("transaction hash", "ip", "sig"),
+			NewTransactionResult("transaction hash", "ip", "enc addr", "sig"),
+			NewTransactionResult("transaction hash", "ip", "enc addr", "sig"),
 		), "sig",
 	), nil
 }
 
-func (v mockSigVerifier) VerifyHashSignature(data string, pubKey string, sig string) error {
+func (v mockSigVerifier) VerifyAccountRequestSignature(encIDHash string, proof listing.RequestProof, pubKey string) error {
+	if v.isInvalid {
+		return errors.New("Invalid signature")
+	}
+	return nil
+}
+
+func (v mockSigVerifier) VerifySharedKeysRequestSignature(emPubKey string, proof listing.RequestProof) error {
 	if v.isInvalid {
 		return errors.New("Invalid signature")
 	}
@@ -127,3 +495,17 @@ func (v mockSigVerifier) VerifyCreationTransactionResultSignature(res Transactio
 	}
 	return nil
 }
+
+func (v mockSigVerifier) VerifyIDDetailsSignature(res listing.IDDetails, pubKey string) error {
+	if v.isInvalid {
+		return errors.New("Invalid signature")
+	}
+	return nil
+}
+
+func (v mockSigVerifier) VerifyKeychainDetailsSignature(res listing.KeychainDetails, pubKey string) error {
+	if v.isInvalid {
+		return errors.New("Invalid signature")
+	}
+	return nil
+}
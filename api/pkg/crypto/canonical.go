@@ -0,0 +1,165 @@
+package crypto
+
+import (
+	"github.com/uniris/uniris-core/api/pkg/adding"
+	"github.com/uniris/uniris-core/api/pkg/listing"
+	"github.com/uniris/uniris-core/api/pkg/webhook"
+	"github.com/uniris/uniris-core/shared/pkg/canonical"
+)
+
+//The payloads are serialized with the canonical encoding of the shared module
+
+//encoder writes the messages of the API service with the canonical encoding
+type encoder struct {
+	canonical.Encoder
+}
+
+func newEncoder(t canonical.PayloadType) *encoder {
+	return &encoder{canonical.NewEncoder(t)}
+}
+
+//writeProposal writes the proposal, which is the last field of the ID and keychain data
+//
+//The activation date is optional: it is written only when set after the unix epoch
+func (e *encoder) writeProposal(p listing.ProposedKeyPair) {
+	e.WriteString(p.EncryptedPrivateKey())
+	e.WriteString(p.PublicKey())
+	if a := p.ActivationDate(); a.Unix() > 0 {
+		e.WriteTimestamp(a)
+	}
+}
+
+func (e *encoder) writeValidation(v listing.Validation) {
+	e.WriteStatus(byte(v.Status()))
+	e.WriteTimestamp(v.Timestamp())
+	e.WriteString(v.PublicKey())
+	e.WriteString(v.Signature())
+}
+
+func (e *encoder) writeEndorsement(end listing.Endorsement) {
+	e.WriteString(end.LastTransactionHash())
+	e.WriteString(end.TransactionHash())
+	e.WriteString(end.MasterValidation().ProofOfWorkKey())
+	e.writeValidation(end.MasterValidation().ProofOfWorkValidation())
+	e.WriteStrings(end.MasterValidation().LastTransactionMiners())
+	e.WriteStrings(end.MasterValidation().ValidationPool())
+	e.WriteLength(len(end.Validations()))
+	for _, v := range end.Validations() {
+		e.writeValidation(v)
+	}
+}
+
+//writeRequestProof writes the freshness data of a signed request
+func (e *encoder) writeRequestProof(p listing.RequestProof) {
+	e.WriteTimestamp(p.Timestamp())
+	e.WriteString(p.Nonce())
+}
+
+func (e *encoder) writeTransactionResult(res adding.TransactionResult) {
+	e.WriteString(res.TransactionHash())
+	e.WriteString(res.MasterPeerIP())
+	e.WriteString(res.EncryptedAddress())
+}
+
+func encodeAccountCreationRequest(req adding.AccountCreationRequest) []byte {
+	return encodeAccountCreation(canonical.AccountCreationRequestPayload, req)
+}
+
+//encodeAccountCreationEmitter encodes the account creation request signed by the emitter expecting the callback
+func encodeAccountCreationEmitter(req adding.AccountCreationRequest) []byte {
+	return encodeAccountCreation(canonical.AccountCreationEmitterPayload, req)
+}
+
+func encodeAccountCreation(t canonical.PayloadType, req adding.AccountCreationRequest) []byte {
+	e := newEncoder(t)
+	e.WriteString(req.EncryptedID())
+	e.WriteString(req.EncryptedKeychain())
+	e.writeRequestProof(req)
+	if req.EmitterPublicKey() != "" {
+		e.WriteString(req.EmitterPublicKey())
+	}
+	return e.Bytes()
+}
+
+func encodeAccountRequest(encIDHash string, proof listing.RequestProof) []byte {
+	e := newEncoder(canonical.AccountRequestPayload)
+	e.WriteString(encIDHash)
+	e.WriteString(proof.EmitterPublicKey())
+	e.writeRequestProof(proof)
+	return e.Bytes()
+}
+
+func encodeSharedKeysRequest(emPubKey string, proof listing.RequestProof) []byte {
+	e := newEncoder(canonical.SharedKeysRequestPayload)
+	e.WriteString(emPubKey)
+	e.writeRequestProof(proof)
+	return e.Bytes()
+}
+
+func encodeAccountResult(res listing.AccountResult) []byte {
+	e := newEncoder(canonical.AccountSearchResultPayload)
+	e.WriteString(res.EncryptedWallet())
+	e.WriteString(res.EncryptedAESKey())
+	e.WriteString(res.EncryptedAddress())
+	return e.Bytes()
+}
+
+func encodeIDDetails(res listing.IDDetails) []byte {
+	e := newEncoder(canonical.IDResponsePayload)
+	id := res.ID()
+	e.WriteString(id.Hash())
+	e.WriteString(id.EncryptedAddrByRobot())
+	e.WriteString(id.EncryptedAddrByID())
+	e.WriteString(id.EncryptedAESKey())
+	e.WriteString(id.PublicKey())
+	e.writeProposal(id.Proposal())
+	e.WriteString(id.IDSignature())
+	e.WriteString(id.EmitterSignature())
+	e.writeEndorsement(res.Endorsement())
+	return e.Bytes()
+}
+
+func encodeKeychainDetails(res listing.KeychainDetails) []byte {
+	e := newEncoder(canonical.KeychainResponsePayload)
+	kc := res.Keychain()
+	e.WriteString(kc.EncryptedAddrByRobot())
+	e.WriteString(kc.EncryptedWallet())
+	e.WriteString(kc.IDPublicKey())
+	e.writeProposal(kc.Proposal())
+	e.WriteString(kc.IDSignature())
+	e.WriteString(kc.EmitterSignature())
+	e.writeEndorsement(res.Endorsement())
+	return e.Bytes()
+}
+
+func encodeTransactionResult(res adding.TransactionResult) []byte {
+	e := newEncoder(canonical.CreationResultPayload)
+	e.writeTransactionResult(res)
+	return e.Bytes()
+}
+
+func encodeAccountCreationResult(res adding.AccountCreationResult) []byte {
+	e := newEncoder(canonical.AccountCreationResultPayload)
+	e.writeTransactionResult(res.ResultTransactions().ID())
+	e.WriteString(res.ResultTransactions().ID().Signature())
+	e.writeTransactionResult(res.ResultTransactions().Keychain())
+	e.WriteString(res.ResultTransactions().Keychain().Signature())
+	return e.Bytes()
+}
+
+func encodeWebhookRegistration(reg webhook.Registration) []byte {
+	e := newEncoder(canonical.WebhookRegistrationPayload)
+	e.WriteString(reg.EmitterPublicKey())
+	e.WriteString(reg.CallbackURL())
+	e.writeRequestProof(reg)
+	return e.Bytes()
+}
+
+func encodeTransactionCallback(cb webhook.TransactionCallback) []byte {
+	e := newEncoder(canonical.TransactionCallbackPayload)
+	e.WriteString(cb.TransactionHash())
+	e.WriteString(cb.TransactionType())
+	e.WriteString(cb.Status().String())
+	e.WriteTimestamp(cb.Timestamp())
+	return e.Bytes()
+}
@@ -0,0 +1,142 @@
+package crypto
+
+import (
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/uniris/uniris-core/api/pkg/adding"
+	"github.com/uniris/uniris-core/api/pkg/listing"
+	"github.com/uniris/uniris-core/api/pkg/webhook"
+)
+
+/*
+Scenario: Encode an account result
+	Given an account result
+	When I want to encode it
+	Then I get the test vector shared with the datamining service
+*/
+func TestEncodeAccountResultVector(t *testing.T) {
+	b := encodeAccountResult(listing.NewAccountResult("aes", "wal", "addr", "sig"))
+	assert.Equal(t, "0114"+"0000000377616c"+"00000003616573"+"0000000461646472", hex.EncodeToString(b))
+}
+
+/*
+Scenario: Encode a transaction result
+	Given a transaction result
+	When I want to encode it
+	Then I get the test vector shared with the datamining service without the signature
+*/
+func TestEncodeTransactionResultVector(t *testing.T) {
+	b := encodeTransactionResult(adding.NewTransactionResult("hash", "ip", "enc addr", "sig"))
+	assert.Equal(t, "0115"+"0000000468617368"+"000000026970"+"00000008656e632061646472", hex.EncodeToString(b))
+}
+
+/*
+Scenario: Encode keychain details
+	Given a keychain with its endorsement
+	When I want to encode the details
+	Then I get the test vector shared with the datamining service
+*/
+func TestEncodeKeychainDetailsVector(t *testing.T) {
+	b := encodeKeychainDetails(listing.NewKeychainDetails(
+		listing.NewKeychain("a", "w", "i", listing.NewProposedKeyPair(listing.NewSharedKeyPair("p", "k"), time.Time{}), "s", "e"),
+		listing.NewEndorsement("l", "t",
+			listing.NewMasterValidation([]string{"m"}, "k", listing.NewValidation(listing.ValidationOK, time.Unix(1, 0), "v", "g"), []string{"p"}),
+			[]listing.Validation{listing.NewValidation(listing.ValidationKO, time.Unix(2, 0), "x", "y")}),
+		"sig"))
+	assert.Equal(t, "0112"+"0000000161"+"0000000177"+"0000000169"+"0000000170"+"000000016b"+"0000000173"+"0000000165"+
+		"000000016c"+"0000000174"+"000000016b"+"00"+"0000000000000001"+"0000000176"+"0000000167"+"00000001"+"000000016d"+
+		"00000001"+"0000000170"+"00000001"+"01"+"0000000000000002"+"0000000178"+"0000000179", hex.EncodeToString(b))
+}
+
+/*
+Scenario: Encode keychain details with the activation date of the proposal
+	Given a keychain proposing a keypair with an activation date
+	When I want to encode the details
+	Then the activation date is written after the proposed keypair, as by the datamining service
+*/
+func TestEncodeKeychainDetailsActivationDateVector(t *testing.T) {
+	b := encodeKeychainDetails(listing.NewKeychainDetails(
+		listing.NewKeychain("a", "w", "i", listing.NewProposedKeyPair(listing.NewSharedKeyPair("p", "k"), time.Unix(3, 0)), "s", "e"),
+		listing.NewEndorsement("l", "t",
+			listing.NewMasterValidation([]string{"m"}, "k", listing.NewValidation(listing.ValidationOK, time.Unix(1, 0), "v", "g"), []string{"p"}),
+			[]listing.Validation{listing.NewValidation(listing.ValidationKO, time.Unix(2, 0), "x", "y")}),
+		"sig"))
+	assert.Equal(t, "0112"+"0000000161"+"0000000177"+"0000000169"+"0000000170"+"000000016b"+"0000000000000003"+"0000000173"+"0000000165"+
+		"000000016c"+"0000000174"+"000000016b"+"00"+"0000000000000001"+"0000000176"+"0000000167"+"00000001"+"000000016d"+
+		"00000001"+"0000000170"+"00000001"+"01"+"0000000000000002"+"0000000178"+"0000000179", hex.EncodeToString(b))
+}
+
+/*
+Scenario: Encode an account creation request
+	Given an account creation request
+	When I want to encode it
+	Then I get the test vector without the signature
+*/
+func TestEncodeAccountCreationRequestVector(t *testing.T) {
+	b := encodeAccountCreationRequest(adding.NewAccountCreationRequest("id", "kc", time.Unix(10, 0), "n", "", "", "sig"))
+	assert.Equal(t, "0116"+"000000026964"+"000000026b63"+"000000000000000a"+"000000016e", hex.EncodeToString(b))
+}
+
+/*
+Scenario: Encode an account creation request expecting a callback
+	Given an account creation request with an emitter public key
+	When I want to encode it
+	Then the emitter public key is written after the freshness data
+*/
+func TestEncodeAccountCreationRequestWithEmitterVector(t *testing.T) {
+	b := encodeAccountCreationRequest(adding.NewAccountCreationRequest("id", "kc", time.Unix(10, 0), "n", "em", "", "sig"))
+	assert.Equal(t, "0116"+"000000026964"+"000000026b63"+"000000000000000a"+"000000016e"+"00000002656d", hex.EncodeToString(b))
+}
+
+/*
+Scenario: Encode an account request
+	Given an encrypted ID hash, the emitter public key and the freshness data of the request
+	When I want to encode it
+	Then I get the test vector without the signature
+*/
+func TestEncodeAccountRequestVector(t *testing.T) {
+	b := encodeAccountRequest("h", listing.NewRequestProof("e", time.Unix(10, 0), "n", "sig"))
+	assert.Equal(t, "011c"+"0000000168"+"0000000165"+"000000000000000a"+"000000016e", hex.EncodeToString(b))
+}
+
+/*
+Scenario: Encode an account creation result
+	Given an account creation result
+	When I want to encode it
+	Then the transaction results are encoded with their signatures
+*/
+func TestEncodeAccountCreationResultVector(t *testing.T) {
+	res := adding.NewAccountCreationResult(adding.NewAccountCreationTransactionResult(
+		adding.NewTransactionResult("h1", "ip", "enc addr", "s1"),
+		adding.NewTransactionResult("h2", "ip", "enc addr", "s2"),
+	), "sig")
+	b := encodeAccountCreationResult(res)
+	assert.Equal(t, "0117"+
+		"000000026831"+"000000026970"+"00000008656e632061646472"+"000000027331"+
+		"000000026832"+"000000026970"+"00000008656e632061646472"+"000000027332", hex.EncodeToString(b))
+}
+
+/*
+Scenario: Encode a webhook registration
+	Given a callback registration
+	When I want to encode it
+	Then I get the test vector without the signature
+*/
+func TestEncodeWebhookRegistrationVector(t *testing.T) {
+	b := encodeWebhookRegistration(webhook.NewRegistration("em", "http://cb", time.Unix(10, 0), "n", "sig"))
+	assert.Equal(t, "011e"+"00000002656d"+"00000009687474703a2f2f6362"+"000000000000000a"+"000000016e", hex.EncodeToString(b))
+}
+
+/*
+Scenario: Encode a transaction callback
+	Given a callback of a transaction finalised
+	When I want to encode it
+	Then I get the test vector without the signature
+*/
+func TestEncodeTransactionCallbackVector(t *testing.T) {
+	b := encodeTransactionCallback(webhook.NewTransactionCallback("h", webhook.IDTransaction, listing.TransactionSuccess, time.Unix(10, 0), "sig"))
+	assert.Equal(t, "011f"+"0000000168"+"000000024944"+"0000000753756363657373"+"000000000000000a", hex.EncodeToString(b))
+}
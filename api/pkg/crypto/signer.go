@@ -1,162 +1,98 @@
 package crypto
 
 import (
-	"crypto/ecdsa"
-	"crypto/rand"
-	"crypto/sha256"
-	"crypto/x509"
-	"encoding/asn1"
-	"encoding/hex"
-	"encoding/json"
 	"errors"
-	"math/big"
 
 	"github.com/uniris/uniris-core/api/pkg/adding"
 	"github.com/uniris/uniris-core/api/pkg/listing"
+	"github.com/uniris/uniris-core/api/pkg/webhook"
+	"github.com/uniris/uniris-core/shared/pkg/keys"
 )
 
 //ErrInvalidSignature is returned when the request contains invalid signatures
 var ErrInvalidSignature = errors.New("Invalid signature")
 
-type ecdsaSignature struct {
-	R, S *big.Int
-}
-
 //Signer define methods to handle signatures
 type Signer interface {
 	adding.Signer
 	listing.SignatureVerifier
+	webhook.Signer
+}
+
+//RemoteSigner defines methods to sign payloads with the shared robot key held by the datamining service
+type RemoteSigner interface {
+
+	//SignPayload asks the datamining service to sign the canonical payload with the shared robot private key
+	SignPayload(payload []byte) (string, error)
 }
 
 type signer struct {
+	remote RemoteSigner
 }
 
-//NewSigner create a new signer
-func NewSigner() Signer {
-	return signer{}
+//NewSigner create a new signer delegating the robot signatures to the remote signer
+func NewSigner(remote RemoteSigner) Signer {
+	return signer{remote}
 }
 
 func (s signer) VerifyAccountCreationRequestSignature(req adding.AccountCreationRequest, pubKey string) error {
-	b, err := json.Marshal(accountCreationRequest{
-		EncryptedID:       req.EncryptedID(),
-		EncryptedKeychain: req.EncryptedKeychain(),
-	})
-	if err != nil {
-		return err
-	}
+	return verifySignature(pubKey, string(encodeAccountCreationRequest(req)), req.Signature())
+}
+
+func (s signer) VerifyAccountCreationEmitterSignature(req adding.AccountCreationRequest) error {
+	return verifySignature(req.EmitterPublicKey(), string(encodeAccountCreationEmitter(req)), req.EmitterSignature())
+}
 
-	return verifySignature(pubKey, string(b), req.Signature())
+func (s signer) VerifyAccountRequestSignature(encIDHash string, proof listing.RequestProof, pubKey string) error {
+	return verifySignature(pubKey, string(encodeAccountRequest(encIDHash, proof)), proof.Signature())
 }
 
-func (s signer) VerifyHashSignature(hashedData string, pubKey string, sig string) error {
-	return verifySignature(pubKey, hashedData, sig)
+func (s signer) VerifySharedKeysRequestSignature(emPubKey string, proof listing.RequestProof) error {
+	return verifySignature(emPubKey, string(encodeSharedKeysRequest(emPubKey, proof)), proof.Signature())
 }
 
 func (s signer) VerifyAccountResultSignature(res listing.AccountResult, pubKey string) error {
-	b, err := json.Marshal(accountResult{
-		EncryptedAddress: res.EncryptedAddress(),
-		EncryptedAESKey:  res.EncryptedAESKey(),
-		EncryptedWallet:  res.EncryptedWallet(),
-	})
-	if err != nil {
-		return err
-	}
-	return verifySignature(pubKey, string(b), res.Signature())
+	return verifySignature(pubKey, string(encodeAccountResult(res)), res.Signature())
 }
 
-func (s signer) VerifyCreationTransactionResultSignature(res adding.TransactionResult, pubKey string) error {
-	b, err := json.Marshal(transactionResult{
-		MasterPeerIP:    res.MasterPeerIP(),
-		TransactionHash: res.TransactionHash(),
-	})
-	if err != nil {
-		return err
-	}
-	return verifySignature(pubKey, string(b), res.Signature())
-}
-
-func (s signer) SignAccountCreationResult(res adding.AccountCreationResult, pvKey string) (adding.AccountCreationResult, error) {
-	b, err := json.Marshal(accountCreationResult{
-		Transactions: accountCreationTransactionsResult{
-			ID: transactionResult{
-				MasterPeerIP:    res.ResultTransactions().ID().MasterPeerIP(),
-				Signature:       res.ResultTransactions().ID().Signature(),
-				TransactionHash: res.ResultTransactions().ID().TransactionHash(),
-			},
-			Keychain: transactionResult{
-				MasterPeerIP:    res.ResultTransactions().Keychain().MasterPeerIP(),
-				TransactionHash: res.ResultTransactions().Keychain().TransactionHash(),
-				Signature:       res.ResultTransactions().Keychain().Signature(),
-			},
-		},
-	})
-	sig, err := sign(pvKey, string(b))
-	if err != nil {
-		return nil, err
-	}
+func (s signer) VerifyIDDetailsSignature(res listing.IDDetails, pubKey string) error {
+	return verifySignature(pubKey, string(encodeIDDetails(res)), res.Signature())
+}
 
-	return adding.NewAccountCreationResult(res.ResultTransactions(), sig), nil
+func (s signer) VerifyKeychainDetailsSignature(res listing.KeychainDetails, pubKey string) error {
+	return verifySignature(pubKey, string(encodeKeychainDetails(res)), res.Signature())
 }
 
-func sign(privk string, data string) (string, error) {
-	pvDecoded, err := hex.DecodeString(privk)
-	if err != nil {
-		return "", err
-	}
+func (s signer) VerifyCreationTransactionResultSignature(res adding.TransactionResult, pubKey string) error {
+	return verifySignature(pubKey, string(encodeTransactionResult(res)), res.Signature())
+}
 
-	pv, err := x509.ParseECPrivateKey(pvDecoded)
+func (s signer) SignAccountCreationResult(res adding.AccountCreationResult) (adding.AccountCreationResult, error) {
+	sig, err := s.remote.SignPayload(encodeAccountCreationResult(res))
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	hash := []byte(hashString(data))
+	return adding.NewAccountCreationResult(res.ResultTransactions(), sig), nil
+}
 
-	r, s, err := ecdsa.Sign(rand.Reader, pv, hash)
-	if err != nil {
-		return "", err
-	}
+func (s signer) VerifyWebhookRegistrationSignature(reg webhook.Registration) error {
+	return verifySignature(reg.EmitterPublicKey(), string(encodeWebhookRegistration(reg)), reg.Signature())
+}
 
-	sig, err := asn1.Marshal(ecdsaSignature{r, s})
+func (s signer) SignTransactionCallback(cb webhook.TransactionCallback) (webhook.TransactionCallback, error) {
+	sig, err := s.remote.SignPayload(encodeTransactionCallback(cb))
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	return hex.EncodeToString(sig), nil
-
+	return webhook.NewTransactionCallback(cb.TransactionHash(), cb.TransactionType(), cb.Status(), cb.Timestamp(), sig), nil
 }
 
 func verifySignature(pubk string, data string, sig string) error {
-	var signature ecdsaSignature
-
-	decodedkey, err := hex.DecodeString(pubk)
-	if err != nil {
-		return err
+	err := keys.Verify(pubk, []byte(data), sig)
+	if err == keys.ErrInvalidSignature {
+		return ErrInvalidSignature
 	}
-
-	decodedsig, err := hex.DecodeString(sig)
-	if err != nil {
-		return err
-	}
-
-	pu, err := x509.ParsePKIXPublicKey(decodedkey)
-	if err != nil {
-		return err
-	}
-
-	ecdsaPublic := pu.(*ecdsa.PublicKey)
-	asn1.Unmarshal(decodedsig, &signature)
-
-	hash := []byte(hashString(data))
-
-	if ecdsa.Verify(ecdsaPublic, hash, signature.R, signature.S) {
-		return nil
-	}
-
-	return ErrInvalidSignature
-}
-
-func hashString(data string) string {
-	hash := sha256.New()
-	hash.Write([]byte(data))
-	return hex.EncodeToString(hash.Sum(nil))
+	return err
 }
@@ -6,13 +6,15 @@ import (
 	"crypto/rand"
 	"crypto/x509"
 	"encoding/hex"
-	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
 	"github.com/uniris/uniris-core/api/pkg/adding"
 	"github.com/uniris/uniris-core/api/pkg/listing"
+	"github.com/uniris/uniris-core/api/pkg/webhook"
+	"github.com/uniris/uniris-core/shared/pkg/keys"
 )
 
 /*
@@ -27,29 +29,16 @@ func TestSignAccountCreationResult(t *testing.T) {
 	pvKey, _ := x509.MarshalECPrivateKey(key)
 	pubKey, _ := x509.MarshalPKIXPublicKey(key.Public())
 
-	txID := adding.NewTransactionResult("hash", "ip", "sig")
-	txKeychain := adding.NewTransactionResult("hash", "ip", "sig")
+	txID := adding.NewTransactionResult("hash", "ip", "enc addr", "sig")
+	txKeychain := adding.NewTransactionResult("hash", "ip", "enc addr", "sig")
 	txRes := adding.NewAccountCreationTransactionResult(txID, txKeychain)
 	res := adding.NewAccountCreationResult(txRes, "")
-	res, err := NewSigner().SignAccountCreationResult(res, hex.EncodeToString(pvKey))
+	res, err := NewSigner(mockRemoteSigner{hex.EncodeToString(pvKey)}).SignAccountCreationResult(res)
 	assert.NotEmpty(t, res.Signature())
 
 	assert.Nil(t, err)
 
-	b, _ := json.Marshal(accountCreationResult{
-		Transactions: accountCreationTransactionsResult{
-			ID: transactionResult{
-				MasterPeerIP:    "ip",
-				Signature:       "sig",
-				TransactionHash: "hash",
-			},
-			Keychain: transactionResult{
-				MasterPeerIP:    "ip",
-				Signature:       "sig",
-				TransactionHash: "hash",
-			},
-		},
-	})
+	b := encodeAccountCreationResult(res)
 
 	assert.Nil(t, verifySignature(hex.EncodeToString(pubKey), string(b), res.Signature()))
 }
@@ -68,16 +57,12 @@ func TestSignAccountResult(t *testing.T) {
 
 	res := listing.NewAccountResult("enc aes key", "enc wallet", "enc addr", "")
 
-	b, err := json.Marshal(accountResult{
-		EncryptedAESKey:  "enc aes key",
-		EncryptedAddress: "enc addr",
-		EncryptedWallet:  "enc wallet",
-	})
+	b := encodeAccountResult(res)
 	sig, err := sign(hex.EncodeToString(pvKey), string(b))
 	assert.Nil(t, err)
 
 	res = listing.NewAccountResult("enc aes key", "enc wallet", "enc addr", sig)
-	assert.Nil(t, NewSigner().VerifyAccountResultSignature(res, hex.EncodeToString(pubKey)))
+	assert.Nil(t, NewSigner(nil).VerifyAccountResultSignature(res, hex.EncodeToString(pubKey)))
 }
 
 /*
@@ -91,14 +76,11 @@ func TestVerifyCreationResultSignature(t *testing.T) {
 	pvKey, _ := x509.MarshalECPrivateKey(key)
 	pubKey, _ := x509.MarshalPKIXPublicKey(key.Public())
 
-	b, _ := json.Marshal(transactionResult{
-		MasterPeerIP:    "ip",
-		TransactionHash: "hash",
-	})
+	b := encodeTransactionResult(adding.NewTransactionResult("hash", "ip", "enc addr", ""))
 	sig, _ := sign(hex.EncodeToString(pvKey), string(b))
-	res := adding.NewTransactionResult("hash", "ip", sig)
+	res := adding.NewTransactionResult("hash", "ip", "enc addr", sig)
 
-	assert.Nil(t, NewSigner().VerifyCreationTransactionResultSignature(res, hex.EncodeToString(pubKey)))
+	assert.Nil(t, NewSigner(nil).VerifyCreationTransactionResultSignature(res, hex.EncodeToString(pubKey)))
 }
 
 /*
@@ -112,12 +94,129 @@ func TestVerifyAccountCreationRequestSignature(t *testing.T) {
 	pvKey, _ := x509.MarshalECPrivateKey(key)
 	pubKey, _ := x509.MarshalPKIXPublicKey(key.Public())
 
-	b, _ := json.Marshal(accountCreationRequest{
-		EncryptedID:       "enc id",
-		EncryptedKeychain: "enc keychain",
-	})
+	now := time.Now()
+	b := encodeAccountCreationRequest(adding.NewAccountCreationRequest("enc id", "enc keychain", now, "nonce", "", "", ""))
 	sig, _ := sign(hex.EncodeToString(pvKey), string(b))
-	req := adding.NewAccountCreationRequest("enc id", "enc keychain", sig)
+	req := adding.NewAccountCreationRequest("enc id", "enc keychain", now, "nonce", "", "", sig)
 
-	assert.Nil(t, NewSigner().VerifyAccountCreationRequestSignature(req, hex.EncodeToString(pubKey)))
+	assert.Nil(t, NewSigner(nil).VerifyAccountCreationRequestSignature(req, hex.EncodeToString(pubKey)))
+
+	req = adding.NewAccountCreationRequest("enc id", "enc keychain", now, "other nonce", "", "", sig)
+	assert.Equal(t, ErrInvalidSignature, NewSigner(nil).VerifyAccountCreationRequestSignature(req, hex.EncodeToString(pubKey)))
+}
+
+/*
+Scenario: Verify the emitter signature of an account creation request
+	Given an emitter keypair and an account creation request naming the emitter
+	When I want to verify the emitter signature
+	Then only the signature of the emitter payload is accepted
+*/
+func TestVerifyAccountCreationEmitterSignature(t *testing.T) {
+	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	pvKey, _ := x509.MarshalECPrivateKey(key)
+	pubKey, _ := x509.MarshalPKIXPublicKey(key.Public())
+	emPubKey := hex.EncodeToString(pubKey)
+
+	now := time.Now()
+	unsigned := adding.NewAccountCreationRequest("enc id", "enc keychain", now, "nonce", emPubKey, "", "")
+	emSig, _ := sign(hex.EncodeToString(pvKey), string(encodeAccountCreationEmitter(unsigned)))
+	reqSig, _ := sign(hex.EncodeToString(pvKey), string(encodeAccountCreationRequest(unsigned)))
+
+	req := adding.NewAccountCreationRequest("enc id", "enc keychain", now, "nonce", emPubKey, emSig, reqSig)
+	assert.Nil(t, NewSigner(nil).VerifyAccountCreationEmitterSignature(req))
+
+	//The request signature cannot be reused as the emitter signature
+	req = adding.NewAccountCreationRequest("enc id", "enc keychain", now, "nonce", emPubKey, reqSig, reqSig)
+	assert.Equal(t, ErrInvalidSignature, NewSigner(nil).VerifyAccountCreationEmitterSignature(req))
+}
+
+/*
+Scenario: Verify account request signature
+	Given a keypair and a signed request to get an account
+	When I want to verify it
+	Then I get not error
+*/
+func TestVerifyAccountRequestSignature(t *testing.T) {
+	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	pvKey, _ := x509.MarshalECPrivateKey(key)
+	pubKey, _ := x509.MarshalPKIXPublicKey(key.Public())
+
+	now := time.Now()
+	b := encodeAccountRequest("enc hash", listing.NewRequestProof("em key", now, "nonce", ""))
+	sig, _ := sign(hex.EncodeToString(pvKey), string(b))
+
+	assert.Nil(t, NewSigner(nil).VerifyAccountRequestSignature("enc hash", listing.NewRequestProof("em key", now, "nonce", sig), hex.EncodeToString(pubKey)))
+	assert.Equal(t, ErrInvalidSignature, NewSigner(nil).VerifyAccountRequestSignature("enc hash", listing.NewRequestProof("em key", now.Add(time.Minute), "nonce", sig), hex.EncodeToString(pubKey)))
+}
+
+/*
+Scenario: Verify shared keys request signature
+	Given an emitter keypair and a signed request to get the shared keys
+	When I want to verify it
+	Then I get not error
+*/
+func TestVerifySharedKeysRequestSignature(t *testing.T) {
+	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	pvKey, _ := x509.MarshalECPrivateKey(key)
+	pubKey, _ := x509.MarshalPKIXPublicKey(key.Public())
+	emPubKey := hex.EncodeToString(pubKey)
+
+	now := time.Now()
+	b := encodeSharedKeysRequest(emPubKey, listing.NewRequestProof("em key", now, "nonce", ""))
+	sig, _ := sign(hex.EncodeToString(pvKey), string(b))
+
+	assert.Nil(t, NewSigner(nil).VerifySharedKeysRequestSignature(emPubKey, listing.NewRequestProof("em key", now, "nonce", sig)))
+}
+
+/*
+Scenario: Verify a webhook registration signature
+	Given an emitter keypair and a callback registration signed by the emitter
+	When I want to verify it
+	Then I get no error, unless the callback URL has been changed
+*/
+func TestVerifyWebhookRegistrationSignature(t *testing.T) {
+	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	pvKey, _ := x509.MarshalECPrivateKey(key)
+	pubKey, _ := x509.MarshalPKIXPublicKey(key.Public())
+
+	now := time.Now()
+	b := encodeWebhookRegistration(webhook.NewRegistration(hex.EncodeToString(pubKey), "https://callback", now, "nonce", ""))
+	sig, _ := sign(hex.EncodeToString(pvKey), string(b))
+
+	reg := webhook.NewRegistration(hex.EncodeToString(pubKey), "https://callback", now, "nonce", sig)
+	assert.Nil(t, NewSigner(nil).VerifyWebhookRegistrationSignature(reg))
+
+	reg = webhook.NewRegistration(hex.EncodeToString(pubKey), "https://other", now, "nonce", sig)
+	assert.Equal(t, ErrInvalidSignature, NewSigner(nil).VerifyWebhookRegistrationSignature(reg))
+}
+
+/*
+Scenario: Sign a transaction callback
+	Given a keypair and a callback of a transaction finalised
+	When I want sign it
+	Then the signature is inserted and valid
+*/
+func TestSignTransactionCallback(t *testing.T) {
+	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	pvKey, _ := x509.MarshalECPrivateKey(key)
+	pubKey, _ := x509.MarshalPKIXPublicKey(key.Public())
+
+	cb := webhook.NewTransactionCallback("hash", webhook.KeychainTransaction, listing.TransactionFailure, time.Now(), "")
+	cb, err := NewSigner(mockRemoteSigner{hex.EncodeToString(pvKey)}).SignTransactionCallback(cb)
+	assert.Nil(t, err)
+	assert.Equal(t, "hash", cb.TransactionHash())
+	assert.Equal(t, listing.TransactionFailure, cb.Status())
+	assert.Nil(t, verifySignature(hex.EncodeToString(pubKey), string(encodeTransactionCallback(cb)), cb.Signature()))
+}
+
+type mockRemoteSigner struct {
+	pvKey string
+}
+
+func (s mockRemoteSigner) SignPayload(payload []byte) (string, error) {
+	return sign(s.pvKey, string(payload))
+}
+
+func sign(privk string, data string) (string, error) {
+	return keys.Sign(privk, []byte(data))
 }
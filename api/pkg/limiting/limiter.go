@@ -0,0 +1,174 @@
+package limiting
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+//ErrLimitExceeded is returned when a client or an emitter sent more requests than allowed on an endpoint
+var ErrLimitExceeded = errors.New("Rate limit exceeded")
+
+//Rule defines a token bucket: the number of requests accepted at once and the interval between two refilled tokens
+type Rule struct {
+	Burst    int
+	Interval time.Duration
+}
+
+//NewRule creates a rule accepting a number of requests per period, with bursts up to the burst size
+//
+//The burst size is the number of requests when it is not defined
+func NewRule(requests int, period time.Duration, burst int) Rule {
+	if requests <= 0 || period <= 0 {
+		return Rule{}
+	}
+	if burst <= 0 {
+		burst = requests
+	}
+	return Rule{
+		Burst:    burst,
+		Interval: period / time.Duration(requests),
+	}
+}
+
+//IsLimited checks if the rule limits the requests, a rule without tokens nor interval accepts all the requests
+func (r Rule) IsLimited() bool {
+	return r.Burst > 0 && r.Interval > 0
+}
+
+//Limiter defines methods to limit the requests by client IP and by emitter public key
+//
+//The token of an emitter is reserved before the request and refunded when its signature is not verified,
+//so concurrent requests cannot exceed the limit and a client spoofing the key of an emitter cannot exhaust its bucket
+type Limiter interface {
+
+	//Allow consumes a token from the bucket of the client IP for the endpoint of the request
+	//
+	//It returns ErrLimitExceeded with the delay before a retry when the bucket is empty
+	Allow(method string, path string, ip string) (time.Duration, error)
+
+	//TakeEmitter consumes a token from the bucket of the emitter public key for the endpoint of the request
+	//
+	//It returns ErrLimitExceeded with the delay before a retry when the bucket is empty
+	TakeEmitter(method string, path string, emPubKey string) (time.Duration, error)
+
+	//RefundEmitter gives back the token taken for a request which is not signed by the emitter
+	RefundEmitter(method string, path string, emPubKey string) error
+}
+
+type endpoint struct {
+	method   string
+	segments []string
+	rule     Rule
+}
+
+type limiter struct {
+	store       Store
+	defaultRule Rule
+	endpoints   []endpoint
+}
+
+//NewLimiter creates a limiter using the rules of the endpoints, and the default rule for all the other endpoints
+//
+//The endpoints are identified by their method and their route, as "POST /api/account" or "GET /api/account/:hash"
+func NewLimiter(store Store, defaultRule Rule, endpoints map[string]Rule) Limiter {
+	l := limiter{
+		store:       store,
+		defaultRule: defaultRule,
+	}
+	for route, r := range endpoints {
+		parts := strings.Fields(route)
+		if len(parts) != 2 {
+			continue
+		}
+		l.endpoints = append(l.endpoints, endpoint{
+			method:   strings.ToUpper(parts[0]),
+			segments: strings.Split(strings.Trim(parts[1], "/"), "/"),
+			rule:     r,
+		})
+	}
+	return l
+}
+
+func (l limiter) Allow(method string, path string, ip string) (time.Duration, error) {
+	route, rule := l.findRule(method, path)
+	if !rule.IsLimited() {
+		return 0, nil
+	}
+
+	ok, retryAfter, err := l.store.Take("ip:"+route+":"+ip, rule, time.Now())
+	if err != nil {
+		return 0, err
+	}
+	if !ok {
+		return retryAfter, ErrLimitExceeded
+	}
+	return 0, nil
+}
+
+func (l limiter) TakeEmitter(method string, path string, emPubKey string) (time.Duration, error) {
+	route, rule := l.findRule(method, path)
+	if !rule.IsLimited() {
+		return 0, nil
+	}
+
+	ok, retryAfter, err := l.store.Take("emitter:"+route+":"+emPubKey, rule, time.Now())
+	if err != nil {
+		return 0, err
+	}
+	if !ok {
+		return retryAfter, ErrLimitExceeded
+	}
+	return 0, nil
+}
+
+func (l limiter) RefundEmitter(method string, path string, emPubKey string) error {
+	route, rule := l.findRule(method, path)
+	if !rule.IsLimited() {
+		return nil
+	}
+	return l.store.Refund("emitter:"+route+":"+emPubKey, rule, time.Now())
+}
+
+//findRule identifies the endpoint of a request
+//
+//The requests on the endpoints without rule share the same buckets, so the clients cannot bypass the limit by changing the path
+func (l limiter) findRule(method string, path string) (string, Rule) {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	var match *endpoint
+	for i, e := range l.endpoints {
+		if e.method != method || !matchSegments(e.segments, segments) {
+			continue
+		}
+		//The static segments take precedence over the route parameters
+		if match == nil || countParams(e.segments) < countParams(match.segments) {
+			match = &l.endpoints[i]
+		}
+	}
+	if match == nil {
+		return "default", l.defaultRule
+	}
+	return match.method + " /" + strings.Join(match.segments, "/"), match.rule
+}
+
+func matchSegments(route []string, path []string) bool {
+	if len(route) != len(path) {
+		return false
+	}
+	for i, s := range route {
+		if !strings.HasPrefix(s, ":") && s != path[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func countParams(segments []string) int {
+	count := 0
+	for _, s := range segments {
+		if strings.HasPrefix(s, ":") {
+			count++
+		}
+	}
+	return count
+}
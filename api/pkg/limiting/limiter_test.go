@@ -0,0 +1,194 @@
+package limiting
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+/*
+Scenario: Create a rule from a number of requests per period
+	Given 60 requests per minute
+	When I create the rule with and without burst size
+	Then a token is refilled every second and the burst size is the number of requests by default
+*/
+func TestNewRule(t *testing.T) {
+	r := NewRule(60, time.Minute, 10)
+	assert.Equal(t, 10, r.Burst)
+	assert.Equal(t, time.Second, r.Interval)
+	assert.True(t, r.IsLimited())
+
+	assert.Equal(t, 60, NewRule(60, time.Minute, 0).Burst)
+	assert.False(t, NewRule(0, time.Minute, 10).IsLimited())
+}
+
+/*
+Scenario: Take the tokens of a bucket
+	Given a bucket of 2 tokens refilled every second
+	When I take 3 tokens at once
+	Then the third one is refused with the delay until the next token
+	When I take a token after the delay
+	Then it is accepted
+*/
+func TestMemoryStoreTake(t *testing.T) {
+	s := NewMemoryStore()
+	r := Rule{Burst: 2, Interval: time.Second}
+	now := time.Now()
+
+	ok, _, _ := s.Take("key", r, now)
+	assert.True(t, ok)
+	ok, _, _ = s.Take("key", r, now)
+	assert.True(t, ok)
+	ok, retryAfter, err := s.Take("key", r, now.Add(250*time.Millisecond))
+	assert.Nil(t, err)
+	assert.False(t, ok)
+	assert.Equal(t, 750*time.Millisecond, retryAfter)
+
+	ok, _, _ = s.Take("key", r, now.Add(time.Second))
+	assert.True(t, ok)
+
+	ok, _, _ = s.Take("other key", r, now)
+	assert.True(t, ok)
+}
+
+/*
+Scenario: Refund the tokens of a bucket
+	Given a bucket of a single token refilled every second
+	When I take its token and refund it
+	Then the next token is accepted
+	When I refund a full bucket
+	Then it still holds a single token
+*/
+func TestMemoryStoreRefund(t *testing.T) {
+	s := NewMemoryStore()
+	r := Rule{Burst: 1, Interval: time.Second}
+	now := time.Now()
+
+	ok, _, _ := s.Take("key", r, now)
+	assert.True(t, ok)
+	assert.Nil(t, s.Refund("key", r, now))
+	ok, _, _ = s.Take("key", r, now)
+	assert.True(t, ok)
+
+	assert.Nil(t, s.Refund("key", r, now))
+	assert.Nil(t, s.Refund("key", r, now))
+	ok, _, _ = s.Take("key", r, now)
+	assert.True(t, ok)
+	ok, _, _ = s.Take("key", r, now)
+	assert.False(t, ok)
+
+	assert.Nil(t, s.Refund("other key", r, now))
+}
+
+/*
+Scenario: Limit the requests of an endpoint
+	Given an endpoint accepting a single request and a default rule
+	When a client sends two requests on the endpoint
+	Then the second one is refused
+	When the client requests another endpoint
+	Then the request is accepted with the default rule
+*/
+func TestLimiterEndpointRule(t *testing.T) {
+	l := NewLimiter(NewMemoryStore(), NewRule(10, time.Minute, 0), map[string]Rule{
+		"POST /api/account": NewRule(1, time.Minute, 0),
+	})
+
+	_, err := l.Allow("POST", "/api/account", "127.0.0.1")
+	assert.Nil(t, err)
+	retryAfter, err := l.Allow("POST", "/api/account", "127.0.0.1")
+	assert.Equal(t, ErrLimitExceeded, err)
+	assert.True(t, retryAfter > 59*time.Second)
+
+	_, err = l.Allow("GET", "/api/account/hash", "127.0.0.1")
+	assert.Nil(t, err)
+
+	_, err = l.Allow("POST", "/api/account", "127.0.0.2")
+	assert.Nil(t, err)
+}
+
+/*
+Scenario: Limit the requests of an emitter
+	Given an endpoint accepting a single request
+	When an emitter takes a token twice
+	Then the second one is refused
+	When the first token is refunded
+	Then the next request of the emitter is accepted
+*/
+func TestLimiterEmitterKey(t *testing.T) {
+	l := NewLimiter(NewMemoryStore(), Rule{}, map[string]Rule{
+		"GET /api/sharedkeys/:publicKey": NewRule(1, time.Minute, 0),
+	})
+
+	_, err := l.TakeEmitter("GET", "/api/sharedkeys/key", "key")
+	assert.Nil(t, err)
+	retryAfter, err := l.TakeEmitter("GET", "/api/sharedkeys/key", "key")
+	assert.Equal(t, ErrLimitExceeded, err)
+	assert.True(t, retryAfter > 59*time.Second)
+
+	assert.Nil(t, l.RefundEmitter("GET", "/api/sharedkeys/key", "key"))
+	_, err = l.TakeEmitter("GET", "/api/sharedkeys/key", "key")
+	assert.Nil(t, err)
+
+	_, err = l.TakeEmitter("GET", "/api/webhook/key", "key")
+	assert.Nil(t, err)
+	_, err = l.TakeEmitter("GET", "/api/webhook/key", "key")
+	assert.Nil(t, err, "The endpoints without rule are not limited")
+}
+
+/*
+Scenario: Limit the concurrent requests of an emitter
+	Given an endpoint accepting 5 requests at once
+	When an emitter sends 50 concurrent requests
+	Then only 5 of them are accepted
+*/
+func TestLimiterConcurrentEmitter(t *testing.T) {
+	l := NewLimiter(NewMemoryStore(), Rule{}, map[string]Rule{
+		"GET /api/account/:hash": NewRule(5, time.Hour, 0),
+	})
+
+	var wg sync.WaitGroup
+	accepted := make(chan bool, 50)
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := l.TakeEmitter("GET", "/api/account/hash", "key")
+			accepted <- err == nil
+		}()
+	}
+	wg.Wait()
+	close(accepted)
+
+	count := 0
+	for ok := range accepted {
+		if ok {
+			count++
+		}
+	}
+	assert.Equal(t, 5, count)
+}
+
+/*
+Scenario: Limit the requests with a failing store
+	Given a shared store which cannot be reached
+	When a client sends a request
+	Then I get the error of the store
+*/
+func TestLimiterStoreFailure(t *testing.T) {
+	l := NewLimiter(mockFailingStore{}, NewRule(10, time.Minute, 0), nil)
+	_, err := l.Allow("GET", "/api/account/hash", "127.0.0.1")
+	assert.EqualError(t, err, "store unavailable")
+}
+
+type mockFailingStore struct{}
+
+func (s mockFailingStore) Take(key string, r Rule, now time.Time) (bool, time.Duration, error) {
+	return false, 0, errors.New("store unavailable")
+}
+
+func (s mockFailingStore) Refund(key string, r Rule, now time.Time) error {
+	return errors.New("store unavailable")
+}
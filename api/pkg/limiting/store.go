@@ -0,0 +1,106 @@
+package limiting
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+//sweepInterval is the minimum duration between two removals of the refilled buckets from the memory store
+const sweepInterval = time.Minute
+
+//Store defines methods to keep the token buckets
+//
+//The memory store limits the requests received by a single API instance,
+//a store shared between the instances must take the tokens atomically
+type Store interface {
+
+	//Take consumes a token from the bucket of the key
+	//
+	//It returns false with the delay until the next token when the bucket is empty
+	Take(key string, r Rule, now time.Time) (bool, time.Duration, error)
+
+	//Refund gives back a token taken from the bucket of the key, up to the burst size
+	Refund(key string, r Rule, now time.Time) error
+}
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+	full   time.Time
+}
+
+type memoryStore struct {
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+}
+
+//NewMemoryStore creates a store keeping the token buckets in memory
+func NewMemoryStore() Store {
+	return &memoryStore{
+		buckets: make(map[string]*bucket),
+	}
+}
+
+func (s *memoryStore) Take(key string, r Rule, now time.Time) (bool, time.Duration, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sweep(now)
+
+	b, exist := s.buckets[key]
+	if !exist {
+		b = &bucket{tokens: float64(r.Burst), last: now}
+		s.buckets[key] = b
+	}
+	b.refill(r, now)
+
+	if b.tokens < 1 {
+		return false, b.retryAfter(r), nil
+	}
+	b.tokens--
+	b.full = now.Add(time.Duration((float64(r.Burst) - b.tokens) * float64(r.Interval)))
+	return true, 0, nil
+}
+
+func (s *memoryStore) Refund(key string, r Rule, now time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	b, exist := s.buckets[key]
+	if !exist {
+		return nil
+	}
+	b.refill(r, now)
+
+	b.tokens = math.Min(float64(r.Burst), b.tokens+1)
+	b.full = now.Add(time.Duration((float64(r.Burst) - b.tokens) * float64(r.Interval)))
+	return nil
+}
+
+//sweep removes the buckets refilled since their last request, as they are the same as new ones
+func (s *memoryStore) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) <= sweepInterval {
+		return
+	}
+	for k, b := range s.buckets {
+		if !now.Before(b.full) {
+			delete(s.buckets, k)
+		}
+	}
+	s.lastSweep = now
+}
+
+//refill adds the tokens of the intervals elapsed since the last request, up to the burst size
+func (b *bucket) refill(r Rule, now time.Time) {
+	if elapsed := now.Sub(b.last); elapsed > 0 {
+		b.tokens = math.Min(float64(r.Burst), b.tokens+float64(elapsed)/float64(r.Interval))
+		b.last = now
+	}
+}
+
+//retryAfter returns the delay until the next token
+func (b *bucket) retryAfter(r Rule) time.Duration {
+	return time.Duration((1 - b.tokens) * float64(r.Interval))
+}
@@ -0,0 +1,93 @@
+package listing
+
+//AccountCreationStatus represents the combined status of the ID and keychain transactions of an account creation
+type AccountCreationStatus int
+
+const (
+
+	//AccountCreationPending represents an account creation with transactions not finalised yet
+	AccountCreationPending AccountCreationStatus = 0
+
+	//AccountCreationSuccess represents an account creation with both transactions succeeded
+	AccountCreationSuccess AccountCreationStatus = 1
+
+	//AccountCreationFailure represents an account creation with a failed ID transaction
+	AccountCreationFailure AccountCreationStatus = 2
+
+	//AccountCreationIncomplete represents an account creation with an ID stored without its keychain
+	AccountCreationIncomplete AccountCreationStatus = 3
+
+	//AccountCreationUnknown represents an account creation not known by the robot
+	AccountCreationUnknown AccountCreationStatus = 4
+
+	//AccountCreationAborted represents an account creation whose ID could not be launched, its keychain being abandoned
+	AccountCreationAborted AccountCreationStatus = 5
+
+	//AccountCreationTimeout represents an account creation with a transaction not finalised before the timeout, its outcome being unknown
+	AccountCreationTimeout AccountCreationStatus = 6
+)
+
+func (s AccountCreationStatus) String() string {
+	switch s {
+	case AccountCreationPending:
+		return "Pending"
+	case AccountCreationSuccess:
+		return "Success"
+	case AccountCreationFailure:
+		return "Failure"
+	case AccountCreationIncomplete:
+		return "Incomplete"
+	case AccountCreationUnknown:
+		return "Unknown"
+	case AccountCreationAborted:
+		return "Aborted"
+	case AccountCreationTimeout:
+		return "Timeout"
+	}
+
+	return ""
+}
+
+//AccountCreationState represents the progress of an account creation
+type AccountCreationState interface {
+
+	//Status returns the combined status of the account creation
+	Status() AccountCreationStatus
+
+	//IDStatus returns the status of the ID transaction
+	IDStatus() TransactionStatus
+
+	//KeychainStatus returns the status of the keychain transaction
+	KeychainStatus() TransactionStatus
+
+	//KeychainTransactionHash returns the hash of the keychain transaction followed, which changes when the keychain is mined again
+	KeychainTransactionHash() string
+}
+
+type accCreationState struct {
+	status         AccountCreationStatus
+	idStatus       TransactionStatus
+	keychainStatus TransactionStatus
+	keychainTxHash string
+}
+
+//NewAccountCreationState creates a new account creation state
+func NewAccountCreationState(status AccountCreationStatus, idStatus TransactionStatus, keychainStatus TransactionStatus, keychainTxHash string) AccountCreationState {
+	return accCreationState{status, idStatus, keychainStatus, keychainTxHash}
+}
+
+func (s accCreationState) Status() AccountCreationStatus {
+	return s.status
+}
+
+func (s accCreationState) IDStatus() TransactionStatus {
+	return s.idStatus
+}
+
+func (s accCreationState) KeychainStatus() TransactionStatus {
+	return s.keychainStatus
+}
+
+func (s accCreationState) KeychainTransactionHash() string {
+	return s.keychainTxHash
+}
@@ -0,0 +1,434 @@
+package listing
+
+import "time"
+
+//ValidationStatus represents the status of a validation
+type ValidationStatus int
+
+const (
+
+	//ValidationOK represents a successful validation
+	ValidationOK ValidationStatus = 0
+
+	//ValidationKO represents a failed validation
+	ValidationKO ValidationStatus = 1
+)
+
+func (s ValidationStatus) String() string {
+	switch s {
+	case ValidationOK:
+		return "OK"
+	case ValidationKO:
+		return "KO"
+	}
+
+	return ""
+}
+
+//Validation describes the validation of a transaction by a miner
+type Validation interface {
+
+	//Status returns the status of the validation
+	Status() ValidationStatus
+
+	//Timestamp returns the time of the validation
+	Timestamp() time.Time
+
+	//PublicKey returns the public key of the miner
+	PublicKey() string
+
+	//Signature returns the signature of the validation made by the miner
+	Signature() string
+}
+
+type validation struct {
+	status    ValidationStatus
+	timestamp time.Time
+	pubKey    string
+	sig       string
+}
+
+//NewValidation creates a new validation
+func NewValidation(status ValidationStatus, t time.Time, pubKey string, sig string) Validation {
+	return validation{status, t, pubKey, sig}
+}
+
+func (v validation) Status() ValidationStatus {
+	return v.status
+}
+
+func (v validation) Timestamp() time.Time {
+	return v.timestamp
+}
+
+func (v validation) PublicKey() string {
+	return v.pubKey
+}
+
+func (v validation) Signature() string {
+	return v.sig
+}
+
+//MasterValidation describes the validation made by the master peer leading the transaction
+type MasterValidation interface {
+
+	//LastTransactionMiners returns the public keys of the miners of the previous transaction
+	LastTransactionMiners() []string
+
+	//ProofOfWorkKey returns the public key of the emitter which signed the transaction
+	ProofOfWorkKey() string
+
+	//ProofOfWorkValidation returns the validation of the proof of work
+	ProofOfWorkValidation() Validation
+
+	//ValidationPool returns the public keys of the miners elected to validate the transaction
+	ValidationPool() []string
+}
+
+type masterValidation struct {
+	lastTxMiners []string
+	powKey       string
+	powValid     Validation
+	vPool        []string
+}
+
+//NewMasterValidation creates a new master validation
+func NewMasterValidation(lastTxMiners []string, powKey string, powValid Validation, vPool []string) MasterValidation {
+	return masterValidation{lastTxMiners, powKey, powValid, vPool}
+}
+
+func (mv masterValidation) LastTransactionMiners() []string {
+	return mv.lastTxMiners
+}
+
+func (mv masterValidation) ProofOfWorkKey() string {
+	return mv.powKey
+}
+
+func (mv masterValidation) ProofOfWorkValidation() Validation {
+	return mv.powValid
+}
+
+func (mv masterValidation) ValidationPool() []string {
+	return mv.vPool
+}
+
+//Endorsement describes the proof of the validation and the storage of a transaction
+type Endorsement interface {
+
+	//LastTransactionHash returns the hash of the previous transaction of the chain
+	LastTransactionHash() string
+
+	//TransactionHash returns the hash of the transaction
+	TransactionHash() string
+
+	//MasterValidation returns the validation of the master peer
+	MasterValidation() MasterValidation
+
+	//Validations returns the validations of the validation pool
+	Validations() []Validation
+}
+
+type endorsement struct {
+	lastTxHash string
+	txHash     string
+	masterV    MasterValidation
+	valids     []Validation
+}
+
+//NewEndorsement creates a new endorsement
+func NewEndorsement(lastTxHash string, txHash string, masterV MasterValidation, valids []Validation) Endorsement {
+	return endorsement{lastTxHash, txHash, masterV, valids}
+}
+
+func (e endorsement) LastTransactionHash() string {
+	return e.lastTxHash
+}
+
+func (e endorsement) TransactionHash() string {
+	return e.txHash
+}
+
+func (e endorsement) MasterValidation() MasterValidation {
+	return e.masterV
+}
+
+func (e endorsement) Validations() []Validation {
+	return e.valids
+}
+
+//ID describes the stored ID of an account
+type ID interface {
+
+	//Hash returns the hash of the ID public key
+	Hash() string
+
+	//EncryptedAddrByRobot returns the account address encrypted with the shared robot key
+	EncryptedAddrByRobot() string
+
+	//EncryptedAddrByID returns the account address encrypted with the ID key
+	EncryptedAddrByID() string
+
+	//EncryptedAESKey returns the AES key encrypted with the ID key
+	EncryptedAESKey() string
+
+	//PublicKey returns the ID public key
+	PublicKey() string
+
+	//Proposal returns the shared emitter key pair proposed when the ID was created
+	Proposal() ProposedKeyPair
+
+	//IDSignature returns the signature of the ID made with the ID key
+	IDSignature() string
+
+	//EmitterSignature returns the signature of the ID made by the emitter
+	EmitterSignature() string
+}
+
+type id struct {
+	hash         string
+	encAddrRobot string
+	encAddrID    string
+	encAESKey    string
+	pubKey       string
+	prop         ProposedKeyPair
+	idSig        string
+	emSig        string
+}
+
+//NewID creates a new ID
+func NewID(hash, encAddrRobot, encAddrID, encAESKey, pubKey string, prop ProposedKeyPair, idSig, emSig string) ID {
+	return id{hash, encAddrRobot, encAddrID, encAESKey, pubKey, prop, idSig, emSig}
+}
+
+func (i id) Hash() string {
+	return i.hash
+}
+
+func (i id) EncryptedAddrByRobot() string {
+	return i.encAddrRobot
+}
+
+func (i id) EncryptedAddrByID() string {
+	return i.encAddrID
+}
+
+func (i id) EncryptedAESKey() string {
+	return i.encAESKey
+}
+
+func (i id) PublicKey() string {
+	return i.pubKey
+}
+
+func (i id) Proposal() ProposedKeyPair {
+	return i.prop
+}
+
+func (i id) IDSignature() string {
+	return i.idSig
+}
+
+func (i id) EmitterSignature() string {
+	return i.emSig
+}
+
+//Keychain describes the stored keychain of an account
+type Keychain interface {
+
+	//EncryptedAddrByRobot returns the account address encrypted with the shared robot key
+	EncryptedAddrByRobot() string
+
+	//EncryptedWallet returns the encrypted wallet
+	EncryptedWallet() string
+
+	//IDPublicKey returns the public key of the ID owning the keychain
+	IDPublicKey() string
+
+	//Proposal returns the shared emitter key pair proposed when the keychain was created
+	Proposal() ProposedKeyPair
+
+	//IDSignature returns the signature of the keychain made with the ID key
+	IDSignature() string
+
+	//EmitterSignature returns the signature of the keychain made by the emitter
+	EmitterSignature() string
+}
+
+type keychain struct {
+	encAddrRobot string
+	encWallet    string
+	idPubKey     string
+	prop         ProposedKeyPair
+	idSig        string
+	emSig        string
+}
+
+//NewKeychain creates a new keychain
+func NewKeychain(encAddrRobot, encWallet, idPubKey string, prop ProposedKeyPair, idSig, emSig string) Keychain {
+	return keychain{encAddrRobot, encWallet, idPubKey, prop, idSig, emSig}
+}
+
+func (k keychain) EncryptedAddrByRobot() string {
+	return k.encAddrRobot
+}
+
+func (k keychain) EncryptedWallet() string {
+	return k.encWallet
+}
+
+func (k keychain) IDPublicKey() string {
+	return k.idPubKey
+}
+
+func (k keychain) Proposal() ProposedKeyPair {
+	return k.prop
+}
+
+func (k keychain) IDSignature() string {
+	return k.idSig
+}
+
+func (k keychain) EmitterSignature() string {
+	return k.emSig
+}
+
+//IDDetails describes a stored ID with the proof of its storage
+type IDDetails interface {
+
+	//ID returns the stored ID
+	ID() ID
+
+	//Endorsement returns the endorsement of the ID transaction
+	Endorsement() Endorsement
+
+	//Signature returns the signature of the details made with the shared robot key
+	Signature() string
+}
+
+type idDetails struct {
+	id  ID
+	end Endorsement
+	sig string
+}
+
+//NewIDDetails creates new ID details
+func NewIDDetails(id ID, end Endorsement, sig string) IDDetails {
+	return idDetails{id, end, sig}
+}
+
+func (d idDetails) ID() ID {
+	return d.id
+}
+
+func (d idDetails) Endorsement() Endorsement {
+	return d.end
+}
+
+func (d idDetails) Signature() string {
+	return d.sig
+}
+
+//KeychainDetails describes the last stored keychain of an account with the proof of its storage
+type KeychainDetails interface {
+
+	//Keychain returns the stored keychain
+	Keychain() Keychain
+
+	//Endorsement returns the endorsement of the keychain transaction
+	Endorsement() Endorsement
+
+	//Signature returns the signature of the details made with the shared robot key
+	Signature() string
+}
+
+type keychainDetails struct {
+	kc  Keychain
+	end Endorsement
+	sig string
+}
+
+//NewKeychainDetails creates new keychain details
+func NewKeychainDetails(kc Keychain, end Endorsement, sig string) KeychainDetails {
+	return keychainDetails{kc, end, sig}
+}
+
+func (d keychainDetails) Keychain() Keychain {
+	return d.kc
+}
+
+func (d keychainDetails) Endorsement() Endorsement {
+	return d.end
+}
+
+func (d keychainDetails) Signature() string {
+	return d.sig
+}
+
+//TransactionProof describes the endorsement of a transaction
+//
+//The validation pool elected for the transaction is signed with the master validation
+type TransactionProof interface {
+
+	//Endorsement returns the endorsement of the transaction
+	Endorsement() Endorsement
+}
+
+type transactionProof struct {
+	end Endorsement
+}
+
+//NewTransactionProof creates a new transaction proof
+func NewTransactionProof(end Endorsement) TransactionProof {
+	return transactionProof{end}
+}
+
+func (p transactionProof) Endorsement() Endorsement {
+	return p.end
+}
+
+//AccountProof describes the stored ID and keychain of an account with the proofs of their transactions
+//
+//It is not signed by the robot as it can be verified by the clients themselves
+type AccountProof interface {
+
+	//ID returns the stored ID
+	ID() ID
+
+	//IDProof returns the proof of the ID transaction
+	IDProof() TransactionProof
+
+	//Keychain returns the last stored keychain
+	Keychain() Keychain
+
+	//KeychainProof returns the proof of the keychain transaction
+	KeychainProof() TransactionProof
+}
+
+type accountProof struct {
+	id      ID
+	idProof TransactionProof
+	kc      Keychain
+	kcProof TransactionProof
+}
+
+//NewAccountProof creates a new account proof
+func NewAccountProof(id ID, idProof TransactionProof, kc Keychain, kcProof TransactionProof) AccountProof {
+	return accountProof{id, idProof, kc, kcProof}
+}
+
+func (p accountProof) ID() ID {
+	return p.id
+}
+
+func (p accountProof) IDProof() TransactionProof {
+	return p.idProof
+}
+
+func (p accountProof) Keychain() Keychain {
+	return p.kc
+}
+
+func (p accountProof) KeychainProof() TransactionProof {
+	return p.kcProof
+}
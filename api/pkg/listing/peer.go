@@ -0,0 +1,29 @@
+package listing
+
+//Peer represents a peer of a storage pool
+type Peer interface {
+
+	//IP returns the IP address of the peer
+	IP() string
+
+	//PublicKey returns the public key of the peer
+	PublicKey() string
+}
+
+type peer struct {
+	ip     string
+	pubKey string
+}
+
+//NewPeer creates a new peer
+func NewPeer(ip, pubKey string) Peer {
+	return peer{ip, pubKey}
+}
+
+func (p peer) IP() string {
+	return p.ip
+}
+
+func (p peer) PublicKey() string {
+	return p.pubKey
+}
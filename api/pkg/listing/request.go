@@ -0,0 +1,93 @@
+package listing
+
+import (
+	"errors"
+	"time"
+
+	"github.com/uniris/uniris-core/shared/pkg/nonce"
+)
+
+//ErrExpiredRequest is returned when a signed request is outside the freshness window
+var ErrExpiredRequest = errors.New("Request expired")
+
+//ErrReplayedRequest is returned when the nonce of a signed request has already been received
+var ErrReplayedRequest = errors.New("Request already received")
+
+//requestFreshness is the maximum clock drift accepted between the signature of a request and its reception
+const requestFreshness = 5 * time.Minute
+
+//RequestProof defines the freshness data signed with a request to prevent its replay
+type RequestProof interface {
+
+	//EmitterPublicKey returns the public key of the emitter sending the request
+	EmitterPublicKey() string
+
+	//Timestamp returns the time when the request has been signed
+	Timestamp() time.Time
+
+	//Nonce returns the unique value identifying the request
+	Nonce() string
+
+	//Signature returns the signature of the request including its timestamp and nonce
+	Signature() string
+}
+
+type reqProof struct {
+	emPubKey  string
+	timestamp time.Time
+	nonce     string
+	sig       string
+}
+
+//NewRequestProof creates a new request proof
+func NewRequestProof(emPubKey string, timestamp time.Time, nonce string, sig string) RequestProof {
+	return reqProof{emPubKey, timestamp, nonce, sig}
+}
+
+func (p reqProof) EmitterPublicKey() string {
+	return p.emPubKey
+}
+
+func (p reqProof) Timestamp() time.Time {
+	return p.timestamp
+}
+
+func (p reqProof) Nonce() string {
+	return p.nonce
+}
+
+func (p reqProof) Signature() string {
+	return p.sig
+}
+
+//ReplayGuard defines methods to reject the replay of signed requests
+type ReplayGuard interface {
+
+	//CheckRequest checks the freshness of a request signed by the public key and registers its nonce
+	//
+	//It must be called after the signature verification, so the nonces cannot be registered by forged requests
+	CheckRequest(signer string, proof RequestProof) error
+}
+
+type replayGuard struct {
+	nonces nonce.Cache
+}
+
+//NewReplayGuard creates a replay guard keeping the nonces of each signer in memory until the end of the freshness window
+func NewReplayGuard() ReplayGuard {
+	return replayGuard{
+		nonces: nonce.NewCache(),
+	}
+}
+
+func (g replayGuard) CheckRequest(signer string, proof RequestProof) error {
+	if drift := time.Since(proof.Timestamp()); drift > requestFreshness || drift < -requestFreshness {
+		return ErrExpiredRequest
+	}
+
+	//Older requests are rejected by the freshness check, so the nonce can be removed after the window
+	if !g.nonces.Add(signer, proof.Nonce(), proof.Timestamp().Add(requestFreshness)) {
+		return ErrReplayedRequest
+	}
+	return nil
+}
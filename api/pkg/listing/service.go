@@ -1,6 +1,7 @@
 package listing
 
 import (
+	"context"
 	"errors"
 )
 
@@ -24,16 +25,43 @@ type RobotClient interface {
 
 	//GetTransactionStatus asks the datamining service to get the transaction status
 	GetTransactionStatus(addr string, txHash string) (TransactionStatus, error)
+
+	//WatchTransactionStatus asks the datamining service to stream the transaction status transitions
+	WatchTransactionStatus(ctx context.Context, addr string, txHash string) (<-chan TransactionStatus, error)
+
+	//GetAccountCreationStatus asks the datamining service to get the progress of an account creation
+	GetAccountCreationStatus(idTxHash string) (AccountCreationState, error)
+
+	//GetIDDetails asks the datamining service to get the stored ID and its endorsement based on the encrypted ID hash
+	GetIDDetails(encHash string) (IDDetails, error)
+
+	//GetKeychainDetails asks the datamining service to get the last stored keychain and its endorsement based on the encrypted ID hash
+	GetKeychainDetails(encHash string) (KeychainDetails, error)
+
+	//GetAccountProof asks the datamining service to get the stored ID and keychain with the proofs of their transactions
+	GetAccountProof(encHash string) (AccountProof, error)
+
+	//GetStoragePeers asks the datamining service to get the peers of the storage pool of an encrypted address
+	GetStoragePeers(addr string) ([]Peer, error)
 }
 
 //SignatureVerifier defines methods to handle signature verification
 type SignatureVerifier interface {
 
-	//VerifyHashSignature checks the hash signature
-	VerifyHashSignature(hashedData string, key string, sig string) error
+	//VerifyAccountRequestSignature checks the signature of an account request using the shared emitter public key
+	VerifyAccountRequestSignature(encryptedIDHash string, proof RequestProof, pubKey string) error
+
+	//VerifySharedKeysRequestSignature checks the signature of a shared keys request using the emitter public key
+	VerifySharedKeysRequestSignature(emPubKey string, proof RequestProof) error
 
 	//VerifyAccountResultSignature checks the account result signature
 	VerifyAccountResultSignature(res AccountResult, pubKey string) error
+
+	//VerifyIDDetailsSignature checks the signature of the ID details
+	VerifyIDDetailsSignature(res IDDetails, pubKey string) error
+
+	//VerifyKeychainDetailsSignature checks the signature of the keychain details
+	VerifyKeychainDetailsSignature(res KeychainDetails, pubKey string) error
 }
 
 //Service define methods for the listing feature
@@ -43,42 +71,70 @@ type Service interface {
 	GetSafeSharedKeys() (SharedKeys, error)
 
 	//GetSharedKeys gets the latest shared keys
-	GetSharedKeys(emPubKey string, sig string) (SharedKeys, error)
+	GetSharedKeys(emPubKey string, proof RequestProof) (SharedKeys, error)
 
 	//ExistAccount checks if an account is related to an encrypted ID hash
-	ExistAccount(encryptedIDHash string, sig string) error
+	ExistAccount(encryptedIDHash string, proof RequestProof) error
 
 	//GetAccount gets an account related to the encrypted ID hash
-	GetAccount(encryptedIDHash string, sig string) (AccountResult, error)
+	GetAccount(encryptedIDHash string, proof RequestProof) (AccountResult, error)
 
 	//GetTransactionStatus gets the transaction status
 	GetTransactionStatus(addr, txHash string) (TransactionStatus, error)
+
+	//WatchTransactionStatus streams the transaction status transitions
+	//
+	//The channel is closed when the transaction reaches a final status or when the context is done
+	WatchTransactionStatus(ctx context.Context, addr, txHash string) (<-chan TransactionStatus, error)
+
+	//GetAccountCreationStatus gets the progress of an account creation identified by its ID transaction hash
+	GetAccountCreationStatus(idTxHash string) (AccountCreationState, error)
+
+	//GetIDDetails gets the stored ID related to the encrypted ID hash with its endorsement
+	GetIDDetails(encryptedIDHash string, proof RequestProof) (IDDetails, error)
+
+	//GetKeychainDetails gets the last stored keychain of the account related to the encrypted ID hash with its endorsement
+	GetKeychainDetails(encryptedIDHash string, proof RequestProof) (KeychainDetails, error)
+
+	//GetAccountProof gets the stored ID and keychain related to the encrypted ID hash with the proofs of their transactions
+	//
+	//The proof is not signed by the robot as the clients can verify it without trusting the peer
+	GetAccountProof(encryptedIDHash string, proof RequestProof) (AccountProof, error)
+
+	//GetStoragePeers gets the peers of the storage pool holding the data of an encrypted address
+	GetStoragePeers(addr string) ([]Peer, error)
 }
 
 type service struct {
 	client RobotClient
 	sig    SignatureVerifier
+	guard  ReplayGuard
 }
 
 //NewService creates a new listing service
-func NewService(client RobotClient, sig SignatureVerifier) Service {
+func NewService(client RobotClient, sig SignatureVerifier, guard ReplayGuard) Service {
 	return service{
 		client: client,
 		sig:    sig,
+		guard:  guard,
 	}
 }
 
-func (s service) ExistAccount(encryptedIDHash string, sig string) error {
+func (s service) ExistAccount(encryptedIDHash string, proof RequestProof) error {
 
-	_, err := s.GetAccount(encryptedIDHash, sig)
+	_, err := s.GetAccount(encryptedIDHash, proof)
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
-func (s service) GetSharedKeys(emPubKey string, sig string) (SharedKeys, error) {
-	if err := s.sig.VerifyHashSignature(emPubKey, emPubKey, sig); err != nil {
+func (s service) GetSharedKeys(emPubKey string, proof RequestProof) (SharedKeys, error) {
+	if err := s.sig.VerifySharedKeysRequestSignature(emPubKey, proof); err != nil {
+		return nil, err
+	}
+
+	if err := s.guard.CheckRequest(emPubKey, proof); err != nil {
 		return nil, err
 	}
 
@@ -103,28 +159,123 @@ func (s service) GetSafeSharedKeys() (SharedKeys, error) {
 	return keys, nil
 }
 
-func (s service) GetAccount(encryptedIDHash string, sig string) (AccountResult, error) {
-	keys, err := s.client.GetSharedKeys()
+func (s service) GetAccount(encryptedIDHash string, proof RequestProof) (AccountResult, error) {
+	keys, err := s.checkAccountRequest(encryptedIDHash, proof)
 	if err != nil {
 		return nil, err
 	}
 
-	if err := s.sig.VerifyHashSignature(encryptedIDHash, keys.RequestPublicKey(), sig); err != nil {
+	res, err := s.client.GetAccount(encryptedIDHash)
+	if err != nil {
 		return nil, err
 	}
 
-	res, err := s.client.GetAccount(encryptedIDHash)
+	if err := verifyRobotSignature(keys, func(pub string) error {
+		return s.sig.VerifyAccountResultSignature(res, pub)
+	}); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (s service) GetIDDetails(encryptedIDHash string, proof RequestProof) (IDDetails, error) {
+	keys, err := s.checkAccountRequest(encryptedIDHash, proof)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := s.client.GetIDDetails(encryptedIDHash)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := verifyRobotSignature(keys, func(pub string) error {
+		return s.sig.VerifyIDDetailsSignature(res, pub)
+	}); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (s service) GetKeychainDetails(encryptedIDHash string, proof RequestProof) (KeychainDetails, error) {
+	keys, err := s.checkAccountRequest(encryptedIDHash, proof)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := s.client.GetKeychainDetails(encryptedIDHash)
 	if err != nil {
 		return nil, err
 	}
 
-	if err := s.sig.VerifyAccountResultSignature(res, keys.RobotPublicKey()); err != nil {
+	if err := verifyRobotSignature(keys, func(pub string) error {
+		return s.sig.VerifyKeychainDetailsSignature(res, pub)
+	}); err != nil {
 		return nil, err
 	}
 
 	return res, nil
 }
 
+func (s service) GetAccountProof(encryptedIDHash string, proof RequestProof) (AccountProof, error) {
+	if _, err := s.checkAccountRequest(encryptedIDHash, proof); err != nil {
+		return nil, err
+	}
+
+	return s.client.GetAccountProof(encryptedIDHash)
+}
+
+//checkAccountRequest checks the signature, the freshness and the emitter of an account request and returns the shared keys used
+//
+//The emitter public key is signed with the request and must be authorized, as it identifies the emitter for the rate limiting
+func (s service) checkAccountRequest(encryptedIDHash string, proof RequestProof) (SharedKeys, error) {
+	keys, err := s.client.GetSharedKeys()
+	if err != nil {
+		return nil, err
+	}
+
+	signer, err := VerifyEmitterRequest(keys, func(pubKey string) error {
+		return s.sig.VerifyAccountRequestSignature(encryptedIDHash, proof, pubKey)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.guard.CheckRequest(signer, proof); err != nil {
+		return nil, err
+	}
+
+	if err := s.client.IsEmitterAuthorized(proof.EmitterPublicKey()); err != nil {
+		return nil, err
+	}
+
+	return keys, nil
+}
+
+//verifyRobotSignature checks a result signature with the robot key versions as it can be signed by a newer or a previous one during a switch-over
+func verifyRobotSignature(keys SharedKeys, verify func(pubKey string) error) (err error) {
+	for _, pub := range keys.RobotPublicKeys() {
+		if err = verify(pub); err == nil {
+			return nil
+		}
+	}
+	return err
+}
+
 func (s service) GetTransactionStatus(addr string, txHash string) (TransactionStatus, error) {
 	return s.client.GetTransactionStatus(addr, txHash)
 }
+
+func (s service) WatchTransactionStatus(ctx context.Context, addr string, txHash string) (<-chan TransactionStatus, error) {
+	return s.client.WatchTransactionStatus(ctx, addr, txHash)
+}
+
+func (s service) GetAccountCreationStatus(idTxHash string) (AccountCreationState, error) {
+	return s.client.GetAccountCreationStatus(idTxHash)
+}
+
+func (s service) GetStoragePeers(addr string) ([]Peer, error) {
+	return s.client.GetStoragePeers(addr)
+}
@@ -1,8 +1,11 @@
 package listing
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -14,9 +17,9 @@ Scenario: Get account's details from the robot
 	Then I can get the encrypted data from the roboto
 */
 func TestGetAccount(t *testing.T) {
-	s := NewService(mockClient{}, mockSigVerifier{})
+	s := NewService(mockClient{}, mockSigVerifier{}, NewReplayGuard())
 
-	res, err := s.GetAccount("encrypted person pub key", "sig")
+	res, err := s.GetAccount("encrypted person pub key", newTestProof())
 	assert.Nil(t, err)
 	assert.Equal(t, "encrypted_aes_key", res.EncryptedAESKey())
 	assert.Equal(t, "encrypted_wallet", res.EncryptedWallet())
@@ -30,11 +33,98 @@ Scenario: Catch invalid signature when get account's details from the robot
 	Then I get an error
 */
 func TestGetAccountInvalidSig(t *testing.T) {
-	s := NewService(mockClient{}, mockSigVerifier{isInvalid: true})
-	_, err := s.GetAccount("encrypted person pub key", "sig")
+	s := NewService(mockClient{}, mockSigVerifier{isInvalid: true}, NewReplayGuard())
+	_, err := s.GetAccount("encrypted person pub key", newTestProof())
 	assert.Equal(t, err, errors.New("Invalid signature"))
 }
 
+/*
+Scenario: Get account's details for an unauthorized emitter
+	Given a request signed by the shared emitter key with the public key of an unknown emitter
+	When I want to get the account details
+	Then I get an unauthorized error
+*/
+func TestGetAccountUnauthorizedEmitter(t *testing.T) {
+	s := NewService(mockClient{}, mockSigVerifier{}, NewReplayGuard())
+	_, err := s.GetAccount("encrypted person pub key", NewRequestProof("unknown key", time.Now(), "nonce", "sig"))
+	assert.Equal(t, ErrUnauthorized, err)
+}
+
+/*
+Scenario: Get the ID details from the robot
+	Given an encrypted ID hash and a signature
+	When I want to get the ID details
+	Then I get the stored ID with its endorsement
+*/
+func TestGetIDDetails(t *testing.T) {
+	s := NewService(mockClient{}, mockSigVerifier{}, NewReplayGuard())
+
+	res, err := s.GetIDDetails("encrypted id hash", newTestProof())
+	assert.Nil(t, err)
+	assert.Equal(t, "id hash", res.ID().Hash())
+	assert.Equal(t, "id pub key", res.ID().PublicKey())
+	assert.Equal(t, "last tx hash", res.Endorsement().LastTransactionHash())
+	assert.Equal(t, "pow key", res.Endorsement().MasterValidation().ProofOfWorkKey())
+	assert.Len(t, res.Endorsement().Validations(), 1)
+}
+
+/*
+Scenario: Get the keychain details from the robot
+	Given an encrypted ID hash and a signature
+	When I want to get the keychain details
+	Then I get the last stored keychain with its endorsement
+*/
+func TestGetKeychainDetails(t *testing.T) {
+	s := NewService(mockClient{}, mockSigVerifier{}, NewReplayGuard())
+
+	res, err := s.GetKeychainDetails("encrypted id hash", newTestProof())
+	assert.Nil(t, err)
+	assert.Equal(t, "encrypted_wallet", res.Keychain().EncryptedWallet())
+	assert.Equal(t, "tx hash", res.Endorsement().TransactionHash())
+	assert.Equal(t, "validator pub key", res.Endorsement().Validations()[0].PublicKey())
+}
+
+/*
+Scenario: Catch invalid signature when get the ID details from the robot
+	Given an encrypted ID hash and an invalid signature
+	When I want to get the ID details
+	Then I get an error
+*/
+func TestGetIDDetailsInvalidSig(t *testing.T) {
+	s := NewService(mockClient{}, mockSigVerifier{isInvalid: true}, NewReplayGuard())
+	_, err := s.GetIDDetails("encrypted id hash", newTestProof())
+	assert.Equal(t, errors.New("Invalid signature"), err)
+}
+
+/*
+Scenario: Get the account proof from the robot
+	Given an encrypted ID hash and a signature
+	When I want to get the account proof
+	Then I get the stored ID and keychain with their endorsements and validation pools
+*/
+func TestGetAccountProof(t *testing.T) {
+	s := NewService(mockClient{}, mockSigVerifier{}, NewReplayGuard())
+
+	res, err := s.GetAccountProof("encrypted id hash", newTestProof())
+	assert.Nil(t, err)
+	assert.Equal(t, "id hash", res.ID().Hash())
+	assert.Equal(t, "encrypted_wallet", res.Keychain().EncryptedWallet())
+	assert.Equal(t, "tx hash", res.IDProof().Endorsement().TransactionHash())
+	assert.Equal(t, []string{"validator pub key"}, res.KeychainProof().Endorsement().MasterValidation().ValidationPool())
+}
+
+/*
+Scenario: Catch invalid signature when get the account proof from the robot
+	Given an encrypted ID hash and an invalid signature
+	When I want to get the account proof
+	Then I get an error
+*/
+func TestGetAccountProofInvalidSig(t *testing.T) {
+	s := NewService(mockClient{}, mockSigVerifier{isInvalid: true}, NewReplayGuard())
+	_, err := s.GetAccountProof("encrypted id hash", newTestProof())
+	assert.Equal(t, errors.New("Invalid signature"), err)
+}
+
 /*
 Scenario: Get the shared keys
 	Given emitter publi ckey and signature
@@ -42,8 +132,8 @@ Scenario: Get the shared keys
 	Then I get the shared keys
 */
 func TestGetSharedKeys(t *testing.T) {
-	s := NewService(mockClient{}, mockSigVerifier{})
-	res, err := s.GetSharedKeys("em pub key", "sig")
+	s := NewService(mockClient{}, mockSigVerifier{}, NewReplayGuard())
+	res, err := s.GetSharedKeys("em pub key", newTestProof())
 	assert.Nil(t, err)
 	assert.NotNil(t, res)
 	assert.Equal(t, "robot pub key", res.RobotPublicKey())
@@ -57,8 +147,8 @@ Scenario: Catch invalid signature when get the shared keys
 	Then I get an error
 */
 func TestInvalidSigGetSharedKeys(t *testing.T) {
-	s := NewService(mockClient{}, mockSigVerifier{isInvalid: true})
-	_, err := s.GetSharedKeys("em key", "invalid sig")
+	s := NewService(mockClient{}, mockSigVerifier{isInvalid: true}, NewReplayGuard())
+	_, err := s.GetSharedKeys("em key", newTestProof())
 	assert.Equal(t, "Invalid signature", err.Error())
 }
 
@@ -69,11 +159,161 @@ Scenario: Get the shared keys using unauthorized public key
 	Then I get an error
 */
 func TestGetSharedKeysWithUnauthorized(t *testing.T) {
-	s := NewService(mockClient{}, mockSigVerifier{})
-	_, err := s.GetSharedKeys("invalid key", "sig")
+	s := NewService(mockClient{}, mockSigVerifier{}, NewReplayGuard())
+	_, err := s.GetSharedKeys("invalid key", newTestProof())
 	assert.Equal(t, ErrUnauthorized, err)
 }
 
+/*
+Scenario: Get the robot public keys after a switch-over
+	Given a current robot key, a previous version and a version not yet activated
+	When I want the robot public keys to check a signature
+	Then I get the current key first followed by the previous version
+*/
+func TestRobotPublicKeysAfterSwitchOver(t *testing.T) {
+	keys := NewSharedKeys("pub v1", []RobotKeyPair{
+		NewRobotKeyPair(2, "pub v2", time.Now().Add(time.Hour)),
+		NewRobotKeyPair(1, "pub v1", time.Now()),
+		NewRobotKeyPair(0, "pub v0", time.Time{}),
+	}, []SharedKeyPair{}, []PreviousSharedKeyPair{})
+
+	assert.Equal(t, []string{"pub v1", "pub v0"}, keys.RobotPublicKeys())
+}
+
+/*
+Scenario: Get the public keys accepted for the emitter requests after a rotation
+	Given a current shared emitter key, a previous key in its grace period and an expired previous key
+	When I want the public keys to check an emitter request
+	Then I get the current key first followed by the previous key in its grace period
+*/
+func TestRequestPublicKeysAfterRotation(t *testing.T) {
+	keys := NewSharedKeys("robot pub key", []RobotKeyPair{}, []SharedKeyPair{NewSharedKeyPair("enc pv key", "pub key")}, []PreviousSharedKeyPair{
+		NewPreviousSharedKeyPair(NewSharedKeyPair("enc pv key", "previous pub key"), time.Now().Add(time.Hour)),
+		NewPreviousSharedKeyPair(NewSharedKeyPair("enc pv key", "expired pub key"), time.Now().Add(-time.Hour)),
+	})
+
+	assert.Equal(t, []string{"pub key", "previous pub key"}, keys.RequestPublicKeys())
+}
+
+/*
+Scenario: Get account's details with a request signed by the previous shared emitter key
+	Given a rotated shared emitter key
+	When I send a request signed by the previous key during its grace period and after its expiration
+	Then the request is accepted during the grace period and rejected after
+*/
+func TestGetAccountSignedByPreviousEmitterKey(t *testing.T) {
+	s := NewService(mockRotatedClient{expiration: time.Now().Add(time.Hour)}, mockKeySigVerifier{signer: "previous pub key"}, NewReplayGuard())
+	_, err := s.GetAccount("encrypted person pub key", newTestProof())
+	assert.Nil(t, err)
+
+	s = NewService(mockRotatedClient{expiration: time.Now().Add(-time.Hour)}, mockKeySigVerifier{signer: "previous pub key"}, NewReplayGuard())
+	_, err = s.GetAccount("encrypted person pub key", newTestProof())
+	assert.EqualError(t, err, "Invalid signature")
+
+	s = NewService(mockRotatedClient{expiration: time.Now().Add(time.Hour)}, mockKeySigVerifier{signer: "unknown pub key"}, NewReplayGuard())
+	_, err = s.GetAccount("encrypted person pub key", newTestProof())
+	assert.EqualError(t, err, "Invalid signature")
+}
+
+/*
+Scenario: Replay a request to get account's details
+	Given a signed request already received
+	When I send the same request again
+	Then I get a replay error
+*/
+func TestGetAccountReplayed(t *testing.T) {
+	s := NewService(mockClient{}, mockSigVerifier{}, NewReplayGuard())
+	proof := newTestProof()
+
+	_, err := s.GetAccount("encrypted person pub key", proof)
+	assert.Nil(t, err)
+
+	_, err = s.GetAccount("encrypted person pub key", proof)
+	assert.Equal(t, ErrReplayedRequest, err)
+}
+
+/*
+Scenario: Get the shared keys with an expired request
+	Given a request signed before the freshness window
+	When I want to get the shared keys
+	Then I get an expiration error
+*/
+func TestGetSharedKeysExpired(t *testing.T) {
+	s := NewService(mockClient{}, mockSigVerifier{}, NewReplayGuard())
+	_, err := s.GetSharedKeys("em pub key", NewRequestProof("em pub key", time.Now().Add(-2*requestFreshness), "nonce", "sig"))
+	assert.Equal(t, ErrExpiredRequest, err)
+}
+
+/*
+Scenario: Register the nonces by signer
+	Given a nonce registered by a signer
+	When another signer uses the same nonce
+	Then the request is accepted
+*/
+func TestReplayGuardNonceBySigner(t *testing.T) {
+	g := NewReplayGuard()
+	assert.Nil(t, g.CheckRequest("signer1", NewRequestProof("em pub key", time.Now(), "nonce", "sig")))
+	assert.Nil(t, g.CheckRequest("signer2", NewRequestProof("em pub key", time.Now(), "nonce", "sig")))
+	assert.Equal(t, ErrReplayedRequest, g.CheckRequest("signer1", NewRequestProof("em pub key", time.Now(), "nonce", "sig")))
+}
+
+func newTestProof() RequestProof {
+	return NewRequestProof("em pub key", time.Now(), fmt.Sprintf("%d", time.Now().UnixNano()), "sig")
+}
+
+/*
+Scenario: Watch the status of a transaction
+	Given a transaction mined by the robot
+	When I want to watch its status
+	Then I get the transitions until the final status
+*/
+func TestWatchTransactionStatus(t *testing.T) {
+	s := NewService(mockClient{}, mockSigVerifier{}, NewReplayGuard())
+
+	statuses, err := s.WatchTransactionStatus(context.Background(), "addr", "hash")
+	assert.Nil(t, err)
+
+	transitions := make([]TransactionStatus, 0)
+	for status := range statuses {
+		transitions = append(transitions, status)
+	}
+	assert.Equal(t, []TransactionStatus{TransactionPending, TransactionSuccess}, transitions)
+	assert.True(t, transitions[len(transitions)-1].IsFinal())
+}
+
+/*
+Scenario: Get the status of an account creation
+	Given an account creation with both transactions succeeded
+	When I want to get its status
+	Then I get the combined status with the status of each transaction
+*/
+func TestGetAccountCreationStatus(t *testing.T) {
+	s := NewService(mockClient{}, mockSigVerifier{}, NewReplayGuard())
+
+	state, err := s.GetAccountCreationStatus("id hash")
+	assert.Nil(t, err)
+	assert.Equal(t, AccountCreationSuccess, state.Status())
+	assert.Equal(t, "Success", state.Status().String())
+	assert.Equal(t, TransactionSuccess, state.IDStatus())
+	assert.Equal(t, TransactionSuccess, state.KeychainStatus())
+}
+
+/*
+Scenario: Get the storage peers of an address
+	Given an encrypted address
+	When I want to get its storage peers
+	Then I get the peers of the storage pool
+*/
+func TestGetStoragePeers(t *testing.T) {
+	s := NewService(mockClient{}, mockSigVerifier{}, NewReplayGuard())
+
+	peers, err := s.GetStoragePeers("enc addr")
+	assert.Nil(t, err)
+	assert.Len(t, peers, 1)
+	assert.Equal(t, "127.0.0.1", peers[0].IP())
+	assert.Equal(t, "peer pub key", peers[0].PublicKey())
+}
+
 type mockClient struct{}
 
 func (c mockClient) GetAccount(encIDHash string) (AccountResult, error) {
@@ -89,22 +329,101 @@ func (c mockClient) IsEmitterAuthorized(emPubKey string) error {
 
 func (c mockClient) GetSharedKeys() (SharedKeys, error) {
 	return NewSharedKeys(
-		"robot pv key",
 		"robot pub key",
+		[]RobotKeyPair{},
 		[]SharedKeyPair{
 			NewSharedKeyPair("enc pv key", "pub key"),
-		}), nil
+		},
+		[]PreviousSharedKeyPair{}), nil
 }
 
 func (c mockClient) GetTransactionStatus(addr string, txHash string) (TransactionStatus, error) {
 	return TransactionSuccess, nil
 }
 
+func (c mockClient) WatchTransactionStatus(ctx context.Context, addr string, txHash string) (<-chan TransactionStatus, error) {
+	statuses := make(chan TransactionStatus, 2)
+	statuses <- TransactionPending
+	statuses <- TransactionSuccess
+	close(statuses)
+	return statuses, nil
+}
+
+func (c mockClient) GetAccountCreationStatus(idTxHash string) (AccountCreationState, error) {
+	return NewAccountCreationState(AccountCreationSuccess, TransactionSuccess, TransactionSuccess, "keychain hash"), nil
+}
+
+func (c mockClient) GetIDDetails(encHash string) (IDDetails, error) {
+	return NewIDDetails(
+		NewID("id hash", "enc addr robot", "enc addr id", "enc aes key", "id pub key", NewProposedKeyPair(NewSharedKeyPair("enc pv key", "pub key"), time.Time{}), "id sig", "em sig"),
+		newTestEndorsement(),
+		"sig"), nil
+}
+
+func (c mockClient) GetKeychainDetails(encHash string) (KeychainDetails, error) {
+	return NewKeychainDetails(
+		NewKeychain("enc addr robot", "encrypted_wallet", "id pub key", NewProposedKeyPair(NewSharedKeyPair("enc pv key", "pub key"), time.Time{}), "id sig", "em sig"),
+		newTestEndorsement(),
+		"sig"), nil
+}
+
+func (c mockClient) GetAccountProof(encHash string) (AccountProof, error) {
+	return NewAccountProof(
+		NewID("id hash", "enc addr robot", "enc addr id", "enc aes key", "id pub key", NewProposedKeyPair(NewSharedKeyPair("enc pv key", "pub key"), time.Time{}), "id sig", "em sig"),
+		NewTransactionProof(newTestEndorsement()),
+		NewKeychain("enc addr robot", "encrypted_wallet", "id pub key", NewProposedKeyPair(NewSharedKeyPair("enc pv key", "pub key"), time.Time{}), "id sig", "em sig"),
+		NewTransactionProof(newTestEndorsement())), nil
+}
+
+func (c mockClient) GetStoragePeers(addr string) ([]Peer, error) {
+	return []Peer{NewPeer("127.0.0.1", "peer pub key")}, nil
+}
+
+func newTestEndorsement() Endorsement {
+	return NewEndorsement("last tx hash", "tx hash",
+		NewMasterValidation([]string{"miner pub key"}, "pow key", NewValidation(ValidationOK, time.Now(), "master pub key", "master sig"), []string{"validator pub key"}),
+		[]Validation{NewValidation(ValidationOK, time.Now(), "validator pub key", "validator sig")})
+}
+
+//mockRotatedClient returns a shared emitter key rotated until the expiration
+type mockRotatedClient struct {
+	mockClient
+	expiration time.Time
+}
+
+func (c mockRotatedClient) GetSharedKeys() (SharedKeys, error) {
+	return NewSharedKeys(
+		"robot pub key",
+		[]RobotKeyPair{},
+		[]SharedKeyPair{NewSharedKeyPair("enc pv key", "pub key")},
+		[]PreviousSharedKeyPair{NewPreviousSharedKeyPair(NewSharedKeyPair("enc pv key", "previous pub key"), c.expiration)}), nil
+}
+
 type mockSigVerifier struct {
 	isInvalid bool
 }
 
-func (v mockSigVerifier) VerifyHashSignature(data string, pubKey string, sig string) error {
+//mockKeySigVerifier only accepts the account requests signed by the signer key
+type mockKeySigVerifier struct {
+	mockSigVerifier
+	signer string
+}
+
+func (v mockKeySigVerifier) VerifyAccountRequestSignature(encIDHash string, proof RequestProof, pubKey string) error {
+	if pubKey != v.signer {
+		return errors.New("Invalid signature")
+	}
+	return nil
+}
+
+func (v mockSigVerifier) VerifyAccountRequestSignature(encIDHash string, proof RequestProof, pubKey string) error {
+	if v.isInvalid {
+		return errors.New("Invalid signature")
+	}
+	return nil
+}
+
+func (v mockSigVerifier) VerifySharedKeysRequestSignature(emPubKey string, proof RequestProof) error {
 	if v.isInvalid {
 		return errors.New("Invalid signature")
 	}
@@ -117,3 +436,17 @@ func (v mockSigVerifier) VerifyAccountResultSignature(res AccountResult, pubKey
 	}
 	return nil
 }
+
+func (v mockSigVerifier) VerifyIDDetailsSignature(res IDDetails, pubKey string) error {
+	if v.isInvalid {
+		return errors.New("Invalid signature")
+	}
+	return nil
+}
+
+func (v mockSigVerifier) VerifyKeychainDetailsSignature(res KeychainDetails, pubKey string) error {
+	if v.isInvalid {
+		return errors.New("Invalid signature")
+	}
+	return nil
+}
@@ -1,33 +1,44 @@
 package listing
 
+import "time"
+
 //SharedKeys describes the shared keys
 type SharedKeys interface {
 
 	//RobotPublicKey returns the shared robot public key
 	RobotPublicKey() string
 
-	//RobotPrivateKey  returns the shared robot private key (used only on memory | no sent to the client)
-	RobotPrivateKey() string
+	//RobotKeyPairs returns all the versions of the shared robot keys from the latest to the oldest
+	RobotKeyPairs() []RobotKeyPair
+
+	//RobotPublicKeys returns the public keys of the activated shared robot key versions, the current one first
+	RobotPublicKeys() []string
 
 	//EmitterKeyPairs returns the list of shared emitter keys
 	EmitterKeyPairs() []SharedKeyPair
 
-	//RequestPublicKey returns the public key for emitter request
-	RequestPublicKey() string
+	//PreviousEmitterKeyPairs returns the list of the rotated shared emitter keys still accepted until their expiration
+	PreviousEmitterKeyPairs() []PreviousSharedKeyPair
+
+	//RequestPublicKeys returns the public keys accepted for the emitter requests,
+	//the current shared emitter keys first followed by the previous ones until their expiration
+	RequestPublicKeys() []string
 }
 
 type sharedKeys struct {
-	rPubKey string
-	rPvKey  string
-	emKP    []SharedKeyPair
+	rPubKey  string
+	rKP      []RobotKeyPair
+	emKP     []SharedKeyPair
+	prevEmKP []PreviousSharedKeyPair
 }
 
 //NewSharedKeys creates a new shared keys list
-func NewSharedKeys(rPv, rPub string, emKP []SharedKeyPair) SharedKeys {
+func NewSharedKeys(rPub string, rKP []RobotKeyPair, emKP []SharedKeyPair, prevEmKP []PreviousSharedKeyPair) SharedKeys {
 	return sharedKeys{
-		rPubKey: rPub,
-		rPvKey:  rPv,
-		emKP:    emKP,
+		rPubKey:  rPub,
+		rKP:      rKP,
+		emKP:     emKP,
+		prevEmKP: prevEmKP,
 	}
 }
 
@@ -35,16 +46,55 @@ func (sk sharedKeys) RobotPublicKey() string {
 	return sk.rPubKey
 }
 
-func (sk sharedKeys) RobotPrivateKey() string {
-	return sk.rPvKey
+func (sk sharedKeys) RobotKeyPairs() []RobotKeyPair {
+	return sk.rKP
+}
+
+func (sk sharedKeys) RobotPublicKeys() []string {
+	now := time.Now()
+	keys := []string{sk.rPubKey}
+	for _, kp := range sk.rKP {
+		if kp.PublicKey() != sk.rPubKey && !kp.ActivationDate().After(now) {
+			keys = append(keys, kp.PublicKey())
+		}
+	}
+	return keys
 }
 
 func (sk sharedKeys) EmitterKeyPairs() []SharedKeyPair {
 	return sk.emKP
 }
 
-func (sk sharedKeys) RequestPublicKey() string {
-	return sk.emKP[0].PublicKey()
+func (sk sharedKeys) PreviousEmitterKeyPairs() []PreviousSharedKeyPair {
+	return sk.prevEmKP
+}
+
+func (sk sharedKeys) RequestPublicKeys() []string {
+	now := time.Now()
+	keys := make([]string, 0)
+	for _, kp := range sk.emKP {
+		keys = append(keys, kp.PublicKey())
+	}
+	for _, kp := range sk.prevEmKP {
+		if kp.ExpirationDate().After(now) {
+			keys = append(keys, kp.PublicKey())
+		}
+	}
+	return keys
+}
+
+//VerifyEmitterRequest checks the signature of an emitter request with the accepted shared emitter keys,
+//as it can be signed by a previous key during the grace period of a rotation
+//
+//It returns the public key matching the signature
+func VerifyEmitterRequest(keys SharedKeys, verify func(pubKey string) error) (string, error) {
+	err := ErrUnauthorized
+	for _, pub := range keys.RequestPublicKeys() {
+		if err = verify(pub); err == nil {
+			return pub, nil
+		}
+	}
+	return "", err
 }
 
 //SharedKeyPair represent a shared keypair
@@ -73,3 +123,85 @@ func (kp sharedKeyPair) EncryptedPrivateKey() string {
 func (kp sharedKeyPair) PublicKey() string {
 	return kp.pubKey
 }
+
+//PreviousSharedKeyPair represents a rotated shared keypair accepted until its expiration date
+type PreviousSharedKeyPair interface {
+	SharedKeyPair
+	ExpirationDate() time.Time
+}
+
+type previousSharedKeyPair struct {
+	SharedKeyPair
+	expiration time.Time
+}
+
+//NewPreviousSharedKeyPair creates a new rotated shared keypair
+func NewPreviousSharedKeyPair(kp SharedKeyPair, expiration time.Time) PreviousSharedKeyPair {
+	return previousSharedKeyPair{
+		SharedKeyPair: kp,
+		expiration:    expiration,
+	}
+}
+
+func (kp previousSharedKeyPair) ExpirationDate() time.Time {
+	return kp.expiration
+}
+
+//ProposedKeyPair represents a shared emitter keypair proposed with a transaction, switched by all the nodes at its activation date
+type ProposedKeyPair interface {
+	SharedKeyPair
+	ActivationDate() time.Time
+}
+
+type proposedKeyPair struct {
+	SharedKeyPair
+	activation time.Time
+}
+
+//NewProposedKeyPair creates a new proposed shared emitter keypair
+func NewProposedKeyPair(kp SharedKeyPair, activation time.Time) ProposedKeyPair {
+	return proposedKeyPair{
+		SharedKeyPair: kp,
+		activation:    activation,
+	}
+}
+
+func (kp proposedKeyPair) ActivationDate() time.Time {
+	return kp.activation
+}
+
+//RobotKeyPair represents a version of the shared robot keypair
+type RobotKeyPair interface {
+	Version() int
+	PublicKey() string
+
+	//ActivationDate returns the time when the nodes switch to this version, zero when always activated
+	ActivationDate() time.Time
+}
+
+type robotKeyPair struct {
+	version    int
+	pubKey     string
+	activation time.Time
+}
+
+//NewRobotKeyPair creates a new version of the shared robot keypair
+func NewRobotKeyPair(version int, pub string, activation time.Time) RobotKeyPair {
+	return robotKeyPair{
+		version:    version,
+		pubKey:     pub,
+		activation: activation,
+	}
+}
+
+func (kp robotKeyPair) Version() int {
+	return kp.version
+}
+
+func (kp robotKeyPair) PublicKey() string {
+	return kp.pubKey
+}
+
+func (kp robotKeyPair) ActivationDate() time.Time {
+	return kp.activation
+}
@@ -32,3 +32,8 @@ func (s TransactionStatus) String() string {
 
 	return ""
 }
+
+//IsFinal checks if the transaction status will not change anymore
+func (s TransactionStatus) IsFinal() bool {
+	return s == TransactionSuccess || s == TransactionFailure
+}
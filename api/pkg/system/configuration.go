@@ -2,6 +2,7 @@ package system
 
 import (
 	"io/ioutil"
+	"time"
 
 	yaml "gopkg.in/yaml.v2"
 )
@@ -18,8 +19,28 @@ type ServicesConfiguration struct {
 }
 
 //APIConfiguration describes the api service configuration
+//
+//The trusted proxies are the CIDRs of the proxies allowed to forward the client IP in the X-Forwarded-For header
 type APIConfiguration struct {
-	Port int `yaml:"port"`
+	Port           int                    `yaml:"port"`
+	RateLimits     RateLimitConfiguration `yaml:"rateLimits"`
+	TrustedProxies []string               `yaml:"trustedProxies"`
+}
+
+//RateLimitConfiguration describes the limits of the requests by client IP and by emitter public key
+//
+//The endpoints are identified by their method and their route, as "POST /api/account" or "GET /api/account/:hash",
+//the other endpoints share the default limit
+type RateLimitConfiguration struct {
+	Default   RateLimit            `yaml:"default"`
+	Endpoints map[string]RateLimit `yaml:"endpoints"`
+}
+
+//RateLimit describes the number of requests accepted per period, with bursts up to the burst size
+type RateLimit struct {
+	Requests int           `yaml:"requests"`
+	Period   time.Duration `yaml:"period"`
+	Burst    int           `yaml:"burst"`
 }
 
 //DataMiningConfiguration describes the datamining configuration
@@ -0,0 +1,82 @@
+package rest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/uniris/uniris-core/api/pkg/webhook"
+)
+
+//ErrCallbackRejected is returned when the callback URL does not acknowledge the callback with a success status code
+var ErrCallbackRejected = errors.New("Callback rejected")
+
+//ErrNonPublicCallbackHost is returned when the callback host resolves to an address which is not public
+var ErrNonPublicCallbackHost = errors.New("Callback host is not public")
+
+type callbackSender struct {
+	client *http.Client
+}
+
+//NewCallbackSender creates a callback sender posting the callbacks as JSON, each delivery being bounded by the timeout
+//
+//The connections are only opened to public IPs, including after a redirection, and never through a proxy
+func NewCallbackSender(timeout time.Duration) webhook.Sender {
+	return callbackSender{
+		client: &http.Client{
+			Timeout: timeout,
+			Transport: &http.Transport{
+				DialContext: dialPublicHost,
+			},
+		},
+	}
+}
+
+//dialPublicHost resolves the host and connects to it only if its IPs are public
+func dialPublicHost(ctx context.Context, network, addr string) (net.Conn, error) {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, err
+	}
+	ips, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+	for _, ip := range ips {
+		if !webhook.IsPublicIP(ip.IP) {
+			return nil, ErrNonPublicCallbackHost
+		}
+	}
+
+	//The checked IP is dialed, so a second resolution cannot return another address
+	var d net.Dialer
+	return d.DialContext(ctx, network, net.JoinHostPort(ips[0].IP.String(), port))
+}
+
+func (s callbackSender) Send(url string, cb webhook.TransactionCallback) error {
+	body, err := json.Marshal(transactionCallback{
+		TransactionHash: cb.TransactionHash(),
+		TransactionType: cb.TransactionType(),
+		Status:          cb.Status().String(),
+		Timestamp:       cb.Timestamp().Unix(),
+		Signature:       cb.Signature(),
+	})
+	if err != nil {
+		return err
+	}
+
+	res, err := s.client.Post(url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return ErrCallbackRejected
+	}
+	return nil
+}
@@ -0,0 +1,32 @@
+package rest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/uniris/uniris-core/api/pkg/listing"
+	"github.com/uniris/uniris-core/api/pkg/webhook"
+)
+
+/*
+Scenario: Send a callback to a non public host
+	Given a callback URL resolving to the loopback
+	When I want to send a callback
+	Then the connection is refused and the callback URL is never called
+*/
+func TestSendCallbackToNonPublicHost(t *testing.T) {
+	called := false
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+	defer srv.Close()
+
+	err := NewCallbackSender(time.Second).Send(srv.URL, webhook.NewTransactionCallback("hash", webhook.IDTransaction, listing.TransactionSuccess, time.Now(), "sig"))
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), ErrNonPublicCallbackHost.Error())
+	assert.False(t, called)
+}
@@ -1,7 +1,12 @@
 package rest
 
 import (
+	"errors"
+	"io"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/uniris/uniris-core/api/pkg/crypto"
 
@@ -9,64 +14,133 @@ import (
 
 	"github.com/uniris/uniris-core/api/pkg/adding"
 	"github.com/uniris/uniris-core/api/pkg/listing"
+	"github.com/uniris/uniris-core/api/pkg/webhook"
+	"github.com/uniris/uniris-core/shared/pkg/errcode"
+)
+
+//ErrInvalidTimestamp is returned when the timestamp of a signed request is not a unix timestamp
+var ErrInvalidTimestamp = errors.New("Invalid timestamp")
+
+const (
+
+	//idempotencyKeyHeader is the header identifying the retries of an account creation
+	idempotencyKeyHeader = "Idempotency-Key"
+
+	//idempotentReplayedHeader is the header reporting a result returned from a previous request with the same idempotency key
+	idempotentReplayedHeader = "Idempotent-Replayed"
+
+	//errorTypeKey is the key of the context keeping the type of the error aborting a request, for the middlewares
+	errorTypeKey = "errorType"
 )
 
 //ErrorMessage define an HTTP error
+//
+//The type is the stable code of the error catalogue, the SDKs must rely on it rather than on the message
 type ErrorMessage struct {
-	Message string `json:"error_message"`
-	Code    int    `json:"error_code"`
+	Message string       `json:"error_message"`
+	Code    int          `json:"error_code"`
+	Type    errcode.Code `json:"error_type"`
 }
 
 //Handler manages http rest methods handling
-func Handler(r *gin.Engine, l listing.Service, a adding.Service) {
+func Handler(r *gin.Engine, l listing.Service, a adding.Service, w webhook.Service) {
 
 	api := r.Group("/api")
 	{
 		api.GET("/transaction/:addr/status/:hash", getTransactionStatus(l))
-		api.POST("/account", createAccount(a))
+		api.GET("/transaction/:addr/status/:hash/events", watchTransactionStatus(l))
+		api.POST("/account", createAccount(a, w))
+		api.POST("/accounts/batch", createAccounts(a, w))
 		api.HEAD("/account/:hash", checkAccount(l))
 		api.GET("/account/:hash", getAccount(l))
+		api.GET("/account/:hash/status", getAccountCreationStatus(l))
+		api.GET("/account/:hash/id", getIDDetails(l))
+		api.GET("/account/:hash/keychain", getKeychainDetails(l))
+		api.GET("/account/:hash/proof", getAccountProof(l))
+		api.PUT("/account/:hash/keychain", updateKeychain(a))
 		api.GET("/sharedkeys/:publicKey", getSharedKeys(l))
+		api.PUT("/webhook/:publicKey", registerWebhook(w))
 	}
 }
 
-func createAccount(a adding.Service) func(c *gin.Context) {
+func createAccount(a adding.Service, w webhook.Service) func(c *gin.Context) {
 	return func(c *gin.Context) {
 
 		var req *accountRequest
 
 		if err := c.ShouldBindJSON(&req); err != nil {
-			e := createError(http.StatusBadRequest, err)
+			e := createError(errcode.InvalidRequest, err)
 			c.JSON(e.Code, e)
 			return
 		}
 
-		res, err := a.AddAccount(adding.NewAccountCreationRequest(req.EncryptedID, req.EncryptedKeychain, req.Signature))
+		accReq := adding.NewAccountCreationRequest(req.EncryptedID, req.EncryptedKeychain, time.Unix(req.Timestamp, 0), req.Nonce, req.EmitterPublicKey, req.EmitterSignature, req.Signature)
+
+		var res adding.AccountCreationResult
+		var replayed bool
+		var err error
+		if key := c.GetHeader(idempotencyKeyHeader); key != "" {
+			res, replayed, err = a.AddIdempotentAccount(key, accReq)
+		} else {
+			res, err = a.AddAccount(accReq)
+		}
 		if err != nil {
-			if err == crypto.ErrInvalidSignature {
-				e := createError(http.StatusBadRequest, err)
-				c.JSON(e.Code, e)
-				return
-			}
-			e := createError(http.StatusInternalServerError, err)
+			e := createServiceError(err)
 			c.JSON(e.Code, e)
 			return
 		}
 
-		c.JSON(http.StatusCreated, accountCreationResult{
-			Signature: res.Signature(),
-			Transactions: accountCreationTransactionsResult{
-				ID: transactionResult{
-					MasterPeerIP:    res.ResultTransactions().ID().MasterPeerIP(),
-					Signature:       res.ResultTransactions().ID().Signature(),
-					TransactionHash: res.ResultTransactions().ID().TransactionHash(),
-				},
-				Keychain: transactionResult{
-					MasterPeerIP:    res.ResultTransactions().Keychain().MasterPeerIP(),
-					Signature:       res.ResultTransactions().Keychain().Signature(),
-					TransactionHash: res.ResultTransactions().Keychain().TransactionHash(),
-				},
-			},
+		//The callbacks of a replayed result are already delivered by the original request
+		if replayed {
+			c.Header(idempotentReplayedHeader, "true")
+		} else if req.EmitterPublicKey != "" {
+			w.WatchAccountCreation(req.EmitterPublicKey, res)
+		}
+
+		c.JSON(http.StatusCreated, formatAccountCreationResult(res))
+	}
+}
+
+func createAccounts(a adding.Service, w webhook.Service) func(c *gin.Context) {
+	return func(c *gin.Context) {
+
+		var req *accountCreationBatchRequest
+
+		if err := c.ShouldBindJSON(&req); err != nil {
+			e := createError(errcode.InvalidRequest, err)
+			c.JSON(e.Code, e)
+			return
+		}
+
+		reqs := make([]adding.AccountCreationRequest, 0)
+		for _, r := range req.Accounts {
+			reqs = append(reqs, adding.NewAccountCreationRequest(r.EncryptedID, r.EncryptedKeychain, time.Unix(r.Timestamp, 0), r.Nonce, r.EmitterPublicKey, r.EmitterSignature, r.Signature))
+		}
+
+		res, err := a.AddAccounts(reqs)
+		if err != nil {
+			e := createServiceError(err)
+			c.JSON(e.Code, e)
+			return
+		}
+
+		items := make([]accountCreationBatchItem, 0)
+		for i, r := range res {
+			if r.Err() != nil {
+				e := createServiceError(r.Err())
+				items = append(items, accountCreationBatchItem{Error: &e})
+				continue
+			}
+
+			if emPubKey := req.Accounts[i].EmitterPublicKey; emPubKey != "" {
+				w.WatchAccountCreation(emPubKey, r.Result())
+			}
+			result := formatAccountCreationResult(r.Result())
+			items = append(items, accountCreationBatchItem{Result: &result})
+		}
+
+		c.JSON(http.StatusOK, accountCreationBatchResult{
+			Results: items,
 		})
 	}
 }
@@ -74,20 +148,19 @@ func createAccount(a adding.Service) func(c *gin.Context) {
 func checkAccount(l listing.Service) func(c *gin.Context) {
 	return func(c *gin.Context) {
 		hash := c.Param("hash")
-		sig := c.Query("signature")
+		proof, err := requestProof(c)
+		if err != nil {
+			abortHead(c, createError(errcode.InvalidRequest, err))
+			return
+		}
 
-		err := l.ExistAccount(hash, sig)
+		err = l.ExistAccount(hash, proof)
 		if err != nil {
-			if err == crypto.ErrInvalidSignature {
-				c.Header("Error", err.Error())
-				return
-			}
 			if err == listing.ErrAccountNotExist {
 				c.Header("Account-Exist", "false")
 				return
 			}
-			e := createError(http.StatusInternalServerError, err)
-			c.JSON(e.Code, e)
+			abortHead(c, createServiceError(err))
 			return
 		}
 
@@ -95,67 +168,195 @@ func checkAccount(l listing.Service) func(c *gin.Context) {
 	}
 }
 
+//abortRequest reports an error from a middleware in the format of the route version
+func abortRequest(c *gin.Context, e ErrorMessage) {
+	c.Set(errorTypeKey, e.Type)
+	switch {
+	case c.Request.Method == http.MethodHead:
+		abortHead(c, e)
+	case strings.HasPrefix(c.Request.URL.Path, "/api/v2/"):
+		c.AbortWithStatusJSON(e.Code, envelope{Error: &e})
+	default:
+		c.AbortWithStatusJSON(e.Code, e)
+	}
+}
+
+//abortHead reports an error without body, as the responses of the HEAD requests
+func abortHead(c *gin.Context, e ErrorMessage) {
+	c.Header("Error", e.Message)
+	c.Header("Error-Type", string(e.Type))
+	c.AbortWithStatus(e.Code)
+}
+
 func getAccount(l listing.Service) func(c *gin.Context) {
 	return func(c *gin.Context) {
 
 		hash := c.Param("hash")
-		sig := c.Query("signature")
+		proof, err := requestProof(c)
+		if err != nil {
+			e := createError(errcode.InvalidRequest, err)
+			c.JSON(e.Code, e)
+			return
+		}
 
-		res, err := l.GetAccount(hash, sig)
+		res, err := l.GetAccount(hash, proof)
 		if err != nil {
-			if err == crypto.ErrInvalidSignature {
-				e := createError(http.StatusBadRequest, err)
-				c.JSON(e.Code, e)
-				return
-			}
-			if err == listing.ErrAccountNotExist {
-				e := createError(http.StatusNotFound, err)
-				c.JSON(e.Code, e)
-				return
-			}
-			e := createError(http.StatusInternalServerError, err)
+			e := createServiceError(err)
 			c.JSON(e.Code, e)
 			return
 		}
 
-		c.JSON(http.StatusOK, accountResult{
-			EncryptedAddress: res.EncryptedAddress(),
-			EncryptedAESKey:  res.EncryptedAESKey(),
-			EncryptedWallet:  res.EncryptedWallet(),
-			Signature:        res.Signature(),
+		c.JSON(http.StatusOK, formatAccountResult(res))
+	}
+}
+
+func updateKeychain(a adding.Service) func(c *gin.Context) {
+	return func(c *gin.Context) {
+
+		var req *keychainUpdateRequest
+
+		if err := c.ShouldBindJSON(&req); err != nil {
+			e := createError(errcode.InvalidRequest, err)
+			c.JSON(e.Code, e)
+			return
+		}
+
+		res, err := a.UpdateKeychain(adding.NewKeychainUpdateRequest(c.Param("hash"), req.EncryptedKeychain, time.Unix(req.Timestamp, 0), req.Nonce, req.Signature))
+		if err != nil {
+			e := createServiceError(err)
+			c.JSON(e.Code, e)
+			return
+		}
+
+		c.JSON(http.StatusCreated, transactionResult{
+			MasterPeerIP:    res.MasterPeerIP(),
+			Signature:       res.Signature(),
+			TransactionHash: res.TransactionHash(),
 		})
 	}
 }
 
+func getIDDetails(l listing.Service) func(c *gin.Context) {
+	return func(c *gin.Context) {
+
+		hash := c.Param("hash")
+		proof, err := requestProof(c)
+		if err != nil {
+			e := createError(errcode.InvalidRequest, err)
+			c.JSON(e.Code, e)
+			return
+		}
+
+		res, err := l.GetIDDetails(hash, proof)
+		if err != nil {
+			e := createServiceError(err)
+			c.JSON(e.Code, e)
+			return
+		}
+
+		c.JSON(http.StatusOK, formatIDDetails(res))
+	}
+}
+
+func getKeychainDetails(l listing.Service) func(c *gin.Context) {
+	return func(c *gin.Context) {
+
+		hash := c.Param("hash")
+		proof, err := requestProof(c)
+		if err != nil {
+			e := createError(errcode.InvalidRequest, err)
+			c.JSON(e.Code, e)
+			return
+		}
+
+		res, err := l.GetKeychainDetails(hash, proof)
+		if err != nil {
+			e := createServiceError(err)
+			c.JSON(e.Code, e)
+			return
+		}
+
+		c.JSON(http.StatusOK, formatKeychainDetails(res))
+	}
+}
+
+func getAccountProof(l listing.Service) func(c *gin.Context) {
+	return func(c *gin.Context) {
+
+		hash := c.Param("hash")
+		proof, err := requestProof(c)
+		if err != nil {
+			e := createError(errcode.InvalidRequest, err)
+			c.JSON(e.Code, e)
+			return
+		}
+
+		res, err := l.GetAccountProof(hash, proof)
+		if err != nil {
+			e := createServiceError(err)
+			c.JSON(e.Code, e)
+			return
+		}
+
+		c.JSON(http.StatusOK, formatAccountProof(res))
+	}
+}
+
+func getAccountCreationStatus(l listing.Service) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		state, err := l.GetAccountCreationStatus(c.Param("hash"))
+		if err != nil {
+			e := createServiceError(err)
+			c.JSON(e.Code, e)
+			return
+		}
+
+		c.JSON(http.StatusOK, formatAccountCreationStatus(state))
+	}
+}
+
 func getSharedKeys(l listing.Service) func(c *gin.Context) {
 	return func(c *gin.Context) {
 
 		emPublicKey := c.Param("publicKey")
-		sig := c.Query("signature")
+		proof, err := requestProof(c)
+		if err != nil {
+			e := createError(errcode.InvalidRequest, err)
+			c.JSON(e.Code, e)
+			return
+		}
 
-		keys, err := l.GetSharedKeys(emPublicKey, sig)
+		keys, err := l.GetSharedKeys(emPublicKey, proof)
 		if err != nil {
-			if err == listing.ErrUnauthorized {
-				e := createError(http.StatusUnauthorized, err)
-				c.JSON(e.Code, e)
-				return
-			}
-			e := createError(http.StatusInternalServerError, err)
+			e := createServiceError(err)
 			c.JSON(e.Code, e)
 			return
 		}
 
-		sharedEms := make([]sharedEmitterKeys, 0)
-		for _, kp := range keys.EmitterKeyPairs() {
-			sharedEms = append(sharedEms, sharedEmitterKeys{
-				PublicKey:           kp.PublicKey(),
-				EncryptedPrivateKey: kp.EncryptedPrivateKey(),
-			})
+		c.JSON(http.StatusOK, formatSharedKeys(keys))
+	}
+}
+
+func registerWebhook(w webhook.Service) func(c *gin.Context) {
+	return func(c *gin.Context) {
+
+		var req *webhookRequest
+
+		if err := c.ShouldBindJSON(&req); err != nil {
+			e := createError(errcode.InvalidRequest, err)
+			c.JSON(e.Code, e)
+			return
+		}
+
+		reg := webhook.NewRegistration(c.Param("publicKey"), req.CallbackURL, time.Unix(req.Timestamp, 0), req.Nonce, req.Signature)
+		if err := w.RegisterCallback(reg); err != nil {
+			e := createServiceError(err)
+			c.JSON(e.Code, e)
+			return
 		}
 
-		c.JSON(http.StatusOK, sharedKeys{
-			SharedEmitterKeys:    sharedEms,
-			SharedRobotPublicKey: keys.RobotPublicKey(),
+		c.JSON(http.StatusOK, webhookResult{
+			CallbackURL: reg.CallbackURL(),
 		})
 	}
 }
@@ -167,7 +368,7 @@ func getTransactionStatus(l listing.Service) func(c *gin.Context) {
 
 		status, err := l.GetTransactionStatus(addr, txHash)
 		if err != nil {
-			e := createError(http.StatusInternalServerError, err)
+			e := createServiceError(err)
 			c.JSON(e.Code, e)
 			return
 		}
@@ -180,9 +381,268 @@ func getTransactionStatus(l listing.Service) func(c *gin.Context) {
 	}
 }
 
-func createError(handleErrorCode int, handleErr error) ErrorMessage {
+func watchTransactionStatus(l listing.Service) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		addr := c.Param("addr")
+		txHash := c.Param("hash")
+
+		statuses, err := l.WatchTransactionStatus(c.Request.Context(), addr, txHash)
+		if err != nil {
+			e := createServiceError(err)
+			c.JSON(e.Code, e)
+			return
+		}
+
+		//Pushes each status transition as a Server-Sent Event until the final status
+		c.Stream(func(w io.Writer) bool {
+			status, open := <-statuses
+			if !open {
+				return false
+			}
+			c.SSEvent("status", struct {
+				Status string `json:"status"`
+			}{
+				Status: status.String(),
+			})
+			return !status.IsFinal()
+		})
+	}
+}
+
+//requestProof reads the emitter public key, the freshness data and the signature of a signed request from the query parameters
+func requestProof(c *gin.Context) (listing.RequestProof, error) {
+	timestamp, err := strconv.ParseInt(c.Query("timestamp"), 10, 64)
+	if err != nil {
+		return nil, ErrInvalidTimestamp
+	}
+	return listing.NewRequestProof(queryEmitterKey(c), time.Unix(timestamp, 0), c.Query("nonce"), c.Query("signature")), nil
+}
+
+//queryEmitterKey returns the emitter public key of the route, or of the query parameters of the signed reads
+func queryEmitterKey(c *gin.Context) string {
+	if key := c.Param("publicKey"); key != "" {
+		return key
+	}
+	return c.Query("emitter_public_key")
+}
+
+//serviceErrorCodes identifies the errors of the services in the error catalogue
+var serviceErrorCodes = map[error]errcode.Code{
+	ErrInvalidTimestamp:                 errcode.InvalidRequest,
+	crypto.ErrInvalidSignature:          errcode.InvalidSignature,
+	listing.ErrExpiredRequest:           errcode.ExpiredRequest,
+	listing.ErrReplayedRequest:          errcode.ReplayedRequest,
+	listing.ErrUnauthorized:             errcode.Unauthorized,
+	listing.ErrAccountNotExist:          errcode.AccountNotExist,
+	adding.ErrKeychainNotOwned:          errcode.KeychainNotOwned,
+	adding.ErrRequestInProgress:         errcode.RequestInProgress,
+	adding.ErrIdempotencyKeyReused:      errcode.IdempotencyKeyReused,
+	adding.ErrIdempotencyWithoutEmitter: errcode.InvalidRequest,
+	adding.ErrEmptyBatch:                errcode.InvalidRequest,
+	adding.ErrBatchTooLarge:             errcode.InvalidRequest,
+	webhook.ErrInvalidCallbackURL:       errcode.InvalidRequest,
+}
+
+//createServiceError maps the errors returned by the services with the error catalogue
+//
+//The errors of the catalogue returned by the datamining service keep their code
+func createServiceError(err error) ErrorMessage {
+	if code, exist := serviceErrorCodes[err]; exist {
+		return createError(code, err)
+	}
+	if e, ok := err.(errcode.Error); ok {
+		return createError(e.Code, err)
+	}
+	return createError(errcode.Internal, err)
+}
+
+func formatProposal(kp listing.ProposedKeyPair) keyPairProposal {
+	p := keyPairProposal{
+		EncryptedPrivateKey: kp.EncryptedPrivateKey(),
+		PublicKey:           kp.PublicKey(),
+	}
+	if a := kp.ActivationDate(); a.Unix() > 0 {
+		p.ActivationDate = a.Unix()
+	}
+	return p
+}
+
+func formatValidation(v listing.Validation) validation {
+	return validation{
+		Status:    v.Status().String(),
+		Timestamp: v.Timestamp().Unix(),
+		PublicKey: v.PublicKey(),
+		Signature: v.Signature(),
+	}
+}
+
+func formatEndorsement(end listing.Endorsement) endorsement {
+	valids := make([]validation, 0)
+	for _, v := range end.Validations() {
+		valids = append(valids, formatValidation(v))
+	}
+
+	//The first transaction of a chain has no previous miners, the lists are sent empty rather than null
+	lastTxMiners := append(make([]string, 0), end.MasterValidation().LastTransactionMiners()...)
+	vPool := append(make([]string, 0), end.MasterValidation().ValidationPool()...)
+
+	return endorsement{
+		LastTransactionHash: end.LastTransactionHash(),
+		TransactionHash:     end.TransactionHash(),
+		MasterValidation: masterValidation{
+			LastTransactionMiners: lastTxMiners,
+			ProofOfWorkKey:        end.MasterValidation().ProofOfWorkKey(),
+			ProofOfWorkValidation: formatValidation(end.MasterValidation().ProofOfWorkValidation()),
+			ValidationPool:        vPool,
+		},
+		Validations: valids,
+	}
+}
+
+func formatAccountCreationResult(res adding.AccountCreationResult) accountCreationResult {
+	return accountCreationResult{
+		Signature: res.Signature(),
+		Transactions: accountCreationTransactionsResult{
+			ID: transactionResult{
+				MasterPeerIP:    res.ResultTransactions().ID().MasterPeerIP(),
+				Signature:       res.ResultTransactions().ID().Signature(),
+				TransactionHash: res.ResultTransactions().ID().TransactionHash(),
+			},
+			Keychain: transactionResult{
+				MasterPeerIP:    res.ResultTransactions().Keychain().MasterPeerIP(),
+				Signature:       res.ResultTransactions().Keychain().Signature(),
+				TransactionHash: res.ResultTransactions().Keychain().TransactionHash(),
+			},
+		},
+	}
+}
+
+func formatTransactionProof(p listing.TransactionProof) transactionProof {
+	return transactionProof{
+		Endorsement: formatEndorsement(p.Endorsement()),
+	}
+}
+
+func formatAccountResult(res listing.AccountResult) accountResult {
+	return accountResult{
+		EncryptedAddress: res.EncryptedAddress(),
+		EncryptedAESKey:  res.EncryptedAESKey(),
+		EncryptedWallet:  res.EncryptedWallet(),
+		Signature:        res.Signature(),
+	}
+}
+
+func formatAccountCreationStatus(state listing.AccountCreationState) accountCreationStatus {
+	return accountCreationStatus{
+		Status:         state.Status().String(),
+		IDStatus:       state.IDStatus().String(),
+		KeychainStatus: state.KeychainStatus().String(),
+		KeychainHash:   state.KeychainTransactionHash(),
+	}
+}
+
+func formatIDDetails(res listing.IDDetails) idDetails {
+	id := res.ID()
+	return idDetails{
+		Hash:                 id.Hash(),
+		EncryptedAddrByRobot: id.EncryptedAddrByRobot(),
+		EncryptedAddrByID:    id.EncryptedAddrByID(),
+		EncryptedAESKey:      id.EncryptedAESKey(),
+		PublicKey:            id.PublicKey(),
+		Proposal:             formatProposal(id.Proposal()),
+		IDSignature:          id.IDSignature(),
+		EmitterSignature:     id.EmitterSignature(),
+		Endorsement:          formatEndorsement(res.Endorsement()),
+		Signature:            res.Signature(),
+	}
+}
+
+func formatKeychainDetails(res listing.KeychainDetails) keychainDetails {
+	kc := res.Keychain()
+	return keychainDetails{
+		EncryptedAddrByRobot: kc.EncryptedAddrByRobot(),
+		EncryptedWallet:      kc.EncryptedWallet(),
+		IDPublicKey:          kc.IDPublicKey(),
+		Proposal:             formatProposal(kc.Proposal()),
+		IDSignature:          kc.IDSignature(),
+		EmitterSignature:     kc.EmitterSignature(),
+		Endorsement:          formatEndorsement(res.Endorsement()),
+		Signature:            res.Signature(),
+	}
+}
+
+func formatAccountProof(res listing.AccountProof) accountProof {
+	id := res.ID()
+	kc := res.Keychain()
+	return accountProof{
+		ID: storedID{
+			Hash:                 id.Hash(),
+			EncryptedAddrByRobot: id.EncryptedAddrByRobot(),
+			EncryptedAddrByID:    id.EncryptedAddrByID(),
+			EncryptedAESKey:      id.EncryptedAESKey(),
+			PublicKey:            id.PublicKey(),
+			Proposal:             formatProposal(id.Proposal()),
+			IDSignature:          id.IDSignature(),
+			EmitterSignature:     id.EmitterSignature(),
+		},
+		IDProof: formatTransactionProof(res.IDProof()),
+		Keychain: storedKeychain{
+			EncryptedAddrByRobot: kc.EncryptedAddrByRobot(),
+			EncryptedWallet:      kc.EncryptedWallet(),
+			IDPublicKey:          kc.IDPublicKey(),
+			Proposal:             formatProposal(kc.Proposal()),
+			IDSignature:          kc.IDSignature(),
+			EmitterSignature:     kc.EmitterSignature(),
+		},
+		KeychainProof: formatTransactionProof(res.KeychainProof()),
+	}
+}
+
+func formatSharedKeys(keys listing.SharedKeys) sharedKeys {
+	sharedEms := make([]sharedEmitterKeys, 0)
+	for _, kp := range keys.EmitterKeyPairs() {
+		sharedEms = append(sharedEms, sharedEmitterKeys{
+			PublicKey:           kp.PublicKey(),
+			EncryptedPrivateKey: kp.EncryptedPrivateKey(),
+		})
+	}
+
+	prevSharedEms := make([]previousSharedEmitterKeys, 0)
+	for _, kp := range keys.PreviousEmitterKeyPairs() {
+		prevSharedEms = append(prevSharedEms, previousSharedEmitterKeys{
+			PublicKey:           kp.PublicKey(),
+			EncryptedPrivateKey: kp.EncryptedPrivateKey(),
+			ExpirationDate:      kp.ExpirationDate().Unix(),
+		})
+	}
+
+	//Publish the robot key versions to let the clients switch at the activation date
+	robotKeys := make([]sharedRobotKey, 0)
+	for _, kp := range keys.RobotKeyPairs() {
+		rk := sharedRobotKey{
+			Version:   kp.Version(),
+			PublicKey: kp.PublicKey(),
+		}
+
+		//The version without activation date is always activated
+		if !kp.ActivationDate().IsZero() {
+			rk.ActivationDate = kp.ActivationDate().Unix()
+		}
+		robotKeys = append(robotKeys, rk)
+	}
+
+	return sharedKeys{
+		SharedEmitterKeys:         sharedEms,
+		PreviousSharedEmitterKeys: prevSharedEms,
+		SharedRobotPublicKey:      keys.RobotPublicKey(),
+		SharedRobotKeys:           robotKeys,
+	}
+}
+
+func createError(code errcode.Code, handleErr error) ErrorMessage {
 	return ErrorMessage{
 		Message: handleErr.Error(),
-		Code:    http.StatusInternalServerError,
+		Code:    code.HTTPStatus(),
+		Type:    code,
 	}
 }
@@ -0,0 +1,422 @@
+package rest
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/uniris/uniris-core/api/pkg/adding"
+	"github.com/uniris/uniris-core/api/pkg/listing"
+	"github.com/uniris/uniris-core/api/pkg/webhook"
+	"github.com/uniris/uniris-core/shared/pkg/errcode"
+)
+
+//ErrInvalidPagination is returned when the offset or the limit of a list request is not a positive integer
+var ErrInvalidPagination = errors.New("Invalid pagination")
+
+//ErrMissingAddress is returned when a request on a transaction or on peers does not define the address
+var ErrMissingAddress = errors.New("Missing address")
+
+const (
+
+	//defaultPageLimit is the number of items returned by the list endpoints without limit
+	defaultPageLimit = 20
+
+	//maxPageLimit is the maximum number of items returned by the list endpoints
+	maxPageLimit = 100
+)
+
+//go:generate go run ../../../cmd/swaggergen -spec ../../../api/swagger-spec/swagger.yaml -version v2 -out routesV2_gen.go
+
+//HandlerV2 manages the http rest methods of the version 2 of the API
+//
+//The routes are generated from the /v2 section of the swagger specification:
+//the resources are identified by their path and every response is wrapped into an envelope.
+//The version 1 routes remain served by Handler for the existing SDKs
+func HandlerV2(r *gin.Engine, l listing.Service, a adding.Service, w webhook.Service) {
+	registerV2(r, handlerV2{
+		lister:  l,
+		adder:   a,
+		webhook: w,
+	})
+}
+
+type handlerV2 struct {
+	lister  listing.Service
+	adder   adding.Service
+	webhook webhook.Service
+}
+
+//envelope wraps the responses of the version 2 of the API
+//
+//A response contains either the data or the error, the lists add their pagination
+type envelope struct {
+	Data       interface{}   `json:"data,omitempty"`
+	Pagination *pagination   `json:"pagination,omitempty"`
+	Error      *ErrorMessage `json:"error,omitempty"`
+}
+
+type pagination struct {
+	Offset int `json:"offset"`
+	Limit  int `json:"limit"`
+	Total  int `json:"total"`
+}
+
+func respond(c *gin.Context, status int, data interface{}) {
+	c.JSON(status, envelope{Data: data})
+}
+
+func respondError(c *gin.Context, e ErrorMessage) {
+	c.JSON(e.Code, envelope{Error: &e})
+}
+
+//respondPage sends the page of a list identified by the offset and the limit of the request
+func respondPage(c *gin.Context, items []interface{}) {
+	offset, limit, err := pageRequest(c)
+	if err != nil {
+		respondError(c, createError(errcode.InvalidRequest, err))
+		return
+	}
+
+	start := offset
+	if start > len(items) {
+		start = len(items)
+	}
+	end := start + limit
+	if end > len(items) {
+		end = len(items)
+	}
+
+	c.JSON(http.StatusOK, envelope{
+		Data: items[start:end],
+		Pagination: &pagination{
+			Offset: offset,
+			Limit:  limit,
+			Total:  len(items),
+		},
+	})
+}
+
+//respondSinglePage sends a whole list created by a request, as a batch, in a single page
+//
+//The batches are not larger than a page, so the list is not cut
+func respondSinglePage(c *gin.Context, status int, items []interface{}) {
+	c.JSON(status, envelope{
+		Data: items,
+		Pagination: &pagination{
+			Offset: 0,
+			Limit:  len(items),
+			Total:  len(items),
+		},
+	})
+}
+
+//pageRequest reads the offset and the limit of a list request from the query parameters
+func pageRequest(c *gin.Context) (offset int, limit int, err error) {
+	offset, err = strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if err != nil || offset < 0 {
+		return 0, 0, ErrInvalidPagination
+	}
+	limit, err = strconv.Atoi(c.DefaultQuery("limit", strconv.Itoa(defaultPageLimit)))
+	if err != nil || limit <= 0 {
+		return 0, 0, ErrInvalidPagination
+	}
+	if limit > maxPageLimit {
+		limit = maxPageLimit
+	}
+	return offset, limit, nil
+}
+
+func (h handlerV2) createAccountV2(c *gin.Context) {
+
+	var req *accountRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, createError(errcode.InvalidRequest, err))
+		return
+	}
+
+	accReq := adding.NewAccountCreationRequest(req.EncryptedID, req.EncryptedKeychain, time.Unix(req.Timestamp, 0), req.Nonce, req.EmitterPublicKey, req.EmitterSignature, req.Signature)
+
+	var res adding.AccountCreationResult
+	var replayed bool
+	var err error
+	if key := c.GetHeader(idempotencyKeyHeader); key != "" {
+		res, replayed, err = h.adder.AddIdempotentAccount(key, accReq)
+	} else {
+		res, err = h.adder.AddAccount(accReq)
+	}
+	if err != nil {
+		respondError(c, createServiceError(err))
+		return
+	}
+
+	if replayed {
+		c.Header(idempotentReplayedHeader, "true")
+	} else if req.EmitterPublicKey != "" {
+		h.webhook.WatchAccountCreation(req.EmitterPublicKey, res)
+	}
+
+	respond(c, http.StatusCreated, formatAccountCreationResult(res))
+}
+
+func (h handlerV2) createAccountsV2(c *gin.Context) {
+
+	var req *accountCreationBatchRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, createError(errcode.InvalidRequest, err))
+		return
+	}
+
+	reqs := make([]adding.AccountCreationRequest, 0)
+	for _, r := range req.Accounts {
+		reqs = append(reqs, adding.NewAccountCreationRequest(r.EncryptedID, r.EncryptedKeychain, time.Unix(r.Timestamp, 0), r.Nonce, r.EmitterPublicKey, r.EmitterSignature, r.Signature))
+	}
+
+	res, err := h.adder.AddAccounts(reqs)
+	if err != nil {
+		respondError(c, createServiceError(err))
+		return
+	}
+
+	//Each item is an envelope, as the items fail on their own
+	items := make([]interface{}, 0)
+	for i, r := range res {
+		if r.Err() != nil {
+			e := createServiceError(r.Err())
+			items = append(items, envelope{Error: &e})
+			continue
+		}
+
+		if emPubKey := req.Accounts[i].EmitterPublicKey; emPubKey != "" {
+			h.webhook.WatchAccountCreation(emPubKey, r.Result())
+		}
+		items = append(items, envelope{Data: formatAccountCreationResult(r.Result())})
+	}
+
+	respondSinglePage(c, http.StatusOK, items)
+}
+
+func (h handlerV2) existAccountV2(c *gin.Context) {
+	proof, err := requestProof(c)
+	if err != nil {
+		abortHead(c, createError(errcode.InvalidRequest, err))
+		return
+	}
+
+	//The missing accounts are reported as missing resources
+	if err := h.lister.ExistAccount(c.Param("hash"), proof); err != nil {
+		abortHead(c, createServiceError(err))
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+func (h handlerV2) getAccountV2(c *gin.Context) {
+	proof, err := requestProof(c)
+	if err != nil {
+		respondError(c, createError(errcode.InvalidRequest, err))
+		return
+	}
+
+	res, err := h.lister.GetAccount(c.Param("hash"), proof)
+	if err != nil {
+		respondError(c, createServiceError(err))
+		return
+	}
+
+	respond(c, http.StatusOK, formatAccountResult(res))
+}
+
+func (h handlerV2) getAccountProofV2(c *gin.Context) {
+	proof, err := requestProof(c)
+	if err != nil {
+		respondError(c, createError(errcode.InvalidRequest, err))
+		return
+	}
+
+	res, err := h.lister.GetAccountProof(c.Param("hash"), proof)
+	if err != nil {
+		respondError(c, createServiceError(err))
+		return
+	}
+
+	respond(c, http.StatusOK, formatAccountProof(res))
+}
+
+func (h handlerV2) getAccountCreationStatusV2(c *gin.Context) {
+	state, err := h.lister.GetAccountCreationStatus(c.Param("hash"))
+	if err != nil {
+		respondError(c, createServiceError(err))
+		return
+	}
+
+	respond(c, http.StatusOK, formatAccountCreationStatus(state))
+}
+
+func (h handlerV2) getIDV2(c *gin.Context) {
+	proof, err := requestProof(c)
+	if err != nil {
+		respondError(c, createError(errcode.InvalidRequest, err))
+		return
+	}
+
+	res, err := h.lister.GetIDDetails(c.Param("hash"), proof)
+	if err != nil {
+		respondError(c, createServiceError(err))
+		return
+	}
+
+	respond(c, http.StatusOK, formatIDDetails(res))
+}
+
+func (h handlerV2) getKeychainV2(c *gin.Context) {
+	proof, err := requestProof(c)
+	if err != nil {
+		respondError(c, createError(errcode.InvalidRequest, err))
+		return
+	}
+
+	res, err := h.lister.GetKeychainDetails(c.Param("hash"), proof)
+	if err != nil {
+		respondError(c, createServiceError(err))
+		return
+	}
+
+	respond(c, http.StatusOK, formatKeychainDetails(res))
+}
+
+func (h handlerV2) updateKeychainV2(c *gin.Context) {
+
+	var req *keychainUpdateRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, createError(errcode.InvalidRequest, err))
+		return
+	}
+
+	res, err := h.adder.UpdateKeychain(adding.NewKeychainUpdateRequest(c.Param("hash"), req.EncryptedKeychain, time.Unix(req.Timestamp, 0), req.Nonce, req.Signature))
+	if err != nil {
+		respondError(c, createServiceError(err))
+		return
+	}
+
+	respond(c, http.StatusCreated, transactionResult{
+		MasterPeerIP:    res.MasterPeerIP(),
+		Signature:       res.Signature(),
+		TransactionHash: res.TransactionHash(),
+	})
+}
+
+func (h handlerV2) getTransactionV2(c *gin.Context) {
+	addr := c.Query("address")
+	if addr == "" {
+		respondError(c, createError(errcode.InvalidRequest, ErrMissingAddress))
+		return
+	}
+
+	status, err := h.lister.GetTransactionStatus(addr, c.Param("hash"))
+	if err != nil {
+		respondError(c, createServiceError(err))
+		return
+	}
+
+	respond(c, http.StatusOK, transactionStatus{
+		TransactionHash: c.Param("hash"),
+		Status:          status.String(),
+	})
+}
+
+func (h handlerV2) watchTransactionV2(c *gin.Context) {
+	addr := c.Query("address")
+	if addr == "" {
+		respondError(c, createError(errcode.InvalidRequest, ErrMissingAddress))
+		return
+	}
+
+	txHash := c.Param("hash")
+	statuses, err := h.lister.WatchTransactionStatus(c.Request.Context(), addr, txHash)
+	if err != nil {
+		respondError(c, createServiceError(err))
+		return
+	}
+
+	c.Stream(func(w io.Writer) bool {
+		status, open := <-statuses
+		if !open {
+			return false
+		}
+		c.SSEvent("status", envelope{
+			Data: transactionStatus{
+				TransactionHash: txHash,
+				Status:          status.String(),
+			},
+		})
+		return !status.IsFinal()
+	})
+}
+
+func (h handlerV2) getSharedKeysV2(c *gin.Context) {
+	proof, err := requestProof(c)
+	if err != nil {
+		respondError(c, createError(errcode.InvalidRequest, err))
+		return
+	}
+
+	keys, err := h.lister.GetSharedKeys(c.Param("publicKey"), proof)
+	if err != nil {
+		respondError(c, createServiceError(err))
+		return
+	}
+
+	respond(c, http.StatusOK, formatSharedKeys(keys))
+}
+
+func (h handlerV2) listPeersV2(c *gin.Context) {
+	addr := c.Query("address")
+	if addr == "" {
+		respondError(c, createError(errcode.InvalidRequest, ErrMissingAddress))
+		return
+	}
+
+	peers, err := h.lister.GetStoragePeers(addr)
+	if err != nil {
+		respondError(c, createServiceError(err))
+		return
+	}
+
+	items := make([]interface{}, 0)
+	for _, p := range peers {
+		items = append(items, peer{
+			IP:        p.IP(),
+			PublicKey: p.PublicKey(),
+		})
+	}
+	respondPage(c, items)
+}
+
+func (h handlerV2) registerWebhookV2(c *gin.Context) {
+
+	var req *webhookRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, createError(errcode.InvalidRequest, err))
+		return
+	}
+
+	reg := webhook.NewRegistration(c.Param("publicKey"), req.CallbackURL, time.Unix(req.Timestamp, 0), req.Nonce, req.Signature)
+	if err := h.webhook.RegisterCallback(reg); err != nil {
+		respondError(c, createServiceError(err))
+		return
+	}
+
+	respond(c, http.StatusOK, webhookResult{
+		CallbackURL: reg.CallbackURL(),
+	})
+}
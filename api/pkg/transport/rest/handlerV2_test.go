@@ -0,0 +1,87 @@
+package rest
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/uniris/uniris-core/api/pkg/transport/rest/swaggergen"
+)
+
+/*
+Scenario: Compare the version 2 routes with the specification
+	Given the generated version 2 routes and the swagger specification
+	When I generate the routes again from the specification
+	Then the generated routes are up to date
+*/
+func TestHandlerV2FollowsSpecification(t *testing.T) {
+	spec, err := ioutil.ReadFile("../../../api/swagger-spec/swagger.yaml")
+	assert.Nil(t, err)
+	src, err := swaggergen.Generate(spec, "v2")
+	assert.Nil(t, err)
+
+	generated, err := ioutil.ReadFile("routesV2_gen.go")
+	assert.Nil(t, err)
+	assert.Equal(t, string(src), string(generated), "routesV2_gen.go is outdated, run go generate")
+}
+
+/*
+Scenario: Get a page of a list
+	Given a list of 30 items
+	When I request the list with an offset and a limit
+	Then I get the items of the page with the pagination of the list
+*/
+func TestRespondPage(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	items := make([]interface{}, 0)
+	for i := 0; i < 30; i++ {
+		items = append(items, i)
+	}
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/?offset=25&limit=10", nil)
+	respondPage(c, items)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var res struct {
+		Data       []int      `json:"data"`
+		Pagination pagination `json:"pagination"`
+	}
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &res))
+	assert.Equal(t, []int{25, 26, 27, 28, 29}, res.Data)
+	assert.Equal(t, pagination{Offset: 25, Limit: 10, Total: 30}, res.Pagination)
+
+	w = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/?limit=500", nil)
+	respondPage(c, items)
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &res))
+	assert.Len(t, res.Data, 30)
+	assert.Equal(t, maxPageLimit, res.Pagination.Limit)
+}
+
+/*
+Scenario: Get a page with an invalid pagination
+	Given a list
+	When I request the list with a negative offset
+	Then I get an invalid request error in the envelope
+*/
+func TestRespondPageInvalidPagination(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/?offset=-1", nil)
+	respondPage(c, []interface{}{})
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	var res envelope
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &res))
+	assert.Nil(t, res.Data)
+	assert.Equal(t, ErrInvalidPagination.Error(), res.Error.Message)
+}
@@ -3,6 +3,21 @@ package rest
 type accountRequest struct {
 	EncryptedID       string `json:"encrypted_id" binding:"required"`
 	EncryptedKeychain string `json:"encrypted_keychain" binding:"required"`
+	Timestamp         int64  `json:"timestamp" binding:"required"`
+	Nonce             string `json:"nonce" binding:"required"`
+	EmitterPublicKey  string `json:"emitter_public_key"`
+	EmitterSignature  string `json:"emitter_signature"`
+	Signature         string `json:"signature" binding:"required"`
+}
+
+type accountCreationBatchRequest struct {
+	Accounts []accountRequest `json:"accounts" binding:"required,dive"`
+}
+
+type keychainUpdateRequest struct {
+	EncryptedKeychain string `json:"encrypted_keychain" binding:"required"`
+	Timestamp         int64  `json:"timestamp" binding:"required"`
+	Nonce             string `json:"nonce" binding:"required"`
 	Signature         string `json:"signature" binding:"required"`
 }
 
@@ -11,6 +26,15 @@ type accountCreationResult struct {
 	Signature    string                            `json:"signature" binding:"required"`
 }
 
+type accountCreationBatchResult struct {
+	Results []accountCreationBatchItem `json:"results"`
+}
+
+type accountCreationBatchItem struct {
+	Result *accountCreationResult `json:"result,omitempty"`
+	Error  *ErrorMessage          `json:"error,omitempty"`
+}
+
 type accountCreationTransactionsResult struct {
 	ID       transactionResult `json:"id" binding:"required"`
 	Keychain transactionResult `json:"keychain" binding:"required"`
@@ -22,6 +46,13 @@ type transactionResult struct {
 	Signature       string `json:"signature" binding:"required"`
 }
 
+type accountCreationStatus struct {
+	Status         string `json:"status" binding:"required"`
+	IDStatus       string `json:"id_status" binding:"required"`
+	KeychainStatus string `json:"keychain_status" binding:"required"`
+	KeychainHash   string `json:"keychain_transaction_hash,omitempty"`
+}
+
 type accountResult struct {
 	EncryptedAESKey  string `json:"encrypted_aes_key" binding:"required"`
 	EncryptedWallet  string `json:"encrypted_wallet" binding:"required"`
@@ -29,12 +60,137 @@ type accountResult struct {
 	Signature        string `json:"signature" binding:"required"`
 }
 
+type idDetails struct {
+	Hash                 string          `json:"hash"`
+	EncryptedAddrByRobot string          `json:"encrypted_address_by_robot"`
+	EncryptedAddrByID    string          `json:"encrypted_address_by_id"`
+	EncryptedAESKey      string          `json:"encrypted_aes_key"`
+	PublicKey            string          `json:"public_key"`
+	Proposal             keyPairProposal `json:"proposal"`
+	IDSignature          string          `json:"id_signature"`
+	EmitterSignature     string          `json:"emitter_signature"`
+	Endorsement          endorsement     `json:"endorsement"`
+	Signature            string          `json:"signature"`
+}
+
+type keychainDetails struct {
+	EncryptedAddrByRobot string          `json:"encrypted_address_by_robot"`
+	EncryptedWallet      string          `json:"encrypted_wallet"`
+	IDPublicKey          string          `json:"id_public_key"`
+	Proposal             keyPairProposal `json:"proposal"`
+	IDSignature          string          `json:"id_signature"`
+	EmitterSignature     string          `json:"emitter_signature"`
+	Endorsement          endorsement     `json:"endorsement"`
+	Signature            string          `json:"signature"`
+}
+
+type accountProof struct {
+	ID            storedID         `json:"id"`
+	IDProof       transactionProof `json:"id_proof"`
+	Keychain      storedKeychain   `json:"keychain"`
+	KeychainProof transactionProof `json:"keychain_proof"`
+}
+
+type storedID struct {
+	Hash                 string          `json:"hash"`
+	EncryptedAddrByRobot string          `json:"encrypted_address_by_robot"`
+	EncryptedAddrByID    string          `json:"encrypted_address_by_id"`
+	EncryptedAESKey      string          `json:"encrypted_aes_key"`
+	PublicKey            string          `json:"public_key"`
+	Proposal             keyPairProposal `json:"proposal"`
+	IDSignature          string          `json:"id_signature"`
+	EmitterSignature     string          `json:"emitter_signature"`
+}
+
+type storedKeychain struct {
+	EncryptedAddrByRobot string          `json:"encrypted_address_by_robot"`
+	EncryptedWallet      string          `json:"encrypted_wallet"`
+	IDPublicKey          string          `json:"id_public_key"`
+	Proposal             keyPairProposal `json:"proposal"`
+	IDSignature          string          `json:"id_signature"`
+	EmitterSignature     string          `json:"emitter_signature"`
+}
+
+type transactionProof struct {
+	Endorsement endorsement `json:"endorsement"`
+}
+
+type keyPairProposal struct {
+	EncryptedPrivateKey string `json:"encrypted_private_key"`
+	PublicKey           string `json:"public_key"`
+	ActivationDate      int64  `json:"activation_date,omitempty"`
+}
+
+type endorsement struct {
+	LastTransactionHash string           `json:"last_transaction_hash"`
+	TransactionHash     string           `json:"transaction_hash"`
+	MasterValidation    masterValidation `json:"master_validation"`
+	Validations         []validation     `json:"validations"`
+}
+
+type masterValidation struct {
+	LastTransactionMiners []string   `json:"last_transaction_miners"`
+	ProofOfWorkKey        string     `json:"proof_of_work_key"`
+	ProofOfWorkValidation validation `json:"proof_of_work_validation"`
+	ValidationPool        []string   `json:"validation_pool"`
+}
+
+type validation struct {
+	Status    string `json:"status"`
+	Timestamp int64  `json:"timestamp"`
+	PublicKey string `json:"public_key"`
+	Signature string `json:"signature"`
+}
+
 type sharedEmitterKeys struct {
 	PublicKey           string `json:"public_key" binding:"required"`
 	EncryptedPrivateKey string `json:"encrypted_private_key" binding:"required"`
 }
 
+type previousSharedEmitterKeys struct {
+	PublicKey           string `json:"public_key" binding:"required"`
+	EncryptedPrivateKey string `json:"encrypted_private_key" binding:"required"`
+	ExpirationDate      int64  `json:"expiration_date" binding:"required"`
+}
+
+type sharedRobotKey struct {
+	Version        int    `json:"version"`
+	PublicKey      string `json:"public_key" binding:"required"`
+	ActivationDate int64  `json:"activation_date,omitempty"`
+}
+
 type sharedKeys struct {
-	SharedRobotPublicKey string              `json:"shared_robot_pubkey" binding:"required"`
-	SharedEmitterKeys    []sharedEmitterKeys `json:"shared_emitter_keys" binding:"required"`
+	SharedRobotPublicKey      string                      `json:"shared_robot_pubkey" binding:"required"`
+	SharedRobotKeys           []sharedRobotKey            `json:"shared_robot_keys"`
+	SharedEmitterKeys         []sharedEmitterKeys         `json:"shared_emitter_keys" binding:"required"`
+	PreviousSharedEmitterKeys []previousSharedEmitterKeys `json:"previous_shared_emitter_keys"`
+}
+
+type webhookRequest struct {
+	CallbackURL string `json:"callback_url" binding:"required"`
+	Timestamp   int64  `json:"timestamp" binding:"required"`
+	Nonce       string `json:"nonce" binding:"required"`
+	Signature   string `json:"signature" binding:"required"`
+}
+
+type webhookResult struct {
+	CallbackURL string `json:"callback_url"`
+}
+
+type transactionCallback struct {
+	TransactionHash string `json:"transaction_hash"`
+	TransactionType string `json:"transaction_type"`
+	Status          string `json:"status"`
+	Timestamp       int64  `json:"timestamp"`
+	Signature       string `json:"signature"`
+}
+
+type transactionStatus struct {
+	TransactionHash string `json:"transaction_hash"`
+	Status          string `json:"status"`
+}
+
+type peer struct {
+	IP        string `json:"ip"`
+	PublicKey string `json:"public_key"`
 }
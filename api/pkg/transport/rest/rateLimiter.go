@@ -0,0 +1,159 @@
+package rest
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"log"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/uniris/uniris-core/api/pkg/limiting"
+	"github.com/uniris/uniris-core/shared/pkg/errcode"
+)
+
+//retryAfterHeader is the header giving the number of seconds to wait before sending a request again
+const retryAfterHeader = "Retry-After"
+
+//forwardedForHeader is the header listing the client IP and the proxies a request went through
+const forwardedForHeader = "X-Forwarded-For"
+
+//unverifiedErrorCodes identifies the errors refusing a request before or when its signature is verified
+var unverifiedErrorCodes = map[errcode.Code]bool{
+	errcode.InvalidRequest:   true,
+	errcode.InvalidSignature: true,
+	errcode.ExpiredRequest:   true,
+	errcode.ReplayedRequest:  true,
+	errcode.Unauthorized:     true,
+	errcode.RateLimited:      true,
+}
+
+//RateLimit creates a middleware limiting the requests by client IP and by emitter public key
+//
+//The client IP is the remote address of the request. The X-Forwarded-For header is only read
+//when the remote address is one of the trusted proxies, as any client can set it.
+//
+//The token of the emitter is taken before the request and refunded when its signature is not verified,
+//so concurrent requests cannot exceed the limit and a client spoofing the public key of an emitter cannot exhaust its bucket.
+//
+//The requests are accepted when the store of the limiter fails, so an unreachable shared store does not stop the API
+func RateLimit(l limiting.Limiter, trustedProxies []*net.IPNet) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		method, path := c.Request.Method, c.Request.URL.Path
+
+		retryAfter, err := l.Allow(method, path, clientIP(c.Request, trustedProxies))
+		if limitExceeded(c, retryAfter, err) {
+			return
+		}
+
+		emPubKey, err := requestEmitterKey(c)
+		if err != nil {
+			abortRequest(c, createError(errcode.InvalidRequest, err))
+			return
+		}
+		if emPubKey == "" {
+			return
+		}
+		retryAfter, err = l.TakeEmitter(method, path, emPubKey)
+		if limitExceeded(c, retryAfter, err) {
+			return
+		}
+
+		c.Next()
+
+		if code, exist := c.Get(errorTypeKey); !exist || !unverifiedErrorCodes[code.(errcode.Code)] {
+			return
+		}
+		if err := l.RefundEmitter(method, path, emPubKey); err != nil {
+			log.Printf("Rate limiter failure: %s", err.Error())
+		}
+	}
+}
+
+//limitExceeded aborts the request when the limit is exceeded, and logs the failures of the store
+func limitExceeded(c *gin.Context, retryAfter time.Duration, err error) bool {
+	if err == nil {
+		return false
+	}
+	if err != limiting.ErrLimitExceeded {
+		log.Printf("Rate limiter failure: %s", err.Error())
+		return false
+	}
+
+	c.Header(retryAfterHeader, strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+	abortRequest(c, createError(errcode.RateLimited, err))
+	return true
+}
+
+//clientIP returns the host of the remote address of the request
+//
+//Behind the trusted proxies, it returns the last address of the X-Forwarded-For header which is not a trusted proxy
+func clientIP(r *http.Request, trustedProxies []*net.IPNet) string {
+	ip, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		ip = r.RemoteAddr
+	}
+	if !isTrustedProxy(ip, trustedProxies) {
+		return ip
+	}
+
+	forwarded := strings.Split(r.Header.Get(forwardedForHeader), ",")
+	for i := len(forwarded) - 1; i >= 0; i-- {
+		addr := strings.TrimSpace(forwarded[i])
+		if addr == "" {
+			continue
+		}
+		if !isTrustedProxy(addr, trustedProxies) {
+			return addr
+		}
+		ip = addr
+	}
+	return ip
+}
+
+func isTrustedProxy(addr string, trustedProxies []*net.IPNet) bool {
+	ip := net.ParseIP(addr)
+	if ip == nil {
+		return false
+	}
+	for _, p := range trustedProxies {
+		if p.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+//requestEmitterKey retrieves the emitter public key from the route, from the query of the signed reads or from the JSON body of the account creations
+//
+//The body is read up to the maximum body size, it returns ErrBodyTooLarge beyond
+func requestEmitterKey(c *gin.Context) (string, error) {
+	if key := queryEmitterKey(c); key != "" {
+		return key, nil
+	}
+	if c.Request.Body == nil || c.ContentType() != gin.MIMEJSON {
+		return "", nil
+	}
+
+	b, err := ioutil.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxBodySize))
+	if err != nil {
+		if len(b) >= maxBodySize {
+			return "", ErrBodyTooLarge
+		}
+		return "", err
+	}
+	//The body is restored for the binding of the handlers
+	c.Request.Body = ioutil.NopCloser(bytes.NewReader(b))
+
+	var req struct {
+		EmitterPublicKey string `json:"emitter_public_key"`
+	}
+	json.Unmarshal(b, &req)
+	return req.EmitterPublicKey, nil
+}
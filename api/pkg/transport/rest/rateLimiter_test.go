@@ -0,0 +1,225 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/uniris/uniris-core/api/pkg/limiting"
+	"github.com/uniris/uniris-core/shared/pkg/errcode"
+)
+
+/*
+Scenario: Send more requests than allowed
+	Given an account creation endpoint accepting a single request per minute
+	When an emitter sends two signed account creations
+	Then the second one is refused with a rate limited error and the delay before a retry
+*/
+func TestRateLimitExceeded(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	l := limiting.NewLimiter(limiting.NewMemoryStore(), limiting.Rule{}, map[string]limiting.Rule{
+		"POST /api/v2/accounts": limiting.NewRule(1, time.Minute, 0),
+	})
+	r := gin.New()
+	r.Use(RateLimit(l, nil))
+	r.POST("/api/v2/accounts", func(c *gin.Context) {
+		//The body is still readable by the handler
+		var req accountRequest
+		assert.Nil(t, json.NewDecoder(c.Request.Body).Decode(&req))
+		assert.Equal(t, "em key", req.EmitterPublicKey)
+		c.Status(http.StatusCreated)
+	})
+
+	send := func(ip string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/api/v2/accounts", strings.NewReader(`{"encrypted_id": "abcd", "emitter_public_key": "em key"}`))
+		req.Header.Set("Content-Type", "application/json")
+		req.RemoteAddr = ip + ":1234"
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	assert.Equal(t, http.StatusCreated, send("127.0.0.1").Code)
+
+	//The emitter is limited whatever its IP
+	w := send("127.0.0.2")
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, "60", w.Header().Get(retryAfterHeader))
+	var res envelope
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &res))
+	assert.Equal(t, errcode.RateLimited, res.Error.Type)
+}
+
+/*
+Scenario: Send requests when the store of the limiter fails
+	Given a limiter with a failing store
+	When I send a request
+	Then the request reaches the handler
+*/
+func TestRateLimitStoreFailure(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(RateLimit(limiting.NewLimiter(mockFailingStore{}, limiting.NewRule(1, time.Minute, 0), nil), nil))
+	r.GET("/api/account/:hash", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/account/abcd", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+/*
+Scenario: Send requests spoofing the public key of an emitter
+	Given an account creation endpoint accepting a single request per minute
+	When a client sends two account creations with the public key of an emitter and an invalid signature
+	Then both are refused with an invalid signature error and the bucket of the emitter is not charged
+	When the emitter sends a signed account creation
+	Then it is accepted
+*/
+func TestRateLimitUnverifiedEmitter(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	l := limiting.NewLimiter(limiting.NewMemoryStore(), limiting.Rule{}, map[string]limiting.Rule{
+		"POST /api/v2/accounts": limiting.NewRule(1, time.Minute, 0),
+	})
+	r := gin.New()
+	r.Use(RateLimit(l, nil))
+	r.POST("/api/v2/accounts", func(c *gin.Context) {
+		if c.Query("signature") != "valid" {
+			abortRequest(c, createError(errcode.InvalidSignature, errors.New("Invalid signature")))
+			return
+		}
+		c.Status(http.StatusCreated)
+	})
+
+	send := func(ip string, sig string) int {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/api/v2/accounts?signature="+sig, strings.NewReader(`{"emitter_public_key": "em key"}`))
+		req.Header.Set("Content-Type", "application/json")
+		req.RemoteAddr = ip + ":1234"
+		r.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	assert.Equal(t, http.StatusBadRequest, send("127.0.0.2", "spoofed"))
+	assert.Equal(t, http.StatusBadRequest, send("127.0.0.3", "spoofed"))
+	assert.Equal(t, http.StatusCreated, send("127.0.0.1", "valid"))
+}
+
+/*
+Scenario: Send concurrent requests of an emitter
+	Given an account endpoint accepting 2 requests per minute
+	When an emitter sends 20 concurrent signed reads from different IPs
+	Then only 2 of them reach the handler and the others are rate limited
+*/
+func TestRateLimitConcurrentEmitter(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	l := limiting.NewLimiter(limiting.NewMemoryStore(), limiting.Rule{}, map[string]limiting.Rule{
+		"GET /api/v2/accounts/:hash": limiting.NewRule(2, time.Minute, 0),
+	})
+	r := gin.New()
+	r.Use(RateLimit(l, nil))
+	r.GET("/api/v2/accounts/:hash", func(c *gin.Context) {
+		//The handler is slow enough to process the requests concurrently
+		time.Sleep(50 * time.Millisecond)
+		c.Status(http.StatusOK)
+	})
+
+	var wg sync.WaitGroup
+	codes := make(chan int, 20)
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/api/v2/accounts/abcd?emitter_public_key=0a0c", nil)
+			req.RemoteAddr = fmt.Sprintf("127.0.0.%d:1234", i+1)
+			r.ServeHTTP(w, req)
+			codes <- w.Code
+		}(i)
+	}
+	wg.Wait()
+	close(codes)
+
+	count := make(map[int]int)
+	for code := range codes {
+		count[code]++
+	}
+	assert.Equal(t, 2, count[http.StatusOK])
+	assert.Equal(t, 18, count[http.StatusTooManyRequests])
+}
+
+/*
+Scenario: Send a body larger than the maximum body size
+	Given an account creation endpoint
+	When I send a JSON body larger than the maximum body size
+	Then the request is refused with an invalid request error
+*/
+func TestRateLimitBodyTooLarge(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(RateLimit(limiting.NewLimiter(limiting.NewMemoryStore(), limiting.NewRule(10, time.Minute, 0), nil), nil))
+	r.POST("/api/v2/accounts", func(c *gin.Context) {
+		c.Status(http.StatusCreated)
+	})
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/api/v2/accounts", strings.NewReader(`{"encrypted_id": "`+strings.Repeat("a", maxBodySize)+`"}`))
+	req.Header.Set("Content-Type", "application/json")
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	var res envelope
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &res))
+	assert.Equal(t, errcode.InvalidRequest, res.Error.Type)
+	assert.Equal(t, ErrBodyTooLarge.Error(), res.Error.Message)
+}
+
+/*
+Scenario: Identify the client IP of a request
+	Given requests with a forwarded client IP
+	When the remote address is not a trusted proxy
+	Then the client IP is the remote address
+	When the remote address is a trusted proxy
+	Then the client IP is the last forwarded address which is not a trusted proxy
+*/
+func TestClientIP(t *testing.T) {
+	_, proxy, _ := net.ParseCIDR("10.0.0.0/8")
+	trusted := []*net.IPNet{proxy}
+
+	request := func(remoteAddr string, forwarded string) *http.Request {
+		req := httptest.NewRequest(http.MethodGet, "/api/account/abcd", nil)
+		req.RemoteAddr = remoteAddr
+		req.Header.Set(forwardedForHeader, forwarded)
+		return req
+	}
+
+	assert.Equal(t, "127.0.0.1", clientIP(request("127.0.0.1:1234", "1.2.3.4"), nil))
+	assert.Equal(t, "127.0.0.1", clientIP(request("127.0.0.1:1234", "1.2.3.4"), trusted))
+	assert.Equal(t, "10.0.0.1", clientIP(request("10.0.0.1:1234", "1.2.3.4"), nil))
+
+	assert.Equal(t, "1.2.3.4", clientIP(request("10.0.0.1:1234", "1.2.3.4"), trusted))
+	assert.Equal(t, "5.6.7.8", clientIP(request("10.0.0.1:1234", "1.2.3.4, 5.6.7.8, 10.0.0.2"), trusted), "The first addresses can be set by the client")
+	assert.Equal(t, "10.0.0.2", clientIP(request("10.0.0.1:1234", "10.0.0.2"), trusted))
+	assert.Equal(t, "10.0.0.1", clientIP(request("10.0.0.1:1234", ""), trusted))
+}
+
+type mockFailingStore struct{}
+
+func (s mockFailingStore) Take(key string, r limiting.Rule, now time.Time) (bool, time.Duration, error) {
+	return false, 0, errcode.New(errcode.Unavailable, "store unavailable")
+}
+
+func (s mockFailingStore) Refund(key string, r limiting.Rule, now time.Time) error {
+	return errcode.New(errcode.Unavailable, "store unavailable")
+}
@@ -0,0 +1,237 @@
+package rest
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/uniris/uniris-core/api/pkg/adding"
+	"github.com/uniris/uniris-core/api/pkg/listing"
+	"github.com/uniris/uniris-core/api/pkg/webhook"
+)
+
+const (
+	testAccountBody  = `{"encrypted_id": "abcd", "encrypted_keychain": "ef01", "timestamp": 1545000000, "nonce": "nonce", "signature": "0a0b"}`
+	testKeychainBody = `{"encrypted_keychain": "ef01", "timestamp": 1545000000, "nonce": "nonce", "signature": "0a0b"}`
+	testWebhookBody  = `{"callback_url": "https://emitter.io/callback", "timestamp": 1545000000, "nonce": "nonce", "signature": "0a0b"}`
+	testProofQuery   = "?emitter_public_key=0a0c&timestamp=1545000000&nonce=nonce&signature=0a0b"
+)
+
+func newRoutesRouter(t *testing.T, s *mockServices) *gin.Engine {
+	r := newSpecRouter(t, true)
+	Handler(r, s, s, s)
+	HandlerV2(r, s, s, s)
+	return r
+}
+
+/*
+Scenario: Call each route through the specification validator
+	Given the version 1 and 2 routes checked against the specification
+	When I send a valid request to each route
+	Then I get its documented success response
+*/
+func TestRoutesFollowSpecification(t *testing.T) {
+	r := newRoutesRouter(t, &mockServices{})
+
+	requests := []struct {
+		method string
+		path   string
+		body   string
+		status int
+	}{
+		{http.MethodGet, "/api/transaction/abcd/status/ef01", "", http.StatusOK},
+		{http.MethodGet, "/api/transaction/abcd/status/ef01/events", "", http.StatusOK},
+		{http.MethodGet, "/api/sharedkeys/abcd" + testProofQuery, "", http.StatusOK},
+		{http.MethodPut, "/api/webhook/abcd", testWebhookBody, http.StatusOK},
+		{http.MethodPost, "/api/account", testAccountBody, http.StatusCreated},
+		{http.MethodPost, "/api/accounts/batch", `{"accounts": [` + testAccountBody + `, ` + testAccountBody + `]}`, http.StatusOK},
+		{http.MethodHead, "/api/account/abcd" + testProofQuery, "", http.StatusOK},
+		{http.MethodGet, "/api/account/abcd" + testProofQuery, "", http.StatusOK},
+		{http.MethodGet, "/api/account/abcd/status", "", http.StatusOK},
+		{http.MethodGet, "/api/account/abcd/id" + testProofQuery, "", http.StatusOK},
+		{http.MethodGet, "/api/account/abcd/keychain" + testProofQuery, "", http.StatusOK},
+		{http.MethodPut, "/api/account/abcd/keychain", testKeychainBody, http.StatusCreated},
+		{http.MethodGet, "/api/account/abcd/proof" + testProofQuery, "", http.StatusOK},
+
+		{http.MethodGet, "/api/v2/account-creations/abcd", "", http.StatusOK},
+		{http.MethodPost, "/api/v2/accounts", testAccountBody, http.StatusCreated},
+		{http.MethodPost, "/api/v2/accounts/batch", `{"accounts": [` + testAccountBody + `, ` + testAccountBody + `]}`, http.StatusOK},
+		{http.MethodHead, "/api/v2/accounts/abcd" + testProofQuery, "", http.StatusOK},
+		{http.MethodGet, "/api/v2/accounts/abcd" + testProofQuery, "", http.StatusOK},
+		{http.MethodGet, "/api/v2/accounts/abcd/proof" + testProofQuery, "", http.StatusOK},
+		{http.MethodGet, "/api/v2/ids/abcd" + testProofQuery, "", http.StatusOK},
+		{http.MethodGet, "/api/v2/keychains/abcd" + testProofQuery, "", http.StatusOK},
+		{http.MethodPut, "/api/v2/keychains/abcd", testKeychainBody, http.StatusCreated},
+		{http.MethodGet, "/api/v2/peers?address=abcd&limit=1", "", http.StatusOK},
+		{http.MethodGet, "/api/v2/sharedkeys/abcd" + testProofQuery, "", http.StatusOK},
+		{http.MethodGet, "/api/v2/transactions/ef01?address=abcd", "", http.StatusOK},
+		{http.MethodGet, "/api/v2/transactions/ef01/events?address=abcd", "", http.StatusOK},
+		{http.MethodPut, "/api/v2/webhooks/abcd", testWebhookBody, http.StatusOK},
+	}
+
+	for _, req := range requests {
+		w := newStreamRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(req.method, req.path, strings.NewReader(req.body)))
+		assert.Equal(t, req.status, w.Code, "%s %s: %s", req.method, req.path, w.Body.String())
+	}
+}
+
+/*
+Scenario: Get the errors of the services through the specification validator
+	Given services failing with an error of the catalogue
+	When I send a valid request to the routes of both versions
+	Then I get the documented error responses
+*/
+func TestRoutesErrorsFollowSpecification(t *testing.T) {
+	r := newRoutesRouter(t, &mockServices{err: listing.ErrAccountNotExist})
+
+	requests := []struct {
+		method string
+		path   string
+		body   string
+	}{
+		{http.MethodGet, "/api/account/abcd" + testProofQuery, ""},
+		{http.MethodPost, "/api/account", testAccountBody},
+		{http.MethodHead, "/api/v2/accounts/abcd" + testProofQuery, ""},
+		{http.MethodGet, "/api/v2/ids/abcd" + testProofQuery, ""},
+		{http.MethodGet, "/api/v2/accounts/abcd" + testProofQuery, ""},
+	}
+
+	for _, req := range requests {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(req.method, req.path, strings.NewReader(req.body)))
+		assert.Equal(t, http.StatusNotFound, w.Code, "%s %s: %s", req.method, req.path, w.Body.String())
+	}
+}
+
+//streamRecorder records the responses of the streams, which require a close notifier
+type streamRecorder struct {
+	*httptest.ResponseRecorder
+}
+
+func newStreamRecorder() streamRecorder {
+	return streamRecorder{httptest.NewRecorder()}
+}
+
+func (r streamRecorder) CloseNotify() <-chan bool {
+	return make(chan bool)
+}
+
+//mockServices implements the listing, adding and webhook services with fixed results
+type mockServices struct {
+	err error
+}
+
+func (s *mockServices) GetSafeSharedKeys() (listing.SharedKeys, error) {
+	return s.sharedKeys(), s.err
+}
+
+func (s *mockServices) GetSharedKeys(emPubKey string, proof listing.RequestProof) (listing.SharedKeys, error) {
+	return s.sharedKeys(), s.err
+}
+
+func (s *mockServices) ExistAccount(encryptedIDHash string, proof listing.RequestProof) error {
+	return s.err
+}
+
+func (s *mockServices) GetAccount(encryptedIDHash string, proof listing.RequestProof) (listing.AccountResult, error) {
+	return listing.NewAccountResult("aes key", "wallet", "address", "sig"), s.err
+}
+
+func (s *mockServices) GetTransactionStatus(addr, txHash string) (listing.TransactionStatus, error) {
+	return listing.TransactionSuccess, s.err
+}
+
+func (s *mockServices) WatchTransactionStatus(ctx context.Context, addr, txHash string) (<-chan listing.TransactionStatus, error) {
+	statuses := make(chan listing.TransactionStatus, 1)
+	statuses <- listing.TransactionSuccess
+	close(statuses)
+	return statuses, s.err
+}
+
+func (s *mockServices) GetAccountCreationStatus(idTxHash string) (listing.AccountCreationState, error) {
+	return listing.NewAccountCreationState(listing.AccountCreationPending, listing.TransactionSuccess, listing.TransactionPending, "keychain hash"), s.err
+}
+
+func (s *mockServices) GetIDDetails(encryptedIDHash string, proof listing.RequestProof) (listing.IDDetails, error) {
+	return listing.NewIDDetails(s.id(), s.endorsement(), "sig"), s.err
+}
+
+func (s *mockServices) GetKeychainDetails(encryptedIDHash string, proof listing.RequestProof) (listing.KeychainDetails, error) {
+	return listing.NewKeychainDetails(s.keychain(), s.endorsement(), "sig"), s.err
+}
+
+func (s *mockServices) GetAccountProof(encryptedIDHash string, proof listing.RequestProof) (listing.AccountProof, error) {
+	return listing.NewAccountProof(s.id(), listing.NewTransactionProof(s.endorsement()), s.keychain(), listing.NewTransactionProof(s.endorsement())), s.err
+}
+
+func (s *mockServices) GetStoragePeers(addr string) ([]listing.Peer, error) {
+	return []listing.Peer{listing.NewPeer("127.0.0.1", "key1"), listing.NewPeer("127.0.0.2", "key2")}, s.err
+}
+
+func (s *mockServices) AddAccount(req adding.AccountCreationRequest) (adding.AccountCreationResult, error) {
+	return s.accountCreation(), s.err
+}
+
+func (s *mockServices) AddAccounts(reqs []adding.AccountCreationRequest) ([]adding.AccountCreationBatchResult, error) {
+	res := make([]adding.AccountCreationBatchResult, 0)
+	for range reqs {
+		if s.err != nil {
+			res = append(res, adding.NewAccountCreationBatchResult(nil, s.err))
+			continue
+		}
+		res = append(res, adding.NewAccountCreationBatchResult(s.accountCreation(), nil))
+	}
+	return res, nil
+}
+
+func (s *mockServices) AddIdempotentAccount(key string, req adding.AccountCreationRequest) (adding.AccountCreationResult, bool, error) {
+	return s.accountCreation(), false, s.err
+}
+
+func (s *mockServices) UpdateKeychain(req adding.KeychainUpdateRequest) (adding.TransactionResult, error) {
+	return adding.NewTransactionResult("hash", "127.0.0.1", "address", "sig"), s.err
+}
+
+func (s *mockServices) RegisterCallback(reg webhook.Registration) error {
+	return s.err
+}
+
+func (s *mockServices) WatchAccountCreation(emPubKey string, res adding.AccountCreationResult) {
+}
+
+func (s *mockServices) sharedKeys() listing.SharedKeys {
+	kp := listing.NewSharedKeyPair("enc pv", "pub")
+	return listing.NewSharedKeys(
+		"robot pub",
+		[]listing.RobotKeyPair{listing.NewRobotKeyPair(1, "robot pub", time.Time{})},
+		[]listing.SharedKeyPair{kp},
+		[]listing.PreviousSharedKeyPair{listing.NewPreviousSharedKeyPair(kp, time.Now())},
+	)
+}
+
+func (s *mockServices) id() listing.ID {
+	return listing.NewID("hash", "addr robot", "addr id", "aes key", "pub", listing.NewProposedKeyPair(listing.NewSharedKeyPair("enc pv", "pub"), time.Time{}), "id sig", "em sig")
+}
+
+func (s *mockServices) keychain() listing.Keychain {
+	return listing.NewKeychain("addr robot", "wallet", "id pub", listing.NewProposedKeyPair(listing.NewSharedKeyPair("enc pv", "pub"), time.Time{}), "id sig", "em sig")
+}
+
+func (s *mockServices) endorsement() listing.Endorsement {
+	v := listing.NewValidation(listing.ValidationOK, time.Now(), "validator", "sig")
+	return listing.NewEndorsement("last hash", "hash", listing.NewMasterValidation(nil, "pow key", v, []string{"validator"}), []listing.Validation{v})
+}
+
+func (s *mockServices) accountCreation() adding.AccountCreationResult {
+	return adding.NewAccountCreationResult(adding.NewAccountCreationTransactionResult(
+		adding.NewTransactionResult("id hash", "127.0.0.1", "address", "sig"),
+		adding.NewTransactionResult("keychain hash", "127.0.0.1", "address", "sig"),
+	), "sig")
+}
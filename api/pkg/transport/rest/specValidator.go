@@ -0,0 +1,532 @@
+package rest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gin-gonic/gin"
+	yaml "gopkg.in/yaml.v2"
+
+	"github.com/uniris/uniris-core/shared/pkg/errcode"
+)
+
+//ErrUndocumentedResponse is returned when a response status is not documented by the operation of the specification
+var ErrUndocumentedResponse = errors.New("Undocumented response status")
+
+//ErrBodyTooLarge is returned when the body of a request is larger than the maximum body size
+var ErrBodyTooLarge = errors.New("body: too large")
+
+//maxBodySize is the maximum size of the request bodies read by the validator
+//
+//It fits a batch of the maximum size with the largest encrypted data allowed by the specification
+const maxBodySize = 16 << 20
+
+//SpecValidator defines methods to check the requests and the responses against the OpenAPI specification
+type SpecValidator interface {
+
+	//ValidateRequest checks the parameters and the body of a request
+	//
+	//The requests outside the operations of the specification are not checked
+	ValidateRequest(r *http.Request) error
+
+	//ValidateResponse checks the status and the JSON body of the response of a request
+	ValidateResponse(r *http.Request, status int, body []byte) error
+
+	//IsStream checks if the operation of a request streams its response
+	IsStream(r *http.Request) bool
+}
+
+type operation struct {
+	segments   []string
+	method     string
+	parameters []map[string]interface{}
+	responses  map[string]interface{}
+	stream     bool
+}
+
+type specValidator struct {
+	basePath    string
+	definitions map[string]interface{}
+	operations  []operation
+	patterns    map[string]*regexp.Regexp
+}
+
+//NewSpecValidator creates a validator from a swagger 2.0 specification file
+//
+//It supports the subset of the JSON schema used by the specification:
+//types, required properties, enumerations, patterns, lengths, bounds and references to the definitions
+func NewSpecValidator(file string) (SpecValidator, error) {
+	b, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	var raw interface{}
+	if err := yaml.Unmarshal(b, &raw); err != nil {
+		return nil, err
+	}
+	doc, _ := normalize(raw).(map[string]interface{})
+
+	v := specValidator{
+		basePath:    strings.TrimSuffix(stringOf(doc["basePath"]), "/"),
+		definitions: mapOf(doc["definitions"]),
+		patterns:    make(map[string]*regexp.Regexp),
+	}
+	if err := v.compilePatterns(doc); err != nil {
+		return nil, err
+	}
+
+	sharedParams := mapOf(doc["parameters"])
+	for path, item := range mapOf(doc["paths"]) {
+		for method, op := range mapOf(item) {
+			opDef := mapOf(op)
+
+			params := make([]map[string]interface{}, 0)
+			for _, p := range sliceOf(opDef["parameters"]) {
+				param := mapOf(p)
+				if ref := stringOf(param["$ref"]); ref != "" {
+					param = mapOf(sharedParams[strings.TrimPrefix(ref, "#/parameters/")])
+				}
+				params = append(params, param)
+			}
+
+			stream := false
+			for _, p := range sliceOf(opDef["produces"]) {
+				if stringOf(p) == "text/event-stream" {
+					stream = true
+				}
+			}
+
+			v.operations = append(v.operations, operation{
+				segments:   strings.Split(strings.Trim(path, "/"), "/"),
+				method:     strings.ToUpper(method),
+				parameters: params,
+				responses:  mapOf(opDef["responses"]),
+				stream:     stream,
+			})
+		}
+	}
+
+	//The static segments take precedence over the path parameters
+	sort.Slice(v.operations, func(i, j int) bool {
+		return countParams(v.operations[i].segments) < countParams(v.operations[j].segments)
+	})
+
+	return v, nil
+}
+
+func (v specValidator) ValidateRequest(r *http.Request) error {
+	op, pathParams, found := v.findOperation(r)
+	if !found {
+		return nil
+	}
+
+	for _, p := range op.parameters {
+		name := stringOf(p["name"])
+		required, _ := p["required"].(bool)
+
+		var value string
+		var present bool
+		switch stringOf(p["in"]) {
+		case "path":
+			value, present = pathParams[name]
+		case "query":
+			values, exist := r.URL.Query()[name]
+			if exist {
+				value, present = values[0], true
+			}
+		case "header":
+			value = r.Header.Get(name)
+			present = value != ""
+		case "body":
+			if err := v.validateBody(r, p, required); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if !present {
+			if required {
+				return fmt.Errorf("%s: required parameter", name)
+			}
+			continue
+		}
+		if err := v.validateParameter(p, value); err != nil {
+			return fmt.Errorf("%s: %s", name, err.Error())
+		}
+	}
+	return nil
+}
+
+func (v specValidator) ValidateResponse(r *http.Request, status int, body []byte) error {
+	op, _, found := v.findOperation(r)
+	if !found {
+		return nil
+	}
+
+	res, exist := op.responses[strconv.Itoa(status)]
+	if !exist {
+		if res, exist = op.responses["default"]; !exist {
+			return ErrUndocumentedResponse
+		}
+	}
+
+	schema, exist := mapOf(res)["schema"]
+	if !exist || op.stream {
+		return nil
+	}
+
+	var value interface{}
+	if err := json.Unmarshal(body, &value); err != nil {
+		return err
+	}
+	return v.validateValue(mapOf(schema), value, "response")
+}
+
+func (v specValidator) IsStream(r *http.Request) bool {
+	op, _, found := v.findOperation(r)
+	return found && op.stream
+}
+
+//findOperation identifies the operation of a request and extracts its path parameters
+func (v specValidator) findOperation(r *http.Request) (operation, map[string]string, bool) {
+	if !strings.HasPrefix(r.URL.Path, v.basePath+"/") {
+		return operation{}, nil, false
+	}
+	segments := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, v.basePath), "/"), "/")
+
+	for _, op := range v.operations {
+		if op.method != r.Method || len(op.segments) != len(segments) {
+			continue
+		}
+
+		params := make(map[string]string)
+		match := true
+		for i, s := range op.segments {
+			if strings.HasPrefix(s, "{") && strings.HasSuffix(s, "}") {
+				params[strings.Trim(s, "{}")] = segments[i]
+				continue
+			}
+			if s != segments[i] {
+				match = false
+				break
+			}
+		}
+		if match {
+			return op, params, true
+		}
+	}
+	return operation{}, nil, false
+}
+
+func (v specValidator) validateBody(r *http.Request, param map[string]interface{}, required bool) error {
+	if r.Body == nil {
+		if required {
+			return errors.New("body: required")
+		}
+		return nil
+	}
+
+	//One more byte than allowed is read to detect the larger bodies
+	b, err := ioutil.ReadAll(io.LimitReader(r.Body, maxBodySize+1))
+	if err != nil {
+		return err
+	}
+	if len(b) > maxBodySize {
+		return ErrBodyTooLarge
+	}
+	//The body is restored for the binding of the handlers
+	r.Body = ioutil.NopCloser(bytes.NewReader(b))
+
+	if len(bytes.TrimSpace(b)) == 0 {
+		if required {
+			return errors.New("body: required")
+		}
+		return nil
+	}
+
+	var value interface{}
+	if err := json.Unmarshal(b, &value); err != nil {
+		return fmt.Errorf("body: %s", err.Error())
+	}
+	return v.validateValue(mapOf(param["schema"]), value, "body")
+}
+
+//validateParameter checks the raw value of a path, query or header parameter
+func (v specValidator) validateParameter(param map[string]interface{}, value string) error {
+	switch stringOf(param["type"]) {
+	case "integer":
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return errors.New("not an integer")
+		}
+		return v.validateValue(param, float64(n), "")
+	case "boolean":
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return errors.New("not a boolean")
+		}
+		return v.validateValue(param, b, "")
+	}
+	return v.validateValue(param, value, "")
+}
+
+//validateValue checks a decoded JSON value against a schema
+//
+//The null values are rejected, unless the schema is marked with x-nullable
+func (v specValidator) validateValue(schema map[string]interface{}, value interface{}, path string) error {
+	for ref := stringOf(schema["$ref"]); ref != ""; ref = stringOf(schema["$ref"]) {
+		schema = mapOf(v.definitions[strings.TrimPrefix(ref, "#/definitions/")])
+	}
+
+	fail := func(format string, args ...interface{}) error {
+		msg := fmt.Sprintf(format, args...)
+		if path == "" {
+			return errors.New(msg)
+		}
+		return fmt.Errorf("%s: %s", path, msg)
+	}
+
+	if value == nil {
+		if nullable, _ := schema["x-nullable"].(bool); nullable {
+			return nil
+		}
+		return fail("null")
+	}
+
+	if enum := sliceOf(schema["enum"]); len(enum) > 0 {
+		valid := false
+		for _, e := range enum {
+			if fmt.Sprint(e) == fmt.Sprint(value) {
+				valid = true
+			}
+		}
+		if !valid {
+			return fail("not one of the allowed values")
+		}
+	}
+
+	typ := stringOf(schema["type"])
+	if typ == "" && schema["properties"] != nil {
+		typ = "object"
+	}
+
+	switch typ {
+	case "object":
+		obj, ok := value.(map[string]interface{})
+		if !ok {
+			return fail("not an object")
+		}
+		for _, r := range sliceOf(schema["required"]) {
+			if _, exist := obj[stringOf(r)]; !exist {
+				return fail("%s is required", stringOf(r))
+			}
+		}
+		for name, prop := range mapOf(schema["properties"]) {
+			value, exist := obj[name]
+			if !exist {
+				continue
+			}
+			if err := v.validateValue(mapOf(prop), value, joinPath(path, name)); err != nil {
+				return err
+			}
+		}
+
+	case "array":
+		items, ok := value.([]interface{})
+		if !ok {
+			return fail("not an array")
+		}
+		if max, exist := numberOf(schema["maxItems"]); exist && float64(len(items)) > max {
+			return fail("more than %v items", max)
+		}
+		if min, exist := numberOf(schema["minItems"]); exist && float64(len(items)) < min {
+			return fail("less than %v items", min)
+		}
+		for i, item := range items {
+			if err := v.validateValue(mapOf(schema["items"]), item, joinPath(path, strconv.Itoa(i))); err != nil {
+				return err
+			}
+		}
+
+	case "string":
+		s, ok := value.(string)
+		if !ok {
+			return fail("not a string")
+		}
+		if max, exist := numberOf(schema["maxLength"]); exist && float64(utf8.RuneCountInString(s)) > max {
+			return fail("longer than %v characters", max)
+		}
+		if min, exist := numberOf(schema["minLength"]); exist && float64(utf8.RuneCountInString(s)) < min {
+			return fail("shorter than %v characters", min)
+		}
+		if pattern := stringOf(schema["pattern"]); pattern != "" && !v.patterns[pattern].MatchString(s) {
+			return fail("does not match the pattern %s", pattern)
+		}
+
+	case "integer", "number":
+		n, ok := value.(float64)
+		if !ok {
+			return fail("not a number")
+		}
+		if typ == "integer" && n != math.Trunc(n) {
+			return fail("not an integer")
+		}
+		if max, exist := numberOf(schema["maximum"]); exist && n > max {
+			return fail("greater than %v", max)
+		}
+		if min, exist := numberOf(schema["minimum"]); exist && n < min {
+			return fail("lower than %v", min)
+		}
+
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return fail("not a boolean")
+		}
+	}
+
+	return nil
+}
+
+//compilePatterns compiles the patterns of the specification once, to report the invalid ones when it is loaded
+func (v specValidator) compilePatterns(node interface{}) error {
+	switch n := node.(type) {
+	case map[string]interface{}:
+		for k, child := range n {
+			if p, ok := child.(string); ok && k == "pattern" {
+				re, err := regexp.Compile(p)
+				if err != nil {
+					return err
+				}
+				v.patterns[p] = re
+				continue
+			}
+			if err := v.compilePatterns(child); err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		for _, child := range n {
+			if err := v.compilePatterns(child); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+//normalize converts the YAML maps into JSON like maps
+func normalize(node interface{}) interface{} {
+	switch n := node.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{})
+		for k, v := range n {
+			m[fmt.Sprint(k)] = normalize(v)
+		}
+		return m
+	case []interface{}:
+		for i, v := range n {
+			n[i] = normalize(v)
+		}
+	}
+	return node
+}
+
+func mapOf(node interface{}) map[string]interface{} {
+	m, _ := node.(map[string]interface{})
+	return m
+}
+
+func sliceOf(node interface{}) []interface{} {
+	s, _ := node.([]interface{})
+	return s
+}
+
+func stringOf(node interface{}) string {
+	s, _ := node.(string)
+	return s
+}
+
+func numberOf(node interface{}) (float64, bool) {
+	switch n := node.(type) {
+	case int:
+		return float64(n), true
+	case float64:
+		return n, true
+	}
+	return 0, false
+}
+
+func countParams(segments []string) int {
+	count := 0
+	for _, s := range segments {
+		if strings.HasPrefix(s, "{") {
+			count++
+		}
+	}
+	return count
+}
+
+func joinPath(path string, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+//ValidateSpec creates a middleware rejecting the requests which do not follow the specification
+//
+//When the responses are checked, they are buffered and replaced by an internal error when they do not follow the specification,
+//so the drifts between the handlers and the specification are reported by the tests. The streams are not checked
+func ValidateSpec(v SpecValidator, checkResponses bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if err := v.ValidateRequest(c.Request); err != nil {
+			abortRequest(c, createError(errcode.InvalidRequest, err))
+			return
+		}
+
+		if !checkResponses || v.IsStream(c.Request) {
+			c.Next()
+			return
+		}
+
+		w := &bufferedWriter{ResponseWriter: c.Writer}
+		c.Writer = w
+		c.Next()
+		c.Writer = w.ResponseWriter
+
+		if err := v.ValidateResponse(c.Request, w.Status(), w.body.Bytes()); err != nil {
+			e := createError(errcode.Internal, fmt.Errorf("Response does not follow the specification: %s", err.Error()))
+			w.ResponseWriter.WriteHeader(e.Code)
+			b, _ := json.Marshal(e)
+			w.ResponseWriter.Write(b)
+			return
+		}
+		w.ResponseWriter.Write(w.body.Bytes())
+	}
+}
+
+//bufferedWriter keeps the body of a response until it is checked
+type bufferedWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *bufferedWriter) Write(b []byte) (int, error) {
+	return w.body.Write(b)
+}
+
+func (w *bufferedWriter) WriteString(s string) (int, error) {
+	return w.body.WriteString(s)
+}
@@ -0,0 +1,214 @@
+package rest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/uniris/uniris-core/shared/pkg/errcode"
+)
+
+const specFile = "../../../api/swagger-spec/swagger.yaml"
+
+func newSpecRouter(t *testing.T, checkResponses bool) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	v, err := NewSpecValidator(specFile)
+	assert.Nil(t, err)
+
+	r := gin.New()
+	r.Use(ValidateSpec(v, checkResponses))
+	return r
+}
+
+/*
+Scenario: Send a request following the specification
+	Given an account creation request with hex encoded data
+	When I send the request
+	Then the request reaches the handler and the response is sent
+*/
+func TestValidateSpecValidRequest(t *testing.T) {
+	r := newSpecRouter(t, true)
+	r.POST("/api/account", func(c *gin.Context) {
+		var req accountRequest
+		assert.Nil(t, c.ShouldBindJSON(&req))
+		assert.Equal(t, "abcd", req.EncryptedID)
+
+		c.JSON(http.StatusCreated, accountCreationResult{
+			Signature: "sig",
+			Transactions: accountCreationTransactionsResult{
+				ID:       transactionResult{TransactionHash: "id hash", MasterPeerIP: "127.0.0.1"},
+				Keychain: transactionResult{TransactionHash: "keychain hash", MasterPeerIP: "127.0.0.1"},
+			},
+		})
+	})
+
+	w := httptest.NewRecorder()
+	body := `{"encrypted_id": "abcd", "encrypted_keychain": "ef01", "timestamp": 1545000000, "nonce": "nonce", "signature": "0a0b"}`
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/account", strings.NewReader(body)))
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var res accountCreationResult
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &res))
+	assert.Equal(t, "id hash", res.Transactions.ID.TransactionHash)
+}
+
+/*
+Scenario: Send a request body not following the specification
+	Given an account creation request with an encrypted ID not hex encoded
+	When I send the request
+	Then I get an invalid request error and the handler is not called
+*/
+func TestValidateSpecInvalidBody(t *testing.T) {
+	r := newSpecRouter(t, false)
+	r.POST("/api/account", func(c *gin.Context) {
+		t.Fatal("the handler must not be called")
+	})
+
+	w := httptest.NewRecorder()
+	body := `{"encrypted_id": "not hex", "encrypted_keychain": "ef01", "timestamp": 1545000000, "nonce": "nonce", "signature": "0a0b"}`
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/account", strings.NewReader(body)))
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	var res ErrorMessage
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &res))
+	assert.Equal(t, errcode.InvalidRequest, res.Type)
+	assert.Contains(t, res.Message, "body.encrypted_id")
+
+	w = httptest.NewRecorder()
+	body = `{"encrypted_id": "abcd", "encrypted_keychain": "ef01", "timestamp": "now", "nonce": "nonce", "signature": "0a0b"}`
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/account", strings.NewReader(body)))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &res))
+	assert.Contains(t, res.Message, "body.timestamp")
+}
+
+/*
+Scenario: Send a request body with null values
+	Given an account creation request with a null nonce and another one with a null optional emitter key
+	When I send the requests
+	Then I get invalid request errors and the handler is not called
+*/
+func TestValidateSpecNullValues(t *testing.T) {
+	r := newSpecRouter(t, false)
+	r.POST("/api/account", func(c *gin.Context) {
+		t.Fatal("the handler must not be called")
+	})
+
+	for _, body := range []string{
+		`{"encrypted_id": "abcd", "encrypted_keychain": "ef01", "timestamp": 1545000000, "nonce": null, "signature": "0a0b"}`,
+		`{"encrypted_id": "abcd", "encrypted_keychain": "ef01", "timestamp": 1545000000, "nonce": "nonce", "emitter_public_key": null, "signature": "0a0b"}`,
+		`null`,
+	} {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/account", strings.NewReader(body)))
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		var res ErrorMessage
+		assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &res))
+		assert.Contains(t, res.Message, "null")
+	}
+}
+
+/*
+Scenario: Send a request body larger than the maximum size
+	Given an account creation request larger than the maximum body size
+	When I send the request
+	Then I get an invalid request error and the handler is not called
+*/
+func TestValidateSpecBodyTooLarge(t *testing.T) {
+	r := newSpecRouter(t, false)
+	r.POST("/api/account", func(c *gin.Context) {
+		t.Fatal("the handler must not be called")
+	})
+
+	body := `{"encrypted_id": "` + strings.Repeat("ab", maxBodySize/2) + `"}`
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/account", strings.NewReader(body)))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	var res ErrorMessage
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &res))
+	assert.Equal(t, ErrBodyTooLarge.Error(), res.Message)
+}
+
+/*
+Scenario: Send a request with parameters not following the specification
+	Given a version 2 request without signature and a HEAD request with an invalid hash
+	When I send the requests
+	Then I get invalid request errors in the format of each route
+*/
+func TestValidateSpecInvalidParameters(t *testing.T) {
+	r := newSpecRouter(t, false)
+	r.GET("/api/v2/ids/:hash", func(c *gin.Context) {
+		t.Fatal("the handler must not be called")
+	})
+	r.HEAD("/api/account/:hash", func(c *gin.Context) {
+		t.Fatal("the handler must not be called")
+	})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v2/ids/abcd?emitter_public_key=0a0c&timestamp=1545000000&nonce=nonce", nil))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	var res envelope
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &res))
+	assert.Equal(t, "signature: required parameter", res.Error.Message)
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodHead, "/api/account/xyz?emitter_public_key=0a0c&timestamp=1545000000&nonce=nonce&signature=0a0b", nil))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, string(errcode.InvalidRequest), w.Header().Get("Error-Type"))
+	assert.Empty(t, w.Body.Bytes())
+}
+
+/*
+Scenario: Send a response not following the specification
+	Given a list of peers sent without its pagination
+	When the responses are checked
+	Then I get an internal error reporting the drift
+	When the responses are not checked
+	Then I get the response of the handler
+*/
+func TestValidateSpecInvalidResponse(t *testing.T) {
+	handler := func(c *gin.Context) {
+		c.JSON(http.StatusOK, envelope{
+			Data: []peer{{IP: "127.0.0.1", PublicKey: "key"}},
+		})
+	}
+
+	r := newSpecRouter(t, true)
+	r.GET("/api/v2/peers", handler)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v2/peers?address=abcd", nil))
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	var res ErrorMessage
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &res))
+	assert.Equal(t, errcode.Internal, res.Type)
+	assert.Contains(t, res.Message, "response: pagination is required")
+
+	r = newSpecRouter(t, false)
+	r.GET("/api/v2/peers", handler)
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v2/peers?address=abcd", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+/*
+Scenario: Send a request outside the specification
+	Given a route not documented by the specification
+	When I send a request
+	Then the request reaches the handler
+*/
+func TestValidateSpecUndocumentedRoute(t *testing.T) {
+	r := newSpecRouter(t, true)
+	r.GET("/swagger.yaml", func(c *gin.Context) {
+		c.String(http.StatusOK, "swagger")
+	})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/swagger.yaml", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "swagger", w.Body.String())
+}
@@ -0,0 +1,194 @@
+package swaggergen
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/format"
+	"regexp"
+	"sort"
+	"strings"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+//ErrUnpaginatedCollection is returned when an operation responds a list without its pagination
+var ErrUnpaginatedCollection = errors.New("Collection responded without pagination")
+
+//ErrMissingOperationID is returned when an operation of the version cannot be named
+var ErrMissingOperationID = errors.New("Operation without operationId")
+
+const (
+	offsetParameter = "#/parameters/Offset"
+	limitParameter  = "#/parameters/Limit"
+)
+
+//methodOrder sorts the operations of a path as they are usually declared
+var methodOrder = map[string]int{"head": 0, "get": 1, "post": 2, "put": 3, "patch": 4, "delete": 5}
+
+type spec struct {
+	BasePath    string                          `yaml:"basePath"`
+	Paths       map[string]map[string]operation `yaml:"paths"`
+	Definitions map[string]schema               `yaml:"definitions"`
+}
+
+type operation struct {
+	OperationID string              `yaml:"operationId"`
+	Summary     string              `yaml:"summary"`
+	Parameters  []parameter         `yaml:"parameters"`
+	Responses   map[string]response `yaml:"responses"`
+}
+
+type parameter struct {
+	Ref string `yaml:"$ref"`
+}
+
+type response struct {
+	Schema *schema `yaml:"schema"`
+}
+
+type schema struct {
+	Ref        string            `yaml:"$ref"`
+	Type       string            `yaml:"type"`
+	Properties map[string]schema `yaml:"properties"`
+	Items      *schema           `yaml:"items"`
+}
+
+type route struct {
+	method    string
+	path      string
+	operation operation
+}
+
+//Generate creates the Go source routing the operations of an API version of the swagger specification
+//
+//The source declares the server interface with a method by operationId and the function registering its routes.
+//The version names them and prefixes the paths of its operations, as /v2.
+//A list responded without its pagination is rejected, and so is a list retrieved without the offset and the limit parameters
+func Generate(specFile []byte, version string) ([]byte, error) {
+	var s spec
+	if err := yaml.Unmarshal(specFile, &s); err != nil {
+		return nil, err
+	}
+
+	routes, err := s.routes("/" + version)
+	if err != nil {
+		return nil, err
+	}
+
+	name := strings.ToUpper(version[:1]) + version[1:]
+	param := regexp.MustCompile("{([a-zA-Z]+)}")
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// Code generated by swaggergen from the swagger specification. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&b, "package rest\n\n")
+	fmt.Fprintf(&b, "import \"github.com/gin-gonic/gin\"\n\n")
+
+	fmt.Fprintf(&b, "// server%s defines the operations of the version %s of the API\n", name, version[1:])
+	fmt.Fprintf(&b, "type server%s interface {\n", name)
+	for i, r := range routes {
+		if i > 0 {
+			fmt.Fprintf(&b, "\n")
+		}
+		fmt.Fprintf(&b, "// %s handles %s %s: %s\n", r.operation.OperationID, strings.ToUpper(r.method), r.path, r.operation.Summary)
+		fmt.Fprintf(&b, "%s(c *gin.Context)\n", r.operation.OperationID)
+	}
+	fmt.Fprintf(&b, "}\n\n")
+
+	fmt.Fprintf(&b, "// register%s routes the operations of the version %s of the API to the server\n", name, version[1:])
+	fmt.Fprintf(&b, "func register%s(r *gin.Engine, s server%s) {\n", name, name)
+	fmt.Fprintf(&b, "%s := r.Group(%q)\n", version, s.BasePath+"/"+version)
+	fmt.Fprintf(&b, "{\n")
+	for _, r := range routes {
+		path := param.ReplaceAllString(strings.TrimPrefix(r.path, "/"+version), ":$1")
+		fmt.Fprintf(&b, "%s.%s(%q, s.%s)\n", version, strings.ToUpper(r.method), path, r.operation.OperationID)
+	}
+	fmt.Fprintf(&b, "}\n")
+	fmt.Fprintf(&b, "}\n")
+
+	return format.Source(b.Bytes())
+}
+
+//routes lists the operations of the paths with the prefix, sorted by path and method
+func (s spec) routes(prefix string) ([]route, error) {
+	routes := make([]route, 0)
+	for path, ops := range s.Paths {
+		if !strings.HasPrefix(path, prefix+"/") {
+			continue
+		}
+		for method, op := range ops {
+			if op.OperationID == "" {
+				return nil, fmt.Errorf("%s %s: %s", strings.ToUpper(method), path, ErrMissingOperationID)
+			}
+			if err := s.checkPagination(method, op); err != nil {
+				return nil, fmt.Errorf("%s %s: %s", strings.ToUpper(method), path, err)
+			}
+			routes = append(routes, route{method, path, op})
+		}
+	}
+
+	sort.Slice(routes, func(i, j int) bool {
+		if routes[i].path != routes[j].path {
+			return routes[i].path < routes[j].path
+		}
+		return methodOrder[routes[i].method] < methodOrder[routes[j].method]
+	})
+	return routes, nil
+}
+
+//checkPagination ensures a list is responded with its pagination, and retrieved with the offset and the limit
+//
+//The lists created by a request, as a batch, are responded in a single page
+func (s spec) checkPagination(method string, op operation) error {
+	envelope := s.successSchema(op)
+	if envelope == nil {
+		return nil
+	}
+	if s.resolve(envelope.Properties["data"]).Type != "array" {
+		return nil
+	}
+	if _, exist := envelope.Properties["pagination"]; !exist {
+		return ErrUnpaginatedCollection
+	}
+	if method != "get" {
+		return nil
+	}
+
+	params := make(map[string]bool)
+	for _, p := range op.Parameters {
+		params[p.Ref] = true
+	}
+	if !params[offsetParameter] || !params[limitParameter] {
+		return ErrUnpaginatedCollection
+	}
+	return nil
+}
+
+//successSchema returns the schema of the first success response of the operation, nil without schema
+func (s spec) successSchema(op operation) *schema {
+	codes := make([]string, 0)
+	for code := range op.Responses {
+		if strings.HasPrefix(code, "2") {
+			codes = append(codes, code)
+		}
+	}
+	if len(codes) == 0 {
+		return nil
+	}
+	sort.Strings(codes)
+
+	res := op.Responses[codes[0]]
+	if res.Schema == nil {
+		return nil
+	}
+	resolved := s.resolve(*res.Schema)
+	return &resolved
+}
+
+//resolve returns the definition referenced by the schema
+func (s spec) resolve(sch schema) schema {
+	if sch.Ref == "" {
+		return sch
+	}
+	return s.resolve(s.Definitions[strings.TrimPrefix(sch.Ref, "#/definitions/")])
+}
@@ -0,0 +1,132 @@
+package swaggergen
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testSpec = `
+basePath: /api
+paths:
+  /items:
+    get:
+      operationId: listItems
+  /v2/items:
+    get:
+      summary: List the items
+      operationId: listItemsV2
+      parameters:
+        - $ref: "#/parameters/Offset"
+        - $ref: "#/parameters/Limit"
+      responses:
+        "200":
+          schema:
+            $ref: "#/definitions/ItemListEnvelope"
+  /v2/items/{id}:
+    put:
+      summary: Update an item
+      operationId: updateItemV2
+    head:
+      summary: Check an item
+      operationId: existItemV2
+definitions:
+  ItemList:
+    type: array
+  ItemListEnvelope:
+    properties:
+      data:
+        $ref: "#/definitions/ItemList"
+      pagination:
+        type: object
+`
+
+/*
+Scenario: Generate the routes of an API version
+	Given a specification with operations of several versions
+	When I generate the routes of the version 2
+	Then I get the server interface and the routes of the version 2 operations only
+*/
+func TestGenerate(t *testing.T) {
+	src, err := Generate([]byte(testSpec), "v2")
+	assert.Nil(t, err)
+	assert.Equal(t, `// Code generated by swaggergen from the swagger specification. DO NOT EDIT.
+
+package rest
+
+import "github.com/gin-gonic/gin"
+
+// serverV2 defines the operations of the version 2 of the API
+type serverV2 interface {
+	// listItemsV2 handles GET /v2/items: List the items
+	listItemsV2(c *gin.Context)
+
+	// existItemV2 handles HEAD /v2/items/{id}: Check an item
+	existItemV2(c *gin.Context)
+
+	// updateItemV2 handles PUT /v2/items/{id}: Update an item
+	updateItemV2(c *gin.Context)
+}
+
+// registerV2 routes the operations of the version 2 of the API to the server
+func registerV2(r *gin.Engine, s serverV2) {
+	v2 := r.Group("/api/v2")
+	{
+		v2.GET("/items", s.listItemsV2)
+		v2.HEAD("/items/:id", s.existItemV2)
+		v2.PUT("/items/:id", s.updateItemV2)
+	}
+}
+`, string(src))
+}
+
+/*
+Scenario: Generate the routes of a list without pagination
+	Given a specification listing items without the pagination parameters or without the pagination of the response
+	When I generate the routes
+	Then I get an error
+*/
+func TestGenerateUnpaginatedCollection(t *testing.T) {
+	withoutParameters := `
+paths:
+  /v2/items:
+    get:
+      operationId: listItemsV2
+      responses:
+        "200":
+          schema:
+            properties:
+              data:
+                type: array
+              pagination:
+                type: object
+`
+	_, err := Generate([]byte(withoutParameters), "v2")
+	assert.EqualError(t, err, "GET /v2/items: "+ErrUnpaginatedCollection.Error())
+
+	withoutPagination := `
+paths:
+  /v2/items:
+    post:
+      operationId: createItemsV2
+      responses:
+        "200":
+          schema:
+            properties:
+              data:
+                type: array
+`
+	_, err = Generate([]byte(withoutPagination), "v2")
+	assert.EqualError(t, err, "POST /v2/items: "+ErrUnpaginatedCollection.Error())
+}
+
+/*
+Scenario: Generate the routes of an operation without identifier
+	Given a specification with an operation without operationId
+	When I generate the routes
+	Then I get an error
+*/
+func TestGenerateMissingOperationID(t *testing.T) {
+	_, err := Generate([]byte("paths:\n  /v2/items:\n    get:\n      summary: List the items\n"), "v2")
+	assert.EqualError(t, err, "GET /v2/items: "+ErrMissingOperationID.Error())
+}
@@ -2,16 +2,17 @@ package rpc
 
 import (
 	"context"
-	"errors"
 	"fmt"
+	"time"
 
 	"github.com/golang/protobuf/ptypes/empty"
 
-	"google.golang.org/grpc/status"
-
 	api "github.com/uniris/uniris-core/datamining/api/protobuf-spec"
+	"github.com/uniris/uniris-core/shared/pkg/connpool"
+	"github.com/uniris/uniris-core/shared/pkg/errcode"
 
 	adding "github.com/uniris/uniris-core/api/pkg/adding"
+	crypto "github.com/uniris/uniris-core/api/pkg/crypto"
 	listing "github.com/uniris/uniris-core/api/pkg/listing"
 	system "github.com/uniris/uniris-core/api/pkg/system"
 	"google.golang.org/grpc"
@@ -21,32 +22,37 @@ import (
 type RobotClient interface {
 	adding.RobotClient
 	listing.RobotClient
+	crypto.RemoteSigner
 }
 
 type robotClient struct {
 	conf system.UnirisConfig
+	pool connpool.Pool
 }
 
+//insecureCredentials identifies the connections to the datamining service on the loopback, which are not secured
+const insecureCredentials = "insecure"
+
 //NewRobotClient creates a new robot client using GRPC
-func NewRobotClient(conf system.UnirisConfig) RobotClient {
-	return robotClient{conf}
+//
+//The connection to the datamining service is shared between the calls through the connection pool
+func NewRobotClient(conf system.UnirisConfig, pool connpool.Pool) RobotClient {
+	return robotClient{conf, pool}
 }
 
 func (c robotClient) IsEmitterAuthorized(emPubKey string) error {
 	serverAddr := fmt.Sprintf("localhost:%d", c.conf.Services.Datamining.InternalPort)
-	conn, err := grpc.Dial(serverAddr, grpc.WithInsecure())
-	defer conn.Close()
-
+	conn, release, err := c.pool.Get(serverAddr, insecureCredentials, grpc.WithInsecure())
 	if err != nil {
 		return err
 	}
+	defer release()
 
 	client := api.NewInternalClient(conn)
 
 	res, err := client.IsEmitterAuthorized(context.Background(), &api.AuthorizationRequest{PublicKey: emPubKey})
 	if err != nil {
-		s, _ := status.FromError(err)
-		return errors.New(s.Message())
+		return robotError(err)
 	}
 
 	if res.Status == false {
@@ -58,19 +64,17 @@ func (c robotClient) IsEmitterAuthorized(emPubKey string) error {
 
 func (c robotClient) GetSharedKeys() (listing.SharedKeys, error) {
 	serverAddr := fmt.Sprintf("localhost:%d", c.conf.Services.Datamining.InternalPort)
-	conn, err := grpc.Dial(serverAddr, grpc.WithInsecure())
-	defer conn.Close()
-
+	conn, release, err := c.pool.Get(serverAddr, insecureCredentials, grpc.WithInsecure())
 	if err != nil {
 		return nil, err
 	}
+	defer release()
 
 	client := api.NewInternalClient(conn)
 
 	res, err := client.GetSharedKeys(context.Background(), &empty.Empty{})
 	if err != nil {
-		s, _ := status.FromError(err)
-		return nil, errors.New(s.Message())
+		return nil, robotError(err)
 	}
 
 	emKeys := make([]listing.SharedKeyPair, 0)
@@ -78,17 +82,50 @@ func (c robotClient) GetSharedKeys() (listing.SharedKeys, error) {
 		emKeys = append(emKeys, listing.NewSharedKeyPair(kp.EncryptedPrivateKey, kp.PublicKey))
 	}
 
-	return listing.NewSharedKeys(res.RobotPrivateKey, res.RobotPublicKey, emKeys), nil
+	prevEmKeys := make([]listing.PreviousSharedKeyPair, 0)
+	for _, kp := range res.PreviousEmitterKeys {
+		prevEmKeys = append(prevEmKeys, listing.NewPreviousSharedKeyPair(
+			listing.NewSharedKeyPair(kp.EncryptedPrivateKey, kp.PublicKey),
+			time.Unix(kp.ExpirationDate, 0)))
+	}
+
+	robotKeys := make([]listing.RobotKeyPair, 0)
+	for _, kp := range res.RobotKeys {
+		var activation time.Time
+		if kp.ActivationDate > 0 {
+			activation = time.Unix(kp.ActivationDate, 0)
+		}
+		robotKeys = append(robotKeys, listing.NewRobotKeyPair(int(kp.Version), kp.PublicKey, activation))
+	}
+
+	return listing.NewSharedKeys(res.RobotPublicKey, robotKeys, emKeys, prevEmKeys), nil
 }
 
-func (c robotClient) GetAccount(encHash string) (listing.AccountResult, error) {
+func (c robotClient) SignPayload(payload []byte) (string, error) {
 	serverAddr := fmt.Sprintf("localhost:%d", c.conf.Services.Datamining.InternalPort)
-	conn, err := grpc.Dial(serverAddr, grpc.WithInsecure())
-	defer conn.Close()
+	conn, release, err := c.pool.Get(serverAddr, insecureCredentials, grpc.WithInsecure())
+	if err != nil {
+		return "", err
+	}
+	defer release()
+
+	client := api.NewInternalClient(conn)
+
+	res, err := client.SignPayload(context.Background(), &api.PayloadSignatureRequest{Payload: payload})
+	if err != nil {
+		return "", robotError(err)
+	}
+
+	return res.Signature, nil
+}
 
+func (c robotClient) GetAccount(encHash string) (listing.AccountResult, error) {
+	serverAddr := fmt.Sprintf("localhost:%d", c.conf.Services.Datamining.InternalPort)
+	conn, release, err := c.pool.Get(serverAddr, insecureCredentials, grpc.WithInsecure())
 	if err != nil {
 		return nil, err
 	}
+	defer release()
 
 	client := api.NewInternalClient(conn)
 
@@ -96,58 +133,215 @@ func (c robotClient) GetAccount(encHash string) (listing.AccountResult, error) {
 		EncryptedIDHash: encHash,
 	})
 	if err != nil {
-		s, _ := status.FromError(err)
-		if s.Message() == c.conf.Services.Datamining.Errors.AccountNotExist {
-			return nil, listing.ErrAccountNotExist
-		}
-		return nil, errors.New(s.Message())
+		return nil, robotError(err)
 	}
 
 	return listing.NewAccountResult(res.EncryptedAESkey, res.EncryptedWallet, res.EncryptedAddress, res.Signature), nil
 }
 
-func (c robotClient) AddAccount(req adding.AccountCreationRequest) (adding.AccountCreationResult, error) {
+func (c robotClient) GetIDDetails(encHash string) (listing.IDDetails, error) {
 	serverAddr := fmt.Sprintf("localhost:%d", c.conf.Services.Datamining.InternalPort)
-	conn, err := grpc.Dial(serverAddr, grpc.WithInsecure())
-	defer conn.Close()
+	conn, release, err := c.pool.Get(serverAddr, insecureCredentials, grpc.WithInsecure())
+	if err != nil {
+		return nil, err
+	}
+	defer release()
 
+	client := api.NewInternalClient(conn)
+
+	res, err := client.GetIDDetails(context.Background(), &api.AccountSearchRequest{
+		EncryptedIDHash: encHash,
+	})
+	if err != nil {
+		return nil, robotError(err)
+	}
+
+	id := res.GetData()
+	return listing.NewIDDetails(
+		listing.NewID(
+			id.GetHash(),
+			id.GetEncryptedAddrByRobot(),
+			id.GetEncryptedAddrByID(),
+			id.GetEncryptedAESKey(),
+			id.GetPublicKey(),
+			formatProposal(id.GetProposal()),
+			id.GetIDSignature(),
+			id.GetEmitterSignature()),
+		formatEndorsement(res.GetEndorsement()),
+		res.Signature), nil
+}
+
+func (c robotClient) GetKeychainDetails(encHash string) (listing.KeychainDetails, error) {
+	serverAddr := fmt.Sprintf("localhost:%d", c.conf.Services.Datamining.InternalPort)
+	conn, release, err := c.pool.Get(serverAddr, insecureCredentials, grpc.WithInsecure())
 	if err != nil {
 		return nil, err
 	}
+	defer release()
 
 	client := api.NewInternalClient(conn)
 
-	resID, err := client.CreateID(context.Background(), &api.IDCreationRequest{
-		EncryptedID: req.EncryptedID(),
+	res, err := client.GetKeychainDetails(context.Background(), &api.AccountSearchRequest{
+		EncryptedIDHash: encHash,
 	})
 	if err != nil {
-		s, _ := status.FromError(err)
-		return nil, errors.New(s.Message())
+		return nil, robotError(err)
 	}
 
-	resKeychain, err := client.CreateKeychain(context.Background(), &api.KeychainCreationRequest{
+	kc := res.GetData()
+	return listing.NewKeychainDetails(
+		listing.NewKeychain(
+			kc.GetEncryptedAddrByRobot(),
+			kc.GetEncryptedWallet(),
+			kc.GetIDPublicKey(),
+			formatProposal(kc.GetProposal()),
+			kc.GetIDSignature(),
+			kc.GetEmitterSignature()),
+		formatEndorsement(res.GetEndorsement()),
+		res.Signature), nil
+}
+
+func (c robotClient) GetAccountProof(encHash string) (listing.AccountProof, error) {
+	serverAddr := fmt.Sprintf("localhost:%d", c.conf.Services.Datamining.InternalPort)
+	conn, release, err := c.pool.Get(serverAddr, insecureCredentials, grpc.WithInsecure())
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	client := api.NewInternalClient(conn)
+
+	res, err := client.GetAccountProof(context.Background(), &api.AccountSearchRequest{
+		EncryptedIDHash: encHash,
+	})
+	if err != nil {
+		return nil, robotError(err)
+	}
+
+	id := res.GetID()
+	kc := res.GetKeychain()
+	return listing.NewAccountProof(
+		listing.NewID(
+			id.GetHash(),
+			id.GetEncryptedAddrByRobot(),
+			id.GetEncryptedAddrByID(),
+			id.GetEncryptedAESKey(),
+			id.GetPublicKey(),
+			formatProposal(id.GetProposal()),
+			id.GetIDSignature(),
+			id.GetEmitterSignature()),
+		formatTransactionProof(res.GetIDProof()),
+		listing.NewKeychain(
+			kc.GetEncryptedAddrByRobot(),
+			kc.GetEncryptedWallet(),
+			kc.GetIDPublicKey(),
+			formatProposal(kc.GetProposal()),
+			kc.GetIDSignature(),
+			kc.GetEmitterSignature()),
+		formatTransactionProof(res.GetKeychainProof())), nil
+}
+
+func (c robotClient) AddAccount(req adding.AccountCreationRequest) (adding.AccountCreationResult, error) {
+	serverAddr := fmt.Sprintf("localhost:%d", c.conf.Services.Datamining.InternalPort)
+	conn, release, err := c.pool.Get(serverAddr, insecureCredentials, grpc.WithInsecure())
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	client := api.NewInternalClient(conn)
+
+	//The ID and the keychain transactions are orchestrated together by the datamining service
+	res, err := client.CreateAccount(context.Background(), &api.AccountCreationRequest{
+		EncryptedID:       req.EncryptedID(),
 		EncryptedKeychain: req.EncryptedKeychain(),
 	})
 	if err != nil {
-		s, _ := status.FromError(err)
-		return nil, errors.New(s.Message())
+		return nil, robotError(err)
 	}
 
-	txID := adding.NewTransactionResult(resID.TransactionHash, resID.MasterPeerIP, resID.Signature)
-	txKeychain := adding.NewTransactionResult(resKeychain.TransactionHash, resKeychain.MasterPeerIP, resKeychain.Signature)
+	id := res.GetID()
+	keychain := res.GetKeychain()
+	txID := adding.NewTransactionResult(id.GetTransactionHash(), id.GetMasterPeerIP(), id.GetEncryptedAddress(), id.GetSignature())
+	txKeychain := adding.NewTransactionResult(keychain.GetTransactionHash(), keychain.GetMasterPeerIP(), keychain.GetEncryptedAddress(), keychain.GetSignature())
 
 	resTx := adding.NewAccountCreationTransactionResult(txID, txKeychain)
 	return adding.NewAccountCreationResult(resTx, ""), nil
 }
 
-func (c robotClient) GetTransactionStatus(addr string, txHash string) (listing.TransactionStatus, error) {
+func (c robotClient) AddAccounts(reqs []adding.AccountCreationRequest) ([]adding.AccountCreationBatchResult, error) {
+	serverAddr := fmt.Sprintf("localhost:%d", c.conf.Services.Datamining.InternalPort)
+	conn, release, err := c.pool.Get(serverAddr, insecureCredentials, grpc.WithInsecure())
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	client := api.NewInternalClient(conn)
+
+	batch := make([]*api.AccountCreationRequest, 0)
+	for _, req := range reqs {
+		batch = append(batch, &api.AccountCreationRequest{
+			EncryptedID:       req.EncryptedID(),
+			EncryptedKeychain: req.EncryptedKeychain(),
+		})
+	}
+
+	res, err := client.CreateAccounts(context.Background(), &api.AccountCreationBatchRequest{
+		Requests: batch,
+	})
+	if err != nil {
+		return nil, robotError(err)
+	}
+
+	results := make([]adding.AccountCreationBatchResult, 0)
+	for _, item := range res.GetResults() {
+		if item.GetError() != "" {
+			results = append(results, adding.NewAccountCreationBatchResult(nil, robotError(errcode.New(errcode.Code(item.GetErrorCode()), item.GetError()))))
+			continue
+		}
+		id := item.GetResult().GetID()
+		keychain := item.GetResult().GetKeychain()
+		txID := adding.NewTransactionResult(id.GetTransactionHash(), id.GetMasterPeerIP(), id.GetEncryptedAddress(), id.GetSignature())
+		txKeychain := adding.NewTransactionResult(keychain.GetTransactionHash(), keychain.GetMasterPeerIP(), keychain.GetEncryptedAddress(), keychain.GetSignature())
+		results = append(results, adding.NewAccountCreationBatchResult(adding.NewAccountCreationResult(adding.NewAccountCreationTransactionResult(txID, txKeychain), ""), nil))
+	}
+	return results, nil
+}
+
+func (c robotClient) UpdateKeychain(req adding.KeychainUpdateRequest) (adding.TransactionResult, error) {
 	serverAddr := fmt.Sprintf("localhost:%d", c.conf.Services.Datamining.InternalPort)
-	conn, err := grpc.Dial(serverAddr, grpc.WithInsecure())
-	defer conn.Close()
+	conn, release, err := c.pool.Get(serverAddr, insecureCredentials, grpc.WithInsecure())
+	if err != nil {
+		return nil, err
+	}
+	defer release()
 
+	client := api.NewInternalClient(conn)
+
+	res, err := client.UpdateKeychain(context.Background(), &api.KeychainUpdateRequest{
+		EncryptedIDHash:   req.EncryptedIDHash(),
+		EncryptedKeychain: req.EncryptedKeychain(),
+		Timestamp:         req.Timestamp().Unix(),
+		Nonce:             req.Nonce(),
+		Signature:         req.Signature(),
+	})
+	if err != nil {
+
+		//The request is checked by the datamining service as it holds the ID public key
+		return nil, robotError(err)
+	}
+
+	return adding.NewTransactionResult(res.TransactionHash, res.MasterPeerIP, res.EncryptedAddress, res.Signature), nil
+}
+
+func (c robotClient) GetTransactionStatus(addr string, txHash string) (listing.TransactionStatus, error) {
+	serverAddr := fmt.Sprintf("localhost:%d", c.conf.Services.Datamining.InternalPort)
+	conn, release, err := c.pool.Get(serverAddr, insecureCredentials, grpc.WithInsecure())
 	if err != nil {
 		return listing.TransactionFailure, err
 	}
+	defer release()
 
 	client := api.NewInternalClient(conn)
 	res, err := client.GetTransactionStatus(context.Background(), &api.TransactionStatusRequest{
@@ -155,9 +349,152 @@ func (c robotClient) GetTransactionStatus(addr string, txHash string) (listing.T
 		Hash:    txHash,
 	})
 	if err != nil {
-		s, _ := status.FromError(err)
-		return listing.TransactionFailure, errors.New(s.Message())
+		return listing.TransactionFailure, robotError(err)
 	}
 
 	return listing.TransactionStatus(res.Status), nil
 }
+
+func (c robotClient) WatchTransactionStatus(ctx context.Context, addr string, txHash string) (<-chan listing.TransactionStatus, error) {
+	serverAddr := fmt.Sprintf("localhost:%d", c.conf.Services.Datamining.InternalPort)
+	conn, release, err := c.pool.GetStream(serverAddr, insecureCredentials, grpc.WithInsecure())
+	if err != nil {
+		return nil, err
+	}
+
+	client := api.NewInternalClient(conn)
+	stream, err := client.WatchTransactionStatus(ctx, &api.TransactionStatusRequest{
+		Address: addr,
+		Hash:    txHash,
+	})
+	if err != nil {
+		release()
+		return nil, robotError(err)
+	}
+
+	statuses := make(chan listing.TransactionStatus)
+	go func() {
+		defer release()
+		defer close(statuses)
+
+		for {
+			res, err := stream.Recv()
+			if err != nil {
+				return
+			}
+			select {
+			case statuses <- listing.TransactionStatus(res.Status):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return statuses, nil
+}
+
+func (c robotClient) GetAccountCreationStatus(idTxHash string) (listing.AccountCreationState, error) {
+	serverAddr := fmt.Sprintf("localhost:%d", c.conf.Services.Datamining.InternalPort)
+	conn, release, err := c.pool.Get(serverAddr, insecureCredentials, grpc.WithInsecure())
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	client := api.NewInternalClient(conn)
+	res, err := client.GetAccountCreationStatus(context.Background(), &api.AccountCreationStatusRequest{
+		IDTransactionHash: idTxHash,
+	})
+	if err != nil {
+		return nil, robotError(err)
+	}
+
+	return listing.NewAccountCreationState(
+		listing.AccountCreationStatus(res.Status),
+		listing.TransactionStatus(res.IDStatus),
+		listing.TransactionStatus(res.KeychainStatus),
+		res.GetKeychain().GetTransactionHash()), nil
+}
+
+func (c robotClient) GetStoragePeers(addr string) ([]listing.Peer, error) {
+	serverAddr := fmt.Sprintf("localhost:%d", c.conf.Services.Datamining.InternalPort)
+	conn, release, err := c.pool.Get(serverAddr, insecureCredentials, grpc.WithInsecure())
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	client := api.NewInternalClient(conn)
+	res, err := client.GetStoragePeers(context.Background(), &api.StoragePeersRequest{
+		Address: addr,
+	})
+	if err != nil {
+		return nil, robotError(err)
+	}
+
+	peers := make([]listing.Peer, 0)
+	for _, p := range res.Peers {
+		peers = append(peers, listing.NewPeer(p.IP, p.PublicKey))
+	}
+	return peers, nil
+}
+
+//The protobuf messages are converted using the getters to be safe with missing nested messages
+
+func formatProposal(p *api.Proposal) listing.ProposedKeyPair {
+	return listing.NewProposedKeyPair(
+		listing.NewSharedKeyPair(
+			p.GetSharedEmitterKeyPair().GetEncryptedPrivateKey(),
+			p.GetSharedEmitterKeyPair().GetPublicKey()),
+		time.Unix(p.GetSharedEmitterKeyPair().GetActivationDate(), 0))
+}
+
+func formatValidation(v *api.Validation) listing.Validation {
+	return listing.NewValidation(
+		listing.ValidationStatus(v.GetStatus()),
+		time.Unix(v.GetTimestamp(), 0),
+		v.GetPublicKey(),
+		v.GetSignature())
+}
+
+func formatEndorsement(end *api.Endorsement) listing.Endorsement {
+	valids := make([]listing.Validation, 0)
+	for _, v := range end.GetValidations() {
+		valids = append(valids, formatValidation(v))
+	}
+	return listing.NewEndorsement(
+		end.GetLastTransactionHash(),
+		end.GetTransactionHash(),
+		listing.NewMasterValidation(
+			end.GetMasterValidation().GetLastTransactionMiners(),
+			end.GetMasterValidation().GetProofOfWorkKey(),
+			formatValidation(end.GetMasterValidation().GetProofOfWorkValidation()),
+			end.GetMasterValidation().GetValidationPool()),
+		valids)
+}
+
+func formatTransactionProof(p *api.TransactionProof) listing.TransactionProof {
+	return listing.NewTransactionProof(formatEndorsement(p.GetEndorsement()))
+}
+
+//robotError maps the errors of the catalogue returned by the datamining service to the errors of the API services
+//
+//The errors without equivalent are returned as errors of the catalogue, so their code is kept
+func robotError(err error) error {
+	e := errcode.FromGRPC(err)
+	switch e.Code {
+	case errcode.AccountNotExist:
+		return listing.ErrAccountNotExist
+	case errcode.InvalidSignature:
+		return crypto.ErrInvalidSignature
+	case errcode.ExpiredRequest:
+		return listing.ErrExpiredRequest
+	case errcode.ReplayedRequest:
+		return listing.ErrReplayedRequest
+	case errcode.KeychainNotOwned:
+		return adding.ErrKeychainNotOwned
+	case errcode.Unauthorized:
+		return listing.ErrUnauthorized
+	}
+	return e
+}
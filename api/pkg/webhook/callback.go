@@ -0,0 +1,68 @@
+package webhook
+
+import (
+	"time"
+
+	"github.com/uniris/uniris-core/api/pkg/listing"
+)
+
+const (
+
+	//IDTransaction identifies the ID transaction of an account creation
+	IDTransaction = "ID"
+
+	//KeychainTransaction identifies the keychain transaction of an account creation
+	KeychainTransaction = "Keychain"
+)
+
+//TransactionCallback represents the notification sent to an emitter when a transaction is finalised
+type TransactionCallback interface {
+
+	//TransactionHash returns the hash of the finalised transaction
+	TransactionHash() string
+
+	//TransactionType returns the type of the finalised transaction
+	TransactionType() string
+
+	//Status returns the final status of the transaction
+	Status() listing.TransactionStatus
+
+	//Timestamp returns the time when the callback has been built
+	Timestamp() time.Time
+
+	//Signature returns the signature of the callback by the shared robot key
+	Signature() string
+}
+
+type txCallback struct {
+	txHash    string
+	txType    string
+	status    listing.TransactionStatus
+	timestamp time.Time
+	sig       string
+}
+
+//NewTransactionCallback creates a new transaction callback
+func NewTransactionCallback(txHash string, txType string, status listing.TransactionStatus, timestamp time.Time, sig string) TransactionCallback {
+	return txCallback{txHash, txType, status, timestamp, sig}
+}
+
+func (c txCallback) TransactionHash() string {
+	return c.txHash
+}
+
+func (c txCallback) TransactionType() string {
+	return c.txType
+}
+
+func (c txCallback) Status() listing.TransactionStatus {
+	return c.status
+}
+
+func (c txCallback) Timestamp() time.Time {
+	return c.timestamp
+}
+
+func (c txCallback) Signature() string {
+	return c.sig
+}
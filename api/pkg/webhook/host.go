@@ -0,0 +1,44 @@
+package webhook
+
+import "net"
+
+//nonPublicRanges lists the networks a callback must not reach, so the API cannot be used to call its own infrastructure
+var nonPublicRanges = parseCIDRs(
+	"0.0.0.0/8",
+	"10.0.0.0/8",
+	"100.64.0.0/10",
+	"127.0.0.0/8",
+	"169.254.0.0/16",
+	"172.16.0.0/12",
+	"192.168.0.0/16",
+	"::1/128",
+	"fc00::/7",
+	"fe80::/10",
+)
+
+func parseCIDRs(cidrs ...string) []*net.IPNet {
+	nets := make([]*net.IPNet, 0, len(cidrs))
+	for _, c := range cidrs {
+		_, n, err := net.ParseCIDR(c)
+		if err != nil {
+			panic(err)
+		}
+		nets = append(nets, n)
+	}
+	return nets
+}
+
+//IsPublicIP checks if the IP can be the target of a callback
+//
+//The loopback, private, shared, link-local, unspecified and multicast addresses are rejected
+func IsPublicIP(ip net.IP) bool {
+	if ip.IsUnspecified() || ip.IsMulticast() {
+		return false
+	}
+	for _, n := range nonPublicRanges {
+		if n.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
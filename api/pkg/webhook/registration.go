@@ -0,0 +1,55 @@
+package webhook
+
+import "time"
+
+//Registration represents the request of an emitter to register its callback URL
+type Registration interface {
+
+	//EmitterPublicKey returns the public key of the emitter
+	EmitterPublicKey() string
+
+	//CallbackURL returns the URL called when the transactions of the emitter are finalised
+	CallbackURL() string
+
+	//Timestamp returns the time when the request has been signed
+	Timestamp() time.Time
+
+	//Nonce returns the unique value identifying the request
+	Nonce() string
+
+	//Signature returns the signature of the request by the emitter private key
+	Signature() string
+}
+
+type registration struct {
+	emPubKey  string
+	url       string
+	timestamp time.Time
+	nonce     string
+	sig       string
+}
+
+//NewRegistration creates a new callback registration
+func NewRegistration(emPubKey string, url string, timestamp time.Time, nonce string, sig string) Registration {
+	return registration{emPubKey, url, timestamp, nonce, sig}
+}
+
+func (r registration) EmitterPublicKey() string {
+	return r.emPubKey
+}
+
+func (r registration) CallbackURL() string {
+	return r.url
+}
+
+func (r registration) Timestamp() time.Time {
+	return r.timestamp
+}
+
+func (r registration) Nonce() string {
+	return r.nonce
+}
+
+func (r registration) Signature() string {
+	return r.sig
+}
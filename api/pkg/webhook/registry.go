@@ -0,0 +1,38 @@
+package webhook
+
+import "sync"
+
+//Registry defines methods to keep the callback URLs of the emitters
+type Registry interface {
+
+	//Register stores the callback URL of an emitter, replacing the previous one
+	Register(emPubKey string, url string)
+
+	//CallbackURL returns the callback URL registered by an emitter
+	CallbackURL(emPubKey string) (string, bool)
+}
+
+type registry struct {
+	mu   sync.RWMutex
+	urls map[string]string
+}
+
+//NewRegistry creates a registry keeping the callback URLs in memory
+func NewRegistry() Registry {
+	return &registry{
+		urls: make(map[string]string),
+	}
+}
+
+func (r *registry) Register(emPubKey string, url string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.urls[emPubKey] = url
+}
+
+func (r *registry) CallbackURL(emPubKey string) (string, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	url, exist := r.urls[emPubKey]
+	return url, exist
+}
@@ -0,0 +1,191 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net"
+	"net/url"
+	"time"
+
+	"github.com/uniris/uniris-core/api/pkg/adding"
+	"github.com/uniris/uniris-core/api/pkg/listing"
+)
+
+//ErrInvalidCallbackURL is returned when the callback URL is not an absolute HTTP(S) URL targeting a public host
+var ErrInvalidCallbackURL = errors.New("Invalid callback URL")
+
+const (
+
+	//maxCallbackAttempts is the number of deliveries tried before giving up a callback
+	maxCallbackAttempts = 5
+
+	//callbackBackoff is the delay before the first retry, doubled after each failed delivery
+	callbackBackoff = time.Second
+
+	//callbackWatchTimeout is the maximum duration a transaction is followed before its finalisation
+	callbackWatchTimeout = 15 * time.Minute
+)
+
+//Service defines methods to call back the emitters when their transactions are finalised
+type Service interface {
+
+	//RegisterCallback registers the callback URL of an authorized emitter
+	RegisterCallback(reg Registration) error
+
+	//WatchAccountCreation follows the transactions of an account creation and calls back the emitter once each of them reaches a final status
+	//
+	//Nothing is followed when the emitter has not registered a callback URL
+	WatchAccountCreation(emPubKey string, res adding.AccountCreationResult)
+}
+
+//RobotClient define methods to interfact with the robot
+type RobotClient interface {
+
+	//IsEmitterAuthorized asks the datamining service if the public key is related to an authorized emitter
+	IsEmitterAuthorized(emPubKey string) error
+
+	//WatchTransactionStatus asks the datamining service to stream the transaction status transitions
+	WatchTransactionStatus(ctx context.Context, addr string, txHash string) (<-chan listing.TransactionStatus, error)
+}
+
+//Signer defines methods to handle the signatures of the callbacks
+type Signer interface {
+
+	//VerifyWebhookRegistrationSignature checks the signature of a callback registration using the emitter public key
+	VerifyWebhookRegistrationSignature(reg Registration) error
+
+	//SignTransactionCallback signs the transaction callback with the shared robot key
+	SignTransactionCallback(cb TransactionCallback) (TransactionCallback, error)
+}
+
+//Sender defines methods to deliver the callbacks to the emitters
+type Sender interface {
+
+	//Send delivers the callback to the URL and returns an error when it is not acknowledged
+	Send(url string, cb TransactionCallback) error
+}
+
+type service struct {
+	client   RobotClient
+	sig      Signer
+	guard    listing.ReplayGuard
+	registry Registry
+	sender   Sender
+	backoff  time.Duration
+	lookup   func(host string) ([]net.IP, error)
+}
+
+//NewService creates a new webhook service
+func NewService(client RobotClient, sig Signer, guard listing.ReplayGuard, registry Registry, sender Sender) Service {
+	return newService(client, sig, guard, registry, sender, net.LookupIP)
+}
+
+func newService(client RobotClient, sig Signer, guard listing.ReplayGuard, registry Registry, sender Sender, lookup func(host string) ([]net.IP, error)) service {
+	return service{
+		client:   client,
+		sig:      sig,
+		guard:    guard,
+		registry: registry,
+		sender:   sender,
+		backoff:  callbackBackoff,
+		lookup:   lookup,
+	}
+}
+
+func (s service) RegisterCallback(reg Registration) error {
+	if err := s.sig.VerifyWebhookRegistrationSignature(reg); err != nil {
+		return err
+	}
+
+	if err := s.guard.CheckRequest(reg.EmitterPublicKey(), reg); err != nil {
+		return err
+	}
+
+	if err := s.client.IsEmitterAuthorized(reg.EmitterPublicKey()); err != nil {
+		return err
+	}
+
+	if err := s.checkCallbackURL(reg.CallbackURL()); err != nil {
+		return err
+	}
+
+	s.registry.Register(reg.EmitterPublicKey(), reg.CallbackURL())
+	return nil
+}
+
+//checkCallbackURL checks the callback URL is an absolute HTTP(S) URL whose host resolves only to public IPs
+//
+//The sender checks the resolved IPs again when it connects, as the DNS records can change after the registration
+func (s service) checkCallbackURL(cbURL string) error {
+	u, err := url.Parse(cbURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == "" {
+		return ErrInvalidCallbackURL
+	}
+
+	ips := []net.IP{net.ParseIP(u.Hostname())}
+	if ips[0] == nil {
+		if ips, err = s.lookup(u.Hostname()); err != nil || len(ips) == 0 {
+			return ErrInvalidCallbackURL
+		}
+	}
+	for _, ip := range ips {
+		if !IsPublicIP(ip) {
+			return ErrInvalidCallbackURL
+		}
+	}
+	return nil
+}
+
+func (s service) WatchAccountCreation(emPubKey string, res adding.AccountCreationResult) {
+	cbURL, exist := s.registry.CallbackURL(emPubKey)
+	if !exist {
+		return
+	}
+
+	go s.watch(cbURL, IDTransaction, res.ResultTransactions().ID())
+	go s.watch(cbURL, KeychainTransaction, res.ResultTransactions().Keychain())
+}
+
+//watch waits for the final status of the transaction to deliver its callback
+func (s service) watch(cbURL string, txType string, tx adding.TransactionResult) {
+	ctx, cancel := context.WithTimeout(context.Background(), callbackWatchTimeout)
+	defer cancel()
+
+	statuses, err := s.client.WatchTransactionStatus(ctx, tx.EncryptedAddress(), tx.TransactionHash())
+	if err != nil {
+		log.Printf("Callback watch error: %s", err.Error())
+		return
+	}
+
+	for status := range statuses {
+		if status.IsFinal() {
+			s.deliver(cbURL, NewTransactionCallback(tx.TransactionHash(), txType, status, time.Now(), ""))
+			return
+		}
+	}
+	log.Printf("Callback abandoned: transaction %s not finalised", tx.TransactionHash())
+}
+
+//deliver sends the signed callback, retrying with an exponential backoff until it is acknowledged
+func (s service) deliver(cbURL string, cb TransactionCallback) {
+	cb, err := s.sig.SignTransactionCallback(cb)
+	if err != nil {
+		log.Printf("Callback signature error: %s", err.Error())
+		return
+	}
+
+	backoff := s.backoff
+	for attempt := 1; ; attempt++ {
+		err := s.sender.Send(cbURL, cb)
+		if err == nil {
+			return
+		}
+		if attempt == maxCallbackAttempts {
+			log.Printf("Callback delivery error: %s", err.Error())
+			return
+		}
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+}
@@ -0,0 +1,220 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/uniris/uniris-core/api/pkg/adding"
+	"github.com/uniris/uniris-core/api/pkg/listing"
+)
+
+/*
+Scenario: Register a callback URL
+	Given an authorized emitter and a signed registration
+	When I want to register its callback URL
+	Then the callback URL is stored for the emitter
+*/
+func TestRegisterCallback(t *testing.T) {
+	reg := NewRegistry()
+	s := newService(mockClient{}, mockSigner{}, listing.NewReplayGuard(), reg, nil, mockLookup)
+
+	err := s.RegisterCallback(NewRegistration("em pub key", "https://callback", time.Now(), "nonce", "sig"))
+	assert.Nil(t, err)
+
+	url, exist := reg.CallbackURL("em pub key")
+	assert.True(t, exist)
+	assert.Equal(t, "https://callback", url)
+}
+
+/*
+Scenario: Register an invalid callback
+	Given a registration with an invalid signature, an unauthorized emitter or a relative URL
+	When I want to register the callback URL
+	Then I get an error and nothing is stored
+*/
+func TestRegisterInvalidCallback(t *testing.T) {
+	reg := NewRegistry()
+
+	s := newService(mockClient{}, mockSigner{isInvalid: true}, listing.NewReplayGuard(), reg, nil, mockLookup)
+	err := s.RegisterCallback(NewRegistration("em pub key", "https://callback", time.Now(), "nonce", "sig"))
+	assert.Equal(t, errors.New("Invalid signature"), err)
+
+	s = newService(mockClient{}, mockSigner{}, listing.NewReplayGuard(), reg, nil, mockLookup)
+	err = s.RegisterCallback(NewRegistration("other key", "https://callback", time.Now(), "nonce", "sig"))
+	assert.Equal(t, listing.ErrUnauthorized, err)
+
+	err = s.RegisterCallback(NewRegistration("em pub key", "/callback", time.Now(), "nonce2", "sig"))
+	assert.Equal(t, ErrInvalidCallbackURL, err)
+
+	_, exist := reg.CallbackURL("em pub key")
+	assert.False(t, exist)
+}
+
+/*
+Scenario: Register a callback targeting a non public host
+	Given callback URLs targeting loopback, private or link-local addresses, directly or through the DNS
+	When I want to register them
+	Then I get an error and nothing is stored
+*/
+func TestRegisterNonPublicCallback(t *testing.T) {
+	reg := NewRegistry()
+	s := newService(mockClient{}, mockSigner{}, listing.NewReplayGuard(), reg, nil, mockLookup)
+
+	urls := []string{
+		"http://127.0.0.1:8080/callback",
+		"https://10.0.0.2/callback",
+		"https://192.168.1.10/callback",
+		"http://169.254.169.254/latest/meta-data",
+		"http://[::1]/callback",
+		"http://0.0.0.0/callback",
+		"https://internal/callback",
+		"https://unknown/callback",
+	}
+	for i, u := range urls {
+		err := s.RegisterCallback(NewRegistration("em pub key", u, time.Now(), fmt.Sprintf("nonce%d", i), "sig"))
+		assert.Equal(t, ErrInvalidCallbackURL, err, u)
+	}
+
+	_, exist := reg.CallbackURL("em pub key")
+	assert.False(t, exist)
+}
+
+/*
+Scenario: Call back the emitter of an account creation
+	Given an emitter with a registered callback URL
+	When the transactions of its account creation reach a final status
+	Then the emitter receives a signed callback for each transaction
+*/
+func TestWatchAccountCreation(t *testing.T) {
+	reg := NewRegistry()
+	reg.Register("em pub key", "https://callback")
+	sender := &mockSender{callbacks: make(chan TransactionCallback, 2)}
+	s := NewService(mockClient{}, mockSigner{}, listing.NewReplayGuard(), reg, sender)
+
+	s.WatchAccountCreation("em pub key", newAccountCreationResult())
+
+	types := make([]string, 0)
+	for i := 0; i < 2; i++ {
+		cb := <-sender.callbacks
+		assert.Equal(t, listing.TransactionSuccess, cb.Status())
+		assert.Equal(t, "sig", cb.Signature())
+		types = append(types, cb.TransactionType())
+	}
+	assert.ElementsMatch(t, []string{IDTransaction, KeychainTransaction}, types)
+}
+
+/*
+Scenario: Retry a callback not acknowledged
+	Given a callback URL failing the first deliveries
+	When a transaction reaches a final status
+	Then the callback is delivered again with a backoff until it is acknowledged
+*/
+func TestRetryCallback(t *testing.T) {
+	sender := &mockSender{callbacks: make(chan TransactionCallback, 1), failures: 2}
+	s := service{
+		sig:     mockSigner{},
+		sender:  sender,
+		backoff: time.Millisecond,
+	}
+
+	s.deliver("https://callback", NewTransactionCallback("hash", IDTransaction, listing.TransactionFailure, time.Now(), ""))
+
+	assert.Equal(t, 3, sender.attempts)
+	cb := <-sender.callbacks
+	assert.Equal(t, listing.TransactionFailure, cb.Status())
+}
+
+/*
+Scenario: Give up a callback never acknowledged
+	Given a callback URL always failing
+	When a transaction reaches a final status
+	Then the delivery stops after the maximum number of attempts
+*/
+func TestGiveUpCallback(t *testing.T) {
+	sender := &mockSender{callbacks: make(chan TransactionCallback, 1), failures: maxCallbackAttempts}
+	s := service{
+		sig:     mockSigner{},
+		sender:  sender,
+		backoff: time.Millisecond,
+	}
+
+	s.deliver("https://callback", NewTransactionCallback("hash", IDTransaction, listing.TransactionSuccess, time.Now(), ""))
+
+	assert.Equal(t, maxCallbackAttempts, sender.attempts)
+	assert.Empty(t, sender.callbacks)
+}
+
+func newAccountCreationResult() adding.AccountCreationResult {
+	return adding.NewAccountCreationResult(
+		adding.NewAccountCreationTransactionResult(
+			adding.NewTransactionResult("id hash", "ip", "enc addr", "sig"),
+			adding.NewTransactionResult("keychain hash", "ip", "enc addr", "sig"),
+		), "sig")
+}
+
+func mockLookup(host string) ([]net.IP, error) {
+	switch host {
+	case "callback":
+		return []net.IP{net.ParseIP("203.0.113.10")}, nil
+	case "internal":
+		return []net.IP{net.ParseIP("203.0.113.10"), net.ParseIP("192.168.1.10")}, nil
+	}
+	return nil, errors.New("Unknown host")
+}
+
+type mockClient struct{}
+
+func (c mockClient) IsEmitterAuthorized(emPubKey string) error {
+	if emPubKey == "em pub key" {
+		return nil
+	}
+	return listing.ErrUnauthorized
+}
+
+func (c mockClient) WatchTransactionStatus(ctx context.Context, addr string, txHash string) (<-chan listing.TransactionStatus, error) {
+	statuses := make(chan listing.TransactionStatus, 2)
+	statuses <- listing.TransactionPending
+	statuses <- listing.TransactionSuccess
+	close(statuses)
+	return statuses, nil
+}
+
+type mockSigner struct {
+	isInvalid bool
+}
+
+func (s mockSigner) VerifyWebhookRegistrationSignature(reg Registration) error {
+	if s.isInvalid {
+		return errors.New("Invalid signature")
+	}
+	return nil
+}
+
+func (s mockSigner) SignTransactionCallback(cb TransactionCallback) (TransactionCallback, error) {
+	return NewTransactionCallback(cb.TransactionHash(), cb.TransactionType(), cb.Status(), cb.Timestamp(), "sig"), nil
+}
+
+type mockSender struct {
+	mu        sync.Mutex
+	callbacks chan TransactionCallback
+	failures  int
+	attempts  int
+}
+
+func (s *mockSender) Send(url string, cb TransactionCallback) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.attempts++
+	if s.attempts <= s.failures {
+		return errors.New("Callback rejected")
+	}
+	s.callbacks <- cb
+	return nil
+}
@@ -50,7 +50,45 @@ func (x PeerAppState_PeerStatus) String() string {
 	return proto.EnumName(PeerAppState_PeerStatus_name, int32(x))
 }
 func (PeerAppState_PeerStatus) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_discovery_d4b7115d75ee0068, []int{7, 0}
+	return fileDescriptor_discovery_3c6e6cb99690f5ea, []int{9, 0}
+}
+
+type KnownPeers struct {
+	Peers                []*PeerIdentity `protobuf:"bytes,1,rep,name=Peers,proto3" json:"Peers,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *KnownPeers) Reset()         { *m = KnownPeers{} }
+func (m *KnownPeers) String() string { return proto.CompactTextString(m) }
+func (*KnownPeers) ProtoMessage()    {}
+func (*KnownPeers) Descriptor() ([]byte, []int) {
+	return fileDescriptor_discovery_3c6e6cb99690f5ea, []int{0}
+}
+func (m *KnownPeers) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_KnownPeers.Unmarshal(m, b)
+}
+func (m *KnownPeers) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_KnownPeers.Marshal(b, m, deterministic)
+}
+func (dst *KnownPeers) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_KnownPeers.Merge(dst, src)
+}
+func (m *KnownPeers) XXX_Size() int {
+	return xxx_messageInfo_KnownPeers.Size(m)
+}
+func (m *KnownPeers) XXX_DiscardUnknown() {
+	xxx_messageInfo_KnownPeers.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_KnownPeers proto.InternalMessageInfo
+
+func (m *KnownPeers) GetPeers() []*PeerIdentity {
+	if m != nil {
+		return m.Peers
+	}
+	return nil
 }
 
 type SynRequest struct {
@@ -66,7 +104,7 @@ func (m *SynRequest) Reset()         { *m = SynRequest{} }
 func (m *SynRequest) String() string { return proto.CompactTextString(m) }
 func (*SynRequest) ProtoMessage()    {}
 func (*SynRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_discovery_d4b7115d75ee0068, []int{0}
+	return fileDescriptor_discovery_3c6e6cb99690f5ea, []int{1}
 }
 func (m *SynRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SynRequest.Unmarshal(m, b)
@@ -120,7 +158,7 @@ func (m *AckRequest) Reset()         { *m = AckRequest{} }
 func (m *AckRequest) String() string { return proto.CompactTextString(m) }
 func (*AckRequest) ProtoMessage()    {}
 func (*AckRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_discovery_d4b7115d75ee0068, []int{1}
+	return fileDescriptor_discovery_3c6e6cb99690f5ea, []int{2}
 }
 func (m *AckRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_AckRequest.Unmarshal(m, b)
@@ -175,7 +213,7 @@ func (m *SynAck) Reset()         { *m = SynAck{} }
 func (m *SynAck) String() string { return proto.CompactTextString(m) }
 func (*SynAck) ProtoMessage()    {}
 func (*SynAck) Descriptor() ([]byte, []int) {
-	return fileDescriptor_discovery_d4b7115d75ee0068, []int{2}
+	return fileDescriptor_discovery_3c6e6cb99690f5ea, []int{3}
 }
 func (m *SynAck) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SynAck.Unmarshal(m, b)
@@ -226,6 +264,7 @@ func (m *SynAck) GetUnknownPeers() []*PeerDigest {
 type PeerDigest struct {
 	Identity             *PeerIdentity       `protobuf:"bytes,1,opt,name=Identity,proto3" json:"Identity,omitempty"`
 	HeartbeatState       *PeerHeartbeatState `protobuf:"bytes,2,opt,name=HeartbeatState,proto3" json:"HeartbeatState,omitempty"`
+	Signature            *PeerSignature      `protobuf:"bytes,3,opt,name=Signature,proto3" json:"Signature,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
 	XXX_unrecognized     []byte              `json:"-"`
 	XXX_sizecache        int32               `json:"-"`
@@ -235,7 +274,7 @@ func (m *PeerDigest) Reset()         { *m = PeerDigest{} }
 func (m *PeerDigest) String() string { return proto.CompactTextString(m) }
 func (*PeerDigest) ProtoMessage()    {}
 func (*PeerDigest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_discovery_d4b7115d75ee0068, []int{3}
+	return fileDescriptor_discovery_3c6e6cb99690f5ea, []int{4}
 }
 func (m *PeerDigest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PeerDigest.Unmarshal(m, b)
@@ -269,10 +308,18 @@ func (m *PeerDigest) GetHeartbeatState() *PeerHeartbeatState {
 	return nil
 }
 
+func (m *PeerDigest) GetSignature() *PeerSignature {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
 type PeerDiscovered struct {
 	Identity             *PeerIdentity       `protobuf:"bytes,1,opt,name=Identity,proto3" json:"Identity,omitempty"`
 	HeartbeatState       *PeerHeartbeatState `protobuf:"bytes,2,opt,name=HeartbeatState,proto3" json:"HeartbeatState,omitempty"`
 	AppState             *PeerAppState       `protobuf:"bytes,3,opt,name=AppState,proto3" json:"AppState,omitempty"`
+	Signature            *PeerSignature      `protobuf:"bytes,4,opt,name=Signature,proto3" json:"Signature,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
 	XXX_unrecognized     []byte              `json:"-"`
 	XXX_sizecache        int32               `json:"-"`
@@ -282,7 +329,7 @@ func (m *PeerDiscovered) Reset()         { *m = PeerDiscovered{} }
 func (m *PeerDiscovered) String() string { return proto.CompactTextString(m) }
 func (*PeerDiscovered) ProtoMessage()    {}
 func (*PeerDiscovered) Descriptor() ([]byte, []int) {
-	return fileDescriptor_discovery_d4b7115d75ee0068, []int{4}
+	return fileDescriptor_discovery_3c6e6cb99690f5ea, []int{5}
 }
 func (m *PeerDiscovered) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PeerDiscovered.Unmarshal(m, b)
@@ -323,6 +370,62 @@ func (m *PeerDiscovered) GetAppState() *PeerAppState {
 	return nil
 }
 
+func (m *PeerDiscovered) GetSignature() *PeerSignature {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+// Signatures of a peer by its owner
+type PeerSignature struct {
+	//Signature of the identity and the heartbeat state
+	Digest string `protobuf:"bytes,1,opt,name=Digest,proto3" json:"Digest,omitempty"`
+	//Signature of the identity, the heartbeat state and the app state
+	State                string   `protobuf:"bytes,2,opt,name=State,proto3" json:"State,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PeerSignature) Reset()         { *m = PeerSignature{} }
+func (m *PeerSignature) String() string { return proto.CompactTextString(m) }
+func (*PeerSignature) ProtoMessage()    {}
+func (*PeerSignature) Descriptor() ([]byte, []int) {
+	return fileDescriptor_discovery_3c6e6cb99690f5ea, []int{6}
+}
+func (m *PeerSignature) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PeerSignature.Unmarshal(m, b)
+}
+func (m *PeerSignature) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PeerSignature.Marshal(b, m, deterministic)
+}
+func (dst *PeerSignature) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PeerSignature.Merge(dst, src)
+}
+func (m *PeerSignature) XXX_Size() int {
+	return xxx_messageInfo_PeerSignature.Size(m)
+}
+func (m *PeerSignature) XXX_DiscardUnknown() {
+	xxx_messageInfo_PeerSignature.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PeerSignature proto.InternalMessageInfo
+
+func (m *PeerSignature) GetDigest() string {
+	if m != nil {
+		return m.Digest
+	}
+	return ""
+}
+
+func (m *PeerSignature) GetState() string {
+	if m != nil {
+		return m.State
+	}
+	return ""
+}
+
 type PeerIdentity struct {
 	PublicKey            string   `protobuf:"bytes,1,opt,name=PublicKey,proto3" json:"PublicKey,omitempty"`
 	IP                   string   `protobuf:"bytes,2,opt,name=IP,proto3" json:"IP,omitempty"`
@@ -336,7 +439,7 @@ func (m *PeerIdentity) Reset()         { *m = PeerIdentity{} }
 func (m *PeerIdentity) String() string { return proto.CompactTextString(m) }
 func (*PeerIdentity) ProtoMessage()    {}
 func (*PeerIdentity) Descriptor() ([]byte, []int) {
-	return fileDescriptor_discovery_d4b7115d75ee0068, []int{5}
+	return fileDescriptor_discovery_3c6e6cb99690f5ea, []int{7}
 }
 func (m *PeerIdentity) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PeerIdentity.Unmarshal(m, b)
@@ -389,7 +492,7 @@ func (m *PeerHeartbeatState) Reset()         { *m = PeerHeartbeatState{} }
 func (m *PeerHeartbeatState) String() string { return proto.CompactTextString(m) }
 func (*PeerHeartbeatState) ProtoMessage()    {}
 func (*PeerHeartbeatState) Descriptor() ([]byte, []int) {
-	return fileDescriptor_discovery_d4b7115d75ee0068, []int{6}
+	return fileDescriptor_discovery_3c6e6cb99690f5ea, []int{8}
 }
 func (m *PeerHeartbeatState) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PeerHeartbeatState.Unmarshal(m, b)
@@ -440,7 +543,7 @@ func (m *PeerAppState) Reset()         { *m = PeerAppState{} }
 func (m *PeerAppState) String() string { return proto.CompactTextString(m) }
 func (*PeerAppState) ProtoMessage()    {}
 func (*PeerAppState) Descriptor() ([]byte, []int) {
-	return fileDescriptor_discovery_d4b7115d75ee0068, []int{7}
+	return fileDescriptor_discovery_3c6e6cb99690f5ea, []int{9}
 }
 func (m *PeerAppState) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PeerAppState.Unmarshal(m, b)
@@ -521,7 +624,7 @@ func (m *PeerAppState_GeoCoordinates) Reset()         { *m = PeerAppState_GeoCoo
 func (m *PeerAppState_GeoCoordinates) String() string { return proto.CompactTextString(m) }
 func (*PeerAppState_GeoCoordinates) ProtoMessage()    {}
 func (*PeerAppState_GeoCoordinates) Descriptor() ([]byte, []int) {
-	return fileDescriptor_discovery_d4b7115d75ee0068, []int{7, 0}
+	return fileDescriptor_discovery_3c6e6cb99690f5ea, []int{9, 0}
 }
 func (m *PeerAppState_GeoCoordinates) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PeerAppState_GeoCoordinates.Unmarshal(m, b)
@@ -556,11 +659,13 @@ func (m *PeerAppState_GeoCoordinates) GetLon() float32 {
 }
 
 func init() {
+	proto.RegisterType((*KnownPeers)(nil), "api.KnownPeers")
 	proto.RegisterType((*SynRequest)(nil), "api.SynRequest")
 	proto.RegisterType((*AckRequest)(nil), "api.AckRequest")
 	proto.RegisterType((*SynAck)(nil), "api.SynAck")
 	proto.RegisterType((*PeerDigest)(nil), "api.PeerDigest")
 	proto.RegisterType((*PeerDiscovered)(nil), "api.PeerDiscovered")
+	proto.RegisterType((*PeerSignature)(nil), "api.PeerSignature")
 	proto.RegisterType((*PeerIdentity)(nil), "api.PeerIdentity")
 	proto.RegisterType((*PeerHeartbeatState)(nil), "api.PeerHeartbeatState")
 	proto.RegisterType((*PeerAppState)(nil), "api.PeerAppState")
@@ -673,50 +778,120 @@ var _Discovery_serviceDesc = grpc.ServiceDesc{
 	Metadata: "discovery.proto",
 }
 
-func init() { proto.RegisterFile("discovery.proto", fileDescriptor_discovery_d4b7115d75ee0068) }
-
-var fileDescriptor_discovery_d4b7115d75ee0068 = []byte{
-	// 665 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x55, 0xdd, 0x6e, 0xd3, 0x30,
-	0x14, 0x5e, 0x9a, 0xad, 0x5b, 0x4f, 0x47, 0xd7, 0x19, 0x01, 0x51, 0xd9, 0x45, 0x15, 0x21, 0xd8,
-	0x05, 0xeb, 0xa4, 0x6e, 0x17, 0x48, 0x5c, 0xa0, 0xee, 0x97, 0x6a, 0xd3, 0x16, 0xb9, 0x1b, 0xf7,
-	0x6e, 0x72, 0x28, 0xa6, 0x9d, 0x1d, 0x1c, 0x97, 0x29, 0x88, 0xd7, 0xe0, 0x01, 0x78, 0x02, 0xc4,
-	0x4b, 0xf0, 0x5c, 0x28, 0x4e, 0xd2, 0xf4, 0x4f, 0xe2, 0x6a, 0xdc, 0xd9, 0xdf, 0xf7, 0x9d, 0x9c,
-	0xef, 0x1c, 0xe7, 0xd8, 0xb0, 0x15, 0xf0, 0xc8, 0x97, 0x5f, 0x51, 0xc5, 0xad, 0x50, 0x49, 0x2d,
-	0x89, 0xcd, 0x42, 0xde, 0x78, 0x3e, 0x90, 0x72, 0x30, 0xc2, 0x7d, 0x03, 0xf5, 0xc7, 0x1f, 0xf7,
-	0xf1, 0x2e, 0xd4, 0x99, 0xc2, 0xfd, 0x61, 0x01, 0xf4, 0x62, 0x41, 0xf1, 0xcb, 0x18, 0x23, 0x4d,
-	0xf6, 0xa0, 0xd2, 0x15, 0x5c, 0x73, 0xa6, 0xa5, 0x72, 0xac, 0xa6, 0xb5, 0x5b, 0x6d, 0x6f, 0xb5,
-	0x58, 0xc8, 0x5b, 0x1e, 0xa2, 0x3a, 0xe1, 0x03, 0x8c, 0x34, 0x2d, 0x14, 0xe4, 0x15, 0x94, 0x6f,
-	0x98, 0x1a, 0xa0, 0x76, 0x4a, 0xcb, 0xb5, 0x19, 0x4d, 0xf6, 0x01, 0x2e, 0x84, 0xbc, 0x17, 0x09,
-	0x15, 0x39, 0x76, 0xd3, 0x5e, 0x26, 0x9e, 0x92, 0xb8, 0x3f, 0x2d, 0x80, 0x8e, 0x3f, 0x7c, 0x68,
-	0x5f, 0x6f, 0xa1, 0x96, 0xa5, 0xc0, 0x60, 0xda, 0xdb, 0xe3, 0xa9, 0x80, 0xb4, 0xa5, 0x18, 0xd0,
-	0x39, 0xa9, 0xfb, 0xc7, 0x82, 0x72, 0x2f, 0x16, 0x1d, 0x7f, 0xf8, 0x80, 0x7d, 0xdb, 0xb8, 0xc2,
-	0xfb, 0x7f, 0x3a, 0x9b, 0x88, 0xc8, 0x01, 0x6c, 0xde, 0x8a, 0x61, 0xd1, 0xea, 0xd5, 0xe5, 0xad,
-	0x9e, 0x11, 0xb9, 0xdf, 0x01, 0x0a, 0x8e, 0xec, 0xc1, 0x46, 0x37, 0x40, 0xa1, 0xb9, 0x8e, 0xb3,
-	0x52, 0xb6, 0x27, 0xe1, 0x39, 0x41, 0x27, 0x12, 0xf2, 0x0e, 0x6a, 0xef, 0x91, 0x29, 0xdd, 0x47,
-	0xa6, 0x7b, 0x9a, 0x69, 0xcc, 0x6a, 0x7a, 0x36, 0x09, 0x9a, 0xa5, 0xe9, 0x9c, 0xdc, 0xfd, 0x65,
-	0x41, 0x6d, 0xb6, 0x9e, 0xff, 0x6d, 0x21, 0xc9, 0xd7, 0x09, 0xc3, 0x34, 0xd4, 0x9e, 0xcb, 0x97,
-	0x13, 0x74, 0x22, 0x71, 0x3d, 0xd8, 0x9c, 0x76, 0x42, 0x76, 0xa0, 0xe2, 0x8d, 0xfb, 0x23, 0xee,
-	0x5f, 0x60, 0xea, 0xb7, 0x42, 0x0b, 0x80, 0xd4, 0xa0, 0xd4, 0xf5, 0x8c, 0xa3, 0x0a, 0x2d, 0x75,
-	0x3d, 0x42, 0x60, 0xd5, 0x93, 0x4a, 0x9b, 0x44, 0x6b, 0xd4, 0xac, 0xdd, 0xcf, 0x40, 0x16, 0x6d,
-	0x92, 0x97, 0x50, 0x3b, 0x47, 0x81, 0x8a, 0x69, 0x2e, 0xc5, 0x0d, 0xbf, 0x43, 0xf3, 0x71, 0x9b,
-	0xce, 0xa1, 0xe4, 0x35, 0x6c, 0x9f, 0x8e, 0x58, 0x18, 0x61, 0x30, 0xf9, 0x40, 0x64, 0x12, 0xda,
-	0x74, 0x91, 0x70, 0x7f, 0xdb, 0xa9, 0xfd, 0xbc, 0x1c, 0x72, 0x08, 0xe5, 0x64, 0x31, 0x4e, 0xfe,
-	0x16, 0x6b, 0xb7, 0xd6, 0xde, 0x59, 0xa8, 0xdd, 0x6c, 0x52, 0x0d, 0xcd, 0xb4, 0xc4, 0x81, 0xf5,
-	0x63, 0xef, 0xf6, 0x52, 0xb2, 0xc0, 0x59, 0x33, 0xb5, 0xe5, 0x5b, 0xf2, 0x02, 0x1e, 0x9d, 0x29,
-	0xc4, 0x13, 0x1e, 0x0d, 0x7b, 0x21, 0xf3, 0xd1, 0x29, 0x37, 0xad, 0xdd, 0x12, 0x9d, 0x05, 0x93,
-	0xf8, 0x0f, 0xa8, 0x22, 0x2e, 0x85, 0xb3, 0x9e, 0xc6, 0x67, 0x5b, 0x72, 0x04, 0xd5, 0x73, 0x94,
-	0x9e, 0x8c, 0x78, 0x52, 0xa1, 0xb3, 0x61, 0x0e, 0xa4, 0xb9, 0x68, 0xea, 0x1c, 0xe5, 0xb1, 0x94,
-	0x2a, 0xe0, 0x82, 0x69, 0x8c, 0xe8, 0x74, 0x90, 0x39, 0x92, 0xb6, 0x77, 0xc6, 0xfc, 0x64, 0x20,
-	0x2b, 0xa6, 0xd3, 0x05, 0x40, 0x0e, 0xe1, 0x49, 0xf1, 0xb7, 0x99, 0x19, 0xb8, 0x1a, 0xdf, 0xf5,
-	0x51, 0x39, 0x60, 0x94, 0xcb, 0xc9, 0xc6, 0x61, 0x72, 0x1c, 0xd3, 0x29, 0x49, 0x1d, 0xec, 0x4b,
-	0xa6, 0xcd, 0xa9, 0x94, 0x68, 0xb2, 0x34, 0x88, 0x14, 0xa6, 0xf9, 0x09, 0x22, 0x85, 0x7b, 0x9c,
-	0x0e, 0x57, 0xd6, 0xb5, 0x3a, 0x6c, 0x1e, 0x49, 0xa9, 0x23, 0xad, 0x58, 0xc8, 0xc5, 0xa0, 0xbe,
-	0x42, 0xca, 0x50, 0xba, 0x1e, 0xd6, 0x2d, 0x02, 0x50, 0x3e, 0x63, 0xe3, 0x91, 0x8e, 0xeb, 0x25,
-	0xb2, 0x05, 0xd5, 0x9e, 0x96, 0x8a, 0x0d, 0xf0, 0x5a, 0x8c, 0xe2, 0xba, 0xdd, 0xd6, 0x50, 0xc9,
-	0x3d, 0xc5, 0x64, 0x0f, 0xaa, 0xbd, 0x58, 0xf8, 0x9f, 0x94, 0x14, 0xfc, 0x1b, 0x92, 0x74, 0xb8,
-	0x8b, 0x4b, 0xbc, 0x51, 0xcd, 0x81, 0x8e, 0x3f, 0x74, 0x57, 0xc8, 0x1b, 0xa8, 0x76, 0xfc, 0x64,
-	0xda, 0x47, 0x18, 0x0c, 0x72, 0x79, 0x71, 0xb7, 0x36, 0x9e, 0xb6, 0xd2, 0x07, 0xa2, 0x95, 0x3f,
-	0x10, 0xad, 0xd3, 0xe4, 0x81, 0x70, 0x57, 0xfa, 0x65, 0x83, 0x1c, 0xfc, 0x0d, 0x00, 0x00, 0xff,
-	0xff, 0x38, 0x75, 0xe5, 0xf6, 0x58, 0x06, 0x00, 0x00,
+// InternalClient is the client API for Internal service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type InternalClient interface {
+	ListKnownPeers(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*KnownPeers, error)
+}
+
+type internalClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewInternalClient(cc *grpc.ClientConn) InternalClient {
+	return &internalClient{cc}
+}
+
+func (c *internalClient) ListKnownPeers(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*KnownPeers, error) {
+	out := new(KnownPeers)
+	err := c.cc.Invoke(ctx, "/api.Internal/ListKnownPeers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// InternalServer is the server API for Internal service.
+type InternalServer interface {
+	ListKnownPeers(context.Context, *empty.Empty) (*KnownPeers, error)
+}
+
+func RegisterInternalServer(s *grpc.Server, srv InternalServer) {
+	s.RegisterService(&_Internal_serviceDesc, srv)
+}
+
+func _Internal_ListKnownPeers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InternalServer).ListKnownPeers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.Internal/ListKnownPeers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InternalServer).ListKnownPeers(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Internal_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "api.Internal",
+	HandlerType: (*InternalServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListKnownPeers",
+			Handler:    _Internal_ListKnownPeers_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "discovery.proto",
+}
+
+func init() { proto.RegisterFile("discovery.proto", fileDescriptor_discovery_3c6e6cb99690f5ea) }
+
+var fileDescriptor_discovery_3c6e6cb99690f5ea = []byte{
+	// 760 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x55, 0xdd, 0x8e, 0xdb, 0x44,
+	0x14, 0x5e, 0xdb, 0x1b, 0x37, 0x39, 0xd9, 0x66, 0xd3, 0x01, 0x8a, 0x15, 0x7a, 0x11, 0x59, 0x88,
+	0xee, 0x05, 0xc9, 0xa2, 0x74, 0x91, 0x90, 0x10, 0x42, 0xe9, 0xb6, 0x1b, 0xa2, 0xae, 0x5a, 0x6b,
+	0xd2, 0x72, 0x3f, 0xb1, 0x0f, 0x66, 0x48, 0x76, 0xc6, 0x8c, 0x27, 0x54, 0xe6, 0x3d, 0x78, 0x00,
+	0x5e, 0x00, 0x89, 0x97, 0xe0, 0x3d, 0x78, 0x13, 0xe4, 0xb1, 0x63, 0x3b, 0x3f, 0x08, 0x6e, 0xda,
+	0xbb, 0x99, 0x73, 0xbe, 0x6f, 0xe6, 0x7c, 0xdf, 0xfc, 0x1c, 0x38, 0x8f, 0x78, 0x1a, 0xca, 0x5f,
+	0x50, 0x65, 0xe3, 0x44, 0x49, 0x2d, 0x89, 0xc3, 0x12, 0x3e, 0xf8, 0x24, 0x96, 0x32, 0x5e, 0xe3,
+	0xa5, 0x09, 0x2d, 0x37, 0x3f, 0x5c, 0xe2, 0x5d, 0xa2, 0x4b, 0x84, 0xff, 0x25, 0xc0, 0x0b, 0x21,
+	0xdf, 0x8a, 0x00, 0x51, 0xa5, 0xe4, 0x31, 0xb4, 0xcc, 0xc0, 0xb3, 0x86, 0xce, 0x45, 0x77, 0xf2,
+	0x60, 0xcc, 0x12, 0x3e, 0xce, 0x23, 0xf3, 0x08, 0x85, 0xe6, 0x3a, 0xa3, 0x45, 0xde, 0xff, 0xcd,
+	0x02, 0x58, 0x64, 0x82, 0xe2, 0xcf, 0x1b, 0x4c, 0x35, 0x19, 0x41, 0x67, 0x2e, 0xb8, 0xe6, 0x4c,
+	0x4b, 0xe5, 0x59, 0x43, 0xeb, 0xa2, 0x3b, 0x39, 0xaf, 0xb8, 0xcf, 0x78, 0x8c, 0xa9, 0xa6, 0x35,
+	0x82, 0x3c, 0x06, 0xf7, 0x35, 0x53, 0x31, 0x6a, 0xcf, 0x3e, 0x8e, 0x2d, 0xd3, 0xe4, 0xb2, 0x59,
+	0x9d, 0xe7, 0x0c, 0x9d, 0x63, 0xe0, 0x06, 0xc4, 0xff, 0xdd, 0x02, 0x98, 0x86, 0xab, 0x77, 0x5d,
+	0xd7, 0xd7, 0xd0, 0x2b, 0xb7, 0xc0, 0xa8, 0x59, 0xdb, 0x07, 0x0d, 0x42, 0x71, 0x12, 0x18, 0xd1,
+	0x3d, 0xa8, 0xff, 0x97, 0x05, 0xee, 0x22, 0x13, 0xd3, 0x70, 0xf5, 0x0e, 0x7d, 0x6b, 0xbf, 0xc4,
+	0xb7, 0xff, 0x59, 0x59, 0x05, 0x22, 0x4f, 0xe0, 0xec, 0x8d, 0x58, 0xd5, 0x56, 0x9f, 0x1e, 0xb7,
+	0x7a, 0x07, 0xe4, 0xff, 0x61, 0x01, 0xd4, 0x49, 0x32, 0x82, 0xf6, 0xf6, 0x9a, 0x94, 0x5a, 0x8e,
+	0xdc, 0x9f, 0x0a, 0x42, 0xbe, 0x85, 0xde, 0x77, 0xc8, 0x94, 0x5e, 0x22, 0xd3, 0x0b, 0xcd, 0x34,
+	0x96, 0xa2, 0x3e, 0xae, 0x48, 0xbb, 0x69, 0xba, 0x07, 0x27, 0x5f, 0x40, 0x67, 0xc1, 0x63, 0xc1,
+	0xf4, 0x46, 0xa1, 0xe7, 0x18, 0x2e, 0xa9, 0xb8, 0x55, 0x86, 0xd6, 0x20, 0xff, 0x6f, 0x0b, 0x7a,
+	0xbb, 0x16, 0xbc, 0xf7, 0xa2, 0x47, 0xd0, 0x9e, 0x26, 0x49, 0x41, 0x75, 0xf6, 0xf6, 0xdb, 0x26,
+	0x68, 0x05, 0xd9, 0xd5, 0x78, 0xfa, 0x7f, 0x34, 0x7e, 0x03, 0xf7, 0x77, 0x72, 0xe4, 0x21, 0xb8,
+	0xc5, 0x01, 0x19, 0x7d, 0x1d, 0x5a, 0xce, 0xc8, 0x87, 0xd0, 0xaa, 0x15, 0x74, 0x68, 0x31, 0xf1,
+	0x03, 0x38, 0x6b, 0x4a, 0x27, 0x8f, 0xa0, 0x13, 0x6c, 0x96, 0x6b, 0x1e, 0xbe, 0xc0, 0xac, 0x5c,
+	0xa0, 0x0e, 0x90, 0x1e, 0xd8, 0xf3, 0xa0, 0x5c, 0xc0, 0x9e, 0x07, 0x84, 0xc0, 0x69, 0x20, 0x95,
+	0x36, 0xca, 0x5a, 0xd4, 0x8c, 0xfd, 0x9f, 0x80, 0x1c, 0xfa, 0x42, 0x3e, 0x83, 0xde, 0x0c, 0x05,
+	0x2a, 0xa6, 0xb9, 0x14, 0xaf, 0xf9, 0x1d, 0x9a, 0xc5, 0x1d, 0xba, 0x17, 0x25, 0x9f, 0xc3, 0x83,
+	0xe7, 0x6b, 0x96, 0xa4, 0x18, 0x55, 0x0b, 0xa4, 0x66, 0x43, 0x87, 0x1e, 0x26, 0xfc, 0x3f, 0x9d,
+	0xa2, 0xfc, 0xca, 0xbf, 0x2b, 0x70, 0xf3, 0xc1, 0x26, 0x35, 0xe6, 0xf5, 0x26, 0x8f, 0x0e, 0xcc,
+	0x2e, 0x9c, 0x34, 0x18, 0x5a, 0x62, 0x89, 0x07, 0xf7, 0xae, 0x83, 0x37, 0xb7, 0x92, 0x45, 0x5e,
+	0xcb, 0x68, 0xdb, 0x4e, 0xc9, 0xa7, 0x70, 0xff, 0x46, 0x21, 0x3e, 0xe3, 0xe9, 0x6a, 0x91, 0xb0,
+	0x10, 0x3d, 0x77, 0x68, 0x5d, 0xd8, 0x74, 0x37, 0x98, 0xf3, 0xbf, 0x47, 0x95, 0x72, 0x29, 0xbc,
+	0x7b, 0x05, 0xbf, 0x9c, 0x92, 0xa7, 0xd0, 0x9d, 0xa1, 0x0c, 0x64, 0xca, 0x73, 0x85, 0x5e, 0xdb,
+	0x9c, 0xe8, 0xf0, 0xb0, 0xa8, 0x19, 0xca, 0x6b, 0x29, 0x55, 0xc4, 0x05, 0xd3, 0x98, 0xd2, 0x26,
+	0xc9, 0x1c, 0xc9, 0x24, 0xb8, 0x61, 0x61, 0xfe, 0x69, 0x74, 0x8c, 0xd3, 0x75, 0x80, 0x5c, 0xc1,
+	0x47, 0xf5, 0xf5, 0x36, 0xef, 0xf4, 0xe5, 0xe6, 0x6e, 0x89, 0xca, 0x03, 0x83, 0x3c, 0x9e, 0x1c,
+	0x5c, 0x41, 0x6f, 0x77, 0x4b, 0xd2, 0x07, 0xe7, 0x96, 0x15, 0x77, 0xc6, 0xa6, 0xf9, 0xd0, 0x44,
+	0xa4, 0xf0, 0xec, 0x32, 0x22, 0x85, 0x7f, 0x5d, 0xbc, 0xff, 0xd2, 0xb5, 0x3e, 0x9c, 0x3d, 0x95,
+	0x52, 0xa7, 0x5a, 0xb1, 0x84, 0x8b, 0xb8, 0x7f, 0x42, 0x5c, 0xb0, 0x5f, 0xad, 0xfa, 0x16, 0x01,
+	0x70, 0x6f, 0xd8, 0x66, 0xad, 0xb3, 0xbe, 0x4d, 0xce, 0xa1, 0xbb, 0xd0, 0x52, 0xb1, 0x18, 0x5f,
+	0x89, 0x75, 0xd6, 0x77, 0x26, 0x1a, 0x3a, 0xdb, 0x9a, 0x32, 0x32, 0x82, 0xee, 0x22, 0x13, 0xe1,
+	0x8f, 0x4a, 0x0a, 0xfe, 0x2b, 0x92, 0xe2, 0x03, 0xaa, 0x1b, 0xcd, 0xa0, 0xbb, 0x0d, 0x4c, 0xc3,
+	0x95, 0x7f, 0x42, 0xbe, 0x82, 0xee, 0x34, 0xcc, 0x7f, 0xa4, 0x35, 0x46, 0xf1, 0x16, 0x5e, 0xff,
+	0xff, 0x83, 0x87, 0xe3, 0xa2, 0xf7, 0x8d, 0xb7, 0xbd, 0x6f, 0xfc, 0x3c, 0xef, 0x7d, 0xfe, 0xc9,
+	0x64, 0x06, 0xed, 0xb9, 0xd0, 0xa8, 0x04, 0x5b, 0xe7, 0xbf, 0xf9, 0x2d, 0x4f, 0x75, 0xa3, 0x0f,
+	0xfe, 0x0b, 0x6f, 0x50, 0x6c, 0xd0, 0xe8, 0x37, 0x27, 0x4b, 0xd7, 0x40, 0x9e, 0xfc, 0x33, 0x00,
+	0x66, 0x6b, 0x63, 0xdc, 0x7c, 0x07, 0x00, 0x00,
 }
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"errors"
 	"flag"
 	"fmt"
 	"log"
@@ -20,10 +21,13 @@ import (
 	"github.com/uniris/uniris-core/autodiscovery/pkg/system"
 	"github.com/uniris/uniris-core/autodiscovery/pkg/transport/amqp"
 	"github.com/uniris/uniris-core/autodiscovery/pkg/transport/rpc"
+	"github.com/uniris/uniris-core/shared/pkg/connpool"
+	"github.com/uniris/uniris-core/shared/pkg/keystore"
 )
 
 const (
-	defaultConfFile = "../../../conf.yaml"
+	defaultConfFile       = "../../../conf.yaml"
+	keystorePassphraseEnv = "UNIRIS_KEYSTORE_PASSPHRASE"
 )
 
 func main() {
@@ -33,6 +37,10 @@ func main() {
 	if err != nil {
 		log.Fatal(err)
 	}
+	ks, err := openKeyStore(*conf)
+	if err != nil {
+		log.Fatal(err)
+	}
 
 	log.Print("PEER CONFIGURATION")
 	log.Print("=================================")
@@ -59,11 +67,18 @@ func main() {
 		np = system.NewPrivateNetworker(conf.Network.Interface)
 	}
 	pos := system.NewPeerPositioner()
-	msg := rpc.NewMessenger()
+	sec, err := rpc.NewTransportSecurity(*conf, system.NewCertifier(ks))
+	if err != nil {
+		log.Fatal(err)
+	}
+	pool := connpool.NewPool(connpool.DefaultMaxCalls, connpool.DefaultIdleTimeout)
+	defer pool.Close()
+	msg := rpc.NewMessenger(sec, pool)
 
 	//Setup services
 	mon := monitoring.NewService(repo, system.NewPeerMonitor(), np, system.NewRobotWatcher())
-	gos := gossip.NewService(repo, msg, notif, mon)
+	signer := system.NewPeerSigner(ks)
+	gos := gossip.NewService(repo, msg, notif, mon, signer)
 	boot := bootstraping.NewService(repo, pos, np)
 
 	//Initializes the seeds
@@ -90,7 +105,14 @@ func main() {
 
 	//Starts server
 	go func() {
-		if err := startServer(conf.Services.Discovery.Port, repo, notif); err != nil {
+		if err := startServer(conf.Services.Discovery.Port, repo, notif, sec, signer); err != nil {
+			log.Fatal(err)
+		}
+	}()
+
+	//Starts the internal server for the other services of the node
+	go func() {
+		if err := startInternalServer(conf.Services.Discovery.InternalPort, repo); err != nil {
 			log.Fatal(err)
 		}
 	}()
@@ -136,16 +158,58 @@ func loadConfiguration() (*system.UnirisConfig, error) {
 	return conf, nil
 }
 
-func startServer(port int, repo discovery.Repository, notif gossip.Notifier) error {
+//openKeyStore opens the key store holding the node private key and checks it matches the node public key
+//
+//Without key store path, the node keypair of the configuration is kept in memory
+func openKeyStore(conf system.UnirisConfig) (keystore.KeyStore, error) {
+	if conf.KeyStore.Path == "" {
+		if conf.PrivateKey == "" {
+			return nil, errors.New("Missing the key store or the private key configuration")
+		}
+		ks := keystore.NewMemoryStore()
+		if err := ks.StoreKeyPair(keystore.NodeKey, keystore.KeyPair{PublicKey: conf.PublicKey, PrivateKey: conf.PrivateKey}); err != nil {
+			return nil, err
+		}
+		return ks, nil
+	}
+	ks, err := keystore.NewFileStore(conf.KeyStore.Path, os.Getenv(keystorePassphraseEnv))
+	if err != nil {
+		return nil, err
+	}
+	pub, err := ks.PublicKey(keystore.NodeKey)
+	if err != nil {
+		return nil, err
+	}
+	if pub != conf.PublicKey {
+		return nil, keystore.ErrKeyMismatch
+	}
+	return ks, nil
+}
+
+func startServer(port int, repo discovery.Repository, notif gossip.Notifier, sec rpc.TransportSecurity, signer discovery.PeerSigner) error {
 	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
 	if err != nil {
 		return err
 	}
-	grpcServer := grpc.NewServer()
-	api.RegisterDiscoveryServer(grpcServer, rpc.NewServerHandler(repo, notif))
+	grpcServer := grpc.NewServer(sec.ServerOptions()...)
+	api.RegisterDiscoveryServer(grpcServer, rpc.NewServerHandler(repo, notif, sec, signer))
 	log.Printf("Server listening on %d", port)
 	if err := grpcServer.Serve(lis); err != nil {
 		return err
 	}
 	return nil
 }
+
+func startInternalServer(port int, repo discovery.Repository) error {
+	lis, err := net.Listen("tcp", fmt.Sprintf("127.0.0.1:%d", port))
+	if err != nil {
+		return err
+	}
+	grpcServer := grpc.NewServer()
+	api.RegisterInternalServer(grpcServer, rpc.NewInternalServerHandler(repo))
+	log.Printf("Internal server listening on 127.0.0.1:%d", port)
+	if err := grpcServer.Serve(lis); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/uniris/uniris-core/shared/pkg/keys"
+	"github.com/uniris/uniris-core/shared/pkg/keystore"
+)
+
+const keystorePassphraseEnv = "UNIRIS_KEYSTORE_PASSPHRASE"
+
+//Provisions the node keypair into an encrypted key store and prints the node public key
+//
+//A new keypair is generated only when the key store does not contain one yet
+func main() {
+	keystoreFile := flag.String("keystore", "keystore.json", "Key store file")
+	flag.Parse()
+
+	ks, err := keystore.NewFileStore(*keystoreFile, os.Getenv(keystorePassphraseEnv))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	pub, err := ks.PublicKey(keystore.NodeKey)
+	if err == keystore.ErrKeyNotFound {
+		var pv string
+		pub, pv, err = keys.GenerateKeyPair(keys.ECDSAP256)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if err := ks.StoreKeyPair(keystore.NodeKey, keystore.KeyPair{PublicKey: pub, PrivateKey: pv}); err != nil {
+			log.Fatal(err)
+		}
+	} else if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Println(pub)
+}
@@ -12,6 +12,7 @@ import (
 type Cycle struct {
 	initator discovery.Peer
 	msg      Messenger
+	signer   discovery.PeerSigner
 	result   gossipChannel
 }
 
@@ -28,9 +29,10 @@ var ErrEmptySeed = errors.New("Cannot start a gossip round without a list seeds"
 //NewGossipCycle creates a gossip cucle
 //
 //If an empty list of seeds is provided an error is returned
-func NewGossipCycle(initiator discovery.Peer, msg Messenger) *Cycle {
+func NewGossipCycle(initiator discovery.Peer, msg Messenger, signer discovery.PeerSigner) *Cycle {
 	return &Cycle{
 		msg:      msg,
+		signer:   signer,
 		initator: initiator,
 		result: gossipChannel{
 			discoveries:  make(chan discovery.Peer),
@@ -51,7 +53,7 @@ func (c Cycle) Run(init discovery.Peer, selectedP []discovery.Peer, knownPeers [
 		go func(target discovery.Peer) {
 			defer wg.Done()
 
-			r := NewGossipRound(init, target, c.msg)
+			r := NewGossipRound(init, target, c.msg, c.signer)
 
 			if err := r.Spread(knownPeers, c.result.discoveries, c.result.reaches, c.result.unreachables); err != nil {
 				c.result.errors <- err
@@ -8,6 +8,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	discovery "github.com/uniris/uniris-core/autodiscovery/pkg"
+	"github.com/uniris/uniris-core/autodiscovery/pkg/mock"
 )
 
 /*
@@ -205,7 +206,7 @@ func TestRun(t *testing.T) {
 
 	seeds := []discovery.Seed{discovery.Seed{IP: net.ParseIP("20.0.0.1"), Port: 3000, PublicKey: "key3"}}
 
-	c := NewGossipCycle(init, mockMessenger{})
+	c := NewGossipCycle(init, mockMessenger{}, new(mock.PeerSigner))
 
 	pp, err := c.SelectPeers(seeds, []discovery.Peer{kp1}, []discovery.Peer{})
 	assert.Nil(t, err)
@@ -2,6 +2,7 @@ package gossip
 
 import (
 	"errors"
+	"log"
 
 	discovery "github.com/uniris/uniris-core/autodiscovery/pkg"
 )
@@ -14,6 +15,7 @@ type Round struct {
 	initator discovery.Peer
 	target   discovery.Peer
 	msg      Messenger
+	signer   discovery.PeerSigner
 }
 
 //Messenger is the interface that provides methods to send gossip requests
@@ -83,6 +85,11 @@ func (r *Round) Spread(kp []discovery.Peer, discovP chan<- discovery.Peer, reach
 		}
 
 		for _, p := range res.NewPeers {
+			//The peers not signed by their owner are ignored
+			if err := r.signer.VerifyPeerState(p); err != nil {
+				log.Printf("Gossip ignored peer %s: %s", p.Endpoint(), err.Error())
+				continue
+			}
 			discovP <- p
 		}
 	}
@@ -99,10 +106,11 @@ func (r Round) mapPeers(pp []discovery.Peer) map[string]discovery.Peer {
 }
 
 //NewGossipRound creates a new gossip round
-func NewGossipRound(init discovery.Peer, target discovery.Peer, msg Messenger) *Round {
+func NewGossipRound(init discovery.Peer, target discovery.Peer, msg Messenger, signer discovery.PeerSigner) *Round {
 	return &Round{
 		initator: init,
 		target:   target,
 		msg:      msg,
+		signer:   signer,
 	}
 }
@@ -10,6 +10,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	discovery "github.com/uniris/uniris-core/autodiscovery/pkg"
+	"github.com/uniris/uniris-core/autodiscovery/pkg/mock"
 )
 
 /*
@@ -37,7 +38,7 @@ func TestSpreadDiscoveries(t *testing.T) {
 		discovery.NewPeerAppState("1.0", discovery.OkStatus, discovery.PeerPosition{}, "", 0, 1, 0),
 	)
 
-	g := NewGossipRound(initP, target, mockMessenger{})
+	g := NewGossipRound(initP, target, mockMessenger{}, new(mock.PeerSigner))
 
 	kp := []discovery.Peer{p1, p2}
 
@@ -76,6 +77,36 @@ func TestSpreadDiscoveries(t *testing.T) {
 	assert.NotEmpty(t, reachP)
 }
 
+/*
+Scenario: Spread a gossip round and ignore the peers not signed by their owner
+	Given a initiator peer, a receiver peer and a discovered peer with an invalid signature
+	When we start a gossip round
+	Then the peer is not discovered
+*/
+func TestSpreadIgnoresUnsignedPeers(t *testing.T) {
+	initP := discovery.NewStartupPeer("key", net.ParseIP("127.0.0.1"), 3000, "1.0", discovery.PeerPosition{})
+
+	target := discovery.NewPeerDigest(
+		discovery.NewPeerIdentity(net.ParseIP("20.100.4.120"), 3000, "key2"),
+		discovery.NewPeerHeartbeatState(time.Now(), 0))
+
+	p1 := discovery.NewDiscoveredPeer(
+		discovery.NewPeerIdentity(net.ParseIP("50.10.30.2"), 3000, "uKey1"),
+		discovery.NewPeerHeartbeatState(time.Now(), 0),
+		discovery.NewPeerAppState("1.0", discovery.OkStatus, discovery.PeerPosition{}, "", 0, 1, 0),
+	)
+
+	g := NewGossipRound(initP, target, mockMessenger{}, mock.PeerSigner{RejectedKeys: []string{"dKey1"}})
+
+	discoveries := make(chan discovery.Peer, 1)
+	reaches := make(chan discovery.Peer, 1)
+
+	err := g.Spread([]discovery.Peer{p1}, discoveries, reaches, nil)
+	assert.Nil(t, err)
+	assert.Empty(t, discoveries)
+	assert.Equal(t, 1, len(reaches))
+}
+
 /*
 Scenario: Spread gossip but unreach the target peer during the SYN request
 	Given a initiator peer, a receiver peer and list of known peers
@@ -101,7 +132,7 @@ func TestSYNSpreadUnreachables(t *testing.T) {
 		discovery.NewPeerAppState("1.0", discovery.OkStatus, discovery.PeerPosition{}, "", 0, 1, 0),
 	)
 
-	g := NewGossipRound(initP, target, mockMessengerWithSynFailure{})
+	g := NewGossipRound(initP, target, mockMessengerWithSynFailure{}, new(mock.PeerSigner))
 
 	kp := []discovery.Peer{p1, p2}
 
@@ -148,7 +179,7 @@ func TestACKSpreadUnreachables(t *testing.T) {
 		discovery.NewPeerAppState("1.0", discovery.OkStatus, discovery.PeerPosition{}, "", 0, 1, 0),
 	)
 
-	g := NewGossipRound(initP, target, mockMessengerWithAckFailure{})
+	g := NewGossipRound(initP, target, mockMessengerWithAckFailure{}, new(mock.PeerSigner))
 
 	kp := []discovery.Peer{p1, p2}
 
@@ -211,7 +242,7 @@ func TestSpreadUnexpectedError(t *testing.T) {
 		discovery.NewPeerAppState("1.0", discovery.OkStatus, discovery.PeerPosition{}, "", 0, 1, 0),
 	)
 
-	g := NewGossipRound(initP, target, mockMessengerUnexpectedFailure{})
+	g := NewGossipRound(initP, target, mockMessengerUnexpectedFailure{}, new(mock.PeerSigner))
 
 	kp := []discovery.Peer{p1, p2}
 
@@ -21,10 +21,11 @@ type Notifier interface {
 }
 
 type service struct {
-	repo  discovery.Repository
-	msg   Messenger
-	notif Notifier
-	mon   monitoring.Service
+	repo   discovery.Repository
+	msg    Messenger
+	notif  Notifier
+	mon    monitoring.Service
+	signer discovery.PeerSigner
 }
 
 //SpreadResult represents the gossig results from the peer starting up
@@ -93,6 +94,18 @@ func (s service) spread(init discovery.Peer, seeds []discovery.Seed, dChan chan<
 		return
 	}
 
+	//Signs the refreshed state, so the other peers can check it comes from us
+	sig, err := s.signer.SignPeer(init)
+	if err != nil {
+		eChan <- err
+		return
+	}
+	init.SetSignature(sig)
+	if err := s.repo.SetKnownPeer(init); err != nil {
+		eChan <- err
+		return
+	}
+
 	knownPeers, err := s.repo.ListKnownPeers()
 	if err != nil {
 		eChan <- err
@@ -112,7 +125,7 @@ func (s service) spread(init discovery.Peer, seeds []discovery.Seed, dChan chan<
 	}
 
 	//add reachables, seeds, unreachables
-	c := NewGossipCycle(init, s.msg)
+	c := NewGossipCycle(init, s.msg, s.signer)
 	if err != nil {
 		eChan <- err
 		return
@@ -208,11 +221,12 @@ func (s service) handleCycleDiscoveries(c *Cycle, dChan chan<- discovery.Peer, e
 }
 
 //NewService creates a gossiping service its dependencies
-func NewService(repo discovery.Repository, msg Messenger, notif Notifier, mon monitoring.Service) Service {
+func NewService(repo discovery.Repository, msg Messenger, notif Notifier, mon monitoring.Service, signer discovery.PeerSigner) Service {
 	return service{
-		repo:  repo,
-		msg:   msg,
-		notif: notif,
-		mon:   mon,
+		repo:   repo,
+		msg:    msg,
+		notif:  notif,
+		mon:    mon,
+		signer: signer,
 	}
 }
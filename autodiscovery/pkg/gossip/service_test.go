@@ -34,10 +34,11 @@ func TestGossip(t *testing.T) {
 	repo.SetKnownPeer(init)
 
 	s := service{
-		msg:   msg,
-		repo:  repo,
-		notif: notif,
-		mon:   mon,
+		msg:    msg,
+		repo:   repo,
+		notif:  notif,
+		mon:    mon,
+		signer: new(mock.PeerSigner),
 	}
 
 	seeds, _ := repo.ListSeedPeers()
@@ -92,10 +93,11 @@ func TestGossipFailureCatched(t *testing.T) {
 	repo.SetKnownPeer(init)
 
 	s := service{
-		msg:   msg,
-		repo:  repo,
-		notif: notif,
-		mon:   mon,
+		msg:    msg,
+		repo:   repo,
+		notif:  notif,
+		mon:    mon,
+		signer: new(mock.PeerSigner),
 	}
 
 	seeds, _ := repo.ListSeedPeers()
@@ -142,10 +144,11 @@ func TestAddUnreachable(t *testing.T) {
 	repo.SetKnownPeer(init)
 
 	s := service{
-		msg:   msg,
-		repo:  repo,
-		notif: notif,
-		mon:   mon,
+		msg:    msg,
+		repo:   repo,
+		notif:  notif,
+		mon:    mon,
+		signer: new(mock.PeerSigner),
 	}
 
 	seeds, _ := repo.ListSeedPeers()
@@ -190,10 +193,11 @@ func TestRemoveUnreachable(t *testing.T) {
 	repo.SetKnownPeer(init)
 
 	s := service{
-		msg:   msg,
-		repo:  repo,
-		notif: notif,
-		mon:   mon,
+		msg:    msg,
+		repo:   repo,
+		notif:  notif,
+		mon:    mon,
+		signer: new(mock.PeerSigner),
 	}
 
 	seeds, _ := repo.ListSeedPeers()
@@ -217,10 +221,11 @@ func TestRemoveUnreachable(t *testing.T) {
 
 	msg2 := mockMessenger{}
 	s = service{
-		msg:   msg2,
-		repo:  repo,
-		notif: notif,
-		mon:   mon,
+		msg:    msg2,
+		repo:   repo,
+		notif:  notif,
+		mon:    mon,
+		signer: new(mock.PeerSigner),
 	}
 
 	var wg2 sync.WaitGroup
@@ -264,7 +269,7 @@ func TestStopTimerWhenGossipError(t *testing.T) {
 	init := discovery.NewStartupPeer("key", net.ParseIP("127.0.0.1"), 3000, "1.0", discovery.PeerPosition{})
 	repo.SetKnownPeer(init)
 
-	srv := NewService(repo, msg, notif, mon)
+	srv := NewService(repo, msg, notif, mon, new(mock.PeerSigner))
 
 	var wg sync.WaitGroup
 	wg.Add(1)
@@ -0,0 +1,41 @@
+package mock
+
+import (
+	"crypto"
+
+	"github.com/uniris/uniris-core/shared/pkg/keys"
+	"github.com/uniris/uniris-core/shared/pkg/keystore"
+)
+
+//KeyStore mock
+//
+//It holds the node keypair in memory
+type KeyStore struct {
+	PubKey string
+	PvKey  string
+}
+
+//PublicKey retrieves the node public key
+func (s KeyStore) PublicKey(name string) (string, error) {
+	return s.PubKey, nil
+}
+
+//Sign signs the data with the node private key
+func (s KeyStore) Sign(name string, data []byte) (string, error) {
+	return keys.Sign(s.PvKey, data)
+}
+
+//Decrypt decrypts the cipher with the node private key
+func (s KeyStore) Decrypt(name string, cipher string) ([]byte, error) {
+	return keys.Decrypt(s.PvKey, cipher)
+}
+
+//TLSSigner returns the node private key as TLS signer
+func (s KeyStore) TLSSigner(name string) (crypto.Signer, error) {
+	return keys.TLSSigner(s.PvKey)
+}
+
+//StoreKeyPair replaces the node keypair
+func (s KeyStore) StoreKeyPair(name string, kp keystore.KeyPair) error {
+	return nil
+}
@@ -0,0 +1,37 @@
+package mock
+
+import discovery "github.com/uniris/uniris-core/autodiscovery/pkg"
+
+//PeerSigner mock
+//
+//The peers owning a rejected key are considered as not signed by their owner
+type PeerSigner struct {
+	RejectedKeys []string
+}
+
+//SignPeer signs a peer
+func (s PeerSigner) SignPeer(p discovery.Peer) (discovery.PeerSignature, error) {
+	return discovery.PeerSignature{
+		Digest: "digest sig",
+		State:  "state sig",
+	}, nil
+}
+
+//VerifyPeerDigest checks the peer digest signature
+func (s PeerSigner) VerifyPeerDigest(p discovery.Peer) error {
+	return s.verify(p)
+}
+
+//VerifyPeerState checks the peer state signature
+func (s PeerSigner) VerifyPeerState(p discovery.Peer) error {
+	return s.verify(p)
+}
+
+func (s PeerSigner) verify(p discovery.Peer) error {
+	for _, k := range s.RejectedKeys {
+		if k == p.Identity().PublicKey() {
+			return discovery.ErrInvalidPeerSignature
+		}
+	}
+	return nil
+}
@@ -74,6 +74,8 @@ type Peer interface {
 	AppState() PeerAppState
 	HeartbeatState() PeerHeartbeatState
 	Refresh(status PeerStatus, disk float64, cpu string, p2pFactor int, discoveryPeersNb int) error
+	Signature() PeerSignature
+	SetSignature(sig PeerSignature)
 	Endpoint() string
 	Owned() bool
 	String() string
@@ -81,10 +83,11 @@ type Peer interface {
 
 //Peer describes a member of the P2P network
 type peer struct {
-	identity PeerIdentity
-	hbState  heartbeatState
-	appState appState
-	isOwned  bool
+	identity  PeerIdentity
+	hbState   heartbeatState
+	appState  appState
+	isOwned   bool
+	signature PeerSignature
 }
 
 //Identity returns the peer's identity
@@ -107,6 +110,16 @@ func (p peer) Owned() bool {
 	return p.isOwned
 }
 
+//Signature returns the signatures of the peer by its owner
+func (p peer) Signature() PeerSignature {
+	return p.signature
+}
+
+//SetSignature defines the signatures of the peer by its owner
+func (p *peer) SetSignature(sig PeerSignature) {
+	p.signature = sig
+}
+
 //Endpoint returns the peer endpoint
 func (p peer) Endpoint() string {
 	return fmt.Sprintf("%s:%d", p.Identity().IP().String(), p.Identity().Port())
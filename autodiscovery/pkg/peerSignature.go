@@ -0,0 +1,28 @@
+package discovery
+
+import "errors"
+
+//ErrInvalidPeerSignature is returned when a peer is not signed by its owner
+var ErrInvalidPeerSignature = errors.New("Invalid peer signature")
+
+//PeerSignature describes the signatures of a peer by its owner
+//
+//The digest signature covers the identity and the heartbeat state,
+//the state signature covers the identity, the heartbeat state and the app state
+type PeerSignature struct {
+	Digest string
+	State  string
+}
+
+//PeerSigner defines methods to handle the signatures of the peers
+type PeerSigner interface {
+
+	//SignPeer signs an owned peer with the node private key
+	SignPeer(p Peer) (PeerSignature, error)
+
+	//VerifyPeerDigest checks if the identity and the heartbeat state are signed by the peer's public key
+	VerifyPeerDigest(p Peer) error
+
+	//VerifyPeerState checks if the identity, the heartbeat and the app states are signed by the peer's public key
+	VerifyPeerState(p Peer) error
+}
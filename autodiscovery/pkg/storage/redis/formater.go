@@ -18,13 +18,15 @@ func FormatPeerToHash(p discovery.Peer) map[string]interface{} {
 		"ip":                    p.Identity().IP().String(),
 		"generationTime":        strconv.Itoa(int(p.HeartbeatState().GenerationTime().Unix())),
 		"elapsedHeartbeats":     strconv.Itoa(int(p.HeartbeatState().ElapsedHeartbeats())),
-		"status":                string(p.AppState().Status()),
+		"status":                strconv.Itoa(int(p.AppState().Status())),
 		"cpuLoad":               p.AppState().CPULoad(),
 		"freeDiskSpace":         fmt.Sprintf("%f", p.AppState().FreeDiskSpace()),
 		"version":               p.AppState().Version(),
 		"geoPosition":           fmt.Sprintf("%f;%f", p.AppState().GeoPosition().Lat, p.AppState().GeoPosition().Lon),
-		"p2pFactor":             string(p.AppState().P2PFactor()),
+		"p2pFactor":             strconv.Itoa(p.AppState().P2PFactor()),
 		"discoveredPeersNumber": fmt.Sprintf("%d", p.AppState().DiscoveredPeersNumber()),
+		"digestSignature":       p.Signature().Digest,
+		"stateSignature":        p.Signature().State,
 	}
 }
 
@@ -62,6 +64,10 @@ func FormatHashToPeer(hash map[string]string) discovery.Peer {
 		discovery.NewPeerHeartbeatState(generationTime, elpased),
 		discovery.NewPeerAppState(version, status, pos, cpuLoad, freeDiskSpace, p2pFactor, dpn),
 	)
+	p.SetSignature(discovery.PeerSignature{
+		Digest: hash["digestSignature"],
+		State:  hash["stateSignature"],
+	})
 	return p
 }
 
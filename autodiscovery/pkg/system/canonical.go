@@ -0,0 +1,56 @@
+package system
+
+import (
+	"strconv"
+
+	discovery "github.com/uniris/uniris-core/autodiscovery/pkg"
+	"github.com/uniris/uniris-core/shared/pkg/canonical"
+)
+
+//The signed peers are serialized with the canonical encoding of the shared module, instead of a JSON representation.
+//
+//The decimals are written as the string of the single precision value transferred by the gossip
+
+//encoder writes the peers with the canonical encoding
+type encoder struct {
+	canonical.Encoder
+}
+
+func newEncoder(t canonical.PayloadType) *encoder {
+	return &encoder{canonical.NewEncoder(t)}
+}
+
+func (e *encoder) writeDecimal(f float64) {
+	e.WriteString(strconv.FormatFloat(float64(float32(f)), 'g', -1, 32))
+}
+
+//writePeerDigest writes the identity and the heartbeat state transferred by the gossip
+//
+//The generation time is truncated to the second
+func (e *encoder) writePeerDigest(p discovery.Peer) {
+	e.WriteString(p.Identity().PublicKey())
+	e.WriteString(p.Identity().IP().String())
+	e.WriteInt(int64(p.Identity().Port()))
+	e.WriteInt(p.HeartbeatState().GenerationTime().Unix())
+	e.WriteInt(p.HeartbeatState().ElapsedHeartbeats())
+}
+
+func encodePeerDigest(p discovery.Peer) []byte {
+	e := newEncoder(canonical.PeerDigestPayload)
+	e.writePeerDigest(p)
+	return e.Bytes()
+}
+
+func encodePeerState(p discovery.Peer) []byte {
+	e := newEncoder(canonical.PeerStatePayload)
+	e.writePeerDigest(p)
+	e.WriteInt(int64(p.AppState().Status()))
+	e.WriteString(p.AppState().CPULoad())
+	e.writeDecimal(p.AppState().FreeDiskSpace())
+	e.WriteString(p.AppState().Version())
+	e.writeDecimal(p.AppState().GeoPosition().Lat)
+	e.writeDecimal(p.AppState().GeoPosition().Lon)
+	e.WriteInt(int64(p.AppState().P2PFactor()))
+	e.WriteInt(int64(p.AppState().DiscoveredPeersNumber()))
+	return e.Bytes()
+}
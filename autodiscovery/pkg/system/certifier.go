@@ -0,0 +1,83 @@
+package system
+
+import (
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"math/big"
+	"time"
+
+	"github.com/uniris/uniris-core/shared/pkg/keys"
+	"github.com/uniris/uniris-core/shared/pkg/keystore"
+)
+
+//ErrCertificateMismatch is returned when a certificate is not bound to the expected public key
+var ErrCertificateMismatch = errors.New("Certificate does not match the public key")
+
+const certificateValidity = 365 * 24 * time.Hour
+
+type certifier struct {
+	ks keystore.KeyStore
+}
+
+//NewCertifier creates a certifier issuing self-signed certificates bound to the node keys
+//
+//The handshakes are signed inside the key store
+func NewCertifier(ks keystore.KeyStore) certifier {
+	return certifier{ks}
+}
+
+//NewNodeCertificate creates a self-signed TLS certificate from the node keypair
+func (c certifier) NewNodeCertificate(pubKey string) (tls.Certificate, error) {
+	signer, err := c.ks.TLSSigner(keystore.NodeKey)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: "uniris node"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(certificateValidity),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, signer.Public(), signer)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	if err := c.VerifyCertificateKey(cert, pubKey); err != nil {
+		return tls.Certificate{}, err
+	}
+
+	return tls.Certificate{
+		Certificate: [][]byte{der},
+		PrivateKey:  signer,
+		Leaf:        cert,
+	}, nil
+}
+
+//VerifyCertificateKey checks if the certificate is bound to the given public key
+func (c certifier) VerifyCertificateKey(cert *x509.Certificate, pubKey string) error {
+	match, err := keys.MatchPublicKey(pubKey, cert.PublicKey)
+	if err != nil {
+		return err
+	}
+
+	if !match {
+		return ErrCertificateMismatch
+	}
+	return nil
+}
@@ -13,10 +13,20 @@ import (
 
 //UnirisConfig describes the uniris robot main configuration
 type UnirisConfig struct {
-	Network   Network               `yaml:"network"`
-	PublicKey string                `yaml:"publicKey"`
-	Version   string                `yaml:"version"`
-	Services  ServicesConfiguration `yaml:"services"`
+	Network    Network               `yaml:"network"`
+	PublicKey  string                `yaml:"publicKey"`
+	PrivateKey string                `yaml:"privateKey"`
+	KeyStore   KeyStoreConfig        `yaml:"keystore"`
+	Version    string                `yaml:"version"`
+	Services   ServicesConfiguration `yaml:"services"`
+}
+
+//KeyStoreConfig describes where the node private key is stored
+//
+//The private key never leaves the key store: the discovery asks it to sign the peers and the TLS handshakes.
+//Without key store path, the private key of the configuration is used
+type KeyStoreConfig struct {
+	Path string `yaml:"path"`
 }
 
 //ServicesConfiguration describe the robot services configuration
@@ -31,11 +41,15 @@ type Network struct {
 }
 
 //DiscoveryConfig describes the autodiscovery configuration
+//
+//The internal port serves the other services of the node on the loopback
 type DiscoveryConfig struct {
-	Port  int          `yaml:"port"`
-	Seeds []SeedConfig `yaml:"seeds"`
-	Redis RedisConfig  `yaml:"redis"`
-	AMQP  AMQPConfig   `yaml:"amqp"`
+	Port         int          `yaml:"port"`
+	InternalPort int          `yaml:"internalPort"`
+	MutualTLS    bool         `yaml:"mutualTLS"`
+	Seeds        []SeedConfig `yaml:"seeds"`
+	Redis        RedisConfig  `yaml:"redis"`
+	AMQP         AMQPConfig   `yaml:"amqp"`
 }
 
 //SeedConfig describes the autodiscovery seed configuration
@@ -64,9 +78,13 @@ type AMQPConfig struct {
 func BuildFromEnv() (*UnirisConfig, error) {
 	ver := os.Getenv("UNIRIS_VERSION")
 	pbKey := os.Getenv("UNIRIS_PUBLICKEY")
+	pvKey := os.Getenv("UNIRIS_PRIVATEKEY")
+	keystorePath := os.Getenv("UNIRIS_KEYSTORE_PATH")
 	network := os.Getenv("UNIRIS_NETWORK_TYPE")
 	netiface := os.Getenv("UNIRIS_NETWORK_INTERFACE")
 	port := os.Getenv("UNIRIS_DISCOVERY_PORT")
+	internalPort := os.Getenv("UNIRIS_DISCOVERY_INTERNAL_PORT")
+	mutualTLS := os.Getenv("UNIRIS_DISCOVERY_MTLS")
 	seeds := os.Getenv("UNIRIS_DISCOVERY_SEEDS")
 	redisHost := os.Getenv("UNIRIS_DISCOVERY_REDIS_HOST")
 	redisPort := os.Getenv("UNIRIS_DISCOVERY_REDIS_PORT")
@@ -119,6 +137,16 @@ func BuildFromEnv() (*UnirisConfig, error) {
 	if err != nil {
 		return nil, err
 	}
+	_internalPort, err := strconv.Atoi(internalPort)
+	if err != nil {
+		return nil, err
+	}
+	_mutualTLS := false
+	if mutualTLS != "" {
+		if _mutualTLS, err = strconv.ParseBool(mutualTLS); err != nil {
+			return nil, err
+		}
+	}
 	_redisPort, err := strconv.Atoi(redisPort)
 	if err != nil {
 		return nil, err
@@ -129,8 +157,12 @@ func BuildFromEnv() (*UnirisConfig, error) {
 	}
 
 	return &UnirisConfig{
-		Version:   ver,
-		PublicKey: pbKey,
+		Version:    ver,
+		PublicKey:  pbKey,
+		PrivateKey: pvKey,
+		KeyStore: KeyStoreConfig{
+			Path: keystorePath,
+		},
 
 		Network: Network{
 			Type:      network,
@@ -138,8 +170,10 @@ func BuildFromEnv() (*UnirisConfig, error) {
 		},
 		Services: ServicesConfiguration{
 			Discovery: DiscoveryConfig{
-				Port:  _port,
-				Seeds: _seeds,
+				Port:         _port,
+				InternalPort: _internalPort,
+				MutualTLS:    _mutualTLS,
+				Seeds:        _seeds,
 				Redis: RedisConfig{
 					Host: redisHost,
 					Port: _redisPort,
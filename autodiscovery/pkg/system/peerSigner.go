@@ -0,0 +1,46 @@
+package system
+
+import (
+	discovery "github.com/uniris/uniris-core/autodiscovery/pkg"
+	"github.com/uniris/uniris-core/shared/pkg/keys"
+	"github.com/uniris/uniris-core/shared/pkg/keystore"
+)
+
+type peerSigner struct {
+	ks keystore.KeyStore
+}
+
+//NewPeerSigner creates a signer of the peers using the node private key kept in the key store
+func NewPeerSigner(ks keystore.KeyStore) discovery.PeerSigner {
+	return peerSigner{ks}
+}
+
+func (s peerSigner) SignPeer(p discovery.Peer) (discovery.PeerSignature, error) {
+	digest, err := s.ks.Sign(keystore.NodeKey, encodePeerDigest(p))
+	if err != nil {
+		return discovery.PeerSignature{}, err
+	}
+	state, err := s.ks.Sign(keystore.NodeKey, encodePeerState(p))
+	if err != nil {
+		return discovery.PeerSignature{}, err
+	}
+	return discovery.PeerSignature{
+		Digest: digest,
+		State:  state,
+	}, nil
+}
+
+func (s peerSigner) VerifyPeerDigest(p discovery.Peer) error {
+	return verifyPeerSignature(p.Identity().PublicKey(), encodePeerDigest(p), p.Signature().Digest)
+}
+
+func (s peerSigner) VerifyPeerState(p discovery.Peer) error {
+	return verifyPeerSignature(p.Identity().PublicKey(), encodePeerState(p), p.Signature().State)
+}
+
+func verifyPeerSignature(pubKey string, payload []byte, sig string) error {
+	if err := keys.Verify(pubKey, payload, sig); err != nil {
+		return discovery.ErrInvalidPeerSignature
+	}
+	return nil
+}
@@ -10,21 +10,23 @@ import (
 	api "github.com/uniris/uniris-core/autodiscovery/api/protobuf-spec"
 	discovery "github.com/uniris/uniris-core/autodiscovery/pkg"
 	"github.com/uniris/uniris-core/autodiscovery/pkg/gossip"
-	"google.golang.org/grpc"
+	"github.com/uniris/uniris-core/shared/pkg/connpool"
 	"google.golang.org/grpc/status"
 )
 
-type clientMessenger struct{}
+type clientMessenger struct {
+	sec  TransportSecurity
+	pool connpool.Pool
+}
 
 //SendSyn calls the Synchronize grpc method to retrieve unknown peers (SYN handshake)
 func (m clientMessenger) SendSyn(req gossip.SynRequest) (synAck *gossip.SynAck, err error) {
 	serverAddr := fmt.Sprintf("%s", req.Target.Endpoint())
-	conn, err := grpc.Dial(serverAddr, grpc.WithInsecure())
-	defer conn.Close()
-
+	conn, release, err := m.pool.Get(serverAddr, req.Target.Identity().PublicKey(), m.sec.DialOption(req.Target.Identity().PublicKey()))
 	if err != nil {
 		return nil, err
 	}
+	defer release()
 
 	//We initalize a GRPC client
 	client := api.NewDiscoveryClient(conn)
@@ -70,12 +72,11 @@ func (m clientMessenger) SendSyn(req gossip.SynRequest) (synAck *gossip.SynAck,
 //SendAck calls the Acknoweledge grpc method to send detailed peers requested
 func (m clientMessenger) SendAck(req gossip.AckRequest) error {
 	serverAddr := fmt.Sprintf("%s", req.Target.Endpoint())
-	conn, err := grpc.Dial(serverAddr, grpc.WithInsecure())
-	defer conn.Close()
-
+	conn, release, err := m.pool.Get(serverAddr, req.Target.Identity().PublicKey(), m.sec.DialOption(req.Target.Identity().PublicKey()))
 	if err != nil {
 		return err
 	}
+	defer release()
 
 	builder := PeerBuilder{}
 
@@ -99,6 +100,8 @@ func (m clientMessenger) SendAck(req gossip.AckRequest) error {
 }
 
 //NewMessenger creates a new gossip messenger using GRPC
-func NewMessenger() gossip.Messenger {
-	return clientMessenger{}
+//
+//The connections to the peers are shared between the gossip cycles through the connection pool
+func NewMessenger(sec TransportSecurity, pool connpool.Pool) gossip.Messenger {
+	return clientMessenger{sec, pool}
 }
@@ -0,0 +1,40 @@
+package rpc
+
+import (
+	"github.com/golang/protobuf/ptypes/empty"
+	"golang.org/x/net/context"
+
+	api "github.com/uniris/uniris-core/autodiscovery/api/protobuf-spec"
+	discovery "github.com/uniris/uniris-core/autodiscovery/pkg"
+)
+
+type internalSrvHandler struct {
+	repo discovery.Repository
+}
+
+//NewInternalServerHandler creates a GRPC handler of the requests from the other services of the node
+//
+//The server must only listen on the loopback, as the requests are not authenticated
+func NewInternalServerHandler(repo discovery.Repository) api.InternalServer {
+	return internalSrvHandler{repo}
+}
+
+//ListKnownPeers implements the protobuf ListKnownPeers request handler
+func (h internalSrvHandler) ListKnownPeers(ctx context.Context, req *empty.Empty) (*api.KnownPeers, error) {
+	peers, err := h.repo.ListKnownPeers()
+	if err != nil {
+		return nil, err
+	}
+
+	identities := make([]*api.PeerIdentity, 0)
+	for _, p := range peers {
+		identities = append(identities, &api.PeerIdentity{
+			IP:        p.Identity().IP().String(),
+			Port:      int32(p.Identity().Port()),
+			PublicKey: p.Identity().PublicKey(),
+		})
+	}
+	return &api.KnownPeers{
+		Peers: identities,
+	}, nil
+}
@@ -0,0 +1,35 @@
+package rpc
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+
+	discovery "github.com/uniris/uniris-core/autodiscovery/pkg"
+	"github.com/uniris/uniris-core/autodiscovery/pkg/mock"
+)
+
+/*
+Scenario: List the known peers for the other services of the node
+	Given a peer known by the gossip
+	When the datamining service lists the known peers
+	Then it gets the identity of the peer
+*/
+func TestListKnownPeers(t *testing.T) {
+	repo := new(mock.Repository)
+	repo.SetKnownPeer(discovery.NewPeerDigest(
+		discovery.NewPeerIdentity(net.ParseIP("20.10.0.1"), 3000, "key1"),
+		discovery.NewPeerHeartbeatState(time.Now(), 1000),
+	))
+
+	res, err := NewInternalServerHandler(repo).ListKnownPeers(context.TODO(), &empty.Empty{})
+	assert.Nil(t, err)
+	assert.Len(t, res.Peers, 1)
+	assert.Equal(t, "20.10.0.1", res.Peers[0].IP)
+	assert.Equal(t, int32(3000), res.Peers[0].Port)
+	assert.Equal(t, "key1", res.Peers[0].PublicKey)
+}
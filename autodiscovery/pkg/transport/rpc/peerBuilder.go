@@ -23,15 +23,18 @@ func (f PeerBuilder) ToPeerDigest(p discovery.Peer) *api.PeerDigest {
 			GenerationTime:    int64(p.HeartbeatState().GenerationTime().Unix()),
 			ElapsedHeartbeats: p.HeartbeatState().ElapsedHeartbeats(),
 		},
+		Signature: f.toPeerSignature(p),
 	}
 }
 
 //FromPeerDigest creates a peer from a digest peer
 func (f PeerBuilder) FromPeerDigest(p *api.PeerDigest) discovery.Peer {
-	return discovery.NewPeerDigest(
-		discovery.NewPeerIdentity(net.ParseIP(p.Identity.IP), int(p.Identity.Port), p.Identity.PublicKey),
-		discovery.NewPeerHeartbeatState(time.Unix(int64(p.HeartbeatState.GenerationTime), 0), int64(p.HeartbeatState.ElapsedHeartbeats)),
+	peer := discovery.NewPeerDigest(
+		discovery.NewPeerIdentity(net.ParseIP(p.GetIdentity().GetIP()), int(p.GetIdentity().GetPort()), p.GetIdentity().GetPublicKey()),
+		discovery.NewPeerHeartbeatState(time.Unix(p.GetHeartbeatState().GetGenerationTime(), 0), p.GetHeartbeatState().GetElapsedHeartbeats()),
 	)
+	peer.SetSignature(f.fromPeerSignature(p.Signature))
+	return peer
 }
 
 //ToPeerDiscovered creates a discovered peer from a peer
@@ -58,6 +61,7 @@ func (f PeerBuilder) ToPeerDiscovered(p discovery.Peer) *api.PeerDiscovered {
 			Version:               p.AppState().Version(),
 			DiscoveredPeersNumber: int32(p.AppState().DiscoveredPeersNumber()),
 		},
+		Signature: f.toPeerSignature(p),
 	}
 }
 
@@ -79,5 +83,21 @@ func (f PeerBuilder) FromPeerDiscovered(p *api.PeerDiscovered) discovery.Peer {
 		int(p.AppState.DiscoveredPeersNumber),
 	)
 
-	return discovery.NewDiscoveredPeer(id, hb, state)
+	peer := discovery.NewDiscoveredPeer(id, hb, state)
+	peer.SetSignature(f.fromPeerSignature(p.Signature))
+	return peer
+}
+
+func (f PeerBuilder) toPeerSignature(p discovery.Peer) *api.PeerSignature {
+	return &api.PeerSignature{
+		Digest: p.Signature().Digest,
+		State:  p.Signature().State,
+	}
+}
+
+func (f PeerBuilder) fromPeerSignature(sig *api.PeerSignature) discovery.PeerSignature {
+	return discovery.PeerSignature{
+		Digest: sig.GetDigest(),
+		State:  sig.GetState(),
+	}
 }
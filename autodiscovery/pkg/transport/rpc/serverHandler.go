@@ -1,6 +1,8 @@
 package rpc
 
 import (
+	"log"
+
 	"golang.org/x/net/context"
 
 	"github.com/uniris/uniris-core/autodiscovery/pkg/comparing"
@@ -10,20 +12,39 @@ import (
 	"github.com/golang/protobuf/ptypes/empty"
 	api "github.com/uniris/uniris-core/autodiscovery/api/protobuf-spec"
 	"github.com/uniris/uniris-core/autodiscovery/pkg/gossip"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type srvHandler struct {
-	repo  discovery.Repository
-	notif gossip.Notifier
+	repo   discovery.Repository
+	notif  gossip.Notifier
+	sec    TransportSecurity
+	signer discovery.PeerSigner
 }
 
 //Synchronize implements the protobuf Synchronize request handler
 func (h srvHandler) Synchronize(ctx context.Context, req *api.SynRequest) (*api.SynAck, error) {
+	if err := h.sec.VerifyPeer(ctx, req.GetInitiator().GetIdentity().GetPublicKey()); err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+
 	builder := PeerBuilder{}
 
+	if err := h.signer.VerifyPeerDigest(builder.FromPeerDigest(req.Initiator)); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
 	reqP := make([]discovery.Peer, 0)
 	for _, p := range req.KnownPeers {
-		reqP = append(reqP, builder.FromPeerDigest(p))
+		peer := builder.FromPeerDigest(p)
+
+		//The peers not signed by their owner are ignored
+		if err := h.signer.VerifyPeerDigest(peer); err != nil {
+			log.Printf("Synchronization ignored peer %s: %s", peer.Endpoint(), err.Error())
+			continue
+		}
+		reqP = append(reqP, peer)
 	}
 
 	knownPeers, err := h.repo.ListKnownPeers()
@@ -52,11 +73,26 @@ func (h srvHandler) Synchronize(ctx context.Context, req *api.SynRequest) (*api.
 
 //Acknowledge implements the protobuf Acknowledge request handler
 func (h srvHandler) Acknowledge(ctx context.Context, req *api.AckRequest) (*empty.Empty, error) {
+	if err := h.sec.VerifyPeer(ctx, req.GetInitiator().GetIdentity().GetPublicKey()); err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+
 	builder := PeerBuilder{}
 
-	//Store the peers requested
+	peers := make([]discovery.Peer, 0)
 	for _, rp := range req.RequestedPeers {
 		p := builder.FromPeerDiscovered(rp)
+
+		//The peers not signed by their owner are ignored
+		if err := h.signer.VerifyPeerState(p); err != nil {
+			log.Printf("Acknowledgement ignored peer %s: %s", p.Endpoint(), err.Error())
+			continue
+		}
+		peers = append(peers, p)
+	}
+
+	//Store the peers requested
+	for _, p := range peers {
 		h.notif.NotifyDiscoveries(p)
 		h.repo.SetKnownPeer(p)
 	}
@@ -65,9 +101,11 @@ func (h srvHandler) Acknowledge(ctx context.Context, req *api.AckRequest) (*empt
 }
 
 //NewServerHandler create a new GRPC server handler
-func NewServerHandler(repo discovery.Repository, notif gossip.Notifier) api.DiscoveryServer {
+func NewServerHandler(repo discovery.Repository, notif gossip.Notifier, sec TransportSecurity, signer discovery.PeerSigner) api.DiscoveryServer {
 	return srvHandler{
-		repo:  repo,
-		notif: notif,
+		repo:   repo,
+		notif:  notif,
+		sec:    sec,
+		signer: signer,
 	}
 }
@@ -13,6 +13,7 @@ import (
 
 	api "github.com/uniris/uniris-core/autodiscovery/api/protobuf-spec"
 	discovery "github.com/uniris/uniris-core/autodiscovery/pkg"
+	"github.com/uniris/uniris-core/autodiscovery/pkg/system"
 )
 
 /*
@@ -34,7 +35,7 @@ func TestHandleSynRequest(t *testing.T) {
 
 	notif := new(mock.Notifier)
 
-	h := NewServerHandler(repo, notif)
+	h := NewServerHandler(repo, notif, insecureTransport{}, new(mock.PeerSigner))
 
 	req := &api.SynRequest{
 		Initiator: &api.PeerDigest{},
@@ -85,7 +86,7 @@ func TestHandleSynRequestNewPeers(t *testing.T) {
 
 	notif := new(mock.Notifier)
 
-	h := NewServerHandler(repo, notif)
+	h := NewServerHandler(repo, notif, insecureTransport{}, new(mock.PeerSigner))
 
 	req := &api.SynRequest{
 		Initiator: &api.PeerDigest{},
@@ -135,7 +136,7 @@ func TestHandleSynRequestNewPeersRecentOnly(t *testing.T) {
 
 	notif := new(mock.Notifier)
 
-	h := NewServerHandler(repo, notif)
+	h := NewServerHandler(repo, notif, insecureTransport{}, new(mock.PeerSigner))
 
 	req := &api.SynRequest{
 		Initiator: &api.PeerDigest{},
@@ -192,7 +193,7 @@ func TestHandleSynRequestUnknownPeers(t *testing.T) {
 		discovery.NewStartupPeer("key2", net.ParseIP("127.0.0.1"), 3000, "1.0", discovery.PeerPosition{}))
 
 	notif := new(mock.Notifier)
-	h := NewServerHandler(repo, notif)
+	h := NewServerHandler(repo, notif, insecureTransport{}, new(mock.PeerSigner))
 
 	req := &api.SynRequest{
 		Initiator: &api.PeerDigest{},
@@ -249,7 +250,7 @@ func TestHandleSynRequestUnknownPeersRecentOnly(t *testing.T) {
 
 	notif := new(mock.Notifier)
 
-	h := NewServerHandler(repo, notif)
+	h := NewServerHandler(repo, notif, insecureTransport{}, new(mock.PeerSigner))
 
 	req := &api.SynRequest{
 		Initiator: &api.PeerDigest{},
@@ -298,7 +299,7 @@ Scenario: Process a ACK request by saving and notifying the request detailed pee
 func TestHandlAckRequest(t *testing.T) {
 	notif := new(mock.Notifier)
 	repo := new(mock.Repository)
-	h := NewServerHandler(repo, notif)
+	h := NewServerHandler(repo, notif, insecureTransport{}, new(mock.PeerSigner))
 
 	req := &api.AckRequest{
 		Initiator: &api.PeerDigest{},
@@ -348,3 +349,96 @@ func TestHandlAckRequest(t *testing.T) {
 	assert.NotEmpty(t, notif.NotifiedPeers)
 	assert.Equal(t, "key1", notif.NotifiedPeers()[0].Identity().PublicKey())
 }
+
+/*
+Scenario: Ignore the peers of a ACK request not signed by their owner
+	Given a GRPC server
+	When we receive a ACK request with a peer with an invalid signature and a signed peer
+	Then only the signed peer is stored
+*/
+func TestHandleAckRequestWithInvalidSignature(t *testing.T) {
+	repo := new(mock.Repository)
+	h := NewServerHandler(repo, new(mock.Notifier), insecureTransport{}, mock.PeerSigner{RejectedKeys: []string{"key1"}})
+
+	req := &api.AckRequest{
+		Initiator: &api.PeerDigest{},
+		Target:    &api.PeerDigest{},
+		RequestedPeers: []*api.PeerDiscovered{
+			&api.PeerDiscovered{
+				Identity:       &api.PeerIdentity{IP: "20.10.0.1", Port: 3000, PublicKey: "key1"},
+				HeartbeatState: &api.PeerHeartbeatState{GenerationTime: time.Now().Unix()},
+				AppState:       &api.PeerAppState{GeoPosition: &api.PeerAppState_GeoCoordinates{}},
+			},
+			&api.PeerDiscovered{
+				Identity:       &api.PeerIdentity{IP: "20.10.0.2", Port: 3000, PublicKey: "key2"},
+				HeartbeatState: &api.PeerHeartbeatState{GenerationTime: time.Now().Unix()},
+				AppState:       &api.PeerAppState{GeoPosition: &api.PeerAppState_GeoCoordinates{}},
+			},
+		},
+	}
+	_, err := h.Acknowledge(context.TODO(), req)
+	assert.Nil(t, err)
+
+	kp, _ := repo.ListKnownPeers()
+	assert.Len(t, kp, 1)
+	assert.Equal(t, "key2", kp[0].Identity().PublicKey())
+}
+
+/*
+Scenario: Ignore the peers of a SYN request not signed by their owner
+	Given a GRPC server without peers
+	When we receive a SYN request with a digest with an invalid signature and a signed digest
+	Then only the signed digest is compared and requested
+*/
+func TestHandleSynRequestWithInvalidSignature(t *testing.T) {
+	h := NewServerHandler(new(mock.Repository), new(mock.Notifier), insecureTransport{}, mock.PeerSigner{RejectedKeys: []string{"key1"}})
+
+	req := &api.SynRequest{
+		Initiator: &api.PeerDigest{},
+		Target:    &api.PeerDigest{},
+		KnownPeers: []*api.PeerDigest{
+			&api.PeerDigest{
+				Identity:       &api.PeerIdentity{IP: "20.10.0.1", Port: 3000, PublicKey: "key1"},
+				HeartbeatState: &api.PeerHeartbeatState{GenerationTime: time.Now().Unix()},
+			},
+			&api.PeerDigest{
+				Identity:       &api.PeerIdentity{IP: "20.10.0.2", Port: 3000, PublicKey: "key2"},
+				HeartbeatState: &api.PeerHeartbeatState{GenerationTime: time.Now().Unix()},
+			},
+		},
+	}
+	res, err := h.Synchronize(context.TODO(), req)
+	assert.Nil(t, err)
+	assert.Len(t, res.UnknownPeers, 1)
+	assert.Equal(t, "key2", res.UnknownPeers[0].Identity.PublicKey)
+}
+
+/*
+Scenario: Accept a ACK request including a peer signed by its owner
+	Given a peer signed with its key and transferred through GRPC
+	When we receive a ACK request with the peer
+	Then the signature is verified and the peer is stored
+*/
+func TestHandleAckRequestWithSignedPeer(t *testing.T) {
+	pub, ks := generateKeys()
+	signer := system.NewPeerSigner(ks)
+
+	p := discovery.NewStartupPeer(pub, net.ParseIP("20.10.0.1"), 3000, "1.0", discovery.PeerPosition{Lat: 48.8566, Lon: 2.3522})
+	p.Refresh(discovery.OkStatus, 1234.5678, "0.3", 2, 10)
+	sig, err := signer.SignPeer(p)
+	assert.Nil(t, err)
+	p.SetSignature(sig)
+
+	repo := new(mock.Repository)
+	h := NewServerHandler(repo, new(mock.Notifier), insecureTransport{}, system.NewPeerSigner(mock.KeyStore{}))
+
+	_, err = h.Acknowledge(context.TODO(), &api.AckRequest{
+		Initiator:      &api.PeerDigest{},
+		Target:         &api.PeerDigest{},
+		RequestedPeers: []*api.PeerDiscovered{PeerBuilder{}.ToPeerDiscovered(p)},
+	})
+	assert.Nil(t, err)
+
+	kp, _ := repo.ListKnownPeers()
+	assert.Equal(t, 1, len(kp))
+}
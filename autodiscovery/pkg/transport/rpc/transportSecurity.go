@@ -0,0 +1,119 @@
+package rpc
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+
+	"github.com/uniris/uniris-core/autodiscovery/pkg/system"
+)
+
+//ErrMissingPeerCertificate is returned when a peer does not present a certificate
+var ErrMissingPeerCertificate = errors.New("Missing peer certificate")
+
+//Certifier defines methods to handle the certificates of the peers
+type Certifier interface {
+
+	//NewNodeCertificate creates a TLS certificate bound to the node public key
+	NewNodeCertificate(pubKey string) (tls.Certificate, error)
+
+	//VerifyCertificateKey checks if the certificate is bound to the given public key
+	VerifyCertificateKey(cert *x509.Certificate, pubKey string) error
+}
+
+//TransportSecurity defines the security of the GRPC connections between the peers
+type TransportSecurity interface {
+
+	//ServerOptions returns the options to secure a GRPC server
+	ServerOptions() []grpc.ServerOption
+
+	//DialOption returns the option to secure a GRPC connection to the peer owning the public key
+	DialOption(pubKey string) grpc.DialOption
+
+	//VerifyPeer checks if the peer calling the server owns the public key
+	VerifyPeer(ctx context.Context, pubKey string) error
+}
+
+type insecureTransport struct{}
+
+type mutualTLSTransport struct {
+	cert      tls.Certificate
+	certifier Certifier
+}
+
+//NewTransportSecurity creates the security of the GRPC connections between the peers
+//
+//When the mutual TLS is enabled, each peer presents a certificate bound to its public key
+//and the certificate of the remote peer is verified against the public key known by the gossip
+func NewTransportSecurity(conf system.UnirisConfig, certifier Certifier) (TransportSecurity, error) {
+	if !conf.Services.Discovery.MutualTLS {
+		return insecureTransport{}, nil
+	}
+
+	cert, err := certifier.NewNodeCertificate(conf.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	return mutualTLSTransport{cert, certifier}, nil
+}
+
+func (t insecureTransport) ServerOptions() []grpc.ServerOption {
+	return []grpc.ServerOption{}
+}
+
+func (t insecureTransport) DialOption(pubKey string) grpc.DialOption {
+	return grpc.WithInsecure()
+}
+
+func (t insecureTransport) VerifyPeer(ctx context.Context, pubKey string) error {
+	return nil
+}
+
+func (t mutualTLSTransport) ServerOptions() []grpc.ServerOption {
+	conf := &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		Certificates: []tls.Certificate{t.cert},
+
+		//The initiator of the gossip is only known from the request, so its certificate is verified by the handler
+		ClientAuth: tls.RequireAnyClientCert,
+	}
+	return []grpc.ServerOption{grpc.Creds(credentials.NewTLS(conf))}
+}
+
+func (t mutualTLSTransport) DialOption(pubKey string) grpc.DialOption {
+	conf := &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		Certificates: []tls.Certificate{t.cert},
+
+		//The certificates are not issued by an authority, but bound to the peer public key
+		InsecureSkipVerify: true,
+		VerifyPeerCertificate: func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+			if len(rawCerts) == 0 {
+				return ErrMissingPeerCertificate
+			}
+			cert, err := x509.ParseCertificate(rawCerts[0])
+			if err != nil {
+				return err
+			}
+			return t.certifier.VerifyCertificateKey(cert, pubKey)
+		},
+	}
+	return grpc.WithTransportCredentials(credentials.NewTLS(conf))
+}
+
+func (t mutualTLSTransport) VerifyPeer(ctx context.Context, pubKey string) error {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return ErrMissingPeerCertificate
+	}
+	info, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(info.State.PeerCertificates) == 0 {
+		return ErrMissingPeerCertificate
+	}
+	return t.certifier.VerifyCertificateKey(info.State.PeerCertificates[0], pubKey)
+}
@@ -0,0 +1,89 @@
+package rpc
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+
+	api "github.com/uniris/uniris-core/autodiscovery/api/protobuf-spec"
+	"github.com/uniris/uniris-core/autodiscovery/pkg/mock"
+	"github.com/uniris/uniris-core/autodiscovery/pkg/system"
+	"github.com/uniris/uniris-core/shared/pkg/keys"
+)
+
+/*
+Scenario: Accept a SYN request from the owner of the initiator key
+	Given a server with mutual TLS and an initiator presenting a certificate bound to its key
+	When we receive a SYN request from the initiator
+	Then the request is processed
+*/
+func TestHandleSynRequestWithMutualTLS(t *testing.T) {
+	sec := newMutualTLSTransport(t)
+	pub, ks := generateKeys()
+	cert, err := system.NewCertifier(ks).NewNodeCertificate(pub)
+	assert.Nil(t, err)
+
+	h := NewServerHandler(new(mock.Repository), new(mock.Notifier), sec, new(mock.PeerSigner))
+	_, err = h.Synchronize(tlsPeerContext(cert.Leaf), synRequestFrom(pub))
+	assert.Nil(t, err)
+}
+
+/*
+Scenario: Reject a SYN request from a peer which does not own the initiator key
+	Given a server with mutual TLS and a peer presenting a certificate bound to another key
+	When we receive a SYN request with the initiator key
+	Then the request is rejected
+*/
+func TestHandleSynRequestWithMutualTLSMismatch(t *testing.T) {
+	sec := newMutualTLSTransport(t)
+	pub, ks := generateKeys()
+	otherPub, _ := generateKeys()
+	cert, _ := system.NewCertifier(ks).NewNodeCertificate(pub)
+
+	h := NewServerHandler(new(mock.Repository), new(mock.Notifier), sec, new(mock.PeerSigner))
+	_, err := h.Synchronize(tlsPeerContext(cert.Leaf), synRequestFrom(otherPub))
+	assert.NotNil(t, err)
+
+	_, err = h.Synchronize(context.TODO(), synRequestFrom(pub))
+	assert.NotNil(t, err)
+}
+
+func newMutualTLSTransport(t *testing.T) TransportSecurity {
+	pub, ks := generateKeys()
+	conf := system.UnirisConfig{
+		PublicKey: pub,
+		Services: system.ServicesConfiguration{
+			Discovery: system.DiscoveryConfig{MutualTLS: true},
+		},
+	}
+	sec, err := NewTransportSecurity(conf, system.NewCertifier(ks))
+	assert.Nil(t, err)
+	return sec
+}
+
+func generateKeys() (string, mock.KeyStore) {
+	pub, pv, _ := keys.GenerateKeyPair(keys.ECDSAP256)
+	return pub, mock.KeyStore{PubKey: pub, PvKey: pv}
+}
+
+func tlsPeerContext(cert *x509.Certificate) context.Context {
+	return peer.NewContext(context.TODO(), &peer.Peer{
+		AuthInfo: credentials.TLSInfo{
+			State: tls.ConnectionState{PeerCertificates: []*x509.Certificate{cert}},
+		},
+	})
+}
+
+func synRequestFrom(pubKey string) *api.SynRequest {
+	return &api.SynRequest{
+		Initiator: &api.PeerDigest{
+			Identity: &api.PeerIdentity{PublicKey: pubKey},
+		},
+		Target: &api.PeerDigest{},
+	}
+}
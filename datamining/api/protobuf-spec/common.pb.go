@@ -44,7 +44,30 @@ func (x TransactionStatusResponse_TransactionStatus) String() string {
 	return proto.EnumName(TransactionStatusResponse_TransactionStatus_name, int32(x))
 }
 func (TransactionStatusResponse_TransactionStatus) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_common_503c6ddff5f6ceb0, []int{1, 0}
+	return fileDescriptor_common_eefd8d4a460aa88b, []int{1, 0}
+}
+
+type Validation_ValidationStatus int32
+
+const (
+	Validation_OK Validation_ValidationStatus = 0
+	Validation_KO Validation_ValidationStatus = 1
+)
+
+var Validation_ValidationStatus_name = map[int32]string{
+	0: "OK",
+	1: "KO",
+}
+var Validation_ValidationStatus_value = map[string]int32{
+	"OK": 0,
+	"KO": 1,
+}
+
+func (x Validation_ValidationStatus) String() string {
+	return proto.EnumName(Validation_ValidationStatus_name, int32(x))
+}
+func (Validation_ValidationStatus) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_common_eefd8d4a460aa88b, []int{6, 0}
 }
 
 type TransactionStatusRequest struct {
@@ -59,7 +82,7 @@ func (m *TransactionStatusRequest) Reset()         { *m = TransactionStatusReque
 func (m *TransactionStatusRequest) String() string { return proto.CompactTextString(m) }
 func (*TransactionStatusRequest) ProtoMessage()    {}
 func (*TransactionStatusRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_common_503c6ddff5f6ceb0, []int{0}
+	return fileDescriptor_common_eefd8d4a460aa88b, []int{0}
 }
 func (m *TransactionStatusRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_TransactionStatusRequest.Unmarshal(m, b)
@@ -104,7 +127,7 @@ func (m *TransactionStatusResponse) Reset()         { *m = TransactionStatusResp
 func (m *TransactionStatusResponse) String() string { return proto.CompactTextString(m) }
 func (*TransactionStatusResponse) ProtoMessage()    {}
 func (*TransactionStatusResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_common_503c6ddff5f6ceb0, []int{1}
+	return fileDescriptor_common_eefd8d4a460aa88b, []int{1}
 }
 func (m *TransactionStatusResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_TransactionStatusResponse.Unmarshal(m, b)
@@ -131,27 +154,628 @@ func (m *TransactionStatusResponse) GetStatus() TransactionStatusResponse_Transa
 	return TransactionStatusResponse_Pending
 }
 
+type Keychain struct {
+	EncryptedAddrByRobot string    `protobuf:"bytes,1,opt,name=EncryptedAddrByRobot,proto3" json:"EncryptedAddrByRobot,omitempty"`
+	EncryptedWallet      string    `protobuf:"bytes,2,opt,name=EncryptedWallet,proto3" json:"EncryptedWallet,omitempty"`
+	IDPublicKey          string    `protobuf:"bytes,3,opt,name=IDPublicKey,proto3" json:"IDPublicKey,omitempty"`
+	Proposal             *Proposal `protobuf:"bytes,4,opt,name=Proposal,proto3" json:"Proposal,omitempty"`
+	IDSignature          string    `protobuf:"bytes,5,opt,name=IDSignature,proto3" json:"IDSignature,omitempty"`
+	EmitterSignature     string    `protobuf:"bytes,6,opt,name=EmitterSignature,proto3" json:"EmitterSignature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *Keychain) Reset()         { *m = Keychain{} }
+func (m *Keychain) String() string { return proto.CompactTextString(m) }
+func (*Keychain) ProtoMessage()    {}
+func (*Keychain) Descriptor() ([]byte, []int) {
+	return fileDescriptor_common_eefd8d4a460aa88b, []int{2}
+}
+func (m *Keychain) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Keychain.Unmarshal(m, b)
+}
+func (m *Keychain) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Keychain.Marshal(b, m, deterministic)
+}
+func (dst *Keychain) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Keychain.Merge(dst, src)
+}
+func (m *Keychain) XXX_Size() int {
+	return xxx_messageInfo_Keychain.Size(m)
+}
+func (m *Keychain) XXX_DiscardUnknown() {
+	xxx_messageInfo_Keychain.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Keychain proto.InternalMessageInfo
+
+func (m *Keychain) GetEncryptedAddrByRobot() string {
+	if m != nil {
+		return m.EncryptedAddrByRobot
+	}
+	return ""
+}
+
+func (m *Keychain) GetEncryptedWallet() string {
+	if m != nil {
+		return m.EncryptedWallet
+	}
+	return ""
+}
+
+func (m *Keychain) GetIDPublicKey() string {
+	if m != nil {
+		return m.IDPublicKey
+	}
+	return ""
+}
+
+func (m *Keychain) GetProposal() *Proposal {
+	if m != nil {
+		return m.Proposal
+	}
+	return nil
+}
+
+func (m *Keychain) GetIDSignature() string {
+	if m != nil {
+		return m.IDSignature
+	}
+	return ""
+}
+
+func (m *Keychain) GetEmitterSignature() string {
+	if m != nil {
+		return m.EmitterSignature
+	}
+	return ""
+}
+
+type ID struct {
+	Hash                 string    `protobuf:"bytes,1,opt,name=Hash,proto3" json:"Hash,omitempty"`
+	EncryptedAddrByRobot string    `protobuf:"bytes,2,opt,name=EncryptedAddrByRobot,proto3" json:"EncryptedAddrByRobot,omitempty"`
+	EncryptedAddrByID    string    `protobuf:"bytes,3,opt,name=EncryptedAddrByID,proto3" json:"EncryptedAddrByID,omitempty"`
+	EncryptedAESKey      string    `protobuf:"bytes,4,opt,name=EncryptedAESKey,proto3" json:"EncryptedAESKey,omitempty"`
+	PublicKey            string    `protobuf:"bytes,5,opt,name=PublicKey,proto3" json:"PublicKey,omitempty"`
+	Proposal             *Proposal `protobuf:"bytes,6,opt,name=Proposal,proto3" json:"Proposal,omitempty"`
+	IDSignature          string    `protobuf:"bytes,7,opt,name=IDSignature,proto3" json:"IDSignature,omitempty"`
+	EmitterSignature     string    `protobuf:"bytes,8,opt,name=EmitterSignature,proto3" json:"EmitterSignature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *ID) Reset()         { *m = ID{} }
+func (m *ID) String() string { return proto.CompactTextString(m) }
+func (*ID) ProtoMessage()    {}
+func (*ID) Descriptor() ([]byte, []int) {
+	return fileDescriptor_common_eefd8d4a460aa88b, []int{3}
+}
+func (m *ID) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ID.Unmarshal(m, b)
+}
+func (m *ID) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ID.Marshal(b, m, deterministic)
+}
+func (dst *ID) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ID.Merge(dst, src)
+}
+func (m *ID) XXX_Size() int {
+	return xxx_messageInfo_ID.Size(m)
+}
+func (m *ID) XXX_DiscardUnknown() {
+	xxx_messageInfo_ID.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ID proto.InternalMessageInfo
+
+func (m *ID) GetHash() string {
+	if m != nil {
+		return m.Hash
+	}
+	return ""
+}
+
+func (m *ID) GetEncryptedAddrByRobot() string {
+	if m != nil {
+		return m.EncryptedAddrByRobot
+	}
+	return ""
+}
+
+func (m *ID) GetEncryptedAddrByID() string {
+	if m != nil {
+		return m.EncryptedAddrByID
+	}
+	return ""
+}
+
+func (m *ID) GetEncryptedAESKey() string {
+	if m != nil {
+		return m.EncryptedAESKey
+	}
+	return ""
+}
+
+func (m *ID) GetPublicKey() string {
+	if m != nil {
+		return m.PublicKey
+	}
+	return ""
+}
+
+func (m *ID) GetProposal() *Proposal {
+	if m != nil {
+		return m.Proposal
+	}
+	return nil
+}
+
+func (m *ID) GetIDSignature() string {
+	if m != nil {
+		return m.IDSignature
+	}
+	return ""
+}
+
+func (m *ID) GetEmitterSignature() string {
+	if m != nil {
+		return m.EmitterSignature
+	}
+	return ""
+}
+
+type Endorsement struct {
+	LastTransactionHash  string            `protobuf:"bytes,1,opt,name=LastTransactionHash,proto3" json:"LastTransactionHash,omitempty"`
+	TransactionHash      string            `protobuf:"bytes,2,opt,name=TransactionHash,proto3" json:"TransactionHash,omitempty"`
+	MasterValidation     *MasterValidation `protobuf:"bytes,3,opt,name=MasterValidation,proto3" json:"MasterValidation,omitempty"`
+	Validations          []*Validation     `protobuf:"bytes,4,rep,name=Validations,proto3" json:"Validations,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *Endorsement) Reset()         { *m = Endorsement{} }
+func (m *Endorsement) String() string { return proto.CompactTextString(m) }
+func (*Endorsement) ProtoMessage()    {}
+func (*Endorsement) Descriptor() ([]byte, []int) {
+	return fileDescriptor_common_eefd8d4a460aa88b, []int{4}
+}
+func (m *Endorsement) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Endorsement.Unmarshal(m, b)
+}
+func (m *Endorsement) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Endorsement.Marshal(b, m, deterministic)
+}
+func (dst *Endorsement) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Endorsement.Merge(dst, src)
+}
+func (m *Endorsement) XXX_Size() int {
+	return xxx_messageInfo_Endorsement.Size(m)
+}
+func (m *Endorsement) XXX_DiscardUnknown() {
+	xxx_messageInfo_Endorsement.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Endorsement proto.InternalMessageInfo
+
+func (m *Endorsement) GetLastTransactionHash() string {
+	if m != nil {
+		return m.LastTransactionHash
+	}
+	return ""
+}
+
+func (m *Endorsement) GetTransactionHash() string {
+	if m != nil {
+		return m.TransactionHash
+	}
+	return ""
+}
+
+func (m *Endorsement) GetMasterValidation() *MasterValidation {
+	if m != nil {
+		return m.MasterValidation
+	}
+	return nil
+}
+
+func (m *Endorsement) GetValidations() []*Validation {
+	if m != nil {
+		return m.Validations
+	}
+	return nil
+}
+
+type MasterValidation struct {
+	ProofOfWorkKey        string      `protobuf:"bytes,1,opt,name=ProofOfWorkKey,proto3" json:"ProofOfWorkKey,omitempty"`
+	ProofOfWorkValidation *Validation `protobuf:"bytes,2,opt,name=ProofOfWorkValidation,proto3" json:"ProofOfWorkValidation,omitempty"`
+	LastTransactionMiners []string    `protobuf:"bytes,3,rep,name=LastTransactionMiners,proto3" json:"LastTransactionMiners,omitempty"`
+	ValidationPool        []string    `protobuf:"bytes,4,rep,name=ValidationPool,proto3" json:"ValidationPool,omitempty"`
+	XXX_NoUnkeyedLiteral  struct{}    `json:"-"`
+	XXX_unrecognized      []byte      `json:"-"`
+	XXX_sizecache         int32       `json:"-"`
+}
+
+func (m *MasterValidation) Reset()         { *m = MasterValidation{} }
+func (m *MasterValidation) String() string { return proto.CompactTextString(m) }
+func (*MasterValidation) ProtoMessage()    {}
+func (*MasterValidation) Descriptor() ([]byte, []int) {
+	return fileDescriptor_common_eefd8d4a460aa88b, []int{5}
+}
+func (m *MasterValidation) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MasterValidation.Unmarshal(m, b)
+}
+func (m *MasterValidation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MasterValidation.Marshal(b, m, deterministic)
+}
+func (dst *MasterValidation) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MasterValidation.Merge(dst, src)
+}
+func (m *MasterValidation) XXX_Size() int {
+	return xxx_messageInfo_MasterValidation.Size(m)
+}
+func (m *MasterValidation) XXX_DiscardUnknown() {
+	xxx_messageInfo_MasterValidation.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MasterValidation proto.InternalMessageInfo
+
+func (m *MasterValidation) GetProofOfWorkKey() string {
+	if m != nil {
+		return m.ProofOfWorkKey
+	}
+	return ""
+}
+
+func (m *MasterValidation) GetProofOfWorkValidation() *Validation {
+	if m != nil {
+		return m.ProofOfWorkValidation
+	}
+	return nil
+}
+
+func (m *MasterValidation) GetLastTransactionMiners() []string {
+	if m != nil {
+		return m.LastTransactionMiners
+	}
+	return nil
+}
+
+func (m *MasterValidation) GetValidationPool() []string {
+	if m != nil {
+		return m.ValidationPool
+	}
+	return nil
+}
+
+type Validation struct {
+	Status               Validation_ValidationStatus `protobuf:"varint,1,opt,name=Status,proto3,enum=api.Validation_ValidationStatus" json:"Status,omitempty"`
+	Timestamp            int64                       `protobuf:"varint,2,opt,name=Timestamp,proto3" json:"Timestamp,omitempty"`
+	PublicKey            string                      `protobuf:"bytes,3,opt,name=PublicKey,proto3" json:"PublicKey,omitempty"`
+	Signature            string                      `protobuf:"bytes,4,opt,name=Signature,proto3" json:"Signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                    `json:"-"`
+	XXX_unrecognized     []byte                      `json:"-"`
+	XXX_sizecache        int32                       `json:"-"`
+}
+
+func (m *Validation) Reset()         { *m = Validation{} }
+func (m *Validation) String() string { return proto.CompactTextString(m) }
+func (*Validation) ProtoMessage()    {}
+func (*Validation) Descriptor() ([]byte, []int) {
+	return fileDescriptor_common_eefd8d4a460aa88b, []int{6}
+}
+func (m *Validation) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Validation.Unmarshal(m, b)
+}
+func (m *Validation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Validation.Marshal(b, m, deterministic)
+}
+func (dst *Validation) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Validation.Merge(dst, src)
+}
+func (m *Validation) XXX_Size() int {
+	return xxx_messageInfo_Validation.Size(m)
+}
+func (m *Validation) XXX_DiscardUnknown() {
+	xxx_messageInfo_Validation.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Validation proto.InternalMessageInfo
+
+func (m *Validation) GetStatus() Validation_ValidationStatus {
+	if m != nil {
+		return m.Status
+	}
+	return Validation_OK
+}
+
+func (m *Validation) GetTimestamp() int64 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
+func (m *Validation) GetPublicKey() string {
+	if m != nil {
+		return m.PublicKey
+	}
+	return ""
+}
+
+func (m *Validation) GetSignature() string {
+	if m != nil {
+		return m.Signature
+	}
+	return ""
+}
+
+type IDResponse struct {
+	Data                 *ID          `protobuf:"bytes,1,opt,name=Data,proto3" json:"Data,omitempty"`
+	Endorsement          *Endorsement `protobuf:"bytes,2,opt,name=Endorsement,proto3" json:"Endorsement,omitempty"`
+	Signature            string       `protobuf:"bytes,3,opt,name=Signature,proto3" json:"Signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *IDResponse) Reset()         { *m = IDResponse{} }
+func (m *IDResponse) String() string { return proto.CompactTextString(m) }
+func (*IDResponse) ProtoMessage()    {}
+func (*IDResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_common_eefd8d4a460aa88b, []int{7}
+}
+func (m *IDResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_IDResponse.Unmarshal(m, b)
+}
+func (m *IDResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_IDResponse.Marshal(b, m, deterministic)
+}
+func (dst *IDResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_IDResponse.Merge(dst, src)
+}
+func (m *IDResponse) XXX_Size() int {
+	return xxx_messageInfo_IDResponse.Size(m)
+}
+func (m *IDResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_IDResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_IDResponse proto.InternalMessageInfo
+
+func (m *IDResponse) GetData() *ID {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *IDResponse) GetEndorsement() *Endorsement {
+	if m != nil {
+		return m.Endorsement
+	}
+	return nil
+}
+
+func (m *IDResponse) GetSignature() string {
+	if m != nil {
+		return m.Signature
+	}
+	return ""
+}
+
+type KeychainResponse struct {
+	Data                 *Keychain    `protobuf:"bytes,1,opt,name=Data,proto3" json:"Data,omitempty"`
+	Endorsement          *Endorsement `protobuf:"bytes,2,opt,name=Endorsement,proto3" json:"Endorsement,omitempty"`
+	Signature            string       `protobuf:"bytes,3,opt,name=Signature,proto3" json:"Signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *KeychainResponse) Reset()         { *m = KeychainResponse{} }
+func (m *KeychainResponse) String() string { return proto.CompactTextString(m) }
+func (*KeychainResponse) ProtoMessage()    {}
+func (*KeychainResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_common_eefd8d4a460aa88b, []int{8}
+}
+func (m *KeychainResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_KeychainResponse.Unmarshal(m, b)
+}
+func (m *KeychainResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_KeychainResponse.Marshal(b, m, deterministic)
+}
+func (dst *KeychainResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_KeychainResponse.Merge(dst, src)
+}
+func (m *KeychainResponse) XXX_Size() int {
+	return xxx_messageInfo_KeychainResponse.Size(m)
+}
+func (m *KeychainResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_KeychainResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_KeychainResponse proto.InternalMessageInfo
+
+func (m *KeychainResponse) GetData() *Keychain {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *KeychainResponse) GetEndorsement() *Endorsement {
+	if m != nil {
+		return m.Endorsement
+	}
+	return nil
+}
+
+func (m *KeychainResponse) GetSignature() string {
+	if m != nil {
+		return m.Signature
+	}
+	return ""
+}
+
+type Proposal struct {
+	SharedEmitterKeyPair *KeyPairProposal `protobuf:"bytes,1,opt,name=SharedEmitterKeyPair,proto3" json:"SharedEmitterKeyPair,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *Proposal) Reset()         { *m = Proposal{} }
+func (m *Proposal) String() string { return proto.CompactTextString(m) }
+func (*Proposal) ProtoMessage()    {}
+func (*Proposal) Descriptor() ([]byte, []int) {
+	return fileDescriptor_common_eefd8d4a460aa88b, []int{9}
+}
+func (m *Proposal) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Proposal.Unmarshal(m, b)
+}
+func (m *Proposal) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Proposal.Marshal(b, m, deterministic)
+}
+func (dst *Proposal) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Proposal.Merge(dst, src)
+}
+func (m *Proposal) XXX_Size() int {
+	return xxx_messageInfo_Proposal.Size(m)
+}
+func (m *Proposal) XXX_DiscardUnknown() {
+	xxx_messageInfo_Proposal.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Proposal proto.InternalMessageInfo
+
+func (m *Proposal) GetSharedEmitterKeyPair() *KeyPairProposal {
+	if m != nil {
+		return m.SharedEmitterKeyPair
+	}
+	return nil
+}
+
+type KeyPairProposal struct {
+	EncryptedPrivateKey  string   `protobuf:"bytes,1,opt,name=EncryptedPrivateKey,proto3" json:"EncryptedPrivateKey,omitempty"`
+	PublicKey            string   `protobuf:"bytes,2,opt,name=PublicKey,proto3" json:"PublicKey,omitempty"`
+	ActivationDate       int64    `protobuf:"varint,3,opt,name=ActivationDate,proto3" json:"ActivationDate,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *KeyPairProposal) Reset()         { *m = KeyPairProposal{} }
+func (m *KeyPairProposal) String() string { return proto.CompactTextString(m) }
+func (*KeyPairProposal) ProtoMessage()    {}
+func (*KeyPairProposal) Descriptor() ([]byte, []int) {
+	return fileDescriptor_common_eefd8d4a460aa88b, []int{10}
+}
+func (m *KeyPairProposal) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_KeyPairProposal.Unmarshal(m, b)
+}
+func (m *KeyPairProposal) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_KeyPairProposal.Marshal(b, m, deterministic)
+}
+func (dst *KeyPairProposal) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_KeyPairProposal.Merge(dst, src)
+}
+func (m *KeyPairProposal) XXX_Size() int {
+	return xxx_messageInfo_KeyPairProposal.Size(m)
+}
+func (m *KeyPairProposal) XXX_DiscardUnknown() {
+	xxx_messageInfo_KeyPairProposal.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_KeyPairProposal proto.InternalMessageInfo
+
+func (m *KeyPairProposal) GetEncryptedPrivateKey() string {
+	if m != nil {
+		return m.EncryptedPrivateKey
+	}
+	return ""
+}
+
+func (m *KeyPairProposal) GetPublicKey() string {
+	if m != nil {
+		return m.PublicKey
+	}
+	return ""
+}
+
+func (m *KeyPairProposal) GetActivationDate() int64 {
+	if m != nil {
+		return m.ActivationDate
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*TransactionStatusRequest)(nil), "api.TransactionStatusRequest")
 	proto.RegisterType((*TransactionStatusResponse)(nil), "api.TransactionStatusResponse")
+	proto.RegisterType((*Keychain)(nil), "api.Keychain")
+	proto.RegisterType((*ID)(nil), "api.ID")
+	proto.RegisterType((*Endorsement)(nil), "api.Endorsement")
+	proto.RegisterType((*MasterValidation)(nil), "api.MasterValidation")
+	proto.RegisterType((*Validation)(nil), "api.Validation")
+	proto.RegisterType((*IDResponse)(nil), "api.IDResponse")
+	proto.RegisterType((*KeychainResponse)(nil), "api.KeychainResponse")
+	proto.RegisterType((*Proposal)(nil), "api.Proposal")
+	proto.RegisterType((*KeyPairProposal)(nil), "api.KeyPairProposal")
 	proto.RegisterEnum("api.TransactionStatusResponse_TransactionStatus", TransactionStatusResponse_TransactionStatus_name, TransactionStatusResponse_TransactionStatus_value)
+	proto.RegisterEnum("api.Validation_ValidationStatus", Validation_ValidationStatus_name, Validation_ValidationStatus_value)
 }
 
-func init() { proto.RegisterFile("common.proto", fileDescriptor_common_503c6ddff5f6ceb0) }
-
-var fileDescriptor_common_503c6ddff5f6ceb0 = []byte{
-	// 197 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0xe2, 0x49, 0xce, 0xcf, 0xcd,
-	0xcd, 0xcf, 0xd3, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0x62, 0x4e, 0x2c, 0xc8, 0x54, 0xf2, 0xe0,
-	0x92, 0x08, 0x29, 0x4a, 0xcc, 0x2b, 0x4e, 0x4c, 0x2e, 0xc9, 0xcc, 0xcf, 0x0b, 0x2e, 0x49, 0x2c,
-	0x29, 0x2d, 0x0e, 0x4a, 0x2d, 0x2c, 0x4d, 0x2d, 0x2e, 0x11, 0x92, 0xe0, 0x62, 0x77, 0x4c, 0x49,
-	0x29, 0x4a, 0x2d, 0x2e, 0x96, 0x60, 0x54, 0x60, 0xd4, 0xe0, 0x0c, 0x82, 0x71, 0x85, 0x84, 0xb8,
-	0x58, 0x3c, 0x12, 0x8b, 0x33, 0x24, 0x98, 0xc0, 0xc2, 0x60, 0xb6, 0xd2, 0x3a, 0x46, 0x2e, 0x49,
-	0x2c, 0x46, 0x15, 0x17, 0xe4, 0xe7, 0x15, 0xa7, 0x0a, 0x79, 0x70, 0xb1, 0x41, 0x44, 0xc0, 0x46,
-	0xf1, 0x19, 0x19, 0xe8, 0x25, 0x16, 0x64, 0xea, 0xe1, 0x54, 0x8f, 0x45, 0x06, 0xaa, 0x5f, 0xc9,
-	0x9d, 0x4b, 0x10, 0x43, 0x52, 0x88, 0x9b, 0x8b, 0x3d, 0x20, 0x35, 0x2f, 0x25, 0x33, 0x2f, 0x5d,
-	0x80, 0x01, 0xc4, 0x09, 0x2e, 0x4d, 0x4e, 0x4e, 0x2d, 0x2e, 0x16, 0x60, 0x04, 0x71, 0xdc, 0x12,
-	0x33, 0x73, 0x4a, 0x8b, 0x52, 0x05, 0x98, 0x40, 0x9c, 0xd0, 0xbc, 0xec, 0xbc, 0xfc, 0xf2, 0x3c,
-	0x01, 0xe6, 0x24, 0x36, 0x70, 0x30, 0x18, 0x03, 0x02, 0x00, 0x00, 0xff, 0xff, 0xe4, 0xd1, 0x62,
-	0xf8, 0x16, 0x01, 0x00, 0x00,
+func init() { proto.RegisterFile("common.proto", fileDescriptor_common_eefd8d4a460aa88b) }
+
+var fileDescriptor_common_eefd8d4a460aa88b = []byte{
+	// 724 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x55, 0xcd, 0x6e, 0x13, 0x3b,
+	0x14, 0xee, 0xcc, 0xe4, 0x26, 0xcd, 0x99, 0x7b, 0xdb, 0xa9, 0x6f, 0x2b, 0x0d, 0xa2, 0x8b, 0x30,
+	0x8b, 0x2a, 0x20, 0x54, 0x95, 0xc0, 0x82, 0x6d, 0x50, 0x02, 0x89, 0x42, 0xd5, 0x68, 0x52, 0xe8,
+	0xda, 0x9d, 0xb8, 0xed, 0xa8, 0x89, 0x1d, 0x6c, 0x07, 0x14, 0xb1, 0x64, 0x81, 0x78, 0x19, 0x5e,
+	0x80, 0x2d, 0xef, 0xc1, 0xab, 0x20, 0x7b, 0x26, 0xf3, 0xe3, 0x4c, 0x11, 0x2c, 0x58, 0x65, 0xfc,
+	0x9d, 0xcf, 0xc7, 0xe7, 0xfb, 0x7c, 0x7c, 0x02, 0xff, 0x46, 0x6c, 0x3e, 0x67, 0xf4, 0x78, 0xc1,
+	0x99, 0x64, 0xc8, 0xc1, 0x8b, 0x38, 0x18, 0x80, 0x7f, 0xce, 0x31, 0x15, 0x38, 0x92, 0x31, 0xa3,
+	0x13, 0x89, 0xe5, 0x52, 0x84, 0xe4, 0xdd, 0x92, 0x08, 0x89, 0x7c, 0x68, 0x74, 0xa7, 0x53, 0x4e,
+	0x84, 0xf0, 0xad, 0x96, 0xd5, 0x6e, 0x86, 0xeb, 0x25, 0x42, 0x50, 0x1b, 0x60, 0x71, 0xe3, 0xdb,
+	0x1a, 0xd6, 0xdf, 0xc1, 0x57, 0x0b, 0xee, 0x55, 0xa4, 0x12, 0x0b, 0x46, 0x05, 0x41, 0x03, 0xa8,
+	0x27, 0x88, 0x4e, 0xb5, 0xd3, 0x39, 0x39, 0xc6, 0x8b, 0xf8, 0xf8, 0x4e, 0x7e, 0x45, 0x24, 0xdd,
+	0x1f, 0xbc, 0x82, 0xbd, 0x8d, 0x20, 0x72, 0xa1, 0x31, 0x26, 0x74, 0x1a, 0xd3, 0x6b, 0x6f, 0x4b,
+	0x2d, 0x26, 0xcb, 0x28, 0x22, 0x42, 0x78, 0x96, 0x5a, 0xbc, 0xc4, 0xf1, 0x6c, 0xc9, 0x89, 0x67,
+	0xab, 0xc5, 0x1b, 0x7a, 0x4b, 0xd9, 0x07, 0xea, 0x39, 0xc1, 0x27, 0x1b, 0xb6, 0x47, 0x64, 0x15,
+	0xdd, 0xe0, 0x98, 0xa2, 0x0e, 0xec, 0xf7, 0x69, 0xc4, 0x57, 0x0b, 0x49, 0xa6, 0x4a, 0xe5, 0x8b,
+	0x55, 0xc8, 0x2e, 0x99, 0x4c, 0x85, 0x57, 0xc6, 0x50, 0x1b, 0x76, 0x33, 0xfc, 0x02, 0xcf, 0x66,
+	0x44, 0xa6, 0x86, 0x98, 0x30, 0x6a, 0x81, 0x3b, 0xec, 0x8d, 0x97, 0x97, 0xb3, 0x38, 0x1a, 0x91,
+	0x95, 0xef, 0x68, 0x56, 0x11, 0x42, 0x0f, 0x61, 0x7b, 0xcc, 0xd9, 0x82, 0x09, 0x3c, 0xf3, 0x6b,
+	0x2d, 0xab, 0xed, 0x76, 0xfe, 0xd3, 0x0e, 0xad, 0xc1, 0x30, 0x0b, 0x27, 0xc9, 0x26, 0xf1, 0x35,
+	0xc5, 0x72, 0xc9, 0x89, 0xff, 0xcf, 0x3a, 0x59, 0x06, 0xa1, 0x47, 0xe0, 0xf5, 0xe7, 0xb1, 0x94,
+	0x84, 0xe7, 0xb4, 0xba, 0xa6, 0x6d, 0xe0, 0xc1, 0x37, 0x1b, 0xec, 0x61, 0x2f, 0xbb, 0x51, 0x2b,
+	0xbf, 0xd1, 0x3b, 0x3d, 0xb1, 0x7f, 0xe1, 0xc9, 0x63, 0xd8, 0x33, 0xf0, 0x61, 0x2f, 0xd5, 0xbb,
+	0x19, 0x28, 0x39, 0xd8, 0xed, 0x4f, 0x94, 0x37, 0x35, 0xc3, 0xc1, 0x04, 0x46, 0x87, 0xd0, 0xcc,
+	0xfd, 0x4b, 0x24, 0x37, 0xab, 0xdd, 0xab, 0xff, 0x91, 0x7b, 0x8d, 0xdf, 0x73, 0x6f, 0xfb, 0x0e,
+	0xf7, 0x7e, 0x58, 0xe0, 0xf6, 0xe9, 0x94, 0x71, 0x41, 0xe6, 0x84, 0x4a, 0x74, 0x02, 0xff, 0xbf,
+	0xc6, 0x42, 0x16, 0x1a, 0xb4, 0xe0, 0x6a, 0x55, 0x48, 0x59, 0x60, 0xb2, 0xd3, 0x26, 0x32, 0x99,
+	0x5d, 0xf0, 0x4e, 0xb1, 0x90, 0x84, 0xbf, 0xc5, 0xb3, 0x78, 0x8a, 0x15, 0xae, 0x9d, 0x75, 0x3b,
+	0x07, 0x5a, 0xac, 0x19, 0x0c, 0x37, 0xe8, 0xe8, 0x09, 0xb8, 0xf9, 0x4a, 0xf8, 0xb5, 0x96, 0xd3,
+	0x76, 0x3b, 0xbb, 0x7a, 0x77, 0x61, 0x5f, 0x91, 0xa3, 0x14, 0x6e, 0xe6, 0x39, 0x82, 0x9d, 0x31,
+	0x67, 0xec, 0xea, 0xec, 0xea, 0x82, 0xf1, 0x5b, 0x75, 0x25, 0x89, 0x42, 0x03, 0x45, 0x7d, 0x38,
+	0x28, 0x20, 0x85, 0xba, 0xed, 0x96, 0x55, 0x75, 0x72, 0x35, 0x1b, 0x3d, 0x83, 0x03, 0xc3, 0xba,
+	0xd3, 0x98, 0x12, 0x2e, 0x7c, 0xa7, 0xe5, 0xb4, 0x9b, 0x61, 0x75, 0x50, 0x15, 0x99, 0xe7, 0x18,
+	0x33, 0x36, 0xd3, 0x7a, 0x9b, 0xa1, 0x81, 0x06, 0xdf, 0x2d, 0x80, 0xc2, 0x61, 0xcf, 0x8d, 0x49,
+	0xd5, 0x32, 0x8a, 0x2c, 0x7c, 0x96, 0x27, 0x93, 0xea, 0xd1, 0xf3, 0x78, 0x4e, 0x84, 0xc4, 0xf3,
+	0x85, 0x56, 0xe8, 0x84, 0x39, 0x50, 0xee, 0x60, 0xc7, 0xec, 0xe0, 0x43, 0x68, 0xe6, 0xdd, 0x96,
+	0xbc, 0x81, 0x1c, 0x08, 0x02, 0xf0, 0xcc, 0x53, 0x51, 0x1d, 0xec, 0xb3, 0x91, 0xb7, 0xa5, 0x7e,
+	0x47, 0x67, 0x9e, 0x15, 0x7c, 0x04, 0x18, 0xf6, 0xb2, 0x79, 0x7b, 0x1f, 0x6a, 0x3d, 0x2c, 0xb1,
+	0xd6, 0xe0, 0x76, 0x1a, 0x5a, 0xc3, 0xb0, 0x17, 0x6a, 0x10, 0x75, 0x4a, 0x4d, 0x9b, 0x5e, 0x86,
+	0xa7, 0x39, 0x05, 0x3c, 0x2c, 0x75, 0x76, 0xa9, 0x40, 0xc7, 0x2c, 0xf0, 0xb3, 0x05, 0xde, 0x7a,
+	0x96, 0x66, 0x35, 0x3c, 0x28, 0xd5, 0x90, 0xbc, 0xc8, 0x8c, 0xf4, 0xb7, 0x2a, 0x39, 0xcf, 0x47,
+	0x01, 0x1a, 0xc0, 0xfe, 0xe4, 0x06, 0x73, 0x32, 0x4d, 0xdf, 0xed, 0x88, 0xac, 0xc6, 0x38, 0xe6,
+	0x69, 0x41, 0xfb, 0xeb, 0x82, 0x14, 0xb6, 0xde, 0x13, 0x56, 0xee, 0x08, 0xbe, 0x58, 0xb0, 0x6b,
+	0x30, 0xd5, 0x5b, 0xcf, 0xa6, 0xd4, 0x98, 0xc7, 0xef, 0xb1, 0x24, 0xf9, 0x4b, 0xa8, 0x0a, 0x95,
+	0x5b, 0xc0, 0x36, 0x5b, 0xe0, 0x08, 0x76, 0xba, 0x91, 0x54, 0xe4, 0x98, 0xd1, 0x1e, 0x96, 0x89,
+	0x38, 0x27, 0x34, 0xd0, 0xcb, 0xba, 0xfe, 0xfb, 0x7e, 0xfa, 0x73, 0x00, 0x1d, 0x5c, 0xe7, 0xfd,
+	0xce, 0x07, 0x00, 0x00,
 }
@@ -24,27 +24,74 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
 
-type Validation_ValidationStatus int32
+type RequestEnvelope struct {
+	Method               string   `protobuf:"bytes,1,opt,name=Method,proto3" json:"Method,omitempty"`
+	Signer               string   `protobuf:"bytes,2,opt,name=Signer,proto3" json:"Signer,omitempty"`
+	Timestamp            int64    `protobuf:"varint,3,opt,name=Timestamp,proto3" json:"Timestamp,omitempty"`
+	Nonce                string   `protobuf:"bytes,4,opt,name=Nonce,proto3" json:"Nonce,omitempty"`
+	Signature            string   `protobuf:"bytes,5,opt,name=Signature,proto3" json:"Signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
 
-const (
-	Validation_OK Validation_ValidationStatus = 0
-	Validation_KO Validation_ValidationStatus = 1
-)
+func (m *RequestEnvelope) Reset()         { *m = RequestEnvelope{} }
+func (m *RequestEnvelope) String() string { return proto.CompactTextString(m) }
+func (*RequestEnvelope) ProtoMessage()    {}
+func (*RequestEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_external_39a01f33235bd96a, []int{0}
+}
+func (m *RequestEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RequestEnvelope.Unmarshal(m, b)
+}
+func (m *RequestEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RequestEnvelope.Marshal(b, m, deterministic)
+}
+func (dst *RequestEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RequestEnvelope.Merge(dst, src)
+}
+func (m *RequestEnvelope) XXX_Size() int {
+	return xxx_messageInfo_RequestEnvelope.Size(m)
+}
+func (m *RequestEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_RequestEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RequestEnvelope proto.InternalMessageInfo
 
-var Validation_ValidationStatus_name = map[int32]string{
-	0: "OK",
-	1: "KO",
+func (m *RequestEnvelope) GetMethod() string {
+	if m != nil {
+		return m.Method
+	}
+	return ""
+}
+
+func (m *RequestEnvelope) GetSigner() string {
+	if m != nil {
+		return m.Signer
+	}
+	return ""
 }
-var Validation_ValidationStatus_value = map[string]int32{
-	"OK": 0,
-	"KO": 1,
+
+func (m *RequestEnvelope) GetTimestamp() int64 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
 }
 
-func (x Validation_ValidationStatus) String() string {
-	return proto.EnumName(Validation_ValidationStatus_name, int32(x))
+func (m *RequestEnvelope) GetNonce() string {
+	if m != nil {
+		return m.Nonce
+	}
+	return ""
 }
-func (Validation_ValidationStatus) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_external_7d7d3c2131b69205, []int{14, 0}
+
+func (m *RequestEnvelope) GetSignature() string {
+	if m != nil {
+		return m.Signature
+	}
+	return ""
 }
 
 type LockAck struct {
@@ -59,7 +106,7 @@ func (m *LockAck) Reset()         { *m = LockAck{} }
 func (m *LockAck) String() string { return proto.CompactTextString(m) }
 func (*LockAck) ProtoMessage()    {}
 func (*LockAck) Descriptor() ([]byte, []int) {
-	return fileDescriptor_external_7d7d3c2131b69205, []int{0}
+	return fileDescriptor_external_39a01f33235bd96a, []int{1}
 }
 func (m *LockAck) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_LockAck.Unmarshal(m, b)
@@ -105,7 +152,7 @@ func (m *StorageAck) Reset()         { *m = StorageAck{} }
 func (m *StorageAck) String() string { return proto.CompactTextString(m) }
 func (*StorageAck) ProtoMessage()    {}
 func (*StorageAck) Descriptor() ([]byte, []int) {
-	return fileDescriptor_external_7d7d3c2131b69205, []int{1}
+	return fileDescriptor_external_39a01f33235bd96a, []int{2}
 }
 func (m *StorageAck) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_StorageAck.Unmarshal(m, b)
@@ -143,7 +190,6 @@ type KeychainLeadRequest struct {
 	TransactionHash      string   `protobuf:"bytes,1,opt,name=TransactionHash,proto3" json:"TransactionHash,omitempty"`
 	ValidatorPeerIPs     []string `protobuf:"bytes,2,rep,name=ValidatorPeerIPs,proto3" json:"ValidatorPeerIPs,omitempty"`
 	EncryptedKeychain    string   `protobuf:"bytes,3,opt,name=EncryptedKeychain,proto3" json:"EncryptedKeychain,omitempty"`
-	SignatureRequest     string   `protobuf:"bytes,5,opt,name=SignatureRequest,proto3" json:"SignatureRequest,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -153,7 +199,7 @@ func (m *KeychainLeadRequest) Reset()         { *m = KeychainLeadRequest{} }
 func (m *KeychainLeadRequest) String() string { return proto.CompactTextString(m) }
 func (*KeychainLeadRequest) ProtoMessage()    {}
 func (*KeychainLeadRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_external_7d7d3c2131b69205, []int{2}
+	return fileDescriptor_external_39a01f33235bd96a, []int{3}
 }
 func (m *KeychainLeadRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_KeychainLeadRequest.Unmarshal(m, b)
@@ -194,18 +240,10 @@ func (m *KeychainLeadRequest) GetEncryptedKeychain() string {
 	return ""
 }
 
-func (m *KeychainLeadRequest) GetSignatureRequest() string {
-	if m != nil {
-		return m.SignatureRequest
-	}
-	return ""
-}
-
 type IDLeadRequest struct {
 	TransactionHash      string   `protobuf:"bytes,1,opt,name=TransactionHash,proto3" json:"TransactionHash,omitempty"`
 	ValidatorPeerIPs     []string `protobuf:"bytes,2,rep,name=ValidatorPeerIPs,proto3" json:"ValidatorPeerIPs,omitempty"`
 	EncryptedID          string   `protobuf:"bytes,3,opt,name=EncryptedID,proto3" json:"EncryptedID,omitempty"`
-	SignatureRequest     string   `protobuf:"bytes,4,opt,name=SignatureRequest,proto3" json:"SignatureRequest,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -215,7 +253,7 @@ func (m *IDLeadRequest) Reset()         { *m = IDLeadRequest{} }
 func (m *IDLeadRequest) String() string { return proto.CompactTextString(m) }
 func (*IDLeadRequest) ProtoMessage()    {}
 func (*IDLeadRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_external_7d7d3c2131b69205, []int{3}
+	return fileDescriptor_external_39a01f33235bd96a, []int{4}
 }
 func (m *IDLeadRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_IDLeadRequest.Unmarshal(m, b)
@@ -256,17 +294,10 @@ func (m *IDLeadRequest) GetEncryptedID() string {
 	return ""
 }
 
-func (m *IDLeadRequest) GetSignatureRequest() string {
-	if m != nil {
-		return m.SignatureRequest
-	}
-	return ""
-}
-
 type KeychainValidationRequest struct {
 	TransactionHash      string    `protobuf:"bytes,1,opt,name=TransactionHash,proto3" json:"TransactionHash,omitempty"`
 	Data                 *Keychain `protobuf:"bytes,2,opt,name=Data,proto3" json:"Data,omitempty"`
-	Signature            string    `protobuf:"bytes,3,opt,name=Signature,proto3" json:"Signature,omitempty"`
+	ValidationPool       []string  `protobuf:"bytes,4,rep,name=ValidationPool,proto3" json:"ValidationPool,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
 	XXX_unrecognized     []byte    `json:"-"`
 	XXX_sizecache        int32     `json:"-"`
@@ -276,7 +307,7 @@ func (m *KeychainValidationRequest) Reset()         { *m = KeychainValidationReq
 func (m *KeychainValidationRequest) String() string { return proto.CompactTextString(m) }
 func (*KeychainValidationRequest) ProtoMessage()    {}
 func (*KeychainValidationRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_external_7d7d3c2131b69205, []int{4}
+	return fileDescriptor_external_39a01f33235bd96a, []int{5}
 }
 func (m *KeychainValidationRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_KeychainValidationRequest.Unmarshal(m, b)
@@ -310,17 +341,17 @@ func (m *KeychainValidationRequest) GetData() *Keychain {
 	return nil
 }
 
-func (m *KeychainValidationRequest) GetSignature() string {
+func (m *KeychainValidationRequest) GetValidationPool() []string {
 	if m != nil {
-		return m.Signature
+		return m.ValidationPool
 	}
-	return ""
+	return nil
 }
 
 type IDValidationRequest struct {
 	TransactionHash      string   `protobuf:"bytes,1,opt,name=TransactionHash,proto3" json:"TransactionHash,omitempty"`
 	Data                 *ID      `protobuf:"bytes,2,opt,name=Data,proto3" json:"Data,omitempty"`
-	Signature            string   `protobuf:"bytes,3,opt,name=Signature,proto3" json:"Signature,omitempty"`
+	ValidationPool       []string `protobuf:"bytes,4,rep,name=ValidationPool,proto3" json:"ValidationPool,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -330,7 +361,7 @@ func (m *IDValidationRequest) Reset()         { *m = IDValidationRequest{} }
 func (m *IDValidationRequest) String() string { return proto.CompactTextString(m) }
 func (*IDValidationRequest) ProtoMessage()    {}
 func (*IDValidationRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_external_7d7d3c2131b69205, []int{5}
+	return fileDescriptor_external_39a01f33235bd96a, []int{6}
 }
 func (m *IDValidationRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_IDValidationRequest.Unmarshal(m, b)
@@ -364,17 +395,16 @@ func (m *IDValidationRequest) GetData() *ID {
 	return nil
 }
 
-func (m *IDValidationRequest) GetSignature() string {
+func (m *IDValidationRequest) GetValidationPool() []string {
 	if m != nil {
-		return m.Signature
+		return m.ValidationPool
 	}
-	return ""
+	return nil
 }
 
 type KeychainStorageRequest struct {
 	Data                 *Keychain    `protobuf:"bytes,1,opt,name=Data,proto3" json:"Data,omitempty"`
 	Endorsement          *Endorsement `protobuf:"bytes,2,opt,name=Endorsement,proto3" json:"Endorsement,omitempty"`
-	Signature            string       `protobuf:"bytes,3,opt,name=Signature,proto3" json:"Signature,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
 	XXX_unrecognized     []byte       `json:"-"`
 	XXX_sizecache        int32        `json:"-"`
@@ -384,7 +414,7 @@ func (m *KeychainStorageRequest) Reset()         { *m = KeychainStorageRequest{}
 func (m *KeychainStorageRequest) String() string { return proto.CompactTextString(m) }
 func (*KeychainStorageRequest) ProtoMessage()    {}
 func (*KeychainStorageRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_external_7d7d3c2131b69205, []int{6}
+	return fileDescriptor_external_39a01f33235bd96a, []int{7}
 }
 func (m *KeychainStorageRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_KeychainStorageRequest.Unmarshal(m, b)
@@ -418,17 +448,9 @@ func (m *KeychainStorageRequest) GetEndorsement() *Endorsement {
 	return nil
 }
 
-func (m *KeychainStorageRequest) GetSignature() string {
-	if m != nil {
-		return m.Signature
-	}
-	return ""
-}
-
 type IDStorageRequest struct {
 	Data                 *ID          `protobuf:"bytes,1,opt,name=Data,proto3" json:"Data,omitempty"`
 	Endorsement          *Endorsement `protobuf:"bytes,2,opt,name=Endorsement,proto3" json:"Endorsement,omitempty"`
-	Signature            string       `protobuf:"bytes,3,opt,name=Signature,proto3" json:"Signature,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
 	XXX_unrecognized     []byte       `json:"-"`
 	XXX_sizecache        int32        `json:"-"`
@@ -438,7 +460,7 @@ func (m *IDStorageRequest) Reset()         { *m = IDStorageRequest{} }
 func (m *IDStorageRequest) String() string { return proto.CompactTextString(m) }
 func (*IDStorageRequest) ProtoMessage()    {}
 func (*IDStorageRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_external_7d7d3c2131b69205, []int{7}
+	return fileDescriptor_external_39a01f33235bd96a, []int{8}
 }
 func (m *IDStorageRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_IDStorageRequest.Unmarshal(m, b)
@@ -472,18 +494,10 @@ func (m *IDStorageRequest) GetEndorsement() *Endorsement {
 	return nil
 }
 
-func (m *IDStorageRequest) GetSignature() string {
-	if m != nil {
-		return m.Signature
-	}
-	return ""
-}
-
 type LockRequest struct {
 	Address              string   `protobuf:"bytes,1,opt,name=Address,proto3" json:"Address,omitempty"`
 	TransactionHash      string   `protobuf:"bytes,2,opt,name=TransactionHash,proto3" json:"TransactionHash,omitempty"`
 	MasterRobotKey       string   `protobuf:"bytes,3,opt,name=MasterRobotKey,proto3" json:"MasterRobotKey,omitempty"`
-	Signature            string   `protobuf:"bytes,4,opt,name=Signature,proto3" json:"Signature,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -493,7 +507,7 @@ func (m *LockRequest) Reset()         { *m = LockRequest{} }
 func (m *LockRequest) String() string { return proto.CompactTextString(m) }
 func (*LockRequest) ProtoMessage()    {}
 func (*LockRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_external_7d7d3c2131b69205, []int{8}
+	return fileDescriptor_external_39a01f33235bd96a, []int{9}
 }
 func (m *LockRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_LockRequest.Unmarshal(m, b)
@@ -534,301 +548,6 @@ func (m *LockRequest) GetMasterRobotKey() string {
 	return ""
 }
 
-func (m *LockRequest) GetSignature() string {
-	if m != nil {
-		return m.Signature
-	}
-	return ""
-}
-
-type Keychain struct {
-	EncryptedAddrByRobot string    `protobuf:"bytes,1,opt,name=EncryptedAddrByRobot,proto3" json:"EncryptedAddrByRobot,omitempty"`
-	EncryptedWallet      string    `protobuf:"bytes,2,opt,name=EncryptedWallet,proto3" json:"EncryptedWallet,omitempty"`
-	IDPublicKey          string    `protobuf:"bytes,3,opt,name=IDPublicKey,proto3" json:"IDPublicKey,omitempty"`
-	Proposal             *Proposal `protobuf:"bytes,4,opt,name=Proposal,proto3" json:"Proposal,omitempty"`
-	IDSignature          string    `protobuf:"bytes,5,opt,name=IDSignature,proto3" json:"IDSignature,omitempty"`
-	EmitterSignature     string    `protobuf:"bytes,6,opt,name=EmitterSignature,proto3" json:"EmitterSignature,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
-	XXX_unrecognized     []byte    `json:"-"`
-	XXX_sizecache        int32     `json:"-"`
-}
-
-func (m *Keychain) Reset()         { *m = Keychain{} }
-func (m *Keychain) String() string { return proto.CompactTextString(m) }
-func (*Keychain) ProtoMessage()    {}
-func (*Keychain) Descriptor() ([]byte, []int) {
-	return fileDescriptor_external_7d7d3c2131b69205, []int{9}
-}
-func (m *Keychain) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_Keychain.Unmarshal(m, b)
-}
-func (m *Keychain) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_Keychain.Marshal(b, m, deterministic)
-}
-func (dst *Keychain) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Keychain.Merge(dst, src)
-}
-func (m *Keychain) XXX_Size() int {
-	return xxx_messageInfo_Keychain.Size(m)
-}
-func (m *Keychain) XXX_DiscardUnknown() {
-	xxx_messageInfo_Keychain.DiscardUnknown(m)
-}
-
-var xxx_messageInfo_Keychain proto.InternalMessageInfo
-
-func (m *Keychain) GetEncryptedAddrByRobot() string {
-	if m != nil {
-		return m.EncryptedAddrByRobot
-	}
-	return ""
-}
-
-func (m *Keychain) GetEncryptedWallet() string {
-	if m != nil {
-		return m.EncryptedWallet
-	}
-	return ""
-}
-
-func (m *Keychain) GetIDPublicKey() string {
-	if m != nil {
-		return m.IDPublicKey
-	}
-	return ""
-}
-
-func (m *Keychain) GetProposal() *Proposal {
-	if m != nil {
-		return m.Proposal
-	}
-	return nil
-}
-
-func (m *Keychain) GetIDSignature() string {
-	if m != nil {
-		return m.IDSignature
-	}
-	return ""
-}
-
-func (m *Keychain) GetEmitterSignature() string {
-	if m != nil {
-		return m.EmitterSignature
-	}
-	return ""
-}
-
-type ID struct {
-	Hash                 string    `protobuf:"bytes,1,opt,name=Hash,proto3" json:"Hash,omitempty"`
-	EncryptedAddrByRobot string    `protobuf:"bytes,2,opt,name=EncryptedAddrByRobot,proto3" json:"EncryptedAddrByRobot,omitempty"`
-	EncryptedAddrByID    string    `protobuf:"bytes,3,opt,name=EncryptedAddrByID,proto3" json:"EncryptedAddrByID,omitempty"`
-	EncryptedAESKey      string    `protobuf:"bytes,4,opt,name=EncryptedAESKey,proto3" json:"EncryptedAESKey,omitempty"`
-	PublicKey            string    `protobuf:"bytes,5,opt,name=PublicKey,proto3" json:"PublicKey,omitempty"`
-	Proposal             *Proposal `protobuf:"bytes,6,opt,name=Proposal,proto3" json:"Proposal,omitempty"`
-	IDSignature          string    `protobuf:"bytes,7,opt,name=IDSignature,proto3" json:"IDSignature,omitempty"`
-	EmitterSignature     string    `protobuf:"bytes,8,opt,name=EmitterSignature,proto3" json:"EmitterSignature,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
-	XXX_unrecognized     []byte    `json:"-"`
-	XXX_sizecache        int32     `json:"-"`
-}
-
-func (m *ID) Reset()         { *m = ID{} }
-func (m *ID) String() string { return proto.CompactTextString(m) }
-func (*ID) ProtoMessage()    {}
-func (*ID) Descriptor() ([]byte, []int) {
-	return fileDescriptor_external_7d7d3c2131b69205, []int{10}
-}
-func (m *ID) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ID.Unmarshal(m, b)
-}
-func (m *ID) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ID.Marshal(b, m, deterministic)
-}
-func (dst *ID) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ID.Merge(dst, src)
-}
-func (m *ID) XXX_Size() int {
-	return xxx_messageInfo_ID.Size(m)
-}
-func (m *ID) XXX_DiscardUnknown() {
-	xxx_messageInfo_ID.DiscardUnknown(m)
-}
-
-var xxx_messageInfo_ID proto.InternalMessageInfo
-
-func (m *ID) GetHash() string {
-	if m != nil {
-		return m.Hash
-	}
-	return ""
-}
-
-func (m *ID) GetEncryptedAddrByRobot() string {
-	if m != nil {
-		return m.EncryptedAddrByRobot
-	}
-	return ""
-}
-
-func (m *ID) GetEncryptedAddrByID() string {
-	if m != nil {
-		return m.EncryptedAddrByID
-	}
-	return ""
-}
-
-func (m *ID) GetEncryptedAESKey() string {
-	if m != nil {
-		return m.EncryptedAESKey
-	}
-	return ""
-}
-
-func (m *ID) GetPublicKey() string {
-	if m != nil {
-		return m.PublicKey
-	}
-	return ""
-}
-
-func (m *ID) GetProposal() *Proposal {
-	if m != nil {
-		return m.Proposal
-	}
-	return nil
-}
-
-func (m *ID) GetIDSignature() string {
-	if m != nil {
-		return m.IDSignature
-	}
-	return ""
-}
-
-func (m *ID) GetEmitterSignature() string {
-	if m != nil {
-		return m.EmitterSignature
-	}
-	return ""
-}
-
-type Endorsement struct {
-	LastTransactionHash  string            `protobuf:"bytes,1,opt,name=LastTransactionHash,proto3" json:"LastTransactionHash,omitempty"`
-	TransactionHash      string            `protobuf:"bytes,2,opt,name=TransactionHash,proto3" json:"TransactionHash,omitempty"`
-	MasterValidation     *MasterValidation `protobuf:"bytes,3,opt,name=MasterValidation,proto3" json:"MasterValidation,omitempty"`
-	Validations          []*Validation     `protobuf:"bytes,4,rep,name=Validations,proto3" json:"Validations,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
-	XXX_unrecognized     []byte            `json:"-"`
-	XXX_sizecache        int32             `json:"-"`
-}
-
-func (m *Endorsement) Reset()         { *m = Endorsement{} }
-func (m *Endorsement) String() string { return proto.CompactTextString(m) }
-func (*Endorsement) ProtoMessage()    {}
-func (*Endorsement) Descriptor() ([]byte, []int) {
-	return fileDescriptor_external_7d7d3c2131b69205, []int{11}
-}
-func (m *Endorsement) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_Endorsement.Unmarshal(m, b)
-}
-func (m *Endorsement) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_Endorsement.Marshal(b, m, deterministic)
-}
-func (dst *Endorsement) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Endorsement.Merge(dst, src)
-}
-func (m *Endorsement) XXX_Size() int {
-	return xxx_messageInfo_Endorsement.Size(m)
-}
-func (m *Endorsement) XXX_DiscardUnknown() {
-	xxx_messageInfo_Endorsement.DiscardUnknown(m)
-}
-
-var xxx_messageInfo_Endorsement proto.InternalMessageInfo
-
-func (m *Endorsement) GetLastTransactionHash() string {
-	if m != nil {
-		return m.LastTransactionHash
-	}
-	return ""
-}
-
-func (m *Endorsement) GetTransactionHash() string {
-	if m != nil {
-		return m.TransactionHash
-	}
-	return ""
-}
-
-func (m *Endorsement) GetMasterValidation() *MasterValidation {
-	if m != nil {
-		return m.MasterValidation
-	}
-	return nil
-}
-
-func (m *Endorsement) GetValidations() []*Validation {
-	if m != nil {
-		return m.Validations
-	}
-	return nil
-}
-
-type MasterValidation struct {
-	ProofOfWorkKey        string      `protobuf:"bytes,1,opt,name=ProofOfWorkKey,proto3" json:"ProofOfWorkKey,omitempty"`
-	ProofOfWorkValidation *Validation `protobuf:"bytes,2,opt,name=ProofOfWorkValidation,proto3" json:"ProofOfWorkValidation,omitempty"`
-	LastTransactionMiners []string    `protobuf:"bytes,3,rep,name=LastTransactionMiners,proto3" json:"LastTransactionMiners,omitempty"`
-	XXX_NoUnkeyedLiteral  struct{}    `json:"-"`
-	XXX_unrecognized      []byte      `json:"-"`
-	XXX_sizecache         int32       `json:"-"`
-}
-
-func (m *MasterValidation) Reset()         { *m = MasterValidation{} }
-func (m *MasterValidation) String() string { return proto.CompactTextString(m) }
-func (*MasterValidation) ProtoMessage()    {}
-func (*MasterValidation) Descriptor() ([]byte, []int) {
-	return fileDescriptor_external_7d7d3c2131b69205, []int{12}
-}
-func (m *MasterValidation) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_MasterValidation.Unmarshal(m, b)
-}
-func (m *MasterValidation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_MasterValidation.Marshal(b, m, deterministic)
-}
-func (dst *MasterValidation) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_MasterValidation.Merge(dst, src)
-}
-func (m *MasterValidation) XXX_Size() int {
-	return xxx_messageInfo_MasterValidation.Size(m)
-}
-func (m *MasterValidation) XXX_DiscardUnknown() {
-	xxx_messageInfo_MasterValidation.DiscardUnknown(m)
-}
-
-var xxx_messageInfo_MasterValidation proto.InternalMessageInfo
-
-func (m *MasterValidation) GetProofOfWorkKey() string {
-	if m != nil {
-		return m.ProofOfWorkKey
-	}
-	return ""
-}
-
-func (m *MasterValidation) GetProofOfWorkValidation() *Validation {
-	if m != nil {
-		return m.ProofOfWorkValidation
-	}
-	return nil
-}
-
-func (m *MasterValidation) GetLastTransactionMiners() []string {
-	if m != nil {
-		return m.LastTransactionMiners
-	}
-	return nil
-}
-
 type ValidationResponse struct {
 	Validation           *Validation `protobuf:"bytes,1,opt,name=Validation,proto3" json:"Validation,omitempty"`
 	Signature            string      `protobuf:"bytes,2,opt,name=Signature,proto3" json:"Signature,omitempty"`
@@ -841,7 +560,7 @@ func (m *ValidationResponse) Reset()         { *m = ValidationResponse{} }
 func (m *ValidationResponse) String() string { return proto.CompactTextString(m) }
 func (*ValidationResponse) ProtoMessage()    {}
 func (*ValidationResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_external_7d7d3c2131b69205, []int{13}
+	return fileDescriptor_external_39a01f33235bd96a, []int{10}
 }
 func (m *ValidationResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ValidationResponse.Unmarshal(m, b)
@@ -875,71 +594,8 @@ func (m *ValidationResponse) GetSignature() string {
 	return ""
 }
 
-type Validation struct {
-	Status               Validation_ValidationStatus `protobuf:"varint,1,opt,name=Status,proto3,enum=api.Validation_ValidationStatus" json:"Status,omitempty"`
-	Timestamp            int64                       `protobuf:"varint,2,opt,name=Timestamp,proto3" json:"Timestamp,omitempty"`
-	PublicKey            string                      `protobuf:"bytes,3,opt,name=PublicKey,proto3" json:"PublicKey,omitempty"`
-	Signature            string                      `protobuf:"bytes,4,opt,name=Signature,proto3" json:"Signature,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}                    `json:"-"`
-	XXX_unrecognized     []byte                      `json:"-"`
-	XXX_sizecache        int32                       `json:"-"`
-}
-
-func (m *Validation) Reset()         { *m = Validation{} }
-func (m *Validation) String() string { return proto.CompactTextString(m) }
-func (*Validation) ProtoMessage()    {}
-func (*Validation) Descriptor() ([]byte, []int) {
-	return fileDescriptor_external_7d7d3c2131b69205, []int{14}
-}
-func (m *Validation) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_Validation.Unmarshal(m, b)
-}
-func (m *Validation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_Validation.Marshal(b, m, deterministic)
-}
-func (dst *Validation) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Validation.Merge(dst, src)
-}
-func (m *Validation) XXX_Size() int {
-	return xxx_messageInfo_Validation.Size(m)
-}
-func (m *Validation) XXX_DiscardUnknown() {
-	xxx_messageInfo_Validation.DiscardUnknown(m)
-}
-
-var xxx_messageInfo_Validation proto.InternalMessageInfo
-
-func (m *Validation) GetStatus() Validation_ValidationStatus {
-	if m != nil {
-		return m.Status
-	}
-	return Validation_OK
-}
-
-func (m *Validation) GetTimestamp() int64 {
-	if m != nil {
-		return m.Timestamp
-	}
-	return 0
-}
-
-func (m *Validation) GetPublicKey() string {
-	if m != nil {
-		return m.PublicKey
-	}
-	return ""
-}
-
-func (m *Validation) GetSignature() string {
-	if m != nil {
-		return m.Signature
-	}
-	return ""
-}
-
 type IDRequest struct {
 	EncryptedIDHash      string   `protobuf:"bytes,1,opt,name=EncryptedIDHash,proto3" json:"EncryptedIDHash,omitempty"`
-	Signature            string   `protobuf:"bytes,2,opt,name=Signature,proto3" json:"Signature,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -949,7 +605,7 @@ func (m *IDRequest) Reset()         { *m = IDRequest{} }
 func (m *IDRequest) String() string { return proto.CompactTextString(m) }
 func (*IDRequest) ProtoMessage()    {}
 func (*IDRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_external_7d7d3c2131b69205, []int{15}
+	return fileDescriptor_external_39a01f33235bd96a, []int{11}
 }
 func (m *IDRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_IDRequest.Unmarshal(m, b)
@@ -976,16 +632,8 @@ func (m *IDRequest) GetEncryptedIDHash() string {
 	return ""
 }
 
-func (m *IDRequest) GetSignature() string {
-	if m != nil {
-		return m.Signature
-	}
-	return ""
-}
-
 type KeychainRequest struct {
 	EncryptedAddress     string   `protobuf:"bytes,1,opt,name=EncryptedAddress,proto3" json:"EncryptedAddress,omitempty"`
-	Signature            string   `protobuf:"bytes,2,opt,name=Signature,proto3" json:"Signature,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -995,7 +643,7 @@ func (m *KeychainRequest) Reset()         { *m = KeychainRequest{} }
 func (m *KeychainRequest) String() string { return proto.CompactTextString(m) }
 func (*KeychainRequest) ProtoMessage()    {}
 func (*KeychainRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_external_7d7d3c2131b69205, []int{16}
+	return fileDescriptor_external_39a01f33235bd96a, []int{12}
 }
 func (m *KeychainRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_KeychainRequest.Unmarshal(m, b)
@@ -1022,206 +670,8 @@ func (m *KeychainRequest) GetEncryptedAddress() string {
 	return ""
 }
 
-func (m *KeychainRequest) GetSignature() string {
-	if m != nil {
-		return m.Signature
-	}
-	return ""
-}
-
-type IDResponse struct {
-	Data                 *ID          `protobuf:"bytes,1,opt,name=Data,proto3" json:"Data,omitempty"`
-	Endorsement          *Endorsement `protobuf:"bytes,2,opt,name=Endorsement,proto3" json:"Endorsement,omitempty"`
-	Signature            string       `protobuf:"bytes,3,opt,name=Signature,proto3" json:"Signature,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
-	XXX_unrecognized     []byte       `json:"-"`
-	XXX_sizecache        int32        `json:"-"`
-}
-
-func (m *IDResponse) Reset()         { *m = IDResponse{} }
-func (m *IDResponse) String() string { return proto.CompactTextString(m) }
-func (*IDResponse) ProtoMessage()    {}
-func (*IDResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_external_7d7d3c2131b69205, []int{17}
-}
-func (m *IDResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_IDResponse.Unmarshal(m, b)
-}
-func (m *IDResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_IDResponse.Marshal(b, m, deterministic)
-}
-func (dst *IDResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_IDResponse.Merge(dst, src)
-}
-func (m *IDResponse) XXX_Size() int {
-	return xxx_messageInfo_IDResponse.Size(m)
-}
-func (m *IDResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_IDResponse.DiscardUnknown(m)
-}
-
-var xxx_messageInfo_IDResponse proto.InternalMessageInfo
-
-func (m *IDResponse) GetData() *ID {
-	if m != nil {
-		return m.Data
-	}
-	return nil
-}
-
-func (m *IDResponse) GetEndorsement() *Endorsement {
-	if m != nil {
-		return m.Endorsement
-	}
-	return nil
-}
-
-func (m *IDResponse) GetSignature() string {
-	if m != nil {
-		return m.Signature
-	}
-	return ""
-}
-
-type KeychainResponse struct {
-	Data                 *Keychain    `protobuf:"bytes,1,opt,name=Data,proto3" json:"Data,omitempty"`
-	Endorsement          *Endorsement `protobuf:"bytes,2,opt,name=Endorsement,proto3" json:"Endorsement,omitempty"`
-	Signature            string       `protobuf:"bytes,3,opt,name=Signature,proto3" json:"Signature,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
-	XXX_unrecognized     []byte       `json:"-"`
-	XXX_sizecache        int32        `json:"-"`
-}
-
-func (m *KeychainResponse) Reset()         { *m = KeychainResponse{} }
-func (m *KeychainResponse) String() string { return proto.CompactTextString(m) }
-func (*KeychainResponse) ProtoMessage()    {}
-func (*KeychainResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_external_7d7d3c2131b69205, []int{18}
-}
-func (m *KeychainResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_KeychainResponse.Unmarshal(m, b)
-}
-func (m *KeychainResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_KeychainResponse.Marshal(b, m, deterministic)
-}
-func (dst *KeychainResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_KeychainResponse.Merge(dst, src)
-}
-func (m *KeychainResponse) XXX_Size() int {
-	return xxx_messageInfo_KeychainResponse.Size(m)
-}
-func (m *KeychainResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_KeychainResponse.DiscardUnknown(m)
-}
-
-var xxx_messageInfo_KeychainResponse proto.InternalMessageInfo
-
-func (m *KeychainResponse) GetData() *Keychain {
-	if m != nil {
-		return m.Data
-	}
-	return nil
-}
-
-func (m *KeychainResponse) GetEndorsement() *Endorsement {
-	if m != nil {
-		return m.Endorsement
-	}
-	return nil
-}
-
-func (m *KeychainResponse) GetSignature() string {
-	if m != nil {
-		return m.Signature
-	}
-	return ""
-}
-
-type Proposal struct {
-	SharedEmitterKeyPair *KeyPairProposal `protobuf:"bytes,1,opt,name=SharedEmitterKeyPair,proto3" json:"SharedEmitterKeyPair,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
-	XXX_unrecognized     []byte           `json:"-"`
-	XXX_sizecache        int32            `json:"-"`
-}
-
-func (m *Proposal) Reset()         { *m = Proposal{} }
-func (m *Proposal) String() string { return proto.CompactTextString(m) }
-func (*Proposal) ProtoMessage()    {}
-func (*Proposal) Descriptor() ([]byte, []int) {
-	return fileDescriptor_external_7d7d3c2131b69205, []int{19}
-}
-func (m *Proposal) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_Proposal.Unmarshal(m, b)
-}
-func (m *Proposal) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_Proposal.Marshal(b, m, deterministic)
-}
-func (dst *Proposal) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Proposal.Merge(dst, src)
-}
-func (m *Proposal) XXX_Size() int {
-	return xxx_messageInfo_Proposal.Size(m)
-}
-func (m *Proposal) XXX_DiscardUnknown() {
-	xxx_messageInfo_Proposal.DiscardUnknown(m)
-}
-
-var xxx_messageInfo_Proposal proto.InternalMessageInfo
-
-func (m *Proposal) GetSharedEmitterKeyPair() *KeyPairProposal {
-	if m != nil {
-		return m.SharedEmitterKeyPair
-	}
-	return nil
-}
-
-type KeyPairProposal struct {
-	EncryptedPrivateKey  string   `protobuf:"bytes,1,opt,name=EncryptedPrivateKey,proto3" json:"EncryptedPrivateKey,omitempty"`
-	PublicKey            string   `protobuf:"bytes,2,opt,name=PublicKey,proto3" json:"PublicKey,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
-}
-
-func (m *KeyPairProposal) Reset()         { *m = KeyPairProposal{} }
-func (m *KeyPairProposal) String() string { return proto.CompactTextString(m) }
-func (*KeyPairProposal) ProtoMessage()    {}
-func (*KeyPairProposal) Descriptor() ([]byte, []int) {
-	return fileDescriptor_external_7d7d3c2131b69205, []int{20}
-}
-func (m *KeyPairProposal) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_KeyPairProposal.Unmarshal(m, b)
-}
-func (m *KeyPairProposal) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_KeyPairProposal.Marshal(b, m, deterministic)
-}
-func (dst *KeyPairProposal) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_KeyPairProposal.Merge(dst, src)
-}
-func (m *KeyPairProposal) XXX_Size() int {
-	return xxx_messageInfo_KeyPairProposal.Size(m)
-}
-func (m *KeyPairProposal) XXX_DiscardUnknown() {
-	xxx_messageInfo_KeyPairProposal.DiscardUnknown(m)
-}
-
-var xxx_messageInfo_KeyPairProposal proto.InternalMessageInfo
-
-func (m *KeyPairProposal) GetEncryptedPrivateKey() string {
-	if m != nil {
-		return m.EncryptedPrivateKey
-	}
-	return ""
-}
-
-func (m *KeyPairProposal) GetPublicKey() string {
-	if m != nil {
-		return m.PublicKey
-	}
-	return ""
-}
-
 func init() {
+	proto.RegisterType((*RequestEnvelope)(nil), "api.RequestEnvelope")
 	proto.RegisterType((*LockAck)(nil), "api.LockAck")
 	proto.RegisterType((*StorageAck)(nil), "api.StorageAck")
 	proto.RegisterType((*KeychainLeadRequest)(nil), "api.KeychainLeadRequest")
@@ -1231,19 +681,9 @@ func init() {
 	proto.RegisterType((*KeychainStorageRequest)(nil), "api.KeychainStorageRequest")
 	proto.RegisterType((*IDStorageRequest)(nil), "api.IDStorageRequest")
 	proto.RegisterType((*LockRequest)(nil), "api.LockRequest")
-	proto.RegisterType((*Keychain)(nil), "api.Keychain")
-	proto.RegisterType((*ID)(nil), "api.ID")
-	proto.RegisterType((*Endorsement)(nil), "api.Endorsement")
-	proto.RegisterType((*MasterValidation)(nil), "api.MasterValidation")
 	proto.RegisterType((*ValidationResponse)(nil), "api.ValidationResponse")
-	proto.RegisterType((*Validation)(nil), "api.Validation")
 	proto.RegisterType((*IDRequest)(nil), "api.IDRequest")
 	proto.RegisterType((*KeychainRequest)(nil), "api.KeychainRequest")
-	proto.RegisterType((*IDResponse)(nil), "api.IDResponse")
-	proto.RegisterType((*KeychainResponse)(nil), "api.KeychainResponse")
-	proto.RegisterType((*Proposal)(nil), "api.Proposal")
-	proto.RegisterType((*KeyPairProposal)(nil), "api.KeyPairProposal")
-	proto.RegisterEnum("api.Validation_ValidationStatus", Validation_ValidationStatus_name, Validation_ValidationStatus_value)
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -1269,6 +709,7 @@ type ExternalClient interface {
 	StoreKeychain(ctx context.Context, in *KeychainStorageRequest, opts ...grpc.CallOption) (*StorageAck, error)
 	StoreID(ctx context.Context, in *IDStorageRequest, opts ...grpc.CallOption) (*StorageAck, error)
 	GetTransactionStatus(ctx context.Context, in *TransactionStatusRequest, opts ...grpc.CallOption) (*TransactionStatusResponse, error)
+	WatchTransactionStatus(ctx context.Context, in *TransactionStatusRequest, opts ...grpc.CallOption) (External_WatchTransactionStatusClient, error)
 }
 
 type externalClient struct {
@@ -1378,6 +819,38 @@ func (c *externalClient) GetTransactionStatus(ctx context.Context, in *Transacti
 	return out, nil
 }
 
+func (c *externalClient) WatchTransactionStatus(ctx context.Context, in *TransactionStatusRequest, opts ...grpc.CallOption) (External_WatchTransactionStatusClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_External_serviceDesc.Streams[0], "/api.External/WatchTransactionStatus", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &externalWatchTransactionStatusClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type External_WatchTransactionStatusClient interface {
+	Recv() (*TransactionStatusResponse, error)
+	grpc.ClientStream
+}
+
+type externalWatchTransactionStatusClient struct {
+	grpc.ClientStream
+}
+
+func (x *externalWatchTransactionStatusClient) Recv() (*TransactionStatusResponse, error) {
+	m := new(TransactionStatusResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // ExternalServer is the server API for External service.
 type ExternalServer interface {
 	GetID(context.Context, *IDRequest) (*IDResponse, error)
@@ -1391,6 +864,7 @@ type ExternalServer interface {
 	StoreKeychain(context.Context, *KeychainStorageRequest) (*StorageAck, error)
 	StoreID(context.Context, *IDStorageRequest) (*StorageAck, error)
 	GetTransactionStatus(context.Context, *TransactionStatusRequest) (*TransactionStatusResponse, error)
+	WatchTransactionStatus(*TransactionStatusRequest, External_WatchTransactionStatusServer) error
 }
 
 func RegisterExternalServer(s *grpc.Server, srv ExternalServer) {
@@ -1595,6 +1069,27 @@ func _External_GetTransactionStatus_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _External_WatchTransactionStatus_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(TransactionStatusRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ExternalServer).WatchTransactionStatus(m, &externalWatchTransactionStatusServer{stream})
+}
+
+type External_WatchTransactionStatusServer interface {
+	Send(*TransactionStatusResponse) error
+	grpc.ServerStream
+}
+
+type externalWatchTransactionStatusServer struct {
+	grpc.ServerStream
+}
+
+func (x *externalWatchTransactionStatusServer) Send(m *TransactionStatusResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 var _External_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "api.External",
 	HandlerType: (*ExternalServer)(nil),
@@ -1644,82 +1139,68 @@ var _External_serviceDesc = grpc.ServiceDesc{
 			Handler:    _External_GetTransactionStatus_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchTransactionStatus",
+			Handler:       _External_WatchTransactionStatus_Handler,
+			ServerStreams: true,
+		},
+	},
 	Metadata: "external.proto",
 }
 
-func init() { proto.RegisterFile("external.proto", fileDescriptor_external_7d7d3c2131b69205) }
-
-var fileDescriptor_external_7d7d3c2131b69205 = []byte{
-	// 1114 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xc4, 0x57, 0x4b, 0x6f, 0xeb, 0x44,
-	0x14, 0xae, 0x9d, 0xf4, 0x75, 0xd2, 0x47, 0xee, 0xb4, 0xbd, 0x84, 0x14, 0xae, 0x82, 0x17, 0xa8,
-	0x54, 0x28, 0x85, 0x5c, 0x90, 0x58, 0x20, 0xa1, 0x80, 0xa3, 0xd6, 0x4a, 0xab, 0x46, 0x4e, 0xa1,
-	0x0b, 0x56, 0xd3, 0x64, 0x9a, 0x5a, 0x4d, 0x3c, 0xc1, 0x9e, 0x20, 0x02, 0x2b, 0x84, 0x04, 0x2b,
-	0xd6, 0xfc, 0x10, 0x16, 0x2c, 0xd8, 0xc2, 0x82, 0x5f, 0xc1, 0x5f, 0x41, 0x33, 0x1e, 0x8f, 0xc7,
-	0x8f, 0x34, 0xad, 0x78, 0xad, 0x12, 0x7f, 0xe7, 0xcc, 0x39, 0xdf, 0x79, 0xcc, 0x99, 0x19, 0xd8,
-	0x21, 0x5f, 0x31, 0x12, 0xf8, 0x78, 0xdc, 0x9c, 0x06, 0x94, 0x51, 0x54, 0xc2, 0x53, 0xaf, 0x7e,
-	0x38, 0xa2, 0x74, 0x34, 0x26, 0x27, 0x02, 0xba, 0x99, 0xdd, 0x9e, 0x90, 0xc9, 0x94, 0xcd, 0x23,
-	0x8d, 0xfa, 0xd6, 0x80, 0x4e, 0x26, 0xd4, 0x8f, 0xbe, 0xac, 0x4f, 0x60, 0xfd, 0x9c, 0x0e, 0xee,
-	0xdb, 0x83, 0x7b, 0xf4, 0x1a, 0x6c, 0xf6, 0xbd, 0x91, 0x8f, 0xd9, 0x2c, 0x20, 0x35, 0xa3, 0x61,
-	0x1c, 0x6d, 0xba, 0x09, 0x80, 0xea, 0xb0, 0xc1, 0x15, 0xcf, 0x70, 0x78, 0x57, 0x33, 0x85, 0x50,
-	0x7d, 0x5b, 0xe7, 0x00, 0x7d, 0x46, 0x03, 0x3c, 0x22, 0xcb, 0xed, 0x34, 0xa0, 0x22, 0x75, 0x35,
-	0x53, 0x3a, 0x64, 0xfd, 0x6e, 0xc0, 0x5e, 0x97, 0xcc, 0x07, 0x77, 0xd8, 0xf3, 0xcf, 0x09, 0x1e,
-	0xba, 0xe4, 0x8b, 0x19, 0x09, 0x19, 0x3a, 0x82, 0xdd, 0xab, 0x00, 0xfb, 0x21, 0x1e, 0x30, 0x8f,
-	0xfa, 0x62, 0x75, 0x64, 0x3d, 0x0b, 0xa3, 0x63, 0xa8, 0x7e, 0x86, 0xc7, 0xde, 0x10, 0x33, 0x1a,
-	0xf4, 0x08, 0x09, 0x9c, 0x5e, 0x58, 0x33, 0x1b, 0xa5, 0xa3, 0x4d, 0x37, 0x87, 0xa3, 0xb7, 0xe1,
-	0x59, 0xc7, 0x1f, 0x04, 0xf3, 0x29, 0x23, 0xc3, 0xd8, 0x6b, 0xad, 0x24, 0xec, 0xe6, 0x05, 0xdc,
-	0xb2, 0x0a, 0x45, 0xf2, 0xaa, 0xad, 0x0a, 0xe5, 0x1c, 0x6e, 0xfd, 0x6c, 0xc0, 0xb6, 0x63, 0xff,
-	0xfb, 0x11, 0x34, 0xa0, 0xa2, 0x88, 0x3a, 0xb6, 0xe4, 0xae, 0x43, 0x85, 0xac, 0xcb, 0x0b, 0x58,
-	0x7f, 0x6f, 0xc0, 0xab, 0x71, 0xb8, 0xd2, 0x95, 0x47, 0xfd, 0xa7, 0x47, 0xf0, 0x06, 0x94, 0x6d,
-	0xcc, 0xb0, 0x28, 0x70, 0xa5, 0xb5, 0xdd, 0xc4, 0x53, 0xaf, 0x19, 0xdb, 0x75, 0x85, 0x28, 0xdd,
-	0x28, 0xa5, 0x4c, 0xa3, 0x58, 0x5f, 0xc3, 0x9e, 0x63, 0xff, 0x1d, 0x06, 0x87, 0x29, 0x06, 0xeb,
-	0x82, 0x81, 0x63, 0x3f, 0xca, 0xf7, 0x8f, 0x06, 0x3c, 0x8f, 0xc9, 0xca, 0xd6, 0x8c, 0xfd, 0xc7,
-	0x71, 0x19, 0x8b, 0xe3, 0x6a, 0xf1, 0x82, 0x0c, 0x69, 0x10, 0x92, 0x09, 0xf1, 0x99, 0xf4, 0x5f,
-	0x15, 0x9a, 0x1a, 0xee, 0xea, 0x4a, 0x4b, 0xf8, 0x7c, 0x6b, 0x40, 0xd5, 0xb1, 0x33, 0x4c, 0x0e,
-	0x53, 0x4c, 0x32, 0xf1, 0xfd, 0xf3, 0x1c, 0x7e, 0x32, 0xa0, 0xc2, 0x77, 0x7c, 0xec, 0xbe, 0x06,
-	0xeb, 0xed, 0xe1, 0x30, 0x20, 0x61, 0x28, 0x0b, 0x10, 0x7f, 0x16, 0x95, 0xc8, 0x2c, 0x2e, 0xd1,
-	0x9b, 0xb0, 0x73, 0x81, 0x43, 0x46, 0x02, 0x97, 0xde, 0x50, 0xd6, 0x25, 0x73, 0xe9, 0x36, 0x83,
-	0xa6, 0x99, 0x95, 0xb3, 0xcc, 0xbe, 0x33, 0x61, 0x43, 0xed, 0xd0, 0x16, 0xec, 0xab, 0xd6, 0xe7,
-	0x84, 0x3e, 0x9e, 0x0b, 0x2b, 0x92, 0x63, 0xa1, 0x8c, 0x13, 0x56, 0xf8, 0x35, 0x1e, 0x8f, 0x09,
-	0x8b, 0x09, 0x67, 0x60, 0xbe, 0xd7, 0x1c, 0xbb, 0x37, 0xbb, 0x19, 0x7b, 0x83, 0x84, 0xad, 0x0e,
-	0xa1, 0xb7, 0x60, 0xa3, 0x17, 0xd0, 0x29, 0x0d, 0xf1, 0x58, 0x30, 0x8d, 0x7b, 0x24, 0x06, 0x5d,
-	0x25, 0x8e, 0x8c, 0x25, 0x71, 0xad, 0xc6, 0xc6, 0x92, 0x61, 0x79, 0x0c, 0xd5, 0xce, 0xc4, 0x63,
-	0x8c, 0x04, 0x89, 0xda, 0x5a, 0xb4, 0x71, 0xb3, 0xb8, 0xf5, 0xab, 0x09, 0xa6, 0x63, 0x23, 0x04,
-	0x65, 0x6d, 0x53, 0x88, 0xff, 0x0b, 0x73, 0x62, 0x3e, 0x90, 0x13, 0x7d, 0x2e, 0x46, 0xb8, 0x9a,
-	0x2d, 0x79, 0x41, 0x2a, 0x83, 0xed, 0x4e, 0x9f, 0xe7, 0xa6, 0x9c, 0xc9, 0x60, 0x04, 0xf3, 0x52,
-	0x26, 0xf9, 0x8b, 0x42, 0xde, 0x2c, 0xce, 0xde, 0xda, 0x93, 0xb2, 0xb7, 0xfe, 0xb8, 0xec, 0x6d,
-	0x2c, 0xc8, 0xde, 0x9f, 0x46, 0x6a, 0xc3, 0xa0, 0x77, 0x60, 0xef, 0x1c, 0x87, 0xac, 0x78, 0xd4,
-	0x14, 0x89, 0x9e, 0xd0, 0xf5, 0x6d, 0xa8, 0x46, 0xfd, 0x9d, 0x4c, 0x37, 0x91, 0xd9, 0x4a, 0xeb,
-	0x40, 0x04, 0x9b, 0x15, 0xba, 0x39, 0x75, 0xf4, 0x2e, 0x54, 0x92, 0xaf, 0xb0, 0x56, 0x6e, 0x94,
-	0x8e, 0x2a, 0xad, 0x5d, 0xb1, 0x5a, 0x5b, 0xa7, 0xeb, 0x58, 0xbf, 0x18, 0x79, 0xb7, 0x7c, 0x03,
-	0xf6, 0x02, 0x4a, 0x6f, 0x2f, 0x6f, 0xaf, 0x69, 0x70, 0xcf, 0x4b, 0x12, 0x45, 0x98, 0x41, 0x51,
-	0x07, 0x0e, 0x34, 0x44, 0xe3, 0x1d, 0x0d, 0x96, 0x9c, 0xe7, 0x62, 0x6d, 0xf4, 0x1e, 0x1c, 0x64,
-	0x52, 0x77, 0xe1, 0xf9, 0x24, 0x08, 0x6b, 0x25, 0x71, 0xb6, 0x15, 0x0b, 0xad, 0x01, 0x20, 0xfd,
-	0x1c, 0x08, 0xa7, 0xd4, 0x0f, 0x09, 0x3a, 0x01, 0xd0, 0x78, 0x18, 0xc5, 0x3c, 0x34, 0x95, 0xf4,
-	0x10, 0x31, 0xb3, 0x43, 0xe4, 0x37, 0x43, 0xb7, 0x87, 0x3e, 0x80, 0xb5, 0x3e, 0xc3, 0x6c, 0x16,
-	0x0d, 0xb7, 0x9d, 0x56, 0x23, 0x63, 0x59, 0xfb, 0x1b, 0xe9, 0xb9, 0x52, 0x9f, 0xbb, 0xb9, 0xf2,
-	0x26, 0x24, 0x64, 0x78, 0x32, 0x15, 0x6e, 0x4a, 0x6e, 0x02, 0xa4, 0xdb, 0xbf, 0x94, 0x6d, 0xff,
-	0x87, 0xe7, 0x9c, 0xa5, 0x2e, 0x05, 0xca, 0x2b, 0x5a, 0x03, 0xf3, 0xb2, 0x5b, 0x5d, 0xe1, 0xbf,
-	0xdd, 0xcb, 0xaa, 0x61, 0xf5, 0x61, 0xd3, 0xb1, 0xb5, 0xb3, 0x52, 0xbb, 0x06, 0xe8, 0x67, 0x65,
-	0x06, 0x5e, 0x92, 0x9b, 0xcf, 0x61, 0x57, 0x1d, 0x71, 0xd2, 0x34, 0xdf, 0x5b, 0xfa, 0x14, 0x48,
-	0x8e, 0x81, 0x1c, 0xbe, 0xc4, 0xf8, 0x37, 0x00, 0x9c, 0xb1, 0xac, 0xea, 0x7f, 0x7c, 0xa8, 0xfd,
-	0x60, 0x40, 0x35, 0x09, 0x4d, 0x72, 0xf8, 0x5f, 0x8e, 0xf8, 0xab, 0x64, 0xf2, 0xa1, 0x33, 0xd8,
-	0xef, 0xdf, 0xe1, 0x80, 0x0c, 0xe5, 0x98, 0xea, 0x92, 0x79, 0x0f, 0x7b, 0x81, 0x24, 0xb4, 0x1f,
-	0x13, 0xe2, 0x98, 0x1a, 0x8c, 0x85, 0x2b, 0x2c, 0x2c, 0x2a, 0xa7, 0x2b, 0xf2, 0xc9, 0xa6, 0x2a,
-	0xd4, 0x0b, 0xbc, 0x2f, 0x31, 0x23, 0xc9, 0xbe, 0x2f, 0x12, 0xa5, 0x7b, 0xd6, 0xcc, 0xf4, 0x6c,
-	0xeb, 0x8f, 0x55, 0xd8, 0xe8, 0xc8, 0x47, 0x08, 0x3a, 0x86, 0xd5, 0x53, 0xc2, 0x1c, 0x1b, 0xed,
-	0xc4, 0x95, 0x8b, 0xfa, 0xa5, 0xbe, 0xab, 0xbe, 0xa3, 0x24, 0x5b, 0x2b, 0xe8, 0x43, 0xa8, 0x9c,
-	0x12, 0xa6, 0x0e, 0xee, 0xfd, 0x74, 0x9e, 0xe5, 0xba, 0x83, 0x0c, 0xaa, 0x56, 0xbf, 0x84, 0x5d,
-	0x7e, 0x1b, 0xd1, 0xa6, 0x05, 0x8a, 0xf2, 0xaf, 0xdd, 0x51, 0xea, 0x5b, 0x0a, 0x69, 0x0f, 0xee,
-	0xad, 0x15, 0xf4, 0x3e, 0x3c, 0xfb, 0xd4, 0x1f, 0x3f, 0x79, 0xd9, 0x19, 0x20, 0x7e, 0x8d, 0x8f,
-	0x59, 0x5c, 0x78, 0xbe, 0xe7, 0x8f, 0x50, 0x2d, 0x45, 0x4d, 0xbb, 0xe7, 0xd7, 0x9f, 0x37, 0xa3,
-	0x07, 0x58, 0x33, 0x7e, 0x80, 0x35, 0x3b, 0xfc, 0x01, 0x26, 0x62, 0xde, 0xe2, 0x8a, 0x8e, 0x2d,
-	0x6d, 0x20, 0x99, 0x96, 0xc7, 0xad, 0xbe, 0x50, 0x03, 0x80, 0xa8, 0xb4, 0xbd, 0x48, 0xb1, 0xc8,
-	0xdd, 0x97, 0xeb, 0xaf, 0x64, 0x47, 0x62, 0x92, 0xc2, 0xb6, 0x9a, 0x78, 0xc4, 0xb1, 0x65, 0x38,
-	0x05, 0x57, 0xee, 0x87, 0x4c, 0x7c, 0x04, 0xdb, 0xfc, 0x56, 0x9a, 0xd0, 0x39, 0x4c, 0xd1, 0x49,
-	0xdf, 0x58, 0x65, 0x13, 0x24, 0x4f, 0x45, 0x51, 0xc6, 0x75, 0x61, 0xc0, 0xb1, 0xd1, 0x81, 0x24,
-	0xb0, 0x7c, 0xd1, 0x35, 0xec, 0x9f, 0x12, 0xfd, 0xa0, 0x90, 0xc3, 0xf0, 0x75, 0xa1, 0x9a, 0xc3,
-	0x63, 0x4b, 0x2f, 0x16, 0x89, 0xe3, 0x70, 0x6e, 0xd6, 0x44, 0xca, 0x5f, 0xfe, 0x15, 0x00, 0x00,
-	0xff, 0xff, 0x7a, 0xc2, 0x0f, 0xf7, 0x56, 0x0f, 0x00, 0x00,
+func init() { proto.RegisterFile("external.proto", fileDescriptor_external_39a01f33235bd96a) }
+
+var fileDescriptor_external_39a01f33235bd96a = []byte{
+	// 793 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x55, 0xcf, 0x4f, 0xdb, 0x48,
+	0x14, 0xc6, 0x89, 0xf3, 0xeb, 0x05, 0x12, 0x33, 0x40, 0xd6, 0x9b, 0xec, 0xa2, 0xac, 0x0f, 0x28,
+	0x42, 0xab, 0xb0, 0x02, 0xed, 0x69, 0x57, 0xaa, 0x22, 0x1c, 0x81, 0x03, 0xa9, 0x90, 0xa1, 0xe5,
+	0xd0, 0xd3, 0xe0, 0x4c, 0x13, 0x8b, 0xc4, 0xe3, 0xda, 0x93, 0xaa, 0x39, 0xf5, 0x5e, 0xa9, 0xa7,
+	0x4a, 0xbd, 0xf6, 0xd6, 0xbf, 0xb3, 0xf2, 0x78, 0xec, 0xd8, 0x0e, 0x88, 0x42, 0xdb, 0xe3, 0xfb,
+	0xe6, 0xbd, 0xef, 0x7d, 0xef, 0xc7, 0xcc, 0x40, 0x8d, 0xbc, 0x63, 0xc4, 0x73, 0xf0, 0xb4, 0xeb,
+	0x7a, 0x94, 0x51, 0x94, 0xc7, 0xae, 0xdd, 0x6c, 0x8d, 0x29, 0x1d, 0x4f, 0xc9, 0x01, 0x87, 0x6e,
+	0xe6, 0xaf, 0x0f, 0xc8, 0xcc, 0x65, 0x8b, 0xd0, 0xa3, 0xb9, 0x6e, 0xd1, 0xd9, 0x8c, 0x3a, 0xa1,
+	0xa5, 0x7d, 0x92, 0xa0, 0x6e, 0x92, 0x37, 0x73, 0xe2, 0xb3, 0xbe, 0xf3, 0x96, 0x4c, 0xa9, 0x4b,
+	0x50, 0x03, 0x8a, 0x43, 0xc2, 0x26, 0x74, 0xa4, 0x4a, 0x6d, 0xa9, 0x53, 0x31, 0x85, 0x15, 0xe0,
+	0x97, 0xf6, 0xd8, 0x21, 0x9e, 0x9a, 0x0b, 0xf1, 0xd0, 0x42, 0x7f, 0x40, 0xe5, 0xca, 0x9e, 0x11,
+	0x9f, 0xe1, 0x99, 0xab, 0xe6, 0xdb, 0x52, 0x27, 0x6f, 0x2e, 0x01, 0xb4, 0x0d, 0x85, 0xe7, 0xd4,
+	0xb1, 0x88, 0x2a, 0xf3, 0xa0, 0xd0, 0x08, 0x62, 0x82, 0x68, 0xcc, 0xe6, 0x1e, 0x51, 0x0b, 0xfc,
+	0x64, 0x09, 0x68, 0xc7, 0x50, 0x3a, 0xa7, 0xd6, 0x6d, 0xcf, 0xba, 0x4d, 0x3b, 0x4a, 0x19, 0x47,
+	0xd4, 0x84, 0x72, 0xe0, 0x78, 0x8a, 0xfd, 0x89, 0x10, 0x15, 0xdb, 0xda, 0x39, 0xc0, 0x25, 0xa3,
+	0x1e, 0x1e, 0x93, 0x87, 0x79, 0xda, 0x50, 0x15, 0xbe, 0x09, 0xaa, 0x24, 0xa4, 0x7d, 0x91, 0x60,
+	0xeb, 0x8c, 0x2c, 0xac, 0x09, 0xb6, 0x9d, 0x73, 0x82, 0x47, 0xa2, 0x69, 0xa8, 0x03, 0xf5, 0x2b,
+	0x0f, 0x3b, 0x3e, 0xb6, 0x98, 0x4d, 0x1d, 0x1e, 0x1d, 0xb2, 0x67, 0x61, 0xb4, 0x0f, 0xca, 0x4b,
+	0x3c, 0xb5, 0x47, 0x98, 0x51, 0xef, 0x82, 0x10, 0xcf, 0xb8, 0xf0, 0xd5, 0x5c, 0x3b, 0xdf, 0xa9,
+	0x98, 0x2b, 0x38, 0xfa, 0x1b, 0x36, 0xfb, 0x8e, 0xe5, 0x2d, 0x5c, 0x46, 0x46, 0x51, 0x56, 0xde,
+	0xda, 0x8a, 0xb9, 0x7a, 0x30, 0x90, 0xcb, 0x05, 0xa5, 0xa8, 0x7d, 0x94, 0x60, 0xc3, 0xd0, 0x7f,
+	0xbd, 0xb6, 0x36, 0x54, 0x63, 0x09, 0x86, 0x2e, 0x54, 0x25, 0xa1, 0x81, 0x5c, 0x96, 0x95, 0x82,
+	0xf6, 0x59, 0x82, 0xdf, 0x23, 0x89, 0x82, 0xc4, 0xa6, 0xce, 0xe3, 0xb5, 0xfd, 0x05, 0xb2, 0x8e,
+	0x19, 0xe6, 0x43, 0xa9, 0x1e, 0x6e, 0x74, 0xb1, 0x6b, 0x77, 0x23, 0x5e, 0x93, 0x1f, 0xa1, 0x3d,
+	0xa8, 0x2d, 0x33, 0x5c, 0x50, 0x3a, 0x55, 0x65, 0x2e, 0x3e, 0x83, 0x0e, 0xe4, 0x72, 0x5e, 0x91,
+	0xb5, 0x0f, 0x12, 0x6c, 0x19, 0xfa, 0x8f, 0x48, 0x6a, 0xa5, 0x24, 0x95, 0xb8, 0x24, 0x43, 0x7f,
+	0x92, 0x98, 0x39, 0x34, 0xa2, 0x62, 0xc4, 0xba, 0x45, 0x72, 0xa2, 0xba, 0xa5, 0xfb, 0xeb, 0x3e,
+	0x0c, 0x46, 0x31, 0xa2, 0x9e, 0x4f, 0x66, 0xc4, 0x61, 0x42, 0x8e, 0xc2, 0x3d, 0x13, 0xb8, 0x99,
+	0x74, 0x12, 0x69, 0x6d, 0x50, 0x0c, 0x3d, 0x93, 0xb0, 0x95, 0x4a, 0x98, 0xa9, 0xea, 0xe9, 0xa9,
+	0xde, 0x43, 0x35, 0xb8, 0x93, 0x51, 0x16, 0x15, 0x4a, 0xbd, 0xd1, 0xc8, 0x23, 0xbe, 0x2f, 0xba,
+	0x1b, 0x99, 0x77, 0xf5, 0x3f, 0x77, 0x77, 0xff, 0xf7, 0xa0, 0x36, 0xc4, 0x3e, 0x23, 0x9e, 0x49,
+	0x6f, 0x28, 0x3b, 0x23, 0x0b, 0xb1, 0x85, 0x19, 0x54, 0x2c, 0xa2, 0x05, 0x28, 0x39, 0x6c, 0xdf,
+	0xa5, 0x8e, 0x4f, 0xd0, 0x01, 0xc0, 0x12, 0x15, 0x35, 0xd7, 0x79, 0x3d, 0x09, 0xe7, 0x84, 0x4b,
+	0xfa, 0x05, 0xc9, 0x65, 0x9f, 0xac, 0xff, 0xa0, 0x62, 0xe8, 0x89, 0x4d, 0x4a, 0xdc, 0x87, 0xe4,
+	0x26, 0x65, 0xe0, 0x81, 0x5c, 0xce, 0x29, 0x79, 0xed, 0x18, 0xea, 0xf1, 0x64, 0x05, 0xc5, 0x3e,
+	0x28, 0xb1, 0x6f, 0xba, 0x5f, 0x2b, 0x78, 0x48, 0x72, 0xf8, 0xb5, 0x08, 0xe5, 0xbe, 0xf8, 0x0d,
+	0xd0, 0x3e, 0x14, 0x4e, 0x08, 0x33, 0x74, 0x54, 0x8b, 0xc6, 0x18, 0xf2, 0x36, 0xeb, 0xb1, 0x1d,
+	0xf6, 0x41, 0x5b, 0x43, 0xff, 0x43, 0xf5, 0x84, 0xb0, 0x48, 0x00, 0xda, 0x4e, 0x6f, 0x9a, 0x88,
+	0xdb, 0xc9, 0xa0, 0x71, 0xf4, 0x11, 0xd4, 0x83, 0xf1, 0x26, 0x46, 0x84, 0xc2, 0xb5, 0x48, 0x0c,
+	0xbd, 0xb9, 0x1e, 0x23, 0x3d, 0xeb, 0x56, 0x5b, 0x43, 0xff, 0xc2, 0xe6, 0x0b, 0x67, 0xfa, 0xe8,
+	0xb0, 0x53, 0x40, 0xc1, 0xfb, 0x16, 0xa9, 0x18, 0xda, 0x8e, 0xed, 0x8c, 0x91, 0x9a, 0x92, 0x96,
+	0x78, 0x00, 0x9b, 0x8d, 0x6e, 0xf8, 0x13, 0x76, 0xa3, 0x9f, 0xb0, 0xdb, 0x0f, 0x7e, 0x42, 0x5e,
+	0xf3, 0x7a, 0xe0, 0x68, 0xe8, 0x82, 0x03, 0x89, 0xb6, 0x7c, 0x5f, 0xf4, 0x30, 0x7e, 0x2e, 0x49,
+	0xdc, 0xb6, 0xdd, 0x94, 0x8a, 0x95, 0xd7, 0xa5, 0xf9, 0x5b, 0x76, 0xb7, 0x96, 0x2d, 0xec, 0xc5,
+	0xab, 0x48, 0x0c, 0x5d, 0x94, 0x63, 0xe8, 0x8f, 0xa2, 0x78, 0x06, 0x1b, 0xc1, 0x6d, 0x5e, 0xca,
+	0x69, 0xa5, 0xe4, 0xa4, 0x6f, 0xba, 0x58, 0x82, 0xe5, 0xef, 0xc8, 0xc7, 0x58, 0xe2, 0x04, 0x86,
+	0x8e, 0x76, 0x84, 0x80, 0x87, 0x83, 0xae, 0x61, 0xfb, 0x84, 0xb0, 0xc4, 0x0c, 0x2f, 0x19, 0x66,
+	0x73, 0x1f, 0xfd, 0xc9, 0x5d, 0x57, 0xf0, 0x88, 0x69, 0xf7, 0xbe, 0xe3, 0xb8, 0x9c, 0x57, 0xd0,
+	0xb8, 0xc6, 0xcc, 0x9a, 0xfc, 0x7c, 0xea, 0x7f, 0xa4, 0x9b, 0x22, 0x9f, 0xe7, 0xd1, 0xb7, 0x01,
+	0x00, 0x08, 0xbf, 0x72, 0xc4, 0x3c, 0x09, 0x00, 0x00,
 }
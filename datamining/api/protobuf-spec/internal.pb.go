@@ -24,6 +24,44 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
 
+type AccountCreationStatusResponse_AccountCreationStatus int32
+
+const (
+	AccountCreationStatusResponse_Pending    AccountCreationStatusResponse_AccountCreationStatus = 0
+	AccountCreationStatusResponse_Success    AccountCreationStatusResponse_AccountCreationStatus = 1
+	AccountCreationStatusResponse_Failure    AccountCreationStatusResponse_AccountCreationStatus = 2
+	AccountCreationStatusResponse_Incomplete AccountCreationStatusResponse_AccountCreationStatus = 3
+	AccountCreationStatusResponse_Unknown    AccountCreationStatusResponse_AccountCreationStatus = 4
+	AccountCreationStatusResponse_Aborted    AccountCreationStatusResponse_AccountCreationStatus = 5
+	AccountCreationStatusResponse_Timeout    AccountCreationStatusResponse_AccountCreationStatus = 6
+)
+
+var AccountCreationStatusResponse_AccountCreationStatus_name = map[int32]string{
+	0: "Pending",
+	1: "Success",
+	2: "Failure",
+	3: "Incomplete",
+	4: "Unknown",
+	5: "Aborted",
+	6: "Timeout",
+}
+var AccountCreationStatusResponse_AccountCreationStatus_value = map[string]int32{
+	"Pending":    0,
+	"Success":    1,
+	"Failure":    2,
+	"Incomplete": 3,
+	"Unknown":    4,
+	"Aborted":    5,
+	"Timeout":    6,
+}
+
+func (x AccountCreationStatusResponse_AccountCreationStatus) String() string {
+	return proto.EnumName(AccountCreationStatusResponse_AccountCreationStatus_name, int32(x))
+}
+func (AccountCreationStatusResponse_AccountCreationStatus) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_internal_3bd648dbb2ccb931, []int{16, 0}
+}
+
 type AccountSearchRequest struct {
 	EncryptedIDHash      string   `protobuf:"bytes,1,opt,name=EncryptedIDHash,proto3" json:"EncryptedIDHash,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -35,7 +73,7 @@ func (m *AccountSearchRequest) Reset()         { *m = AccountSearchRequest{} }
 func (m *AccountSearchRequest) String() string { return proto.CompactTextString(m) }
 func (*AccountSearchRequest) ProtoMessage()    {}
 func (*AccountSearchRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_internal_b9644584db67bf3d, []int{0}
+	return fileDescriptor_internal_3bd648dbb2ccb931, []int{0}
 }
 func (m *AccountSearchRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_AccountSearchRequest.Unmarshal(m, b)
@@ -76,7 +114,7 @@ func (m *AccountSearchResult) Reset()         { *m = AccountSearchResult{} }
 func (m *AccountSearchResult) String() string { return proto.CompactTextString(m) }
 func (*AccountSearchResult) ProtoMessage()    {}
 func (*AccountSearchResult) Descriptor() ([]byte, []int) {
-	return fileDescriptor_internal_b9644584db67bf3d, []int{1}
+	return fileDescriptor_internal_3bd648dbb2ccb931, []int{1}
 }
 func (m *AccountSearchResult) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_AccountSearchResult.Unmarshal(m, b)
@@ -124,6 +162,228 @@ func (m *AccountSearchResult) GetSignature() string {
 	return ""
 }
 
+type AccountProof struct {
+	ID                   *ID               `protobuf:"bytes,1,opt,name=ID,proto3" json:"ID,omitempty"`
+	IDProof              *TransactionProof `protobuf:"bytes,2,opt,name=IDProof,proto3" json:"IDProof,omitempty"`
+	Keychain             *Keychain         `protobuf:"bytes,3,opt,name=Keychain,proto3" json:"Keychain,omitempty"`
+	KeychainProof        *TransactionProof `protobuf:"bytes,4,opt,name=KeychainProof,proto3" json:"KeychainProof,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *AccountProof) Reset()         { *m = AccountProof{} }
+func (m *AccountProof) String() string { return proto.CompactTextString(m) }
+func (*AccountProof) ProtoMessage()    {}
+func (*AccountProof) Descriptor() ([]byte, []int) {
+	return fileDescriptor_internal_3bd648dbb2ccb931, []int{2}
+}
+func (m *AccountProof) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AccountProof.Unmarshal(m, b)
+}
+func (m *AccountProof) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AccountProof.Marshal(b, m, deterministic)
+}
+func (dst *AccountProof) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AccountProof.Merge(dst, src)
+}
+func (m *AccountProof) XXX_Size() int {
+	return xxx_messageInfo_AccountProof.Size(m)
+}
+func (m *AccountProof) XXX_DiscardUnknown() {
+	xxx_messageInfo_AccountProof.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AccountProof proto.InternalMessageInfo
+
+func (m *AccountProof) GetID() *ID {
+	if m != nil {
+		return m.ID
+	}
+	return nil
+}
+
+func (m *AccountProof) GetIDProof() *TransactionProof {
+	if m != nil {
+		return m.IDProof
+	}
+	return nil
+}
+
+func (m *AccountProof) GetKeychain() *Keychain {
+	if m != nil {
+		return m.Keychain
+	}
+	return nil
+}
+
+func (m *AccountProof) GetKeychainProof() *TransactionProof {
+	if m != nil {
+		return m.KeychainProof
+	}
+	return nil
+}
+
+type TransactionProof struct {
+	Endorsement          *Endorsement `protobuf:"bytes,1,opt,name=Endorsement,proto3" json:"Endorsement,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *TransactionProof) Reset()         { *m = TransactionProof{} }
+func (m *TransactionProof) String() string { return proto.CompactTextString(m) }
+func (*TransactionProof) ProtoMessage()    {}
+func (*TransactionProof) Descriptor() ([]byte, []int) {
+	return fileDescriptor_internal_3bd648dbb2ccb931, []int{3}
+}
+func (m *TransactionProof) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TransactionProof.Unmarshal(m, b)
+}
+func (m *TransactionProof) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TransactionProof.Marshal(b, m, deterministic)
+}
+func (dst *TransactionProof) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TransactionProof.Merge(dst, src)
+}
+func (m *TransactionProof) XXX_Size() int {
+	return xxx_messageInfo_TransactionProof.Size(m)
+}
+func (m *TransactionProof) XXX_DiscardUnknown() {
+	xxx_messageInfo_TransactionProof.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TransactionProof proto.InternalMessageInfo
+
+func (m *TransactionProof) GetEndorsement() *Endorsement {
+	if m != nil {
+		return m.Endorsement
+	}
+	return nil
+}
+
+type StoragePeersRequest struct {
+	Address              string   `protobuf:"bytes,1,opt,name=Address,proto3" json:"Address,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StoragePeersRequest) Reset()         { *m = StoragePeersRequest{} }
+func (m *StoragePeersRequest) String() string { return proto.CompactTextString(m) }
+func (*StoragePeersRequest) ProtoMessage()    {}
+func (*StoragePeersRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_internal_3bd648dbb2ccb931, []int{4}
+}
+func (m *StoragePeersRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StoragePeersRequest.Unmarshal(m, b)
+}
+func (m *StoragePeersRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StoragePeersRequest.Marshal(b, m, deterministic)
+}
+func (dst *StoragePeersRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StoragePeersRequest.Merge(dst, src)
+}
+func (m *StoragePeersRequest) XXX_Size() int {
+	return xxx_messageInfo_StoragePeersRequest.Size(m)
+}
+func (m *StoragePeersRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_StoragePeersRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StoragePeersRequest proto.InternalMessageInfo
+
+func (m *StoragePeersRequest) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+type StoragePeersResult struct {
+	Peers                []*Peer  `protobuf:"bytes,1,rep,name=Peers,proto3" json:"Peers,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StoragePeersResult) Reset()         { *m = StoragePeersResult{} }
+func (m *StoragePeersResult) String() string { return proto.CompactTextString(m) }
+func (*StoragePeersResult) ProtoMessage()    {}
+func (*StoragePeersResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_internal_3bd648dbb2ccb931, []int{5}
+}
+func (m *StoragePeersResult) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StoragePeersResult.Unmarshal(m, b)
+}
+func (m *StoragePeersResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StoragePeersResult.Marshal(b, m, deterministic)
+}
+func (dst *StoragePeersResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StoragePeersResult.Merge(dst, src)
+}
+func (m *StoragePeersResult) XXX_Size() int {
+	return xxx_messageInfo_StoragePeersResult.Size(m)
+}
+func (m *StoragePeersResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_StoragePeersResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StoragePeersResult proto.InternalMessageInfo
+
+func (m *StoragePeersResult) GetPeers() []*Peer {
+	if m != nil {
+		return m.Peers
+	}
+	return nil
+}
+
+type Peer struct {
+	IP                   string   `protobuf:"bytes,1,opt,name=IP,proto3" json:"IP,omitempty"`
+	PublicKey            string   `protobuf:"bytes,2,opt,name=PublicKey,proto3" json:"PublicKey,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Peer) Reset()         { *m = Peer{} }
+func (m *Peer) String() string { return proto.CompactTextString(m) }
+func (*Peer) ProtoMessage()    {}
+func (*Peer) Descriptor() ([]byte, []int) {
+	return fileDescriptor_internal_3bd648dbb2ccb931, []int{6}
+}
+func (m *Peer) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Peer.Unmarshal(m, b)
+}
+func (m *Peer) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Peer.Marshal(b, m, deterministic)
+}
+func (dst *Peer) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Peer.Merge(dst, src)
+}
+func (m *Peer) XXX_Size() int {
+	return xxx_messageInfo_Peer.Size(m)
+}
+func (m *Peer) XXX_DiscardUnknown() {
+	xxx_messageInfo_Peer.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Peer proto.InternalMessageInfo
+
+func (m *Peer) GetIP() string {
+	if m != nil {
+		return m.IP
+	}
+	return ""
+}
+
+func (m *Peer) GetPublicKey() string {
+	if m != nil {
+		return m.PublicKey
+	}
+	return ""
+}
+
 type KeychainCreationRequest struct {
 	EncryptedKeychain    string   `protobuf:"bytes,1,opt,name=EncryptedKeychain,proto3" json:"EncryptedKeychain,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -135,7 +395,7 @@ func (m *KeychainCreationRequest) Reset()         { *m = KeychainCreationRequest
 func (m *KeychainCreationRequest) String() string { return proto.CompactTextString(m) }
 func (*KeychainCreationRequest) ProtoMessage()    {}
 func (*KeychainCreationRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_internal_b9644584db67bf3d, []int{2}
+	return fileDescriptor_internal_3bd648dbb2ccb931, []int{7}
 }
 func (m *KeychainCreationRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_KeychainCreationRequest.Unmarshal(m, b)
@@ -173,7 +433,7 @@ func (m *IDCreationRequest) Reset()         { *m = IDCreationRequest{} }
 func (m *IDCreationRequest) String() string { return proto.CompactTextString(m) }
 func (*IDCreationRequest) ProtoMessage()    {}
 func (*IDCreationRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_internal_b9644584db67bf3d, []int{3}
+	return fileDescriptor_internal_3bd648dbb2ccb931, []int{8}
 }
 func (m *IDCreationRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_IDCreationRequest.Unmarshal(m, b)
@@ -204,6 +464,7 @@ type CreationResult struct {
 	TransactionHash      string   `protobuf:"bytes,1,opt,name=TransactionHash,proto3" json:"TransactionHash,omitempty"`
 	MasterPeerIP         string   `protobuf:"bytes,2,opt,name=MasterPeerIP,proto3" json:"MasterPeerIP,omitempty"`
 	Signature            string   `protobuf:"bytes,3,opt,name=Signature,proto3" json:"Signature,omitempty"`
+	EncryptedAddress     string   `protobuf:"bytes,4,opt,name=EncryptedAddress,proto3" json:"EncryptedAddress,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -213,7 +474,7 @@ func (m *CreationResult) Reset()         { *m = CreationResult{} }
 func (m *CreationResult) String() string { return proto.CompactTextString(m) }
 func (*CreationResult) ProtoMessage()    {}
 func (*CreationResult) Descriptor() ([]byte, []int) {
-	return fileDescriptor_internal_b9644584db67bf3d, []int{4}
+	return fileDescriptor_internal_3bd648dbb2ccb931, []int{9}
 }
 func (m *CreationResult) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CreationResult.Unmarshal(m, b)
@@ -254,270 +515,1038 @@ func (m *CreationResult) GetSignature() string {
 	return ""
 }
 
-type SharedKeysResult struct {
-	RobotPublicKey       string           `protobuf:"bytes,1,opt,name=RobotPublicKey,proto3" json:"RobotPublicKey,omitempty"`
-	RobotPrivateKey      string           `protobuf:"bytes,2,opt,name=RobotPrivateKey,proto3" json:"RobotPrivateKey,omitempty"`
-	EmitterKeys          []*SharedKeyPair `protobuf:"bytes,3,rep,name=EmitterKeys,proto3" json:"EmitterKeys,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
-	XXX_unrecognized     []byte           `json:"-"`
-	XXX_sizecache        int32            `json:"-"`
+func (m *CreationResult) GetEncryptedAddress() string {
+	if m != nil {
+		return m.EncryptedAddress
+	}
+	return ""
 }
 
-func (m *SharedKeysResult) Reset()         { *m = SharedKeysResult{} }
-func (m *SharedKeysResult) String() string { return proto.CompactTextString(m) }
-func (*SharedKeysResult) ProtoMessage()    {}
-func (*SharedKeysResult) Descriptor() ([]byte, []int) {
-	return fileDescriptor_internal_b9644584db67bf3d, []int{5}
+type AccountCreationRequest struct {
+	EncryptedID          string   `protobuf:"bytes,1,opt,name=EncryptedID,proto3" json:"EncryptedID,omitempty"`
+	EncryptedKeychain    string   `protobuf:"bytes,2,opt,name=EncryptedKeychain,proto3" json:"EncryptedKeychain,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
-func (m *SharedKeysResult) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_SharedKeysResult.Unmarshal(m, b)
+
+func (m *AccountCreationRequest) Reset()         { *m = AccountCreationRequest{} }
+func (m *AccountCreationRequest) String() string { return proto.CompactTextString(m) }
+func (*AccountCreationRequest) ProtoMessage()    {}
+func (*AccountCreationRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_internal_3bd648dbb2ccb931, []int{10}
 }
-func (m *SharedKeysResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_SharedKeysResult.Marshal(b, m, deterministic)
+func (m *AccountCreationRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AccountCreationRequest.Unmarshal(m, b)
 }
-func (dst *SharedKeysResult) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_SharedKeysResult.Merge(dst, src)
+func (m *AccountCreationRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AccountCreationRequest.Marshal(b, m, deterministic)
 }
-func (m *SharedKeysResult) XXX_Size() int {
-	return xxx_messageInfo_SharedKeysResult.Size(m)
+func (dst *AccountCreationRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AccountCreationRequest.Merge(dst, src)
 }
-func (m *SharedKeysResult) XXX_DiscardUnknown() {
-	xxx_messageInfo_SharedKeysResult.DiscardUnknown(m)
+func (m *AccountCreationRequest) XXX_Size() int {
+	return xxx_messageInfo_AccountCreationRequest.Size(m)
+}
+func (m *AccountCreationRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_AccountCreationRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_SharedKeysResult proto.InternalMessageInfo
+var xxx_messageInfo_AccountCreationRequest proto.InternalMessageInfo
 
-func (m *SharedKeysResult) GetRobotPublicKey() string {
+func (m *AccountCreationRequest) GetEncryptedID() string {
 	if m != nil {
-		return m.RobotPublicKey
+		return m.EncryptedID
 	}
 	return ""
 }
 
-func (m *SharedKeysResult) GetRobotPrivateKey() string {
+func (m *AccountCreationRequest) GetEncryptedKeychain() string {
 	if m != nil {
-		return m.RobotPrivateKey
+		return m.EncryptedKeychain
 	}
 	return ""
 }
 
-func (m *SharedKeysResult) GetEmitterKeys() []*SharedKeyPair {
+type AccountCreationResult struct {
+	ID                   *CreationResult `protobuf:"bytes,1,opt,name=ID,proto3" json:"ID,omitempty"`
+	Keychain             *CreationResult `protobuf:"bytes,2,opt,name=Keychain,proto3" json:"Keychain,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *AccountCreationResult) Reset()         { *m = AccountCreationResult{} }
+func (m *AccountCreationResult) String() string { return proto.CompactTextString(m) }
+func (*AccountCreationResult) ProtoMessage()    {}
+func (*AccountCreationResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_internal_3bd648dbb2ccb931, []int{11}
+}
+func (m *AccountCreationResult) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AccountCreationResult.Unmarshal(m, b)
+}
+func (m *AccountCreationResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AccountCreationResult.Marshal(b, m, deterministic)
+}
+func (dst *AccountCreationResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AccountCreationResult.Merge(dst, src)
+}
+func (m *AccountCreationResult) XXX_Size() int {
+	return xxx_messageInfo_AccountCreationResult.Size(m)
+}
+func (m *AccountCreationResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_AccountCreationResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AccountCreationResult proto.InternalMessageInfo
+
+func (m *AccountCreationResult) GetID() *CreationResult {
 	if m != nil {
-		return m.EmitterKeys
+		return m.ID
 	}
 	return nil
 }
 
-type SharedKeyPair struct {
-	EncryptedPrivateKey  string   `protobuf:"bytes,1,opt,name=EncryptedPrivateKey,proto3" json:"EncryptedPrivateKey,omitempty"`
-	PublicKey            string   `protobuf:"bytes,2,opt,name=PublicKey,proto3" json:"PublicKey,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *AccountCreationResult) GetKeychain() *CreationResult {
+	if m != nil {
+		return m.Keychain
+	}
+	return nil
 }
 
-func (m *SharedKeyPair) Reset()         { *m = SharedKeyPair{} }
-func (m *SharedKeyPair) String() string { return proto.CompactTextString(m) }
-func (*SharedKeyPair) ProtoMessage()    {}
-func (*SharedKeyPair) Descriptor() ([]byte, []int) {
-	return fileDescriptor_internal_b9644584db67bf3d, []int{6}
+type AccountCreationBatchRequest struct {
+	Requests             []*AccountCreationRequest `protobuf:"bytes,1,rep,name=Requests,proto3" json:"Requests,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
+	XXX_unrecognized     []byte                    `json:"-"`
+	XXX_sizecache        int32                     `json:"-"`
 }
-func (m *SharedKeyPair) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_SharedKeyPair.Unmarshal(m, b)
+
+func (m *AccountCreationBatchRequest) Reset()         { *m = AccountCreationBatchRequest{} }
+func (m *AccountCreationBatchRequest) String() string { return proto.CompactTextString(m) }
+func (*AccountCreationBatchRequest) ProtoMessage()    {}
+func (*AccountCreationBatchRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_internal_3bd648dbb2ccb931, []int{12}
 }
-func (m *SharedKeyPair) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_SharedKeyPair.Marshal(b, m, deterministic)
+func (m *AccountCreationBatchRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AccountCreationBatchRequest.Unmarshal(m, b)
 }
-func (dst *SharedKeyPair) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_SharedKeyPair.Merge(dst, src)
+func (m *AccountCreationBatchRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AccountCreationBatchRequest.Marshal(b, m, deterministic)
 }
-func (m *SharedKeyPair) XXX_Size() int {
-	return xxx_messageInfo_SharedKeyPair.Size(m)
+func (dst *AccountCreationBatchRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AccountCreationBatchRequest.Merge(dst, src)
 }
-func (m *SharedKeyPair) XXX_DiscardUnknown() {
-	xxx_messageInfo_SharedKeyPair.DiscardUnknown(m)
+func (m *AccountCreationBatchRequest) XXX_Size() int {
+	return xxx_messageInfo_AccountCreationBatchRequest.Size(m)
+}
+func (m *AccountCreationBatchRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_AccountCreationBatchRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_SharedKeyPair proto.InternalMessageInfo
+var xxx_messageInfo_AccountCreationBatchRequest proto.InternalMessageInfo
 
-func (m *SharedKeyPair) GetEncryptedPrivateKey() string {
+func (m *AccountCreationBatchRequest) GetRequests() []*AccountCreationRequest {
 	if m != nil {
-		return m.EncryptedPrivateKey
+		return m.Requests
 	}
-	return ""
+	return nil
 }
 
-func (m *SharedKeyPair) GetPublicKey() string {
+type AccountCreationBatchResult struct {
+	Results              []*AccountCreationBatchItem `protobuf:"bytes,1,rep,name=Results,proto3" json:"Results,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                    `json:"-"`
+	XXX_unrecognized     []byte                      `json:"-"`
+	XXX_sizecache        int32                       `json:"-"`
+}
+
+func (m *AccountCreationBatchResult) Reset()         { *m = AccountCreationBatchResult{} }
+func (m *AccountCreationBatchResult) String() string { return proto.CompactTextString(m) }
+func (*AccountCreationBatchResult) ProtoMessage()    {}
+func (*AccountCreationBatchResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_internal_3bd648dbb2ccb931, []int{13}
+}
+func (m *AccountCreationBatchResult) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AccountCreationBatchResult.Unmarshal(m, b)
+}
+func (m *AccountCreationBatchResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AccountCreationBatchResult.Marshal(b, m, deterministic)
+}
+func (dst *AccountCreationBatchResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AccountCreationBatchResult.Merge(dst, src)
+}
+func (m *AccountCreationBatchResult) XXX_Size() int {
+	return xxx_messageInfo_AccountCreationBatchResult.Size(m)
+}
+func (m *AccountCreationBatchResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_AccountCreationBatchResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AccountCreationBatchResult proto.InternalMessageInfo
+
+func (m *AccountCreationBatchResult) GetResults() []*AccountCreationBatchItem {
 	if m != nil {
-		return m.PublicKey
+		return m.Results
 	}
-	return ""
+	return nil
 }
 
-type AuthorizationRequest struct {
-	PublicKey            string   `protobuf:"bytes,1,opt,name=PublicKey,proto3" json:"PublicKey,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+type AccountCreationBatchItem struct {
+	Result               *AccountCreationResult `protobuf:"bytes,1,opt,name=Result,proto3" json:"Result,omitempty"`
+	Error                string                 `protobuf:"bytes,2,opt,name=Error,proto3" json:"Error,omitempty"`
+	ErrorCode            string                 `protobuf:"bytes,3,opt,name=ErrorCode,proto3" json:"ErrorCode,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
+	XXX_unrecognized     []byte                 `json:"-"`
+	XXX_sizecache        int32                  `json:"-"`
 }
 
-func (m *AuthorizationRequest) Reset()         { *m = AuthorizationRequest{} }
-func (m *AuthorizationRequest) String() string { return proto.CompactTextString(m) }
-func (*AuthorizationRequest) ProtoMessage()    {}
-func (*AuthorizationRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_internal_b9644584db67bf3d, []int{7}
+func (m *AccountCreationBatchItem) Reset()         { *m = AccountCreationBatchItem{} }
+func (m *AccountCreationBatchItem) String() string { return proto.CompactTextString(m) }
+func (*AccountCreationBatchItem) ProtoMessage()    {}
+func (*AccountCreationBatchItem) Descriptor() ([]byte, []int) {
+	return fileDescriptor_internal_3bd648dbb2ccb931, []int{14}
 }
-func (m *AuthorizationRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_AuthorizationRequest.Unmarshal(m, b)
+func (m *AccountCreationBatchItem) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AccountCreationBatchItem.Unmarshal(m, b)
 }
-func (m *AuthorizationRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_AuthorizationRequest.Marshal(b, m, deterministic)
+func (m *AccountCreationBatchItem) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AccountCreationBatchItem.Marshal(b, m, deterministic)
 }
-func (dst *AuthorizationRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_AuthorizationRequest.Merge(dst, src)
+func (dst *AccountCreationBatchItem) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AccountCreationBatchItem.Merge(dst, src)
 }
-func (m *AuthorizationRequest) XXX_Size() int {
-	return xxx_messageInfo_AuthorizationRequest.Size(m)
+func (m *AccountCreationBatchItem) XXX_Size() int {
+	return xxx_messageInfo_AccountCreationBatchItem.Size(m)
 }
-func (m *AuthorizationRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_AuthorizationRequest.DiscardUnknown(m)
+func (m *AccountCreationBatchItem) XXX_DiscardUnknown() {
+	xxx_messageInfo_AccountCreationBatchItem.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_AuthorizationRequest proto.InternalMessageInfo
+var xxx_messageInfo_AccountCreationBatchItem proto.InternalMessageInfo
 
-func (m *AuthorizationRequest) GetPublicKey() string {
+func (m *AccountCreationBatchItem) GetResult() *AccountCreationResult {
 	if m != nil {
-		return m.PublicKey
+		return m.Result
+	}
+	return nil
+}
+
+func (m *AccountCreationBatchItem) GetError() string {
+	if m != nil {
+		return m.Error
 	}
 	return ""
 }
 
-type AuthorizationResponse struct {
-	Status               bool     `protobuf:"varint,1,opt,name=Status,proto3" json:"Status,omitempty"`
+func (m *AccountCreationBatchItem) GetErrorCode() string {
+	if m != nil {
+		return m.ErrorCode
+	}
+	return ""
+}
+
+type AccountCreationStatusRequest struct {
+	IDTransactionHash    string   `protobuf:"bytes,1,opt,name=IDTransactionHash,proto3" json:"IDTransactionHash,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *AuthorizationResponse) Reset()         { *m = AuthorizationResponse{} }
-func (m *AuthorizationResponse) String() string { return proto.CompactTextString(m) }
-func (*AuthorizationResponse) ProtoMessage()    {}
-func (*AuthorizationResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_internal_b9644584db67bf3d, []int{8}
+func (m *AccountCreationStatusRequest) Reset()         { *m = AccountCreationStatusRequest{} }
+func (m *AccountCreationStatusRequest) String() string { return proto.CompactTextString(m) }
+func (*AccountCreationStatusRequest) ProtoMessage()    {}
+func (*AccountCreationStatusRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_internal_3bd648dbb2ccb931, []int{15}
 }
-func (m *AuthorizationResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_AuthorizationResponse.Unmarshal(m, b)
+func (m *AccountCreationStatusRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AccountCreationStatusRequest.Unmarshal(m, b)
 }
-func (m *AuthorizationResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_AuthorizationResponse.Marshal(b, m, deterministic)
+func (m *AccountCreationStatusRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AccountCreationStatusRequest.Marshal(b, m, deterministic)
 }
-func (dst *AuthorizationResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_AuthorizationResponse.Merge(dst, src)
+func (dst *AccountCreationStatusRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AccountCreationStatusRequest.Merge(dst, src)
 }
-func (m *AuthorizationResponse) XXX_Size() int {
-	return xxx_messageInfo_AuthorizationResponse.Size(m)
+func (m *AccountCreationStatusRequest) XXX_Size() int {
+	return xxx_messageInfo_AccountCreationStatusRequest.Size(m)
 }
-func (m *AuthorizationResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_AuthorizationResponse.DiscardUnknown(m)
+func (m *AccountCreationStatusRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_AccountCreationStatusRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_AuthorizationResponse proto.InternalMessageInfo
+var xxx_messageInfo_AccountCreationStatusRequest proto.InternalMessageInfo
 
-func (m *AuthorizationResponse) GetStatus() bool {
+func (m *AccountCreationStatusRequest) GetIDTransactionHash() string {
 	if m != nil {
-		return m.Status
+		return m.IDTransactionHash
+	}
+	return ""
+}
+
+type AccountCreationStatusResponse struct {
+	Status               AccountCreationStatusResponse_AccountCreationStatus `protobuf:"varint,1,opt,name=Status,proto3,enum=api.AccountCreationStatusResponse_AccountCreationStatus" json:"Status,omitempty"`
+	IDStatus             TransactionStatusResponse_TransactionStatus         `protobuf:"varint,2,opt,name=IDStatus,proto3,enum=api.TransactionStatusResponse_TransactionStatus" json:"IDStatus,omitempty"`
+	KeychainStatus       TransactionStatusResponse_TransactionStatus         `protobuf:"varint,3,opt,name=KeychainStatus,proto3,enum=api.TransactionStatusResponse_TransactionStatus" json:"KeychainStatus,omitempty"`
+	Keychain             *CreationResult                                     `protobuf:"bytes,4,opt,name=Keychain,proto3" json:"Keychain,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                                            `json:"-"`
+	XXX_unrecognized     []byte                                              `json:"-"`
+	XXX_sizecache        int32                                               `json:"-"`
+}
+
+func (m *AccountCreationStatusResponse) Reset()         { *m = AccountCreationStatusResponse{} }
+func (m *AccountCreationStatusResponse) String() string { return proto.CompactTextString(m) }
+func (*AccountCreationStatusResponse) ProtoMessage()    {}
+func (*AccountCreationStatusResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_internal_3bd648dbb2ccb931, []int{16}
+}
+func (m *AccountCreationStatusResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AccountCreationStatusResponse.Unmarshal(m, b)
+}
+func (m *AccountCreationStatusResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AccountCreationStatusResponse.Marshal(b, m, deterministic)
+}
+func (dst *AccountCreationStatusResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AccountCreationStatusResponse.Merge(dst, src)
+}
+func (m *AccountCreationStatusResponse) XXX_Size() int {
+	return xxx_messageInfo_AccountCreationStatusResponse.Size(m)
+}
+func (m *AccountCreationStatusResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_AccountCreationStatusResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AccountCreationStatusResponse proto.InternalMessageInfo
+
+func (m *AccountCreationStatusResponse) GetStatus() AccountCreationStatusResponse_AccountCreationStatus {
+	if m != nil {
+		return m.Status
+	}
+	return AccountCreationStatusResponse_Pending
+}
+
+func (m *AccountCreationStatusResponse) GetIDStatus() TransactionStatusResponse_TransactionStatus {
+	if m != nil {
+		return m.IDStatus
+	}
+	return TransactionStatusResponse_Pending
+}
+
+func (m *AccountCreationStatusResponse) GetKeychainStatus() TransactionStatusResponse_TransactionStatus {
+	if m != nil {
+		return m.KeychainStatus
+	}
+	return TransactionStatusResponse_Pending
+}
+
+func (m *AccountCreationStatusResponse) GetKeychain() *CreationResult {
+	if m != nil {
+		return m.Keychain
+	}
+	return nil
+}
+
+type KeychainUpdateRequest struct {
+	EncryptedIDHash      string   `protobuf:"bytes,1,opt,name=EncryptedIDHash,proto3" json:"EncryptedIDHash,omitempty"`
+	EncryptedKeychain    string   `protobuf:"bytes,2,opt,name=EncryptedKeychain,proto3" json:"EncryptedKeychain,omitempty"`
+	Timestamp            int64    `protobuf:"varint,3,opt,name=Timestamp,proto3" json:"Timestamp,omitempty"`
+	Nonce                string   `protobuf:"bytes,4,opt,name=Nonce,proto3" json:"Nonce,omitempty"`
+	Signature            string   `protobuf:"bytes,5,opt,name=Signature,proto3" json:"Signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *KeychainUpdateRequest) Reset()         { *m = KeychainUpdateRequest{} }
+func (m *KeychainUpdateRequest) String() string { return proto.CompactTextString(m) }
+func (*KeychainUpdateRequest) ProtoMessage()    {}
+func (*KeychainUpdateRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_internal_3bd648dbb2ccb931, []int{17}
+}
+func (m *KeychainUpdateRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_KeychainUpdateRequest.Unmarshal(m, b)
+}
+func (m *KeychainUpdateRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_KeychainUpdateRequest.Marshal(b, m, deterministic)
+}
+func (dst *KeychainUpdateRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_KeychainUpdateRequest.Merge(dst, src)
+}
+func (m *KeychainUpdateRequest) XXX_Size() int {
+	return xxx_messageInfo_KeychainUpdateRequest.Size(m)
+}
+func (m *KeychainUpdateRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_KeychainUpdateRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_KeychainUpdateRequest proto.InternalMessageInfo
+
+func (m *KeychainUpdateRequest) GetEncryptedIDHash() string {
+	if m != nil {
+		return m.EncryptedIDHash
+	}
+	return ""
+}
+
+func (m *KeychainUpdateRequest) GetEncryptedKeychain() string {
+	if m != nil {
+		return m.EncryptedKeychain
+	}
+	return ""
+}
+
+func (m *KeychainUpdateRequest) GetTimestamp() int64 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
+func (m *KeychainUpdateRequest) GetNonce() string {
+	if m != nil {
+		return m.Nonce
+	}
+	return ""
+}
+
+func (m *KeychainUpdateRequest) GetSignature() string {
+	if m != nil {
+		return m.Signature
+	}
+	return ""
+}
+
+type SharedKeysResult struct {
+	RobotPublicKey       string           `protobuf:"bytes,1,opt,name=RobotPublicKey,proto3" json:"RobotPublicKey,omitempty"`
+	EmitterKeys          []*SharedKeyPair `protobuf:"bytes,3,rep,name=EmitterKeys,proto3" json:"EmitterKeys,omitempty"`
+	PreviousEmitterKeys  []*SharedKeyPair `protobuf:"bytes,4,rep,name=PreviousEmitterKeys,proto3" json:"PreviousEmitterKeys,omitempty"`
+	RobotKeys            []*RobotKeyPair  `protobuf:"bytes,5,rep,name=RobotKeys,proto3" json:"RobotKeys,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *SharedKeysResult) Reset()         { *m = SharedKeysResult{} }
+func (m *SharedKeysResult) String() string { return proto.CompactTextString(m) }
+func (*SharedKeysResult) ProtoMessage()    {}
+func (*SharedKeysResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_internal_3bd648dbb2ccb931, []int{18}
+}
+func (m *SharedKeysResult) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SharedKeysResult.Unmarshal(m, b)
+}
+func (m *SharedKeysResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SharedKeysResult.Marshal(b, m, deterministic)
+}
+func (dst *SharedKeysResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SharedKeysResult.Merge(dst, src)
+}
+func (m *SharedKeysResult) XXX_Size() int {
+	return xxx_messageInfo_SharedKeysResult.Size(m)
+}
+func (m *SharedKeysResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_SharedKeysResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SharedKeysResult proto.InternalMessageInfo
+
+func (m *SharedKeysResult) GetRobotPublicKey() string {
+	if m != nil {
+		return m.RobotPublicKey
+	}
+	return ""
+}
+
+func (m *SharedKeysResult) GetEmitterKeys() []*SharedKeyPair {
+	if m != nil {
+		return m.EmitterKeys
+	}
+	return nil
+}
+
+func (m *SharedKeysResult) GetPreviousEmitterKeys() []*SharedKeyPair {
+	if m != nil {
+		return m.PreviousEmitterKeys
+	}
+	return nil
+}
+
+func (m *SharedKeysResult) GetRobotKeys() []*RobotKeyPair {
+	if m != nil {
+		return m.RobotKeys
+	}
+	return nil
+}
+
+type RobotKeyPair struct {
+	Version              int32    `protobuf:"varint,1,opt,name=Version,proto3" json:"Version,omitempty"`
+	PublicKey            string   `protobuf:"bytes,2,opt,name=PublicKey,proto3" json:"PublicKey,omitempty"`
+	ActivationDate       int64    `protobuf:"varint,4,opt,name=ActivationDate,proto3" json:"ActivationDate,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RobotKeyPair) Reset()         { *m = RobotKeyPair{} }
+func (m *RobotKeyPair) String() string { return proto.CompactTextString(m) }
+func (*RobotKeyPair) ProtoMessage()    {}
+func (*RobotKeyPair) Descriptor() ([]byte, []int) {
+	return fileDescriptor_internal_3bd648dbb2ccb931, []int{19}
+}
+func (m *RobotKeyPair) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RobotKeyPair.Unmarshal(m, b)
+}
+func (m *RobotKeyPair) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RobotKeyPair.Marshal(b, m, deterministic)
+}
+func (dst *RobotKeyPair) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RobotKeyPair.Merge(dst, src)
+}
+func (m *RobotKeyPair) XXX_Size() int {
+	return xxx_messageInfo_RobotKeyPair.Size(m)
+}
+func (m *RobotKeyPair) XXX_DiscardUnknown() {
+	xxx_messageInfo_RobotKeyPair.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RobotKeyPair proto.InternalMessageInfo
+
+func (m *RobotKeyPair) GetVersion() int32 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *RobotKeyPair) GetPublicKey() string {
+	if m != nil {
+		return m.PublicKey
+	}
+	return ""
+}
+
+func (m *RobotKeyPair) GetActivationDate() int64 {
+	if m != nil {
+		return m.ActivationDate
+	}
+	return 0
+}
+
+type SharedKeyPair struct {
+	EncryptedPrivateKey  string   `protobuf:"bytes,1,opt,name=EncryptedPrivateKey,proto3" json:"EncryptedPrivateKey,omitempty"`
+	PublicKey            string   `protobuf:"bytes,2,opt,name=PublicKey,proto3" json:"PublicKey,omitempty"`
+	ExpirationDate       int64    `protobuf:"varint,3,opt,name=ExpirationDate,proto3" json:"ExpirationDate,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SharedKeyPair) Reset()         { *m = SharedKeyPair{} }
+func (m *SharedKeyPair) String() string { return proto.CompactTextString(m) }
+func (*SharedKeyPair) ProtoMessage()    {}
+func (*SharedKeyPair) Descriptor() ([]byte, []int) {
+	return fileDescriptor_internal_3bd648dbb2ccb931, []int{20}
+}
+func (m *SharedKeyPair) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SharedKeyPair.Unmarshal(m, b)
+}
+func (m *SharedKeyPair) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SharedKeyPair.Marshal(b, m, deterministic)
+}
+func (dst *SharedKeyPair) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SharedKeyPair.Merge(dst, src)
+}
+func (m *SharedKeyPair) XXX_Size() int {
+	return xxx_messageInfo_SharedKeyPair.Size(m)
+}
+func (m *SharedKeyPair) XXX_DiscardUnknown() {
+	xxx_messageInfo_SharedKeyPair.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SharedKeyPair proto.InternalMessageInfo
+
+func (m *SharedKeyPair) GetEncryptedPrivateKey() string {
+	if m != nil {
+		return m.EncryptedPrivateKey
+	}
+	return ""
+}
+
+func (m *SharedKeyPair) GetPublicKey() string {
+	if m != nil {
+		return m.PublicKey
+	}
+	return ""
+}
+
+func (m *SharedKeyPair) GetExpirationDate() int64 {
+	if m != nil {
+		return m.ExpirationDate
+	}
+	return 0
+}
+
+type AuthorizationRequest struct {
+	PublicKey            string   `protobuf:"bytes,1,opt,name=PublicKey,proto3" json:"PublicKey,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AuthorizationRequest) Reset()         { *m = AuthorizationRequest{} }
+func (m *AuthorizationRequest) String() string { return proto.CompactTextString(m) }
+func (*AuthorizationRequest) ProtoMessage()    {}
+func (*AuthorizationRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_internal_3bd648dbb2ccb931, []int{21}
+}
+func (m *AuthorizationRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AuthorizationRequest.Unmarshal(m, b)
+}
+func (m *AuthorizationRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AuthorizationRequest.Marshal(b, m, deterministic)
+}
+func (dst *AuthorizationRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AuthorizationRequest.Merge(dst, src)
+}
+func (m *AuthorizationRequest) XXX_Size() int {
+	return xxx_messageInfo_AuthorizationRequest.Size(m)
+}
+func (m *AuthorizationRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_AuthorizationRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AuthorizationRequest proto.InternalMessageInfo
+
+func (m *AuthorizationRequest) GetPublicKey() string {
+	if m != nil {
+		return m.PublicKey
+	}
+	return ""
+}
+
+type AuthorizationResponse struct {
+	Status               bool     `protobuf:"varint,1,opt,name=Status,proto3" json:"Status,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AuthorizationResponse) Reset()         { *m = AuthorizationResponse{} }
+func (m *AuthorizationResponse) String() string { return proto.CompactTextString(m) }
+func (*AuthorizationResponse) ProtoMessage()    {}
+func (*AuthorizationResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_internal_3bd648dbb2ccb931, []int{22}
+}
+func (m *AuthorizationResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AuthorizationResponse.Unmarshal(m, b)
+}
+func (m *AuthorizationResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AuthorizationResponse.Marshal(b, m, deterministic)
+}
+func (dst *AuthorizationResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AuthorizationResponse.Merge(dst, src)
+}
+func (m *AuthorizationResponse) XXX_Size() int {
+	return xxx_messageInfo_AuthorizationResponse.Size(m)
+}
+func (m *AuthorizationResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_AuthorizationResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AuthorizationResponse proto.InternalMessageInfo
+
+func (m *AuthorizationResponse) GetStatus() bool {
+	if m != nil {
+		return m.Status
 	}
 	return false
 }
 
-func init() {
-	proto.RegisterType((*AccountSearchRequest)(nil), "api.AccountSearchRequest")
-	proto.RegisterType((*AccountSearchResult)(nil), "api.AccountSearchResult")
-	proto.RegisterType((*KeychainCreationRequest)(nil), "api.KeychainCreationRequest")
-	proto.RegisterType((*IDCreationRequest)(nil), "api.IDCreationRequest")
-	proto.RegisterType((*CreationResult)(nil), "api.CreationResult")
-	proto.RegisterType((*SharedKeysResult)(nil), "api.SharedKeysResult")
-	proto.RegisterType((*SharedKeyPair)(nil), "api.SharedKeyPair")
-	proto.RegisterType((*AuthorizationRequest)(nil), "api.AuthorizationRequest")
-	proto.RegisterType((*AuthorizationResponse)(nil), "api.AuthorizationResponse")
+type EmitterAuthorizationRequest struct {
+	PublicKey            string   `protobuf:"bytes,1,opt,name=PublicKey,proto3" json:"PublicKey,omitempty"`
+	Timestamp            int64    `protobuf:"varint,2,opt,name=Timestamp,proto3" json:"Timestamp,omitempty"`
+	Nonce                string   `protobuf:"bytes,3,opt,name=Nonce,proto3" json:"Nonce,omitempty"`
+	Signature            string   `protobuf:"bytes,4,opt,name=Signature,proto3" json:"Signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EmitterAuthorizationRequest) Reset()         { *m = EmitterAuthorizationRequest{} }
+func (m *EmitterAuthorizationRequest) String() string { return proto.CompactTextString(m) }
+func (*EmitterAuthorizationRequest) ProtoMessage()    {}
+func (*EmitterAuthorizationRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_internal_3bd648dbb2ccb931, []int{23}
+}
+func (m *EmitterAuthorizationRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EmitterAuthorizationRequest.Unmarshal(m, b)
+}
+func (m *EmitterAuthorizationRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EmitterAuthorizationRequest.Marshal(b, m, deterministic)
+}
+func (dst *EmitterAuthorizationRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EmitterAuthorizationRequest.Merge(dst, src)
+}
+func (m *EmitterAuthorizationRequest) XXX_Size() int {
+	return xxx_messageInfo_EmitterAuthorizationRequest.Size(m)
+}
+func (m *EmitterAuthorizationRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_EmitterAuthorizationRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EmitterAuthorizationRequest proto.InternalMessageInfo
+
+func (m *EmitterAuthorizationRequest) GetPublicKey() string {
+	if m != nil {
+		return m.PublicKey
+	}
+	return ""
+}
+
+func (m *EmitterAuthorizationRequest) GetTimestamp() int64 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
+func (m *EmitterAuthorizationRequest) GetNonce() string {
+	if m != nil {
+		return m.Nonce
+	}
+	return ""
+}
+
+func (m *EmitterAuthorizationRequest) GetSignature() string {
+	if m != nil {
+		return m.Signature
+	}
+	return ""
+}
+
+type PayloadSignatureRequest struct {
+	Payload              []byte   `protobuf:"bytes,1,opt,name=Payload,proto3" json:"Payload,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PayloadSignatureRequest) Reset()         { *m = PayloadSignatureRequest{} }
+func (m *PayloadSignatureRequest) String() string { return proto.CompactTextString(m) }
+func (*PayloadSignatureRequest) ProtoMessage()    {}
+func (*PayloadSignatureRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_internal_3bd648dbb2ccb931, []int{24}
+}
+func (m *PayloadSignatureRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PayloadSignatureRequest.Unmarshal(m, b)
+}
+func (m *PayloadSignatureRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PayloadSignatureRequest.Marshal(b, m, deterministic)
+}
+func (dst *PayloadSignatureRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PayloadSignatureRequest.Merge(dst, src)
+}
+func (m *PayloadSignatureRequest) XXX_Size() int {
+	return xxx_messageInfo_PayloadSignatureRequest.Size(m)
+}
+func (m *PayloadSignatureRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PayloadSignatureRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PayloadSignatureRequest proto.InternalMessageInfo
+
+func (m *PayloadSignatureRequest) GetPayload() []byte {
+	if m != nil {
+		return m.Payload
+	}
+	return nil
+}
+
+type PayloadSignatureResponse struct {
+	Signature            string   `protobuf:"bytes,1,opt,name=Signature,proto3" json:"Signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PayloadSignatureResponse) Reset()         { *m = PayloadSignatureResponse{} }
+func (m *PayloadSignatureResponse) String() string { return proto.CompactTextString(m) }
+func (*PayloadSignatureResponse) ProtoMessage()    {}
+func (*PayloadSignatureResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_internal_3bd648dbb2ccb931, []int{25}
+}
+func (m *PayloadSignatureResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PayloadSignatureResponse.Unmarshal(m, b)
+}
+func (m *PayloadSignatureResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PayloadSignatureResponse.Marshal(b, m, deterministic)
+}
+func (dst *PayloadSignatureResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PayloadSignatureResponse.Merge(dst, src)
+}
+func (m *PayloadSignatureResponse) XXX_Size() int {
+	return xxx_messageInfo_PayloadSignatureResponse.Size(m)
+}
+func (m *PayloadSignatureResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PayloadSignatureResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PayloadSignatureResponse proto.InternalMessageInfo
+
+func (m *PayloadSignatureResponse) GetSignature() string {
+	if m != nil {
+		return m.Signature
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterType((*AccountSearchRequest)(nil), "api.AccountSearchRequest")
+	proto.RegisterType((*AccountSearchResult)(nil), "api.AccountSearchResult")
+	proto.RegisterType((*AccountProof)(nil), "api.AccountProof")
+	proto.RegisterType((*TransactionProof)(nil), "api.TransactionProof")
+	proto.RegisterType((*StoragePeersRequest)(nil), "api.StoragePeersRequest")
+	proto.RegisterType((*StoragePeersResult)(nil), "api.StoragePeersResult")
+	proto.RegisterType((*Peer)(nil), "api.Peer")
+	proto.RegisterType((*KeychainCreationRequest)(nil), "api.KeychainCreationRequest")
+	proto.RegisterType((*IDCreationRequest)(nil), "api.IDCreationRequest")
+	proto.RegisterType((*CreationResult)(nil), "api.CreationResult")
+	proto.RegisterType((*AccountCreationRequest)(nil), "api.AccountCreationRequest")
+	proto.RegisterType((*AccountCreationResult)(nil), "api.AccountCreationResult")
+	proto.RegisterType((*AccountCreationBatchRequest)(nil), "api.AccountCreationBatchRequest")
+	proto.RegisterType((*AccountCreationBatchResult)(nil), "api.AccountCreationBatchResult")
+	proto.RegisterType((*AccountCreationBatchItem)(nil), "api.AccountCreationBatchItem")
+	proto.RegisterType((*AccountCreationStatusRequest)(nil), "api.AccountCreationStatusRequest")
+	proto.RegisterType((*AccountCreationStatusResponse)(nil), "api.AccountCreationStatusResponse")
+	proto.RegisterType((*KeychainUpdateRequest)(nil), "api.KeychainUpdateRequest")
+	proto.RegisterType((*SharedKeysResult)(nil), "api.SharedKeysResult")
+	proto.RegisterType((*RobotKeyPair)(nil), "api.RobotKeyPair")
+	proto.RegisterType((*SharedKeyPair)(nil), "api.SharedKeyPair")
+	proto.RegisterType((*AuthorizationRequest)(nil), "api.AuthorizationRequest")
+	proto.RegisterType((*AuthorizationResponse)(nil), "api.AuthorizationResponse")
+	proto.RegisterType((*EmitterAuthorizationRequest)(nil), "api.EmitterAuthorizationRequest")
+	proto.RegisterType((*PayloadSignatureRequest)(nil), "api.PayloadSignatureRequest")
+	proto.RegisterType((*PayloadSignatureResponse)(nil), "api.PayloadSignatureResponse")
+	proto.RegisterEnum("api.AccountCreationStatusResponse_AccountCreationStatus", AccountCreationStatusResponse_AccountCreationStatus_name, AccountCreationStatusResponse_AccountCreationStatus_value)
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// InternalClient is the client API for Internal service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type InternalClient interface {
+	GetAccount(ctx context.Context, in *AccountSearchRequest, opts ...grpc.CallOption) (*AccountSearchResult, error)
+	CreateKeychain(ctx context.Context, in *KeychainCreationRequest, opts ...grpc.CallOption) (*CreationResult, error)
+	CreateID(ctx context.Context, in *IDCreationRequest, opts ...grpc.CallOption) (*CreationResult, error)
+	GetSharedKeys(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*SharedKeysResult, error)
+	IsEmitterAuthorized(ctx context.Context, in *AuthorizationRequest, opts ...grpc.CallOption) (*AuthorizationResponse, error)
+	AuthorizeEmitter(ctx context.Context, in *EmitterAuthorizationRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	RevokeEmitter(ctx context.Context, in *EmitterAuthorizationRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	SignPayload(ctx context.Context, in *PayloadSignatureRequest, opts ...grpc.CallOption) (*PayloadSignatureResponse, error)
+	GetTransactionStatus(ctx context.Context, in *TransactionStatusRequest, opts ...grpc.CallOption) (*TransactionStatusResponse, error)
+	WatchTransactionStatus(ctx context.Context, in *TransactionStatusRequest, opts ...grpc.CallOption) (Internal_WatchTransactionStatusClient, error)
+	CreateAccount(ctx context.Context, in *AccountCreationRequest, opts ...grpc.CallOption) (*AccountCreationResult, error)
+	CreateAccounts(ctx context.Context, in *AccountCreationBatchRequest, opts ...grpc.CallOption) (*AccountCreationBatchResult, error)
+	GetAccountCreationStatus(ctx context.Context, in *AccountCreationStatusRequest, opts ...grpc.CallOption) (*AccountCreationStatusResponse, error)
+	UpdateKeychain(ctx context.Context, in *KeychainUpdateRequest, opts ...grpc.CallOption) (*CreationResult, error)
+	GetIDDetails(ctx context.Context, in *AccountSearchRequest, opts ...grpc.CallOption) (*IDResponse, error)
+	GetKeychainDetails(ctx context.Context, in *AccountSearchRequest, opts ...grpc.CallOption) (*KeychainResponse, error)
+	GetAccountProof(ctx context.Context, in *AccountSearchRequest, opts ...grpc.CallOption) (*AccountProof, error)
+	GetStoragePeers(ctx context.Context, in *StoragePeersRequest, opts ...grpc.CallOption) (*StoragePeersResult, error)
+}
+
+type internalClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewInternalClient(cc *grpc.ClientConn) InternalClient {
+	return &internalClient{cc}
+}
+
+func (c *internalClient) GetAccount(ctx context.Context, in *AccountSearchRequest, opts ...grpc.CallOption) (*AccountSearchResult, error) {
+	out := new(AccountSearchResult)
+	err := c.cc.Invoke(ctx, "/api.Internal/GetAccount", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *internalClient) CreateKeychain(ctx context.Context, in *KeychainCreationRequest, opts ...grpc.CallOption) (*CreationResult, error) {
+	out := new(CreationResult)
+	err := c.cc.Invoke(ctx, "/api.Internal/CreateKeychain", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *internalClient) CreateID(ctx context.Context, in *IDCreationRequest, opts ...grpc.CallOption) (*CreationResult, error) {
+	out := new(CreationResult)
+	err := c.cc.Invoke(ctx, "/api.Internal/CreateID", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *internalClient) GetSharedKeys(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*SharedKeysResult, error) {
+	out := new(SharedKeysResult)
+	err := c.cc.Invoke(ctx, "/api.Internal/GetSharedKeys", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *internalClient) IsEmitterAuthorized(ctx context.Context, in *AuthorizationRequest, opts ...grpc.CallOption) (*AuthorizationResponse, error) {
+	out := new(AuthorizationResponse)
+	err := c.cc.Invoke(ctx, "/api.Internal/IsEmitterAuthorized", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *internalClient) AuthorizeEmitter(ctx context.Context, in *EmitterAuthorizationRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/api.Internal/AuthorizeEmitter", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *internalClient) RevokeEmitter(ctx context.Context, in *EmitterAuthorizationRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/api.Internal/RevokeEmitter", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *internalClient) SignPayload(ctx context.Context, in *PayloadSignatureRequest, opts ...grpc.CallOption) (*PayloadSignatureResponse, error) {
+	out := new(PayloadSignatureResponse)
+	err := c.cc.Invoke(ctx, "/api.Internal/SignPayload", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *internalClient) GetTransactionStatus(ctx context.Context, in *TransactionStatusRequest, opts ...grpc.CallOption) (*TransactionStatusResponse, error) {
+	out := new(TransactionStatusResponse)
+	err := c.cc.Invoke(ctx, "/api.Internal/GetTransactionStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
+func (c *internalClient) WatchTransactionStatus(ctx context.Context, in *TransactionStatusRequest, opts ...grpc.CallOption) (Internal_WatchTransactionStatusClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Internal_serviceDesc.Streams[0], "/api.Internal/WatchTransactionStatus", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &internalWatchTransactionStatusClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
 
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
+type Internal_WatchTransactionStatusClient interface {
+	Recv() (*TransactionStatusResponse, error)
+	grpc.ClientStream
+}
 
-// InternalClient is the client API for Internal service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type InternalClient interface {
-	GetAccount(ctx context.Context, in *AccountSearchRequest, opts ...grpc.CallOption) (*AccountSearchResult, error)
-	CreateKeychain(ctx context.Context, in *KeychainCreationRequest, opts ...grpc.CallOption) (*CreationResult, error)
-	CreateID(ctx context.Context, in *IDCreationRequest, opts ...grpc.CallOption) (*CreationResult, error)
-	GetSharedKeys(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*SharedKeysResult, error)
-	IsEmitterAuthorized(ctx context.Context, in *AuthorizationRequest, opts ...grpc.CallOption) (*AuthorizationResponse, error)
-	GetTransactionStatus(ctx context.Context, in *TransactionStatusRequest, opts ...grpc.CallOption) (*TransactionStatusResponse, error)
+type internalWatchTransactionStatusClient struct {
+	grpc.ClientStream
 }
 
-type internalClient struct {
-	cc *grpc.ClientConn
+func (x *internalWatchTransactionStatusClient) Recv() (*TransactionStatusResponse, error) {
+	m := new(TransactionStatusResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
 }
 
-func NewInternalClient(cc *grpc.ClientConn) InternalClient {
-	return &internalClient{cc}
+func (c *internalClient) CreateAccount(ctx context.Context, in *AccountCreationRequest, opts ...grpc.CallOption) (*AccountCreationResult, error) {
+	out := new(AccountCreationResult)
+	err := c.cc.Invoke(ctx, "/api.Internal/CreateAccount", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-func (c *internalClient) GetAccount(ctx context.Context, in *AccountSearchRequest, opts ...grpc.CallOption) (*AccountSearchResult, error) {
-	out := new(AccountSearchResult)
-	err := c.cc.Invoke(ctx, "/api.Internal/GetAccount", in, out, opts...)
+func (c *internalClient) CreateAccounts(ctx context.Context, in *AccountCreationBatchRequest, opts ...grpc.CallOption) (*AccountCreationBatchResult, error) {
+	out := new(AccountCreationBatchResult)
+	err := c.cc.Invoke(ctx, "/api.Internal/CreateAccounts", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *internalClient) CreateKeychain(ctx context.Context, in *KeychainCreationRequest, opts ...grpc.CallOption) (*CreationResult, error) {
-	out := new(CreationResult)
-	err := c.cc.Invoke(ctx, "/api.Internal/CreateKeychain", in, out, opts...)
+func (c *internalClient) GetAccountCreationStatus(ctx context.Context, in *AccountCreationStatusRequest, opts ...grpc.CallOption) (*AccountCreationStatusResponse, error) {
+	out := new(AccountCreationStatusResponse)
+	err := c.cc.Invoke(ctx, "/api.Internal/GetAccountCreationStatus", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *internalClient) CreateID(ctx context.Context, in *IDCreationRequest, opts ...grpc.CallOption) (*CreationResult, error) {
+func (c *internalClient) UpdateKeychain(ctx context.Context, in *KeychainUpdateRequest, opts ...grpc.CallOption) (*CreationResult, error) {
 	out := new(CreationResult)
-	err := c.cc.Invoke(ctx, "/api.Internal/CreateID", in, out, opts...)
+	err := c.cc.Invoke(ctx, "/api.Internal/UpdateKeychain", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *internalClient) GetSharedKeys(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*SharedKeysResult, error) {
-	out := new(SharedKeysResult)
-	err := c.cc.Invoke(ctx, "/api.Internal/GetSharedKeys", in, out, opts...)
+func (c *internalClient) GetIDDetails(ctx context.Context, in *AccountSearchRequest, opts ...grpc.CallOption) (*IDResponse, error) {
+	out := new(IDResponse)
+	err := c.cc.Invoke(ctx, "/api.Internal/GetIDDetails", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *internalClient) IsEmitterAuthorized(ctx context.Context, in *AuthorizationRequest, opts ...grpc.CallOption) (*AuthorizationResponse, error) {
-	out := new(AuthorizationResponse)
-	err := c.cc.Invoke(ctx, "/api.Internal/IsEmitterAuthorized", in, out, opts...)
+func (c *internalClient) GetKeychainDetails(ctx context.Context, in *AccountSearchRequest, opts ...grpc.CallOption) (*KeychainResponse, error) {
+	out := new(KeychainResponse)
+	err := c.cc.Invoke(ctx, "/api.Internal/GetKeychainDetails", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *internalClient) GetTransactionStatus(ctx context.Context, in *TransactionStatusRequest, opts ...grpc.CallOption) (*TransactionStatusResponse, error) {
-	out := new(TransactionStatusResponse)
-	err := c.cc.Invoke(ctx, "/api.Internal/GetTransactionStatus", in, out, opts...)
+func (c *internalClient) GetAccountProof(ctx context.Context, in *AccountSearchRequest, opts ...grpc.CallOption) (*AccountProof, error) {
+	out := new(AccountProof)
+	err := c.cc.Invoke(ctx, "/api.Internal/GetAccountProof", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *internalClient) GetStoragePeers(ctx context.Context, in *StoragePeersRequest, opts ...grpc.CallOption) (*StoragePeersResult, error) {
+	out := new(StoragePeersResult)
+	err := c.cc.Invoke(ctx, "/api.Internal/GetStoragePeers", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -531,7 +1560,19 @@ type InternalServer interface {
 	CreateID(context.Context, *IDCreationRequest) (*CreationResult, error)
 	GetSharedKeys(context.Context, *empty.Empty) (*SharedKeysResult, error)
 	IsEmitterAuthorized(context.Context, *AuthorizationRequest) (*AuthorizationResponse, error)
+	AuthorizeEmitter(context.Context, *EmitterAuthorizationRequest) (*empty.Empty, error)
+	RevokeEmitter(context.Context, *EmitterAuthorizationRequest) (*empty.Empty, error)
+	SignPayload(context.Context, *PayloadSignatureRequest) (*PayloadSignatureResponse, error)
 	GetTransactionStatus(context.Context, *TransactionStatusRequest) (*TransactionStatusResponse, error)
+	WatchTransactionStatus(*TransactionStatusRequest, Internal_WatchTransactionStatusServer) error
+	CreateAccount(context.Context, *AccountCreationRequest) (*AccountCreationResult, error)
+	CreateAccounts(context.Context, *AccountCreationBatchRequest) (*AccountCreationBatchResult, error)
+	GetAccountCreationStatus(context.Context, *AccountCreationStatusRequest) (*AccountCreationStatusResponse, error)
+	UpdateKeychain(context.Context, *KeychainUpdateRequest) (*CreationResult, error)
+	GetIDDetails(context.Context, *AccountSearchRequest) (*IDResponse, error)
+	GetKeychainDetails(context.Context, *AccountSearchRequest) (*KeychainResponse, error)
+	GetAccountProof(context.Context, *AccountSearchRequest) (*AccountProof, error)
+	GetStoragePeers(context.Context, *StoragePeersRequest) (*StoragePeersResult, error)
 }
 
 func RegisterInternalServer(s *grpc.Server, srv InternalServer) {
@@ -628,6 +1669,60 @@ func _Internal_IsEmitterAuthorized_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Internal_AuthorizeEmitter_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EmitterAuthorizationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InternalServer).AuthorizeEmitter(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.Internal/AuthorizeEmitter",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InternalServer).AuthorizeEmitter(ctx, req.(*EmitterAuthorizationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Internal_RevokeEmitter_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EmitterAuthorizationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InternalServer).RevokeEmitter(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.Internal/RevokeEmitter",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InternalServer).RevokeEmitter(ctx, req.(*EmitterAuthorizationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Internal_SignPayload_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PayloadSignatureRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InternalServer).SignPayload(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.Internal/SignPayload",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InternalServer).SignPayload(ctx, req.(*PayloadSignatureRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Internal_GetTransactionStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(TransactionStatusRequest)
 	if err := dec(in); err != nil {
@@ -646,6 +1741,171 @@ func _Internal_GetTransactionStatus_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Internal_WatchTransactionStatus_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(TransactionStatusRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(InternalServer).WatchTransactionStatus(m, &internalWatchTransactionStatusServer{stream})
+}
+
+type Internal_WatchTransactionStatusServer interface {
+	Send(*TransactionStatusResponse) error
+	grpc.ServerStream
+}
+
+type internalWatchTransactionStatusServer struct {
+	grpc.ServerStream
+}
+
+func (x *internalWatchTransactionStatusServer) Send(m *TransactionStatusResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Internal_CreateAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AccountCreationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InternalServer).CreateAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.Internal/CreateAccount",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InternalServer).CreateAccount(ctx, req.(*AccountCreationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Internal_CreateAccounts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AccountCreationBatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InternalServer).CreateAccounts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.Internal/CreateAccounts",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InternalServer).CreateAccounts(ctx, req.(*AccountCreationBatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Internal_GetAccountCreationStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AccountCreationStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InternalServer).GetAccountCreationStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.Internal/GetAccountCreationStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InternalServer).GetAccountCreationStatus(ctx, req.(*AccountCreationStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Internal_UpdateKeychain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(KeychainUpdateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InternalServer).UpdateKeychain(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.Internal/UpdateKeychain",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InternalServer).UpdateKeychain(ctx, req.(*KeychainUpdateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Internal_GetIDDetails_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AccountSearchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InternalServer).GetIDDetails(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.Internal/GetIDDetails",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InternalServer).GetIDDetails(ctx, req.(*AccountSearchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Internal_GetKeychainDetails_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AccountSearchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InternalServer).GetKeychainDetails(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.Internal/GetKeychainDetails",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InternalServer).GetKeychainDetails(ctx, req.(*AccountSearchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Internal_GetAccountProof_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AccountSearchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InternalServer).GetAccountProof(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.Internal/GetAccountProof",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InternalServer).GetAccountProof(ctx, req.(*AccountSearchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Internal_GetStoragePeers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StoragePeersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InternalServer).GetStoragePeers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.Internal/GetStoragePeers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InternalServer).GetStoragePeers(ctx, req.(*StoragePeersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Internal_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "api.Internal",
 	HandlerType: (*InternalServer)(nil),
@@ -670,53 +1930,155 @@ var _Internal_serviceDesc = grpc.ServiceDesc{
 			MethodName: "IsEmitterAuthorized",
 			Handler:    _Internal_IsEmitterAuthorized_Handler,
 		},
+		{
+			MethodName: "AuthorizeEmitter",
+			Handler:    _Internal_AuthorizeEmitter_Handler,
+		},
+		{
+			MethodName: "RevokeEmitter",
+			Handler:    _Internal_RevokeEmitter_Handler,
+		},
+		{
+			MethodName: "SignPayload",
+			Handler:    _Internal_SignPayload_Handler,
+		},
 		{
 			MethodName: "GetTransactionStatus",
 			Handler:    _Internal_GetTransactionStatus_Handler,
 		},
+		{
+			MethodName: "CreateAccount",
+			Handler:    _Internal_CreateAccount_Handler,
+		},
+		{
+			MethodName: "CreateAccounts",
+			Handler:    _Internal_CreateAccounts_Handler,
+		},
+		{
+			MethodName: "GetAccountCreationStatus",
+			Handler:    _Internal_GetAccountCreationStatus_Handler,
+		},
+		{
+			MethodName: "UpdateKeychain",
+			Handler:    _Internal_UpdateKeychain_Handler,
+		},
+		{
+			MethodName: "GetIDDetails",
+			Handler:    _Internal_GetIDDetails_Handler,
+		},
+		{
+			MethodName: "GetKeychainDetails",
+			Handler:    _Internal_GetKeychainDetails_Handler,
+		},
+		{
+			MethodName: "GetAccountProof",
+			Handler:    _Internal_GetAccountProof_Handler,
+		},
+		{
+			MethodName: "GetStoragePeers",
+			Handler:    _Internal_GetStoragePeers_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchTransactionStatus",
+			Handler:       _Internal_WatchTransactionStatus_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "internal.proto",
 }
 
-func init() { proto.RegisterFile("internal.proto", fileDescriptor_internal_b9644584db67bf3d) }
-
-var fileDescriptor_internal_b9644584db67bf3d = []byte{
-	// 570 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x54, 0x4d, 0x6f, 0xd3, 0x40,
-	0x10, 0x6d, 0x30, 0xaa, 0xda, 0x49, 0x1b, 0xda, 0x4d, 0x1a, 0x8c, 0x29, 0x28, 0xf2, 0x01, 0x45,
-	0x08, 0x39, 0xa8, 0x94, 0x03, 0xb7, 0x46, 0x4d, 0x14, 0xac, 0x08, 0x14, 0x25, 0x48, 0x3d, 0xa2,
-	0x8d, 0x33, 0x24, 0x16, 0x8e, 0xd7, 0xec, 0xae, 0x91, 0x82, 0xf8, 0x25, 0xfc, 0x0d, 0xae, 0xfc,
-	0x38, 0xe4, 0x5d, 0x3b, 0xfe, 0x48, 0x72, 0xdc, 0x37, 0x6f, 0xde, 0xce, 0xbc, 0x99, 0x5d, 0x68,
-	0xf8, 0xa1, 0x44, 0x1e, 0xd2, 0xc0, 0x89, 0x38, 0x93, 0x8c, 0x18, 0x34, 0xf2, 0xad, 0xe7, 0x4b,
-	0xc6, 0x96, 0x01, 0xf6, 0x14, 0x34, 0x8f, 0xbf, 0xf5, 0x70, 0x1d, 0xc9, 0x8d, 0x66, 0x58, 0x67,
-	0x1e, 0x5b, 0xaf, 0x59, 0xa8, 0x4f, 0xf6, 0x1d, 0xb4, 0xfa, 0x9e, 0xc7, 0xe2, 0x50, 0xce, 0x90,
-	0x72, 0x6f, 0x35, 0xc5, 0x1f, 0x31, 0x0a, 0x49, 0xba, 0xf0, 0x64, 0x18, 0x7a, 0x7c, 0x13, 0x49,
-	0x5c, 0xb8, 0x83, 0x8f, 0x54, 0xac, 0xcc, 0x5a, 0xa7, 0xd6, 0x3d, 0x9d, 0x56, 0x61, 0xfb, 0x6f,
-	0x0d, 0x9a, 0x15, 0x09, 0x11, 0x07, 0x65, 0x85, 0x07, 0x1a, 0x04, 0x28, 0x77, 0x14, 0x34, 0x5c,
-	0x62, 0xf6, 0x87, 0xb3, 0xef, 0xb8, 0x31, 0x1f, 0x55, 0x98, 0x1a, 0x26, 0xaf, 0xe1, 0x22, 0x87,
-	0x16, 0x0b, 0x8e, 0x42, 0x98, 0x86, 0xa2, 0xee, 0xe0, 0xe4, 0x1a, 0x4e, 0x67, 0xfe, 0x32, 0xa4,
-	0x32, 0xe6, 0x68, 0x3e, 0x56, 0xa4, 0x1c, 0xb0, 0x47, 0xf0, 0x74, 0x8c, 0x1b, 0x6f, 0x45, 0xfd,
-	0xf0, 0x9e, 0x23, 0x95, 0x3e, 0x0b, 0xb3, 0xd6, 0xdf, 0xc0, 0xe5, 0x56, 0x2c, 0xe3, 0xa4, 0xa5,
-	0xef, 0x06, 0xec, 0xf7, 0x70, 0xe9, 0x0e, 0xaa, 0x12, 0x1d, 0xa8, 0x17, 0x6c, 0x4a, 0x93, 0x8b,
-	0x90, 0xfd, 0x1b, 0x1a, 0x79, 0x52, 0xe6, 0xd7, 0x17, 0x4e, 0x43, 0x41, 0xbd, 0x04, 0x2c, 0x3a,
-	0x5e, 0x81, 0x89, 0x0d, 0x67, 0x9f, 0xa8, 0x90, 0xc8, 0x27, 0x88, 0xdc, 0x9d, 0xa4, 0x66, 0x95,
-	0xb0, 0x72, 0xf7, 0x46, 0xb5, 0xfb, 0x3f, 0x35, 0xb8, 0x98, 0xad, 0x28, 0x57, 0x7d, 0x88, 0xb4,
-	0x80, 0x57, 0xd0, 0x98, 0xb2, 0x39, 0x93, 0x93, 0x78, 0x1e, 0xf8, 0xde, 0x18, 0x37, 0xe9, 0xfd,
-	0x15, 0x34, 0x29, 0x54, 0x23, 0xdc, 0xff, 0x49, 0x25, 0x8e, 0xf3, 0x71, 0x55, 0x60, 0x72, 0x0b,
-	0xf5, 0xe1, 0xda, 0x97, 0x12, 0x79, 0x72, 0x8d, 0x69, 0x74, 0x8c, 0x6e, 0xfd, 0x86, 0x38, 0x34,
-	0xf2, 0x9d, 0xed, 0xed, 0x13, 0xea, 0xf3, 0x69, 0x91, 0x66, 0x7f, 0x85, 0xf3, 0x52, 0x94, 0xbc,
-	0x85, 0xe6, 0xd6, 0xba, 0xc2, 0xa5, 0xba, 0xba, 0x7d, 0xa1, 0xa4, 0xfb, 0xbc, 0x0b, 0x5d, 0x5c,
-	0x0e, 0xd8, 0xb7, 0xd0, 0xea, 0xc7, 0x72, 0xc5, 0xb8, 0xff, 0xab, 0x34, 0xb5, 0x52, 0x56, 0xad,
-	0x9a, 0xd5, 0x83, 0xab, 0x4a, 0x96, 0x88, 0x58, 0x28, 0x90, 0xb4, 0xe1, 0x78, 0x26, 0xa9, 0x8c,
-	0x85, 0xca, 0x39, 0x99, 0xa6, 0xa7, 0x9b, 0x7f, 0x06, 0x9c, 0xb8, 0xe9, 0xeb, 0x24, 0xf7, 0x00,
-	0x23, 0x94, 0xe9, 0x3b, 0x21, 0xcf, 0x94, 0x07, 0xfb, 0x1e, 0x9e, 0x65, 0xee, 0x0b, 0x25, 0xf3,
-	0xb1, 0x8f, 0xc8, 0x30, 0x5d, 0x1a, 0xcc, 0xb6, 0x8f, 0x5c, 0x2b, 0xf6, 0x81, 0x4d, 0xb6, 0x9a,
-	0x2a, 0x5a, 0xde, 0x33, 0xfb, 0x88, 0x7c, 0x80, 0x13, 0x2d, 0xe3, 0x0e, 0x48, 0x5b, 0x51, 0x76,
-	0x36, 0xf8, 0x50, 0xea, 0x1d, 0x9c, 0x8f, 0x50, 0xe6, 0xab, 0x43, 0xda, 0x8e, 0xfe, 0x6b, 0x9c,
-	0xec, 0xaf, 0x71, 0x86, 0xc9, 0x5f, 0x63, 0x5d, 0x95, 0xa7, 0x2c, 0xb6, 0x0a, 0x9f, 0xa1, 0xe9,
-	0x8a, 0x74, 0xdc, 0x99, 0x9f, 0xb8, 0xc8, 0x1c, 0xd9, 0x33, 0x16, 0xcb, 0xda, 0x17, 0xd2, 0xde,
-	0xdb, 0x47, 0xe4, 0x01, 0x5a, 0x23, 0x94, 0x85, 0x27, 0xa2, 0xdd, 0x27, 0x2f, 0x54, 0xd6, 0x0e,
-	0x9e, 0x89, 0xbe, 0x3c, 0x14, 0xce, 0x84, 0xe7, 0xc7, 0xaa, 0xa3, 0x77, 0xff, 0x03, 0x00, 0x00,
-	0xff, 0xff, 0x18, 0x4f, 0x4b, 0x90, 0x62, 0x05, 0x00, 0x00,
+func init() { proto.RegisterFile("internal.proto", fileDescriptor_internal_3bd648dbb2ccb931) }
+
+var fileDescriptor_internal_3bd648dbb2ccb931 = []byte{
+	// 1393 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x57, 0xcb, 0x72, 0xdb, 0x36,
+	0x17, 0x16, 0x25, 0xd9, 0x96, 0x8f, 0x6c, 0x85, 0x86, 0x6f, 0xfa, 0xe9, 0xf8, 0x8f, 0x8b, 0xce,
+	0x74, 0xd2, 0x4e, 0xc7, 0xce, 0x28, 0xc9, 0x24, 0x9d, 0x76, 0x11, 0xd7, 0x52, 0x1c, 0x26, 0x6e,
+	0x46, 0x43, 0xc7, 0x49, 0x67, 0xba, 0xa2, 0x29, 0xc4, 0xe6, 0x44, 0x22, 0x54, 0x10, 0x4c, 0xeb,
+	0x2e, 0xbb, 0xe9, 0xaa, 0xef, 0xd1, 0x7d, 0x5f, 0xa0, 0x79, 0x97, 0x6e, 0xfb, 0x0e, 0x1d, 0xe2,
+	0xc2, 0x9b, 0x48, 0x25, 0xee, 0x64, 0x07, 0x9c, 0xcb, 0x07, 0xe0, 0xe0, 0xc3, 0x39, 0x07, 0xd0,
+	0xf1, 0x03, 0x4e, 0x58, 0xe0, 0x8e, 0xf7, 0xa7, 0x8c, 0x72, 0x8a, 0x1a, 0xee, 0xd4, 0xb7, 0x76,
+	0x2e, 0x28, 0xbd, 0x18, 0x93, 0x03, 0x21, 0x3a, 0x8f, 0x5e, 0x1f, 0x90, 0xc9, 0x94, 0x5f, 0x49,
+	0x0b, 0x6b, 0xc5, 0xa3, 0x93, 0x09, 0x0d, 0xe4, 0x0c, 0x3f, 0x82, 0x8d, 0x43, 0xcf, 0xa3, 0x51,
+	0xc0, 0x4f, 0x89, 0xcb, 0xbc, 0x4b, 0x87, 0xfc, 0x18, 0x91, 0x90, 0xa3, 0xdb, 0x70, 0x63, 0x10,
+	0x78, 0xec, 0x6a, 0xca, 0xc9, 0xc8, 0xee, 0x3f, 0x71, 0xc3, 0xcb, 0xae, 0xb1, 0x67, 0xdc, 0x5e,
+	0x76, 0x8a, 0x62, 0xfc, 0xa7, 0x01, 0xeb, 0x05, 0x88, 0x30, 0x1a, 0xe7, 0x11, 0x5e, 0xb9, 0xe3,
+	0x31, 0xe1, 0x33, 0x08, 0x52, 0x9c, 0xb3, 0x3c, 0x1c, 0x9c, 0xbe, 0x21, 0x57, 0xdd, 0x7a, 0xc1,
+	0x52, 0x8a, 0xd1, 0x17, 0x60, 0xa6, 0xa2, 0xd1, 0x88, 0x91, 0x30, 0xec, 0x36, 0x84, 0xe9, 0x8c,
+	0x1c, 0xdd, 0x84, 0xe5, 0x53, 0xff, 0x22, 0x70, 0x79, 0xc4, 0x48, 0xb7, 0x29, 0x8c, 0x52, 0x01,
+	0xfe, 0xcb, 0x80, 0x15, 0xb5, 0xeb, 0x21, 0xa3, 0xf4, 0x35, 0xda, 0x86, 0xba, 0xdd, 0x17, 0x3b,
+	0x6c, 0xf7, 0x96, 0xf6, 0xdd, 0xa9, 0xbf, 0x6f, 0xf7, 0x9d, 0xba, 0xdd, 0x47, 0x07, 0xb0, 0x64,
+	0xf7, 0x85, 0x8d, 0xd8, 0x55, 0xbb, 0xb7, 0x29, 0xb4, 0x2f, 0x98, 0x1b, 0x84, 0xae, 0xc7, 0x7d,
+	0x1a, 0x08, 0xa5, 0xa3, 0xad, 0xd0, 0xe7, 0xd0, 0x7a, 0x46, 0xae, 0xbc, 0x4b, 0xd7, 0x0f, 0xc4,
+	0xe6, 0xda, 0xbd, 0x55, 0xe1, 0xa1, 0x85, 0x4e, 0xa2, 0x46, 0x5f, 0xc3, 0xaa, 0x1e, 0xcb, 0x15,
+	0x9a, 0xf3, 0x56, 0xc8, 0xdb, 0xe2, 0x13, 0x30, 0x8b, 0x26, 0xa8, 0x07, 0xed, 0x41, 0x30, 0xa2,
+	0x2c, 0x24, 0x13, 0x12, 0x70, 0x75, 0x1c, 0x53, 0xc0, 0x65, 0xe4, 0x4e, 0xd6, 0xe8, 0x69, 0xb3,
+	0x55, 0x37, 0x1b, 0xf8, 0x00, 0xd6, 0x4f, 0x39, 0x65, 0xee, 0x05, 0x19, 0x12, 0xc2, 0x42, 0xcd,
+	0x83, 0x2e, 0x2c, 0xe9, 0x40, 0xcb, 0xdb, 0xd3, 0x53, 0x7c, 0x1f, 0x50, 0xde, 0x41, 0xdc, 0xfa,
+	0x2d, 0x58, 0x10, 0xd3, 0xae, 0xb1, 0xd7, 0xb8, 0xdd, 0xee, 0x2d, 0x8b, 0xa5, 0x63, 0x89, 0x23,
+	0xe5, 0xf8, 0x1e, 0x34, 0xe3, 0x01, 0xea, 0x40, 0xdd, 0x1e, 0x2a, 0xcc, 0xba, 0x3d, 0x8c, 0xaf,
+	0x6b, 0x18, 0x9d, 0x8f, 0x7d, 0xef, 0x59, 0x72, 0xfd, 0xa9, 0x00, 0x1f, 0xc3, 0xb6, 0x3e, 0xfc,
+	0x11, 0x23, 0x6e, 0x7c, 0x60, 0xbd, 0xc3, 0x2f, 0x61, 0x2d, 0xb9, 0xfb, 0x24, 0xee, 0x12, 0x77,
+	0x56, 0x81, 0xef, 0xc3, 0x9a, 0xdd, 0x2f, 0x42, 0xec, 0x41, 0x3b, 0xb1, 0x54, 0x24, 0x58, 0x76,
+	0xb2, 0x22, 0xfc, 0x87, 0x01, 0x9d, 0xd4, 0x4b, 0xf3, 0x3b, 0x13, 0xfe, 0xec, 0x0b, 0x29, 0x88,
+	0x11, 0x86, 0x95, 0xef, 0xdc, 0x90, 0x13, 0x16, 0x1f, 0xdc, 0x1e, 0xaa, 0xd3, 0xe5, 0x64, 0x79,
+	0xb6, 0x36, 0x0a, 0x6c, 0x2d, 0xe5, 0x7d, 0xb3, 0x9c, 0xf7, 0xf8, 0x12, 0xb6, 0x14, 0xb1, 0xaf,
+	0x7d, 0xcc, 0xf2, 0x58, 0xd6, 0xab, 0x62, 0x39, 0x81, 0xcd, 0x99, 0x95, 0x44, 0x68, 0x3e, 0xcd,
+	0xbc, 0xa5, 0x75, 0xc1, 0x80, 0xbc, 0x81, 0x7a, 0x57, 0xad, 0xdc, 0x12, 0x15, 0xa6, 0x89, 0x11,
+	0x7e, 0x09, 0x3b, 0x85, 0xe5, 0xbe, 0x75, 0x79, 0x9a, 0xb1, 0x1e, 0x40, 0x4b, 0x0d, 0x35, 0xf9,
+	0x76, 0x04, 0x5e, 0x79, 0x30, 0x9c, 0xc4, 0x18, 0x9f, 0x81, 0x55, 0x8e, 0x2b, 0xce, 0xf2, 0x00,
+	0x96, 0xe4, 0x48, 0xa3, 0xee, 0x96, 0xa1, 0x0a, 0x0f, 0x9b, 0x93, 0x89, 0xa3, 0xad, 0xf1, 0xaf,
+	0x06, 0x74, 0xab, 0xac, 0x50, 0x0f, 0x16, 0xa5, 0x9d, 0x8a, 0x92, 0x55, 0xbe, 0x55, 0x11, 0x01,
+	0x65, 0x89, 0x36, 0x60, 0x61, 0xc0, 0x18, 0x65, 0xea, 0x42, 0xe4, 0x24, 0x26, 0x8e, 0x18, 0x1c,
+	0xd1, 0x51, 0x42, 0x9c, 0x44, 0x80, 0x4f, 0xe0, 0x66, 0x01, 0xf4, 0x94, 0xbb, 0x3c, 0x0a, 0x33,
+	0x8f, 0xc7, 0xee, 0x97, 0xd3, 0x78, 0x56, 0x81, 0xdf, 0x35, 0x60, 0xb7, 0x02, 0x2e, 0x9c, 0xd2,
+	0x20, 0x24, 0x68, 0x08, 0x8b, 0x52, 0x22, 0x40, 0x3a, 0xbd, 0x87, 0x65, 0xe7, 0xca, 0xfb, 0x54,
+	0x68, 0x15, 0x0e, 0x3a, 0x81, 0x96, 0xdd, 0x57, 0x98, 0x75, 0x81, 0x79, 0xa7, 0x98, 0x1d, 0x0b,
+	0x78, 0xb3, 0x9a, 0x04, 0x01, 0x7d, 0x0f, 0x1d, 0xcd, 0x27, 0x85, 0xd9, 0xf8, 0x8f, 0x98, 0x05,
+	0x9c, 0x1c, 0x9d, 0x9b, 0x1f, 0x42, 0xe7, 0x68, 0xe6, 0xf5, 0x28, 0xa4, 0x36, 0x2c, 0x0d, 0x49,
+	0x30, 0xf2, 0x83, 0x0b, 0xb3, 0x16, 0x4f, 0x4e, 0x23, 0xcf, 0x23, 0x61, 0x68, 0x1a, 0xf1, 0xe4,
+	0xb1, 0xeb, 0x8f, 0x23, 0x46, 0xcc, 0x3a, 0xea, 0x00, 0xd8, 0x81, 0x47, 0x27, 0xd3, 0x31, 0xe1,
+	0xc4, 0x6c, 0xc4, 0xca, 0xb3, 0xe0, 0x4d, 0x40, 0x7f, 0x0a, 0xcc, 0x66, 0x3c, 0x39, 0x3c, 0xa7,
+	0x8c, 0x93, 0x91, 0xb9, 0x10, 0x4f, 0x5e, 0xf8, 0x13, 0x42, 0x23, 0x6e, 0x2e, 0xe2, 0x77, 0x06,
+	0x6c, 0xea, 0x3d, 0x9c, 0x4d, 0x47, 0x2e, 0x27, 0xd7, 0x2e, 0xf9, 0xd7, 0x4b, 0x13, 0x31, 0x43,
+	0xe3, 0xe5, 0x43, 0xee, 0x4e, 0xa6, 0x22, 0xdc, 0x0d, 0x27, 0x15, 0xc4, 0xac, 0x7e, 0x4e, 0x03,
+	0x4f, 0x97, 0x68, 0x39, 0xc9, 0xa7, 0xc3, 0x85, 0x62, 0xf1, 0xfe, 0xc7, 0x00, 0xf3, 0xf4, 0xd2,
+	0x65, 0x62, 0x11, 0x5d, 0x79, 0x3e, 0x83, 0x8e, 0x43, 0xcf, 0x29, 0x4f, 0xab, 0x88, 0xdc, 0x7d,
+	0x41, 0x8a, 0xee, 0x41, 0x7b, 0x30, 0xf1, 0x39, 0x27, 0x2c, 0x76, 0xee, 0x36, 0xc4, 0xa3, 0x46,
+	0xe2, 0xae, 0x12, 0xcc, 0xa1, 0xeb, 0x33, 0x27, 0x6b, 0x86, 0xfa, 0xb0, 0x3e, 0x64, 0xe4, 0xad,
+	0x4f, 0xa3, 0x30, 0xeb, 0xdd, 0xac, 0xf4, 0x2e, 0x33, 0x47, 0x07, 0xb0, 0x2c, 0x76, 0x23, 0x7c,
+	0x17, 0x84, 0xef, 0x9a, 0xf0, 0xd5, 0x52, 0xe1, 0x9a, 0xda, 0xa8, 0xda, 0xcc, 0x61, 0x25, 0x6b,
+	0x10, 0x17, 0xe5, 0x97, 0x84, 0x85, 0x3e, 0x95, 0x85, 0x6e, 0xc1, 0xd1, 0xd3, 0xf9, 0x55, 0x34,
+	0x0e, 0xd1, 0xa1, 0xc7, 0xfd, 0xb7, 0x82, 0x6d, 0x7d, 0x97, 0xcb, 0xa0, 0x37, 0x9c, 0x82, 0xf4,
+	0x69, 0xb3, 0xd5, 0x30, 0x9b, 0xf8, 0x37, 0x03, 0x56, 0x73, 0x67, 0x42, 0x77, 0x60, 0x3d, 0xb9,
+	0xde, 0x21, 0x8b, 0x3d, 0x48, 0x1a, 0xe7, 0x32, 0xd5, 0xfb, 0xf7, 0x33, 0xf8, 0x79, 0xea, 0xb3,
+	0x74, 0x3f, 0x92, 0x1e, 0x05, 0x29, 0xbe, 0x07, 0x1b, 0x87, 0x11, 0xbf, 0xa4, 0xcc, 0xff, 0x25,
+	0x57, 0xd0, 0x72, 0xe8, 0x46, 0xb1, 0x67, 0x38, 0x80, 0xcd, 0x82, 0x97, 0x4a, 0x52, 0x5b, 0xb9,
+	0x24, 0xd5, 0xd2, 0xa9, 0x06, 0xff, 0x6e, 0xc0, 0x8e, 0xba, 0xad, 0xeb, 0x2f, 0x97, 0xa7, 0x79,
+	0xbd, 0x92, 0xe6, 0x8d, 0x4a, 0x9a, 0xcf, 0xf4, 0xa8, 0x77, 0x61, 0x7b, 0xe8, 0x5e, 0x8d, 0xa9,
+	0x3b, 0x4a, 0x64, 0x99, 0xb6, 0x4c, 0xa9, 0xc4, 0x46, 0x56, 0x1c, 0x3d, 0xc5, 0x0f, 0xa1, 0x3b,
+	0xeb, 0xa4, 0x0e, 0x9e, 0x5b, 0xce, 0x28, 0x2c, 0xd7, 0xfb, 0x1b, 0xa0, 0x65, 0xab, 0xdf, 0x04,
+	0x3a, 0x02, 0x38, 0x26, 0x5c, 0x25, 0x28, 0xf4, 0xbf, 0x6c, 0x1a, 0xcf, 0x7d, 0x14, 0xac, 0x6e,
+	0x99, 0x2a, 0x7e, 0x90, 0xb8, 0x86, 0x06, 0xaa, 0x69, 0x22, 0x69, 0x2e, 0xc8, 0x75, 0xc2, 0x85,
+	0x9a, 0x6c, 0x95, 0x65, 0x4c, 0x5c, 0x43, 0x5f, 0x41, 0x4b, 0xc2, 0xd8, 0x7d, 0xb4, 0xa5, 0x5a,
+	0xf3, 0x0f, 0x74, 0x7d, 0x04, 0xab, 0xc7, 0x84, 0xa7, 0xb9, 0x02, 0x6d, 0xed, 0xcb, 0xbf, 0xd1,
+	0xbe, 0xfe, 0x1b, 0xed, 0x0f, 0xe2, 0xbf, 0x91, 0xb5, 0x99, 0x7f, 0xc2, 0x61, 0x82, 0xf0, 0x1c,
+	0xd6, 0xed, 0xb0, 0xc0, 0x0a, 0x32, 0xd2, 0x11, 0x29, 0xa1, 0x89, 0x65, 0x95, 0xa9, 0xe4, 0x0d,
+	0x08, 0x3c, 0x33, 0x81, 0x51, 0xb0, 0x68, 0x4f, 0x36, 0xe8, 0xd5, 0xd4, 0xb3, 0x2a, 0xb6, 0x8d,
+	0x6b, 0xe8, 0x19, 0xac, 0x3a, 0xe4, 0x2d, 0x7d, 0xf3, 0x51, 0xc0, 0x4e, 0xa0, 0x1d, 0xf3, 0x41,
+	0x11, 0x48, 0xdd, 0x56, 0x05, 0x07, 0xad, 0xdd, 0x0a, 0x6d, 0x72, 0xd4, 0x57, 0xb0, 0x71, 0x4c,
+	0xf8, 0x4c, 0xe9, 0x44, 0xbb, 0x55, 0xc5, 0x56, 0xe2, 0xfe, 0x7f, 0x7e, 0x2d, 0xc6, 0x35, 0xf4,
+	0x03, 0x6c, 0xbd, 0x8a, 0x5b, 0xa9, 0x8f, 0x0f, 0x7d, 0xc7, 0x40, 0x4f, 0x61, 0x55, 0xb2, 0x4d,
+	0x93, 0x7f, 0x5e, 0x1b, 0x69, 0xcd, 0x69, 0xdc, 0x70, 0x0d, 0x9d, 0xe9, 0x07, 0xa0, 0x0c, 0x42,
+	0x75, 0x3b, 0x73, 0xfa, 0x58, 0xeb, 0xd6, 0x1c, 0x0b, 0x05, 0xeb, 0x41, 0x37, 0x7d, 0x9c, 0x85,
+	0xee, 0xe1, 0x93, 0x79, 0x1d, 0x97, 0x5c, 0x01, 0xbf, 0xbf, 0x29, 0xc3, 0x35, 0x74, 0x04, 0x1d,
+	0xd9, 0x1f, 0x24, 0x8f, 0xd7, 0xca, 0x3d, 0xde, 0x5c, 0xf3, 0x50, 0xf5, 0xfe, 0xbe, 0x81, 0x95,
+	0x63, 0xc2, 0xed, 0x7e, 0x9f, 0x70, 0xd7, 0x1f, 0x87, 0xf3, 0x12, 0xc9, 0x0d, 0xfd, 0xe9, 0x4e,
+	0xb7, 0xf0, 0x04, 0xd0, 0x31, 0xe1, 0x7a, 0xc1, 0x0f, 0xc0, 0xd8, 0xcc, 0x7f, 0xb4, 0x53, 0xa4,
+	0x43, 0xb8, 0x91, 0x46, 0x4c, 0x7e, 0x95, 0xe7, 0xc0, 0xac, 0x65, 0x55, 0xf2, 0xb3, 0x5d, 0x43,
+	0x8f, 0x05, 0x44, 0xf6, 0xcb, 0x8b, 0x64, 0xee, 0x2b, 0xf9, 0x36, 0x5b, 0xdb, 0x25, 0x1a, 0x19,
+	0x92, 0xf3, 0x45, 0xf1, 0xea, 0xee, 0xfe, 0x3b, 0x00, 0x3f, 0x04, 0xbe, 0x0d, 0xba, 0x11, 0x00,
+	0x00,
 }
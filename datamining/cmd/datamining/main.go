@@ -5,35 +5,46 @@ import (
 	"fmt"
 	"log"
 	"net"
+	"os"
 	"path/filepath"
 
+	datamining "github.com/uniris/uniris-core/datamining/pkg"
 	"github.com/uniris/uniris-core/datamining/pkg/emitter"
 
 	accountAdding "github.com/uniris/uniris-core/datamining/pkg/account/adding"
+	accountCreating "github.com/uniris/uniris-core/datamining/pkg/account/creating"
 	accountListing "github.com/uniris/uniris-core/datamining/pkg/account/listing"
 	accountMining "github.com/uniris/uniris-core/datamining/pkg/account/mining"
+	emadding "github.com/uniris/uniris-core/datamining/pkg/emitter/adding"
 	emlisting "github.com/uniris/uniris-core/datamining/pkg/emitter/listing"
 	"github.com/uniris/uniris-core/datamining/pkg/lock"
 	"github.com/uniris/uniris-core/datamining/pkg/mining"
 	"github.com/uniris/uniris-core/datamining/pkg/transport/rpc"
+	"github.com/uniris/uniris-core/shared/pkg/connpool"
 
 	"github.com/uniris/uniris-core/datamining/pkg/crypto"
 	"github.com/uniris/uniris-core/datamining/pkg/system"
+	"github.com/uniris/uniris-core/shared/pkg/keystore"
 
 	"google.golang.org/grpc"
 
 	api "github.com/uniris/uniris-core/datamining/api/protobuf-spec"
+	"github.com/uniris/uniris-core/datamining/pkg/storage/file"
 	mem "github.com/uniris/uniris-core/datamining/pkg/storage/mem"
+	"github.com/uniris/uniris-core/datamining/pkg/transport/amqp"
+	"github.com/uniris/uniris-core/datamining/pkg/transport/discovery"
+	"github.com/uniris/uniris-core/datamining/pkg/transport/logging"
 	mocktransport "github.com/uniris/uniris-core/datamining/pkg/transport/mock"
 )
 
 const (
-	defaultConfFile = "../../../conf.yaml"
+	defaultConfFile       = "../../../conf.yaml"
+	keystorePassphraseEnv = "UNIRIS_KEYSTORE_PASSPHRASE"
 )
 
 func main() {
 
-	config, err := loadConfiguration()
+	config, ks, err := loadConfiguration()
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -50,25 +61,53 @@ func main() {
 	poolFinder := mocktransport.NewPoolFinder()
 	aiClient := mocktransport.NewAIClient()
 
-	signer := crypto.NewSigner()
+	signer := crypto.NewSigner(ks)
 	hasher := crypto.NewHasher()
-	decrypter := crypto.NewDecrypter()
+	decrypter := crypto.NewDecrypter(ks)
 
 	rpcCrypto := rpc.NewCrypto(decrypter, signer, hasher)
 
-	externalClient := rpc.NewExternalClient(rpcCrypto, *config)
+	//The peer keys authenticate the request envelopes and the mutual TLS:
+	//the discovery is only reached when a remote peer is resolved
+	peerKeys, err := discovery.NewPeerKeyResolver(config.Services.Discovery, config.PublicKey)
+	if err != nil {
+		log.Fatal(err)
+	}
+	transportSec, err := rpc.NewTransportSecurity(*config, crypto.NewCertifier(ks), peerKeys)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	pool := connpool.NewPool(connpool.DefaultMaxCalls, connpool.DefaultIdleTimeout)
+	defer pool.Close()
+
+	externalClient := rpc.NewExternalClient(rpcCrypto, transportSec, pool, *config)
 	poolRequester := rpc.NewPoolRequester(externalClient, *config, rpcCrypto)
 
 	emLister := emlisting.NewService(db)
-	lockSrv := lock.NewService(db)
+	emAdder := emadding.NewService(db, emLister, *config)
+	for _, pubKey := range config.Emitters.Genesis {
+		if err := emAdder.AuthorizeEmitter(pubKey); err != nil {
+			log.Fatal(err)
+		}
+	}
+	var notifier datamining.Notifier
+	if config.Services.Datamining.AMQP.Host != "" {
+		notifier = amqp.NewNotifier(config.Services.Datamining.AMQP)
+	} else {
+		notifier = logging.NewNotifier()
+	}
+
+	lockSrv := lock.NewService(db, notifier)
 	accountLister := accountListing.NewService(db)
-	accountAdder := accountAdding.NewService(aiClient, db, accountLister, signer, hasher)
+	accountAdder := accountAdding.NewService(aiClient, db, accountLister, emAdder, signer, hasher, notifier)
 
 	txMiners := map[mining.TransactionType]mining.TransactionMiner{
 		mining.KeychainTransaction: accountMining.NewKeychainMiner(signer, hasher, accountLister),
 		mining.IDTransaction:       accountMining.NewIDMiner(signer, hasher),
 	}
 
+	statusWatcher := mining.NewStatusWatcher()
 	miningSrv := mining.NewService(
 		aiClient,
 		poolFinder,
@@ -77,12 +116,23 @@ func main() {
 		emLister,
 		*config,
 		txMiners,
+		statusWatcher,
+		notifier,
 	)
 
 	log.Print("DataMining Service starting...")
 
+	creationRepo, err := openAccountCreationRepository(config.Services.Datamining, db)
+	if err != nil {
+		log.Fatal(err)
+	}
+
 	go func() {
-		internalHandler := rpc.NewInternalServerHandler(emLister, poolRequester, poolFinder, aiClient, externalClient, rpcCrypto, *config)
+		accountCreator := accountCreating.NewService(rpc.NewAccountTransactionLauncher(aiClient, externalClient, poolFinder, rpcCrypto, *config), creationRepo)
+		if err := accountCreator.ResumeAccountCreations(); err != nil {
+			log.Fatal(err)
+		}
+		internalHandler := rpc.NewInternalServerHandler(emLister, emAdder, poolRequester, poolFinder, aiClient, externalClient, accountCreator, rpcCrypto, *config)
 
 		//Starts Internal grpc server
 		if err := startInternalServer(internalHandler, config.Services.Datamining.InternalPort); err != nil {
@@ -91,9 +141,11 @@ func main() {
 	}()
 
 	//Starts Internal grpc server
-	rpcServices := rpc.NewExternalServices(lockSrv, miningSrv, accountAdder, accountLister)
+	rpcServices := rpc.NewExternalServices(lockSrv, miningSrv, accountAdder, accountLister, statusWatcher)
 	externalHandler := rpc.NewExternalServerHandler(rpcServices, rpcCrypto, *config)
-	if err := startExternalServer(externalHandler, config.Services.Datamining.ExternalPort); err != nil {
+	authenticator := rpc.NewRequestAuthenticator(rpcCrypto, peerKeys)
+	streamAuthenticator := rpc.NewStreamRequestAuthenticator(rpcCrypto, peerKeys)
+	if err := startExternalServer(externalHandler, transportSec, authenticator, streamAuthenticator, config.Services.Datamining.ExternalPort); err != nil {
 		log.Fatal(err)
 	}
 
@@ -104,7 +156,7 @@ func startInternalServer(handler api.InternalServer, port int) error {
 	if err != nil {
 		return err
 	}
-	grpcServer := grpc.NewServer()
+	grpcServer := grpc.NewServer(grpc.UnaryInterceptor(rpc.NewErrorTranslator()), grpc.StreamInterceptor(rpc.NewStreamErrorTranslator()))
 
 	api.RegisterInternalServer(grpcServer, handler)
 	log.Printf("Internal grpc Server listening on 127.0.0.1:%d", port)
@@ -114,13 +166,17 @@ func startInternalServer(handler api.InternalServer, port int) error {
 	return nil
 }
 
-func startExternalServer(handler api.ExternalServer, port int) error {
+func startExternalServer(handler api.ExternalServer, sec rpc.TransportSecurity, auth grpc.UnaryServerInterceptor, streamAuth grpc.StreamServerInterceptor, port int) error {
 	lis, err := net.Listen("tcp", fmt.Sprintf("127.0.0.1:%d", port))
 	if err != nil {
 		return err
 	}
 
-	grpcServer := grpc.NewServer()
+	//The errors of the authentication are translated too, so the peers always receive errors of the catalogue
+	opts := append(sec.ServerOptions(),
+		grpc.UnaryInterceptor(rpc.ChainInterceptors(rpc.NewErrorTranslator(), auth)),
+		grpc.StreamInterceptor(rpc.ChainStreamInterceptors(rpc.NewStreamErrorTranslator(), streamAuth)))
+	grpcServer := grpc.NewServer(opts...)
 
 	api.RegisterExternalServer(grpcServer, handler)
 	log.Printf("External grpc Server listening on 127.0.0.1:%d", port)
@@ -130,14 +186,36 @@ func startExternalServer(handler api.ExternalServer, port int) error {
 	return nil
 }
 
-func loadConfiguration() (*system.UnirisConfig, error) {
+//openAccountCreationRepository persists the sagas of the account creations in the saga directory, or in the database without directory
+func openAccountCreationRepository(conf system.DataMiningConfiguration, db accountCreating.Repository) (accountCreating.Repository, error) {
+	if conf.SagaDir == "" {
+		log.Print("No saga directory, the account creations are not resumed after a restart")
+		return db, nil
+	}
+	return file.NewAccountCreationRepository(conf.SagaDir)
+}
+
+func loadConfiguration() (*system.UnirisConfig, keystore.KeyStore, error) {
 	confFile := flag.String("config", defaultConfFile, "Configuration file")
 	flag.Parse()
 
 	confFilePath, err := filepath.Abs(*confFile)
 	conf, err := system.BuildFromFile(confFilePath)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-	return conf, nil
+
+	if conf.KeyStore.Path == "" {
+		return conf, nil, nil
+	}
+
+	ks, err := keystore.NewFileStore(conf.KeyStore.Path, os.Getenv(keystorePassphraseEnv))
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := system.LoadKeyStore(ks, conf); err != nil {
+		return nil, nil, err
+	}
+
+	return conf, ks, nil
 }
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/uniris/uniris-core/datamining/pkg/system"
+	"github.com/uniris/uniris-core/shared/pkg/keystore"
+)
+
+const (
+	defaultConfFile       = "../../../conf.yaml"
+	keystorePassphraseEnv = "UNIRIS_KEYSTORE_PASSPHRASE"
+)
+
+//Imports the private keys of a configuration file into an encrypted key store
+//
+//Once imported, the private keys can be removed from the configuration file
+func main() {
+	confFile := flag.String("config", defaultConfFile, "Configuration file")
+	keystoreFile := flag.String("keystore", "keystore.json", "Key store file")
+	flag.Parse()
+
+	confFilePath, err := filepath.Abs(*confFile)
+	if err != nil {
+		log.Fatal(err)
+	}
+	conf, err := system.BuildFromFile(confFilePath)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	ks, err := keystore.NewFileStore(*keystoreFile, os.Getenv(keystorePassphraseEnv))
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := system.ImportKeyStore(ks, *conf); err != nil {
+		log.Fatal(err)
+	}
+
+	log.Printf("Private keys imported into %s", *keystoreFile)
+}
@@ -2,9 +2,13 @@ package adding
 
 import (
 	"errors"
+	"log"
 
+	datamining "github.com/uniris/uniris-core/datamining/pkg"
 	"github.com/uniris/uniris-core/datamining/pkg/account"
 	"github.com/uniris/uniris-core/datamining/pkg/account/listing"
+	"github.com/uniris/uniris-core/datamining/pkg/emitter"
+	emadding "github.com/uniris/uniris-core/datamining/pkg/emitter/adding"
 	"github.com/uniris/uniris-core/datamining/pkg/mining"
 )
 
@@ -64,13 +68,15 @@ type service struct {
 	aiClient AIClient
 	repo     Repository
 	lister   listing.Service
+	emAdder  emadding.Service
 	sigVerif signatureVerifier
 	hasher   hasher
+	notif    datamining.Notifier
 }
 
 //NewService creates a new adding service
-func NewService(aiClient AIClient, repo Repository, lister listing.Service, sigVerif signatureVerifier, hash hasher) Service {
-	return service{aiClient, repo, lister, sigVerif, hash}
+func NewService(aiClient AIClient, repo Repository, lister listing.Service, emAdder emadding.Service, sigVerif signatureVerifier, hash hasher, notif datamining.Notifier) Service {
+	return service{aiClient, repo, lister, emAdder, sigVerif, hash, notif}
 }
 
 func (s service) StoreKeychain(kc account.EndorsedKeychain) error {
@@ -113,10 +119,20 @@ func (s service) StoreKeychain(kc account.EndorsedKeychain) error {
 
 	//If the keychain contains any KO validations, it will be stored on the KO database
 	if s.isKO(kc.Endorsement()) {
-		return s.repo.StoreKOKeychain(kc)
+		if err := s.repo.StoreKOKeychain(kc); err != nil {
+			return err
+		}
+		s.notifyStorage(kc.Endorsement().TransactionHash(), mining.KeychainTransaction, true)
+		return nil
+	}
+
+	if err := s.repo.StoreKeychain(kc); err != nil {
+		return err
 	}
+	s.notifyStorage(kc.Endorsement().TransactionHash(), mining.KeychainTransaction, false)
 
-	return s.repo.StoreKeychain(kc)
+	//The stored transaction endorses its shared emitter keys proposal on behalf of the ID which signed it
+	return s.proposeSharedEmitterKeys(kc.Proposal(), kc.IDPublicKey())
 }
 
 func (s service) StoreID(id account.EndorsedID) error {
@@ -155,10 +171,58 @@ func (s service) StoreID(id account.EndorsedID) error {
 
 	//If the ID contains any KO validations, it will be stored on the KO database
 	if s.isKO(id.Endorsement()) {
-		return s.repo.StoreKOID(id)
+		if err := s.repo.StoreKOID(id); err != nil {
+			return err
+		}
+		s.notifyStorage(id.Endorsement().TransactionHash(), mining.IDTransaction, true)
+		return nil
 	}
 
-	return s.repo.StoreID(id)
+	if err := s.repo.StoreID(id); err != nil {
+		return err
+	}
+	s.notifyStorage(id.Endorsement().TransactionHash(), mining.IDTransaction, false)
+
+	//The ID public key becomes an authorized emitter
+	if err := s.emAdder.AuthorizeEmitter(id.PublicKey()); err != nil {
+		return err
+	}
+
+	//The stored transaction endorses its shared emitter keys proposal on behalf of the ID which signed it
+	return s.proposeSharedEmitterKeys(id.Proposal(), id.PublicKey())
+}
+
+//notifyStorage publishes the storage of a transaction, the transaction remains stored even if the event cannot be published
+func (s service) notifyStorage(txHash string, txType mining.TransactionType, ko bool) {
+	var err error
+	if ko {
+		err = s.notif.NotifyTransactionRejected(datamining.TransactionRejected{
+			TransactionHash: txHash,
+			TransactionType: txType.String(),
+		})
+	} else {
+		err = s.notif.NotifyTransactionStored(datamining.TransactionStored{
+			TransactionHash: txHash,
+			TransactionType: txType.String(),
+		})
+	}
+	if err != nil {
+		log.Printf("Storage notification error: %s", err.Error())
+	}
+}
+
+//proposeSharedEmitterKeys endorses the proposal by the ID public key, which signed the proposal within the transaction data
+//
+//The proof of work key cannot identify the endorser, as it is one of the shared emitter keys
+func (s service) proposeSharedEmitterKeys(prop datamining.Proposal, idPubKey string) error {
+	if prop == nil || prop.SharedEmitterKeyPair() == nil {
+		return nil
+	}
+	return s.emAdder.ProposeSharedEmitterKeyPair(emitter.SharedKeyPair{
+		PublicKey:           prop.SharedEmitterKeyPair().PublicKey(),
+		EncryptedPrivateKey: prop.SharedEmitterKeyPair().EncryptedPrivateKey(),
+		ActivationDate:      prop.SharedEmitterKeyPair().ActivationDate(),
+	}, idPubKey)
 }
 
 func (s service) isKO(end mining.Endorsement) bool {
@@ -175,13 +239,14 @@ func (s service) isKO(end mining.Endorsement) bool {
 }
 
 func (s service) verifyEndorsementSignatures(end mining.Endorsement) error {
+	vPool := end.MasterValidation().ValidationPool()
 
-	if err := s.sigVerif.VerifyValidationSignature(end.MasterValidation().ProofOfWorkValidation()); err != nil {
+	if err := s.sigVerif.VerifyValidationSignature(end.TransactionHash(), vPool, end.MasterValidation().ProofOfWorkValidation()); err != nil {
 		return ErrInvalidDataMining
 	}
 
 	for _, v := range end.Validations() {
-		if err := s.sigVerif.VerifyValidationSignature(v); err != nil {
+		if err := s.sigVerif.VerifyValidationSignature(end.TransactionHash(), vPool, v); err != nil {
 			return ErrInvalidDataMining
 		}
 	}
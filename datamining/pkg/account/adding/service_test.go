@@ -12,29 +12,36 @@ import (
 
 	"github.com/uniris/uniris-core/datamining/pkg/account"
 	"github.com/uniris/uniris-core/datamining/pkg/account/listing"
+	"github.com/uniris/uniris-core/datamining/pkg/emitter"
+	emadding "github.com/uniris/uniris-core/datamining/pkg/emitter/adding"
+	emlisting "github.com/uniris/uniris-core/datamining/pkg/emitter/listing"
 	"github.com/uniris/uniris-core/datamining/pkg/mining"
+	"github.com/uniris/uniris-core/datamining/pkg/system"
+	"github.com/uniris/uniris-core/datamining/pkg/transport/mem"
 )
 
 /*
 Scenario: Store a keychain
 	Given a data data
 	When I want to store a keychain
-	Then the wallet is stored on the database
+	Then the wallet is stored on the database and its storage is notified
 */
 func TestStoreKeychain(t *testing.T) {
 	repo := &databasemock{}
 
+	notif := mem.NewNotifier()
+
 	lister := listing.NewService(repo)
-	s := NewService(mockAiClient{}, repo, lister, mockSigVerfier{}, mockHasher{})
+	s := NewService(mockAiClient{}, repo, lister, &mockEmAdder{}, mockSigVerfier{}, mockHasher{}, notif)
 
 	end := mining.NewEndorsement(
 		"", "hash",
-		mining.NewMasterValidation([]string{}, "key1", mining.NewValidation(mining.ValidationOK, time.Now(), "robotkey", "sig")),
+		mining.NewMasterValidation([]string{}, "key1", mining.NewValidation(mining.ValidationOK, time.Now(), "robotkey", "sig"), []string{"validator key"}),
 		[]mining.Validation{
 			mining.NewValidation(mining.ValidationOK, time.Now(), "pub", "sig"),
 		},
 	)
-	prop := datamining.NewProposal(datamining.NewProposedKeyPair("enc pv key", "pub key"))
+	prop := datamining.NewProposal(datamining.NewProposedKeyPair("enc pv key", "pub key", time.Time{}))
 	kc := account.NewKeychain("enc addr", "enc wallet", "id pub", prop, "id sig", "em sig")
 	eKc := account.NewEndorsedKeychain("addr", kc, end)
 
@@ -44,6 +51,87 @@ func TestStoreKeychain(t *testing.T) {
 	assert.Equal(t, "addr", repo.keychains[0].Address())
 	assert.Equal(t, "hash", repo.keychains[0].Endorsement().TransactionHash())
 	assert.Equal(t, "enc pv key", repo.keychains[0].Proposal().SharedEmitterKeyPair().EncryptedPrivateKey())
+
+	assert.Equal(t, []interface{}{datamining.TransactionStored{TransactionHash: "hash", TransactionType: "Keychain"}}, notif.Events())
+}
+
+/*
+Scenario: Store a keychain endorsing its shared emitter keys proposal
+	Given a keychain with a proposal of shared emitter keys
+	When I store the keychain
+	Then the proposal is endorsed by the ID which signed the keychain
+*/
+func TestStoreKeychainProposeSharedEmitterKeys(t *testing.T) {
+	repo := &databasemock{}
+	emAdder := &mockEmAdder{}
+	s := NewService(mockAiClient{}, repo, listing.NewService(repo), emAdder, mockSigVerfier{}, mockHasher{}, mem.NewNotifier())
+
+	end := mining.NewEndorsement(
+		"", "hash",
+		mining.NewMasterValidation([]string{}, "key1", mining.NewValidation(mining.ValidationOK, time.Now(), "robotkey", "sig"), []string{"validator key"}),
+		[]mining.Validation{
+			mining.NewValidation(mining.ValidationOK, time.Now(), "pub", "sig"),
+		},
+	)
+	prop := datamining.NewProposal(datamining.NewProposedKeyPair("enc pv key", "pub key", time.Time{}))
+	kc := account.NewKeychain("enc addr", "enc wallet", "id pub", prop, "id sig", "em sig")
+
+	assert.Nil(t, s.StoreKeychain(account.NewEndorsedKeychain("addr", kc, end)))
+	assert.Len(t, emAdder.proposals, 1)
+	assert.Equal(t, "pub key", emAdder.proposals[0].PublicKey)
+	assert.Equal(t, "enc pv key", emAdder.proposals[0].EncryptedPrivateKey)
+	assert.Equal(t, "id pub", emAdder.endorsers[0])
+}
+
+/*
+Scenario: Activate a shared emitter keys proposal from stored transactions
+	Given a rotation requiring two endorsements and transactions mined with the current shared emitter key as proof of work
+	When an ID and the keychain of another ID propose the same new shared emitter keypair
+	Then each ID endorses the proposal once and the new keypair becomes the current one at its activation date
+*/
+func TestStoreTransactionsActivateSharedEmitterKeys(t *testing.T) {
+	repo := &databasemock{sharedKeys: []emitter.SharedKeyPair{emitter.SharedKeyPair{PublicKey: "shared pub"}}}
+	emLister := emlisting.NewService(repo)
+	conf := system.UnirisConfig{
+		SharedKeys: system.SharedKeys{
+			EmRotation: system.KeyRotation{MinEndorsements: 2, GracePeriod: time.Hour},
+		},
+	}
+	emAdder := emadding.NewService(repo, emLister, conf)
+	s := NewService(mockAiClient{}, repo, listing.NewService(repo), emAdder, mockSigVerfier{}, mockHasher{}, mem.NewNotifier())
+
+	//The proof of work key is the shared emitter key matching the emitter signature
+	end := mining.NewEndorsement(
+		"", "hash",
+		mining.NewMasterValidation([]string{}, "shared pub", mining.NewValidation(mining.ValidationOK, time.Now(), "robotkey", "sig"), []string{"validator key"}),
+		[]mining.Validation{
+			mining.NewValidation(mining.ValidationOK, time.Now(), "pub", "sig"),
+		},
+	)
+	prop := datamining.NewProposal(datamining.NewProposedKeyPair("enc pv key", "new pub", time.Now().Add(200*time.Millisecond)))
+	noProp := datamining.NewProposal(datamining.NewProposedKeyPair("", "", time.Time{}))
+
+	assert.Nil(t, s.StoreID(account.NewEndorsedID(account.NewID("hash", "enc addr", "enc addr", "enc aes key", "id pub 1", prop, "id sig", "em sig"), end)))
+	assert.Nil(t, s.StoreKeychain(account.NewEndorsedKeychain("addr 1", account.NewKeychain("enc addr", "enc wallet", "id pub 1", prop, "id sig", "em sig"), end)))
+	assert.Len(t, repo.proposals, 1)
+	assert.Equal(t, []string{"id pub 1"}, repo.proposals[0].Endorsers)
+
+	assert.Nil(t, s.StoreID(account.NewEndorsedID(account.NewID("hash", "enc addr", "enc addr", "enc aes key", "id pub 2", noProp, "id sig", "em sig"), end)))
+	assert.Nil(t, s.StoreKeychain(account.NewEndorsedKeychain("addr 2", account.NewKeychain("enc addr", "enc wallet", "id pub 2", prop, "id sig", "em sig"), end)))
+	assert.Empty(t, repo.proposals)
+
+	current, _ := emLister.ListSharedEmitterKeyPairs()
+	assert.Len(t, current, 1)
+	assert.Equal(t, "shared pub", current[0].PublicKey)
+
+	time.Sleep(300 * time.Millisecond)
+
+	current, _ = emLister.ListSharedEmitterKeyPairs()
+	assert.Len(t, current, 1)
+	assert.Equal(t, "new pub", current[0].PublicKey)
+	previous, _ := emLister.ListPreviousSharedEmitterKeyPairs()
+	assert.Len(t, previous, 1)
+	assert.Equal(t, "shared pub", previous[0].KeyPair.PublicKey)
 }
 
 /*
@@ -55,17 +143,19 @@ Scenario: Store a keychain with master validation KO
 func TestStoreKeychainWithMasterValidKO(t *testing.T) {
 	repo := &databasemock{}
 
+	notif := mem.NewNotifier()
+
 	lister := listing.NewService(repo)
-	s := NewService(mockAiClient{}, repo, lister, mockSigVerfier{}, mockHasher{})
+	s := NewService(mockAiClient{}, repo, lister, &mockEmAdder{}, mockSigVerfier{}, mockHasher{}, notif)
 
 	end := mining.NewEndorsement(
 		"", "hash",
-		mining.NewMasterValidation([]string{}, "key1", mining.NewValidation(mining.ValidationKO, time.Now(), "robotkey", "sig")),
+		mining.NewMasterValidation([]string{}, "key1", mining.NewValidation(mining.ValidationKO, time.Now(), "robotkey", "sig"), []string{"validator key"}),
 		[]mining.Validation{
 			mining.NewValidation(mining.ValidationOK, time.Now(), "pub", "sig"),
 		},
 	)
-	prop := datamining.NewProposal(datamining.NewProposedKeyPair("enc pv key", "pub key"))
+	prop := datamining.NewProposal(datamining.NewProposedKeyPair("enc pv key", "pub key", time.Time{}))
 	kc := account.NewKeychain("enc addr", "enc wallet", "id pub", prop, "id sig", "em sig")
 	eKC := account.NewEndorsedKeychain("addr", kc, end)
 
@@ -76,6 +166,8 @@ func TestStoreKeychainWithMasterValidKO(t *testing.T) {
 	assert.Len(t, repo.keychainsKO, 1)
 	assert.Equal(t, "addr", repo.keychainsKO[0].Address())
 	assert.Equal(t, "hash", repo.keychainsKO[0].Endorsement().TransactionHash())
+
+	assert.Equal(t, []interface{}{datamining.TransactionRejected{TransactionHash: "hash", TransactionType: "Keychain"}}, notif.Events())
 }
 
 /*
@@ -88,17 +180,17 @@ func TestStoreKeychainWithOneSlaveValidKO(t *testing.T) {
 	repo := &databasemock{}
 
 	lister := listing.NewService(repo)
-	s := NewService(mockAiClient{}, repo, lister, mockSigVerfier{}, mockHasher{})
+	s := NewService(mockAiClient{}, repo, lister, &mockEmAdder{}, mockSigVerfier{}, mockHasher{}, mem.NewNotifier())
 
 	end := mining.NewEndorsement(
 		"", "hash",
-		mining.NewMasterValidation([]string{}, "key1", mining.NewValidation(mining.ValidationOK, time.Now(), "robotkey", "sig")),
+		mining.NewMasterValidation([]string{}, "key1", mining.NewValidation(mining.ValidationOK, time.Now(), "robotkey", "sig"), []string{"validator key"}),
 		[]mining.Validation{
 			mining.NewValidation(mining.ValidationOK, time.Now(), "pub", "sig"),
 			mining.NewValidation(mining.ValidationKO, time.Now(), "pub", "sig"),
 		},
 	)
-	prop := datamining.NewProposal(datamining.NewProposedKeyPair("enc pv key", "pub key"))
+	prop := datamining.NewProposal(datamining.NewProposedKeyPair("enc pv key", "pub key", time.Time{}))
 	kc := account.NewKeychain("enc addr", "enc wallet", "id pub", prop, "id sig", "em sig")
 	eKC := account.NewEndorsedKeychain("addr", kc, end)
 
@@ -120,17 +212,17 @@ Scenario: Store a keychain with a invalid last transaction hash
 func TestInvalidLastTransactionKeychain(t *testing.T) {
 	repo := &databasemock{}
 	lister := listing.NewService(repo)
-	s := NewService(mockAiClient{}, repo, lister, mockSigVerfier{}, mockHasher{})
+	s := NewService(mockAiClient{}, repo, lister, &mockEmAdder{}, mockSigVerfier{}, mockHasher{}, mem.NewNotifier())
 
 	end1 := mining.NewEndorsement(
 		"", "hash",
-		mining.NewMasterValidation([]string{}, "key1", mining.NewValidation(mining.ValidationOK, time.Now(), "robotkey", "sig")),
+		mining.NewMasterValidation([]string{}, "key1", mining.NewValidation(mining.ValidationOK, time.Now(), "robotkey", "sig"), []string{"validator key"}),
 		[]mining.Validation{
 			mining.NewValidation(mining.ValidationOK, time.Now(), "pub", "sig"),
 		},
 	)
 
-	prop := datamining.NewProposal(datamining.NewProposedKeyPair("enc pv key", "pub key"))
+	prop := datamining.NewProposal(datamining.NewProposedKeyPair("enc pv key", "pub key", time.Time{}))
 	kc := account.NewKeychain("enc addr", "enc wallet", "id pub", prop, "id sig", "em sig")
 	eKc1 := account.NewEndorsedKeychain("addr", kc, end1)
 
@@ -138,7 +230,7 @@ func TestInvalidLastTransactionKeychain(t *testing.T) {
 
 	end2 := mining.NewEndorsement(
 		"bad last hash", "hash",
-		mining.NewMasterValidation([]string{}, "key1", mining.NewValidation(mining.ValidationOK, time.Now(), "robotkey", "sig")),
+		mining.NewMasterValidation([]string{}, "key1", mining.NewValidation(mining.ValidationOK, time.Now(), "robotkey", "sig"), []string{"validator key"}),
 		[]mining.Validation{
 			mining.NewValidation(mining.ValidationOK, time.Now(), "pub", "sig"),
 		},
@@ -150,7 +242,7 @@ func TestInvalidLastTransactionKeychain(t *testing.T) {
 
 	end3 := mining.NewEndorsement(
 		"", "hash",
-		mining.NewMasterValidation([]string{}, "key1", mining.NewValidation(mining.ValidationOK, time.Now(), "robotkey", "sig")),
+		mining.NewMasterValidation([]string{}, "key1", mining.NewValidation(mining.ValidationOK, time.Now(), "robotkey", "sig"), []string{"validator key"}),
 		[]mining.Validation{
 			mining.NewValidation(mining.ValidationOK, time.Now(), "pub", "sig"),
 		},
@@ -170,14 +262,14 @@ Scenario: Store a keychain with a zero
 func TestStoreKeychainWithZeroValidations(t *testing.T) {
 	repo := &databasemock{}
 	lister := listing.NewService(repo)
-	s := NewService(mockAiClient{}, repo, lister, mockSigVerfier{}, mockHasher{})
+	s := NewService(mockAiClient{}, repo, lister, &mockEmAdder{}, mockSigVerfier{}, mockHasher{}, mem.NewNotifier())
 
 	end := mining.NewEndorsement(
 		"", "hash",
-		mining.NewMasterValidation([]string{}, "key1", mining.NewValidation(mining.ValidationOK, time.Now(), "robotkey", "sig")),
+		mining.NewMasterValidation([]string{}, "key1", mining.NewValidation(mining.ValidationOK, time.Now(), "robotkey", "sig"), []string{"validator key"}),
 		[]mining.Validation{},
 	)
-	prop := datamining.NewProposal(datamining.NewProposedKeyPair("enc pv key", "pub key"))
+	prop := datamining.NewProposal(datamining.NewProposedKeyPair("enc pv key", "pub key", time.Time{}))
 	kc := account.NewKeychain("enc addr", "enc wallet", "id pub", prop, "id sig", "em sig")
 	eKC := account.NewEndorsedKeychain("addr", kc, end)
 
@@ -194,17 +286,17 @@ Scenario: Store a keychain with a invalid transaction hash
 func TestStoreKeychainWithInvalidTxHash(t *testing.T) {
 	repo := &databasemock{}
 	lister := listing.NewService(repo)
-	s := NewService(mockAiClient{}, repo, lister, mockSigVerfier{}, mockHasher{})
+	s := NewService(mockAiClient{}, repo, lister, &mockEmAdder{}, mockSigVerfier{}, mockHasher{}, mem.NewNotifier())
 
 	end := mining.NewEndorsement(
 		"", "bad hash",
-		mining.NewMasterValidation([]string{}, "key1", mining.NewValidation(mining.ValidationOK, time.Now(), "robotkey", "sig")),
+		mining.NewMasterValidation([]string{}, "key1", mining.NewValidation(mining.ValidationOK, time.Now(), "robotkey", "sig"), []string{"validator key"}),
 		[]mining.Validation{
 			mining.NewValidation(mining.ValidationOK, time.Now(), "pub", "sig"),
 		},
 	)
 
-	prop := datamining.NewProposal(datamining.NewProposedKeyPair("enc pv key", "pub key"))
+	prop := datamining.NewProposal(datamining.NewProposedKeyPair("enc pv key", "pub key", time.Time{}))
 	kc := account.NewKeychain("enc addr", "enc wallet", "id pub", prop, "id sig", "em sig")
 	eKC := account.NewEndorsedKeychain("addr", kc, end)
 
@@ -220,17 +312,18 @@ Scenario: Store an ID
 func TestStoreID(t *testing.T) {
 	repo := &databasemock{}
 	lister := listing.NewService(repo)
-	s := NewService(mockAiClient{}, repo, lister, mockSigVerfier{}, mockHasher{})
+	emAdder := &mockEmAdder{}
+	s := NewService(mockAiClient{}, repo, lister, emAdder, mockSigVerfier{}, mockHasher{}, mem.NewNotifier())
 
 	end := mining.NewEndorsement(
 		"", "hash",
-		mining.NewMasterValidation([]string{}, "key1", mining.NewValidation(mining.ValidationOK, time.Now(), "robotkey", "sig")),
+		mining.NewMasterValidation([]string{}, "key1", mining.NewValidation(mining.ValidationOK, time.Now(), "robotkey", "sig"), []string{"validator key"}),
 		[]mining.Validation{
 			mining.NewValidation(mining.ValidationOK, time.Now(), "pub", "sig"),
 		},
 	)
 
-	prop := datamining.NewProposal(datamining.NewProposedKeyPair("enc pv key", "pub key"))
+	prop := datamining.NewProposal(datamining.NewProposedKeyPair("enc pv key", "pub key", time.Time{}))
 	id := account.NewID("hash", "enc addr robot", "enc addr person", "enc aes key", "id pub", prop, "id sig", "em pub")
 	eID := account.NewEndorsedID(id, end)
 	err := s.StoreID(eID)
@@ -240,7 +333,7 @@ func TestStoreID(t *testing.T) {
 	assert.Equal(t, 1, l)
 	assert.Equal(t, "hash", repo.ids[0].Hash())
 	assert.Equal(t, "enc pv key", repo.ids[0].Proposal().SharedEmitterKeyPair().EncryptedPrivateKey())
-
+	assert.Equal(t, []string{"id pub"}, emAdder.authorized)
 }
 
 /*
@@ -252,15 +345,15 @@ Scenario: Store a id with a zero
 func TestStoreIDWithZeroValidations(t *testing.T) {
 	repo := &databasemock{}
 	lister := listing.NewService(repo)
-	s := NewService(mockAiClient{}, repo, lister, mockSigVerfier{}, mockHasher{})
+	s := NewService(mockAiClient{}, repo, lister, &mockEmAdder{}, mockSigVerfier{}, mockHasher{}, mem.NewNotifier())
 
 	end := mining.NewEndorsement(
 		"", "hash",
-		mining.NewMasterValidation([]string{}, "key1", mining.NewValidation(mining.ValidationOK, time.Now(), "robotkey", "sig")),
+		mining.NewMasterValidation([]string{}, "key1", mining.NewValidation(mining.ValidationOK, time.Now(), "robotkey", "sig"), []string{"validator key"}),
 		[]mining.Validation{},
 	)
 
-	prop := datamining.NewProposal(datamining.NewProposedKeyPair("enc pv key", "pub key"))
+	prop := datamining.NewProposal(datamining.NewProposedKeyPair("enc pv key", "pub key", time.Time{}))
 	id := account.NewID("hash", "enc addr robot", "enc addr person", "enc aes key", "id pub", prop, "id sig", "em pub")
 	eID := account.NewEndorsedID(id, end)
 
@@ -278,17 +371,17 @@ func TestStoreIDWithMasterValidKO(t *testing.T) {
 	repo := &databasemock{}
 
 	lister := listing.NewService(repo)
-	s := NewService(mockAiClient{}, repo, lister, mockSigVerfier{}, mockHasher{})
+	s := NewService(mockAiClient{}, repo, lister, &mockEmAdder{}, mockSigVerfier{}, mockHasher{}, mem.NewNotifier())
 
 	end := mining.NewEndorsement(
 		"", "hash",
-		mining.NewMasterValidation([]string{}, "key1", mining.NewValidation(mining.ValidationKO, time.Now(), "robotkey", "sig")),
+		mining.NewMasterValidation([]string{}, "key1", mining.NewValidation(mining.ValidationKO, time.Now(), "robotkey", "sig"), []string{"validator key"}),
 		[]mining.Validation{
 			mining.NewValidation(mining.ValidationOK, time.Now(), "pub", "sig"),
 		},
 	)
 
-	prop := datamining.NewProposal(datamining.NewProposedKeyPair("enc pv key", "pub key"))
+	prop := datamining.NewProposal(datamining.NewProposedKeyPair("enc pv key", "pub key", time.Time{}))
 	id := account.NewID("hash", "enc addr robot", "enc addr person", "enc aes key", "id pub", prop, "id sig", "em pub")
 	eID := account.NewEndorsedID(id, end)
 
@@ -310,16 +403,16 @@ func TestStoreIDWithOneSlaveValidKO(t *testing.T) {
 	repo := &databasemock{}
 
 	lister := listing.NewService(repo)
-	s := NewService(mockAiClient{}, repo, lister, mockSigVerfier{}, mockHasher{})
+	s := NewService(mockAiClient{}, repo, lister, &mockEmAdder{}, mockSigVerfier{}, mockHasher{}, mem.NewNotifier())
 
 	end := mining.NewEndorsement(
 		"", "hash",
-		mining.NewMasterValidation([]string{}, "key1", mining.NewValidation(mining.ValidationOK, time.Now(), "robotkey", "sig")),
+		mining.NewMasterValidation([]string{}, "key1", mining.NewValidation(mining.ValidationOK, time.Now(), "robotkey", "sig"), []string{"validator key"}),
 		[]mining.Validation{
 			mining.NewValidation(mining.ValidationKO, time.Now(), "pub", "sig"),
 		},
 	)
-	prop := datamining.NewProposal(datamining.NewProposedKeyPair("enc pv key", "pub key"))
+	prop := datamining.NewProposal(datamining.NewProposedKeyPair("enc pv key", "pub key", time.Time{}))
 	id := account.NewID("hash", "enc addr robot", "enc addr person", "enc aes key", "id pub", prop, "id sig", "em pub")
 	eID := account.NewEndorsedID(id, end)
 
@@ -340,16 +433,16 @@ Scenario: Store a id with a invalid transaction hash
 func TestStoreIDWithInvalidTxHash(t *testing.T) {
 	repo := &databasemock{}
 	lister := listing.NewService(repo)
-	s := NewService(mockAiClient{}, repo, lister, mockSigVerfier{}, mockHasher{})
+	s := NewService(mockAiClient{}, repo, lister, &mockEmAdder{}, mockSigVerfier{}, mockHasher{}, mem.NewNotifier())
 
 	end := mining.NewEndorsement(
 		"", "bad hash",
-		mining.NewMasterValidation([]string{}, "key1", mining.NewValidation(mining.ValidationOK, time.Now(), "robotkey", "sig")),
+		mining.NewMasterValidation([]string{}, "key1", mining.NewValidation(mining.ValidationOK, time.Now(), "robotkey", "sig"), []string{"validator key"}),
 		[]mining.Validation{
 			mining.NewValidation(mining.ValidationKO, time.Now(), "pub", "sig"),
 		},
 	)
-	prop := datamining.NewProposal(datamining.NewProposedKeyPair("enc pv key", "pub key"))
+	prop := datamining.NewProposal(datamining.NewProposedKeyPair("enc pv key", "pub key", time.Time{}))
 	id := account.NewID("hash", "enc addr robot", "enc addr person", "enc aes key", "id pub", prop, "id sig", "em pub")
 	eID := account.NewEndorsedID(id, end)
 
@@ -366,7 +459,7 @@ func TestEndorsementWithBadMasterSignature(t *testing.T) {
 
 	end := mining.NewEndorsement(
 		"", "hash",
-		mining.NewMasterValidation([]string{}, "key1", mining.NewValidation(mining.ValidationOK, time.Now(), "robotkey", "invalid sig")),
+		mining.NewMasterValidation([]string{}, "key1", mining.NewValidation(mining.ValidationOK, time.Now(), "robotkey", "invalid sig"), []string{"validator key"}),
 		[]mining.Validation{
 			mining.NewValidation(mining.ValidationKO, time.Now(), "pub", "sig"),
 		},
@@ -379,7 +472,7 @@ func TestEndorsementWithBadMasterSignature(t *testing.T) {
 
 	end2 := mining.NewEndorsement(
 		"", "hash",
-		mining.NewMasterValidation([]string{}, "key1", mining.NewValidation(mining.ValidationOK, time.Now(), "robotkey", "invalid sig")),
+		mining.NewMasterValidation([]string{}, "key1", mining.NewValidation(mining.ValidationOK, time.Now(), "robotkey", "invalid sig"), []string{"validator key"}),
 		[]mining.Validation{
 			mining.NewValidation(mining.ValidationKO, time.Now(), "pub", "sig"),
 		},
@@ -392,6 +485,10 @@ type databasemock struct {
 	keychains   []account.EndorsedKeychain
 	idsKO       []account.EndorsedID
 	keychainsKO []account.EndorsedKeychain
+	sharedKeys  []emitter.SharedKeyPair
+	retired     []emitter.RetiredSharedKeyPair
+	proposals   []emitter.SharedKeyPairProposal
+	auths       []emitter.Authorization
 }
 
 func (d *databasemock) StoreKeychain(kc account.EndorsedKeychain) error {
@@ -475,6 +572,98 @@ func (d *databasemock) FindLastKeychain(addr string) (account.EndorsedKeychain,
 	return nil, nil
 }
 
+func (d *databasemock) StoreSharedEmitterKeyPair(kp emitter.SharedKeyPair) error {
+	d.sharedKeys = append(d.sharedKeys, kp)
+	return nil
+}
+
+func (d *databasemock) ListSharedEmitterKeyPairs() ([]emitter.SharedKeyPair, error) {
+	return d.sharedKeys, nil
+}
+
+func (d *databasemock) ListRetiredSharedEmitterKeyPairs() ([]emitter.RetiredSharedKeyPair, error) {
+	return d.retired, nil
+}
+
+func (d *databasemock) RetireSharedEmitterKeyPairs(retirement time.Time, expiration time.Time) error {
+	for _, kp := range d.sharedKeys {
+		d.retired = append(d.retired, emitter.RetiredSharedKeyPair{KeyPair: kp, RetirementDate: retirement, ExpirationDate: expiration})
+	}
+	d.sharedKeys = make([]emitter.SharedKeyPair, 0)
+	return nil
+}
+
+func (d *databasemock) StoreSharedEmitterKeyPairProposal(p emitter.SharedKeyPairProposal) error {
+	for i, prop := range d.proposals {
+		if prop.KeyPair.PublicKey == p.KeyPair.PublicKey {
+			d.proposals[i] = p
+			return nil
+		}
+	}
+	d.proposals = append(d.proposals, p)
+	return nil
+}
+
+func (d *databasemock) FindSharedEmitterKeyPairProposal(pubKey string) (*emitter.SharedKeyPairProposal, error) {
+	for _, prop := range d.proposals {
+		if prop.KeyPair.PublicKey == pubKey {
+			return &prop, nil
+		}
+	}
+	return nil, nil
+}
+
+func (d *databasemock) RemoveSharedEmitterKeyPairProposal(pubKey string) error {
+	for i, prop := range d.proposals {
+		if prop.KeyPair.PublicKey == pubKey {
+			d.proposals = append(d.proposals[:i], d.proposals[i+1:]...)
+			return nil
+		}
+	}
+	return nil
+}
+
+func (d *databasemock) StoreEmitterAuthorization(a emitter.Authorization) error {
+	for i, auth := range d.auths {
+		if auth.PublicKey == a.PublicKey {
+			d.auths[i] = a
+			return nil
+		}
+	}
+	d.auths = append(d.auths, a)
+	return nil
+}
+
+func (d *databasemock) FindEmitterAuthorization(pubKey string) (*emitter.Authorization, error) {
+	for _, auth := range d.auths {
+		if auth.PublicKey == pubKey {
+			return &auth, nil
+		}
+	}
+	return nil, nil
+}
+
+type mockEmAdder struct {
+	proposals  []emitter.SharedKeyPair
+	endorsers  []string
+	authorized []string
+}
+
+func (a *mockEmAdder) ProposeSharedEmitterKeyPair(kp emitter.SharedKeyPair, endorser string) error {
+	a.proposals = append(a.proposals, kp)
+	a.endorsers = append(a.endorsers, endorser)
+	return nil
+}
+
+func (a *mockEmAdder) AuthorizeEmitter(pubKey string) error {
+	a.authorized = append(a.authorized, pubKey)
+	return nil
+}
+
+func (a *mockEmAdder) RevokeEmitter(pubKey string) error {
+	return nil
+}
+
 type mockSigVerfier struct{}
 
 func (v mockSigVerfier) VerifyKeychainSignatures(account.Keychain) error {
@@ -483,7 +672,7 @@ func (v mockSigVerfier) VerifyKeychainSignatures(account.Keychain) error {
 func (v mockSigVerfier) VerifyIDSignatures(account.ID) error {
 	return nil
 }
-func (v mockSigVerfier) VerifyValidationSignature(mining.Validation) error {
+func (v mockSigVerfier) VerifyValidationSignature(txHash string, vPool []string, valid mining.Validation) error {
 	return nil
 }
 
@@ -499,7 +688,7 @@ func (v mockBadSigVerfier) VerifyKeychainSignatures(account.Keychain) error {
 func (v mockBadSigVerfier) VerifyIDSignatures(account.ID) error {
 	return nil
 }
-func (v mockBadSigVerfier) VerifyValidationSignature(valid mining.Validation) error {
+func (v mockBadSigVerfier) VerifyValidationSignature(txHash string, vPool []string, valid mining.Validation) error {
 	if valid.Signature() == "invalid sig" {
 		return errors.New("invalid signature")
 	}
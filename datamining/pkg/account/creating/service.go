@@ -0,0 +1,424 @@
+package creating
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/uniris/uniris-core/datamining/pkg/mining"
+	"github.com/uniris/uniris-core/shared/pkg/parallel"
+)
+
+const (
+
+	//maxKeychainRetries is the number of times the keychain is mined again when it fails after its ID succeeded
+	maxKeychainRetries = 2
+
+	//maxIDLaunchRetries is the number of times the ID launch is tried again before the keychain already launched is abandoned
+	maxIDLaunchRetries = 2
+
+	//stepTimeout is the maximum duration a transaction of the account creation is followed before its outcome is considered as unknown
+	stepTimeout = 10 * time.Minute
+
+	//batchConcurrency is the maximum number of account creations of a batch launched at the same time
+	batchConcurrency = 8
+)
+
+//AccountCreationStatus represents the combined status of the ID and keychain transactions of an account creation
+type AccountCreationStatus int
+
+const (
+
+	//AccountCreationPending represents an account creation with transactions not finalised yet
+	AccountCreationPending AccountCreationStatus = 0
+
+	//AccountCreationSuccess represents an account creation with both transactions succeeded
+	AccountCreationSuccess AccountCreationStatus = 1
+
+	//AccountCreationFailure represents an account creation with a failed ID transaction
+	AccountCreationFailure AccountCreationStatus = 2
+
+	//AccountCreationIncomplete represents an account creation with an ID stored without its keychain
+	AccountCreationIncomplete AccountCreationStatus = 3
+
+	//AccountCreationUnknown represents an account creation not orchestrated by the peer
+	AccountCreationUnknown AccountCreationStatus = 4
+
+	//AccountCreationAborted represents an account creation whose ID could not be launched, its keychain being abandoned
+	//
+	//A keychain transaction cannot be reverted: when it succeeds, the keychain is stored without ID and no account leads to it.
+	//Creating the account again with the same encrypted keychain mines a new version at the same address,
+	//so the orphan keychain becomes a previous version of the keychain of the new account
+	AccountCreationAborted AccountCreationStatus = 5
+
+	//AccountCreationTimeout represents an account creation with a transaction not finalised before the timeout, its outcome being unknown
+	AccountCreationTimeout AccountCreationStatus = 6
+)
+
+//TransactionResult represents a transaction launched for an account creation
+type TransactionResult struct {
+	TransactionHash  string
+	MasterPeerIP     string
+	EncryptedAddress string
+	Signature        string
+}
+
+//AccountCreation represents the transactions launched for an account creation
+type AccountCreation struct {
+	ID       TransactionResult
+	Keychain TransactionResult
+}
+
+//AccountCreationRequest represents the encrypted data of an account to create
+type AccountCreationRequest struct {
+	EncryptedID       string
+	EncryptedKeychain string
+}
+
+//AccountCreationOutcome represents the result of an account creation of a batch
+type AccountCreationOutcome struct {
+	Creation AccountCreation
+	Err      error
+}
+
+//AccountCreationState represents the progress of an account creation
+type AccountCreationState struct {
+	Status         AccountCreationStatus
+	IDStatus       mining.TransactionStatus
+	KeychainStatus mining.TransactionStatus
+
+	//Keychain is the keychain transaction followed, replaced when the keychain is mined again
+	Keychain TransactionResult
+}
+
+//Saga represents the persisted progress of an account creation, allowing its orchestration to be resumed
+type Saga struct {
+	ID                TransactionResult
+	EncryptedKeychain string
+	KeychainRetries   int
+	State             AccountCreationState
+}
+
+//Hash returns the hash identifying the account creation: its ID transaction hash, or the keychain one when the ID was never launched
+func (s Saga) Hash() string {
+	if s.ID.TransactionHash != "" {
+		return s.ID.TransactionHash
+	}
+	return s.State.Keychain.TransactionHash
+}
+
+//Repository defines methods to persist the account creations
+type Repository interface {
+
+	//StoreAccountCreation stores the saga of an account creation, replacing the one with the same hash
+	StoreAccountCreation(Saga) error
+
+	//FindAccountCreation retrieves the saga of an account creation from its hash, nil when it does not exist
+	FindAccountCreation(hash string) (*Saga, error)
+
+	//ListPendingAccountCreations lists the sagas of the account creations not finished
+	ListPendingAccountCreations() ([]Saga, error)
+}
+
+//TransactionLauncher defines methods to launch and follow the transactions of an account creation
+type TransactionLauncher interface {
+
+	//LaunchID asks the master peer to lead the ID mining
+	LaunchID(encID string) (TransactionResult, error)
+
+	//LaunchKeychain asks the master peer to lead the keychain mining
+	LaunchKeychain(encKeychain string) (TransactionResult, error)
+
+	//WatchStatus streams the status transitions of a transaction until its final status or the end of the context
+	WatchStatus(ctx context.Context, encAddr string, txHash string) (<-chan mining.TransactionStatus, error)
+
+	//PrepareBatch decrypts the account creations of a batch and selects the pools of their transactions at once
+	//
+	//The returned launcher launches the prepared transactions without decrypting them nor selecting their pools again
+	PrepareBatch(reqs []AccountCreationRequest) TransactionLauncher
+}
+
+//Service defines methods to orchestrate the account creations
+type Service interface {
+
+	//CreateAccount launches the ID and the keychain transactions of an account
+	//
+	//The transactions are then followed in background: a keychain failing after its ID succeeded is mined again,
+	//and the account creation is marked as incomplete if the keychain cannot be stored
+	CreateAccount(encID string, encKeychain string) (AccountCreation, error)
+
+	//CreateAccounts launches the account creations of a batch with a bounded concurrency
+	//
+	//The decryption and the pool selection of the transactions are done once for the whole batch.
+	//The outcomes are returned in the order of the requests, a failing account creation does not stop the others
+	CreateAccounts(reqs []AccountCreationRequest) []AccountCreationOutcome
+
+	//GetAccountCreationStatus returns the progress of an account creation identified by its ID transaction hash
+	GetAccountCreationStatus(idTxHash string) (AccountCreationState, error)
+
+	//ResumeAccountCreations follows again the account creations not finished, after a restart of the peer
+	//
+	//The account creations are only resumed when the repository outlives the process
+	ResumeAccountCreations() error
+}
+
+type service struct {
+	launcher TransactionLauncher
+	repo     Repository
+	mu       sync.Mutex
+	timeout  time.Duration
+}
+
+//NewService creates an account creation service persisting the sagas in the repository
+func NewService(launcher TransactionLauncher, repo Repository) Service {
+	return &service{
+		launcher: launcher,
+		repo:     repo,
+		timeout:  stepTimeout,
+	}
+}
+
+func (s *service) CreateAccount(encID string, encKeychain string) (AccountCreation, error) {
+	return s.createAccount(s.launcher, encID, encKeychain)
+}
+
+func (s *service) CreateAccounts(reqs []AccountCreationRequest) []AccountCreationOutcome {
+	launcher := s.launcher.PrepareBatch(reqs)
+
+	outcomes := make([]AccountCreationOutcome, len(reqs))
+	parallel.Each(len(reqs), batchConcurrency, func(i int) {
+		creation, err := s.createAccount(launcher, reqs[i].EncryptedID, reqs[i].EncryptedKeychain)
+		outcomes[i] = AccountCreationOutcome{
+			Creation: creation,
+			Err:      err,
+		}
+	})
+
+	return outcomes
+}
+
+//createAccount launches the transactions of an account with the launcher, then orchestrates them in background
+func (s *service) createAccount(launcher TransactionLauncher, encID string, encKeychain string) (AccountCreation, error) {
+	//The keychain is launched first, so an ID is never launched without its keychain
+	keychain, err := launcher.LaunchKeychain(encKeychain)
+	if err != nil {
+		return AccountCreation{}, err
+	}
+
+	id, err := s.launchID(launcher, encID)
+	if err != nil {
+		s.abandonKeychain(keychain, encKeychain)
+		return AccountCreation{}, err
+	}
+
+	saga := Saga{
+		ID:                id,
+		EncryptedKeychain: encKeychain,
+		State: AccountCreationState{
+			Status:         AccountCreationPending,
+			IDStatus:       mining.TransactionPending,
+			KeychainStatus: mining.TransactionPending,
+			Keychain:       keychain,
+		},
+	}
+	s.mu.Lock()
+	err = s.repo.StoreAccountCreation(saga)
+	s.mu.Unlock()
+	if err != nil {
+		return AccountCreation{}, err
+	}
+
+	go s.orchestrate(saga)
+
+	return AccountCreation{
+		ID:       id,
+		Keychain: keychain,
+	}, nil
+}
+
+func (s *service) GetAccountCreationStatus(idTxHash string) (AccountCreationState, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	saga, err := s.repo.FindAccountCreation(idTxHash)
+	if err != nil {
+		return AccountCreationState{}, err
+	}
+	if saga == nil {
+		return AccountCreationState{
+			Status:         AccountCreationUnknown,
+			IDStatus:       mining.TransactionUnknown,
+			KeychainStatus: mining.TransactionUnknown,
+		}, nil
+	}
+	return saga.State, nil
+}
+
+func (s *service) ResumeAccountCreations() error {
+	s.mu.Lock()
+	sagas, err := s.repo.ListPendingAccountCreations()
+	s.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	for _, saga := range sagas {
+		log.Printf("Account creation %s resumed", saga.Hash())
+		go s.orchestrate(saga)
+	}
+	return nil
+}
+
+//launchID launches the ID, trying again when the master peer cannot lead it
+func (s *service) launchID(launcher TransactionLauncher, encID string) (id TransactionResult, err error) {
+	for attempt := 0; attempt <= maxIDLaunchRetries; attempt++ {
+		if id, err = launcher.LaunchID(encID); err == nil {
+			return id, nil
+		}
+		log.Printf("ID launch error (%d/%d): %s", attempt+1, maxIDLaunchRetries+1, err.Error())
+	}
+	return TransactionResult{}, err
+}
+
+//abandonKeychain records a keychain launched without its ID, so the orphan keychain can be traced until its final status
+//
+//The keychain is not compensated by another transaction, see AccountCreationAborted
+func (s *service) abandonKeychain(keychain TransactionResult, encKeychain string) {
+	log.Printf("ID not launched, keychain %s abandoned", keychain.TransactionHash)
+
+	saga := Saga{
+		EncryptedKeychain: encKeychain,
+		State: AccountCreationState{
+			Status:         AccountCreationAborted,
+			IDStatus:       mining.TransactionUnknown,
+			KeychainStatus: mining.TransactionPending,
+			Keychain:       keychain,
+		},
+	}
+	s.mu.Lock()
+	err := s.repo.StoreAccountCreation(saga)
+	s.mu.Unlock()
+	if err != nil {
+		log.Printf("Account creation storage error: %s", err.Error())
+		return
+	}
+
+	go s.await(saga.Hash(), keychain, s.setKeychainStatus)
+}
+
+//orchestrate waits for the final status of both transactions and compensates a keychain failing after its ID succeeded
+func (s *service) orchestrate(saga Saga) {
+	hash := saga.Hash()
+
+	keychainStatus := make(chan mining.TransactionStatus, 1)
+	go func() {
+		keychainStatus <- s.await(hash, saga.State.Keychain, s.setKeychainStatus)
+	}()
+
+	idStatus := s.await(hash, saga.ID, s.setIDStatus)
+	kcStatus := <-keychainStatus
+
+	switch idStatus {
+	case mining.TransactionFailure:
+		s.finish(hash, AccountCreationFailure)
+		return
+	case mining.TransactionUnknown:
+		log.Printf("ID %s not finalised before the timeout", hash)
+		s.finish(hash, AccountCreationTimeout)
+		return
+	}
+
+	for retry := saga.KeychainRetries + 1; kcStatus == mining.TransactionFailure && retry <= maxKeychainRetries; retry++ {
+		log.Printf("Keychain of %s failed, mining it again (%d/%d)", hash, retry, maxKeychainRetries)
+
+		relaunched, err := s.launcher.LaunchKeychain(saga.EncryptedKeychain)
+		s.update(hash, func(saga *Saga) {
+			saga.KeychainRetries = retry
+			if err == nil {
+				saga.State.Keychain = relaunched
+				saga.State.KeychainStatus = mining.TransactionPending
+			}
+		})
+		if err != nil {
+			log.Printf("Keychain mining error: %s", err.Error())
+			continue
+		}
+		kcStatus = s.await(hash, relaunched, s.setKeychainStatus)
+	}
+
+	switch kcStatus {
+	case mining.TransactionSuccess:
+		s.finish(hash, AccountCreationSuccess)
+	case mining.TransactionFailure:
+		log.Printf("ID %s stored without its keychain, account creation incomplete", hash)
+		s.finish(hash, AccountCreationIncomplete)
+	default:
+		log.Printf("Keychain of %s not finalised before the timeout", hash)
+		s.finish(hash, AccountCreationTimeout)
+	}
+}
+
+//await follows a transaction until its final status
+//
+//The status is unknown when the transaction is not finalised before the timeout, as it can still succeed
+func (s *service) await(hash string, tx TransactionResult, update func(string, mining.TransactionStatus)) mining.TransactionStatus {
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	defer cancel()
+
+	statuses, err := s.launcher.WatchStatus(ctx, tx.EncryptedAddress, tx.TransactionHash)
+	if err != nil {
+		log.Printf("Account creation watch error: %s", err.Error())
+		return mining.TransactionUnknown
+	}
+
+	for status := range statuses {
+		if status == mining.TransactionUnknown {
+			continue
+		}
+		update(hash, status)
+		if status.IsFinal() {
+			return status
+		}
+	}
+
+	return mining.TransactionUnknown
+}
+
+func (s *service) setIDStatus(hash string, status mining.TransactionStatus) {
+	s.update(hash, func(saga *Saga) {
+		saga.State.IDStatus = status
+	})
+}
+
+func (s *service) setKeychainStatus(hash string, status mining.TransactionStatus) {
+	s.update(hash, func(saga *Saga) {
+		saga.State.KeychainStatus = status
+	})
+}
+
+func (s *service) finish(hash string, status AccountCreationStatus) {
+	s.update(hash, func(saga *Saga) {
+		saga.State.Status = status
+	})
+}
+
+//update applies the change to the persisted saga of the account creation
+func (s *service) update(hash string, change func(*Saga)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	saga, err := s.repo.FindAccountCreation(hash)
+	if err != nil {
+		log.Printf("Account creation storage error: %s", err.Error())
+		return
+	}
+	if saga == nil {
+		return
+	}
+
+	change(saga)
+	if err := s.repo.StoreAccountCreation(*saga); err != nil {
+		log.Printf("Account creation storage error: %s", err.Error())
+	}
+}
@@ -0,0 +1,383 @@
+package creating
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/uniris/uniris-core/datamining/pkg/mining"
+)
+
+/*
+Scenario: Create an account
+	Given an encrypted ID and an encrypted keychain
+	When I want to create the account
+	Then both transactions are launched and the account creation is pending
+*/
+func TestCreateAccount(t *testing.T) {
+	l := &mockLauncher{idStatus: mining.TransactionPending}
+	s := NewService(l, newMockRepository())
+
+	res, err := s.CreateAccount("enc id", "enc keychain")
+	assert.Nil(t, err)
+	assert.Equal(t, "id hash", res.ID.TransactionHash)
+	assert.Equal(t, "keychain hash", res.Keychain.TransactionHash)
+
+	state, err := s.GetAccountCreationStatus("id hash")
+	assert.Nil(t, err)
+	assert.Equal(t, AccountCreationPending, state.Status)
+	assert.Equal(t, "keychain hash", state.Keychain.TransactionHash)
+}
+
+/*
+Scenario: Create an account with a keychain which cannot be launched
+	Given an encrypted keychain rejected by the master peer
+	When I want to create the account
+	Then I get an error and the ID is not launched
+*/
+func TestCreateAccountKeychainNotLaunched(t *testing.T) {
+	l := &mockLauncher{keychainErr: errors.New("Unreachable master")}
+	s := NewService(l, newMockRepository())
+
+	_, err := s.CreateAccount("enc id", "enc keychain")
+	assert.Equal(t, errors.New("Unreachable master"), err)
+	assert.Equal(t, 0, l.idLaunches)
+	state, _ := s.GetAccountCreationStatus("id hash")
+	assert.Equal(t, AccountCreationUnknown, state.Status)
+}
+
+/*
+Scenario: Create an account with an ID which cannot be launched
+	Given an encrypted ID rejected by the master peers
+	When I want to create the account
+	Then the ID launch is tried again, I get an error and the keychain already launched is recorded as aborted
+*/
+func TestCreateAccountIDNotLaunched(t *testing.T) {
+	l := &mockLauncher{
+		idErr:            errors.New("Unreachable master"),
+		keychainStatuses: []mining.TransactionStatus{mining.TransactionSuccess},
+	}
+	repo := newMockRepository()
+	s := NewService(l, repo)
+
+	_, err := s.CreateAccount("enc id", "enc keychain")
+	assert.Equal(t, errors.New("Unreachable master"), err)
+	assert.Equal(t, maxIDLaunchRetries+1, l.idLaunches)
+
+	state, err := s.GetAccountCreationStatus("keychain hash")
+	assert.Nil(t, err)
+	assert.Equal(t, AccountCreationAborted, state.Status)
+	assert.Equal(t, mining.TransactionUnknown, state.IDStatus)
+
+	//The abandoned keychain is followed until its final status
+	state = waitForState(s, "keychain hash", func(state AccountCreationState) bool {
+		return state.KeychainStatus.IsFinal()
+	})
+	assert.Equal(t, mining.TransactionSuccess, state.KeychainStatus)
+}
+
+/*
+Scenario: Create a batch of accounts
+	Given several encrypted IDs and keychains with one keychain rejected
+	When I want to create the accounts
+	Then the batch is prepared once, and I get the outcomes in the order of the requests with only the rejected account failing
+*/
+func TestCreateAccounts(t *testing.T) {
+	l := &mockLauncher{idStatus: mining.TransactionPending, rejectedKeychain: "enc keychain 2"}
+	s := NewService(l, newMockRepository())
+
+	outcomes := s.CreateAccounts([]AccountCreationRequest{
+		AccountCreationRequest{EncryptedID: "enc id 1", EncryptedKeychain: "enc keychain 1"},
+		AccountCreationRequest{EncryptedID: "enc id 2", EncryptedKeychain: "enc keychain 2"},
+		AccountCreationRequest{EncryptedID: "enc id 3", EncryptedKeychain: "enc keychain 3"},
+	})
+	assert.Len(t, outcomes, 3)
+	assert.Nil(t, outcomes[0].Err)
+	assert.Equal(t, "id hash", outcomes[0].Creation.ID.TransactionHash)
+	assert.Equal(t, errors.New("Invalid keychain"), outcomes[1].Err)
+	assert.Nil(t, outcomes[2].Err)
+	assert.Equal(t, "keychain hash", outcomes[2].Creation.Keychain.TransactionHash)
+	assert.Equal(t, 2, l.idLaunches)
+	assert.Equal(t, 3, l.preparedBatch)
+}
+
+/*
+Scenario: Succeed an account creation
+	Given an ID and a keychain transactions succeeding
+	When the account creation is orchestrated
+	Then the account creation succeeds
+*/
+func TestOrchestrateAccountCreationSuccess(t *testing.T) {
+	l := &mockLauncher{
+		idStatus:         mining.TransactionSuccess,
+		keychainStatuses: []mining.TransactionStatus{mining.TransactionSuccess},
+	}
+	s := newTestService(l)
+
+	s.orchestrate(newTestSaga())
+
+	state, _ := s.GetAccountCreationStatus("id hash")
+	assert.Equal(t, AccountCreationSuccess, state.Status)
+	assert.Equal(t, mining.TransactionSuccess, state.IDStatus)
+	assert.Equal(t, mining.TransactionSuccess, state.KeychainStatus)
+	assert.Equal(t, 0, l.keychainLaunches)
+}
+
+/*
+Scenario: Mine again a keychain failing after its ID succeeded
+	Given an ID succeeding and a keychain failing once
+	When the account creation is orchestrated
+	Then the keychain is mined again and the account creation succeeds
+*/
+func TestOrchestrateAccountCreationRetryKeychain(t *testing.T) {
+	l := &mockLauncher{
+		idStatus:         mining.TransactionSuccess,
+		keychainStatuses: []mining.TransactionStatus{mining.TransactionFailure, mining.TransactionSuccess},
+		relaunchedHash:   "relaunched hash",
+	}
+	s := newTestService(l)
+
+	s.orchestrate(newTestSaga())
+
+	assert.Equal(t, 1, l.keychainLaunches)
+	state, _ := s.GetAccountCreationStatus("id hash")
+	assert.Equal(t, AccountCreationSuccess, state.Status)
+	assert.Equal(t, "relaunched hash", state.Keychain.TransactionHash)
+}
+
+/*
+Scenario: Mark an account creation as incomplete
+	Given an ID succeeding and a keychain always failing
+	When the account creation is orchestrated
+	Then the keychain is mined again until the retries limit and the account creation is incomplete
+*/
+func TestOrchestrateAccountCreationIncomplete(t *testing.T) {
+	l := &mockLauncher{
+		idStatus: mining.TransactionSuccess,
+		keychainStatuses: []mining.TransactionStatus{
+			mining.TransactionFailure,
+			mining.TransactionFailure,
+			mining.TransactionFailure,
+		},
+	}
+	s := newTestService(l)
+
+	s.orchestrate(newTestSaga())
+
+	state, _ := s.GetAccountCreationStatus("id hash")
+	assert.Equal(t, maxKeychainRetries, l.keychainLaunches)
+	assert.Equal(t, AccountCreationIncomplete, state.Status)
+	assert.Equal(t, mining.TransactionSuccess, state.IDStatus)
+	assert.Equal(t, mining.TransactionFailure, state.KeychainStatus)
+}
+
+/*
+Scenario: Fail an account creation
+	Given an ID failing
+	When the account creation is orchestrated
+	Then the keychain is not mined again and the account creation fails
+*/
+func TestOrchestrateAccountCreationFailure(t *testing.T) {
+	l := &mockLauncher{
+		idStatus:         mining.TransactionFailure,
+		keychainStatuses: []mining.TransactionStatus{mining.TransactionFailure},
+	}
+	s := newTestService(l)
+
+	s.orchestrate(newTestSaga())
+
+	assert.Equal(t, 0, l.keychainLaunches)
+	state, _ := s.GetAccountCreationStatus("id hash")
+	assert.Equal(t, AccountCreationFailure, state.Status)
+}
+
+/*
+Scenario: Time out an account creation
+	Given an ID succeeding and a keychain never finalised
+	When the account creation is orchestrated
+	Then the keychain is not mined again and the account creation is timed out instead of failed
+*/
+func TestOrchestrateAccountCreationTimeout(t *testing.T) {
+	l := &mockLauncher{
+		idStatus:         mining.TransactionSuccess,
+		keychainStatuses: []mining.TransactionStatus{mining.TransactionPending},
+	}
+	s := newTestService(l)
+	s.timeout = 10 * time.Millisecond
+
+	s.orchestrate(newTestSaga())
+
+	state, _ := s.GetAccountCreationStatus("id hash")
+	assert.Equal(t, 0, l.keychainLaunches)
+	assert.Equal(t, AccountCreationTimeout, state.Status)
+	assert.Equal(t, mining.TransactionPending, state.KeychainStatus)
+}
+
+/*
+Scenario: Resume the account creations after a restart
+	Given a pending account creation persisted before a restart
+	When the account creations are resumed
+	Then the account creation is followed until its final status
+*/
+func TestResumeAccountCreations(t *testing.T) {
+	l := &mockLauncher{
+		idStatus:         mining.TransactionSuccess,
+		keychainStatuses: []mining.TransactionStatus{mining.TransactionSuccess},
+	}
+	repo := newMockRepository()
+	repo.StoreAccountCreation(newTestSaga())
+	s := NewService(l, repo)
+
+	assert.Nil(t, s.ResumeAccountCreations())
+	state := waitForState(s, "id hash", func(state AccountCreationState) bool {
+		return state.Status != AccountCreationPending
+	})
+	assert.Equal(t, AccountCreationSuccess, state.Status)
+}
+
+func newTestService(l TransactionLauncher) *service {
+	repo := newMockRepository()
+	repo.StoreAccountCreation(newTestSaga())
+	return NewService(l, repo).(*service)
+}
+
+//waitForState polls the state of an account creation until the condition holds or a second elapsed
+func waitForState(s Service, hash string, cond func(AccountCreationState) bool) AccountCreationState {
+	deadline := time.Now().Add(time.Second)
+	for {
+		state, _ := s.GetAccountCreationStatus(hash)
+		if cond(state) || time.Now().After(deadline) {
+			return state
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func newTestSaga() Saga {
+	return Saga{
+		ID:                newTestTransaction("id hash"),
+		EncryptedKeychain: "enc keychain",
+		State: AccountCreationState{
+			Status:         AccountCreationPending,
+			IDStatus:       mining.TransactionPending,
+			KeychainStatus: mining.TransactionPending,
+			Keychain:       newTestTransaction("keychain hash"),
+		},
+	}
+}
+
+func newTestTransaction(txHash string) TransactionResult {
+	return TransactionResult{
+		TransactionHash:  txHash,
+		EncryptedAddress: "enc addr",
+	}
+}
+
+type mockLauncher struct {
+	mu               sync.Mutex
+	idStatus         mining.TransactionStatus
+	keychainStatuses []mining.TransactionStatus
+	keychainErr      error
+	idErr            error
+	rejectedKeychain string
+	relaunchedHash   string
+	idLaunches       int
+	keychainLaunches int
+	preparedBatch    int
+}
+
+func (l *mockLauncher) LaunchID(encID string) (TransactionResult, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.idLaunches++
+	if l.idErr != nil {
+		return TransactionResult{}, l.idErr
+	}
+	return newTestTransaction("id hash"), nil
+}
+
+func (l *mockLauncher) LaunchKeychain(encKeychain string) (TransactionResult, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.keychainErr != nil {
+		return TransactionResult{}, l.keychainErr
+	}
+	if encKeychain == l.rejectedKeychain {
+		return TransactionResult{}, errors.New("Invalid keychain")
+	}
+	l.keychainLaunches++
+	if l.relaunchedHash != "" {
+		return newTestTransaction(l.relaunchedHash), nil
+	}
+	return newTestTransaction("keychain hash"), nil
+}
+
+func (l *mockLauncher) WatchStatus(ctx context.Context, encAddr string, txHash string) (<-chan mining.TransactionStatus, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	statuses := make(chan mining.TransactionStatus, 2)
+	statuses <- mining.TransactionPending
+	pending := l.idStatus == mining.TransactionPending
+	if txHash != "id hash" {
+		pending = len(l.keychainStatuses) > 0 && l.keychainStatuses[0] == mining.TransactionPending
+	}
+	if pending {
+
+		//The transaction is never finalised, the stream ends with the context
+		go func() {
+			<-ctx.Done()
+			close(statuses)
+		}()
+		return statuses, nil
+	}
+	if txHash == "id hash" {
+		statuses <- l.idStatus
+	} else if len(l.keychainStatuses) > 0 {
+		statuses <- l.keychainStatuses[0]
+		l.keychainStatuses = l.keychainStatuses[1:]
+	}
+	close(statuses)
+	return statuses, nil
+}
+
+func (l *mockLauncher) PrepareBatch(reqs []AccountCreationRequest) TransactionLauncher {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.preparedBatch += len(reqs)
+	return l
+}
+
+type mockRepository struct {
+	sagas map[string]Saga
+}
+
+func newMockRepository() *mockRepository {
+	return &mockRepository{sagas: make(map[string]Saga)}
+}
+
+func (r *mockRepository) StoreAccountCreation(s Saga) error {
+	r.sagas[s.Hash()] = s
+	return nil
+}
+
+func (r *mockRepository) FindAccountCreation(hash string) (*Saga, error) {
+	s, exist := r.sagas[hash]
+	if !exist {
+		return nil, nil
+	}
+	return &s, nil
+}
+
+func (r *mockRepository) ListPendingAccountCreations() ([]Saga, error) {
+	sagas := make([]Saga, 0)
+	for _, s := range r.sagas {
+		if s.State.Status == AccountCreationPending {
+			sagas = append(sagas, s)
+		}
+	}
+	return sagas, nil
+}
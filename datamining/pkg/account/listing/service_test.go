@@ -23,12 +23,12 @@ func TestGetLastKeychain(t *testing.T) {
 	db := new(databasemock)
 	s := NewService(db)
 
-	prop := datamining.NewProposal(datamining.NewProposedKeyPair("enc pv key", "pub key"))
+	prop := datamining.NewProposal(datamining.NewProposedKeyPair("enc pv key", "pub key", time.Time{}))
 	keychain := account.NewKeychain("enc addr", "enc wallet", "id pub", prop, "id sig", "em sig")
 
 	endors1 := mining.NewEndorsement(
 		"", "hash1",
-		mining.NewMasterValidation([]string{}, "key1", mining.NewValidation(mining.ValidationOK, time.Now(), "robotkey", "sig")),
+		mining.NewMasterValidation([]string{}, "key1", mining.NewValidation(mining.ValidationOK, time.Now(), "robotkey", "sig"), []string{"validator key"}),
 		[]mining.Validation{
 			mining.NewValidation(mining.ValidationOK, time.Now(), "pub", "sig"),
 		},
@@ -39,7 +39,7 @@ func TestGetLastKeychain(t *testing.T) {
 	time.Sleep(1 * time.Second)
 	endors2 := mining.NewEndorsement(
 		"hash1", "hash2",
-		mining.NewMasterValidation([]string{}, "key1", mining.NewValidation(mining.ValidationOK, time.Now(), "robotkey", "sig")),
+		mining.NewMasterValidation([]string{}, "key1", mining.NewValidation(mining.ValidationOK, time.Now(), "robotkey", "sig"), []string{"validator key"}),
 		[]mining.Validation{
 			mining.NewValidation(mining.ValidationOK, time.Now(), "pub", "sig"),
 		},
@@ -68,12 +68,12 @@ func TestGetID(t *testing.T) {
 	db := new(databasemock)
 	s := NewService(db)
 
-	prop := datamining.NewProposal(datamining.NewProposedKeyPair("enc pv key", "pub key"))
+	prop := datamining.NewProposal(datamining.NewProposedKeyPair("enc pv key", "pub key", time.Time{}))
 	id := account.NewID("hash1", "enc addr robot", "enc addr person", "enc aes key", "id pub key", prop, "id sig", "em sig")
 
 	endors := mining.NewEndorsement(
 		"", "hash1",
-		mining.NewMasterValidation([]string{}, "key1", mining.NewValidation(mining.ValidationOK, time.Now(), "robotkey", "sig")),
+		mining.NewMasterValidation([]string{}, "key1", mining.NewValidation(mining.ValidationOK, time.Now(), "robotkey", "sig"), []string{"validator key"}),
 		[]mining.Validation{
 			mining.NewValidation(mining.ValidationOK, time.Now(), "pub", "sig"),
 		},
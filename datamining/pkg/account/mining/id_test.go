@@ -2,6 +2,7 @@ package mining
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -18,7 +19,7 @@ Scenario: Checks the ID data integrity
 */
 func TestIDIntegrity(t *testing.T) {
 	miner := idMiner{hasher: mockIDHasher{}}
-	prop := datamining.NewProposal(datamining.NewProposedKeyPair("enc pv key", "pub key"))
+	prop := datamining.NewProposal(datamining.NewProposedKeyPair("enc pv key", "pub key", time.Time{}))
 
 	id := account.NewID("hash", "enc addr", "enc addr", "enc aes key", "id pub", prop, "id sig", "em sig")
 	err := miner.checkDataIntegrity("hash", id)
@@ -33,7 +34,7 @@ Scenario: Checks the ID data integrity
 */
 func TestInvalidIDIntegrity(t *testing.T) {
 	miner := idMiner{hasher: mockBadIDHasher{}}
-	prop := datamining.NewProposal(datamining.NewProposedKeyPair("enc pv key", "pub key"))
+	prop := datamining.NewProposal(datamining.NewProposedKeyPair("enc pv key", "pub key", time.Time{}))
 
 	id := account.NewID("hash", "enc addr", "enc addr", "enc aes key", "id pub", prop, "id sig", "em sig")
 	err := miner.checkDataIntegrity("hash", id)
@@ -48,7 +49,7 @@ Scenario: Check ID data as master peer
 */
 func TestIDMasterCheck(t *testing.T) {
 	miner := NewIDMiner(mockIDSigner{}, mockIDHasher{})
-	prop := datamining.NewProposal(datamining.NewProposedKeyPair("enc pv key", "pub key"))
+	prop := datamining.NewProposal(datamining.NewProposedKeyPair("enc pv key", "pub key", time.Time{}))
 
 	id := account.NewID("hash", "enc addr", "enc addr", "enc aes key", "id pub", prop, "id sig", "em sig")
 	err := miner.CheckAsMaster("hash", id)
@@ -63,7 +64,7 @@ Scenario: Check ID data as slave peer
 */
 func TestIDSlaveCheck(t *testing.T) {
 	miner := NewIDMiner(mockIDSigner{}, mockIDHasher{})
-	prop := datamining.NewProposal(datamining.NewProposedKeyPair("enc pv key", "pub key"))
+	prop := datamining.NewProposal(datamining.NewProposedKeyPair("enc pv key", "pub key", time.Time{}))
 
 	id := account.NewID("hash", "enc addr", "enc addr", "enc aes key", "id pub", prop, "id sig", "em sig")
 	err := miner.CheckAsSlave("hash", id)
@@ -2,6 +2,7 @@ package mining
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -21,7 +22,7 @@ Scenario: Get last transaction hash
 func TestKeychainGetLastTransactionHash(t *testing.T) {
 	db := mock.NewDatabase()
 
-	prop := datamining.NewProposal(datamining.NewProposedKeyPair("enc pv key", "pub key"))
+	prop := datamining.NewProposal(datamining.NewProposedKeyPair("enc pv key", "pub key", time.Time{}))
 	kc := account.NewKeychain("enc addr", "enc wallet", "id pub", prop, "id sig", "em sig")
 	eKc := account.NewEndorsedKeychain("address", kc, mining.NewEndorsement("", "hash", nil, nil))
 	db.StoreKeychain(eKc)
@@ -39,7 +40,7 @@ Scenario: Checks the keychain data integrity
 */
 func TestKeychainIntegrity(t *testing.T) {
 	miner := keychainMiner{hasher: mockKeychainHasher{}}
-	prop := datamining.NewProposal(datamining.NewProposedKeyPair("enc pv key", "pub key"))
+	prop := datamining.NewProposal(datamining.NewProposedKeyPair("enc pv key", "pub key", time.Time{}))
 	kc := account.NewKeychain("enc addr", "enc wallet", "id pub", prop, "id sig", "em sig")
 	err := miner.checkDataIntegrity("hash", kc)
 	assert.Nil(t, err)
@@ -53,7 +54,7 @@ Scenario: Checks the keychain data integrity
 */
 func TestInvalidKeychainIntegrity(t *testing.T) {
 	miner := keychainMiner{hasher: mockBadKeychainHasher{}}
-	prop := datamining.NewProposal(datamining.NewProposedKeyPair("enc pv key", "pub key"))
+	prop := datamining.NewProposal(datamining.NewProposedKeyPair("enc pv key", "pub key", time.Time{}))
 
 	kc := account.NewKeychain("enc addr", "enc wallet", "id pub", prop, "id sig", "em sig")
 	err := miner.checkDataIntegrity("hash", kc)
@@ -69,7 +70,7 @@ Scenario: Check keychain data as master peer
 func TestKeychainMasterCheck(t *testing.T) {
 	miner := NewKeychainMiner(mockKeychainSigner{}, mockKeychainHasher{}, nil)
 
-	prop := datamining.NewProposal(datamining.NewProposedKeyPair("enc pv key", "pub key"))
+	prop := datamining.NewProposal(datamining.NewProposedKeyPair("enc pv key", "pub key", time.Time{}))
 
 	kc := account.NewKeychain("enc addr", "enc wallet", "id pub", prop, "id sig", "em sig")
 	err := miner.CheckAsMaster("hash", kc)
@@ -84,7 +85,7 @@ Scenario: Check keychain data as slave peer
 */
 func TestKeychainSlaveCheck(t *testing.T) {
 	miner := NewKeychainMiner(mockKeychainSigner{}, mockKeychainHasher{}, nil)
-	prop := datamining.NewProposal(datamining.NewProposedKeyPair("enc pv key", "pub key"))
+	prop := datamining.NewProposal(datamining.NewProposedKeyPair("enc pv key", "pub key", time.Time{}))
 
 	data := account.NewKeychain("enc addr", "enc wallet", "id pub", prop, "id sig", "em sig")
 	err := miner.CheckAsSlave("hash", data)
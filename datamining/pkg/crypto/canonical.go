@@ -0,0 +1,307 @@
+package crypto
+
+import (
+	"errors"
+	"time"
+
+	api "github.com/uniris/uniris-core/datamining/api/protobuf-spec"
+	datamining "github.com/uniris/uniris-core/datamining/pkg"
+	"github.com/uniris/uniris-core/datamining/pkg/account"
+	"github.com/uniris/uniris-core/datamining/pkg/internal/encoding"
+	"github.com/uniris/uniris-core/datamining/pkg/lock"
+	"github.com/uniris/uniris-core/datamining/pkg/mining"
+	"github.com/uniris/uniris-core/shared/pkg/canonical"
+)
+
+//ErrUnsupportedRequest is returned when a request cannot be encoded into a request envelope
+var ErrUnsupportedRequest = errors.New("Unsupported request")
+
+//The payloads are serialized with the canonical encoding of the shared module
+
+//remotePayloadTypes lists the payloads the API service can ask to sign with the shared robot key
+var remotePayloadTypes = map[canonical.PayloadType]bool{
+	canonical.AccountCreationResultPayload: true,
+	canonical.TransactionCallbackPayload:   true,
+}
+
+//isRemotePayload checks if an encoded payload can be signed on behalf of the API service
+func isRemotePayload(payload []byte) bool {
+	t, ok := canonical.TypeOf(payload)
+	return ok && remotePayloadTypes[t]
+}
+
+//encoder writes the messages of the datamining service with the canonical encoding
+type encoder struct {
+	*encoding.Encoder
+}
+
+func newEncoder(t canonical.PayloadType) *encoder {
+	return &encoder{encoding.NewEncoder(t)}
+}
+
+func encodeEndorsedID(id account.EndorsedID) []byte {
+	e := newEncoder(canonical.EndorsedIDPayload)
+	e.WriteID(id)
+	e.WriteEndorsement(id.Endorsement())
+	return e.Bytes()
+}
+
+func encodeEndorsedKeychain(kc account.EndorsedKeychain) []byte {
+	e := newEncoder(canonical.EndorsedKeychainPayload)
+	e.WriteString(kc.Address())
+	e.WriteKeychain(kc)
+	e.WriteEndorsement(kc.Endorsement())
+	return e.Bytes()
+}
+
+func encodeLock(txLock lock.TransactionLock) []byte {
+	e := newEncoder(canonical.LockPayload)
+	e.WriteString(txLock.Address)
+	e.WriteString(txLock.TxHash)
+	e.WriteString(txLock.MasterRobotKey)
+	return e.Bytes()
+}
+
+func encodeLockRequest(req *api.LockRequest) []byte {
+	return encodeLock(lock.TransactionLock{
+		Address:        req.Address,
+		TxHash:         req.TransactionHash,
+		MasterRobotKey: req.MasterRobotKey,
+	})
+}
+
+func encodeKeychainLeadRequest(req *api.KeychainLeadRequest) []byte {
+	e := newEncoder(canonical.KeychainLeadRequestPayload)
+	e.WriteString(req.TransactionHash)
+	e.WriteStrings(req.ValidatorPeerIPs)
+	e.WriteString(req.EncryptedKeychain)
+	return e.Bytes()
+}
+
+func encodeIDLeadRequest(req *api.IDLeadRequest) []byte {
+	e := newEncoder(canonical.IDLeadRequestPayload)
+	e.WriteString(req.TransactionHash)
+	e.WriteStrings(req.ValidatorPeerIPs)
+	e.WriteString(req.EncryptedID)
+	return e.Bytes()
+}
+
+func encodeKeychainValidationRequest(req *api.KeychainValidationRequest) []byte {
+	e := newEncoder(canonical.KeychainValidationRequestPayload)
+	e.WriteString(req.TransactionHash)
+	e.WriteKeychain(keychainFromAPI(req.Data))
+	e.WriteStrings(req.ValidationPool)
+	return e.Bytes()
+}
+
+func encodeIDValidationRequest(req *api.IDValidationRequest) []byte {
+	e := newEncoder(canonical.IDValidationRequestPayload)
+	e.WriteString(req.TransactionHash)
+	e.WriteID(idFromAPI(req.Data))
+	e.WriteStrings(req.ValidationPool)
+	return e.Bytes()
+}
+
+func encodeKeychainStorageRequest(req *api.KeychainStorageRequest) []byte {
+	e := newEncoder(canonical.KeychainStorageRequestPayload)
+	e.WriteKeychain(keychainFromAPI(req.Data))
+	e.WriteEndorsement(endorsementFromAPI(req.Endorsement))
+	return e.Bytes()
+}
+
+func encodeIDStorageRequest(req *api.IDStorageRequest) []byte {
+	e := newEncoder(canonical.IDStorageRequestPayload)
+	e.WriteID(idFromAPI(req.Data))
+	e.WriteEndorsement(endorsementFromAPI(req.Endorsement))
+	return e.Bytes()
+}
+
+func encodeIDRequest(req *api.IDRequest) []byte {
+	e := newEncoder(canonical.IDRequestPayload)
+	e.WriteString(req.EncryptedIDHash)
+	return e.Bytes()
+}
+
+func encodeKeychainRequest(req *api.KeychainRequest) []byte {
+	e := newEncoder(canonical.KeychainRequestPayload)
+	e.WriteString(req.EncryptedAddress)
+	return e.Bytes()
+}
+
+func encodeTransactionStatusRequest(req *api.TransactionStatusRequest) []byte {
+	e := newEncoder(canonical.TransactionStatusRequestPayload)
+	e.WriteString(req.Address)
+	e.WriteString(req.Hash)
+	return e.Bytes()
+}
+
+func encodeKeychainUpdateRequest(req *api.KeychainUpdateRequest) []byte {
+	e := newEncoder(canonical.KeychainUpdateRequestPayload)
+	e.WriteString(req.EncryptedIDHash)
+	e.WriteString(req.EncryptedKeychain)
+	e.WriteTimestamp(time.Unix(req.Timestamp, 0))
+	e.WriteString(req.Nonce)
+	return e.Bytes()
+}
+
+//encodeEmitterAuthorizationRequest encodes an authorization or a revocation of an emitter, so a signature cannot be reused for the other action
+func encodeEmitterAuthorizationRequest(req *api.EmitterAuthorizationRequest, t canonical.PayloadType) []byte {
+	e := newEncoder(t)
+	e.WriteString(req.PublicKey)
+	e.WriteTimestamp(time.Unix(req.Timestamp, 0))
+	e.WriteString(req.Nonce)
+	return e.Bytes()
+}
+
+//encodeRequest encodes a request of the External service
+func encodeRequest(req interface{}) ([]byte, error) {
+	switch r := req.(type) {
+	case *api.IDRequest:
+		return encodeIDRequest(r), nil
+	case *api.KeychainRequest:
+		return encodeKeychainRequest(r), nil
+	case *api.LockRequest:
+		return encodeLockRequest(r), nil
+	case *api.KeychainLeadRequest:
+		return encodeKeychainLeadRequest(r), nil
+	case *api.IDLeadRequest:
+		return encodeIDLeadRequest(r), nil
+	case *api.KeychainValidationRequest:
+		return encodeKeychainValidationRequest(r), nil
+	case *api.IDValidationRequest:
+		return encodeIDValidationRequest(r), nil
+	case *api.KeychainStorageRequest:
+		return encodeKeychainStorageRequest(r), nil
+	case *api.IDStorageRequest:
+		return encodeIDStorageRequest(r), nil
+	case *api.TransactionStatusRequest:
+		return encodeTransactionStatusRequest(r), nil
+	}
+	return nil, ErrUnsupportedRequest
+}
+
+//encodeRequestEnvelope encodes the envelope of a request, including the method called
+//so a signed request cannot be sent to another method
+func encodeRequestEnvelope(env *api.RequestEnvelope, r interface{}) ([]byte, error) {
+	req, err := encodeRequest(r)
+	if err != nil {
+		return nil, err
+	}
+
+	e := newEncoder(canonical.RequestEnvelopePayload)
+	e.WriteString(env.Method)
+	e.WriteString(env.Signer)
+	e.WriteTimestamp(time.Unix(env.Timestamp, 0))
+	e.WriteString(env.Nonce)
+	e.WritePayload(req)
+	return e.Bytes(), nil
+}
+
+func encodeValidationResponse(res *api.ValidationResponse) []byte {
+	e := newEncoder(canonical.ValidationResponsePayload)
+	e.WriteValidation(validationFromAPI(res.Validation))
+	return e.Bytes()
+}
+
+func encodeLockAck(ack *api.LockAck) []byte {
+	e := newEncoder(canonical.LockAckPayload)
+	e.WriteString(ack.LockHash)
+	return e.Bytes()
+}
+
+func encodeStorageAck(ack *api.StorageAck) []byte {
+	e := newEncoder(canonical.StorageAckPayload)
+	e.WriteString(ack.StorageHash)
+	return e.Bytes()
+}
+
+func encodeKeychainResponse(res *api.KeychainResponse) []byte {
+	e := newEncoder(canonical.KeychainResponsePayload)
+	e.WriteKeychain(keychainFromAPI(res.Data))
+	e.WriteEndorsement(endorsementFromAPI(res.Endorsement))
+	return e.Bytes()
+}
+
+func encodeIDResponse(res *api.IDResponse) []byte {
+	e := newEncoder(canonical.IDResponsePayload)
+	e.WriteID(idFromAPI(res.Data))
+	e.WriteEndorsement(endorsementFromAPI(res.Endorsement))
+	return e.Bytes()
+}
+
+func encodeAccountSearchResult(res *api.AccountSearchResult) []byte {
+	e := newEncoder(canonical.AccountSearchResultPayload)
+	e.WriteString(res.EncryptedWallet)
+	e.WriteString(res.EncryptedAESkey)
+	e.WriteString(res.EncryptedAddress)
+	return e.Bytes()
+}
+
+func encodeCreationResult(res *api.CreationResult) []byte {
+	e := newEncoder(canonical.CreationResultPayload)
+	e.WriteString(res.TransactionHash)
+	e.WriteString(res.MasterPeerIP)
+	e.WriteString(res.EncryptedAddress)
+	return e.Bytes()
+}
+
+//The protobuf messages are converted using the getters to be safe with missing nested messages
+
+func proposalFromAPI(p *api.Proposal) datamining.Proposal {
+	return datamining.NewProposal(datamining.NewProposedKeyPair(
+		p.GetSharedEmitterKeyPair().GetEncryptedPrivateKey(),
+		p.GetSharedEmitterKeyPair().GetPublicKey(),
+		time.Unix(p.GetSharedEmitterKeyPair().GetActivationDate(), 0),
+	))
+}
+
+func idFromAPI(id *api.ID) account.ID {
+	return account.NewID(
+		id.GetHash(),
+		id.GetEncryptedAddrByRobot(),
+		id.GetEncryptedAddrByID(),
+		id.GetEncryptedAESKey(),
+		id.GetPublicKey(),
+		proposalFromAPI(id.GetProposal()),
+		id.GetIDSignature(),
+		id.GetEmitterSignature(),
+	)
+}
+
+func keychainFromAPI(kc *api.Keychain) account.Keychain {
+	return account.NewKeychain(
+		kc.GetEncryptedAddrByRobot(),
+		kc.GetEncryptedWallet(),
+		kc.GetIDPublicKey(),
+		proposalFromAPI(kc.GetProposal()),
+		kc.GetIDSignature(),
+		kc.GetEmitterSignature(),
+	)
+}
+
+func validationFromAPI(v *api.Validation) mining.Validation {
+	return mining.NewValidation(
+		mining.ValidationStatus(v.GetStatus()),
+		time.Unix(v.GetTimestamp(), 0),
+		v.GetPublicKey(),
+		v.GetSignature(),
+	)
+}
+
+func endorsementFromAPI(end *api.Endorsement) mining.Endorsement {
+	valids := make([]mining.Validation, 0)
+	for _, v := range end.GetValidations() {
+		valids = append(valids, validationFromAPI(v))
+	}
+	return mining.NewEndorsement(
+		end.GetLastTransactionHash(),
+		end.GetTransactionHash(),
+		mining.NewMasterValidation(
+			end.GetMasterValidation().GetLastTransactionMiners(),
+			end.GetMasterValidation().GetProofOfWorkKey(),
+			validationFromAPI(end.GetMasterValidation().GetProofOfWorkValidation()),
+			end.GetMasterValidation().GetValidationPool(),
+		),
+		valids,
+	)
+}
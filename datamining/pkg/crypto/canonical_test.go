@@ -0,0 +1,152 @@
+package crypto
+
+import (
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	api "github.com/uniris/uniris-core/datamining/api/protobuf-spec"
+	datamining "github.com/uniris/uniris-core/datamining/pkg"
+	"github.com/uniris/uniris-core/datamining/pkg/account"
+	"github.com/uniris/uniris-core/datamining/pkg/lock"
+	"github.com/uniris/uniris-core/datamining/pkg/mining"
+)
+
+/*
+Scenario: Encode a lock
+	Given a transaction lock
+	When I want to encode it
+	Then I get the test vector
+*/
+func TestEncodeLockVector(t *testing.T) {
+	b := encodeLock(lock.TransactionLock{
+		Address:        "addr",
+		TxHash:         "hash",
+		MasterRobotKey: "key",
+	})
+	assert.Equal(t, "0108"+"0000000461646472"+"0000000468617368"+"000000036b6579", hex.EncodeToString(b))
+}
+
+/*
+Scenario: Encode an account search result
+	Given an account search result
+	When I want to encode it
+	Then I get the test vector shared with the API
+*/
+func TestEncodeAccountSearchResultVector(t *testing.T) {
+	b := encodeAccountSearchResult(&api.AccountSearchResult{
+		EncryptedWallet:  "wal",
+		EncryptedAESkey:  "aes",
+		EncryptedAddress: "addr",
+	})
+	assert.Equal(t, "0114"+"0000000377616c"+"00000003616573"+"0000000461646472", hex.EncodeToString(b))
+}
+
+/*
+Scenario: Encode a keychain response
+	Given a keychain with its endorsement
+	When I want to encode the response
+	Then I get the test vector shared with the API
+*/
+func TestEncodeKeychainResponseVector(t *testing.T) {
+	b := encodeKeychainResponse(&api.KeychainResponse{
+		Data: &api.Keychain{
+			EncryptedAddrByRobot: "a",
+			EncryptedWallet:      "w",
+			IDPublicKey:          "i",
+			Proposal: &api.Proposal{
+				SharedEmitterKeyPair: &api.KeyPairProposal{EncryptedPrivateKey: "p", PublicKey: "k"},
+			},
+			IDSignature:      "s",
+			EmitterSignature: "e",
+		},
+		Endorsement: &api.Endorsement{
+			LastTransactionHash: "l",
+			TransactionHash:     "t",
+			MasterValidation: &api.MasterValidation{
+				ProofOfWorkKey:        "k",
+				ProofOfWorkValidation: &api.Validation{Status: api.Validation_OK, Timestamp: 1, PublicKey: "v", Signature: "g"},
+				LastTransactionMiners: []string{"m"},
+				ValidationPool:        []string{"p"},
+			},
+			Validations: []*api.Validation{
+				&api.Validation{Status: api.Validation_KO, Timestamp: 2, PublicKey: "x", Signature: "y"},
+			},
+		},
+	})
+	assert.Equal(t, "0112"+"0000000161"+"0000000177"+"0000000169"+"0000000170"+"000000016b"+"0000000173"+"0000000165"+
+		"000000016c"+"0000000174"+"000000016b"+"00"+"0000000000000001"+"0000000176"+"0000000167"+"00000001"+"000000016d"+
+		"00000001"+"0000000170"+"00000001"+"01"+"0000000000000002"+"0000000178"+"0000000179", hex.EncodeToString(b))
+}
+
+/*
+Scenario: Encode a creation result
+	Given a transaction creation result
+	When I want to encode it
+	Then I get the test vector shared with the API
+*/
+func TestEncodeCreationResultVector(t *testing.T) {
+	b := encodeCreationResult(&api.CreationResult{
+		TransactionHash:  "hash",
+		MasterPeerIP:     "ip",
+		EncryptedAddress: "enc addr",
+	})
+	assert.Equal(t, "0115"+"0000000468617368"+"000000026970"+"00000008656e632061646472", hex.EncodeToString(b))
+}
+
+/*
+Scenario: Encode the same endorsed keychain from the domain and the protobuf message
+	Given an endorsed keychain and its protobuf storage request
+	When I want to encode them
+	Then the keychain and the endorsement are encoded identically
+*/
+func TestEncodeKeychainFromDomainAndAPI(t *testing.T) {
+	prop := datamining.NewProposal(datamining.NewProposedKeyPair("pv", "pub", time.Time{}))
+	kc := account.NewKeychain("addr", "wal", "id", prop, "id sig", "em sig")
+	v := mining.NewValidation(mining.ValidationOK, time.Unix(10, 0), "pub", "sig")
+	end := mining.NewEndorsement("last", "hash", mining.NewMasterValidation([]string{"miner"}, "pow", v, []string{"validator key"}), []mining.Validation{v})
+
+	apiV := &api.Validation{Status: api.Validation_OK, Timestamp: 10, PublicKey: "pub", Signature: "sig"}
+	req := &api.KeychainStorageRequest{
+		Data: &api.Keychain{
+			EncryptedAddrByRobot: "addr",
+			EncryptedWallet:      "wal",
+			IDPublicKey:          "id",
+			Proposal: &api.Proposal{
+				SharedEmitterKeyPair: &api.KeyPairProposal{EncryptedPrivateKey: "pv", PublicKey: "pub"},
+			},
+			IDSignature:      "id sig",
+			EmitterSignature: "em sig",
+		},
+		Endorsement: &api.Endorsement{
+			LastTransactionHash: "last",
+			TransactionHash:     "hash",
+			MasterValidation: &api.MasterValidation{
+				LastTransactionMiners: []string{"miner"},
+				ProofOfWorkKey:        "pow",
+				ProofOfWorkValidation: apiV,
+				ValidationPool:        []string{"validator key"},
+			},
+			Validations: []*api.Validation{apiV},
+		},
+	}
+
+	eKc := encodeEndorsedKeychain(account.NewEndorsedKeychain("", kc, end))
+	reqB := encodeKeychainStorageRequest(req)
+
+	//Skip the version, the payload type and the empty address
+	assert.Equal(t, eKc[6:], reqB[2:])
+}
+
+/*
+Scenario: Encode a protobuf message with missing nested messages
+	Given a storage request without data nor endorsement
+	When I want to encode it
+	Then the missing fields are encoded as empty values
+*/
+func TestEncodeRequestWithMissingMessages(t *testing.T) {
+	assert.NotPanics(t, func() {
+		encodeIDStorageRequest(&api.IDStorageRequest{})
+	})
+}
@@ -0,0 +1,104 @@
+package crypto
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"math/big"
+	"time"
+
+	"github.com/uniris/uniris-core/datamining/pkg/transport/rpc"
+	"github.com/uniris/uniris-core/shared/pkg/keys"
+	"github.com/uniris/uniris-core/shared/pkg/keystore"
+)
+
+//ErrCertificateMismatch is returned when a certificate is not bound to the expected public key
+var ErrCertificateMismatch = errors.New("Certificate does not match the public key")
+
+//certificateValidity is the validity period of a node certificate
+const certificateValidity = 365 * 24 * time.Hour
+
+//Certifier defines methods to secure the connections between the peers with certificates bound to their keys
+type Certifier interface {
+	rpc.Certifier
+}
+
+type certifier struct {
+	ks keystore.KeyStore
+}
+
+//NewCertifier creates a new certifier to secure the connections between the peers
+//
+//The private keys referencing the key store sign the handshakes inside it, without leaving it
+func NewCertifier(ks keystore.KeyStore) Certifier {
+	return certifier{ks: ks}
+}
+
+//NewNodeCertificate creates a self-signed TLS certificate from the node keypair
+//
+//The certificate is bound to the node public key, so the peers verify it against the public key
+//known by the network instead of a certificate authority.
+//Only ECDSA P-256 and Ed25519 keys can be used in TLS.
+func (c certifier) NewNodeCertificate(pubKey string, pvKey string) (tls.Certificate, error) {
+	signer, err := c.tlsSigner(pvKey)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: "uniris node"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(certificateValidity),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, signer.Public(), signer)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	if err := c.VerifyCertificateKey(cert, pubKey); err != nil {
+		return tls.Certificate{}, err
+	}
+
+	return tls.Certificate{
+		Certificate: [][]byte{der},
+		PrivateKey:  signer,
+		Leaf:        cert,
+	}, nil
+}
+
+//VerifyCertificateKey checks if the certificate is bound to the given public key
+func (c certifier) VerifyCertificateKey(cert *x509.Certificate, pubKey string) error {
+	match, err := keys.MatchPublicKey(pubKey, cert.PublicKey)
+	if err != nil {
+		return err
+	}
+	if !match {
+		return ErrCertificateMismatch
+	}
+	return nil
+}
+
+func (c certifier) tlsSigner(pvKey string) (crypto.Signer, error) {
+	if name, ok := keystore.ParseReference(pvKey); ok {
+		if c.ks == nil {
+			return nil, keystore.ErrKeyNotFound
+		}
+		return c.ks.TLSSigner(name)
+	}
+	return keys.TLSSigner(pvKey)
+}
@@ -0,0 +1,38 @@
+package crypto
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/uniris/uniris-core/shared/pkg/keys"
+)
+
+/*
+Scenario: Create a node certificate bound to the node public key
+	Given a P-256 and an Ed25519 keypair
+	When I create the node certificate
+	Then the certificate is bound to the node public key and not to another one
+*/
+func TestNewNodeCertificate(t *testing.T) {
+	for _, algo := range []keys.Algorithm{keys.ECDSAP256, keys.Ed25519} {
+		pub, pv, _ := keys.GenerateKeyPair(algo)
+		otherPub, _, _ := keys.GenerateKeyPair(algo)
+
+		cert, err := NewCertifier(nil).NewNodeCertificate(pub, pv)
+		assert.Nil(t, err)
+		assert.Nil(t, NewCertifier(nil).VerifyCertificateKey(cert.Leaf, pub))
+		assert.Equal(t, ErrCertificateMismatch, NewCertifier(nil).VerifyCertificateKey(cert.Leaf, otherPub))
+	}
+}
+
+/*
+Scenario: Create a node certificate from a secp256k1 keypair
+	Given a secp256k1 keypair
+	When I create the node certificate
+	Then I get an error because TLS does not support the curve
+*/
+func TestNewNodeCertificateUnsupported(t *testing.T) {
+	pub, pv, _ := keys.GenerateKeyPair(keys.ECDSASecp256k1)
+	_, err := NewCertifier(nil).NewNodeCertificate(pub, pv)
+	assert.Equal(t, keys.ErrUnsupportedAlgorithm, err)
+}
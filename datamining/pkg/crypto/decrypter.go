@@ -1,16 +1,15 @@
 package crypto
 
 import (
-	"crypto/x509"
-	"encoding/hex"
 	"encoding/json"
+	"time"
 
 	"github.com/uniris/uniris-core/datamining/pkg"
 
 	"github.com/uniris/uniris-core/datamining/pkg/account"
 	"github.com/uniris/uniris-core/datamining/pkg/transport/rpc"
-
-	"github.com/uniris/ecies/pkg"
+	"github.com/uniris/uniris-core/shared/pkg/keys"
+	"github.com/uniris/uniris-core/shared/pkg/keystore"
 )
 
 //Decrypter defines methods to handle decryption
@@ -18,19 +17,23 @@ type Decrypter interface {
 	rpc.Decrypter
 }
 
-type decrypter struct{}
+type decrypter struct {
+	ks keystore.KeyStore
+}
 
 //NewDecrypter create a new decrypter
-func NewDecrypter() Decrypter {
-	return decrypter{}
+//
+//The private keys referencing the key store are used inside it, without leaving it
+func NewDecrypter(ks keystore.KeyStore) Decrypter {
+	return decrypter{ks: ks}
 }
 
 func (d decrypter) DecryptHash(hash string, pvKey string) (string, error) {
-	return decrypt(pvKey, hash)
+	return d.decrypt(pvKey, hash)
 }
 
 func (d decrypter) DecryptID(data string, pvKey string) (account.ID, error) {
-	clear, err := decrypt(pvKey, data)
+	clear, err := d.decrypt(pvKey, data)
 	if err != nil {
 		return nil, err
 	}
@@ -43,7 +46,8 @@ func (d decrypter) DecryptID(data string, pvKey string) (account.ID, error) {
 	prop := datamining.NewProposal(
 		datamining.NewProposedKeyPair(
 			id.Proposal.SharedEmitterKeyPair.EncryptedPrivateKey,
-			id.Proposal.SharedEmitterKeyPair.PublicKey),
+			id.Proposal.SharedEmitterKeyPair.PublicKey,
+			time.Unix(id.Proposal.SharedEmitterKeyPair.ActivationDate, 0)),
 	)
 
 	return account.NewID(
@@ -58,7 +62,7 @@ func (d decrypter) DecryptID(data string, pvKey string) (account.ID, error) {
 }
 
 func (d decrypter) DecryptKeychain(data string, pvKey string) (account.Keychain, error) {
-	clear, err := decrypt(pvKey, data)
+	clear, err := d.decrypt(pvKey, data)
 	if err != nil {
 		return nil, err
 	}
@@ -71,7 +75,8 @@ func (d decrypter) DecryptKeychain(data string, pvKey string) (account.Keychain,
 	prop := datamining.NewProposal(
 		datamining.NewProposedKeyPair(
 			kc.Proposal.SharedEmitterKeyPair.EncryptedPrivateKey,
-			kc.Proposal.SharedEmitterKeyPair.PublicKey),
+			kc.Proposal.SharedEmitterKeyPair.PublicKey,
+			time.Unix(kc.Proposal.SharedEmitterKeyPair.ActivationDate, 0)),
 	)
 
 	return account.NewKeychain(
@@ -83,26 +88,19 @@ func (d decrypter) DecryptKeychain(data string, pvKey string) (account.Keychain,
 		kc.EmitterSignature), nil
 }
 
-func decrypt(privk string, data string) (string, error) {
-	decodeKey, err := hex.DecodeString(privk)
-	if err != nil {
-		return "", err
+func (d decrypter) decrypt(pvKey string, data string) (string, error) {
+	var clear []byte
+	var err error
+	if name, ok := keystore.ParseReference(pvKey); ok {
+		if d.ks == nil {
+			return "", keystore.ErrKeyNotFound
+		}
+		clear, err = d.ks.Decrypt(name, data)
+	} else {
+		clear, err = keys.Decrypt(pvKey, data)
 	}
-
-	robotKey, err := x509.ParseECPrivateKey(decodeKey)
-	if err != nil {
-		return "", err
-	}
-
-	decodeCipher, err := hex.DecodeString(data)
-	if err != nil {
-		return "", err
-	}
-
-	robotEciesKey := ecies.ImportECDSA(robotKey)
-	message, err := robotEciesKey.Decrypt(decodeCipher, nil, nil)
 	if err != nil {
 		return "", err
 	}
-	return string(message), nil
+	return string(clear), nil
 }
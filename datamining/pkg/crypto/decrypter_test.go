@@ -30,7 +30,7 @@ func TestDecryptText(t *testing.T) {
 
 	pvkey, _ := x509.MarshalECPrivateKey(superKey)
 
-	clear, _ := decrypt(hex.EncodeToString(pvkey), hex.EncodeToString(cipher))
+	clear, _ := NewDecrypter(nil).DecryptHash(hex.EncodeToString(cipher), hex.EncodeToString(pvkey))
 	assert.Equal(t, "uniris", string(clear))
 }
 
@@ -49,7 +49,7 @@ func TestDecryptHash(t *testing.T) {
 
 	pvkey, _ := x509.MarshalECPrivateKey(superKey)
 
-	hash, err := NewDecrypter().DecryptHash(hex.EncodeToString(cipher), hex.EncodeToString(pvkey))
+	hash, err := NewDecrypter(nil).DecryptHash(hex.EncodeToString(cipher), hex.EncodeToString(pvkey))
 	assert.Nil(t, err)
 	assert.Equal(t, "hash", hash)
 }
@@ -88,7 +88,7 @@ func TestDecryptID(t *testing.T) {
 
 	pvkey, _ := x509.MarshalECPrivateKey(superKey)
 
-	newID, err := NewDecrypter().DecryptID(hex.EncodeToString(cipher), hex.EncodeToString(pvkey))
+	newID, err := NewDecrypter(nil).DecryptID(hex.EncodeToString(cipher), hex.EncodeToString(pvkey))
 	assert.Nil(t, err)
 	assert.Equal(t, "hash", newID.Hash())
 	assert.Equal(t, "addr", newID.EncryptedAddrByID())
@@ -131,7 +131,7 @@ func TestDecryptKeychaincData(t *testing.T) {
 
 	pvkey, _ := x509.MarshalECPrivateKey(superKey)
 
-	keychain, err := NewDecrypter().DecryptKeychain(hex.EncodeToString(cipher), hex.EncodeToString(pvkey))
+	keychain, err := NewDecrypter(nil).DecryptKeychain(hex.EncodeToString(cipher), hex.EncodeToString(pvkey))
 	assert.Nil(t, err)
 	assert.Equal(t, "enc wallet", keychain.EncryptedWallet())
 	assert.Equal(t, "addr", keychain.EncryptedAddrByRobot())
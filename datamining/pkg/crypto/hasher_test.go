@@ -44,11 +44,11 @@ func TestHashBytes(t *testing.T) {
 /*
 Scenario: Hash a ID
 	Given ID
-	When I want to hash it, I create a JSON of it
+	When I want to hash it, I encode it canonically
 	Then it produces a hash
 */
 func TestHashID(t *testing.T) {
-	prop := datamining.NewProposal(datamining.NewProposedKeyPair("enc pv key", "pub key"))
+	prop := datamining.NewProposal(datamining.NewProposedKeyPair("enc pv key", "pub key", time.Time{}))
 
 	id := account.NewID("hash", "addr", "addr", "aesKey", "id pub", prop, "id sig", "em sig")
 	hash, err := NewHasher().HashID(id)
@@ -59,11 +59,11 @@ func TestHashID(t *testing.T) {
 /*
 Scenario: Hash a keychain
 	Given a keychain
-	When I want to hash it, I create a JSON of it
+	When I want to hash it, I encode it canonically
 	Then it produces a hash
 */
 func TestHashKeychain(t *testing.T) {
-	prop := datamining.NewProposal(datamining.NewProposedKeyPair("enc pv key", "pub key"))
+	prop := datamining.NewProposal(datamining.NewProposedKeyPair("enc pv key", "pub key", time.Time{}))
 
 	kc := account.NewKeychain("addr", "enc wallet", "id pub", prop, "id sig", "em sig")
 	hash, err := NewHasher().HashKeychain(kc)
@@ -74,7 +74,7 @@ func TestHashKeychain(t *testing.T) {
 /*
 Scenario: Hash a lock
 	Given a lock for a transaction
-	When I want to hash it, I create JSON of it
+	When I want to hash it, I encode it canonically
 	Then it produces a hash
 */
 func TestHashLock(t *testing.T) {
@@ -92,14 +92,14 @@ func TestHashLock(t *testing.T) {
 /*
 Scenario: Hash a endorsed id
 	Given an endorsed id
-	When I want to hash it, I create JSON of it
+	When I want to hash it, I encode it canonically
 	Then it produces a hash
 */
 func TestHashEndorsedID(t *testing.T) {
-	prop := datamining.NewProposal(datamining.NewProposedKeyPair("enc pv key", "pub key"))
+	prop := datamining.NewProposal(datamining.NewProposedKeyPair("enc pv key", "pub key", time.Time{}))
 	id := account.NewID("hash", "addr", "addr", "aesKey", "id pub", prop, "id sig", "em sig")
 	end := mining.NewEndorsement("last hash", "hash",
-		mining.NewMasterValidation([]string{"pubkey"}, "pubkey", mining.NewValidation(mining.ValidationOK, time.Now(), "pubkey", "signature")),
+		mining.NewMasterValidation([]string{"pubkey"}, "pubkey", mining.NewValidation(mining.ValidationOK, time.Now(), "pubkey", "signature"), []string{"validator key"}),
 		[]mining.Validation{mining.NewValidation(mining.ValidationOK, time.Now(), "pubkey", "signature")},
 	)
 
@@ -112,15 +112,15 @@ func TestHashEndorsedID(t *testing.T) {
 /*
 Scenario: Hash an endorsed keychain
 	Given an endorsed keychain
-	When I want to hash it, I create JSON of it
+	When I want to hash it, I encode it canonically
 	Then it produces a hash
 */
 func TestHashEndorsedKeychain(t *testing.T) {
-	prop := datamining.NewProposal(datamining.NewProposedKeyPair("enc pv key", "pub key"))
+	prop := datamining.NewProposal(datamining.NewProposedKeyPair("enc pv key", "pub key", time.Time{}))
 
 	kc := account.NewKeychain("addr", "enc wallet", "id pub", prop, "id sig", "em sig")
 	end := mining.NewEndorsement("last hash", "hash",
-		mining.NewMasterValidation([]string{"pubkey"}, "pubkey", mining.NewValidation(mining.ValidationOK, time.Now(), "pubkey", "signature")),
+		mining.NewMasterValidation([]string{"pubkey"}, "pubkey", mining.NewValidation(mining.ValidationOK, time.Now(), "pubkey", "signature"), []string{"validator key"}),
 		[]mining.Validation{mining.NewValidation(mining.ValidationOK, time.Now(), "pubkey", "signature")},
 	)
 
@@ -0,0 +1,50 @@
+package mock
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"math/big"
+	"time"
+)
+
+type mockCertifier struct{}
+
+//NewCertifier creates a new mocked certifier
+//
+//The certificates are bound to the public key through their common name
+func NewCertifier() mockCertifier {
+	return mockCertifier{}
+}
+
+func (c mockCertifier) NewNodeCertificate(pubKey string, pvKey string) (tls.Certificate, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: pubKey},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	return tls.Certificate{
+		Certificate: [][]byte{der},
+		PrivateKey:  key,
+	}, nil
+}
+
+func (c mockCertifier) VerifyCertificateKey(cert *x509.Certificate, pubKey string) error {
+	if cert.Subject.CommonName != pubKey {
+		return errors.New("Certificate does not match the public key")
+	}
+	return nil
+}
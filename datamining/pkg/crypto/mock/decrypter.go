@@ -1,6 +1,8 @@
 package mock
 
 import (
+	"time"
+
 	"github.com/uniris/uniris-core/datamining/pkg"
 	"github.com/uniris/uniris-core/datamining/pkg/account"
 )
@@ -18,12 +20,12 @@ func (d mockDecrypter) DecryptHash(hash string, pvKey string) (string, error) {
 
 func (d mockDecrypter) DecryptKeychain(data string, pvKey string) (account.Keychain, error) {
 	return account.NewKeychain("", "", "", datamining.NewProposal(
-		datamining.NewProposedKeyPair("enc pv key", "pub key"),
+		datamining.NewProposedKeyPair("enc pv key", "pub key", time.Time{}),
 	), "id sig", "em sig"), nil
 }
 
 func (d mockDecrypter) DecryptID(data string, pvKey string) (account.ID, error) {
 	return account.NewID("hash", "", "", "", "", datamining.NewProposal(
-		datamining.NewProposedKeyPair("enc pv key", "pub key"),
+		datamining.NewProposedKeyPair("enc pv key", "pub key", time.Time{}),
 	), "id sig", "em sig"), nil
 }
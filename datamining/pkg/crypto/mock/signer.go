@@ -1,6 +1,8 @@
 package mock
 
 import (
+	"errors"
+
 	api "github.com/uniris/uniris-core/datamining/api/protobuf-spec"
 	"github.com/uniris/uniris-core/datamining/pkg/account"
 	"github.com/uniris/uniris-core/datamining/pkg/mining"
@@ -17,31 +19,31 @@ func (s mockSigner) VerifyTransactionDataSignature(txType mining.TransactionType
 	return nil
 }
 
-func (s mockSigner) VerifyHashSignature(pubKey string, text string, sig string) error {
+func (s mockSigner) VerifyRequestEnvelopeSignature(env *api.RequestEnvelope, req interface{}) error {
+	if env.Signature != "sig" {
+		return errors.New("Invalid signature")
+	}
 	return nil
 }
 
-func (s mockSigner) VerifyKeychainLeadRequestSignature(pubKey string, data *api.KeychainLeadRequest) error {
-	return nil
-}
-func (s mockSigner) VerifyIDLeadRequestSignature(pubKey string, data *api.IDLeadRequest) error {
+func (s mockSigner) VerifyKeychainUpdateRequestSignature(req *api.KeychainUpdateRequest, pubKey string) error {
+	if req.Signature != "sig" {
+		return errors.New("Invalid signature")
+	}
 	return nil
 }
 
-func (s mockSigner) VerifyKeychainValidationRequestSignature(pubKey string, data *api.KeychainValidationRequest) error {
-	return nil
-}
-func (s mockSigner) VerifyIDValidationRequestSignature(pubKey string, data *api.IDValidationRequest) error {
-	return nil
-}
-func (s mockSigner) VerifyKeychainStorageRequestSignature(pubKey string, data *api.KeychainStorageRequest) error {
-	return nil
-}
-func (s mockSigner) VerifyIDStorageRequestSignature(pubKey string, data *api.IDStorageRequest) error {
+func (s mockSigner) VerifyEmitterAuthorizationRequestSignature(req *api.EmitterAuthorizationRequest, pubKey string) error {
+	if req.Signature != "sig" {
+		return errors.New("Invalid signature")
+	}
 	return nil
 }
 
-func (s mockSigner) VerifyLockRequestSignature(pubkey string, req *api.LockRequest) error {
+func (s mockSigner) VerifyEmitterRevocationRequestSignature(req *api.EmitterAuthorizationRequest, pubKey string) error {
+	if req.Signature != "sig" {
+		return errors.New("Invalid signature")
+	}
 	return nil
 }
 
@@ -73,7 +75,7 @@ func (s mockSigner) VerifyIDResponseSignature(pubKey string, res *api.IDResponse
 	return nil
 }
 
-func (s mockSigner) VerifyValidationSignature(v mining.Validation) error {
+func (s mockSigner) VerifyValidationSignature(txHash string, vPool []string, v mining.Validation) error {
 	return nil
 }
 
@@ -86,7 +88,7 @@ func (s mockSigner) SignKeychainResponse(res *api.KeychainResponse, pvKey string
 	return nil
 }
 
-func (s mockSigner) SignValidation(v mining.Validation, pvKey string) (mining.Validation, error) {
+func (s mockSigner) SignValidation(v mining.Validation, txHash string, vPool []string, pvKey string) (mining.Validation, error) {
 	return mining.NewValidation(v.Status(), v.Timestamp(), v.PublicKey(), "sig"), nil
 }
 
@@ -95,36 +97,12 @@ func (s mockSigner) SignValidationResponse(res *api.ValidationResponse, pvKey st
 	return nil
 }
 
-func (s mockSigner) SignKeychainLeadRequest(req *api.KeychainLeadRequest, pvKey string) error {
-	req.SignatureRequest = "sig"
-	return nil
-}
-func (s mockSigner) SignIDLeadRequest(req *api.IDLeadRequest, pvKey string) error {
-	req.SignatureRequest = "sig"
-	return nil
-}
-func (s mockSigner) SignKeychainValidationRequestSignature(req *api.KeychainValidationRequest, pvKey string) error {
-	req.Signature = "sig"
-	return nil
-}
-func (s mockSigner) SignIDValidationRequestSignature(req *api.IDValidationRequest, pvKey string) error {
-	req.Signature = "sig"
-	return nil
-}
-func (s mockSigner) SignKeychainStorageRequestSignature(req *api.KeychainStorageRequest, pvKey string) error {
-	req.Signature = "sig"
-	return nil
-}
-func (s mockSigner) SignIDStorageRequestSignature(req *api.IDStorageRequest, pvKey string) error {
-	req.Signature = "sig"
-	return nil
-}
-func (s mockSigner) SignLockRequest(req *api.LockRequest, pvKey string) error {
-	req.Signature = "sig"
+func (s mockSigner) SignRequestEnvelope(env *api.RequestEnvelope, req interface{}, pvKey string) error {
+	env.Signature = "sig"
 	return nil
 }
 
-func (s mockSigner) SignHash(hash string, pvKey string) (string, error) {
+func (s mockSigner) SignPayload(payload []byte, pvKey string) (string, error) {
 	return "sig", nil
 }
 
@@ -8,6 +8,7 @@ import (
 	"encoding/asn1"
 	"encoding/hex"
 	"encoding/json"
+	"math/big"
 	"time"
 
 	"github.com/uniris/uniris-core/datamining/pkg"
@@ -17,7 +18,11 @@ import (
 	"github.com/stretchr/testify/assert"
 	api "github.com/uniris/uniris-core/datamining/api/protobuf-spec"
 	"github.com/uniris/uniris-core/datamining/pkg/account"
+	"github.com/uniris/uniris-core/datamining/pkg/internal/encoding"
+	"github.com/uniris/uniris-core/datamining/pkg/lock"
 	"github.com/uniris/uniris-core/datamining/pkg/mining"
+	"github.com/uniris/uniris-core/shared/pkg/canonical"
+	"github.com/uniris/uniris-core/shared/pkg/keys"
 )
 
 /*
@@ -31,10 +36,12 @@ func TestSign(t *testing.T) {
 	pvKey, _ := x509.MarshalECPrivateKey(key)
 	encData := "uxazexc"
 
-	sig, err := sign(hex.EncodeToString(pvKey), encData)
+	sig, err := keys.Sign(hex.EncodeToString(pvKey), []byte(encData))
 	assert.Nil(t, err)
 	assert.NotEmpty(t, sig)
-	var signature ecdsaSignature
+	var signature struct {
+		R, S *big.Int
+	}
 	decodesig, _ := hex.DecodeString(string(sig))
 	asn1.Unmarshal(decodesig, &signature)
 
@@ -59,7 +66,7 @@ func TestVerify(t *testing.T) {
 
 	b, _ := json.Marshal(encData)
 
-	sig, _ := sign(hex.EncodeToString(pvKey), string(b))
+	sig, _ := keys.Sign(hex.EncodeToString(pvKey), b)
 
 	err := checkSignature(
 		hex.EncodeToString(pubKey),
@@ -76,28 +83,17 @@ Scenario: Verifies keychain transaction signature
 	Then I get not error
 */
 func TestVerifyTransactionKeychainSignature(t *testing.T) {
-	prop := datamining.NewProposal(datamining.NewProposedKeyPair("enc key", "pub"))
+	prop := datamining.NewProposal(datamining.NewProposedKeyPair("enc key", "pub", time.Time{}))
 	k := account.NewKeychain("enc addr", "enc wallet", "id pub", prop, "id sig", "em sig")
 	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
 	pvKey, _ := x509.MarshalECPrivateKey(key)
 	pubKey, _ := x509.MarshalPKIXPublicKey(key.Public())
 
-	data := keychainWithoutSig{
-		EncryptedAddrByRobot: k.EncryptedAddrByRobot(),
-		EncryptedWallet:      k.EncryptedWallet(),
-		IDPublicKey:          k.IDPublicKey(),
-		Proposal: proposal{
-			SharedEmitterKeyPair: proposalKeypair{
-				EncryptedPrivateKey: prop.SharedEmitterKeyPair().EncryptedPrivateKey(),
-				PublicKey:           prop.SharedEmitterKeyPair().PublicKey(),
-			},
-		},
-	}
-	b, _ := json.Marshal(data)
+	b := encoding.EncodeKeychainData(k)
 
-	sig, _ := sign(hex.EncodeToString(pvKey), string(b))
+	sig, _ := keys.Sign(hex.EncodeToString(pvKey), b)
 
-	assert.Nil(t, NewSigner().VerifyTransactionDataSignature(mining.KeychainTransaction, hex.EncodeToString(pubKey), k, sig))
+	assert.Nil(t, NewSigner(nil).VerifyTransactionDataSignature(mining.KeychainTransaction, hex.EncodeToString(pubKey), k, sig))
 }
 
 /*
@@ -107,58 +103,91 @@ Scenario: Verify ID transaction signature
 	Then I get not error
 */
 func TestVerifyTransactionIDSignature(t *testing.T) {
-	prop := datamining.NewProposal(datamining.NewProposedKeyPair("enc key", "pub"))
+	prop := datamining.NewProposal(datamining.NewProposedKeyPair("enc key", "pub", time.Time{}))
 
 	id := account.NewID("hash", "enc addr", "enc addr", "enc aes key", "id pub", prop, "id sig", "em sig")
 	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
 	pvKey, _ := x509.MarshalECPrivateKey(key)
 	pubKey, _ := x509.MarshalPKIXPublicKey(key.Public())
 
-	data := idWithoutSig{
-		Hash:                 id.Hash(),
-		EncryptedAESKey:      id.EncryptedAESKey(),
-		EncryptedAddrByRobot: id.EncryptedAddrByRobot(),
-		EncryptedAddrByID:    id.EncryptedAddrByID(),
-		PublicKey:            id.PublicKey(),
-		Proposal: proposal{
-			SharedEmitterKeyPair: proposalKeypair{
-				EncryptedPrivateKey: prop.SharedEmitterKeyPair().EncryptedPrivateKey(),
-				PublicKey:           prop.SharedEmitterKeyPair().PublicKey(),
-			},
-		},
-	}
-	b, _ := json.Marshal(data)
+	b := encoding.EncodeIDData(id)
 
-	sig, _ := sign(hex.EncodeToString(pvKey), string(b))
+	sig, _ := keys.Sign(hex.EncodeToString(pvKey), b)
 
-	assert.Nil(t, NewSigner().VerifyTransactionDataSignature(mining.IDTransaction, hex.EncodeToString(pubKey), id, sig))
+	assert.Nil(t, NewSigner(nil).VerifyTransactionDataSignature(mining.IDTransaction, hex.EncodeToString(pubKey), id, sig))
 }
 
 /*
-Scenario: Sign and check hash signature
-	Given a hash and a key pair
-	When I want to sign the hash and checks the signature generated
+Scenario: Sign and check the envelope of an ID request
+	Given an ID request, an envelope and a key pair
+	When I want to sign the envelope and checks the signature generated
 	Then I get not error
 */
-func TestSignAndVerifyHashSignature(t *testing.T) {
+func TestSignAndVerifyIDRequestEnvelopeSignature(t *testing.T) {
 	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
 	pvKey, _ := x509.MarshalECPrivateKey(key)
 	pubKey, _ := x509.MarshalPKIXPublicKey(key.Public())
 
-	sig, err := NewSigner().SignHash("hash", hex.EncodeToString(pvKey))
+	req := &api.IDRequest{
+		EncryptedIDHash: "enc hash",
+	}
+	env := newTestEnvelope("/api.External/GetID", hex.EncodeToString(pubKey))
+
+	err := NewSigner(nil).SignRequestEnvelope(env, req, hex.EncodeToString(pvKey))
 	assert.Nil(t, err)
-	assert.NotEmpty(t, sig)
+	assert.NotEmpty(t, env.Signature)
+
+	assert.Nil(t, NewSigner(nil).VerifyRequestEnvelopeSignature(env, req))
+}
+
+/*
+Scenario: Check the envelope of a tampered request
+	Given a signed envelope of a keychain request
+	When the request or the envelope are modified
+	Then the signature is invalid
+*/
+func TestVerifyTamperedRequestEnvelopeSignature(t *testing.T) {
+	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	pvKey, _ := x509.MarshalECPrivateKey(key)
+	pubKey, _ := x509.MarshalPKIXPublicKey(key.Public())
+
+	req := &api.KeychainRequest{
+		EncryptedAddress: "enc addr",
+	}
+	env := newTestEnvelope("/api.External/GetKeychain", hex.EncodeToString(pubKey))
+	assert.Nil(t, NewSigner(nil).SignRequestEnvelope(env, req, hex.EncodeToString(pvKey)))
 
-	assert.Nil(t, NewSigner().VerifyHashSignature(hex.EncodeToString(pubKey), "hash", sig))
+	req.EncryptedAddress = "other addr"
+	assert.NotNil(t, NewSigner(nil).VerifyRequestEnvelopeSignature(env, req))
+
+	req.EncryptedAddress = "enc addr"
+	env.Nonce = "other nonce"
+	assert.NotNil(t, NewSigner(nil).VerifyRequestEnvelopeSignature(env, req))
+}
+
+/*
+Scenario: Sign the envelope of an unsupported request
+	Given a request which is not part of the External service
+	When I want to sign its envelope
+	Then I get an error
+*/
+func TestSignUnsupportedRequestEnvelope(t *testing.T) {
+	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	pvKey, _ := x509.MarshalECPrivateKey(key)
+	pubKey, _ := x509.MarshalPKIXPublicKey(key.Public())
+
+	env := newTestEnvelope("/api.Internal/GetAccount", hex.EncodeToString(pubKey))
+	err := NewSigner(nil).SignRequestEnvelope(env, &api.AccountSearchRequest{}, hex.EncodeToString(pvKey))
+	assert.Equal(t, ErrUnsupportedRequest, err)
 }
 
 /*
-Scenario: Sign and checks keychain validation request signature
-	Given a validation request and a key pair
-	When I want to sign the request and checks the signature generated
+Scenario: Sign and checks the envelope of keychain validation request
+	Given a validation request, an envelope and a key pair
+	When I want to sign the envelope and checks the signature generated
 	Then I get not error
 */
-func TestSignAndVerifyKeychainValidationRequestSignature(t *testing.T) {
+func TestSignAndVerifyKeychainValidationRequestEnvelopeSignature(t *testing.T) {
 	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
 	pvKey, _ := x509.MarshalECPrivateKey(key)
 	pubKey, _ := x509.MarshalPKIXPublicKey(key.Public())
@@ -180,20 +209,22 @@ func TestSignAndVerifyKeychainValidationRequestSignature(t *testing.T) {
 		TransactionHash: "txHash",
 	}
 
-	err := NewSigner().SignKeychainValidationRequestSignature(req, hex.EncodeToString(pvKey))
+	env := newTestEnvelope("/api.External/ValidateKeychain", hex.EncodeToString(pubKey))
+
+	err := NewSigner(nil).SignRequestEnvelope(env, req, hex.EncodeToString(pvKey))
 	assert.Nil(t, err)
-	assert.NotEmpty(t, req.Signature)
+	assert.NotEmpty(t, env.Signature)
 
-	assert.Nil(t, NewSigner().VerifyKeychainValidationRequestSignature(hex.EncodeToString(pubKey), req))
+	assert.Nil(t, NewSigner(nil).VerifyRequestEnvelopeSignature(env, req))
 }
 
 /*
-Scenario: Sign and checks ID validation request signature
-	Given a validation request and a key pair
-	When I want to sign the request and checks the signature generated
+Scenario: Sign and checks the envelope of ID validation request
+	Given a validation request, an envelope and a key pair
+	When I want to sign the envelope and checks the signature generated
 	Then I get not error
 */
-func TestSignAndVerifyIDValidationRequestSignature(t *testing.T) {
+func TestSignAndVerifyIDValidationRequestEnvelopeSignature(t *testing.T) {
 	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
 	pvKey, _ := x509.MarshalECPrivateKey(key)
 	pubKey, _ := x509.MarshalPKIXPublicKey(key.Public())
@@ -217,20 +248,22 @@ func TestSignAndVerifyIDValidationRequestSignature(t *testing.T) {
 		TransactionHash: "txHash",
 	}
 
-	err := NewSigner().SignIDValidationRequestSignature(req, hex.EncodeToString(pvKey))
+	env := newTestEnvelope("/api.External/ValidateID", hex.EncodeToString(pubKey))
+
+	err := NewSigner(nil).SignRequestEnvelope(env, req, hex.EncodeToString(pvKey))
 	assert.Nil(t, err)
-	assert.NotEmpty(t, req.Signature)
+	assert.NotEmpty(t, env.Signature)
 
-	assert.Nil(t, NewSigner().VerifyIDValidationRequestSignature(hex.EncodeToString(pubKey), req))
+	assert.Nil(t, NewSigner(nil).VerifyRequestEnvelopeSignature(env, req))
 }
 
 /*
-Scenario: Sign and checks keychain storage request signature
-	Given a storage request and a key pair
-	When I want to sign the request and checks the signature generated
+Scenario: Sign and checks the envelope of keychain storage request
+	Given a storage request, an envelope and a key pair
+	When I want to sign the envelope and checks the signature generated
 	Then I get not error
 */
-func TestSignAndVerifyKeychainStorageRequestSignature(t *testing.T) {
+func TestSignAndVerifyKeychainStorageRequestEnvelopeSignature(t *testing.T) {
 	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
 	pvKey, _ := x509.MarshalECPrivateKey(key)
 	pubKey, _ := x509.MarshalPKIXPublicKey(key.Public())
@@ -265,20 +298,22 @@ func TestSignAndVerifyKeychainStorageRequestSignature(t *testing.T) {
 		},
 	}
 
-	err := NewSigner().SignKeychainStorageRequestSignature(req, hex.EncodeToString(pvKey))
+	env := newTestEnvelope("/api.External/StoreKeychain", hex.EncodeToString(pubKey))
+
+	err := NewSigner(nil).SignRequestEnvelope(env, req, hex.EncodeToString(pvKey))
 	assert.Nil(t, err)
-	assert.NotEmpty(t, req.Signature)
+	assert.NotEmpty(t, env.Signature)
 
-	assert.Nil(t, NewSigner().VerifyKeychainStorageRequestSignature(hex.EncodeToString(pubKey), req))
+	assert.Nil(t, NewSigner(nil).VerifyRequestEnvelopeSignature(env, req))
 }
 
 /*
-Scenario: Sign and checks ID storage request signature
-	Given a storage request and a key pair
-	When I want to sign the request and checks the signature generated
+Scenario: Sign and checks the envelope of ID storage request
+	Given a storage request, an envelope and a key pair
+	When I want to sign the envelope and checks the signature generated
 	Then I get not error
 */
-func TestSignAndVerifyIDStorageRequestSignature(t *testing.T) {
+func TestSignAndVerifyIDStorageRequestEnvelopeSignature(t *testing.T) {
 	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
 	pvKey, _ := x509.MarshalECPrivateKey(key)
 	pubKey, _ := x509.MarshalPKIXPublicKey(key.Public())
@@ -315,20 +350,22 @@ func TestSignAndVerifyIDStorageRequestSignature(t *testing.T) {
 		},
 	}
 
-	err := NewSigner().SignIDStorageRequestSignature(req, hex.EncodeToString(pvKey))
+	env := newTestEnvelope("/api.External/StoreID", hex.EncodeToString(pubKey))
+
+	err := NewSigner(nil).SignRequestEnvelope(env, req, hex.EncodeToString(pvKey))
 	assert.Nil(t, err)
-	assert.NotEmpty(t, req.Signature)
+	assert.NotEmpty(t, env.Signature)
 
-	assert.Nil(t, NewSigner().VerifyIDStorageRequestSignature(hex.EncodeToString(pubKey), req))
+	assert.Nil(t, NewSigner(nil).VerifyRequestEnvelopeSignature(env, req))
 }
 
 /*
-Scenario: Sign and checks lock request signature
-	Given a lock request and a key pair
-	When I want to sign the request and checks the signature generated
+Scenario: Sign and checks the envelope of lock request
+	Given a lock request, an envelope and a key pair
+	When I want to sign the envelope and checks the signature generated
 	Then I get not error
 */
-func TestSignVerifyLockRequestSignature(t *testing.T) {
+func TestSignAndVerifyLockRequestEnvelopeSignature(t *testing.T) {
 	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
 	pvKey, _ := x509.MarshalECPrivateKey(key)
 	pubKey, _ := x509.MarshalPKIXPublicKey(key.Public())
@@ -339,20 +376,22 @@ func TestSignVerifyLockRequestSignature(t *testing.T) {
 		TransactionHash: "hash",
 	}
 
-	err := NewSigner().SignLockRequest(req, hex.EncodeToString(pvKey))
+	env := newTestEnvelope("/api.External/LockTransaction", hex.EncodeToString(pubKey))
+
+	err := NewSigner(nil).SignRequestEnvelope(env, req, hex.EncodeToString(pvKey))
 	assert.Nil(t, err)
-	assert.NotEmpty(t, req.Signature)
+	assert.NotEmpty(t, env.Signature)
 
-	assert.Nil(t, NewSigner().VerifyLockRequestSignature(hex.EncodeToString(pubKey), req))
+	assert.Nil(t, NewSigner(nil).VerifyRequestEnvelopeSignature(env, req))
 }
 
 /*
-Scenario: Sign and checks keychain lead mining request signature
-	Given a keychain lead mining request and a key pair
-	When I want to sign the request and checks the signature generated
+Scenario: Sign and checks the envelope of keychain lead mining request
+	Given a keychain lead mining request, an envelope and a key pair
+	When I want to sign the envelope and checks the signature generated
 	Then I get not error
 */
-func TestSignAndVerifyKeychainLeadRequestSignature(t *testing.T) {
+func TestSignAndVerifyKeychainLeadRequestEnvelopeSignature(t *testing.T) {
 	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
 	pvKey, _ := x509.MarshalECPrivateKey(key)
 	pubKey, _ := x509.MarshalPKIXPublicKey(key.Public())
@@ -363,20 +402,22 @@ func TestSignAndVerifyKeychainLeadRequestSignature(t *testing.T) {
 		ValidatorPeerIPs:  []string{"127.0.0.1"},
 	}
 
-	err := NewSigner().SignKeychainLeadRequest(req, hex.EncodeToString(pvKey))
+	env := newTestEnvelope("/api.External/LeadKeychainMining", hex.EncodeToString(pubKey))
+
+	err := NewSigner(nil).SignRequestEnvelope(env, req, hex.EncodeToString(pvKey))
 	assert.Nil(t, err)
-	assert.NotEmpty(t, req.SignatureRequest)
+	assert.NotEmpty(t, env.Signature)
 
-	assert.Nil(t, NewSigner().VerifyKeychainLeadRequestSignature(hex.EncodeToString(pubKey), req))
+	assert.Nil(t, NewSigner(nil).VerifyRequestEnvelopeSignature(env, req))
 }
 
 /*
-Scenario: Sign and checks ID lead mining request signature
-	Given a ID lead mining request and a key pair
-	When I want to sign the request and checks the signature generated
+Scenario: Sign and checks the envelope of ID lead mining request
+	Given a ID lead mining request, an envelope and a key pair
+	When I want to sign the envelope and checks the signature generated
 	Then I get not error
 */
-func TestSignAndVerifyIDLeadRequestSignature(t *testing.T) {
+func TestSignAndVerifyIDLeadRequestEnvelopeSignature(t *testing.T) {
 	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
 	pvKey, _ := x509.MarshalECPrivateKey(key)
 	pubKey, _ := x509.MarshalPKIXPublicKey(key.Public())
@@ -387,11 +428,13 @@ func TestSignAndVerifyIDLeadRequestSignature(t *testing.T) {
 		ValidatorPeerIPs: []string{"127.0.0.1"},
 	}
 
-	err := NewSigner().SignIDLeadRequest(req, hex.EncodeToString(pvKey))
+	env := newTestEnvelope("/api.External/LeadIDMining", hex.EncodeToString(pubKey))
+
+	err := NewSigner(nil).SignRequestEnvelope(env, req, hex.EncodeToString(pvKey))
 	assert.Nil(t, err)
-	assert.NotEmpty(t, req.SignatureRequest)
+	assert.NotEmpty(t, env.Signature)
 
-	assert.Nil(t, NewSigner().VerifyIDLeadRequestSignature(hex.EncodeToString(pubKey), req))
+	assert.Nil(t, NewSigner(nil).VerifyRequestEnvelopeSignature(env, req))
 }
 
 /*
@@ -414,11 +457,11 @@ func TestSignAndVerifyValidationResponseSignature(t *testing.T) {
 		},
 	}
 
-	err := NewSigner().SignValidationResponse(res, hex.EncodeToString(pvKey))
+	err := NewSigner(nil).SignValidationResponse(res, hex.EncodeToString(pvKey))
 	assert.Nil(t, err)
 	assert.NotEmpty(t, res.Signature)
 
-	assert.Nil(t, NewSigner().VerifyValidationResponseSignature(hex.EncodeToString(pubKey), res))
+	assert.Nil(t, NewSigner(nil).VerifyValidationResponseSignature(hex.EncodeToString(pubKey), res))
 }
 
 /*
@@ -436,11 +479,11 @@ func TestSignAndChecLockAckSignature(t *testing.T) {
 		LockHash: "hash",
 	}
 
-	err := NewSigner().SignLockAck(ack, hex.EncodeToString(pvKey))
+	err := NewSigner(nil).SignLockAck(ack, hex.EncodeToString(pvKey))
 	assert.Nil(t, err)
 	assert.NotEmpty(t, ack.Signature)
 
-	assert.Nil(t, NewSigner().VerifyLockAckSignature(hex.EncodeToString(pubKey), ack))
+	assert.Nil(t, NewSigner(nil).VerifyLockAckSignature(hex.EncodeToString(pubKey), ack))
 }
 
 /*
@@ -458,11 +501,11 @@ func TestSignAndVerifyStorageAckSignature(t *testing.T) {
 		StorageHash: "hash",
 	}
 
-	err := NewSigner().SignStorageAck(ack, hex.EncodeToString(pvKey))
+	err := NewSigner(nil).SignStorageAck(ack, hex.EncodeToString(pvKey))
 	assert.Nil(t, err)
 	assert.NotEmpty(t, ack.Signature)
 
-	assert.Nil(t, NewSigner().VerifyStorageAckSignature(hex.EncodeToString(pubKey), ack))
+	assert.Nil(t, NewSigner(nil).VerifyStorageAckSignature(hex.EncodeToString(pubKey), ack))
 }
 
 /*
@@ -481,12 +524,10 @@ func TestSignAndVerifyAccountSearchResultSignature(t *testing.T) {
 		EncryptedAESkey:  "enc aes key",
 		EncryptedWallet:  "enc wallet",
 	}
-	b, _ := json.Marshal(res)
-
-	assert.Nil(t, NewSigner().SignAccountSearchResult(res, hex.EncodeToString(pvKey)))
+	assert.Nil(t, NewSigner(nil).SignAccountSearchResult(res, hex.EncodeToString(pvKey)))
 	assert.NotEmpty(t, res.Signature)
 
-	checkSignature(hex.EncodeToString(pubKey), string(b), res.Signature)
+	assert.Nil(t, checkSignature(hex.EncodeToString(pubKey), string(encodeAccountSearchResult(res)), res.Signature))
 }
 
 /*
@@ -504,12 +545,10 @@ func TestSignAndVerifyCreationResultSignature(t *testing.T) {
 		MasterPeerIP:    "127.0.0.1",
 		TransactionHash: "hash",
 	}
-	b, _ := json.Marshal(res)
-
-	assert.Nil(t, NewSigner().SignCreationResult(res, hex.EncodeToString(pvKey)))
+	assert.Nil(t, NewSigner(nil).SignCreationResult(res, hex.EncodeToString(pvKey)))
 	assert.NotEmpty(t, res.Signature)
 
-	checkSignature(hex.EncodeToString(pubKey), string(b), res.Signature)
+	assert.Nil(t, checkSignature(hex.EncodeToString(pubKey), string(encodeCreationResult(res)), res.Signature))
 }
 
 /*
@@ -554,10 +593,10 @@ func TestSignAndVerifyIDResponseSignature(t *testing.T) {
 			},
 		},
 	}
-	assert.Nil(t, NewSigner().SignIDResponse(res, hex.EncodeToString(pvKey)))
+	assert.Nil(t, NewSigner(nil).SignIDResponse(res, hex.EncodeToString(pvKey)))
 	assert.NotEmpty(t, res.Signature)
 
-	assert.Nil(t, NewSigner().VerifyIDResponseSignature(hex.EncodeToString(pubKey), res))
+	assert.Nil(t, NewSigner(nil).VerifyIDResponseSignature(hex.EncodeToString(pubKey), res))
 }
 
 /*
@@ -600,10 +639,10 @@ func TestSignAndVerifyKeychainResponseSignature(t *testing.T) {
 			},
 		},
 	}
-	assert.Nil(t, NewSigner().SignKeychainResponse(res, hex.EncodeToString(pvKey)))
+	assert.Nil(t, NewSigner(nil).SignKeychainResponse(res, hex.EncodeToString(pvKey)))
 	assert.NotEmpty(t, res.Signature)
 
-	assert.Nil(t, NewSigner().VerifyKeychainResponseSignature(hex.EncodeToString(pubKey), res))
+	assert.Nil(t, NewSigner(nil).VerifyKeychainResponseSignature(hex.EncodeToString(pubKey), res))
 }
 
 /*
@@ -618,9 +657,105 @@ func TestSignAndVerifyValidationSignature(t *testing.T) {
 	pubKey, _ := x509.MarshalPKIXPublicKey(key.Public())
 
 	v := mining.NewValidation(mining.ValidationOK, time.Now(), hex.EncodeToString(pubKey), "")
-	sValid, err := NewSigner().SignValidation(v, hex.EncodeToString(pvKey))
+	vPool := []string{hex.EncodeToString(pubKey)}
+	sValid, err := NewSigner(nil).SignValidation(v, "tx hash", vPool, hex.EncodeToString(pvKey))
 	assert.Nil(t, err)
 	assert.NotEmpty(t, sValid.Signature())
 
-	assert.Nil(t, NewSigner().VerifyValidationSignature(sValid))
+	assert.Nil(t, NewSigner(nil).VerifyValidationSignature("tx hash", vPool, sValid))
+}
+
+/*
+Scenario: Checks a validation signature for another transaction or validation pool
+	Given a validation signed for a transaction and a validation pool
+	When I want to check the signature with another transaction hash or another validation pool
+	Then I get an error
+*/
+func TestVerifyValidationSignatureOtherTransaction(t *testing.T) {
+	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	pvKey, _ := x509.MarshalECPrivateKey(key)
+	pubKey, _ := x509.MarshalPKIXPublicKey(key.Public())
+
+	v := mining.NewValidation(mining.ValidationOK, time.Now(), hex.EncodeToString(pubKey), "")
+	vPool := []string{hex.EncodeToString(pubKey)}
+	sValid, err := NewSigner(nil).SignValidation(v, "tx hash", vPool, hex.EncodeToString(pvKey))
+	assert.Nil(t, err)
+
+	assert.NotNil(t, NewSigner(nil).VerifyValidationSignature("other tx hash", vPool, sValid))
+	assert.NotNil(t, NewSigner(nil).VerifyValidationSignature("tx hash", append(vPool, "other key"), sValid))
+}
+
+/*
+Scenario: Sign a payload for the API service
+	Given an account creation result or a transaction callback encoded by the API service
+	When I want to sign it with the robot key
+	Then I get a valid signature of the payload
+*/
+func TestSignPayload(t *testing.T) {
+	pub, pv, _ := keys.GenerateKeyPair(keys.ECDSAP256)
+
+	e := newEncoder(canonical.AccountCreationResultPayload)
+	e.WriteString("hash")
+	payload := e.Bytes()
+
+	sig, err := NewSigner(nil).SignPayload(payload, pv)
+	assert.Nil(t, err)
+	assert.Nil(t, checkSignature(pub, string(payload), sig))
+
+	e = newEncoder(canonical.TransactionCallbackPayload)
+	e.WriteString("hash")
+	payload = e.Bytes()
+
+	sig, err = NewSigner(nil).SignPayload(payload, pv)
+	assert.Nil(t, err)
+	assert.Nil(t, checkSignature(pub, string(payload), sig))
+}
+
+/*
+Scenario: Sign a payload not built by the API service
+	Given a lock encoded by the datamining service
+	When the API service asks to sign it with the robot key
+	Then I get an error
+*/
+func TestSignPayloadNotAllowed(t *testing.T) {
+	_, pv, _ := keys.GenerateKeyPair(keys.ECDSAP256)
+
+	_, err := NewSigner(nil).SignPayload(encodeLock(lock.TransactionLock{TxHash: "hash"}), pv)
+	assert.Equal(t, ErrUnsignablePayload, err)
+
+	_, err = NewSigner(nil).SignPayload([]byte("hash"), pv)
+	assert.Equal(t, ErrUnsignablePayload, err)
+}
+
+func newTestEnvelope(method string, pubKey string) *api.RequestEnvelope {
+	return &api.RequestEnvelope{
+		Method:    method,
+		Signer:    pubKey,
+		Timestamp: time.Now().Unix(),
+		Nonce:     "nonce",
+	}
+}
+
+/*
+Scenario: Check the signature of an emitter authorization
+	Given an emitter authorization signed by an authority
+	When I check it as an authorization and as a revocation
+	Then the signature is only valid for the authorization
+*/
+func TestVerifyEmitterAuthorizationRequestSignature(t *testing.T) {
+	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	pvKey, _ := x509.MarshalECPrivateKey(key)
+	pubKey, _ := x509.MarshalPKIXPublicKey(key.Public())
+
+	req := &api.EmitterAuthorizationRequest{
+		PublicKey: "em key",
+		Timestamp: time.Now().Unix(),
+		Nonce:     "nonce",
+	}
+	sig, err := keys.Sign(hex.EncodeToString(pvKey), encodeEmitterAuthorizationRequest(req, canonical.EmitterAuthorizationRequestPayload))
+	assert.Nil(t, err)
+	req.Signature = sig
+
+	assert.Nil(t, NewSigner(nil).VerifyEmitterAuthorizationRequestSignature(req, hex.EncodeToString(pubKey)))
+	assert.NotNil(t, NewSigner(nil).VerifyEmitterRevocationRequestSignature(req, hex.EncodeToString(pubKey)))
 }
@@ -1,10 +1,166 @@
 package adding
 
-import "github.com/uniris/uniris-core/datamining/pkg/emitter"
+import (
+	"time"
+
+	"github.com/uniris/uniris-core/datamining/pkg/emitter"
+	"github.com/uniris/uniris-core/datamining/pkg/emitter/listing"
+	"github.com/uniris/uniris-core/datamining/pkg/system"
+)
 
 //Repository define methods to handle emitter keys storage
 type Repository interface {
 
 	//StoreSharedEmitterKeyPair stores a shared emitter keypair
 	StoreSharedEmitterKeyPair(kp emitter.SharedKeyPair) error
+
+	//StoreSharedEmitterKeyPairProposal stores a proposal of shared emitter keypair
+	StoreSharedEmitterKeyPairProposal(p emitter.SharedKeyPairProposal) error
+
+	//FindSharedEmitterKeyPairProposal retrieves the proposal for a shared emitter public key
+	FindSharedEmitterKeyPairProposal(pubKey string) (*emitter.SharedKeyPairProposal, error)
+
+	//RemoveSharedEmitterKeyPairProposal removes the proposal for a shared emitter public key
+	RemoveSharedEmitterKeyPairProposal(pubKey string) error
+
+	//ListSharedEmitterKeyPairs retrieves the shared emitter keypairs, including the ones waiting for their activation date
+	ListSharedEmitterKeyPairs() ([]emitter.SharedKeyPair, error)
+
+	//RetireSharedEmitterKeyPairs moves the shared emitter keypairs to the retired keypairs,
+	//which are replaced at the retirement date and remain valid for verification until the expiration date
+	RetireSharedEmitterKeyPairs(retirement time.Time, expiration time.Time) error
+
+	//StoreEmitterAuthorization stores or updates the authorization of an emitter public key
+	StoreEmitterAuthorization(a emitter.Authorization) error
+
+	//FindEmitterAuthorization retrieves the authorization of an emitter public key
+	FindEmitterAuthorization(pubKey string) (*emitter.Authorization, error)
+}
+
+//Service defines methods to handle the shared emitter keys rotation
+type Service interface {
+
+	//ProposeSharedEmitterKeyPair endorses a proposed shared emitter keypair by the emitter of a stored transaction
+	//
+	//The endorser is the ID public key which signed the proposal within the transaction data.
+	//Only authorized emitters endorse a proposal and each of them is counted once.
+	//
+	//The proposal carries the activation date of the keypair, so all the nodes switch to it at the same time:
+	//an endorsement is only counted when it is stored at least the activation delay before the activation date,
+	//and the activation date must follow the ones already scheduled.
+	//Once enough distinct emitters endorsed the proposal, the keypair is scheduled at its activation date
+	//and the previous keypairs remain valid for verification during the grace period after it
+	ProposeSharedEmitterKeyPair(kp emitter.SharedKeyPair, endorser string) error
+
+	//AuthorizeEmitter registers an emitter public key as authorized
+	//
+	//A revoked emitter cannot be authorized again
+	AuthorizeEmitter(pubKey string) error
+
+	//RevokeEmitter revokes the authorization of an emitter public key
+	RevokeEmitter(pubKey string) error
+}
+
+type service struct {
+	repo   Repository
+	lister listing.Service
+	conf   system.UnirisConfig
+}
+
+//NewService creates a new service for the shared emitter keys rotation
+func NewService(repo Repository, lister listing.Service, conf system.UnirisConfig) Service {
+	return service{repo, lister, conf}
+}
+
+func (s service) ProposeSharedEmitterKeyPair(kp emitter.SharedKeyPair, endorser string) error {
+	//Rotation is disabled without endorsements threshold
+	rotation := s.conf.SharedKeys.EmRotation
+	if rotation.MinEndorsements <= 0 || kp.PublicKey == "" {
+		return nil
+	}
+
+	//A late endorsement could reach the threshold on some nodes only after the activation date
+	if kp.ActivationDate.Before(time.Now().Add(rotation.ActivationDelay)) {
+		return nil
+	}
+
+	scheduled, err := s.repo.ListSharedEmitterKeyPairs()
+	if err != nil {
+		return err
+	}
+	for _, c := range scheduled {
+		if c.PublicKey == kp.PublicKey || !c.ActivationDate.Before(kp.ActivationDate) {
+			return nil
+		}
+	}
+
+	//An unauthorized or revoked emitter cannot endorse a proposal
+	if err := s.lister.IsEmitterAuthorized(endorser); err != nil {
+		if err == listing.ErrUnauthorizedEmitter {
+			return nil
+		}
+		return err
+	}
+
+	prop, err := s.repo.FindSharedEmitterKeyPairProposal(kp.PublicKey)
+	if err != nil {
+		return err
+	}
+	if prop == nil {
+		prop = &emitter.SharedKeyPairProposal{KeyPair: kp}
+	}
+
+	//The endorsers must agree on the activation date
+	if !prop.KeyPair.ActivationDate.Equal(kp.ActivationDate) {
+		return nil
+	}
+	for _, e := range prop.Endorsers {
+		if e == endorser {
+			return nil
+		}
+	}
+	prop.Endorsers = append(prop.Endorsers, endorser)
+
+	if len(prop.Endorsers) < rotation.MinEndorsements {
+		return s.repo.StoreSharedEmitterKeyPairProposal(*prop)
+	}
+
+	//Schedules the proposed keypair and keeps the previous ones during the grace period after its activation
+	activation := prop.KeyPair.ActivationDate
+	if err := s.repo.RetireSharedEmitterKeyPairs(activation, activation.Add(rotation.GracePeriod)); err != nil {
+		return err
+	}
+	if err := s.repo.StoreSharedEmitterKeyPair(prop.KeyPair); err != nil {
+		return err
+	}
+	return s.repo.RemoveSharedEmitterKeyPairProposal(kp.PublicKey)
+}
+
+func (s service) AuthorizeEmitter(pubKey string) error {
+	auth, err := s.repo.FindEmitterAuthorization(pubKey)
+	if err != nil {
+		return err
+	}
+	if auth != nil {
+		return nil
+	}
+	return s.repo.StoreEmitterAuthorization(emitter.Authorization{
+		PublicKey:         pubKey,
+		AuthorizationDate: time.Now(),
+	})
+}
+
+func (s service) RevokeEmitter(pubKey string) error {
+	auth, err := s.repo.FindEmitterAuthorization(pubKey)
+	if err != nil {
+		return err
+	}
+	if auth == nil {
+		return listing.ErrUnauthorizedEmitter
+	}
+	if auth.Revoked() {
+		return nil
+	}
+	auth.RevocationDate = time.Now()
+	return s.repo.StoreEmitterAuthorization(*auth)
 }
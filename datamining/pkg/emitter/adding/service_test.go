@@ -0,0 +1,331 @@
+package adding
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/uniris/uniris-core/datamining/pkg/emitter"
+	"github.com/uniris/uniris-core/datamining/pkg/emitter/listing"
+	"github.com/uniris/uniris-core/datamining/pkg/system"
+)
+
+/*
+Scenario: Propose a shared emitter keypair without enough endorsements
+	Given a rotation requiring two endorsements
+	When an authorized emitter proposes a new shared emitter keypair
+	Then the proposal is stored and the current keypair is unchanged
+*/
+func TestProposeSharedEmitterKeyPair(t *testing.T) {
+	repo := newRotationDatabase("em1", "em2")
+	s := NewService(repo, listing.NewService(repo), rotationConfig(2, time.Minute, time.Hour))
+
+	kp := emitter.SharedKeyPair{PublicKey: "key2", EncryptedPrivateKey: "enc key2", ActivationDate: time.Now().Add(time.Hour)}
+	assert.Nil(t, s.ProposeSharedEmitterKeyPair(kp, "em1"))
+	assert.Len(t, repo.proposals, 1)
+	assert.Equal(t, []string{"em1"}, repo.proposals[0].Endorsers)
+	assert.Len(t, repo.sharedKeys, 1)
+	assert.Equal(t, "key1", repo.sharedKeys[0].PublicKey)
+}
+
+/*
+Scenario: Endorse twice a proposal by the same emitter
+	Given a proposal endorsed by an emitter
+	When the same emitter proposes again the keypair with another transaction
+	Then the endorsement is not counted twice and the keypair is not scheduled
+*/
+func TestProposeSharedEmitterKeyPairSameEmitter(t *testing.T) {
+	repo := newRotationDatabase("em1", "em2")
+	s := NewService(repo, listing.NewService(repo), rotationConfig(2, time.Minute, time.Hour))
+
+	kp := emitter.SharedKeyPair{PublicKey: "key2", ActivationDate: time.Now().Add(time.Hour)}
+	assert.Nil(t, s.ProposeSharedEmitterKeyPair(kp, "em1"))
+	assert.Nil(t, s.ProposeSharedEmitterKeyPair(kp, "em1"))
+	assert.Len(t, repo.proposals[0].Endorsers, 1)
+	assert.Len(t, repo.sharedKeys, 1)
+}
+
+/*
+Scenario: Endorse a proposal by an unauthorized emitter
+	Given an unknown emitter and a revoked emitter
+	When they propose a new shared emitter keypair
+	Then the endorsements are ignored
+*/
+func TestProposeSharedEmitterKeyPairUnauthorizedEmitter(t *testing.T) {
+	repo := newRotationDatabase("em1")
+	lister := listing.NewService(repo)
+	s := NewService(repo, lister, rotationConfig(1, time.Minute, time.Hour))
+	assert.Nil(t, s.RevokeEmitter("em1"))
+
+	kp := emitter.SharedKeyPair{PublicKey: "key2", ActivationDate: time.Now().Add(time.Hour)}
+	assert.Nil(t, s.ProposeSharedEmitterKeyPair(kp, "em1"))
+	assert.Nil(t, s.ProposeSharedEmitterKeyPair(kp, "unknown"))
+	assert.Empty(t, repo.proposals)
+	assert.Len(t, repo.sharedKeys, 1)
+}
+
+/*
+Scenario: Endorse a proposal too close to its activation date
+	Given a rotation requiring the endorsements one hour before the activation
+	When an authorized emitter endorses a keypair activated in half an hour, or without activation date
+	Then the endorsements are ignored
+*/
+func TestProposeSharedEmitterKeyPairLateEndorsement(t *testing.T) {
+	repo := newRotationDatabase("em1")
+	s := NewService(repo, listing.NewService(repo), rotationConfig(1, time.Hour, time.Hour))
+
+	assert.Nil(t, s.ProposeSharedEmitterKeyPair(emitter.SharedKeyPair{PublicKey: "key2", ActivationDate: time.Now().Add(30 * time.Minute)}, "em1"))
+	assert.Nil(t, s.ProposeSharedEmitterKeyPair(emitter.SharedKeyPair{PublicKey: "key2"}, "em1"))
+	assert.Empty(t, repo.proposals)
+	assert.Len(t, repo.sharedKeys, 1)
+	assert.Empty(t, repo.retired)
+}
+
+/*
+Scenario: Endorse a proposal with another activation date
+	Given a keypair proposed by an emitter with an activation date
+	When another emitter endorses the same keypair with another activation date
+	Then the endorsement is not counted and the keypair is not scheduled
+*/
+func TestProposeSharedEmitterKeyPairOtherActivationDate(t *testing.T) {
+	repo := newRotationDatabase("em1", "em2")
+	s := NewService(repo, listing.NewService(repo), rotationConfig(2, time.Minute, time.Hour))
+
+	activation := time.Now().Add(time.Hour)
+	assert.Nil(t, s.ProposeSharedEmitterKeyPair(emitter.SharedKeyPair{PublicKey: "key2", ActivationDate: activation}, "em1"))
+	assert.Nil(t, s.ProposeSharedEmitterKeyPair(emitter.SharedKeyPair{PublicKey: "key2", ActivationDate: activation.Add(time.Hour)}, "em2"))
+	assert.Equal(t, []string{"em1"}, repo.proposals[0].Endorsers)
+	assert.Len(t, repo.sharedKeys, 1)
+}
+
+/*
+Scenario: Activate a proposed shared emitter keypair at its activation date
+	Given a rotation requiring two endorsements
+	When two distinct authorized emitters propose the same new keypair
+	Then the current keypair is unchanged until the activation date
+	And the keypair becomes the current one at the activation date, the previous keypair being kept during the grace period
+*/
+func TestActivateSharedEmitterKeyPair(t *testing.T) {
+	repo := newRotationDatabase("em1", "em2")
+	lister := listing.NewService(repo)
+	s := NewService(repo, lister, rotationConfig(2, 0, time.Hour))
+
+	kp := emitter.SharedKeyPair{PublicKey: "key2", EncryptedPrivateKey: "enc key2", ActivationDate: time.Now().Add(200 * time.Millisecond)}
+	assert.Nil(t, s.ProposeSharedEmitterKeyPair(kp, "em1"))
+	assert.Nil(t, s.ProposeSharedEmitterKeyPair(kp, "em2"))
+	assert.Empty(t, repo.proposals)
+
+	current, _ := lister.ListSharedEmitterKeyPairs()
+	assert.Len(t, current, 1)
+	assert.Equal(t, "key1", current[0].PublicKey)
+	previous, _ := lister.ListPreviousSharedEmitterKeyPairs()
+	assert.Empty(t, previous)
+
+	time.Sleep(300 * time.Millisecond)
+
+	current, _ = lister.ListSharedEmitterKeyPairs()
+	assert.Len(t, current, 1)
+	assert.Equal(t, "key2", current[0].PublicKey)
+	assert.Equal(t, "enc key2", current[0].EncryptedPrivateKey)
+
+	previous, _ = lister.ListPreviousSharedEmitterKeyPairs()
+	assert.Len(t, previous, 1)
+	assert.Equal(t, "key1", previous[0].KeyPair.PublicKey)
+	assert.Equal(t, kp.ActivationDate.Add(time.Hour), previous[0].ExpirationDate)
+}
+
+/*
+Scenario: Propose a keypair activated before a scheduled keypair
+	Given a keypair scheduled in two hours
+	When a keypair activated in one hour is endorsed
+	Then the endorsement is ignored
+*/
+func TestProposeSharedEmitterKeyPairBeforeScheduled(t *testing.T) {
+	repo := newRotationDatabase("em1")
+	s := NewService(repo, listing.NewService(repo), rotationConfig(1, time.Minute, time.Hour))
+
+	assert.Nil(t, s.ProposeSharedEmitterKeyPair(emitter.SharedKeyPair{PublicKey: "key2", ActivationDate: time.Now().Add(2 * time.Hour)}, "em1"))
+	assert.Nil(t, s.ProposeSharedEmitterKeyPair(emitter.SharedKeyPair{PublicKey: "key3", ActivationDate: time.Now().Add(time.Hour)}, "em1"))
+	assert.Len(t, repo.sharedKeys, 1)
+	assert.Equal(t, "key2", repo.sharedKeys[0].PublicKey)
+	assert.Empty(t, repo.proposals)
+}
+
+/*
+Scenario: Previous keypair after the grace period
+	Given a keypair retired with an expired grace period
+	When I list the previous keypairs after the activation date
+	Then the expired keypair is not returned
+*/
+func TestExpiredPreviousSharedEmitterKeyPair(t *testing.T) {
+	repo := newRotationDatabase("em1")
+	lister := listing.NewService(repo)
+	s := NewService(repo, lister, rotationConfig(1, 0, -time.Hour))
+
+	assert.Nil(t, s.ProposeSharedEmitterKeyPair(emitter.SharedKeyPair{PublicKey: "key2", ActivationDate: time.Now().Add(50 * time.Millisecond)}, "em1"))
+	time.Sleep(100 * time.Millisecond)
+
+	previous, _ := lister.ListPreviousSharedEmitterKeyPairs()
+	assert.Empty(t, previous)
+}
+
+/*
+Scenario: Propose a shared emitter keypair when the rotation is disabled
+	Given no endorsements threshold configured
+	When a transaction proposes a new shared emitter keypair
+	Then the proposal is ignored
+*/
+func TestProposeSharedEmitterKeyPairRotationDisabled(t *testing.T) {
+	repo := newRotationDatabase("em1")
+	s := NewService(repo, listing.NewService(repo), system.UnirisConfig{})
+
+	assert.Nil(t, s.ProposeSharedEmitterKeyPair(emitter.SharedKeyPair{PublicKey: "key2", ActivationDate: time.Now().Add(time.Hour)}, "em1"))
+	assert.Empty(t, repo.proposals)
+	assert.Len(t, repo.sharedKeys, 1)
+}
+
+/*
+Scenario: Authorize an emitter
+	Given an unknown emitter public key
+	When I authorize it
+	Then the emitter is authorized
+*/
+func TestAuthorizeEmitter(t *testing.T) {
+	repo := &mockDatabase{}
+	lister := listing.NewService(repo)
+	s := NewService(repo, lister, system.UnirisConfig{})
+
+	assert.Equal(t, listing.ErrUnauthorizedEmitter, lister.IsEmitterAuthorized("em key"))
+	assert.Nil(t, s.AuthorizeEmitter("em key"))
+	assert.Nil(t, lister.IsEmitterAuthorized("em key"))
+}
+
+/*
+Scenario: Revoke an emitter
+	Given an authorized emitter
+	When I revoke it and authorize it again
+	Then the emitter is no longer authorized
+*/
+func TestRevokeEmitter(t *testing.T) {
+	repo := &mockDatabase{}
+	lister := listing.NewService(repo)
+	s := NewService(repo, lister, system.UnirisConfig{})
+
+	assert.Nil(t, s.AuthorizeEmitter("em key"))
+	assert.Nil(t, s.RevokeEmitter("em key"))
+	assert.Equal(t, listing.ErrUnauthorizedEmitter, lister.IsEmitterAuthorized("em key"))
+
+	assert.Nil(t, s.AuthorizeEmitter("em key"))
+	assert.Equal(t, listing.ErrUnauthorizedEmitter, lister.IsEmitterAuthorized("em key"))
+}
+
+/*
+Scenario: Revoke an unknown emitter
+	Given an unknown emitter public key
+	When I revoke it
+	Then I get an error
+*/
+func TestRevokeUnknownEmitter(t *testing.T) {
+	repo := &mockDatabase{}
+	s := NewService(repo, listing.NewService(repo), system.UnirisConfig{})
+
+	assert.Equal(t, listing.ErrUnauthorizedEmitter, s.RevokeEmitter("em key"))
+}
+
+func rotationConfig(minEndorsements int, activationDelay time.Duration, gracePeriod time.Duration) system.UnirisConfig {
+	return system.UnirisConfig{
+		SharedKeys: system.SharedKeys{
+			EmRotation: system.KeyRotation{
+				MinEndorsements: minEndorsements,
+				ActivationDelay: activationDelay,
+				GracePeriod:     gracePeriod,
+			},
+		},
+	}
+}
+
+func newRotationDatabase(emitters ...string) *mockDatabase {
+	db := &mockDatabase{sharedKeys: []emitter.SharedKeyPair{emitter.SharedKeyPair{PublicKey: "key1"}}}
+	for _, em := range emitters {
+		db.auths = append(db.auths, emitter.Authorization{PublicKey: em, AuthorizationDate: time.Now()})
+	}
+	return db
+}
+
+type mockDatabase struct {
+	sharedKeys []emitter.SharedKeyPair
+	retired    []emitter.RetiredSharedKeyPair
+	proposals  []emitter.SharedKeyPairProposal
+	auths      []emitter.Authorization
+}
+
+func (d *mockDatabase) StoreSharedEmitterKeyPair(kp emitter.SharedKeyPair) error {
+	d.sharedKeys = append(d.sharedKeys, kp)
+	return nil
+}
+
+func (d *mockDatabase) ListSharedEmitterKeyPairs() ([]emitter.SharedKeyPair, error) {
+	return d.sharedKeys, nil
+}
+
+func (d *mockDatabase) ListRetiredSharedEmitterKeyPairs() ([]emitter.RetiredSharedKeyPair, error) {
+	return d.retired, nil
+}
+
+func (d *mockDatabase) RetireSharedEmitterKeyPairs(retirement time.Time, expiration time.Time) error {
+	for _, kp := range d.sharedKeys {
+		d.retired = append(d.retired, emitter.RetiredSharedKeyPair{KeyPair: kp, RetirementDate: retirement, ExpirationDate: expiration})
+	}
+	d.sharedKeys = make([]emitter.SharedKeyPair, 0)
+	return nil
+}
+
+func (d *mockDatabase) StoreSharedEmitterKeyPairProposal(p emitter.SharedKeyPairProposal) error {
+	for i, prop := range d.proposals {
+		if prop.KeyPair.PublicKey == p.KeyPair.PublicKey {
+			d.proposals[i] = p
+			return nil
+		}
+	}
+	d.proposals = append(d.proposals, p)
+	return nil
+}
+
+func (d *mockDatabase) FindSharedEmitterKeyPairProposal(pubKey string) (*emitter.SharedKeyPairProposal, error) {
+	for _, prop := range d.proposals {
+		if prop.KeyPair.PublicKey == pubKey {
+			return &prop, nil
+		}
+	}
+	return nil, nil
+}
+
+func (d *mockDatabase) RemoveSharedEmitterKeyPairProposal(pubKey string) error {
+	for i, prop := range d.proposals {
+		if prop.KeyPair.PublicKey == pubKey {
+			d.proposals = append(d.proposals[:i], d.proposals[i+1:]...)
+			return nil
+		}
+	}
+	return nil
+}
+
+func (d *mockDatabase) StoreEmitterAuthorization(a emitter.Authorization) error {
+	for i, auth := range d.auths {
+		if auth.PublicKey == a.PublicKey {
+			d.auths[i] = a
+			return nil
+		}
+	}
+	d.auths = append(d.auths, a)
+	return nil
+}
+
+func (d *mockDatabase) FindEmitterAuthorization(pubKey string) (*emitter.Authorization, error) {
+	for _, auth := range d.auths {
+		if auth.PublicKey == pubKey {
+			return &auth, nil
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,15 @@
+package emitter
+
+import "time"
+
+//Authorization represents the authorization of an emitter public key
+type Authorization struct {
+	PublicKey         string
+	AuthorizationDate time.Time
+	RevocationDate    time.Time
+}
+
+//Revoked returns true if the emitter authorization has been revoked
+func (a Authorization) Revoked() bool {
+	return !a.RevocationDate.IsZero()
+}
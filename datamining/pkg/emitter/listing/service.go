@@ -2,27 +2,40 @@ package listing
 
 import (
 	"errors"
+	"time"
 
 	"github.com/uniris/uniris-core/datamining/pkg/emitter"
 )
 
+//ErrUnauthorizedEmitter is returned when the emitter is unknown or revoked
 var ErrUnauthorizedEmitter = errors.New("Unauthorized emitter")
 
 //Repository defines methods to handle emitters sotrage
 type Repository interface {
 
-	//ListSharedEmitterKeyPairs retrieves the shared emitter keypair
+	//ListSharedEmitterKeyPairs retrieves the shared emitter keypairs, including the ones waiting for their activation date
 	ListSharedEmitterKeyPairs() ([]emitter.SharedKeyPair, error)
+
+	//ListRetiredSharedEmitterKeyPairs retrieves the retired shared emitter keypairs, including the ones waiting for their retirement date
+	ListRetiredSharedEmitterKeyPairs() ([]emitter.RetiredSharedKeyPair, error)
+
+	//FindEmitterAuthorization retrieves the authorization of an emitter public key
+	FindEmitterAuthorization(pubKey string) (*emitter.Authorization, error)
 }
 
 //Service define methods to list emitters
 type Service interface {
 
 	//IsEmitterAuthorized checks if the emitter public key is authorized
+	//
+	//ErrUnauthorizedEmitter is returned when the emitter is unknown or revoked
 	IsEmitterAuthorized(pubKey string) error
 
-	//ListSharedEmitterKeyPairs get the shared emitter key pairs
+	//ListSharedEmitterKeyPairs get the shared emitter key pairs activated and not yet retired
 	ListSharedEmitterKeyPairs() ([]emitter.SharedKeyPair, error)
+
+	//ListPreviousSharedEmitterKeyPairs get the previous shared emitter key pairs which are retired but not yet expired
+	ListPreviousSharedEmitterKeyPairs() ([]emitter.RetiredSharedKeyPair, error)
 }
 
 type service struct {
@@ -35,10 +48,54 @@ func NewService(repo Repository) Service {
 }
 
 func (s service) ListSharedEmitterKeyPairs() ([]emitter.SharedKeyPair, error) {
-	return s.repo.ListSharedEmitterKeyPairs()
+	stored, err := s.repo.ListSharedEmitterKeyPairs()
+	if err != nil {
+		return nil, err
+	}
+	retired, err := s.repo.ListRetiredSharedEmitterKeyPairs()
+	if err != nil {
+		return nil, err
+	}
+
+	//The keypairs are switched at the activation dates, so all the nodes use the same keys at the same time
+	now := time.Now()
+	kps := make([]emitter.SharedKeyPair, 0)
+	for _, kp := range stored {
+		if kp.Active(now) {
+			kps = append(kps, kp)
+		}
+	}
+	for _, kp := range retired {
+		if kp.Current(now) {
+			kps = append(kps, kp.KeyPair)
+		}
+	}
+	return kps, nil
+}
+
+func (s service) ListPreviousSharedEmitterKeyPairs() ([]emitter.RetiredSharedKeyPair, error) {
+	retired, err := s.repo.ListRetiredSharedEmitterKeyPairs()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	kps := make([]emitter.RetiredSharedKeyPair, 0)
+	for _, kp := range retired {
+		if kp.Previous(now) {
+			kps = append(kps, kp)
+		}
+	}
+	return kps, nil
 }
 
 func (s service) IsEmitterAuthorized(pubKey string) error {
-	//TODO: request smart contract
+	auth, err := s.repo.FindEmitterAuthorization(pubKey)
+	if err != nil {
+		return err
+	}
+	if auth == nil || auth.Revoked() {
+		return ErrUnauthorizedEmitter
+	}
 	return nil
 }
@@ -1,7 +1,41 @@
 package emitter
 
+import "time"
+
 //SharedKeyPair represent a shared key pair
+//
+//All the nodes switch to the key pair at its activation date
 type SharedKeyPair struct {
 	PublicKey           string
 	EncryptedPrivateKey string
+	ActivationDate      time.Time
+}
+
+//Active checks if the key pair is activated at the given time
+func (kp SharedKeyPair) Active(t time.Time) bool {
+	return !kp.ActivationDate.After(t)
+}
+
+//SharedKeyPairProposal represents a shared key pair endorsed by distinct authorized emitters
+type SharedKeyPairProposal struct {
+	KeyPair   SharedKeyPair
+	Endorsers []string
+}
+
+//RetiredSharedKeyPair represents a shared key pair replaced at its retirement date,
+//and still valid for verification until its expiration date
+type RetiredSharedKeyPair struct {
+	KeyPair        SharedKeyPair
+	RetirementDate time.Time
+	ExpirationDate time.Time
+}
+
+//Current checks if the key pair is still the current one at the given time
+func (kp RetiredSharedKeyPair) Current(t time.Time) bool {
+	return kp.KeyPair.Active(t) && kp.RetirementDate.After(t)
+}
+
+//Previous checks if the key pair is retired but not yet expired at the given time
+func (kp RetiredSharedKeyPair) Previous(t time.Time) bool {
+	return !kp.RetirementDate.After(t) && kp.ExpirationDate.After(t)
 }
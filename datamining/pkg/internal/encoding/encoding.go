@@ -0,0 +1,127 @@
+package encoding
+
+import (
+	datamining "github.com/uniris/uniris-core/datamining/pkg"
+	"github.com/uniris/uniris-core/datamining/pkg/account"
+	"github.com/uniris/uniris-core/datamining/pkg/mining"
+	"github.com/uniris/uniris-core/shared/pkg/canonical"
+)
+
+//Encoder writes the account and mining data with the canonical encoding
+//
+//It is shared by the crypto package of the datamining and the light client, so they sign and verify the same bytes
+type Encoder struct {
+	canonical.Encoder
+}
+
+//NewEncoder creates an encoder for a payload type
+func NewEncoder(t canonical.PayloadType) *Encoder {
+	return &Encoder{canonical.NewEncoder(t)}
+}
+
+//WriteProposal writes the proposal, which is the last field of the ID and keychain data
+//
+//The activation date is optional: it is written only when set after the unix epoch
+func (e *Encoder) WriteProposal(p datamining.Proposal) {
+	e.WriteString(p.SharedEmitterKeyPair().EncryptedPrivateKey())
+	e.WriteString(p.SharedEmitterKeyPair().PublicKey())
+	if a := p.SharedEmitterKeyPair().ActivationDate(); a.Unix() > 0 {
+		e.WriteTimestamp(a)
+	}
+}
+
+//WriteIDData writes the ID data signed by the ID and by the emitter
+func (e *Encoder) WriteIDData(id account.ID) {
+	e.WriteString(id.Hash())
+	e.WriteString(id.EncryptedAddrByRobot())
+	e.WriteString(id.EncryptedAddrByID())
+	e.WriteString(id.EncryptedAESKey())
+	e.WriteString(id.PublicKey())
+	e.WriteProposal(id.Proposal())
+}
+
+//WriteID writes the ID with its signatures
+func (e *Encoder) WriteID(id account.ID) {
+	e.WriteIDData(id)
+	e.WriteString(id.IDSignature())
+	e.WriteString(id.EmitterSignature())
+}
+
+//WriteKeychainData writes the keychain data signed by the ID and by the emitter
+func (e *Encoder) WriteKeychainData(kc account.Keychain) {
+	e.WriteString(kc.EncryptedAddrByRobot())
+	e.WriteString(kc.EncryptedWallet())
+	e.WriteString(kc.IDPublicKey())
+	e.WriteProposal(kc.Proposal())
+}
+
+//WriteKeychain writes the keychain with its signatures
+func (e *Encoder) WriteKeychain(kc account.Keychain) {
+	e.WriteKeychainData(kc)
+	e.WriteString(kc.IDSignature())
+	e.WriteString(kc.EmitterSignature())
+}
+
+//WriteValidationData writes the validation data signed by the miner
+func (e *Encoder) WriteValidationData(v mining.Validation) {
+	e.WriteStatus(byte(v.Status()))
+	e.WriteTimestamp(v.Timestamp())
+	e.WriteString(v.PublicKey())
+}
+
+//WriteValidation writes the validation with its signature
+func (e *Encoder) WriteValidation(v mining.Validation) {
+	e.WriteValidationData(v)
+	e.WriteString(v.Signature())
+}
+
+//WriteEndorsement writes the endorsement of a transaction with its validations
+func (e *Encoder) WriteEndorsement(end mining.Endorsement) {
+	e.WriteString(end.LastTransactionHash())
+	e.WriteString(end.TransactionHash())
+	e.WriteString(end.MasterValidation().ProofOfWorkKey())
+	e.WriteValidation(end.MasterValidation().ProofOfWorkValidation())
+	e.WriteStrings(end.MasterValidation().LastTransactionMiners())
+	e.WriteStrings(end.MasterValidation().ValidationPool())
+	e.WriteLength(len(end.Validations()))
+	for _, v := range end.Validations() {
+		e.WriteValidation(v)
+	}
+}
+
+//EncodeIDData encodes the ID data signed by the ID and by the emitter
+func EncodeIDData(id account.ID) []byte {
+	e := NewEncoder(canonical.IDDataPayload)
+	e.WriteIDData(id)
+	return e.Bytes()
+}
+
+//EncodeID encodes the ID hashed as the ID transaction
+func EncodeID(id account.ID) []byte {
+	e := NewEncoder(canonical.IDPayload)
+	e.WriteID(id)
+	return e.Bytes()
+}
+
+//EncodeKeychainData encodes the keychain data signed by the ID and by the emitter
+func EncodeKeychainData(kc account.Keychain) []byte {
+	e := NewEncoder(canonical.KeychainDataPayload)
+	e.WriteKeychainData(kc)
+	return e.Bytes()
+}
+
+//EncodeKeychain encodes the keychain hashed as the keychain transaction
+func EncodeKeychain(kc account.Keychain) []byte {
+	e := NewEncoder(canonical.KeychainPayload)
+	e.WriteKeychain(kc)
+	return e.Bytes()
+}
+
+//EncodeValidationData encodes the validation of a transaction with the validation pool which performed it
+func EncodeValidationData(txHash string, vPool []string, v mining.Validation) []byte {
+	e := NewEncoder(canonical.ValidationDataPayload)
+	e.WriteString(txHash)
+	e.WriteStrings(vPool)
+	e.WriteValidationData(v)
+	return e.Bytes()
+}
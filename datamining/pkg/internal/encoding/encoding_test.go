@@ -0,0 +1,63 @@
+package encoding
+
+import (
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	datamining "github.com/uniris/uniris-core/datamining/pkg"
+	"github.com/uniris/uniris-core/datamining/pkg/account"
+	"github.com/uniris/uniris-core/datamining/pkg/mining"
+)
+
+/*
+Scenario: Encode a validation
+	Given a validation of a transaction by a validation pool
+	When I want to encode the data to sign
+	Then I get the test vector starting with the transaction hash and the validation pool
+*/
+func TestEncodeValidationDataVector(t *testing.T) {
+	v := mining.NewValidation(mining.ValidationKO, time.Unix(1, 0), "pub", "sig")
+	b := EncodeValidationData("hash", []string{"pub"}, v)
+	assert.Equal(t, "0105"+"0000000468617368"+"00000001"+"00000003707562"+"01"+"0000000000000001"+"00000003707562", hex.EncodeToString(b))
+}
+
+/*
+Scenario: Encode keychain data
+	Given a keychain
+	When I want to encode the data signed by the ID
+	Then I get the test vector without the signatures
+*/
+func TestEncodeKeychainDataVector(t *testing.T) {
+	prop := datamining.NewProposal(datamining.NewProposedKeyPair("pv", "pub", time.Time{}))
+	kc := account.NewKeychain("addr", "wal", "id", prop, "id sig", "em sig")
+	b := EncodeKeychainData(kc)
+	assert.Equal(t, "0104"+"0000000461646472"+"0000000377616c"+"000000026964"+"000000027076"+"00000003707562", hex.EncodeToString(b))
+}
+
+/*
+Scenario: Encode keychain data with the activation date of the proposal
+	Given a keychain proposing a keypair with an activation date
+	When I want to encode the data signed by the ID
+	Then I get the test vector ending with the activation date
+*/
+func TestEncodeKeychainDataActivationDateVector(t *testing.T) {
+	prop := datamining.NewProposal(datamining.NewProposedKeyPair("pv", "pub", time.Unix(1, 0)))
+	kc := account.NewKeychain("addr", "wal", "id", prop, "id sig", "em sig")
+	b := EncodeKeychainData(kc)
+	assert.Equal(t, "0104"+"0000000461646472"+"0000000377616c"+"000000026964"+"000000027076"+"00000003707562"+"0000000000000001", hex.EncodeToString(b))
+}
+
+/*
+Scenario: Encode ID data
+	Given an ID
+	When I want to encode the data signed by the ID
+	Then I get the test vector without the signatures
+*/
+func TestEncodeIDDataVector(t *testing.T) {
+	prop := datamining.NewProposal(datamining.NewProposedKeyPair("pv", "pub", time.Time{}))
+	id := account.NewID("h", "a1", "a2", "aes", "pub", prop, "id sig", "em sig")
+	b := EncodeIDData(id)
+	assert.Equal(t, "0102"+"0000000168"+"000000026131"+"000000026132"+"00000003616573"+"00000003707562"+"000000027076"+"00000003707562", hex.EncodeToString(b))
+}
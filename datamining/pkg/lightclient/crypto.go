@@ -0,0 +1,63 @@
+package lightclient
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+
+	"github.com/uniris/uniris-core/datamining/pkg/account"
+	"github.com/uniris/uniris-core/datamining/pkg/internal/encoding"
+	"github.com/uniris/uniris-core/datamining/pkg/mining"
+	"github.com/uniris/uniris-core/shared/pkg/keys"
+)
+
+type signer struct{}
+
+//NewSigner creates a signer verifying the signatures of a proof with the shared keys package
+//
+//It only verifies signatures, so no private key nor key store is needed to check a proof
+func NewSigner() Signer {
+	return signer{}
+}
+
+func (s signer) VerifyTransactionDataSignature(txType mining.TransactionType, pubKey string, data interface{}, sig string) error {
+	switch txType {
+	case mining.KeychainTransaction:
+		return keys.Verify(pubKey, encoding.EncodeKeychainData(data.(account.Keychain)), sig)
+	case mining.IDTransaction:
+		return keys.Verify(pubKey, encoding.EncodeIDData(data.(account.ID)), sig)
+	}
+
+	return mining.ErrUnsupportedTransaction
+}
+
+func (s signer) VerifyIDSignatures(id account.ID) error {
+	return keys.Verify(id.PublicKey(), encoding.EncodeIDData(id), id.IDSignature())
+}
+
+func (s signer) VerifyKeychainSignatures(kc account.Keychain) error {
+	return keys.Verify(kc.IDPublicKey(), encoding.EncodeKeychainData(kc), kc.IDSignature())
+}
+
+func (s signer) VerifyValidationSignature(txHash string, vPool []string, v mining.Validation) error {
+	return keys.Verify(v.PublicKey(), encoding.EncodeValidationData(txHash, vPool, v), v.Signature())
+}
+
+type hasher struct{}
+
+//NewHasher creates a hasher computing the transaction hashes of a proof as the datamining service does
+func NewHasher() Hasher {
+	return hasher{}
+}
+
+func (h hasher) HashID(id account.ID) (string, error) {
+	return hashBytes(encoding.EncodeID(id)), nil
+}
+
+func (h hasher) HashKeychain(kc account.Keychain) (string, error) {
+	return hashBytes(encoding.EncodeKeychain(kc)), nil
+}
+
+func hashBytes(data []byte) string {
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:])
+}
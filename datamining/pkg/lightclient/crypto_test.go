@@ -0,0 +1,119 @@
+package lightclient
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/uniris/uniris-core/datamining/pkg/crypto"
+	"github.com/uniris/uniris-core/datamining/pkg/internal/encoding"
+	"github.com/uniris/uniris-core/datamining/pkg/mining"
+	"github.com/uniris/uniris-core/shared/pkg/keys"
+)
+
+/*
+Scenario: Verify a signed account proof with the default signer and hasher
+	Given an account proof signed by the ID, the emitter and the validators
+	When I verify it without the datamining crypto
+	Then I get no error
+	When the keychain is altered
+	Then I get an error
+*/
+func TestVerifySignedAccountProof(t *testing.T) {
+	p, trusted := newSignedAccountProof(t)
+
+	v := NewVerifier(NewSigner(), NewHasher(), trusted, 2)
+	assert.Nil(t, v.VerifyAccountProof(p))
+
+	p.Keychain.EncryptedWallet = "other wallet"
+	assert.NotNil(t, v.VerifyAccountProof(p))
+}
+
+/*
+Scenario: Compute the transaction hashes as the datamining service
+	Given an ID and a keychain
+	When I hash them with the default hasher and with the datamining crypto hasher
+	Then I get the same hashes
+*/
+func TestHasherMatchesDatamining(t *testing.T) {
+	p, _ := newSignedAccountProof(t)
+
+	lightHash, _ := NewHasher().HashID(p.ID.toID())
+	dataminingHash, _ := crypto.NewHasher().HashID(p.ID.toID())
+	assert.Equal(t, dataminingHash, lightHash)
+
+	lightHash, _ = NewHasher().HashKeychain(p.Keychain.toKeychain())
+	dataminingHash, _ = crypto.NewHasher().HashKeychain(p.Keychain.toKeychain())
+	assert.Equal(t, dataminingHash, lightHash)
+}
+
+//newSignedAccountProof builds an account proof signed with generated keys and returns it with the trusted peers
+func newSignedAccountProof(t *testing.T) (AccountProof, []string) {
+	idPub, idPv, _ := keys.GenerateKeyPair(keys.Ed25519)
+	emPub, emPv, _ := keys.GenerateKeyPair(keys.Ed25519)
+
+	prop := KeyPairProposal{EncryptedPrivateKey: "pv", PublicKey: "pub", ActivationDate: 10}
+	p := AccountProof{
+		ID: ID{
+			Hash:                 "id hash",
+			EncryptedAddrByRobot: "enc addr",
+			EncryptedAddrByID:    "enc addr",
+			EncryptedAESKey:      "enc aes key",
+			PublicKey:            idPub,
+			Proposal:             prop,
+		},
+		Keychain: Keychain{
+			EncryptedAddrByRobot: "enc addr",
+			EncryptedWallet:      "enc wallet",
+			IDPublicKey:          idPub,
+			Proposal:             prop,
+		},
+	}
+
+	idData := encoding.EncodeIDData(p.ID.toID())
+	p.ID.IDSignature, _ = keys.Sign(idPv, idData)
+	p.ID.EmitterSignature, _ = keys.Sign(emPv, idData)
+	kcData := encoding.EncodeKeychainData(p.Keychain.toKeychain())
+	p.Keychain.IDSignature, _ = keys.Sign(idPv, kcData)
+	p.Keychain.EmitterSignature, _ = keys.Sign(emPv, kcData)
+
+	miners := make([]string, 3)
+	pvKeys := make(map[string]string)
+	for i := range miners {
+		pub, pv, _ := keys.GenerateKeyPair(keys.Ed25519)
+		miners[i] = pub
+		pvKeys[pub] = pv
+	}
+	vPool := miners[1:]
+
+	signValidation := func(txHash string, pub string) Validation {
+		v := mining.NewValidation(mining.ValidationOK, time.Unix(1, 0), pub, "")
+		sig, err := keys.Sign(pvKeys[pub], encoding.EncodeValidationData(txHash, vPool, v))
+		assert.Nil(t, err)
+		return Validation{Status: "OK", Timestamp: 1, PublicKey: pub, Signature: sig}
+	}
+	transactionProof := func(txHash string) TransactionProof {
+		return TransactionProof{
+			Endorsement: Endorsement{
+				TransactionHash: txHash,
+				MasterValidation: MasterValidation{
+					LastTransactionMiners: []string{miners[0]},
+					ProofOfWorkKey:        emPub,
+					ProofOfWorkValidation: signValidation(txHash, miners[0]),
+					ValidationPool:        vPool,
+				},
+				Validations: []Validation{
+					signValidation(txHash, vPool[0]),
+					signValidation(txHash, vPool[1]),
+				},
+			},
+		}
+	}
+
+	idHash, _ := NewHasher().HashID(p.ID.toID())
+	p.IDProof = transactionProof(idHash)
+	kcHash, _ := NewHasher().HashKeychain(p.Keychain.toKeychain())
+	p.KeychainProof = transactionProof(kcHash)
+
+	return p, miners
+}
@@ -0,0 +1,132 @@
+package lightclient
+
+import (
+	"errors"
+	"time"
+
+	datamining "github.com/uniris/uniris-core/datamining/pkg"
+	"github.com/uniris/uniris-core/datamining/pkg/account"
+	"github.com/uniris/uniris-core/datamining/pkg/mining"
+)
+
+//ErrUnknownValidationStatus is returned when a validation of a proof has an unknown status
+var ErrUnknownValidationStatus = errors.New("Unknown validation status")
+
+//AccountProof represents the proof bundle of an account as returned by the API service
+//
+//It can be decoded from the JSON body of the account proof endpoint
+type AccountProof struct {
+	ID            ID               `json:"id"`
+	IDProof       TransactionProof `json:"id_proof"`
+	Keychain      Keychain         `json:"keychain"`
+	KeychainProof TransactionProof `json:"keychain_proof"`
+}
+
+//TransactionProof represents the endorsement of a transaction
+//
+//The validation pool elected for the transaction is carried by the master validation,
+//where it is signed by the master peer and by each validator
+type TransactionProof struct {
+	Endorsement Endorsement `json:"endorsement"`
+}
+
+//ID represents a stored ID
+type ID struct {
+	Hash                 string          `json:"hash"`
+	EncryptedAddrByRobot string          `json:"encrypted_address_by_robot"`
+	EncryptedAddrByID    string          `json:"encrypted_address_by_id"`
+	EncryptedAESKey      string          `json:"encrypted_aes_key"`
+	PublicKey            string          `json:"public_key"`
+	Proposal             KeyPairProposal `json:"proposal"`
+	IDSignature          string          `json:"id_signature"`
+	EmitterSignature     string          `json:"emitter_signature"`
+}
+
+//Keychain represents a stored keychain
+type Keychain struct {
+	EncryptedAddrByRobot string          `json:"encrypted_address_by_robot"`
+	EncryptedWallet      string          `json:"encrypted_wallet"`
+	IDPublicKey          string          `json:"id_public_key"`
+	Proposal             KeyPairProposal `json:"proposal"`
+	IDSignature          string          `json:"id_signature"`
+	EmitterSignature     string          `json:"emitter_signature"`
+}
+
+//KeyPairProposal represents the shared emitter key pair proposed with an ID or a keychain
+type KeyPairProposal struct {
+	EncryptedPrivateKey string `json:"encrypted_private_key"`
+	PublicKey           string `json:"public_key"`
+	ActivationDate      int64  `json:"activation_date"`
+}
+
+//Endorsement represents the validations of a transaction
+type Endorsement struct {
+	LastTransactionHash string           `json:"last_transaction_hash"`
+	TransactionHash     string           `json:"transaction_hash"`
+	MasterValidation    MasterValidation `json:"master_validation"`
+	Validations         []Validation     `json:"validations"`
+}
+
+//MasterValidation represents the validation of the master peer leading a transaction
+type MasterValidation struct {
+	LastTransactionMiners []string   `json:"last_transaction_miners"`
+	ProofOfWorkKey        string     `json:"proof_of_work_key"`
+	ProofOfWorkValidation Validation `json:"proof_of_work_validation"`
+	ValidationPool        []string   `json:"validation_pool"`
+}
+
+//Validation represents the validation of a transaction by a miner
+type Validation struct {
+	Status    string `json:"status"`
+	Timestamp int64  `json:"timestamp"`
+	PublicKey string `json:"public_key"`
+	Signature string `json:"signature"`
+}
+
+func (p KeyPairProposal) toProposal() datamining.Proposal {
+	return datamining.NewProposal(datamining.NewProposedKeyPair(p.EncryptedPrivateKey, p.PublicKey, time.Unix(p.ActivationDate, 0)))
+}
+
+func (id ID) toID() account.ID {
+	return account.NewID(id.Hash, id.EncryptedAddrByRobot, id.EncryptedAddrByID, id.EncryptedAESKey, id.PublicKey, id.Proposal.toProposal(), id.IDSignature, id.EmitterSignature)
+}
+
+func (kc Keychain) toKeychain() account.Keychain {
+	return account.NewKeychain(kc.EncryptedAddrByRobot, kc.EncryptedWallet, kc.IDPublicKey, kc.Proposal.toProposal(), kc.IDSignature, kc.EmitterSignature)
+}
+
+func (v Validation) toValidation() (mining.Validation, error) {
+	var status mining.ValidationStatus
+	switch v.Status {
+	case "OK":
+		status = mining.ValidationOK
+	case "KO":
+		status = mining.ValidationKO
+	default:
+		return nil, ErrUnknownValidationStatus
+	}
+	return mining.NewValidation(status, time.Unix(v.Timestamp, 0), v.PublicKey, v.Signature), nil
+}
+
+func (e Endorsement) toEndorsement() (mining.Endorsement, error) {
+	powValid, err := e.MasterValidation.ProofOfWorkValidation.toValidation()
+	if err != nil {
+		return nil, err
+	}
+
+	valids := make([]mining.Validation, 0)
+	for _, v := range e.Validations {
+		valid, err := v.toValidation()
+		if err != nil {
+			return nil, err
+		}
+		valids = append(valids, valid)
+	}
+
+	return mining.NewEndorsement(
+		e.LastTransactionHash,
+		e.TransactionHash,
+		mining.NewMasterValidation(e.MasterValidation.LastTransactionMiners, e.MasterValidation.ProofOfWorkKey, powValid, e.MasterValidation.ValidationPool),
+		valids,
+	), nil
+}
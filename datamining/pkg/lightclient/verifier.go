@@ -0,0 +1,168 @@
+package lightclient
+
+import (
+	"errors"
+
+	"github.com/uniris/uniris-core/datamining/pkg/account"
+	"github.com/uniris/uniris-core/datamining/pkg/mining"
+)
+
+//ErrTransactionHashMismatch is returned when the data of a proof does not match the transaction hash of its endorsement
+var ErrTransactionHashMismatch = errors.New("Transaction hash does not match the data")
+
+//ErrTransactionNotSucceeded is returned when the endorsement of a proof does not represent a succeeded transaction
+var ErrTransactionNotSucceeded = errors.New("Transaction not succeeded")
+
+//ErrUntrustedPeer is returned when a validation or a validation pool member is not a trusted peer
+var ErrUntrustedPeer = errors.New("Untrusted peer")
+
+//ErrNotPoolMember is returned when a validation is made by a miner outside the validation pool
+var ErrNotPoolMember = errors.New("Validator is not a member of the validation pool")
+
+//ErrDuplicateValidation is returned when a miner validated a transaction several times
+var ErrDuplicateValidation = errors.New("Duplicate validation")
+
+//ErrNotEnoughValidations is returned when a transaction has less validations than required
+var ErrNotEnoughValidations = errors.New("Not enough validations")
+
+//ErrKeychainOwnerMismatch is returned when the keychain of a proof does not belong to its ID
+var ErrKeychainOwnerMismatch = errors.New("Keychain does not belong to the ID")
+
+//Signer defines methods to verify the signatures of a proof
+//
+//NewSigner verifies them with the shared keys, the datamining crypto signer implements it as well
+type Signer interface {
+	mining.PowSigVerifier
+	mining.ValidationVerifier
+	account.IDSignatureVerifier
+	account.KeychainSignatureVerifier
+}
+
+//Hasher defines methods to compute the transaction hashes of a proof
+//
+//NewHasher computes them as the datamining service, the datamining crypto hasher implements it as well
+type Hasher interface {
+	HashID(account.ID) (string, error)
+	HashKeychain(account.Keychain) (string, error)
+}
+
+//Verifier defines methods to verify offline the proofs of the account data
+type Verifier interface {
+
+	//VerifyAccountProof checks an account proof without trusting the peer which built it:
+	// - the ID and the keychain are signed by the ID key and by the emitter of the proof of work
+	// - the transaction hashes of the endorsements are the hashes of the data
+	// - the validations are signed by distinct members of the validation pools and reach the minimum of validations
+	// - the validation pools and the master peers are only composed of trusted peers
+	//
+	//The master validation and the validations sign the transaction hash with the validation pool,
+	//so the pool membership is attested by the trusted peers themselves and not by the peer which built the proof.
+	//The trusted peers must be retrieved from another source than this peer.
+	VerifyAccountProof(p AccountProof) error
+}
+
+type verifier struct {
+	sig            Signer
+	hasher         Hasher
+	trustedPeers   map[string]bool
+	minValidations int
+}
+
+//NewVerifier creates a verifier accepting the validations of the trusted peers public keys
+func NewVerifier(sig Signer, hasher Hasher, trustedPeers []string, minValidations int) Verifier {
+	trusted := make(map[string]bool)
+	for _, k := range trustedPeers {
+		trusted[k] = true
+	}
+	return verifier{
+		sig:            sig,
+		hasher:         hasher,
+		trustedPeers:   trusted,
+		minValidations: minValidations,
+	}
+}
+
+func (v verifier) VerifyAccountProof(p AccountProof) error {
+	id := p.ID.toID()
+	keychain := p.Keychain.toKeychain()
+
+	if keychain.IDPublicKey() != id.PublicKey() {
+		return ErrKeychainOwnerMismatch
+	}
+
+	if err := v.sig.VerifyIDSignatures(id); err != nil {
+		return err
+	}
+	idHash, err := v.hasher.HashID(id)
+	if err != nil {
+		return err
+	}
+	if err := v.verifyTransactionProof(p.IDProof, idHash, mining.IDTransaction, id, id.EmitterSignature()); err != nil {
+		return err
+	}
+
+	if err := v.sig.VerifyKeychainSignatures(keychain); err != nil {
+		return err
+	}
+	keychainHash, err := v.hasher.HashKeychain(keychain)
+	if err != nil {
+		return err
+	}
+	return v.verifyTransactionProof(p.KeychainProof, keychainHash, mining.KeychainTransaction, keychain, keychain.EmitterSignature())
+}
+
+func (v verifier) verifyTransactionProof(p TransactionProof, txHash string, txType mining.TransactionType, data interface{}, emSig string) error {
+	end, err := p.Endorsement.toEndorsement()
+	if err != nil {
+		return err
+	}
+
+	if end.TransactionHash() != txHash {
+		return ErrTransactionHashMismatch
+	}
+	if end.GetStatus() != mining.TransactionSuccess {
+		return ErrTransactionNotSucceeded
+	}
+
+	//The proof of work is valid only if the emitter signature matches the key found by the master peer
+	if err := v.sig.VerifyTransactionDataSignature(txType, end.MasterValidation().ProofOfWorkKey(), data, emSig); err != nil {
+		return err
+	}
+
+	vPool := end.MasterValidation().ValidationPool()
+	pool := make(map[string]bool)
+	for _, k := range vPool {
+		if !v.trustedPeers[k] {
+			return ErrUntrustedPeer
+		}
+		pool[k] = true
+	}
+
+	//The master peer attests the validation pool it elected for the transaction
+	powValid := end.MasterValidation().ProofOfWorkValidation()
+	if !v.trustedPeers[powValid.PublicKey()] {
+		return ErrUntrustedPeer
+	}
+	if err := v.sig.VerifyValidationSignature(txHash, vPool, powValid); err != nil {
+		return err
+	}
+
+	validators := make(map[string]bool)
+	for _, valid := range end.Validations() {
+		if !pool[valid.PublicKey()] {
+			return ErrNotPoolMember
+		}
+		if validators[valid.PublicKey()] {
+			return ErrDuplicateValidation
+		}
+		if err := v.sig.VerifyValidationSignature(txHash, vPool, valid); err != nil {
+			return err
+		}
+		validators[valid.PublicKey()] = true
+	}
+
+	if len(validators) < v.minValidations {
+		return ErrNotEnoughValidations
+	}
+	return nil
+}
@@ -0,0 +1,274 @@
+package lightclient
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/uniris/uniris-core/datamining/pkg/account"
+	"github.com/uniris/uniris-core/datamining/pkg/mining"
+)
+
+/*
+Scenario: Verify a valid account proof
+	Given an account proof validated by trusted peers
+	When I verify it
+	Then I get no error
+*/
+func TestVerifyAccountProof(t *testing.T) {
+	v := NewVerifier(mockSigner{}, mockHasher{}, []string{"master", "validator1", "validator2"}, 2)
+	assert.Nil(t, v.VerifyAccountProof(newAccountProof()))
+}
+
+/*
+Scenario: Decode and verify an account proof from JSON
+	Given an account proof encoded as returned by the API
+	When I decode it and verify it
+	Then I get no error
+*/
+func TestVerifyAccountProofFromJSON(t *testing.T) {
+	b, err := json.Marshal(newAccountProof())
+	assert.Nil(t, err)
+
+	var p AccountProof
+	assert.Nil(t, json.Unmarshal(b, &p))
+
+	v := NewVerifier(mockSigner{}, mockHasher{}, []string{"master", "validator1", "validator2"}, 2)
+	assert.Nil(t, v.VerifyAccountProof(p))
+}
+
+/*
+Scenario: Verify an account proof validated by an untrusted peer
+	Given an account proof with a validation pool containing an untrusted peer
+	When I verify it
+	Then I get an error
+*/
+func TestVerifyAccountProofUntrustedPeer(t *testing.T) {
+	v := NewVerifier(mockSigner{}, mockHasher{}, []string{"master", "validator1"}, 1)
+	assert.Equal(t, ErrUntrustedPeer, v.VerifyAccountProof(newAccountProof()))
+
+	v = NewVerifier(mockSigner{}, mockHasher{}, []string{"validator1", "validator2"}, 1)
+	assert.Equal(t, ErrUntrustedPeer, v.VerifyAccountProof(newAccountProof()))
+}
+
+/*
+Scenario: Verify an account proof with a validation made outside the validation pool
+	Given an account proof with a validation made by a peer not elected
+	When I verify it
+	Then I get an error
+*/
+func TestVerifyAccountProofNotPoolMember(t *testing.T) {
+	p := newAccountProof()
+	p.IDProof = newTransactionProofWithPool("id tx hash", []string{"validator1"})
+
+	v := NewVerifier(mockSigner{}, mockHasher{}, []string{"master", "validator1", "validator2"}, 1)
+	assert.Equal(t, ErrNotPoolMember, v.VerifyAccountProof(p))
+}
+
+/*
+Scenario: Verify an account proof with an altered validation pool
+	Given an account proof with a validation pool not signed by the validators
+	When I verify it
+	Then I get an error
+*/
+func TestVerifyAccountProofAlteredValidationPool(t *testing.T) {
+	p := newAccountProof()
+	p.IDProof.Endorsement.MasterValidation.ValidationPool = []string{"validator1", "validator2", "master"}
+
+	v := NewVerifier(mockSigner{}, mockHasher{}, []string{"master", "validator1", "validator2"}, 2)
+	assert.Equal(t, errInvalidSignature, v.VerifyAccountProof(p))
+}
+
+/*
+Scenario: Verify an account proof with a validation replayed from another transaction
+	Given an account proof with a validation signed for another transaction hash
+	When I verify it
+	Then I get an error
+*/
+func TestVerifyAccountProofReplayedValidation(t *testing.T) {
+	p := newAccountProof()
+	p.KeychainProof.Endorsement.Validations[0] = p.IDProof.Endorsement.Validations[0]
+
+	v := NewVerifier(mockSigner{}, mockHasher{}, []string{"master", "validator1", "validator2"}, 2)
+	assert.Equal(t, errInvalidSignature, v.VerifyAccountProof(p))
+}
+
+/*
+Scenario: Verify an account proof with duplicate validations
+	Given an account proof with twice the same validator
+	When I verify it
+	Then I get an error
+*/
+func TestVerifyAccountProofDuplicateValidation(t *testing.T) {
+	p := newAccountProof()
+	p.KeychainProof.Endorsement.Validations[1] = p.KeychainProof.Endorsement.Validations[0]
+
+	v := NewVerifier(mockSigner{}, mockHasher{}, []string{"master", "validator1", "validator2"}, 1)
+	assert.Equal(t, ErrDuplicateValidation, v.VerifyAccountProof(p))
+}
+
+/*
+Scenario: Verify an account proof without enough validations
+	Given an account proof with two validations
+	When I verify it requiring three validations
+	Then I get an error
+*/
+func TestVerifyAccountProofNotEnoughValidations(t *testing.T) {
+	v := NewVerifier(mockSigner{}, mockHasher{}, []string{"master", "validator1", "validator2"}, 3)
+	assert.Equal(t, ErrNotEnoughValidations, v.VerifyAccountProof(newAccountProof()))
+}
+
+/*
+Scenario: Verify an account proof with altered data
+	Given an account proof with an endorsement not matching the hash of the data
+	When I verify it
+	Then I get an error
+*/
+func TestVerifyAccountProofHashMismatch(t *testing.T) {
+	p := newAccountProof()
+	p.IDProof.Endorsement.TransactionHash = "other hash"
+
+	v := NewVerifier(mockSigner{}, mockHasher{}, []string{"master", "validator1", "validator2"}, 2)
+	assert.Equal(t, ErrTransactionHashMismatch, v.VerifyAccountProof(p))
+}
+
+/*
+Scenario: Verify an account proof with a forged validation
+	Given an account proof with an invalid validation signature
+	When I verify it
+	Then I get an error
+*/
+func TestVerifyAccountProofInvalidValidationSignature(t *testing.T) {
+	p := newAccountProof()
+	p.KeychainProof.Endorsement.Validations[0].Signature = "bad sig"
+
+	v := NewVerifier(mockSigner{}, mockHasher{}, []string{"master", "validator1", "validator2"}, 2)
+	assert.Equal(t, errInvalidSignature, v.VerifyAccountProof(p))
+}
+
+/*
+Scenario: Verify an account proof with a failed transaction
+	Given an account proof with a KO validation
+	When I verify it
+	Then I get an error
+*/
+func TestVerifyAccountProofTransactionFailed(t *testing.T) {
+	p := newAccountProof()
+	p.IDProof.Endorsement.Validations[1].Status = "KO"
+
+	v := NewVerifier(mockSigner{}, mockHasher{}, []string{"master", "validator1", "validator2"}, 2)
+	assert.Equal(t, ErrTransactionNotSucceeded, v.VerifyAccountProof(p))
+}
+
+/*
+Scenario: Verify an account proof with a keychain of another ID
+	Given an account proof with a keychain not owned by the ID
+	When I verify it
+	Then I get an error
+*/
+func TestVerifyAccountProofOwnerMismatch(t *testing.T) {
+	p := newAccountProof()
+	p.Keychain.IDPublicKey = "other key"
+
+	v := NewVerifier(mockSigner{}, mockHasher{}, []string{"master", "validator1", "validator2"}, 2)
+	assert.Equal(t, ErrKeychainOwnerMismatch, v.VerifyAccountProof(p))
+}
+
+func newTransactionProof(txHash string) TransactionProof {
+	return newTransactionProofWithPool(txHash, []string{"validator1", "validator2"})
+}
+
+func newTransactionProofWithPool(txHash string, vPool []string) TransactionProof {
+	sig := validationSig(txHash, vPool)
+	return TransactionProof{
+		Endorsement: Endorsement{
+			LastTransactionHash: "",
+			TransactionHash:     txHash,
+			MasterValidation: MasterValidation{
+				LastTransactionMiners: []string{"master"},
+				ProofOfWorkKey:        "pow key",
+				ProofOfWorkValidation: Validation{Status: "OK", Timestamp: 1, PublicKey: "master", Signature: sig},
+				ValidationPool:        vPool,
+			},
+			Validations: []Validation{
+				Validation{Status: "OK", Timestamp: 1, PublicKey: "validator1", Signature: sig},
+				Validation{Status: "OK", Timestamp: 1, PublicKey: "validator2", Signature: sig},
+			},
+		},
+	}
+}
+
+func newAccountProof() AccountProof {
+	prop := KeyPairProposal{EncryptedPrivateKey: "pv", PublicKey: "pub"}
+	return AccountProof{
+		ID: ID{
+			Hash:                 "id hash",
+			EncryptedAddrByRobot: "enc addr",
+			EncryptedAddrByID:    "enc addr",
+			EncryptedAESKey:      "enc aes key",
+			PublicKey:            "id pub",
+			Proposal:             prop,
+			IDSignature:          "sig",
+			EmitterSignature:     "sig",
+		},
+		IDProof: newTransactionProof("id tx hash"),
+		Keychain: Keychain{
+			EncryptedAddrByRobot: "enc addr",
+			EncryptedWallet:      "enc wallet",
+			IDPublicKey:          "id pub",
+			Proposal:             prop,
+			IDSignature:          "sig",
+			EmitterSignature:     "sig",
+		},
+		KeychainProof: newTransactionProof("keychain tx hash"),
+	}
+}
+
+var errInvalidSignature = errors.New("Invalid signature")
+
+type mockSigner struct{}
+
+func (s mockSigner) VerifyTransactionDataSignature(txType mining.TransactionType, pubKey string, data interface{}, sig string) error {
+	if sig != "sig" {
+		return errInvalidSignature
+	}
+	return nil
+}
+
+//validationSig simulates a validation signature covering the transaction hash and the validation pool
+func validationSig(txHash string, vPool []string) string {
+	return txHash + "|" + strings.Join(vPool, ",")
+}
+
+func (s mockSigner) VerifyValidationSignature(txHash string, vPool []string, v mining.Validation) error {
+	if v.Signature() != validationSig(txHash, vPool) {
+		return errInvalidSignature
+	}
+	return nil
+}
+
+func (s mockSigner) VerifyIDSignatures(id account.ID) error {
+	if id.IDSignature() != "sig" || id.EmitterSignature() != "sig" {
+		return errInvalidSignature
+	}
+	return nil
+}
+
+func (s mockSigner) VerifyKeychainSignatures(kc account.Keychain) error {
+	if kc.IDSignature() != "sig" || kc.EmitterSignature() != "sig" {
+		return errInvalidSignature
+	}
+	return nil
+}
+
+type mockHasher struct{}
+
+func (h mockHasher) HashID(id account.ID) (string, error) {
+	return "id tx hash", nil
+}
+
+func (h mockHasher) HashKeychain(kc account.Keychain) (string, error) {
+	return "keychain tx hash", nil
+}
@@ -2,6 +2,9 @@ package lock
 
 import (
 	"errors"
+	"log"
+
+	datamining "github.com/uniris/uniris-core/datamining/pkg"
 )
 
 //TransactionLock represents lock data
@@ -46,12 +49,13 @@ type Service interface {
 }
 
 type service struct {
-	repo Repository
+	repo  Repository
+	notif datamining.Notifier
 }
 
 //NewService creates a new locking service
-func NewService(repo Repository) Service {
-	return service{repo}
+func NewService(repo Repository, notif datamining.Notifier) Service {
+	return service{repo, notif}
 }
 
 func (s service) LockTransaction(txLock TransactionLock) error {
@@ -59,9 +63,32 @@ func (s service) LockTransaction(txLock TransactionLock) error {
 		return ErrLockExisting
 	}
 
-	return s.repo.NewLock(txLock)
+	if err := s.repo.NewLock(txLock); err != nil {
+		return err
+	}
+
+	//The lock is taken even if the event cannot be published
+	if err := s.notif.NotifyLockAcquired(datamining.LockAcquired{
+		TransactionHash: txLock.TxHash,
+		Address:         txLock.Address,
+		MasterRobotKey:  txLock.MasterRobotKey,
+	}); err != nil {
+		log.Printf("Lock notification error: %s", err.Error())
+	}
+	return nil
 }
 
 func (s service) UnlockTransaction(txLock TransactionLock) error {
-	return s.repo.RemoveLock(txLock)
+	if err := s.repo.RemoveLock(txLock); err != nil {
+		return err
+	}
+
+	if err := s.notif.NotifyLockReleased(datamining.LockReleased{
+		TransactionHash: txLock.TxHash,
+		Address:         txLock.Address,
+		MasterRobotKey:  txLock.MasterRobotKey,
+	}); err != nil {
+		log.Printf("Lock notification error: %s", err.Error())
+	}
+	return nil
 }
@@ -4,6 +4,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	datamining "github.com/uniris/uniris-core/datamining/pkg"
+	"github.com/uniris/uniris-core/datamining/pkg/transport/mem"
 )
 
 /*
@@ -16,7 +18,7 @@ func TestLockTransaction(t *testing.T) {
 
 	repo := new(mockRepository)
 
-	service := NewService(repo)
+	service := NewService(repo, mem.NewNotifier())
 	assert.Nil(t, service.LockTransaction(TransactionLock{
 		MasterRobotKey: "robokey",
 		TxHash:         "txhash",
@@ -36,7 +38,7 @@ func TestCannotAlreadyLockTransaction(t *testing.T) {
 
 	repo := new(mockRepository)
 
-	service := NewService(repo)
+	service := NewService(repo, mem.NewNotifier())
 	assert.Nil(t, service.LockTransaction(TransactionLock{
 		MasterRobotKey: "robokey",
 		TxHash:         "txhash",
@@ -61,8 +63,9 @@ Scenario: UnLock a locked transaction
 func TestUnlockTransaction(t *testing.T) {
 
 	repo := new(mockRepository)
+	notif := mem.NewNotifier()
 
-	service := NewService(repo)
+	service := NewService(repo, notif)
 	assert.Nil(t, service.LockTransaction(TransactionLock{
 		MasterRobotKey: "robokey",
 		TxHash:         "txhash",
@@ -82,6 +85,11 @@ func TestUnlockTransaction(t *testing.T) {
 	}))
 
 	assert.Len(t, repo.locks, 0)
+
+	events := notif.Events()
+	assert.Len(t, events, 2)
+	assert.Equal(t, datamining.LockAcquired{TransactionHash: "txhash", Address: "address", MasterRobotKey: "robokey"}, events[0])
+	assert.Equal(t, datamining.LockReleased{TransactionHash: "txhash", Address: "address", MasterRobotKey: "robokey"}, events[1])
 }
 
 type mockRepository struct {
@@ -10,13 +10,15 @@ type PowSigVerifier interface {
 //ValidationVerifier define methods to handle validation signature verification
 type ValidationVerifier interface {
 
-	//VerifyValidationSignature checks the signature of the validation
-	VerifyValidationSignature(Validation) error
+	//VerifyValidationSignature checks the signature of the validation of a transaction by a member of the validation pool
+	VerifyValidationSignature(txHash string, vPool []string, v Validation) error
 }
 
 //ValidationSigner define methods to handle signing of the validation
 type ValidationSigner interface {
 
 	//SignValidation create signature for a validation data
-	SignValidation(v Validation, pvKey string) (Validation, error)
+	//
+	//The signature covers the transaction hash and the validation pool, so it cannot be replayed for another transaction or pool
+	SignValidation(v Validation, txHash string, vPool []string, pvKey string) (Validation, error)
 }
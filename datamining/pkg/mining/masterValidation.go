@@ -11,17 +11,21 @@ type MasterValidation interface {
 
 	//Validation returns the validation for the proof of work
 	ProofOfWorkValidation() Validation
+
+	//ValidationPool returns the public keys of the peers elected to validate the transaction
+	ValidationPool() []string
 }
 
 type masterValidation struct {
 	lastTxRvk []string
 	powKey    string
 	powValid  Validation
+	vPool     []string
 }
 
 //NewMasterValidation creates a new master validation
-func NewMasterValidation(lastTxRvk []string, powKey string, powValid Validation) MasterValidation {
-	return masterValidation{lastTxRvk, powKey, powValid}
+func NewMasterValidation(lastTxRvk []string, powKey string, powValid Validation, vPool []string) MasterValidation {
+	return masterValidation{lastTxRvk, powKey, powValid, vPool}
 }
 
 func (m masterValidation) LastTransactionMiners() []string {
@@ -35,3 +39,7 @@ func (m masterValidation) ProofOfWorkKey() string {
 func (m masterValidation) ProofOfWorkValidation() Validation {
 	return m.powValid
 }
+
+func (m masterValidation) ValidationPool() []string {
+	return m.vPool
+}
@@ -8,9 +8,11 @@ import (
 )
 
 type pow struct {
+	txHash      string
 	txType      TransactionType
 	txData      interface{}
 	lastVPool   datamining.Pool
+	vPool       datamining.Pool
 	txEmSig     string
 	emLister    emlisting.Service
 	signer      signer
@@ -24,6 +26,15 @@ func (p pow) execute() (MasterValidation, error) {
 		return nil, err
 	}
 
+	//Previous keys remain valid during the grace period of a rotation
+	prevEmKP, err := p.emLister.ListPreviousSharedEmitterKeyPairs()
+	if err != nil {
+		return nil, err
+	}
+	for _, kp := range prevEmKP {
+		sharedEmKP = append(sharedEmKP, kp.KeyPair)
+	}
+
 	//Find the public key which matches the transaction signature
 	status := ValidationKO
 	var matchedKey string
@@ -41,15 +52,11 @@ func (p pow) execute() (MasterValidation, error) {
 		status:    status,
 		timestamp: time.Now(),
 	}
-	sValid, err := p.signer.SignValidation(v, p.robotPvKey)
+	vPoolKeys := p.vPool.Peers().PublicKeys()
+	sValid, err := p.signer.SignValidation(v, p.txHash, vPoolKeys, p.robotPvKey)
 	if err != nil {
 		return nil, err
 	}
 
-	lastTxMiners := make([]string, 0)
-	for _, peer := range p.lastVPool.Peers() {
-		lastTxMiners = append(lastTxMiners, peer.PublicKey)
-	}
-
-	return NewMasterValidation(lastTxMiners, matchedKey, sValid), nil
+	return NewMasterValidation(p.lastVPool.Peers().PublicKeys(), matchedKey, sValid, vPoolKeys), nil
 }
@@ -3,6 +3,7 @@ package mining
 import (
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	datamining "github.com/uniris/uniris-core/datamining/pkg"
@@ -25,6 +26,7 @@ func TestExecutePOW(t *testing.T) {
 
 	pow := pow{
 		lastVPool:   lastValidPool,
+		vPool:       datamining.NewPool(datamining.Peer{PublicKey: "validator key"}),
 		emLister:    emLister,
 		robotPubKey: "my key",
 		robotPvKey:  "my key",
@@ -41,6 +43,7 @@ func TestExecutePOW(t *testing.T) {
 	assert.Equal(t, "key1", valid.ProofOfWorkKey())
 	assert.Equal(t, "my key", valid.ProofOfWorkValidation().PublicKey())
 	assert.Equal(t, ValidationOK, valid.ProofOfWorkValidation().Status())
+	assert.Equal(t, []string{"validator key"}, valid.ValidationPool())
 }
 
 /*
@@ -58,6 +61,7 @@ func TestExecutePOW_KO(t *testing.T) {
 
 	pow := pow{
 		lastVPool:   lastValidPool,
+		vPool:       datamining.NewPool(datamining.Peer{PublicKey: "validator key"}),
 		emLister:    emLister,
 		robotPubKey: "my key",
 		robotPvKey:  "my key",
@@ -76,7 +80,79 @@ func TestExecutePOW_KO(t *testing.T) {
 	assert.Equal(t, ValidationKO, valid.ProofOfWorkValidation().Status())
 }
 
+/*
+Scenario: Execute the POW with a rotated key during its grace period
+	Given a transaction signed with a shared emitter key retired but not expired
+	When I execute the POW
+	Then the retired key is found
+*/
+func TestExecutePOWWithPreviousKey(t *testing.T) {
+
+	repo := &mockDatabase{
+		retired: []emitter.RetiredSharedKeyPair{
+			emitter.RetiredSharedKeyPair{
+				KeyPair:        emitter.SharedKeyPair{PublicKey: "old key"},
+				ExpirationDate: time.Now().Add(time.Hour),
+			},
+		},
+	}
+	emLister := emlisting.NewService(repo)
+
+	pow := pow{
+		lastVPool:   datamining.NewPool(datamining.Peer{PublicKey: "key"}),
+		vPool:       datamining.NewPool(datamining.Peer{PublicKey: "validator key"}),
+		emLister:    emLister,
+		robotPubKey: "my key",
+		robotPvKey:  "my key",
+		signer:      mockKeyPowSigner{key: "old key"},
+		txEmSig:     "signature",
+		txData:      "data",
+		txType:      KeychainTransaction,
+	}
+
+	valid, err := pow.execute()
+	assert.Nil(t, err)
+	assert.Equal(t, "old key", valid.ProofOfWorkKey())
+	assert.Equal(t, ValidationOK, valid.ProofOfWorkValidation().Status())
+}
+
+/*
+Scenario: Execute the POW with a rotated key after its grace period
+	Given a transaction signed with an expired shared emitter key
+	When I execute the POW
+	Then the key is not found
+*/
+func TestExecutePOWWithExpiredKey(t *testing.T) {
+
+	repo := &mockDatabase{
+		retired: []emitter.RetiredSharedKeyPair{
+			emitter.RetiredSharedKeyPair{
+				KeyPair:        emitter.SharedKeyPair{PublicKey: "old key"},
+				ExpirationDate: time.Now().Add(-time.Hour),
+			},
+		},
+	}
+	emLister := emlisting.NewService(repo)
+
+	pow := pow{
+		lastVPool:   datamining.NewPool(datamining.Peer{PublicKey: "key"}),
+		vPool:       datamining.NewPool(datamining.Peer{PublicKey: "validator key"}),
+		emLister:    emLister,
+		robotPubKey: "my key",
+		robotPvKey:  "my key",
+		signer:      mockKeyPowSigner{key: "old key"},
+		txEmSig:     "signature",
+		txData:      "data",
+		txType:      KeychainTransaction,
+	}
+
+	valid, err := pow.execute()
+	assert.Nil(t, err)
+	assert.Equal(t, ValidationKO, valid.ProofOfWorkValidation().Status())
+}
+
 type mockDatabase struct {
+	retired []emitter.RetiredSharedKeyPair
 }
 
 func (d *mockDatabase) ListSharedEmitterKeyPairs() ([]emitter.SharedKeyPair, error) {
@@ -90,13 +166,21 @@ func (d *mockDatabase) ListSharedEmitterKeyPairs() ([]emitter.SharedKeyPair, err
 		}}, nil
 }
 
+func (d *mockDatabase) ListRetiredSharedEmitterKeyPairs() ([]emitter.RetiredSharedKeyPair, error) {
+	return d.retired, nil
+}
+
+func (d *mockDatabase) FindEmitterAuthorization(pubKey string) (*emitter.Authorization, error) {
+	return nil, nil
+}
+
 type mockPowSigner struct{}
 
 func (s mockPowSigner) VerifyTransactionDataSignature(txType TransactionType, pubk string, data interface{}, der string) error {
 	return nil
 }
 
-func (s mockPowSigner) SignValidation(v Validation, pvKey string) (Validation, error) {
+func (s mockPowSigner) SignValidation(v Validation, txHash string, vPool []string, pvKey string) (Validation, error) {
 	return NewValidation(v.Status(), v.Timestamp(), v.PublicKey(), "sig"), nil
 }
 
@@ -106,6 +190,21 @@ func (s mockBadPowSigner) VerifyTransactionDataSignature(txType TransactionType,
 	return errors.New("Invalid signature")
 }
 
-func (s mockBadPowSigner) SignValidation(v Validation, pvKey string) (Validation, error) {
+func (s mockBadPowSigner) SignValidation(v Validation, txHash string, vPool []string, pvKey string) (Validation, error) {
+	return NewValidation(v.Status(), v.Timestamp(), v.PublicKey(), "sig"), nil
+}
+
+type mockKeyPowSigner struct {
+	key string
+}
+
+func (s mockKeyPowSigner) VerifyTransactionDataSignature(txType TransactionType, pubk string, data interface{}, der string) error {
+	if pubk != s.key {
+		return errors.New("Invalid signature")
+	}
+	return nil
+}
+
+func (s mockKeyPowSigner) SignValidation(v Validation, txHash string, vPool []string, pvKey string) (Validation, error) {
 	return NewValidation(v.Status(), v.Timestamp(), v.PublicKey(), "sig"), nil
 }
@@ -27,7 +27,7 @@ const (
 	TransactionFailure TransactionStatus = 2
 
 	//TransactionUnknown represents a unknown status for the transaction
-	TransactionUnknown TransactionStatus = 2
+	TransactionUnknown TransactionStatus = 3
 )
 
 //ErrUnsupportedTransaction when the transaction does not have transaction miners associated
@@ -36,6 +36,9 @@ var ErrUnsupportedTransaction = errors.New("Unsupported transaction")
 //ErrInvalidTransaction is returned a transaction is invalid
 var ErrInvalidTransaction = errors.New("Invalid transaction")
 
+//ErrNotValidationPoolMember is returned when a peer is asked to validate a transaction for a pool it does not belong to
+var ErrNotValidationPoolMember = errors.New("Not a member of the validation pool")
+
 //TransactionType represents the transaction type
 type TransactionType int
 
@@ -47,6 +50,16 @@ const (
 	IDTransaction TransactionType = 1
 )
 
+func (t TransactionType) String() string {
+	switch t {
+	case KeychainTransaction:
+		return "Keychain"
+	case IDTransaction:
+		return "ID"
+	}
+	return ""
+}
+
 //TransactionMiner define methods a transaction miner must define
 type TransactionMiner interface {
 
@@ -82,7 +95,9 @@ type Service interface {
 	LeadMining(txHash string, addr string, data interface{}, vPool datamining.Pool, txType TransactionType, emSig string) error
 
 	//Validate performs checks like a peer in a validation pool and create a validation (successed or not)
-	Validate(txHash string, data interface{}, txType TransactionType) (Validation, error)
+	//
+	//The validation pool is the list of the public keys of the peers elected by the master to validate the transaction
+	Validate(txHash string, data interface{}, txType TransactionType, vPool []string) (Validation, error)
 }
 
 type service struct {
@@ -93,11 +108,13 @@ type service struct {
 	emLister emlisting.Service
 	config   system.UnirisConfig
 	txMiners map[TransactionType]TransactionMiner
+	statuses StatusWatcher
+	notif    datamining.Notifier
 }
 
 //NewService creates a new global mining service
-func NewService(aiCli AIClient, pF PoolFinder, pR PoolRequester, signer signer, emLister emlisting.Service, config system.UnirisConfig, txMiners map[TransactionType]TransactionMiner) Service {
-	return service{aiCli, pF, pR, signer, emLister, config, txMiners}
+func NewService(aiCli AIClient, pF PoolFinder, pR PoolRequester, signer signer, emLister emlisting.Service, config system.UnirisConfig, txMiners map[TransactionType]TransactionMiner, statuses StatusWatcher, notif datamining.Notifier) Service {
+	return service{aiCli, pF, pR, signer, emLister, config, txMiners, statuses, notif}
 }
 
 func (s service) LeadMining(txHash string, addr string, data interface{}, vPool datamining.Pool, txType TransactionType, emSig string) error {
@@ -107,13 +124,16 @@ func (s service) LeadMining(txHash string, addr string, data interface{}, vPool
 	}
 
 	log.Printf("Transaction %s is pending\n", txHash)
+	s.statuses.Notify(txHash, TransactionPending)
 
 	lastVPool, sPool, err := s.findPools(addr)
 	if err != nil {
+		s.notifyFailure(txHash, txType, err)
 		return err
 	}
 
 	if err := s.requestLock(txHash, addr, lastVPool); err != nil {
+		s.notifyFailure(txHash, txType, err)
 		return err
 	}
 
@@ -132,24 +152,48 @@ func (s service) LeadMining(txHash string, addr string, data interface{}, vPool
 }
 
 func (s service) processMining(txHash string, data interface{}, addr string, emSig string, lastVPool, vPool, sPool datamining.Pool, txType TransactionType) error {
-	endorsement, err := s.mine(txHash, data, addr, emSig, lastVPool, vPool, txType)
+	endorsement, err := s.mineAndStore(txHash, data, addr, emSig, lastVPool, vPool, sPool, txType)
 	if err != nil {
+		s.notifyFailure(txHash, txType, err)
 		return err
 	}
 
+	log.Printf("Transaction %s is stored\n", txHash)
+	s.statuses.Notify(txHash, endorsement.GetStatus())
+
+	return s.requestUnlock(txHash, addr, lastVPool)
+}
+
+func (s service) mineAndStore(txHash string, data interface{}, addr string, emSig string, lastVPool, vPool, sPool datamining.Pool, txType TransactionType) (Endorsement, error) {
+	endorsement, err := s.mine(txHash, data, addr, emSig, lastVPool, vPool, txType)
+	if err != nil {
+		return nil, err
+	}
+
 	log.Printf("Transaction %s is validated \n", txHash)
 
 	minReplicas, err := s.aiClient.GetMininumReplications(txHash)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if err := s.poolR.RequestStorage(minReplicas, sPool, data, endorsement, txType); err != nil {
-		return err
+		return nil, err
 	}
 
-	log.Printf("Transaction %s is stored\n", txHash)
+	return endorsement, nil
+}
 
-	return s.requestUnlock(txHash, addr, lastVPool)
+//notifyFailure publishes the failure of a transaction mining led by the peer
+func (s service) notifyFailure(txHash string, txType TransactionType, err error) {
+	s.statuses.Notify(txHash, TransactionFailure)
+
+	if nErr := s.notif.NotifyMiningFailed(datamining.MiningFailed{
+		TransactionHash: txHash,
+		TransactionType: txType.String(),
+		Reason:          err.Error(),
+	}); nErr != nil {
+		log.Printf("Mining notification error: %s", nErr.Error())
+	}
 }
 
 func (s service) findPools(addr string) (datamining.Pool, datamining.Pool, error) {
@@ -170,7 +214,7 @@ func (s service) requestLock(txHash string, addr string, lastVPool datamining.Po
 	//Build lock transaction
 	lock := lock.TransactionLock{
 		TxHash:         txHash,
-		MasterRobotKey: s.config.SharedKeys.Robot.PublicKey,
+		MasterRobotKey: s.config.SharedKeys.CurrentRobotKeyPair(time.Now()).PublicKey,
 		Address:        addr,
 	}
 
@@ -185,7 +229,7 @@ func (s service) requestUnlock(txHash string, addr string, lastVPool datamining.
 	//Build unlock transaction
 	lock := lock.TransactionLock{
 		TxHash:         txHash,
-		MasterRobotKey: s.config.SharedKeys.Robot.PublicKey,
+		MasterRobotKey: s.config.SharedKeys.CurrentRobotKeyPair(time.Now()).PublicKey,
 		Address:        addr,
 	}
 	if err := s.poolR.RequestUnlock(lastVPool, lock); err != nil {
@@ -203,7 +247,9 @@ func (s service) mine(txHash string, data interface{}, addr string, txEmSig stri
 
 	//Execute the Proof of Work
 	pow := pow{
+		txHash:      txHash,
 		lastVPool:   lastVPool,
+		vPool:       vPool,
 		emLister:    s.emLister,
 		robotPubKey: s.config.PublicKey,
 		robotPvKey:  s.config.PrivateKey,
@@ -242,29 +288,42 @@ func (s service) mine(txHash string, data interface{}, addr string, txEmSig stri
 	return NewEndorsement(lastTxHash, txHash, masterValid, valids), nil
 }
 
-func (s service) Validate(txHash string, data interface{}, txType TransactionType) (Validation, error) {
+func (s service) Validate(txHash string, data interface{}, txType TransactionType, vPool []string) (Validation, error) {
 	if s.txMiners[txType] == nil {
 		return nil, ErrUnsupportedTransaction
 	}
 
+	if !containsKey(vPool, s.config.PublicKey) {
+		return nil, ErrNotValidationPoolMember
+	}
+
 	if err := s.txMiners[txType].CheckAsSlave(txHash, data); err != nil {
 		if err == ErrInvalidTransaction || err == ErrUnsupportedTransaction {
-			return s.buildValidation(ValidationKO)
+			return s.buildValidation(ValidationKO, txHash, vPool)
 		}
 		return nil, err
 	}
-	return s.buildValidation(ValidationOK)
+	return s.buildValidation(ValidationOK, txHash, vPool)
 }
 
-func (s service) buildValidation(status ValidationStatus) (Validation, error) {
+func (s service) buildValidation(status ValidationStatus, txHash string, vPool []string) (Validation, error) {
 	v := validation{
 		pubk:      s.config.PublicKey,
 		status:    status,
 		timestamp: time.Now(),
 	}
-	sValid, err := s.signer.SignValidation(v, s.config.PrivateKey)
+	sValid, err := s.signer.SignValidation(v, txHash, vPool, s.config.PrivateKey)
 	if err != nil {
 		return nil, err
 	}
 	return sValid, nil
 }
+
+func containsKey(keys []string, key string) bool {
+	for _, k := range keys {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
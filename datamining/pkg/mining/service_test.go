@@ -11,6 +11,7 @@ import (
 	emListing "github.com/uniris/uniris-core/datamining/pkg/emitter/listing"
 	"github.com/uniris/uniris-core/datamining/pkg/lock"
 	"github.com/uniris/uniris-core/datamining/pkg/system"
+	"github.com/uniris/uniris-core/datamining/pkg/transport/mem"
 )
 
 /*
@@ -77,6 +78,34 @@ func TestUnlock(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+/*
+Scenario: Notify the failure of a transaction mining
+	Given a transaction watched
+	When the mining of the transaction fails
+	Then the watcher receives the failure status and a mining failed event is published
+*/
+func TestNotifyMiningFailure(t *testing.T) {
+	notif := mem.NewNotifier()
+	s := service{
+		statuses: NewStatusWatcher(),
+		notif:    notif,
+	}
+
+	statuses, unwatch := s.statuses.Watch("txHash")
+	defer unwatch()
+
+	s.notifyFailure("txHash", KeychainTransaction, ErrInvalidTransaction)
+
+	assert.Equal(t, TransactionFailure, <-statuses)
+	assert.Equal(t, []interface{}{
+		datamining.MiningFailed{
+			TransactionHash: "txHash",
+			TransactionType: "Keychain",
+			Reason:          ErrInvalidTransaction.Error(),
+		},
+	}, notif.Events())
+}
+
 /*
 Scenario: Validate data from a kind of transaction
 	Given a transaction hash, data and a transaction type
@@ -99,7 +128,7 @@ func TestValidateTx(t *testing.T) {
 		signer: mockSrvSigner{},
 	}
 
-	valid, err := s.Validate("hash", "fake data", KeychainTransaction)
+	valid, err := s.Validate("hash", "fake data", KeychainTransaction, []string{"pub key"})
 	assert.Nil(t, err)
 	assert.NotNil(t, valid)
 	assert.Equal(t, ValidationOK, valid.Status())
@@ -108,6 +137,27 @@ func TestValidateTx(t *testing.T) {
 	assert.Equal(t, time.Now().Unix(), valid.Timestamp().Unix())
 }
 
+/*
+Scenario: Validate a transaction for a validation pool without the peer
+	Given a transaction hash, data and a validation pool which does not contain the peer
+	When I want to validate the data
+	Then I get an error
+*/
+func TestValidateTxNotPoolMember(t *testing.T) {
+	s := service{
+		txMiners: map[TransactionType]TransactionMiner{
+			KeychainTransaction: mockMiner{},
+		},
+		config: system.UnirisConfig{
+			PublicKey: "pub key",
+		},
+		signer: mockSrvSigner{},
+	}
+
+	_, err := s.Validate("hash", "fake data", KeychainTransaction, []string{"other key"})
+	assert.Equal(t, ErrNotValidationPoolMember, err)
+}
+
 /*
 Scenario: Validate invalid data from a kind of transaction
 	Given a transaction hash, invalid data and a transaction type
@@ -130,7 +180,7 @@ func TestValidateInvalidTx(t *testing.T) {
 		signer: mockSrvSigner{},
 	}
 
-	valid, err := s.Validate("hash", "fake data", KeychainTransaction)
+	valid, err := s.Validate("hash", "fake data", KeychainTransaction, []string{"pub key"})
 	assert.Nil(t, err)
 	assert.NotNil(t, valid)
 	assert.Equal(t, ValidationKO, valid.Status())
@@ -145,7 +195,7 @@ func (s mockSrvSigner) VerifyTransactionDataSignature(txType TransactionType, pu
 	return nil
 }
 
-func (s mockSrvSigner) SignValidation(v Validation, pvKey string) (Validation, error) {
+func (s mockSrvSigner) SignValidation(v Validation, txHash string, vPool []string, pvKey string) (Validation, error) {
 	return NewValidation(v.Status(), v.Timestamp(), v.PublicKey(), "sig"), nil
 }
 
@@ -215,6 +265,14 @@ func (d *mockEmDatabase) ListSharedEmitterKeyPairs() ([]emitter.SharedKeyPair, e
 		}}, nil
 }
 
+func (d *mockEmDatabase) ListRetiredSharedEmitterKeyPairs() ([]emitter.RetiredSharedKeyPair, error) {
+	return []emitter.RetiredSharedKeyPair{}, nil
+}
+
+func (d *mockEmDatabase) FindEmitterAuthorization(pubKey string) (*emitter.Authorization, error) {
+	return nil, nil
+}
+
 type mockAIClient struct{}
 
 func (ai mockAIClient) GetMininumValidations(txHash string) (int, error) {
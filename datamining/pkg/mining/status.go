@@ -0,0 +1,75 @@
+package mining
+
+import "sync"
+
+//statusBuffer is the number of transitions kept for a slow subscriber, which covers the whole lifecycle of a transaction
+const statusBuffer = 3
+
+//StatusWatcher defines methods to broadcast the transaction status transitions
+type StatusWatcher interface {
+
+	//Watch subscribes to the status transitions of a transaction
+	//
+	//The returned function must be called to unsubscribe, it closes the channel
+	Watch(txHash string) (<-chan TransactionStatus, func())
+
+	//Notify broadcasts a status transition of a transaction to its subscribers
+	Notify(txHash string, status TransactionStatus)
+}
+
+type statusWatcher struct {
+	mu   sync.Mutex
+	subs map[string]map[chan TransactionStatus]struct{}
+}
+
+//NewStatusWatcher creates a status watcher broadcasting the transitions in memory
+func NewStatusWatcher() StatusWatcher {
+	return &statusWatcher{
+		subs: make(map[string]map[chan TransactionStatus]struct{}),
+	}
+}
+
+func (w *statusWatcher) Watch(txHash string) (<-chan TransactionStatus, func()) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	ch := make(chan TransactionStatus, statusBuffer)
+	if w.subs[txHash] == nil {
+		w.subs[txHash] = make(map[chan TransactionStatus]struct{})
+	}
+	w.subs[txHash][ch] = struct{}{}
+
+	var once sync.Once
+	unwatch := func() {
+		once.Do(func() {
+			w.mu.Lock()
+			defer w.mu.Unlock()
+
+			delete(w.subs[txHash], ch)
+			if len(w.subs[txHash]) == 0 {
+				delete(w.subs, txHash)
+			}
+			close(ch)
+		})
+	}
+	return ch, unwatch
+}
+
+func (w *statusWatcher) Notify(txHash string, status TransactionStatus) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for ch := range w.subs[txHash] {
+
+		//A subscriber which does not consume its transitions must not block the mining
+		select {
+		case ch <- status:
+		default:
+		}
+	}
+}
+
+//IsFinal checks if the transaction status will not change anymore
+func (s TransactionStatus) IsFinal() bool {
+	return s == TransactionSuccess || s == TransactionFailure
+}
@@ -0,0 +1,49 @@
+package mining
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+/*
+Scenario: Watch the status transitions of a transaction
+	Given a subscription to a transaction
+	When the transaction status changes
+	Then the subscriber receives the transitions of its transaction only
+*/
+func TestWatchTransactionStatus(t *testing.T) {
+	w := NewStatusWatcher()
+
+	statuses, unwatch := w.Watch("txHash")
+	w.Notify("other txHash", TransactionFailure)
+	w.Notify("txHash", TransactionPending)
+	w.Notify("txHash", TransactionSuccess)
+
+	assert.Equal(t, TransactionPending, <-statuses)
+	assert.Equal(t, TransactionSuccess, <-statuses)
+
+	unwatch()
+	_, open := <-statuses
+	assert.False(t, open)
+
+	w.Notify("txHash", TransactionFailure)
+	assert.Empty(t, w.(*statusWatcher).subs)
+}
+
+/*
+Scenario: Notify a subscriber which does not consume its transitions
+	Given a subscriber with a full buffer
+	When the transaction status changes
+	Then the notification does not block
+*/
+func TestNotifySlowSubscriber(t *testing.T) {
+	w := NewStatusWatcher()
+
+	_, unwatch := w.Watch("txHash")
+	defer unwatch()
+
+	for i := 0; i < statusBuffer+1; i++ {
+		w.Notify("txHash", TransactionPending)
+	}
+}
@@ -0,0 +1,53 @@
+package datamining
+
+//Notifier defines methods to publish the ledger events of the peer
+type Notifier interface {
+
+	//NotifyTransactionStored notifies a transaction stored by the peer
+	NotifyTransactionStored(TransactionStored) error
+
+	//NotifyTransactionRejected notifies a transaction stored by the peer in the KO database
+	NotifyTransactionRejected(TransactionRejected) error
+
+	//NotifyLockAcquired notifies a transaction locked by the peer
+	NotifyLockAcquired(LockAcquired) error
+
+	//NotifyLockReleased notifies a transaction unlocked by the peer
+	NotifyLockReleased(LockReleased) error
+
+	//NotifyMiningFailed notifies a transaction which the peer failed to mine as master
+	NotifyMiningFailed(MiningFailed) error
+}
+
+//TransactionStored is published when a transaction is stored
+type TransactionStored struct {
+	TransactionHash string
+	TransactionType string
+}
+
+//TransactionRejected is published when a transaction is stored with KO validations
+type TransactionRejected struct {
+	TransactionHash string
+	TransactionType string
+}
+
+//LockAcquired is published when a transaction is locked
+type LockAcquired struct {
+	TransactionHash string
+	Address         string
+	MasterRobotKey  string
+}
+
+//LockReleased is published when a transaction is unlocked
+type LockReleased struct {
+	TransactionHash string
+	Address         string
+	MasterRobotKey  string
+}
+
+//MiningFailed is published when the mining of a transaction fails
+type MiningFailed struct {
+	TransactionHash string
+	TransactionType string
+	Reason          string
+}
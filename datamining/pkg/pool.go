@@ -31,6 +31,15 @@ func (pL PeerList) IPs() []string {
 	return ips
 }
 
+//PublicKeys returns the public keys of the peer list
+func (pL PeerList) PublicKeys() []string {
+	keys := make([]string, 0)
+	for _, peer := range pL {
+		keys = append(keys, peer.PublicKey)
+	}
+	return keys
+}
+
 //NewPool creates a new pool
 func NewPool(pp ...Peer) Pool {
 	return pool{pp}
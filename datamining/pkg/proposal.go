@@ -1,5 +1,7 @@
 package datamining
 
+import "time"
+
 //Proposal describe a proposal for a transaction
 type Proposal interface {
 
@@ -30,16 +32,20 @@ type ProposedKeyPair interface {
 
 	//EncryptedPrivateKey returns the encrypted private key for the proposed keypair
 	EncryptedPrivateKey() string
+
+	//ActivationDate returns the date when all the nodes switch to the proposed keypair once endorsed
+	ActivationDate() time.Time
 }
 
 type propKP struct {
-	encPvKey string
-	pubKey   string
+	encPvKey   string
+	pubKey     string
+	activation time.Time
 }
 
 //NewProposedKeyPair creates a new proposed keypair
-func NewProposedKeyPair(encPvKey, pubKey string) ProposedKeyPair {
-	return propKP{encPvKey, pubKey}
+func NewProposedKeyPair(encPvKey, pubKey string, activation time.Time) ProposedKeyPair {
+	return propKP{encPvKey, pubKey, activation}
 }
 
 func (p propKP) PublicKey() string {
@@ -49,3 +55,7 @@ func (p propKP) PublicKey() string {
 func (p propKP) EncryptedPrivateKey() string {
 	return p.encPvKey
 }
+
+func (p propKP) ActivationDate() time.Time {
+	return p.activation
+}
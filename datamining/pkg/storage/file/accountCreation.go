@@ -0,0 +1,103 @@
+package file
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	account_creating "github.com/uniris/uniris-core/datamining/pkg/account/creating"
+)
+
+//sagaExtension is the extension of the files holding the sagas
+const sagaExtension = ".json"
+
+type accountCreationRepo struct {
+	dir string
+}
+
+//NewAccountCreationRepository creates a repository persisting each saga of the account creations in a file of the directory
+//
+//The sagas are written to a temporary file renamed afterwards, so a saga is never read half written after a crash
+func NewAccountCreationRepository(dir string) (account_creating.Repository, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+	return accountCreationRepo{dir}, nil
+}
+
+func (r accountCreationRepo) StoreAccountCreation(s account_creating.Saga) error {
+	b, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := ioutil.TempFile(r.dir, "saga")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(b); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), r.path(s.Hash()))
+}
+
+func (r accountCreationRepo) FindAccountCreation(hash string) (*account_creating.Saga, error) {
+	//The hash is received from the clients, so it is only used as a file name when it is a transaction hash
+	if _, err := hex.DecodeString(hash); err != nil || hash == "" {
+		return nil, nil
+	}
+
+	saga, err := readSaga(r.path(hash))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	return saga, err
+}
+
+func (r accountCreationRepo) ListPendingAccountCreations() ([]account_creating.Saga, error) {
+	files, err := ioutil.ReadDir(r.dir)
+	if err != nil {
+		return nil, err
+	}
+
+	sagas := make([]account_creating.Saga, 0)
+	for _, f := range files {
+		if f.IsDir() || !strings.HasSuffix(f.Name(), sagaExtension) {
+			continue
+		}
+		saga, err := readSaga(filepath.Join(r.dir, f.Name()))
+		if err != nil {
+			return nil, err
+		}
+		if saga.State.Status == account_creating.AccountCreationPending {
+			sagas = append(sagas, *saga)
+		}
+	}
+	return sagas, nil
+}
+
+func (r accountCreationRepo) path(hash string) string {
+	return filepath.Join(r.dir, hash+sagaExtension)
+}
+
+func readSaga(path string) (*account_creating.Saga, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var saga account_creating.Saga
+	if err := json.Unmarshal(b, &saga); err != nil {
+		return nil, err
+	}
+	return &saga, nil
+}
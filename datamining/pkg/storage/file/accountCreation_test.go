@@ -0,0 +1,77 @@
+package file
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	account_creating "github.com/uniris/uniris-core/datamining/pkg/account/creating"
+	"github.com/uniris/uniris-core/datamining/pkg/mining"
+)
+
+/*
+Scenario: Resume the account creations after a restart
+	Given a pending and a finished account creation stored in a directory
+	When I open the directory again
+	Then I find both account creations and only the pending one is listed
+*/
+func TestAccountCreationRepositoryRestart(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "sagas")
+	defer os.RemoveAll(dir)
+
+	repo, err := NewAccountCreationRepository(dir)
+	assert.Nil(t, err)
+	assert.Nil(t, repo.StoreAccountCreation(account_creating.Saga{
+		ID:                account_creating.TransactionResult{TransactionHash: "0a"},
+		EncryptedKeychain: "enc keychain",
+		State: account_creating.AccountCreationState{
+			Status:         account_creating.AccountCreationPending,
+			IDStatus:       mining.TransactionSuccess,
+			KeychainStatus: mining.TransactionPending,
+			Keychain:       account_creating.TransactionResult{TransactionHash: "0b"},
+		},
+	}))
+	assert.Nil(t, repo.StoreAccountCreation(account_creating.Saga{
+		ID: account_creating.TransactionResult{TransactionHash: "0c"},
+		State: account_creating.AccountCreationState{
+			Status: account_creating.AccountCreationSuccess,
+		},
+	}))
+
+	repo, _ = NewAccountCreationRepository(dir)
+	saga, err := repo.FindAccountCreation("0a")
+	assert.Nil(t, err)
+	assert.Equal(t, "enc keychain", saga.EncryptedKeychain)
+	assert.Equal(t, mining.TransactionSuccess, saga.State.IDStatus)
+	assert.Equal(t, "0b", saga.State.Keychain.TransactionHash)
+
+	saga, _ = repo.FindAccountCreation("0c")
+	assert.Equal(t, account_creating.AccountCreationSuccess, saga.State.Status)
+
+	pending, err := repo.ListPendingAccountCreations()
+	assert.Nil(t, err)
+	assert.Len(t, pending, 1)
+	assert.Equal(t, "0a", pending[0].Hash())
+}
+
+/*
+Scenario: Find an account creation with an invalid hash
+	Given a repository directory
+	When I look for an account creation with a hash which is not hexadecimal
+	Then nothing is found and no file outside the directory is read
+*/
+func TestAccountCreationRepositoryInvalidHash(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "sagas")
+	defer os.RemoveAll(dir)
+
+	repo, _ := NewAccountCreationRepository(dir)
+	saga, err := repo.FindAccountCreation("../../etc/passwd")
+	assert.Nil(t, err)
+	assert.Nil(t, saga)
+
+	saga, err = repo.FindAccountCreation("0d")
+	assert.Nil(t, err)
+	assert.Nil(t, saga)
+}
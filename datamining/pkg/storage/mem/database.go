@@ -2,9 +2,11 @@ package mem
 
 import (
 	"sort"
+	"time"
 
 	"github.com/uniris/uniris-core/datamining/pkg/account"
 	account_adding "github.com/uniris/uniris-core/datamining/pkg/account/adding"
+	account_creating "github.com/uniris/uniris-core/datamining/pkg/account/creating"
 	account_listing "github.com/uniris/uniris-core/datamining/pkg/account/listing"
 	"github.com/uniris/uniris-core/datamining/pkg/emitter"
 	em_adding "github.com/uniris/uniris-core/datamining/pkg/emitter/adding"
@@ -15,6 +17,7 @@ import (
 //Repo mock the entire database
 type Repo interface {
 	account_adding.Repository
+	account_creating.Repository
 	account_listing.Repository
 	em_listing.Repository
 	em_adding.Repository
@@ -28,6 +31,10 @@ type database struct {
 	KOKeychains []account.EndorsedKeychain
 	Locks       []lock.TransactionLock
 	SharedEmKP  []emitter.SharedKeyPair
+	RetiredEmKP []emitter.RetiredSharedKeyPair
+	EmKPProps   []emitter.SharedKeyPairProposal
+	EmAuths     []emitter.Authorization
+	Creations   []account_creating.Saga
 }
 
 //NewDatabase creates a new mock database
@@ -104,6 +111,102 @@ func (d *database) ListSharedEmitterKeyPairs() ([]emitter.SharedKeyPair, error)
 	return d.SharedEmKP, nil
 }
 
+func (d *database) ListRetiredSharedEmitterKeyPairs() ([]emitter.RetiredSharedKeyPair, error) {
+	return d.RetiredEmKP, nil
+}
+
+func (d *database) RetireSharedEmitterKeyPairs(retirement time.Time, expiration time.Time) error {
+	for _, kp := range d.SharedEmKP {
+		d.RetiredEmKP = append(d.RetiredEmKP, emitter.RetiredSharedKeyPair{
+			KeyPair:        kp,
+			RetirementDate: retirement,
+			ExpirationDate: expiration,
+		})
+	}
+	d.SharedEmKP = make([]emitter.SharedKeyPair, 0)
+	return nil
+}
+
+func (d *database) StoreSharedEmitterKeyPairProposal(p emitter.SharedKeyPairProposal) error {
+	for i, prop := range d.EmKPProps {
+		if prop.KeyPair.PublicKey == p.KeyPair.PublicKey {
+			d.EmKPProps[i] = p
+			return nil
+		}
+	}
+	d.EmKPProps = append(d.EmKPProps, p)
+	return nil
+}
+
+func (d *database) FindSharedEmitterKeyPairProposal(pubKey string) (*emitter.SharedKeyPairProposal, error) {
+	for _, prop := range d.EmKPProps {
+		if prop.KeyPair.PublicKey == pubKey {
+			return &prop, nil
+		}
+	}
+	return nil, nil
+}
+
+func (d *database) RemoveSharedEmitterKeyPairProposal(pubKey string) error {
+	for i, prop := range d.EmKPProps {
+		if prop.KeyPair.PublicKey == pubKey {
+			d.EmKPProps = append(d.EmKPProps[:i], d.EmKPProps[i+1:]...)
+			return nil
+		}
+	}
+	return nil
+}
+
+func (d *database) StoreEmitterAuthorization(a emitter.Authorization) error {
+	for i, auth := range d.EmAuths {
+		if auth.PublicKey == a.PublicKey {
+			d.EmAuths[i] = a
+			return nil
+		}
+	}
+	d.EmAuths = append(d.EmAuths, a)
+	return nil
+}
+
+func (d *database) FindEmitterAuthorization(pubKey string) (*emitter.Authorization, error) {
+	for _, auth := range d.EmAuths {
+		if auth.PublicKey == pubKey {
+			return &auth, nil
+		}
+	}
+	return nil, nil
+}
+
+func (d *database) StoreAccountCreation(s account_creating.Saga) error {
+	for i, c := range d.Creations {
+		if c.Hash() == s.Hash() {
+			d.Creations[i] = s
+			return nil
+		}
+	}
+	d.Creations = append(d.Creations, s)
+	return nil
+}
+
+func (d *database) FindAccountCreation(hash string) (*account_creating.Saga, error) {
+	for _, c := range d.Creations {
+		if c.Hash() == hash {
+			return &c, nil
+		}
+	}
+	return nil, nil
+}
+
+func (d *database) ListPendingAccountCreations() ([]account_creating.Saga, error) {
+	sagas := make([]account_creating.Saga, 0)
+	for _, c := range d.Creations {
+		if c.State.Status == account_creating.AccountCreationPending {
+			sagas = append(sagas, c)
+		}
+	}
+	return sagas, nil
+}
+
 func (d *database) StoreKeychain(k account.EndorsedKeychain) error {
 	d.Keychains = append(d.Keychains, k)
 	return nil
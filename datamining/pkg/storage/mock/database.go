@@ -2,6 +2,7 @@ package mock
 
 import (
 	"sort"
+	"time"
 
 	"github.com/uniris/uniris-core/datamining/pkg/account"
 	account_adding "github.com/uniris/uniris-core/datamining/pkg/account/adding"
@@ -33,6 +34,9 @@ type mockDatabase struct {
 	KOKeychains []account.EndorsedKeychain
 	Locks       []lock.TransactionLock
 	SharedEmKP  []emitter.SharedKeyPair
+	RetiredEmKP []emitter.RetiredSharedKeyPair
+	EmKPProps   []emitter.SharedKeyPairProposal
+	EmAuths     []emitter.Authorization
 }
 
 func (d mockDatabase) FindID(hash string) (account.EndorsedID, error) {
@@ -104,6 +108,72 @@ func (d *mockDatabase) ListSharedEmitterKeyPairs() ([]emitter.SharedKeyPair, err
 	return d.SharedEmKP, nil
 }
 
+func (d *mockDatabase) ListRetiredSharedEmitterKeyPairs() ([]emitter.RetiredSharedKeyPair, error) {
+	return d.RetiredEmKP, nil
+}
+
+func (d *mockDatabase) RetireSharedEmitterKeyPairs(retirement time.Time, expiration time.Time) error {
+	for _, kp := range d.SharedEmKP {
+		d.RetiredEmKP = append(d.RetiredEmKP, emitter.RetiredSharedKeyPair{
+			KeyPair:        kp,
+			RetirementDate: retirement,
+			ExpirationDate: expiration,
+		})
+	}
+	d.SharedEmKP = make([]emitter.SharedKeyPair, 0)
+	return nil
+}
+
+func (d *mockDatabase) StoreSharedEmitterKeyPairProposal(p emitter.SharedKeyPairProposal) error {
+	for i, prop := range d.EmKPProps {
+		if prop.KeyPair.PublicKey == p.KeyPair.PublicKey {
+			d.EmKPProps[i] = p
+			return nil
+		}
+	}
+	d.EmKPProps = append(d.EmKPProps, p)
+	return nil
+}
+
+func (d *mockDatabase) FindSharedEmitterKeyPairProposal(pubKey string) (*emitter.SharedKeyPairProposal, error) {
+	for _, prop := range d.EmKPProps {
+		if prop.KeyPair.PublicKey == pubKey {
+			return &prop, nil
+		}
+	}
+	return nil, nil
+}
+
+func (d *mockDatabase) RemoveSharedEmitterKeyPairProposal(pubKey string) error {
+	for i, prop := range d.EmKPProps {
+		if prop.KeyPair.PublicKey == pubKey {
+			d.EmKPProps = append(d.EmKPProps[:i], d.EmKPProps[i+1:]...)
+			return nil
+		}
+	}
+	return nil
+}
+
+func (d *mockDatabase) StoreEmitterAuthorization(a emitter.Authorization) error {
+	for i, auth := range d.EmAuths {
+		if auth.PublicKey == a.PublicKey {
+			d.EmAuths[i] = a
+			return nil
+		}
+	}
+	d.EmAuths = append(d.EmAuths, a)
+	return nil
+}
+
+func (d *mockDatabase) FindEmitterAuthorization(pubKey string) (*emitter.Authorization, error) {
+	for _, auth := range d.EmAuths {
+		if auth.PublicKey == pubKey {
+			return &auth, nil
+		}
+	}
+	return nil, nil
+}
+
 func (d *mockDatabase) StoreKeychain(k account.EndorsedKeychain) error {
 	d.Keychains = append(d.Keychains, k)
 	return nil
@@ -2,6 +2,8 @@ package system
 
 import (
 	"io/ioutil"
+	"sort"
+	"time"
 
 	yaml "gopkg.in/yaml.v2"
 )
@@ -11,12 +13,38 @@ type UnirisConfig struct {
 	PublicKey  string                `yaml:"publicKey"`
 	PrivateKey string                `yaml:"privateKey"`
 	SharedKeys SharedKeys            `yaml:"sharedKeys"`
+	Emitters   EmittersConfiguration `yaml:"emitters"`
+	KeyStore   KeyStoreConfiguration `yaml:"keystore"`
 	Services   ServicesConfiguration `yaml:"services"`
 }
 
+//EmittersConfiguration describes the emitters registry
+//
+//The genesis emitters are authorized when the node starts and
+//only the authorities are allowed to authorize or revoke an emitter afterwards
+type EmittersConfiguration struct {
+	Genesis     []string `yaml:"genesis"`
+	Authorities []string `yaml:"authorities"`
+}
+
+//KeyStoreConfiguration describes where the private keys are stored
+//
+//When a path is defined, the private keys are loaded from the encrypted key store instead of the configuration
+type KeyStoreConfiguration struct {
+	Path string `yaml:"path"`
+}
+
 //ServicesConfiguration describes the services configuration
 type ServicesConfiguration struct {
 	Datamining DataMiningConfiguration `yaml:"datamining"`
+	Discovery  DiscoveryConfiguration  `yaml:"discovery"`
+}
+
+//DiscoveryConfiguration describes the discovery service configuration used by the datamining
+//
+//The internal port serves the peers known by the gossip on the loopback
+type DiscoveryConfiguration struct {
+	InternalPort int `yaml:"internalPort"`
 }
 
 //KeyPair represent a keypair
@@ -27,17 +55,92 @@ type KeyPair struct {
 
 //SharedKeys describes the uniris shared keys
 type SharedKeys struct {
-	EmKeys []KeyPair `yaml:"em"`
-	Robot  KeyPair   `yaml:"robot"`
+	EmKeys        []KeyPair      `yaml:"em"`
+	EmRotation    KeyRotation    `yaml:"emRotation"`
+	Robot         KeyPair        `yaml:"robot"`
+	RobotVersions []RobotKeyPair `yaml:"robotVersions"`
+}
+
+//RobotKeyPair represents a version of the shared robot keypair
+//
+//All the nodes switch to a version at its activation date
+type RobotKeyPair struct {
+	Version        int       `yaml:"version"`
+	PrivateKey     string    `yaml:"priv"`
+	PublicKey      string    `yaml:"pub"`
+	ActivationDate time.Time `yaml:"activation"`
+}
+
+//RobotKeyPairs returns all the versions of the shared robot keypair from the latest to the oldest
+//
+//The robot keypair without version is considered as the version 0 always activated
+func (sk SharedKeys) RobotKeyPairs() []RobotKeyPair {
+	kps := make([]RobotKeyPair, 0)
+	if sk.Robot.PublicKey != "" || len(sk.RobotVersions) == 0 {
+		kps = append(kps, RobotKeyPair{
+			PrivateKey: sk.Robot.PrivateKey,
+			PublicKey:  sk.Robot.PublicKey,
+		})
+	}
+	kps = append(kps, sk.RobotVersions...)
+	sort.SliceStable(kps, func(i, j int) bool {
+		return kps[i].Version > kps[j].Version
+	})
+	return kps
+}
+
+//ActiveRobotKeyPairs returns the shared robot keypairs activated at the given time, the current one first
+func (sk SharedKeys) ActiveRobotKeyPairs(t time.Time) []RobotKeyPair {
+	kps := make([]RobotKeyPair, 0)
+	for _, kp := range sk.RobotKeyPairs() {
+		if !kp.ActivationDate.After(t) {
+			kps = append(kps, kp)
+		}
+	}
+	return kps
+}
+
+//CurrentRobotKeyPair returns the shared robot keypair to use at the given time
+func (sk SharedKeys) CurrentRobotKeyPair(t time.Time) RobotKeyPair {
+	kps := sk.ActiveRobotKeyPairs(t)
+	if len(kps) == 0 {
+		return RobotKeyPair{}
+	}
+	return kps[0]
+}
+
+//KeyRotation describes the rotation policy of shared keys
+//
+//An endorsement is counted only when it is stored at least the activation delay before the activation date of the proposal
+type KeyRotation struct {
+	MinEndorsements int           `yaml:"minEndorsements"`
+	ActivationDelay time.Duration `yaml:"activationDelay"`
+	GracePeriod     time.Duration `yaml:"gracePeriod"`
 }
 
 //DataMiningConfiguration describes the datamining configuration
+//
+//The account creations are persisted in the saga directory so they are resumed after a restart,
+//they are kept in memory and lost with the process when it is not defined
 type DataMiningConfiguration struct {
 	InternalPort int                `yaml:"internalPort"`
 	ExternalPort int                `yaml:"externalPort"`
+	MutualTLS    bool               `yaml:"mutualTLS"`
+	SagaDir      string             `yaml:"sagaDir"`
+	AMQP         AMQPConfig         `yaml:"amqp"`
 	Errors       DataMininingErrors `yaml:"errors"`
 }
 
+//AMQPConfig describes the AMQP notifier configuration
+//
+//The ledger events are only published when a host is defined
+type AMQPConfig struct {
+	Host     string `yaml:"host"`
+	Port     int    `yaml:"port"`
+	Username string `yaml:"username"`
+	Password string `yaml:"password"`
+}
+
 //DataMininingErrors defines the datamining errors
 type DataMininingErrors struct {
 	AccountNotExist string `yaml:"accountNotExist"`
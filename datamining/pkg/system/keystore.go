@@ -0,0 +1,86 @@
+package system
+
+import (
+	"fmt"
+
+	"github.com/uniris/uniris-core/shared/pkg/keystore"
+)
+
+//robotVersionKey returns the name of a version of the shared robot keypair
+func robotVersionKey(version int) string {
+	return fmt.Sprintf("%s-v%d", keystore.RobotKey, version)
+}
+
+//LoadKeyStore references the private keys of the key store in the configuration
+//
+//The public keys of the configuration must match the ones of the key store.
+//The private keys stay in the key store: the configuration only holds references to them.
+func LoadKeyStore(ks keystore.KeyStore, conf *UnirisConfig) error {
+	pub, err := ks.PublicKey(keystore.NodeKey)
+	if err != nil {
+		return err
+	}
+	if conf.PublicKey != "" && pub != conf.PublicKey {
+		return keystore.ErrKeyMismatch
+	}
+	conf.PublicKey = pub
+	conf.PrivateKey = keystore.Reference(keystore.NodeKey)
+
+	if conf.SharedKeys.Robot.PublicKey != "" {
+		if err := checkKeyStore(ks, keystore.RobotKey, conf.SharedKeys.Robot.PublicKey); err != nil {
+			return err
+		}
+		conf.SharedKeys.Robot.PrivateKey = keystore.Reference(keystore.RobotKey)
+	}
+
+	for i, v := range conf.SharedKeys.RobotVersions {
+		name := robotVersionKey(v.Version)
+		if err := checkKeyStore(ks, name, v.PublicKey); err != nil {
+			return err
+		}
+		conf.SharedKeys.RobotVersions[i].PrivateKey = keystore.Reference(name)
+	}
+
+	return nil
+}
+
+func checkKeyStore(ks keystore.KeyStore, name string, pubKey string) error {
+	pub, err := ks.PublicKey(name)
+	if err != nil {
+		return err
+	}
+	if pub != pubKey {
+		return keystore.ErrKeyMismatch
+	}
+	return nil
+}
+
+//ImportKeyStore stores the private keys of the configuration in the key store
+func ImportKeyStore(ks keystore.KeyStore, conf UnirisConfig) error {
+	if err := ks.StoreKeyPair(keystore.NodeKey, keystore.KeyPair{
+		PublicKey:  conf.PublicKey,
+		PrivateKey: conf.PrivateKey,
+	}); err != nil {
+		return err
+	}
+
+	if conf.SharedKeys.Robot.PrivateKey != "" {
+		if err := ks.StoreKeyPair(keystore.RobotKey, keystore.KeyPair{
+			PublicKey:  conf.SharedKeys.Robot.PublicKey,
+			PrivateKey: conf.SharedKeys.Robot.PrivateKey,
+		}); err != nil {
+			return err
+		}
+	}
+
+	for _, v := range conf.SharedKeys.RobotVersions {
+		if err := ks.StoreKeyPair(robotVersionKey(v.Version), keystore.KeyPair{
+			PublicKey:  v.PublicKey,
+			PrivateKey: v.PrivateKey,
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
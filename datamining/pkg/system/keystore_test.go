@@ -0,0 +1,86 @@
+package system
+
+import (
+	"crypto"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/uniris/uniris-core/shared/pkg/keystore"
+)
+
+/*
+Scenario: Load the keys of the configuration from a key store
+	Given a key store with the node and robot keys imported
+	When I load a configuration without private keys
+	Then the private keys are references to the key store
+*/
+func TestLoadKeyStore(t *testing.T) {
+	ks := &mockKeyStore{keys: make(map[string]keystore.KeyPair)}
+	assert.Nil(t, ImportKeyStore(ks, UnirisConfig{
+		PublicKey:  "node pub",
+		PrivateKey: "node pv",
+		SharedKeys: SharedKeys{
+			Robot: KeyPair{PublicKey: "robot pub", PrivateKey: "robot pv"},
+			RobotVersions: []RobotKeyPair{
+				RobotKeyPair{Version: 1, PublicKey: "robot pub v1", PrivateKey: "robot pv v1"},
+			},
+		},
+	}))
+
+	conf := &UnirisConfig{
+		PublicKey: "node pub",
+		SharedKeys: SharedKeys{
+			Robot: KeyPair{PublicKey: "robot pub"},
+			RobotVersions: []RobotKeyPair{
+				RobotKeyPair{Version: 1, PublicKey: "robot pub v1"},
+			},
+		},
+	}
+	assert.Nil(t, LoadKeyStore(ks, conf))
+	assert.Equal(t, keystore.Reference(keystore.NodeKey), conf.PrivateKey)
+	assert.Equal(t, keystore.Reference(keystore.RobotKey), conf.SharedKeys.Robot.PrivateKey)
+	assert.Equal(t, keystore.Reference("robot-v1"), conf.SharedKeys.RobotVersions[0].PrivateKey)
+}
+
+/*
+Scenario: Load a configuration which does not match the key store
+	Given a key store with the node key
+	When I load a configuration with another node public key
+	Then I get an error
+*/
+func TestLoadKeyStoreMismatch(t *testing.T) {
+	ks := &mockKeyStore{keys: map[string]keystore.KeyPair{
+		keystore.NodeKey: keystore.KeyPair{PublicKey: "node pub", PrivateKey: "node pv"},
+	}}
+	err := LoadKeyStore(ks, &UnirisConfig{PublicKey: "other pub"})
+	assert.Equal(t, keystore.ErrKeyMismatch, err)
+}
+
+type mockKeyStore struct {
+	keys map[string]keystore.KeyPair
+}
+
+func (s *mockKeyStore) PublicKey(name string) (string, error) {
+	kp, exist := s.keys[name]
+	if !exist {
+		return "", keystore.ErrKeyNotFound
+	}
+	return kp.PublicKey, nil
+}
+
+func (s *mockKeyStore) Sign(name string, data []byte) (string, error) {
+	return "", nil
+}
+
+func (s *mockKeyStore) Decrypt(name string, cipher string) ([]byte, error) {
+	return nil, nil
+}
+
+func (s *mockKeyStore) TLSSigner(name string) (crypto.Signer, error) {
+	return nil, nil
+}
+
+func (s *mockKeyStore) StoreKeyPair(name string, kp keystore.KeyPair) error {
+	s.keys[name] = kp
+	return nil
+}
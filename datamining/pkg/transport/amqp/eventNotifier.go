@@ -0,0 +1,175 @@
+package amqp
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/streadway/amqp"
+	datamining "github.com/uniris/uniris-core/datamining/pkg"
+	"github.com/uniris/uniris-core/datamining/pkg/system"
+)
+
+const (
+	queueNameTransactionStored   = "datamining_transaction_stored"
+	queueNameTransactionRejected = "datamining_transaction_rejected"
+	queueNameLockAcquired        = "datamining_lock_acquired"
+	queueNameLockReleased        = "datamining_lock_released"
+	queueNameMiningFailed        = "datamining_mining_failed"
+)
+
+//publicationBuffer is the number of events waiting to be published before the notifications are rejected
+const publicationBuffer = 100
+
+//ErrPublicationQueueFull is returned when the broker cannot keep up with the published events
+var ErrPublicationQueueFull = errors.New("Publication queue is full")
+
+type publication struct {
+	queueName string
+	body      []byte
+	timestamp time.Time
+}
+
+type notifier struct {
+	publications chan publication
+}
+
+//NotifyTransactionStored notifies a transaction stored by the peer
+func (n notifier) NotifyTransactionStored(e datamining.TransactionStored) error {
+	return n.notifyQueue(e, queueNameTransactionStored)
+}
+
+//NotifyTransactionRejected notifies a transaction stored by the peer in the KO database
+func (n notifier) NotifyTransactionRejected(e datamining.TransactionRejected) error {
+	return n.notifyQueue(e, queueNameTransactionRejected)
+}
+
+//NotifyLockAcquired notifies a transaction locked by the peer
+func (n notifier) NotifyLockAcquired(e datamining.LockAcquired) error {
+	return n.notifyQueue(e, queueNameLockAcquired)
+}
+
+//NotifyLockReleased notifies a transaction unlocked by the peer
+func (n notifier) NotifyLockReleased(e datamining.LockReleased) error {
+	return n.notifyQueue(e, queueNameLockReleased)
+}
+
+//NotifyMiningFailed notifies a transaction which the peer failed to mine as master
+func (n notifier) NotifyMiningFailed(e datamining.MiningFailed) error {
+	return n.notifyQueue(e, queueNameMiningFailed)
+}
+
+//notifyQueue enqueues the event for the publisher, so the ledger operations never wait for the broker
+func (n notifier) notifyQueue(event interface{}, queueName string) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	select {
+	case n.publications <- publication{queueName, data, time.Now()}:
+		return nil
+	default:
+		return ErrPublicationQueueFull
+	}
+}
+
+//publisher publishes the events on a single connection, reopened after a broker failure
+type publisher struct {
+	amqpURI string
+	conn    *amqp.Connection
+	ch      *amqp.Channel
+	queues  map[string]bool
+}
+
+func (p *publisher) run(publications <-chan publication) {
+	for pub := range publications {
+		if err := p.publish(pub); err != nil {
+			log.Printf("Event publication error: %s", err.Error())
+		}
+	}
+	p.close()
+}
+
+func (p *publisher) publish(pub publication) error {
+	err := p.tryPublish(pub)
+	if err == nil {
+		return nil
+	}
+
+	//The connection may have been closed by the broker, so one retry is done on a new connection
+	p.close()
+	return p.tryPublish(pub)
+}
+
+func (p *publisher) tryPublish(pub publication) error {
+	if err := p.open(); err != nil {
+		return err
+	}
+
+	if !p.queues[pub.queueName] {
+		if _, err := p.ch.QueueDeclare(
+			pub.queueName, // name
+			true,          // durable
+			false,         // delete when unused
+			false,         // exclusive
+			false,         // no-wait
+			nil,           // arguments
+		); err != nil {
+			return err
+		}
+		p.queues[pub.queueName] = true
+	}
+
+	return p.ch.Publish("", pub.queueName, false, false, amqp.Publishing{
+		ContentType:  "application/json",
+		Body:         pub.body,
+		DeliveryMode: amqp.Persistent,
+		Timestamp:    pub.timestamp,
+	})
+}
+
+func (p *publisher) open() error {
+	if p.ch != nil {
+		return nil
+	}
+
+	conn, err := amqp.Dial(p.amqpURI)
+	if err != nil {
+		return err
+	}
+	ch, err := conn.Channel()
+	if err != nil {
+		conn.Close()
+		return err
+	}
+
+	p.conn = conn
+	p.ch = ch
+	p.queues = make(map[string]bool)
+	return nil
+}
+
+func (p *publisher) close() {
+	if p.ch != nil {
+		p.ch.Close()
+	}
+	if p.conn != nil {
+		p.conn.Close()
+	}
+	p.ch = nil
+	p.conn = nil
+}
+
+//NewNotifier creates an amqp implementation of the datamining Notifier interface
+//
+//The events are published asynchronously on a connection kept open for the lifetime of the peer
+func NewNotifier(conf system.AMQPConfig) datamining.Notifier {
+	amqpURI := fmt.Sprintf("amqp://%s:%s@%s:%d", conf.Username, conf.Password, conf.Host, conf.Port)
+	publications := make(chan publication, publicationBuffer)
+	p := &publisher{amqpURI: amqpURI}
+	go p.run(publications)
+	return notifier{publications}
+}
@@ -0,0 +1,118 @@
+package discovery
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+
+	api "github.com/uniris/uniris-core/autodiscovery/api/protobuf-spec"
+	"github.com/uniris/uniris-core/datamining/pkg/system"
+)
+
+//ErrUnknownPeer is returned when no peer known by the gossip uses the IP
+var ErrUnknownPeer = errors.New("Unknown peer")
+
+//defaultRefreshInterval is the minimum interval between two reloads of the peers known by the gossip
+const defaultRefreshInterval = 5 * time.Second
+
+type peerKeyResolver struct {
+	nodePubKey      string
+	fetch           func() (map[string]string, error)
+	refreshInterval time.Duration
+
+	mu        sync.RWMutex
+	keys      map[string]string
+	refreshed time.Time
+}
+
+//NewPeerKeyResolver creates a resolver of the peer public keys backed by the peers known by the gossip
+//
+//The peers are requested to the internal service of the discovery and reloaded when an unknown IP is resolved.
+//The connections from the loopback come from the node itself and are resolved to the node public key,
+//so the discovery is only reached when a remote peer is resolved.
+func NewPeerKeyResolver(conf system.DiscoveryConfiguration, nodePubKey string) (*peerKeyResolver, error) {
+	conn, err := grpc.Dial(fmt.Sprintf("127.0.0.1:%d", conf.InternalPort), grpc.WithInsecure())
+	if err != nil {
+		return nil, err
+	}
+	client := api.NewInternalClient(conn)
+
+	return newPeerKeyResolver(nodePubKey, func() (map[string]string, error) {
+		return fetchPeerKeys(client)
+	}, defaultRefreshInterval), nil
+}
+
+func newPeerKeyResolver(nodePubKey string, fetch func() (map[string]string, error), refreshInterval time.Duration) *peerKeyResolver {
+	return &peerKeyResolver{
+		nodePubKey:      nodePubKey,
+		fetch:           fetch,
+		refreshInterval: refreshInterval,
+		keys:            make(map[string]string),
+	}
+}
+
+//PeerPublicKey returns the public key of the peer known by the gossip at the given IP
+func (r *peerKeyResolver) PeerPublicKey(ip net.IP) (string, error) {
+	if ip == nil {
+		return "", ErrUnknownPeer
+	}
+	if ip.IsLoopback() {
+		return r.nodePubKey, nil
+	}
+
+	if pubKey, ok := r.lookup(ip); ok {
+		return pubKey, nil
+	}
+	if err := r.refresh(); err != nil {
+		return "", err
+	}
+	if pubKey, ok := r.lookup(ip); ok {
+		return pubKey, nil
+	}
+	return "", ErrUnknownPeer
+}
+
+func (r *peerKeyResolver) lookup(ip net.IP) (string, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	pubKey, ok := r.keys[ip.String()]
+	return pubKey, ok
+}
+
+//refresh reloads the peers known by the gossip, at most once by refresh interval
+func (r *peerKeyResolver) refresh() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if time.Since(r.refreshed) < r.refreshInterval {
+		return nil
+	}
+	keys, err := r.fetch()
+	if err != nil {
+		return err
+	}
+	r.keys = keys
+	r.refreshed = time.Now()
+	return nil
+}
+
+func fetchPeerKeys(client api.InternalClient) (map[string]string, error) {
+	res, err := client.ListKnownPeers(context.Background(), &empty.Empty{})
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make(map[string]string)
+	for _, p := range res.Peers {
+		if ip := net.ParseIP(p.IP); ip != nil && p.PublicKey != "" {
+			keys[ip.String()] = p.PublicKey
+		}
+	}
+	return keys, nil
+}
@@ -0,0 +1,149 @@
+package discovery
+
+import (
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+
+	api "github.com/uniris/uniris-core/autodiscovery/api/protobuf-spec"
+	"github.com/uniris/uniris-core/datamining/pkg/system"
+)
+
+/*
+Scenario: Resolve the public key of a peer known by the gossip
+	Given a peer discovered by the gossip
+	When I resolve the public key of its IP
+	Then I get the public key of the peer
+*/
+func TestResolvePeerPublicKey(t *testing.T) {
+	r := newPeerKeyResolver("node key", func() (map[string]string, error) {
+		return map[string]string{"10.0.0.1": "peer key"}, nil
+	}, time.Second)
+
+	pubKey, err := r.PeerPublicKey(net.ParseIP("10.0.0.1"))
+	assert.Nil(t, err)
+	assert.Equal(t, "peer key", pubKey)
+}
+
+/*
+Scenario: Resolve the public key of an unknown peer
+	Given no peer discovered by the gossip at an IP
+	When I resolve the public key of this IP
+	Then I get an error
+*/
+func TestResolveUnknownPeerPublicKey(t *testing.T) {
+	r := newPeerKeyResolver("node key", func() (map[string]string, error) {
+		return map[string]string{"10.0.0.1": "peer key"}, nil
+	}, time.Second)
+
+	_, err := r.PeerPublicKey(net.ParseIP("10.0.0.2"))
+	assert.Equal(t, ErrUnknownPeer, err)
+
+	_, err = r.PeerPublicKey(nil)
+	assert.Equal(t, ErrUnknownPeer, err)
+}
+
+/*
+Scenario: Resolve the public key of a connection from the node itself
+	Given a connection from the loopback
+	When I resolve the public key of this IP
+	Then I get the node public key without reading the peers
+*/
+func TestResolveLoopbackPublicKey(t *testing.T) {
+	r := newPeerKeyResolver("node key", func() (map[string]string, error) {
+		return nil, errors.New("unexpected")
+	}, time.Second)
+
+	pubKey, err := r.PeerPublicKey(net.ParseIP("127.0.0.1"))
+	assert.Nil(t, err)
+	assert.Equal(t, "node key", pubKey)
+}
+
+/*
+Scenario: Reload the peers known by the gossip at most once by interval
+	Given a peer discovered after the first load
+	When I resolve its IP several times within the refresh interval and after it
+	Then the peers are reloaded only once the interval is elapsed
+*/
+func TestResolvePeerPublicKeyRefresh(t *testing.T) {
+	fetches := 0
+	peers := map[string]string{}
+	r := newPeerKeyResolver("node key", func() (map[string]string, error) {
+		fetches++
+		copy := make(map[string]string)
+		for ip, k := range peers {
+			copy[ip] = k
+		}
+		return copy, nil
+	}, 50*time.Millisecond)
+
+	_, err := r.PeerPublicKey(net.ParseIP("10.0.0.1"))
+	assert.Equal(t, ErrUnknownPeer, err)
+
+	peers["10.0.0.1"] = "peer key"
+	_, err = r.PeerPublicKey(net.ParseIP("10.0.0.1"))
+	assert.Equal(t, ErrUnknownPeer, err)
+	assert.Equal(t, 1, fetches)
+
+	time.Sleep(60 * time.Millisecond)
+	pubKey, err := r.PeerPublicKey(net.ParseIP("10.0.0.1"))
+	assert.Nil(t, err)
+	assert.Equal(t, "peer key", pubKey)
+	assert.Equal(t, 2, fetches)
+}
+
+/*
+Scenario: Resolve the public key of a peer from the discovery service
+	Given a peer known by the discovery, served by its internal service
+	When I resolve the public key of its IP
+	Then I get the public key of the peer returned by the discovery
+*/
+func TestResolvePeerPublicKeyFromDiscovery(t *testing.T) {
+	lis, _ := net.Listen("tcp", "127.0.0.1:0")
+	srv := grpc.NewServer()
+	api.RegisterInternalServer(srv, mockDiscovery{})
+	go srv.Serve(lis)
+	defer srv.Stop()
+
+	r, err := NewPeerKeyResolver(system.DiscoveryConfiguration{InternalPort: lis.Addr().(*net.TCPAddr).Port}, "node key")
+	assert.Nil(t, err)
+
+	pubKey, err := r.PeerPublicKey(net.ParseIP("10.0.0.1"))
+	assert.Nil(t, err)
+	assert.Equal(t, "peer key", pubKey)
+}
+
+/*
+Scenario: Create the resolver while the discovery service is not started
+	Given no discovery service listening
+	When I create the resolver and resolve a connection from the loopback
+	Then the resolver is created and the node public key is returned without reaching the discovery
+*/
+func TestCreatePeerKeyResolverWithoutDiscovery(t *testing.T) {
+	lis, _ := net.Listen("tcp", "127.0.0.1:0")
+	port := lis.Addr().(*net.TCPAddr).Port
+	lis.Close()
+
+	r, err := NewPeerKeyResolver(system.DiscoveryConfiguration{InternalPort: port}, "node key")
+	assert.Nil(t, err)
+
+	pubKey, err := r.PeerPublicKey(net.ParseIP("127.0.0.1"))
+	assert.Nil(t, err)
+	assert.Equal(t, "node key", pubKey)
+}
+
+type mockDiscovery struct{}
+
+func (d mockDiscovery) ListKnownPeers(ctx context.Context, req *empty.Empty) (*api.KnownPeers, error) {
+	return &api.KnownPeers{
+		Peers: []*api.PeerIdentity{
+			&api.PeerIdentity{IP: "10.0.0.1", Port: 3545, PublicKey: "peer key"},
+		},
+	}, nil
+}
@@ -0,0 +1,39 @@
+package logging
+
+import (
+	"log"
+
+	datamining "github.com/uniris/uniris-core/datamining/pkg"
+)
+
+type notifier struct{}
+
+func (n notifier) NotifyTransactionStored(e datamining.TransactionStored) error {
+	log.Printf("Transaction %s stored", e.TransactionHash)
+	return nil
+}
+
+func (n notifier) NotifyTransactionRejected(e datamining.TransactionRejected) error {
+	log.Printf("Transaction %s rejected", e.TransactionHash)
+	return nil
+}
+
+func (n notifier) NotifyLockAcquired(e datamining.LockAcquired) error {
+	log.Printf("Lock acquired for the transaction %s", e.TransactionHash)
+	return nil
+}
+
+func (n notifier) NotifyLockReleased(e datamining.LockReleased) error {
+	log.Printf("Lock released for the transaction %s", e.TransactionHash)
+	return nil
+}
+
+func (n notifier) NotifyMiningFailed(e datamining.MiningFailed) error {
+	log.Printf("Mining failed for the transaction %s: %s", e.TransactionHash, e.Reason)
+	return nil
+}
+
+//NewNotifier creates a notifier only logging the events, when no broker is configured
+func NewNotifier() datamining.Notifier {
+	return notifier{}
+}
@@ -0,0 +1,68 @@
+package mem
+
+import (
+	"log"
+	"sync"
+
+	datamining "github.com/uniris/uniris-core/datamining/pkg"
+)
+
+//Notifier is a datamining notifier keeping the published events in memory
+type Notifier interface {
+	datamining.Notifier
+
+	//Events returns the published events in their publication order
+	Events() []interface{}
+}
+
+type notifier struct {
+	mu     sync.Mutex
+	events []interface{}
+}
+
+func (n *notifier) NotifyTransactionStored(e datamining.TransactionStored) error {
+	log.Printf("Transaction %s stored", e.TransactionHash)
+	n.publish(e)
+	return nil
+}
+
+func (n *notifier) NotifyTransactionRejected(e datamining.TransactionRejected) error {
+	log.Printf("Transaction %s rejected", e.TransactionHash)
+	n.publish(e)
+	return nil
+}
+
+func (n *notifier) NotifyLockAcquired(e datamining.LockAcquired) error {
+	log.Printf("Lock acquired for the transaction %s", e.TransactionHash)
+	n.publish(e)
+	return nil
+}
+
+func (n *notifier) NotifyLockReleased(e datamining.LockReleased) error {
+	log.Printf("Lock released for the transaction %s", e.TransactionHash)
+	n.publish(e)
+	return nil
+}
+
+func (n *notifier) NotifyMiningFailed(e datamining.MiningFailed) error {
+	log.Printf("Mining failed for the transaction %s: %s", e.TransactionHash, e.Reason)
+	n.publish(e)
+	return nil
+}
+
+func (n *notifier) Events() []interface{} {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return append([]interface{}{}, n.events...)
+}
+
+func (n *notifier) publish(e interface{}) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.events = append(n.events, e)
+}
+
+//NewNotifier creates a notifier in memory
+func NewNotifier() Notifier {
+	return &notifier{}
+}
@@ -22,7 +22,26 @@ func (c aiClient) GetMasterPeer(txHash string) (datamining.Peer, error) {
 }
 
 func (c aiClient) GetValidationPool(txHash string) (datamining.Pool, error) {
-	return datamining.NewPool(datamining.Peer{IP: net.ParseIP("127.0.0.1")}), nil
+	return datamining.NewPool(datamining.Peer{
+		IP:        net.ParseIP("127.0.0.1"),
+		PublicKey: "key",
+	}), nil
+}
+
+func (c aiClient) GetMasterPeers(txHashes []string) (map[string]datamining.Peer, error) {
+	peers := make(map[string]datamining.Peer)
+	for _, txHash := range txHashes {
+		peers[txHash], _ = c.GetMasterPeer(txHash)
+	}
+	return peers, nil
+}
+
+func (c aiClient) GetValidationPools(txHashes []string) (map[string]datamining.Pool, error) {
+	pools := make(map[string]datamining.Pool)
+	for _, txHash := range txHashes {
+		pools[txHash], _ = c.GetValidationPool(txHash)
+	}
+	return pools, nil
 }
 
 func (c aiClient) CheckStorageAuthorization(txHash string) error {
@@ -1,6 +1,7 @@
 package mock
 
 import (
+	"context"
 	"errors"
 	"time"
 
@@ -43,7 +44,7 @@ func (c mockExtClient) RequestUnlock(ip string, txLock lock.TransactionLock) err
 	return c.db.RemoveLock(txLock)
 }
 
-func (c mockExtClient) RequestValidation(ip string, txType mining.TransactionType, txHash string, data interface{}) (mining.Validation, error) {
+func (c mockExtClient) RequestValidation(ip string, txType mining.TransactionType, txHash string, data interface{}, vPool []string) (mining.Validation, error) {
 	return mining.NewValidation(
 		mining.ValidationOK,
 		time.Now(),
@@ -68,3 +69,10 @@ func (c mockExtClient) RequestStorage(ip string, txType mining.TransactionType,
 func (c mockExtClient) GetTransactionStatus(ip string, addr string, txHash string) (mining.TransactionStatus, error) {
 	return mining.TransactionSuccess, nil
 }
+
+func (c mockExtClient) WatchTransactionStatus(ctx context.Context, ip string, addr string, txHash string) (<-chan mining.TransactionStatus, error) {
+	statuses := make(chan mining.TransactionStatus, 1)
+	statuses <- mining.TransactionSuccess
+	close(statuses)
+	return statuses, nil
+}
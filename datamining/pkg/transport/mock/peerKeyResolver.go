@@ -0,0 +1,18 @@
+package mock
+
+import "net"
+
+type peerKeyResolver struct {
+	pubKey string
+}
+
+//NewPeerKeyResolver create a new mock of the peer key resolver
+//
+//As the mocked pools contain only the local peer, every peer is resolved to the node public key
+func NewPeerKeyResolver(nodePubKey string) peerKeyResolver {
+	return peerKeyResolver{nodePubKey}
+}
+
+func (r peerKeyResolver) PeerPublicKey(ip net.IP) (string, error) {
+	return r.pubKey, nil
+}
@@ -31,7 +31,7 @@ func (r mockPoolRequester) RequestUnlock(lockPool datamining.Pool, txLock lock.T
 }
 
 func (r mockPoolRequester) RequestValidations(minValid int, sPool datamining.Pool, txHash string, data interface{}, txType mining.TransactionType) ([]mining.Validation, error) {
-	v, _ := r.cli.RequestValidation("127.0.0.1", txType, txHash, data)
+	v, _ := r.cli.RequestValidation("127.0.0.1", txType, txHash, data, sPool.Peers().PublicKeys())
 	return []mining.Validation{v}, nil
 }
 
@@ -0,0 +1,311 @@
+package rpc
+
+import (
+	"log"
+	"sync"
+
+	"golang.org/x/net/context"
+
+	api "github.com/uniris/uniris-core/datamining/api/protobuf-spec"
+	datamining "github.com/uniris/uniris-core/datamining/pkg"
+	"github.com/uniris/uniris-core/datamining/pkg/account"
+	"github.com/uniris/uniris-core/datamining/pkg/account/creating"
+	"github.com/uniris/uniris-core/datamining/pkg/mining"
+	"github.com/uniris/uniris-core/datamining/pkg/system"
+	"github.com/uniris/uniris-core/shared/pkg/parallel"
+)
+
+//batchConcurrency is the maximum number of transactions of a batch decrypted at the same time
+const batchConcurrency = 8
+
+type accountTxLauncher struct {
+	aiClient AIClient
+	extCli   ExternalClient
+	poolF    mining.PoolFinder
+	crypto   Crypto
+	robot    robotKeys
+
+	//prepared are the transactions of a batch decrypted with their pools selected, by encrypted data
+	prepared map[string]preparedTx
+}
+
+//preparedTx is a transaction decrypted and with its pools selected, ready to be launched
+type preparedTx struct {
+	txHash    string
+	encAddr   string
+	master    datamining.Peer
+	validPool datamining.Pool
+}
+
+//NewAccountTransactionLauncher creates a launcher asking the master peers to lead the account transactions
+func NewAccountTransactionLauncher(aiClient AIClient, extCli ExternalClient, pF mining.PoolFinder, crypto Crypto, conf system.UnirisConfig) creating.TransactionLauncher {
+	return newAccountTxLauncher(aiClient, extCli, pF, crypto, conf)
+}
+
+func newAccountTxLauncher(aiClient AIClient, extCli ExternalClient, pF mining.PoolFinder, crypto Crypto, conf system.UnirisConfig) accountTxLauncher {
+	return accountTxLauncher{
+		aiClient: aiClient,
+		extCli:   extCli,
+		poolF:    pF,
+		crypto:   crypto,
+		robot:    robotKeys{conf.SharedKeys},
+	}
+}
+
+func (l accountTxLauncher) LaunchKeychain(encKeychain string) (creating.TransactionResult, error) {
+	tx, ok := l.prepared[encKeychain]
+	if !ok {
+		keychain, err := l.robot.decryptKeychain(l.crypto.decrypter, encKeychain)
+		if err != nil {
+			return creating.TransactionResult{}, err
+		}
+		if tx, err = l.prepare(keychain.EncryptedAddrByRobot(), func() (string, error) {
+			return l.crypto.hasher.HashKeychain(keychain)
+		}); err != nil {
+			return creating.TransactionResult{}, err
+		}
+	}
+
+	if err := l.extCli.LeadKeychainMining(tx.master.IP.String(), tx.txHash, encKeychain, tx.validPool.Peers().IPs()); err != nil {
+		log.Print(err.Error())
+		return creating.TransactionResult{}, err
+	}
+
+	return l.signResult(&api.CreationResult{
+		TransactionHash:  tx.txHash,
+		MasterPeerIP:     tx.master.IP.String(),
+		EncryptedAddress: tx.encAddr,
+	})
+}
+
+func (l accountTxLauncher) LaunchID(encID string) (creating.TransactionResult, error) {
+	tx, ok := l.prepared[encID]
+	if !ok {
+		id, err := l.robot.decryptID(l.crypto.decrypter, encID)
+		if err != nil {
+			return creating.TransactionResult{}, err
+		}
+		if tx, err = l.prepare(id.EncryptedAddrByRobot(), func() (string, error) {
+			return l.crypto.hasher.HashID(id)
+		}); err != nil {
+			return creating.TransactionResult{}, err
+		}
+	}
+
+	if err := l.extCli.LeadIDMining(tx.master.IP.String(), tx.txHash, encID, tx.validPool.Peers().IPs()); err != nil {
+		return creating.TransactionResult{}, err
+	}
+
+	return l.signResult(&api.CreationResult{
+		TransactionHash:  tx.txHash,
+		MasterPeerIP:     tx.master.IP.String(),
+		EncryptedAddress: tx.encAddr,
+	})
+}
+
+//prepare hashes a decrypted transaction and selects its pools
+func (l accountTxLauncher) prepare(encAddr string, hash func() (string, error)) (preparedTx, error) {
+	txHash, err := hash()
+	if err != nil {
+		return preparedTx{}, err
+	}
+
+	master, err := l.aiClient.GetMasterPeer(txHash)
+	if err != nil {
+		return preparedTx{}, err
+	}
+	validPool, err := l.aiClient.GetValidationPool(txHash)
+	if err != nil {
+		return preparedTx{}, err
+	}
+
+	return preparedTx{
+		txHash:    txHash,
+		encAddr:   encAddr,
+		master:    master,
+		validPool: validPool,
+	}, nil
+}
+
+func (l accountTxLauncher) PrepareBatch(reqs []creating.AccountCreationRequest) creating.TransactionLauncher {
+	bk := l.robot.forBatch()
+
+	ids := make([]*preparedTx, len(reqs))
+	keychains := make([]*preparedTx, len(reqs))
+	parallel.Each(len(reqs), batchConcurrency, func(i int) {
+		ids[i] = l.hashBatchID(bk, reqs[i].EncryptedID)
+		keychains[i] = l.hashBatchKeychain(bk, reqs[i].EncryptedKeychain)
+	})
+
+	//The transactions not decrypted are not prepared, so they are launched on their own and fail with their own error
+	pending := make(map[string]*preparedTx)
+	txHashes := make([]string, 0)
+	for i, req := range reqs {
+		if ids[i] != nil {
+			pending[req.EncryptedID] = ids[i]
+			txHashes = append(txHashes, ids[i].txHash)
+		}
+		if keychains[i] != nil {
+			pending[req.EncryptedKeychain] = keychains[i]
+			txHashes = append(txHashes, keychains[i].txHash)
+		}
+	}
+
+	//The pools of all the transactions are selected with a single lookup
+	masters, err := l.aiClient.GetMasterPeers(txHashes)
+	if err != nil {
+		log.Printf("Batch master peers lookup error: %s", err.Error())
+		return l
+	}
+	validPools, err := l.aiClient.GetValidationPools(txHashes)
+	if err != nil {
+		log.Printf("Batch validation pools lookup error: %s", err.Error())
+		return l
+	}
+
+	batch := l
+	batch.prepared = make(map[string]preparedTx)
+	for encData, tx := range pending {
+		master, okMaster := masters[tx.txHash]
+		validPool, okPool := validPools[tx.txHash]
+		if okMaster && okPool {
+			tx.master = master
+			tx.validPool = validPool
+			batch.prepared[encData] = *tx
+		}
+	}
+	return batch
+}
+
+//hashBatchID decrypts an ID of a batch and computes its transaction hash, nil when it cannot be decrypted
+func (l accountTxLauncher) hashBatchID(bk *batchKeys, encID string) *preparedTx {
+	var id account.ID
+	if err := bk.decrypt(func(pvKey string) (err error) {
+		id, err = l.crypto.decrypter.DecryptID(encID, pvKey)
+		return
+	}); err != nil {
+		return nil
+	}
+	txHash, err := l.crypto.hasher.HashID(id)
+	if err != nil {
+		return nil
+	}
+	return &preparedTx{txHash: txHash, encAddr: id.EncryptedAddrByRobot()}
+}
+
+//hashBatchKeychain decrypts a keychain of a batch and computes its transaction hash, nil when it cannot be decrypted
+func (l accountTxLauncher) hashBatchKeychain(bk *batchKeys, encKeychain string) *preparedTx {
+	var keychain account.Keychain
+	if err := bk.decrypt(func(pvKey string) (err error) {
+		keychain, err = l.crypto.decrypter.DecryptKeychain(encKeychain, pvKey)
+		return
+	}); err != nil {
+		return nil
+	}
+	txHash, err := l.crypto.hasher.HashKeychain(keychain)
+	if err != nil {
+		return nil
+	}
+	return &preparedTx{txHash: txHash, encAddr: keychain.EncryptedAddrByRobot()}
+}
+
+func (l accountTxLauncher) signResult(res *api.CreationResult) (creating.TransactionResult, error) {
+	if err := l.crypto.signer.SignCreationResult(res, l.robot.privateKey()); err != nil {
+		return creating.TransactionResult{}, err
+	}
+
+	return creating.TransactionResult{
+		TransactionHash:  res.TransactionHash,
+		MasterPeerIP:     res.MasterPeerIP,
+		EncryptedAddress: res.EncryptedAddress,
+		Signature:        res.Signature,
+	}, nil
+}
+
+func (l accountTxLauncher) WatchStatus(ctx context.Context, encAddr string, txHash string) (<-chan mining.TransactionStatus, error) {
+	addr, err := l.robot.decryptHash(l.crypto.decrypter, encAddr)
+	if err != nil {
+		return nil, err
+	}
+
+	storagePool, err := l.poolF.FindStoragePool(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	//The master peer is the only one aware of a mining failure, as nothing reaches the storage pool
+	master, err := l.aiClient.GetMasterPeer(txHash)
+	if err != nil {
+		return nil, err
+	}
+	ips := storagePool.Peers().IPs()
+	if !containsIP(ips, master.IP.String()) {
+		ips = append(ips, master.IP.String())
+	}
+
+	//Merges the transitions streamed by the master and the storage peers
+	transitions := make(chan mining.TransactionStatus)
+	var wg sync.WaitGroup
+	for _, p := range ips {
+		statuses, err := l.extCli.WatchTransactionStatus(ctx, p, encAddr, txHash)
+		if err != nil {
+			log.Print(err.Error())
+			continue
+		}
+
+		wg.Add(1)
+		go func(statuses <-chan mining.TransactionStatus) {
+			defer wg.Done()
+			for status := range statuses {
+				select {
+				case transitions <- status:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}(statuses)
+	}
+	go func() {
+		wg.Wait()
+		close(transitions)
+	}()
+
+	//TODO: Provide consensus of the data retrieval
+	merged := make(chan mining.TransactionStatus)
+	go func() {
+		defer close(merged)
+
+		last := mining.TransactionUnknown
+		sent := false
+		for status := range transitions {
+
+			//Peers which did not receive the transaction yet must not hide the transitions already sent
+			if sent && (status == last || status == mining.TransactionUnknown) {
+				continue
+			}
+
+			select {
+			case merged <- status:
+			case <-ctx.Done():
+				return
+			}
+			last = status
+			sent = true
+
+			if status.IsFinal() {
+				return
+			}
+		}
+	}()
+
+	return merged, nil
+}
+
+func containsIP(ips []string, ip string) bool {
+	for _, i := range ips {
+		if i == ip {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,79 @@
+package rpc
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	datamining "github.com/uniris/uniris-core/datamining/pkg"
+	"github.com/uniris/uniris-core/datamining/pkg/account/creating"
+	mockcrypto "github.com/uniris/uniris-core/datamining/pkg/crypto/mock"
+	mockstorage "github.com/uniris/uniris-core/datamining/pkg/storage/mock"
+	"github.com/uniris/uniris-core/datamining/pkg/system"
+	mocktransport "github.com/uniris/uniris-core/datamining/pkg/transport/mock"
+)
+
+/*
+Scenario: Launch the transactions of a prepared batch
+	Given a batch of account creations
+	When I prepare the batch and launch its transactions
+	Then the pools are selected with a single lookup for the whole batch
+*/
+func TestPrepareBatch(t *testing.T) {
+	crypto := Crypto{
+		decrypter: mockcrypto.NewDecrypter(),
+		signer:    mockcrypto.NewSigner(),
+		hasher:    mockcrypto.NewHasher(),
+	}
+	aiCli := &mockCountingAIClient{AIClient: mocktransport.NewAIClient()}
+	extCli := mocktransport.NewExternalClient(mockstorage.NewDatabase())
+	l := newAccountTxLauncher(aiCli, extCli, nil, crypto, system.UnirisConfig{})
+
+	batch := l.PrepareBatch([]creating.AccountCreationRequest{
+		creating.AccountCreationRequest{EncryptedID: "enc id 1", EncryptedKeychain: "enc keychain 1"},
+		creating.AccountCreationRequest{EncryptedID: "enc id 2", EncryptedKeychain: "enc keychain 2"},
+	})
+
+	for _, enc := range []string{"enc keychain 1", "enc keychain 2"} {
+		res, err := batch.LaunchKeychain(enc)
+		assert.Nil(t, err)
+		assert.Equal(t, "hash", res.TransactionHash)
+		assert.Equal(t, "127.0.0.1", res.MasterPeerIP)
+	}
+	for _, enc := range []string{"enc id 1", "enc id 2"} {
+		res, err := batch.LaunchID(enc)
+		assert.Nil(t, err)
+		assert.Equal(t, "hash", res.TransactionHash)
+	}
+
+	assert.Equal(t, 1, aiCli.batchLookups)
+	assert.Equal(t, 0, aiCli.lookups)
+
+	//A transaction outside the batch is prepared on its own
+	_, err := batch.LaunchKeychain("enc keychain 3")
+	assert.Nil(t, err)
+	assert.Equal(t, 1, aiCli.lookups)
+}
+
+//mockCountingAIClient counts the pool lookups
+type mockCountingAIClient struct {
+	AIClient
+	mu           sync.Mutex
+	lookups      int
+	batchLookups int
+}
+
+func (c *mockCountingAIClient) GetMasterPeer(txHash string) (datamining.Peer, error) {
+	c.mu.Lock()
+	c.lookups++
+	c.mu.Unlock()
+	return c.AIClient.GetMasterPeer(txHash)
+}
+
+func (c *mockCountingAIClient) GetMasterPeers(txHashes []string) (map[string]datamining.Peer, error) {
+	c.mu.Lock()
+	c.batchLookups++
+	c.mu.Unlock()
+	return c.AIClient.GetMasterPeers(txHashes)
+}
@@ -14,4 +14,10 @@ type AIClient interface {
 
 	//GetValidationPool asks the AI service to perform a search of validation pools based on a transaction hash
 	GetValidationPool(txHash string) (datamining.Pool, error)
+
+	//GetMasterPeers asks the AI service to elect the master peers of several transactions in a single lookup
+	GetMasterPeers(txHashes []string) (map[string]datamining.Peer, error)
+
+	//GetValidationPools asks the AI service to search the validation pools of several transactions in a single lookup
+	GetValidationPools(txHashes []string) (map[string]datamining.Pool, error)
 }
@@ -19,6 +19,7 @@ func (b apiBuilder) buildID(id account.ID) *api.ID {
 			SharedEmitterKeyPair: &api.KeyPairProposal{
 				EncryptedPrivateKey: id.Proposal().SharedEmitterKeyPair().EncryptedPrivateKey(),
 				PublicKey:           id.Proposal().SharedEmitterKeyPair().PublicKey(),
+				ActivationDate:      id.Proposal().SharedEmitterKeyPair().ActivationDate().Unix(),
 			},
 		},
 		IDSignature:      id.IDSignature(),
@@ -35,6 +36,7 @@ func (b apiBuilder) buildKeychain(kc account.Keychain) *api.Keychain {
 			SharedEmitterKeyPair: &api.KeyPairProposal{
 				EncryptedPrivateKey: kc.Proposal().SharedEmitterKeyPair().EncryptedPrivateKey(),
 				PublicKey:           kc.Proposal().SharedEmitterKeyPair().PublicKey(),
+				ActivationDate:      kc.Proposal().SharedEmitterKeyPair().ActivationDate().Unix(),
 			},
 		},
 		EmitterSignature: kc.EmitterSignature(),
@@ -60,6 +62,7 @@ func (b apiBuilder) buildMasterValidation(mv mining.MasterValidation) *api.Maste
 		LastTransactionMiners: mv.LastTransactionMiners(),
 		ProofOfWorkKey:        mv.ProofOfWorkKey(),
 		ProofOfWorkValidation: b.buildValidation(mv.ProofOfWorkValidation()),
+		ValidationPool:        mv.ValidationPool(),
 	}
 }
 
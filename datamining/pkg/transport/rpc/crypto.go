@@ -54,39 +54,21 @@ type Signer interface {
 
 type signatureVerifier interface {
 
-	//VerifyKeychainValidationRequestSignature checks the keychain validation request's signature
-	//Using the share robot public key
-	VerifyKeychainValidationRequestSignature(pubKey string, req *api.KeychainValidationRequest) error
+	//VerifyRequestEnvelopeSignature checks the signature of a request envelope using the public key of its signer
+	VerifyRequestEnvelopeSignature(env *api.RequestEnvelope, req interface{}) error
 
-	//VerifyIDValidationRequestSignature checks the ID validation request's signature
-	//Using the share robot public key
-	VerifyIDValidationRequestSignature(pubKey string, req *api.IDValidationRequest) error
+	//VerifyKeychainUpdateRequestSignature checks the signature of a keychain update request using the ID public key
+	VerifyKeychainUpdateRequestSignature(req *api.KeychainUpdateRequest, pubKey string) error
 
-	//VerifyKeychainStorageRequestSignature checks the keychain storage request's signature
-	//Using the share robot public key
-	VerifyKeychainStorageRequestSignature(pubKey string, req *api.KeychainStorageRequest) error
+	//VerifyEmitterAuthorizationRequestSignature checks the signature of an emitter authorization request using an authority public key
+	VerifyEmitterAuthorizationRequestSignature(req *api.EmitterAuthorizationRequest, pubKey string) error
 
-	//VerifyIDStorageRequestSignature checks the ID storage request's signature
-	//Using the share robot public key
-	VerifyIDStorageRequestSignature(pubKey string, req *api.IDStorageRequest) error
-
-	//VerifyLockRequestSignature checks the lock request's signature using the share robot public key
-	VerifyLockRequestSignature(pubKey string, req *api.LockRequest) error
-
-	//VerifyKeychainLeadRequestSignature checks the keychain lead mining request's signature
-	//Using the share robot public key
-	VerifyKeychainLeadRequestSignature(pubKey string, req *api.KeychainLeadRequest) error
-
-	//VerifyIDLeadRequestSignature checks the ID lead mining request's signature
-	//Using the share robot public key
-	VerifyIDLeadRequestSignature(pubKey string, req *api.IDLeadRequest) error
+	//VerifyEmitterRevocationRequestSignature checks the signature of an emitter revocation request using an authority public key
+	VerifyEmitterRevocationRequestSignature(req *api.EmitterAuthorizationRequest, pubKey string) error
 
 	//VerifyValidationResponseSignature checks the signature of a validation response using the share robot public key
 	VerifyValidationResponseSignature(pubKey string, res *api.ValidationResponse) error
 
-	//VerifyHashSignature checks the signature of a hash using the shared robot public key
-	VerifyHashSignature(pubKey string, hash string, sig string) error
-
 	//VerifyStorageAckSignature checks the signature of a storage ack using the shared robot public key
 	VerifyStorageAckSignature(pubKey string, ack *api.StorageAck) error
 
@@ -102,8 +84,11 @@ type signatureVerifier interface {
 
 type signatureBuilder interface {
 
-	//SignHash create a signature of hash using the shared robot private key
-	SignHash(text string, pvKey string) (string, error)
+	//SignRequestEnvelope create a signature of a request envelope and the request it wraps using the node private key
+	SignRequestEnvelope(env *api.RequestEnvelope, req interface{}, pvKey string) error
+
+	//SignPayload create a signature of a canonical payload built by the API service using the shared robot private key
+	SignPayload(payload []byte, pvKey string) (string, error)
 
 	//SignIDResponse create a signature of the ID response using the shared robot private key
 	SignIDResponse(res *api.IDResponse, pvKey string) error
@@ -111,33 +96,6 @@ type signatureBuilder interface {
 	//SignKeychainResponse create a signature of the keychain response using the shared robot private key
 	SignKeychainResponse(res *api.KeychainResponse, pvKey string) error
 
-	//SignKeychainLeadRequest create a signature of the keychain lead mining's request
-	//Using the shared robot private key
-	SignKeychainLeadRequest(req *api.KeychainLeadRequest, pvKey string) error
-
-	//SignIDLeadRequest create a signature of the ID lead mining's request
-	//Using the shared robot private key
-	SignIDLeadRequest(req *api.IDLeadRequest, pvKey string) error
-
-	//SignKeychainValidationRequestSignature create a signature of the keychain validation's request
-	//Using the shared robot private key
-	SignKeychainValidationRequestSignature(req *api.KeychainValidationRequest, pvKey string) error
-
-	//SignIDValidationRequestSignature create a signature of the ID validation's request
-	//Using the shared robot private key
-	SignIDValidationRequestSignature(req *api.IDValidationRequest, pvKey string) error
-
-	//SignKeychainStorageRequestSignature create a signature of the keychain storage's request
-	//Using the shared robot private key
-	SignKeychainStorageRequestSignature(req *api.KeychainStorageRequest, pvKey string) error
-
-	//SignIDStorageRequestSignature create a signature of ID storage'request
-	//Using the shared robot private key
-	SignIDStorageRequestSignature(req *api.IDStorageRequest, pvKey string) error
-
-	//SignLockRequest create a signature of lock request using the shared robot private key
-	SignLockRequest(req *api.LockRequest, pvKey string) error
-
 	//SignValidationResponse create a signature of validation response using the shared robot private key
 	SignValidationResponse(res *api.ValidationResponse, pvKey string) error
 
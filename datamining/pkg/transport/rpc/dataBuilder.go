@@ -17,6 +17,7 @@ func (b dataBuilder) buildID(id *api.ID) account.ID {
 	prop := datamining.NewProposal(datamining.NewProposedKeyPair(
 		id.Proposal.SharedEmitterKeyPair.EncryptedPrivateKey,
 		id.Proposal.SharedEmitterKeyPair.PublicKey,
+		time.Unix(id.Proposal.SharedEmitterKeyPair.ActivationDate, 0),
 	))
 
 	return account.NewID(id.Hash,
@@ -34,6 +35,7 @@ func (b dataBuilder) buildKeychain(kc *api.Keychain) account.Keychain {
 	prop := datamining.NewProposal(datamining.NewProposedKeyPair(
 		kc.Proposal.SharedEmitterKeyPair.EncryptedPrivateKey,
 		kc.Proposal.SharedEmitterKeyPair.PublicKey,
+		time.Unix(kc.Proposal.SharedEmitterKeyPair.ActivationDate, 0),
 	))
 
 	return account.NewKeychain(
@@ -65,6 +67,7 @@ func (b dataBuilder) buildMasterValidation(mv *api.MasterValidation) mining.Mast
 		mv.LastTransactionMiners,
 		mv.ProofOfWorkKey,
 		b.buildValidation(mv.ProofOfWorkValidation),
+		mv.ValidationPool,
 	)
 }
 
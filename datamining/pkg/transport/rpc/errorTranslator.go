@@ -0,0 +1,86 @@
+package rpc
+
+import (
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+
+	emListing "github.com/uniris/uniris-core/datamining/pkg/emitter/listing"
+	"github.com/uniris/uniris-core/shared/pkg/errcode"
+)
+
+//NewErrorTranslator creates an interceptor sending the errors of the handlers as errors of the shared catalogue
+func NewErrorTranslator() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		res, err := handler(ctx, req)
+		if err != nil {
+			return nil, catalogueError(err)
+		}
+		return res, nil
+	}
+}
+
+//NewStreamErrorTranslator creates a stream interceptor sending the errors of the handlers as errors of the shared catalogue
+func NewStreamErrorTranslator() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := handler(srv, ss); err != nil {
+			return catalogueError(err)
+		}
+		return nil
+	}
+}
+
+//catalogueError identifies the code of an error, the errors already carrying a GRPC status keep it
+func catalogueError(err error) error {
+	if _, ok := err.(errcode.Error); ok {
+		return err
+	}
+
+	switch err {
+	case ErrInvalidSignature:
+		return errcode.New(errcode.InvalidSignature, err.Error())
+	case ErrExpiredRequest:
+		return errcode.New(errcode.ExpiredRequest, err.Error())
+	case ErrReplayedRequest:
+		return errcode.New(errcode.ReplayedRequest, err.Error())
+	case ErrKeychainNotOwned:
+		return errcode.New(errcode.KeychainNotOwned, err.Error())
+	case ErrInvalidEncryption:
+		return errcode.New(errcode.InvalidRequest, err.Error())
+	case emListing.ErrUnauthorizedEmitter, ErrUnauthorizedAuthority:
+		return errcode.New(errcode.Unauthorized, err.Error())
+	}
+
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	return errcode.New(errcode.Internal, err.Error())
+}
+
+//ChainInterceptors creates an interceptor calling the interceptors in order, the first one wrapping the next ones
+func ChainInterceptors(interceptors ...grpc.UnaryServerInterceptor) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		next := handler
+		for i := len(interceptors) - 1; i >= 0; i-- {
+			interceptor, inner := interceptors[i], next
+			next = func(ctx context.Context, req interface{}) (interface{}, error) {
+				return interceptor(ctx, req, info, inner)
+			}
+		}
+		return next(ctx, req)
+	}
+}
+
+//ChainStreamInterceptors creates a stream interceptor calling the interceptors in order, the first one wrapping the next ones
+func ChainStreamInterceptors(interceptors ...grpc.StreamServerInterceptor) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		next := handler
+		for i := len(interceptors) - 1; i >= 0; i-- {
+			interceptor, inner := interceptors[i], next
+			next = func(srv interface{}, ss grpc.ServerStream) error {
+				return interceptor(srv, ss, info, inner)
+			}
+		}
+		return next(srv, ss)
+	}
+}
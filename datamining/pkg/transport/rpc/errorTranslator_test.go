@@ -0,0 +1,68 @@
+package rpc
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/uniris/uniris-core/shared/pkg/errcode"
+)
+
+/*
+Scenario: Translate the errors of the internal handlers
+	Given errors returned by the internal handlers
+	When they are sent through the error translator
+	Then I get the errors of the catalogue with their codes
+*/
+func TestErrorTranslator(t *testing.T) {
+	translator := NewErrorTranslator()
+
+	translate := func(handlerErr error) error {
+		_, err := translator(context.TODO(), nil, &grpc.UnaryServerInfo{}, func(ctx context.Context, req interface{}) (interface{}, error) {
+			return nil, handlerErr
+		})
+		return err
+	}
+
+	assert.Equal(t, errcode.New(errcode.InvalidSignature, "Invalid signature"), translate(ErrInvalidSignature))
+	assert.Equal(t, errcode.New(errcode.ReplayedRequest, "Request already received"), translate(ErrReplayedRequest))
+	assert.Equal(t, errcode.New(errcode.AccountNotExist, "Account doesn't exist"), translate(errcode.New(errcode.AccountNotExist, "Account doesn't exist")))
+	assert.Equal(t, errcode.New(errcode.Internal, "unexpected"), translate(errors.New("unexpected")))
+
+	st, _ := status.FromError(translate(ErrKeychainNotOwned))
+	assert.Equal(t, codes.PermissionDenied, st.Code())
+	assert.Equal(t, errcode.KeychainNotOwned, errcode.FromGRPC(st.Err()).Code)
+}
+
+/*
+Scenario: Chain the interceptors of a server
+	Given an error translator and an interceptor rejecting the requests
+	When they are chained with the translator first
+	Then the rejection reaches the client as an error of the catalogue
+*/
+func TestChainInterceptors(t *testing.T) {
+	calls := make([]string, 0)
+	reject := func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		calls = append(calls, "reject")
+		return nil, ErrInvalidSignature
+	}
+	chain := ChainInterceptors(NewErrorTranslator(), reject)
+
+	_, err := chain(context.TODO(), nil, &grpc.UnaryServerInfo{}, func(ctx context.Context, req interface{}) (interface{}, error) {
+		calls = append(calls, "handler")
+		return nil, nil
+	})
+	assert.Equal(t, errcode.New(errcode.InvalidSignature, "Invalid signature"), err)
+	assert.Equal(t, []string{"reject"}, calls)
+
+	res, err := ChainInterceptors()(context.TODO(), "req", &grpc.UnaryServerInfo{}, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return req, nil
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "req", res)
+}
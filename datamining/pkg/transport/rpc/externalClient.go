@@ -3,6 +3,8 @@ package rpc
 import (
 	"errors"
 	"fmt"
+	"io"
+	"log"
 
 	"github.com/uniris/uniris-core/datamining/pkg/lock"
 
@@ -10,6 +12,7 @@ import (
 	"github.com/uniris/uniris-core/datamining/pkg/account"
 	"github.com/uniris/uniris-core/datamining/pkg/mining"
 	"github.com/uniris/uniris-core/datamining/pkg/system"
+	"github.com/uniris/uniris-core/shared/pkg/connpool"
 	"golang.org/x/net/context"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/status"
@@ -36,41 +39,58 @@ type ExternalClient interface {
 	//RequestLock requests a peer to unlock a given transaction
 	RequestUnlock(ip string, txLock lock.TransactionLock) error
 
-	//RequestValidations requests a peer to process validation/mining as a slave robot
-	RequestValidation(ip string, txType mining.TransactionType, txHash string, data interface{}) (mining.Validation, error)
+	//RequestValidations requests a peer to process validation/mining as a slave robot of the given validation pool
+	RequestValidation(ip string, txType mining.TransactionType, txHash string, data interface{}, vPool []string) (mining.Validation, error)
 
 	//RequestStorage requests a peer to store the transaction
 	RequestStorage(ip string, txType mining.TransactionType, data interface{}, end mining.Endorsement) error
 
 	//GetTransactionStatus requests a peer to retrieve transaction status
 	GetTransactionStatus(ip string, addr string, txHash string) (mining.TransactionStatus, error)
+
+	//WatchTransactionStatus requests a peer to stream the transaction status transitions
+	//
+	//The channel is closed when the transaction reaches a final status or when the context is done
+	WatchTransactionStatus(ctx context.Context, ip string, addr string, txHash string) (<-chan mining.TransactionStatus, error)
 }
 
+//watchTransactionStatusMethod is the GRPC method signed in the envelope of the status streams
+const watchTransactionStatusMethod = "/api.External/WatchTransactionStatus"
+
 type externalClient struct {
-	crypto Crypto
-	conf   system.UnirisConfig
-	data   dataBuilder
-	api    apiBuilder
+	crypto   Crypto
+	sec      TransportSecurity
+	conf     system.UnirisConfig
+	data     dataBuilder
+	api      apiBuilder
+	robot    robotKeys
+	envelope envelopeSigner
+	pool     connpool.Pool
 }
 
 //NewExternalClient create a GRPC implementation of the external client
-func NewExternalClient(crypto Crypto, conf system.UnirisConfig) ExternalClient {
+//
+//The connections to the peers are shared between the calls through the connection pool
+func NewExternalClient(crypto Crypto, sec TransportSecurity, pool connpool.Pool, conf system.UnirisConfig) ExternalClient {
 	return externalClient{
-		crypto: crypto,
-		conf:   conf,
-		data:   dataBuilder{},
-		api:    apiBuilder{},
+		crypto:   crypto,
+		sec:      sec,
+		pool:     pool,
+		conf:     conf,
+		data:     dataBuilder{},
+		api:      apiBuilder{},
+		robot:    robotKeys{conf.SharedKeys},
+		envelope: newEnvelopeSigner(crypto.signer, conf),
 	}
 }
 
 func (c externalClient) LeadKeychainMining(ip string, txHash string, encData string, validators []string) error {
 	serverAddr := fmt.Sprintf("%s:%d", ip, c.conf.Services.Datamining.ExternalPort)
-	conn, err := grpc.Dial(serverAddr, grpc.WithInsecure())
-	defer conn.Close()
-
+	conn, release, err := c.pool.Get(serverAddr, c.sec.Credentials(), c.sec.DialOption(ip), grpc.WithUnaryInterceptor(c.envelope.intercept))
 	if err != nil {
 		return err
 	}
+	defer release()
 
 	client := api.NewExternalClient(conn)
 
@@ -79,10 +99,6 @@ func (c externalClient) LeadKeychainMining(ip string, txHash string, encData str
 		TransactionHash:   txHash,
 		ValidatorPeerIPs:  validators,
 	}
-	if err := c.crypto.signer.SignKeychainLeadRequest(req, c.conf.SharedKeys.Robot.PrivateKey); err != nil {
-		return err
-	}
-
 	_, err = client.LeadKeychainMining(context.Background(), req)
 	if err != nil {
 		s, _ := status.FromError(err)
@@ -93,12 +109,11 @@ func (c externalClient) LeadKeychainMining(ip string, txHash string, encData str
 
 func (c externalClient) LeadIDMining(ip string, txHash string, encData string, validators []string) error {
 	serverAddr := fmt.Sprintf("%s:%d", ip, c.conf.Services.Datamining.ExternalPort)
-	conn, err := grpc.Dial(serverAddr, grpc.WithInsecure())
-	defer conn.Close()
-
+	conn, release, err := c.pool.Get(serverAddr, c.sec.Credentials(), c.sec.DialOption(ip), grpc.WithUnaryInterceptor(c.envelope.intercept))
 	if err != nil {
 		return err
 	}
+	defer release()
 
 	client := api.NewExternalClient(conn)
 
@@ -107,9 +122,6 @@ func (c externalClient) LeadIDMining(ip string, txHash string, encData string, v
 		TransactionHash:  txHash,
 		ValidatorPeerIPs: validators,
 	}
-	if err := c.crypto.signer.SignIDLeadRequest(req, c.conf.SharedKeys.Robot.PrivateKey); err != nil {
-		return err
-	}
 	_, err = client.LeadIDMining(context.Background(), req)
 	if err != nil {
 		s, _ := status.FromError(err)
@@ -120,30 +132,25 @@ func (c externalClient) LeadIDMining(ip string, txHash string, encData string, v
 
 func (c externalClient) RequestID(ip string, encIDHash string) (account.EndorsedID, error) {
 	serverAddr := fmt.Sprintf("%s:%d", ip, c.conf.Services.Datamining.ExternalPort)
-	conn, err := grpc.Dial(serverAddr, grpc.WithInsecure())
-	defer conn.Close()
-
+	conn, release, err := c.pool.Get(serverAddr, c.sec.Credentials(), c.sec.DialOption(ip), grpc.WithUnaryInterceptor(c.envelope.intercept))
 	if err != nil {
 		return nil, err
 	}
+	defer release()
 
 	client := api.NewExternalClient(conn)
 
-	sigReq, err := c.crypto.signer.SignHash(encIDHash, c.conf.SharedKeys.Robot.PrivateKey)
-	if err != nil {
-		return nil, err
-	}
-
 	res, err := client.GetID(context.Background(), &api.IDRequest{
 		EncryptedIDHash: encIDHash,
-		Signature:       sigReq,
 	})
 	if err != nil {
 		s, _ := status.FromError(err)
 		return nil, errors.New(s.Message())
 	}
 
-	if err := c.crypto.signer.VerifyIDResponseSignature(c.conf.SharedKeys.Robot.PublicKey, res); err != nil {
+	if err := c.robot.verify(func(pubKey string) error {
+		return c.crypto.signer.VerifyIDResponseSignature(pubKey, res)
+	}); err != nil {
 		return nil, err
 	}
 
@@ -154,35 +161,30 @@ func (c externalClient) RequestID(ip string, encIDHash string) (account.Endorsed
 
 func (c externalClient) RequestKeychain(ip string, encAddress string) (account.EndorsedKeychain, error) {
 	serverAddr := fmt.Sprintf("%s:%d", ip, c.conf.Services.Datamining.ExternalPort)
-	conn, err := grpc.Dial(serverAddr, grpc.WithInsecure())
-	defer conn.Close()
-
+	conn, release, err := c.pool.Get(serverAddr, c.sec.Credentials(), c.sec.DialOption(ip), grpc.WithUnaryInterceptor(c.envelope.intercept))
 	if err != nil {
 		return nil, err
 	}
+	defer release()
 
 	client := api.NewExternalClient(conn)
 
-	sigReq, err := c.crypto.signer.SignHash(encAddress, c.conf.SharedKeys.Robot.PrivateKey)
-	if err != nil {
-		return nil, err
-	}
-
 	res, err := client.GetKeychain(context.Background(), &api.KeychainRequest{
 		EncryptedAddress: encAddress,
-		Signature:        sigReq,
 	})
 	if err != nil {
 		s, _ := status.FromError(err)
 		return nil, errors.New(s.Message())
 	}
 
-	clearaddr, err := c.crypto.decrypter.DecryptHash(res.Data.EncryptedAddrByRobot, c.conf.SharedKeys.Robot.PrivateKey)
+	clearaddr, err := c.robot.decryptHash(c.crypto.decrypter, res.Data.EncryptedAddrByRobot)
 	if err != nil {
 		return nil, err
 	}
 
-	if err := c.crypto.signer.VerifyKeychainResponseSignature(c.conf.SharedKeys.Robot.PublicKey, res); err != nil {
+	if err := c.robot.verify(func(pubKey string) error {
+		return c.crypto.signer.VerifyKeychainResponseSignature(pubKey, res)
+	}); err != nil {
 		return nil, err
 	}
 
@@ -195,12 +197,11 @@ func (c externalClient) RequestKeychain(ip string, encAddress string) (account.E
 
 func (c externalClient) RequestLock(ip string, txLock lock.TransactionLock) error {
 	serverAddr := fmt.Sprintf("%s:%d", ip, c.conf.Services.Datamining.ExternalPort)
-	conn, err := grpc.Dial(serverAddr, grpc.WithInsecure())
-	defer conn.Close()
-
+	conn, release, err := c.pool.Get(serverAddr, c.sec.Credentials(), c.sec.DialOption(ip), grpc.WithUnaryInterceptor(c.envelope.intercept))
 	if err != nil {
 		return err
 	}
+	defer release()
 
 	client := api.NewExternalClient(conn)
 
@@ -209,17 +210,15 @@ func (c externalClient) RequestLock(ip string, txLock lock.TransactionLock) erro
 		TransactionHash: txLock.TxHash,
 		Address:         txLock.Address,
 	}
-	if err := c.crypto.signer.SignLockRequest(lockReq, c.conf.SharedKeys.Robot.PrivateKey); err != nil {
-		return err
-	}
-
 	res, err := client.LockTransaction(context.Background(), lockReq)
 	if err != nil {
 		s, _ := status.FromError(err)
 		return errors.New(s.Message())
 	}
 
-	if err := c.crypto.signer.VerifyLockAckSignature(c.conf.SharedKeys.Robot.PublicKey, res); err != nil {
+	if err := c.robot.verify(func(pubKey string) error {
+		return c.crypto.signer.VerifyLockAckSignature(pubKey, res)
+	}); err != nil {
 		return err
 	}
 
@@ -230,12 +229,11 @@ func (c externalClient) RequestLock(ip string, txLock lock.TransactionLock) erro
 
 func (c externalClient) RequestUnlock(ip string, txLock lock.TransactionLock) error {
 	serverAddr := fmt.Sprintf("%s:%d", ip, c.conf.Services.Datamining.ExternalPort)
-	conn, err := grpc.Dial(serverAddr, grpc.WithInsecure())
-	defer conn.Close()
-
+	conn, release, err := c.pool.Get(serverAddr, c.sec.Credentials(), c.sec.DialOption(ip), grpc.WithUnaryInterceptor(c.envelope.intercept))
 	if err != nil {
 		return err
 	}
+	defer release()
 
 	client := api.NewExternalClient(conn)
 
@@ -244,17 +242,15 @@ func (c externalClient) RequestUnlock(ip string, txLock lock.TransactionLock) er
 		TransactionHash: txLock.TxHash,
 		Address:         txLock.Address,
 	}
-	if err := c.crypto.signer.SignLockRequest(lockReq, c.conf.SharedKeys.Robot.PrivateKey); err != nil {
-		return err
-	}
-
 	res, err := client.UnlockTransaction(context.Background(), lockReq)
 	if err != nil {
 		s, _ := status.FromError(err)
 		return errors.New(s.Message())
 	}
 
-	if err := c.crypto.signer.VerifyLockAckSignature(c.conf.SharedKeys.Robot.PublicKey, res); err != nil {
+	if err := c.robot.verify(func(pubKey string) error {
+		return c.crypto.signer.VerifyLockAckSignature(pubKey, res)
+	}); err != nil {
 		return err
 	}
 
@@ -263,22 +259,21 @@ func (c externalClient) RequestUnlock(ip string, txLock lock.TransactionLock) er
 	return nil
 }
 
-func (c externalClient) RequestValidation(ip string, txType mining.TransactionType, txHash string, data interface{}) (mining.Validation, error) {
+func (c externalClient) RequestValidation(ip string, txType mining.TransactionType, txHash string, data interface{}, vPool []string) (mining.Validation, error) {
 	serverAddr := fmt.Sprintf("%s:%d", ip, c.conf.Services.Datamining.ExternalPort)
-	conn, err := grpc.Dial(serverAddr, grpc.WithInsecure())
-	defer conn.Close()
-
+	conn, release, err := c.pool.Get(serverAddr, c.sec.Credentials(), c.sec.DialOption(ip), grpc.WithUnaryInterceptor(c.envelope.intercept))
 	if err != nil {
 		return nil, err
 	}
+	defer release()
 
 	client := api.NewExternalClient(conn)
 
 	switch txType {
 	case mining.KeychainTransaction:
-		return c.validateKeychain(client, txHash, c.api.buildKeychain(data.(account.Keychain)))
+		return c.validateKeychain(client, txHash, c.api.buildKeychain(data.(account.Keychain)), vPool)
 	case mining.IDTransaction:
-		return c.validateID(client, txHash, c.api.buildID(data.(account.ID)))
+		return c.validateID(client, txHash, c.api.buildID(data.(account.ID)), vPool)
 	}
 
 	return nil, errors.New("Unsupported transaction type")
@@ -286,12 +281,11 @@ func (c externalClient) RequestValidation(ip string, txType mining.TransactionTy
 
 func (c externalClient) RequestStorage(ip string, txType mining.TransactionType, data interface{}, end mining.Endorsement) error {
 	serverAddr := fmt.Sprintf("%s:%d", ip, c.conf.Services.Datamining.ExternalPort)
-	conn, err := grpc.Dial(serverAddr, grpc.WithInsecure())
-	defer conn.Close()
-
+	conn, release, err := c.pool.Get(serverAddr, c.sec.Credentials(), c.sec.DialOption(ip), grpc.WithUnaryInterceptor(c.envelope.intercept))
 	if err != nil {
 		return err
 	}
+	defer release()
 
 	client := api.NewExternalClient(conn)
 
@@ -307,35 +301,32 @@ func (c externalClient) RequestStorage(ip string, txType mining.TransactionType,
 	return nil
 }
 
-func (c externalClient) validateKeychain(client api.ExternalClient, txHash string, kc *api.Keychain) (mining.Validation, error) {
+func (c externalClient) validateKeychain(client api.ExternalClient, txHash string, kc *api.Keychain, vPool []string) (mining.Validation, error) {
 	req := &api.KeychainValidationRequest{
 		Data:            kc,
 		TransactionHash: txHash,
+		ValidationPool:  vPool,
 	}
-	if err := c.crypto.signer.SignKeychainValidationRequestSignature(req, c.conf.SharedKeys.Robot.PrivateKey); err != nil {
-		return nil, err
-	}
-
 	res, err := client.ValidateKeychain(context.Background(), req)
 	if err != nil {
 		s, _ := status.FromError(err)
 		return nil, errors.New(s.Message())
 	}
 
-	if err := c.crypto.signer.VerifyValidationResponseSignature(c.conf.SharedKeys.Robot.PublicKey, res); err != nil {
+	if err := c.robot.verify(func(pubKey string) error {
+		return c.crypto.signer.VerifyValidationResponseSignature(pubKey, res)
+	}); err != nil {
 		return nil, err
 	}
 
 	return c.data.buildValidation(res.Validation), nil
 }
 
-func (c externalClient) validateID(client api.ExternalClient, txHash string, id *api.ID) (mining.Validation, error) {
+func (c externalClient) validateID(client api.ExternalClient, txHash string, id *api.ID, vPool []string) (mining.Validation, error) {
 	req := &api.IDValidationRequest{
 		Data:            id,
 		TransactionHash: txHash,
-	}
-	if err := c.crypto.signer.SignIDValidationRequestSignature(req, c.conf.SharedKeys.Robot.PrivateKey); err != nil {
-		return nil, err
+		ValidationPool:  vPool,
 	}
 	res, err := client.ValidateID(context.Background(), req)
 	if err != nil {
@@ -343,7 +334,9 @@ func (c externalClient) validateID(client api.ExternalClient, txHash string, id
 		return nil, errors.New(s.Message())
 	}
 
-	if err := c.crypto.signer.VerifyValidationResponseSignature(c.conf.SharedKeys.Robot.PublicKey, res); err != nil {
+	if err := c.robot.verify(func(pubKey string) error {
+		return c.crypto.signer.VerifyValidationResponseSignature(pubKey, res)
+	}); err != nil {
 		return nil, err
 	}
 
@@ -355,17 +348,15 @@ func (c externalClient) storeKeychain(client api.ExternalClient, kc *api.Keychai
 		Data:        kc,
 		Endorsement: end,
 	}
-	if err := c.crypto.signer.SignKeychainStorageRequestSignature(req, c.conf.SharedKeys.Robot.PrivateKey); err != nil {
-		return err
-	}
-
 	res, err := client.StoreKeychain(context.Background(), req)
 	if err != nil {
 		s, _ := status.FromError(err)
 		return errors.New(s.Message())
 	}
 
-	if err := c.crypto.signer.VerifyStorageAckSignature(c.conf.SharedKeys.Robot.PublicKey, res); err != nil {
+	if err := c.robot.verify(func(pubKey string) error {
+		return c.crypto.signer.VerifyStorageAckSignature(pubKey, res)
+	}); err != nil {
 		return err
 	}
 
@@ -379,17 +370,15 @@ func (c externalClient) storeID(client api.ExternalClient, id *api.ID, end *api.
 		Data:        id,
 		Endorsement: end,
 	}
-	if err := c.crypto.signer.SignIDStorageRequestSignature(req, c.conf.SharedKeys.Robot.PrivateKey); err != nil {
-		return err
-	}
-
 	res, err := client.StoreID(context.Background(), req)
 	if err != nil {
 		s, _ := status.FromError(err)
 		return errors.New(s.Message())
 	}
 
-	if err := c.crypto.signer.VerifyStorageAckSignature(c.conf.SharedKeys.Robot.PublicKey, res); err != nil {
+	if err := c.robot.verify(func(pubKey string) error {
+		return c.crypto.signer.VerifyStorageAckSignature(pubKey, res)
+	}); err != nil {
 		return err
 	}
 
@@ -406,12 +395,11 @@ func (c externalClient) GetTransactionStatus(ip string, addr string, txHash stri
 	}
 
 	serverAddr := fmt.Sprintf("%s:%d", ip, c.conf.Services.Datamining.ExternalPort)
-	conn, err := grpc.Dial(serverAddr, grpc.WithInsecure())
-	defer conn.Close()
-
+	conn, release, err := c.pool.Get(serverAddr, c.sec.Credentials(), c.sec.DialOption(ip), grpc.WithUnaryInterceptor(c.envelope.intercept))
 	if err != nil {
 		return mining.TransactionFailure, err
 	}
+	defer release()
 
 	client := api.NewExternalClient(conn)
 
@@ -423,3 +411,54 @@ func (c externalClient) GetTransactionStatus(ip string, addr string, txHash stri
 
 	return mining.TransactionStatus(res.Status), nil
 }
+
+func (c externalClient) WatchTransactionStatus(ctx context.Context, ip string, addr string, txHash string) (<-chan mining.TransactionStatus, error) {
+
+	req := &api.TransactionStatusRequest{
+		Address: addr,
+		Hash:    txHash,
+	}
+
+	ctx, err := c.envelope.sign(ctx, watchTransactionStatusMethod, req)
+	if err != nil {
+		return nil, err
+	}
+
+	serverAddr := fmt.Sprintf("%s:%d", ip, c.conf.Services.Datamining.ExternalPort)
+	conn, release, err := c.pool.GetStream(serverAddr, c.sec.Credentials(), c.sec.DialOption(ip), grpc.WithUnaryInterceptor(c.envelope.intercept))
+	if err != nil {
+		return nil, err
+	}
+
+	client := api.NewExternalClient(conn)
+
+	stream, err := client.WatchTransactionStatus(ctx, req)
+	if err != nil {
+		release()
+		s, _ := status.FromError(err)
+		return nil, errors.New(s.Message())
+	}
+
+	statuses := make(chan mining.TransactionStatus)
+	go func() {
+		defer release()
+		defer close(statuses)
+
+		for {
+			res, err := stream.Recv()
+			if err != nil {
+				if err != io.EOF && ctx.Err() == nil {
+					log.Printf("Transaction status stream error: %s", err.Error())
+				}
+				return
+			}
+			select {
+			case statuses <- mining.TransactionStatus(res.Status):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return statuses, nil
+}
@@ -1,6 +1,7 @@
 package rpc
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"net"
@@ -11,11 +12,14 @@ import (
 	"github.com/uniris/uniris-core/datamining/pkg/lock"
 	"github.com/uniris/uniris-core/datamining/pkg/mining"
 	mocktransport "github.com/uniris/uniris-core/datamining/pkg/transport/mock"
+	"github.com/uniris/uniris-core/shared/pkg/connpool"
 
 	"github.com/stretchr/testify/assert"
 
 	"github.com/uniris/uniris-core/datamining/pkg/account"
 	mockcrypto "github.com/uniris/uniris-core/datamining/pkg/crypto/mock"
+	emAdding "github.com/uniris/uniris-core/datamining/pkg/emitter/adding"
+	emListing "github.com/uniris/uniris-core/datamining/pkg/emitter/listing"
 	"github.com/uniris/uniris-core/datamining/pkg/system"
 
 	api "github.com/uniris/uniris-core/datamining/api/protobuf-spec"
@@ -23,6 +27,7 @@ import (
 	accountListing "github.com/uniris/uniris-core/datamining/pkg/account/listing"
 	accountMining "github.com/uniris/uniris-core/datamining/pkg/account/mining"
 	mockstorage "github.com/uniris/uniris-core/datamining/pkg/storage/mock"
+	"github.com/uniris/uniris-core/datamining/pkg/transport/mem"
 	"google.golang.org/grpc"
 )
 
@@ -72,19 +77,19 @@ func TestRequestIDClient(t *testing.T) {
 	time.Sleep(1 * time.Second)
 
 	prop := datamining.NewProposal(
-		datamining.NewProposedKeyPair("enc pv key", "pub key"),
+		datamining.NewProposedKeyPair("enc pv key", "pub key", time.Time{}),
 	)
 
 	db.StoreID(
 		account.NewEndorsedID(
 			account.NewID("hash", "enc addr", "enc addr", "enc aes key", "id pub", prop, "id sig", "em sig"),
 			mining.NewEndorsement("", "hash",
-				mining.NewMasterValidation([]string{"hash"}, "key", mining.NewValidation(mining.ValidationOK, time.Now(), "pub", "sig")),
+				mining.NewMasterValidation([]string{"hash"}, "key", mining.NewValidation(mining.ValidationOK, time.Now(), "pub", "sig"), []string{"validator key"}),
 				[]mining.Validation{mining.NewValidation(mining.ValidationOK, time.Now(), "pub", "sig")}),
 		),
 	)
 
-	cli := NewExternalClient(crypto, conf)
+	cli := NewExternalClient(crypto, insecureTransport{}, newTestPool(t), conf)
 	bio, err := cli.RequestID("127.0.0.1", "hash")
 	assert.Nil(t, err)
 	assert.NotNil(t, bio)
@@ -138,7 +143,7 @@ func TestRequestKeychainClient(t *testing.T) {
 	time.Sleep(1 * time.Second)
 
 	prop := datamining.NewProposal(
-		datamining.NewProposedKeyPair("enc pv key", "pub key"),
+		datamining.NewProposedKeyPair("enc pv key", "pub key", time.Time{}),
 	)
 
 	db.StoreKeychain(
@@ -146,12 +151,12 @@ func TestRequestKeychainClient(t *testing.T) {
 			"hash",
 			account.NewKeychain("enc addr", "enc wallet", "id pub", prop, "id sig", "em pub"),
 			mining.NewEndorsement("", "hash",
-				mining.NewMasterValidation([]string{"hash"}, "key", mining.NewValidation(mining.ValidationOK, time.Now(), "pub", "sig")),
+				mining.NewMasterValidation([]string{"hash"}, "key", mining.NewValidation(mining.ValidationOK, time.Now(), "pub", "sig"), []string{"validator key"}),
 				[]mining.Validation{mining.NewValidation(mining.ValidationOK, time.Now(), "pub", "sig")}),
 		),
 	)
 
-	cli := NewExternalClient(crypto, conf)
+	cli := NewExternalClient(crypto, insecureTransport{}, newTestPool(t), conf)
 	kc, err := cli.RequestKeychain("127.0.0.1", "hash")
 	assert.Nil(t, err)
 	assert.NotNil(t, kc)
@@ -167,7 +172,7 @@ Scenario: Call RequestLock GRPC endpoint
 func TestRequestLockClient(t *testing.T) {
 
 	db := mockstorage.NewDatabase()
-	locker := lock.NewService(db)
+	locker := lock.NewService(db, mem.NewNotifier())
 
 	crypto := Crypto{
 		decrypter: mockcrypto.NewDecrypter(),
@@ -205,7 +210,7 @@ func TestRequestLockClient(t *testing.T) {
 
 	time.Sleep(1 * time.Second)
 
-	cli := NewExternalClient(crypto, conf)
+	cli := NewExternalClient(crypto, insecureTransport{}, newTestPool(t), conf)
 	err := cli.RequestLock("127.0.0.1", lock.TransactionLock{
 		Address:        "address",
 		MasterRobotKey: "robotkey",
@@ -229,7 +234,7 @@ Scenario: Call RequestUnLock GRPC endpoint
 func TestRequestUnLockClient(t *testing.T) {
 
 	db := mockstorage.NewDatabase()
-	locker := lock.NewService(db)
+	locker := lock.NewService(db, mem.NewNotifier())
 
 	crypto := Crypto{
 		decrypter: mockcrypto.NewDecrypter(),
@@ -267,7 +272,7 @@ func TestRequestUnLockClient(t *testing.T) {
 
 	time.Sleep(1 * time.Second)
 
-	cli := NewExternalClient(crypto, conf)
+	cli := NewExternalClient(crypto, insecureTransport{}, newTestPool(t), conf)
 	err := cli.RequestLock("127.0.0.1", lock.TransactionLock{
 		Address:        "address",
 		MasterRobotKey: "robotkey",
@@ -320,7 +325,7 @@ func TestRequestKeychainValidationClient(t *testing.T) {
 	}
 
 	aiClient := mocktransport.NewAIClient()
-	miner := mining.NewService(aiClient, nil, nil, mockcrypto.NewSigner(), nil, conf, txMiners)
+	miner := mining.NewService(aiClient, nil, nil, mockcrypto.NewSigner(), nil, conf, txMiners, mining.NewStatusWatcher(), mem.NewNotifier())
 
 	grpcServer := grpc.NewServer()
 	defer grpcServer.Stop()
@@ -344,13 +349,13 @@ func TestRequestKeychainValidationClient(t *testing.T) {
 	time.Sleep(1 * time.Second)
 
 	prop := datamining.NewProposal(
-		datamining.NewProposedKeyPair("enc pv key", "pub key"),
+		datamining.NewProposedKeyPair("enc pv key", "pub key", time.Time{}),
 	)
 
 	keychain := account.NewKeychain("enc addr", "enc wallet", "id pub", prop, "id sig", "em sig")
 
-	cli := NewExternalClient(crypto, conf)
-	valid, err := cli.RequestValidation("127.0.0.1", mining.KeychainTransaction, "hash", keychain)
+	cli := NewExternalClient(crypto, insecureTransport{}, newTestPool(t), conf)
+	valid, err := cli.RequestValidation("127.0.0.1", mining.KeychainTransaction, "hash", keychain, []string{conf.PublicKey})
 	assert.Nil(t, err)
 	assert.NotNil(t, valid)
 }
@@ -382,7 +387,7 @@ func TestRequestIDValidationClient(t *testing.T) {
 	}
 
 	aiClient := mocktransport.NewAIClient()
-	miner := mining.NewService(aiClient, nil, nil, mockcrypto.NewSigner(), nil, conf, txMiners)
+	miner := mining.NewService(aiClient, nil, nil, mockcrypto.NewSigner(), nil, conf, txMiners, mining.NewStatusWatcher(), mem.NewNotifier())
 
 	grpcServer := grpc.NewServer()
 	defer grpcServer.Stop()
@@ -406,13 +411,13 @@ func TestRequestIDValidationClient(t *testing.T) {
 	time.Sleep(1 * time.Second)
 
 	prop := datamining.NewProposal(
-		datamining.NewProposedKeyPair("enc pv key", "pub key"),
+		datamining.NewProposedKeyPair("enc pv key", "pub key", time.Time{}),
 	)
 
 	id := account.NewID("hash", "enc addr", "enc addr", "enc aes key", "id pub", prop, "id sig", "em sig")
 
-	cli := NewExternalClient(crypto, conf)
-	valid, err := cli.RequestValidation("127.0.0.1", mining.IDTransaction, "hash", id)
+	cli := NewExternalClient(crypto, insecureTransport{}, newTestPool(t), conf)
+	valid, err := cli.RequestValidation("127.0.0.1", mining.IDTransaction, "hash", id, []string{conf.PublicKey})
 	assert.Nil(t, err)
 	assert.NotNil(t, valid)
 }
@@ -427,7 +432,7 @@ func TestRequestKeychainStorageClient(t *testing.T) {
 	db := mockstorage.NewDatabase()
 	accLister := accountListing.NewService(db)
 	aiClient := mocktransport.NewAIClient()
-	accAdder := accountAdding.NewService(aiClient, db, accLister, mockcrypto.NewSigner(), mockcrypto.NewHasher())
+	accAdder := accountAdding.NewService(aiClient, db, accLister, emAdding.NewService(db, emListing.NewService(db), system.UnirisConfig{}), mockcrypto.NewSigner(), mockcrypto.NewHasher(), mem.NewNotifier())
 
 	crypto := Crypto{
 		decrypter: mockcrypto.NewDecrypter(),
@@ -452,7 +457,8 @@ func TestRequestKeychainStorageClient(t *testing.T) {
 		assert.Nil(t, err)
 
 		services := Services{
-			accAdd: accAdder,
+			accAdd:   accAdder,
+			statuses: mining.NewStatusWatcher(),
 		}
 
 		handler := NewExternalServerHandler(services, crypto, conf)
@@ -466,16 +472,16 @@ func TestRequestKeychainStorageClient(t *testing.T) {
 	time.Sleep(1 * time.Second)
 
 	prop := datamining.NewProposal(
-		datamining.NewProposedKeyPair("enc pv key", "pub key"),
+		datamining.NewProposedKeyPair("enc pv key", "pub key", time.Time{}),
 	)
 
 	keychain := account.NewKeychain("enc addr", "enc wallet", "id pub", prop, "id sig", "em sig")
 	end := mining.NewEndorsement("", "hash",
-		mining.NewMasterValidation([]string{""}, "robotkey", mining.NewValidation(mining.ValidationOK, time.Now(), "robotkey", "sig")),
+		mining.NewMasterValidation([]string{""}, "robotkey", mining.NewValidation(mining.ValidationOK, time.Now(), "robotkey", "sig"), []string{"validator key"}),
 		[]mining.Validation{mining.NewValidation(mining.ValidationOK, time.Now(), "pub", "sig")},
 	)
 
-	cli := NewExternalClient(crypto, conf)
+	cli := NewExternalClient(crypto, insecureTransport{}, newTestPool(t), conf)
 	err := cli.RequestStorage("127.0.0.1", mining.KeychainTransaction, keychain, end)
 	assert.Nil(t, err)
 
@@ -493,7 +499,7 @@ func TestRequestIDStorageClient(t *testing.T) {
 	db := mockstorage.NewDatabase()
 	accLister := accountListing.NewService(db)
 	aiClient := mocktransport.NewAIClient()
-	accAdder := accountAdding.NewService(aiClient, db, accLister, mockcrypto.NewSigner(), mockcrypto.NewHasher())
+	accAdder := accountAdding.NewService(aiClient, db, accLister, emAdding.NewService(db, emListing.NewService(db), system.UnirisConfig{}), mockcrypto.NewSigner(), mockcrypto.NewHasher(), mem.NewNotifier())
 
 	crypto := Crypto{
 		decrypter: mockcrypto.NewDecrypter(),
@@ -518,7 +524,8 @@ func TestRequestIDStorageClient(t *testing.T) {
 		assert.Nil(t, err)
 
 		services := Services{
-			accAdd: accAdder,
+			accAdd:   accAdder,
+			statuses: mining.NewStatusWatcher(),
 		}
 
 		handler := NewExternalServerHandler(services, crypto, conf)
@@ -532,19 +539,82 @@ func TestRequestIDStorageClient(t *testing.T) {
 	time.Sleep(1 * time.Second)
 
 	prop := datamining.NewProposal(
-		datamining.NewProposedKeyPair("enc pv key", "pub key"),
+		datamining.NewProposedKeyPair("enc pv key", "pub key", time.Time{}),
 	)
 
 	id := account.NewID("hash", "enc addr", "enc addr", "enc aes key", "id pub", prop, "id sig", "em sig")
 	end := mining.NewEndorsement("", "hash",
-		mining.NewMasterValidation([]string{""}, "robotkey", mining.NewValidation(mining.ValidationOK, time.Now(), "robotkey", "sig")),
+		mining.NewMasterValidation([]string{""}, "robotkey", mining.NewValidation(mining.ValidationOK, time.Now(), "robotkey", "sig"), []string{"validator key"}),
 		[]mining.Validation{mining.NewValidation(mining.ValidationOK, time.Now(), "pub", "sig")},
 	)
 
-	cli := NewExternalClient(crypto, conf)
+	cli := NewExternalClient(crypto, insecureTransport{}, newTestPool(t), conf)
 	err := cli.RequestStorage("127.0.0.1", mining.IDTransaction, id, end)
 	assert.Nil(t, err)
 
 	kc, _ := db.FindID("hash")
 	assert.NotNil(t, kc)
 }
+
+/*
+Scenario: Call WatchTransactionStatus GRPC endpoint
+	Given a transaction not stored yet
+	When the transaction becomes pending and then succeeds
+	Then the client receives each status transition until the final one
+*/
+func TestWatchTransactionStatusClient(t *testing.T) {
+
+	db := mockstorage.NewDatabase()
+	statuses := mining.NewStatusWatcher()
+
+	crypto := Crypto{
+		decrypter: mockcrypto.NewDecrypter(),
+		signer:    mockcrypto.NewSigner(),
+	}
+
+	port := 2010
+	conf := system.UnirisConfig{
+		PublicKey:  "node pub",
+		PrivateKey: "node pv",
+		Services: system.ServicesConfiguration{
+			Datamining: system.DataMiningConfiguration{
+				ExternalPort: port,
+			},
+		},
+	}
+
+	grpcServer := grpc.NewServer(grpc.StreamInterceptor(NewStreamRequestAuthenticator(crypto, mocktransport.NewPeerKeyResolver("node pub"))))
+	defer grpcServer.Stop()
+
+	lis, err := net.Listen("tcp", fmt.Sprintf("127.0.0.1:%d", port))
+	assert.Nil(t, err)
+
+	services := Services{
+		accLister: accountListing.NewService(db),
+		statuses:  statuses,
+	}
+	api.RegisterExternalServer(grpcServer, NewExternalServerHandler(services, crypto, conf))
+	go grpcServer.Serve(lis)
+
+	cli := NewExternalClient(crypto, insecureTransport{}, newTestPool(t), conf)
+	transitions, err := cli.WatchTransactionStatus(context.Background(), "127.0.0.1", "addr", "txHash")
+	assert.Nil(t, err)
+
+	assert.Equal(t, mining.TransactionUnknown, <-transitions)
+
+	statuses.Notify("txHash", mining.TransactionPending)
+	assert.Equal(t, mining.TransactionPending, <-transitions)
+
+	statuses.Notify("txHash", mining.TransactionSuccess)
+	assert.Equal(t, mining.TransactionSuccess, <-transitions)
+
+	_, open := <-transitions
+	assert.False(t, open)
+}
+
+//newTestPool creates a connection pool closed at the end of the test
+func newTestPool(t *testing.T) connpool.Pool {
+	pool := connpool.NewPool(connpool.DefaultMaxCalls, connpool.DefaultIdleTimeout)
+	t.Cleanup(pool.Close)
+	return pool
+}
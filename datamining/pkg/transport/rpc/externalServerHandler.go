@@ -2,7 +2,6 @@ package rpc
 
 import (
 	"context"
-	"errors"
 	"net"
 
 	"github.com/golang/protobuf/ptypes/empty"
@@ -13,6 +12,7 @@ import (
 	"github.com/uniris/uniris-core/datamining/pkg/lock"
 
 	"github.com/uniris/uniris-core/datamining/pkg/system"
+	"github.com/uniris/uniris-core/shared/pkg/errcode"
 
 	api "github.com/uniris/uniris-core/datamining/api/protobuf-spec"
 
@@ -26,15 +26,17 @@ type Services struct {
 	mining    mining.Service
 	accAdd    accAdding.Service
 	accLister accListing.Service
+	statuses  mining.StatusWatcher
 }
 
 //NewExternalServices creates a new container of required services
-func NewExternalServices(lock lock.Service, mine mining.Service, accountAdder accAdding.Service, accountLister accListing.Service) Services {
+func NewExternalServices(lock lock.Service, mine mining.Service, accountAdder accAdding.Service, accountLister accListing.Service, statuses mining.StatusWatcher) Services {
 	return Services{
 		lock:      lock,
 		mining:    mine,
 		accAdd:    accountAdder,
 		accLister: accountLister,
+		statuses:  statuses,
 	}
 }
 
@@ -44,6 +46,7 @@ type externalSrvHandler struct {
 	conf     system.UnirisConfig
 	api      apiBuilder
 	data     dataBuilder
+	robot    robotKeys
 }
 
 //NewExternalServerHandler creates a new External GRPC handler
@@ -54,15 +57,12 @@ func NewExternalServerHandler(srv Services, crypto Crypto, conf system.UnirisCon
 		conf:     conf,
 		api:      apiBuilder{},
 		data:     dataBuilder{},
+		robot:    robotKeys{conf.SharedKeys},
 	}
 }
 
 func (h externalSrvHandler) GetID(ctxt context.Context, req *api.IDRequest) (*api.IDResponse, error) {
-	if err := h.crypto.signer.VerifyHashSignature(h.conf.SharedKeys.Robot.PublicKey, req.EncryptedIDHash, req.Signature); err != nil {
-		return nil, ErrInvalidSignature
-	}
-
-	idHash, err := h.crypto.decrypter.DecryptHash(req.EncryptedIDHash, h.conf.SharedKeys.Robot.PrivateKey)
+	idHash, err := h.robot.decryptHash(h.crypto.decrypter, req.EncryptedIDHash)
 	if err != nil {
 		return nil, ErrInvalidEncryption
 	}
@@ -73,7 +73,7 @@ func (h externalSrvHandler) GetID(ctxt context.Context, req *api.IDRequest) (*ap
 	}
 
 	if id == nil {
-		return nil, errors.New(h.conf.Services.Datamining.Errors.AccountNotExist)
+		return nil, errcode.New(errcode.AccountNotExist, h.conf.Services.Datamining.Errors.AccountNotExist)
 	}
 
 	res := &api.IDResponse{
@@ -81,7 +81,7 @@ func (h externalSrvHandler) GetID(ctxt context.Context, req *api.IDRequest) (*ap
 		Endorsement: h.api.buildEndorsement(id.Endorsement()),
 	}
 
-	if err := h.crypto.signer.SignIDResponse(res, h.conf.SharedKeys.Robot.PrivateKey); err != nil {
+	if err := h.crypto.signer.SignIDResponse(res, h.robot.privateKey()); err != nil {
 		return nil, err
 	}
 
@@ -89,11 +89,7 @@ func (h externalSrvHandler) GetID(ctxt context.Context, req *api.IDRequest) (*ap
 }
 
 func (h externalSrvHandler) GetKeychain(ctxt context.Context, req *api.KeychainRequest) (*api.KeychainResponse, error) {
-	if err := h.crypto.signer.VerifyHashSignature(h.conf.SharedKeys.Robot.PublicKey, req.EncryptedAddress, req.Signature); err != nil {
-		return nil, ErrInvalidSignature
-	}
-
-	clearAddress, err := h.crypto.decrypter.DecryptHash(req.EncryptedAddress, h.conf.SharedKeys.Robot.PrivateKey)
+	clearAddress, err := h.robot.decryptHash(h.crypto.decrypter, req.EncryptedAddress)
 	if err != nil {
 		return nil, ErrInvalidEncryption
 	}
@@ -104,7 +100,7 @@ func (h externalSrvHandler) GetKeychain(ctxt context.Context, req *api.KeychainR
 	}
 
 	if keychain == nil {
-		return nil, errors.New(h.conf.Services.Datamining.Errors.AccountNotExist)
+		return nil, errcode.New(errcode.AccountNotExist, h.conf.Services.Datamining.Errors.AccountNotExist)
 	}
 
 	res := &api.KeychainResponse{
@@ -112,7 +108,7 @@ func (h externalSrvHandler) GetKeychain(ctxt context.Context, req *api.KeychainR
 		Endorsement: h.api.buildEndorsement(keychain.Endorsement()),
 	}
 
-	if err := h.crypto.signer.SignKeychainResponse(res, h.conf.SharedKeys.Robot.PrivateKey); err != nil {
+	if err := h.crypto.signer.SignKeychainResponse(res, h.robot.privateKey()); err != nil {
 		return nil, err
 	}
 
@@ -120,16 +116,12 @@ func (h externalSrvHandler) GetKeychain(ctxt context.Context, req *api.KeychainR
 }
 
 func (h externalSrvHandler) LeadKeychainMining(ctx context.Context, req *api.KeychainLeadRequest) (*empty.Empty, error) {
-	if err := h.crypto.signer.VerifyKeychainLeadRequestSignature(h.conf.SharedKeys.Robot.PublicKey, req); err != nil {
-		return nil, ErrInvalidSignature
-	}
-
-	keychain, err := h.crypto.decrypter.DecryptKeychain(req.EncryptedKeychain, h.conf.SharedKeys.Robot.PrivateKey)
+	keychain, err := h.robot.decryptKeychain(h.crypto.decrypter, req.EncryptedKeychain)
 	if err != nil {
 		return nil, ErrInvalidEncryption
 	}
 
-	clearaddr, err := h.crypto.decrypter.DecryptHash(keychain.EncryptedAddrByRobot(), h.conf.SharedKeys.Robot.PrivateKey)
+	clearaddr, err := h.robot.decryptHash(h.crypto.decrypter, keychain.EncryptedAddrByRobot())
 	if err != nil {
 		return nil, ErrInvalidEncryption
 	}
@@ -147,16 +139,12 @@ func (h externalSrvHandler) LeadKeychainMining(ctx context.Context, req *api.Key
 }
 
 func (h externalSrvHandler) LeadIDMining(ctx context.Context, req *api.IDLeadRequest) (*empty.Empty, error) {
-	if err := h.crypto.signer.VerifyIDLeadRequestSignature(h.conf.SharedKeys.Robot.PublicKey, req); err != nil {
-		return nil, ErrInvalidSignature
-	}
-
-	id, err := h.crypto.decrypter.DecryptID(req.EncryptedID, h.conf.SharedKeys.Robot.PrivateKey)
+	id, err := h.robot.decryptID(h.crypto.decrypter, req.EncryptedID)
 	if err != nil {
 		return nil, ErrInvalidEncryption
 	}
 
-	clearaddr, err := h.crypto.decrypter.DecryptHash(id.EncryptedAddrByRobot(), h.conf.SharedKeys.Robot.PrivateKey)
+	clearaddr, err := h.robot.decryptHash(h.crypto.decrypter, id.EncryptedAddrByRobot())
 	if err != nil {
 		return nil, ErrInvalidEncryption
 	}
@@ -174,10 +162,6 @@ func (h externalSrvHandler) LeadIDMining(ctx context.Context, req *api.IDLeadReq
 }
 
 func (h externalSrvHandler) LockTransaction(ctx context.Context, req *api.LockRequest) (*api.LockAck, error) {
-	if err := h.crypto.signer.VerifyLockRequestSignature(h.conf.SharedKeys.Robot.PublicKey, req); err != nil {
-		return nil, err
-	}
-
 	lockTx := lock.TransactionLock{
 		TxHash:         req.TransactionHash,
 		MasterRobotKey: req.MasterRobotKey,
@@ -196,18 +180,13 @@ func (h externalSrvHandler) LockTransaction(ctx context.Context, req *api.LockRe
 	ack := &api.LockAck{
 		LockHash: lockHash,
 	}
-	if err := h.crypto.signer.SignLockAck(ack, h.conf.SharedKeys.Robot.PrivateKey); err != nil {
+	if err := h.crypto.signer.SignLockAck(ack, h.robot.privateKey()); err != nil {
 		return nil, err
 	}
 	return ack, nil
 }
 
 func (h externalSrvHandler) UnlockTransaction(ctx context.Context, req *api.LockRequest) (*api.LockAck, error) {
-
-	if err := h.crypto.signer.VerifyLockRequestSignature(h.conf.SharedKeys.Robot.PublicKey, req); err != nil {
-		return nil, err
-	}
-
 	lockTx := lock.TransactionLock{
 		TxHash:         req.TransactionHash,
 		MasterRobotKey: req.MasterRobotKey,
@@ -226,18 +205,14 @@ func (h externalSrvHandler) UnlockTransaction(ctx context.Context, req *api.Lock
 	ack := &api.LockAck{
 		LockHash: lockHash,
 	}
-	if err := h.crypto.signer.SignLockAck(ack, h.conf.SharedKeys.Robot.PrivateKey); err != nil {
+	if err := h.crypto.signer.SignLockAck(ack, h.robot.privateKey()); err != nil {
 		return nil, err
 	}
 	return ack, nil
 }
 
 func (h externalSrvHandler) ValidateKeychain(ctx context.Context, req *api.KeychainValidationRequest) (*api.ValidationResponse, error) {
-	if err := h.crypto.signer.VerifyKeychainValidationRequestSignature(h.conf.SharedKeys.Robot.PublicKey, req); err != nil {
-		return nil, err
-	}
-
-	valid, err := h.services.mining.Validate(req.TransactionHash, h.data.buildKeychain(req.Data), mining.KeychainTransaction)
+	valid, err := h.services.mining.Validate(req.TransactionHash, h.data.buildKeychain(req.Data), mining.KeychainTransaction, req.ValidationPool)
 	if err != nil {
 		return nil, err
 	}
@@ -252,7 +227,7 @@ func (h externalSrvHandler) ValidateKeychain(ctx context.Context, req *api.Keych
 	res := &api.ValidationResponse{
 		Validation: vRes,
 	}
-	if err := h.crypto.signer.SignValidationResponse(res, h.conf.SharedKeys.Robot.PrivateKey); err != nil {
+	if err := h.crypto.signer.SignValidationResponse(res, h.robot.privateKey()); err != nil {
 		return nil, err
 	}
 
@@ -260,11 +235,7 @@ func (h externalSrvHandler) ValidateKeychain(ctx context.Context, req *api.Keych
 }
 
 func (h externalSrvHandler) ValidateID(ctx context.Context, req *api.IDValidationRequest) (*api.ValidationResponse, error) {
-	if err := h.crypto.signer.VerifyIDValidationRequestSignature(h.conf.SharedKeys.Robot.PublicKey, req); err != nil {
-		return nil, err
-	}
-
-	valid, err := h.services.mining.Validate(req.TransactionHash, h.data.buildID(req.Data), mining.IDTransaction)
+	valid, err := h.services.mining.Validate(req.TransactionHash, h.data.buildID(req.Data), mining.IDTransaction, req.ValidationPool)
 	if err != nil {
 		return nil, err
 	}
@@ -279,7 +250,7 @@ func (h externalSrvHandler) ValidateID(ctx context.Context, req *api.IDValidatio
 	res := &api.ValidationResponse{
 		Validation: vRes,
 	}
-	if err := h.crypto.signer.SignValidationResponse(res, h.conf.SharedKeys.Robot.PrivateKey); err != nil {
+	if err := h.crypto.signer.SignValidationResponse(res, h.robot.privateKey()); err != nil {
 		return nil, err
 	}
 
@@ -287,11 +258,7 @@ func (h externalSrvHandler) ValidateID(ctx context.Context, req *api.IDValidatio
 }
 
 func (h externalSrvHandler) StoreKeychain(ctx context.Context, req *api.KeychainStorageRequest) (*api.StorageAck, error) {
-	if err := h.crypto.signer.VerifyKeychainStorageRequestSignature(h.conf.SharedKeys.Robot.PublicKey, req); err != nil {
-		return nil, err
-	}
-
-	clearaddr, err := h.crypto.decrypter.DecryptHash(req.Data.EncryptedAddrByRobot, h.conf.SharedKeys.Robot.PrivateKey)
+	clearaddr, err := h.robot.decryptHash(h.crypto.decrypter, req.Data.EncryptedAddrByRobot)
 	if err != nil {
 		return nil, ErrInvalidEncryption
 	}
@@ -303,6 +270,7 @@ func (h externalSrvHandler) StoreKeychain(ctx context.Context, req *api.Keychain
 	if err := h.services.accAdd.StoreKeychain(keychain); err != nil {
 		return nil, err
 	}
+	h.services.statuses.Notify(keychain.Endorsement().TransactionHash(), keychain.Endorsement().GetStatus())
 
 	hash, err := h.crypto.hasher.HashEndorsedKeychain(keychain)
 	if err != nil {
@@ -312,21 +280,18 @@ func (h externalSrvHandler) StoreKeychain(ctx context.Context, req *api.Keychain
 	ack := &api.StorageAck{
 		StorageHash: hash,
 	}
-	if err := h.crypto.signer.SignStorageAck(ack, h.conf.SharedKeys.Robot.PrivateKey); err != nil {
+	if err := h.crypto.signer.SignStorageAck(ack, h.robot.privateKey()); err != nil {
 		return nil, err
 	}
 	return ack, nil
 }
 
 func (h externalSrvHandler) StoreID(ctx context.Context, req *api.IDStorageRequest) (*api.StorageAck, error) {
-	if err := h.crypto.signer.VerifyIDStorageRequestSignature(h.conf.SharedKeys.Robot.PublicKey, req); err != nil {
-		return nil, err
-	}
-
 	id := account.NewEndorsedID(h.data.buildID(req.Data), h.data.buildEndorsement(req.Endorsement))
 	if err := h.services.accAdd.StoreID(id); err != nil {
 		return nil, err
 	}
+	h.services.statuses.Notify(id.Endorsement().TransactionHash(), id.Endorsement().GetStatus())
 
 	hash, err := h.crypto.hasher.HashEndorsedID(id)
 	if err != nil {
@@ -336,7 +301,7 @@ func (h externalSrvHandler) StoreID(ctx context.Context, req *api.IDStorageReque
 	ack := &api.StorageAck{
 		StorageHash: hash,
 	}
-	if err := h.crypto.signer.SignStorageAck(ack, h.conf.SharedKeys.Robot.PrivateKey); err != nil {
+	if err := h.crypto.signer.SignStorageAck(ack, h.robot.privateKey()); err != nil {
 		return nil, err
 	}
 	return ack, nil
@@ -344,40 +309,84 @@ func (h externalSrvHandler) StoreID(ctx context.Context, req *api.IDStorageReque
 
 func (h externalSrvHandler) GetTransactionStatus(ctx context.Context, req *api.TransactionStatusRequest) (*api.TransactionStatusResponse, error) {
 
-	addr, err := h.crypto.decrypter.DecryptHash(req.Address, h.conf.SharedKeys.Robot.PrivateKey)
+	addr, err := h.robot.decryptHash(h.crypto.decrypter, req.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	status, err := h.transactionStatus(addr, req.Hash)
 	if err != nil {
 		return nil, err
 	}
 
+	return &api.TransactionStatusResponse{
+		Status: api.TransactionStatusResponse_TransactionStatus(status),
+	}, nil
+}
+
+func (h externalSrvHandler) WatchTransactionStatus(req *api.TransactionStatusRequest, stream api.External_WatchTransactionStatusServer) error {
+	addr, err := h.robot.decryptHash(h.crypto.decrypter, req.Address)
+	if err != nil {
+		return err
+	}
+
+	//Subscribes before looking for the stored transaction, so a transition happening meanwhile is not missed
+	transitions, unwatch := h.services.statuses.Watch(req.Hash)
+	defer unwatch()
+
+	status, err := h.transactionStatus(addr, req.Hash)
+	if err != nil {
+		return err
+	}
+
+	for {
+		if err := stream.Send(&api.TransactionStatusResponse{
+			Status: api.TransactionStatusResponse_TransactionStatus(status),
+		}); err != nil {
+			return err
+		}
+
+		if status.IsFinal() {
+			return nil
+		}
+
+		next := status
+		for next == status {
+			select {
+			case <-stream.Context().Done():
+				return stream.Context().Err()
+			case next = <-transitions:
+			}
+		}
+		status = next
+	}
+}
+
+func (h externalSrvHandler) transactionStatus(addr string, txHash string) (mining.TransactionStatus, error) {
+
 	//TODO: search in the pending database
 
 	//Search if the transaction is a keychain and is stored
-	kc, err := h.services.accLister.GetKeychain(addr, req.Hash)
+	kc, err := h.services.accLister.GetKeychain(addr, txHash)
 	if err != nil {
-		return nil, err
+		return mining.TransactionUnknown, err
 	}
 	if kc != nil {
-		return &api.TransactionStatusResponse{
-			Status: api.TransactionStatusResponse_TransactionStatus(kc.Endorsement().GetStatus()),
-		}, nil
+		return kc.Endorsement().GetStatus(), nil
 	}
 
 	//Search if the transaction is a ID and is stored
-	id, err := h.services.accLister.GetIDByTransaction(req.Hash)
+	id, err := h.services.accLister.GetIDByTransaction(txHash)
 	if err != nil {
-		return nil, err
+		return mining.TransactionUnknown, err
 	}
 	if id != nil {
-		return &api.TransactionStatusResponse{
-			Status: api.TransactionStatusResponse_TransactionStatus(id.Endorsement().GetStatus()),
-		}, nil
+		return id.Endorsement().GetStatus(), nil
 	}
 
 	//TODO: //Search if the transaction is an IRIS exchange and is stored
 
 	//TODO: //Search if the transaction is a smartcontract and is stored
 
-	return &api.TransactionStatusResponse{
-		Status: api.TransactionStatusResponse_Unknown,
-	}, nil
+	return mining.TransactionUnknown, nil
 }
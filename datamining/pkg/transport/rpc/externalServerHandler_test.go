@@ -15,11 +15,13 @@ import (
 	accountListing "github.com/uniris/uniris-core/datamining/pkg/account/listing"
 	accountMining "github.com/uniris/uniris-core/datamining/pkg/account/mining"
 	mockcrypto "github.com/uniris/uniris-core/datamining/pkg/crypto/mock"
+	emAdding "github.com/uniris/uniris-core/datamining/pkg/emitter/adding"
 	emListing "github.com/uniris/uniris-core/datamining/pkg/emitter/listing"
 	"github.com/uniris/uniris-core/datamining/pkg/lock"
 	"github.com/uniris/uniris-core/datamining/pkg/mining"
 	mockstorage "github.com/uniris/uniris-core/datamining/pkg/storage/mock"
 	"github.com/uniris/uniris-core/datamining/pkg/system"
+	"github.com/uniris/uniris-core/datamining/pkg/transport/mem"
 	mocktransport "github.com/uniris/uniris-core/datamining/pkg/transport/mock"
 )
 
@@ -34,11 +36,11 @@ func TestGetID(t *testing.T) {
 	accLister := accountListing.NewService(db)
 
 	prop := datamining.NewProposal(
-		datamining.NewProposedKeyPair("enc pv key", "pub key"),
+		datamining.NewProposedKeyPair("enc pv key", "pub key", time.Time{}),
 	)
 	id := account.NewID("hash", "enc addr", "enc addr", "enc aes key", "id pub", prop, "id sig", "em sig")
 	endors := mining.NewEndorsement("", "hash",
-		mining.NewMasterValidation([]string{"hash"}, "robotkey", mining.NewValidation(mining.ValidationOK, time.Now(), "pub key", "sig")),
+		mining.NewMasterValidation([]string{"hash"}, "robotkey", mining.NewValidation(mining.ValidationOK, time.Now(), "pub key", "sig"), []string{"validator key"}),
 		[]mining.Validation{})
 
 	db.StoreID(account.NewEndorsedID(id, endors))
@@ -71,11 +73,11 @@ func TestGetKeychain(t *testing.T) {
 	accLister := accountListing.NewService(db)
 
 	prop := datamining.NewProposal(
-		datamining.NewProposedKeyPair("enc pv key", "pub key"),
+		datamining.NewProposedKeyPair("enc pv key", "pub key", time.Time{}),
 	)
 	kc := account.NewKeychain("enc address", "enc wallet", "id pub", prop, "id sig", "em sig")
 	endors := mining.NewEndorsement("", "hash",
-		mining.NewMasterValidation([]string{"hash"}, "robotkey", mining.NewValidation(mining.ValidationOK, time.Now(), "pub key", "sig")),
+		mining.NewMasterValidation([]string{"hash"}, "robotkey", mining.NewValidation(mining.ValidationOK, time.Now(), "pub key", "sig"), []string{"validator key"}),
 		[]mining.Validation{})
 
 	db.StoreKeychain(account.NewEndorsedKeychain("hash", kc, endors))
@@ -105,7 +107,7 @@ Scenario: Lead mining of keychain transaction
 */
 func TestLeadKeychainMining(t *testing.T) {
 	db := mockstorage.NewDatabase()
-	lockSrv := lock.NewService(db)
+	lockSrv := lock.NewService(db, mem.NewNotifier())
 	poolF := mockPoolFinder{}
 	cli := mocktransport.NewExternalClient(db)
 	poolR := mocktransport.NewPoolRequester(cli)
@@ -121,9 +123,9 @@ func TestLeadKeychainMining(t *testing.T) {
 		PublicKey: "robotkey",
 	}
 
-	mineSrv := mining.NewService(aiClient, poolF, poolR, mockcrypto.NewSigner(), emLister, conf, txMiners)
+	mineSrv := mining.NewService(aiClient, poolF, poolR, mockcrypto.NewSigner(), emLister, conf, txMiners, mining.NewStatusWatcher(), mem.NewNotifier())
 
-	accAdder := accountadding.NewService(aiClient, db, accLister, mockcrypto.NewSigner(), mockcrypto.NewHasher())
+	accAdder := accountadding.NewService(aiClient, db, accLister, emAdding.NewService(db, emListing.NewService(db), system.UnirisConfig{}), mockcrypto.NewSigner(), mockcrypto.NewHasher(), mem.NewNotifier())
 
 	srv := Services{accAdd: accAdder, lock: lockSrv, mining: mineSrv}
 	crypto := Crypto{
@@ -154,7 +156,7 @@ Scenario: Lead mining of ID transaction
 */
 func TestLeadIDMining(t *testing.T) {
 	db := mockstorage.NewDatabase()
-	lockSrv := lock.NewService(db)
+	lockSrv := lock.NewService(db, mem.NewNotifier())
 	poolF := mockPoolFinder{}
 	cli := mocktransport.NewExternalClient(db)
 	poolR := mocktransport.NewPoolRequester(cli)
@@ -169,10 +171,10 @@ func TestLeadIDMining(t *testing.T) {
 		mining.IDTransaction: accountMining.NewIDMiner(mockcrypto.NewSigner(), mockcrypto.NewHasher()),
 	}
 
-	mineSrv := mining.NewService(aiClient, poolF, poolR, mockcrypto.NewSigner(), emLister, conf, txMiners)
+	mineSrv := mining.NewService(aiClient, poolF, poolR, mockcrypto.NewSigner(), emLister, conf, txMiners, mining.NewStatusWatcher(), mem.NewNotifier())
 
 	accLister := accountListing.NewService(db)
-	accAdder := accountadding.NewService(aiClient, db, accLister, mockcrypto.NewSigner(), mockcrypto.NewHasher())
+	accAdder := accountadding.NewService(aiClient, db, accLister, emAdding.NewService(db, emListing.NewService(db), system.UnirisConfig{}), mockcrypto.NewSigner(), mockcrypto.NewHasher(), mem.NewNotifier())
 
 	srv := Services{accAdd: accAdder, lock: lockSrv, mining: mineSrv}
 	crypto := Crypto{
@@ -203,7 +205,7 @@ Scenario: Lock a transaction
 */
 func TestLockTransaction(t *testing.T) {
 	db := mockstorage.NewDatabase()
-	lockSrv := lock.NewService(db)
+	lockSrv := lock.NewService(db, mem.NewNotifier())
 
 	srv := Services{lock: lockSrv}
 	crypto := Crypto{
@@ -217,7 +219,6 @@ func TestLockTransaction(t *testing.T) {
 
 	ack, err := h.LockTransaction(context.TODO(), &api.LockRequest{
 		MasterRobotKey:  "robotkey",
-		Signature:       "sig",
 		Address:         "address",
 		TransactionHash: "hash",
 	})
@@ -240,7 +241,7 @@ Scenario: Unlock a transaction
 */
 func TestUnlockTransaction(t *testing.T) {
 	db := mockstorage.NewDatabase()
-	lockSrv := lock.NewService(db)
+	lockSrv := lock.NewService(db, mem.NewNotifier())
 
 	srv := Services{lock: lockSrv}
 	crypto := Crypto{
@@ -254,7 +255,6 @@ func TestUnlockTransaction(t *testing.T) {
 
 	_, err := h.LockTransaction(context.TODO(), &api.LockRequest{
 		MasterRobotKey:  "robotkey",
-		Signature:       "sig",
 		Address:         "address",
 		TransactionHash: "hash",
 	})
@@ -267,7 +267,6 @@ func TestUnlockTransaction(t *testing.T) {
 
 	ack, err := h.UnlockTransaction(context.TODO(), &api.LockRequest{
 		MasterRobotKey:  "robotkey",
-		Signature:       "sig",
 		Address:         "address",
 		TransactionHash: "hash",
 	})
@@ -298,7 +297,7 @@ func TestValidateKeychain(t *testing.T) {
 		PublicKey: "robotkey",
 	}
 
-	mineSrv := mining.NewService(nil, nil, nil, mockcrypto.NewSigner(), nil, conf, txMiners)
+	mineSrv := mining.NewService(nil, nil, nil, mockcrypto.NewSigner(), nil, conf, txMiners, mining.NewStatusWatcher(), mem.NewNotifier())
 
 	services := Services{mining: mineSrv}
 	crypto := Crypto{
@@ -322,6 +321,7 @@ func TestValidateKeychain(t *testing.T) {
 			},
 		},
 		TransactionHash: "hash",
+		ValidationPool:  []string{"robotkey"},
 	})
 	assert.Nil(t, err)
 	assert.Equal(t, api.Validation_OK, valid.Validation.Status)
@@ -346,7 +346,7 @@ func TestValidateID(t *testing.T) {
 		PublicKey: "robotkey",
 	}
 
-	mineSrv := mining.NewService(nil, nil, nil, mockcrypto.NewSigner(), nil, conf, txMiners)
+	mineSrv := mining.NewService(nil, nil, nil, mockcrypto.NewSigner(), nil, conf, txMiners, mining.NewStatusWatcher(), mem.NewNotifier())
 
 	services := Services{mining: mineSrv}
 	crypto := Crypto{
@@ -372,6 +372,7 @@ func TestValidateID(t *testing.T) {
 			},
 		},
 		TransactionHash: "hash",
+		ValidationPool:  []string{"robotkey"},
 	})
 	assert.Nil(t, err)
 	assert.Equal(t, api.Validation_OK, valid.Validation.Status)
@@ -390,9 +391,9 @@ func TestStoreKeychain(t *testing.T) {
 	db := mockstorage.NewDatabase()
 	accLister := accountListing.NewService(db)
 	aiClient := mocktransport.NewAIClient()
-	accAdder := accountadding.NewService(aiClient, db, accLister, mockcrypto.NewSigner(), mockcrypto.NewHasher())
+	accAdder := accountadding.NewService(aiClient, db, accLister, emAdding.NewService(db, emListing.NewService(db), system.UnirisConfig{}), mockcrypto.NewSigner(), mockcrypto.NewHasher(), mem.NewNotifier())
 
-	services := Services{accAdd: accAdder}
+	services := Services{accAdd: accAdder, statuses: mining.NewStatusWatcher()}
 	crypto := Crypto{
 		decrypter: mockcrypto.NewDecrypter(),
 		signer:    mockcrypto.NewSigner(),
@@ -460,9 +461,9 @@ func TestStoreID(t *testing.T) {
 
 	accLister := accountListing.NewService(db)
 	aiClient := mocktransport.NewAIClient()
-	accAdder := accountadding.NewService(aiClient, db, accLister, mockcrypto.NewSigner(), mockcrypto.NewHasher())
+	accAdder := accountadding.NewService(aiClient, db, accLister, emAdding.NewService(db, emListing.NewService(db), system.UnirisConfig{}), mockcrypto.NewSigner(), mockcrypto.NewHasher(), mem.NewNotifier())
 
-	services := Services{accAdd: accAdder}
+	services := Services{accAdd: accAdder, statuses: mining.NewStatusWatcher()}
 	crypto := Crypto{
 		decrypter: mockcrypto.NewDecrypter(),
 		signer:    mockcrypto.NewSigner(),
@@ -533,9 +534,9 @@ func TestGetKeychainTransactionStatus(t *testing.T) {
 	db := mockstorage.NewDatabase()
 	accLister := accountListing.NewService(db)
 
-	kc := account.NewKeychain("addr", "wallet", "idpub", datamining.NewProposal(datamining.NewProposedKeyPair("enc pv", "pubk")), "id sig", "emsig")
+	kc := account.NewKeychain("addr", "wallet", "idpub", datamining.NewProposal(datamining.NewProposedKeyPair("enc pv", "pubk", time.Time{})), "id sig", "emsig")
 	end := mining.NewEndorsement("", "txHash", mining.NewMasterValidation(
-		[]string{}, "powkey", mining.NewValidation(mining.ValidationOK, time.Now(), "pubkey", "sig"),
+		[]string{}, "powkey", mining.NewValidation(mining.ValidationOK, time.Now(), "pubkey", "sig"), []string{"validator key"},
 	), []mining.Validation{
 		mining.NewValidation(mining.ValidationOK, time.Now(), "pubkey", "sig"),
 	})
@@ -571,9 +572,9 @@ func TestGetIDTransactionStatus(t *testing.T) {
 	db := mockstorage.NewDatabase()
 	accLister := accountListing.NewService(db)
 
-	id := account.NewID("hash", "addr", "addr", "aes key", "pubk", datamining.NewProposal(datamining.NewProposedKeyPair("enc", "pub")), "id sig", "em sig")
+	id := account.NewID("hash", "addr", "addr", "aes key", "pubk", datamining.NewProposal(datamining.NewProposedKeyPair("enc", "pub", time.Time{})), "id sig", "em sig")
 	end := mining.NewEndorsement("", "txHash", mining.NewMasterValidation(
-		[]string{}, "powkey", mining.NewValidation(mining.ValidationOK, time.Now(), "pubkey", "sig"),
+		[]string{}, "powkey", mining.NewValidation(mining.ValidationOK, time.Now(), "pubkey", "sig"), []string{"validator key"},
 	), []mining.Validation{
 		mining.NewValidation(mining.ValidationOK, time.Now(), "pubkey", "sig"),
 	})
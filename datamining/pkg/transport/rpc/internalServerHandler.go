@@ -3,19 +3,39 @@ package rpc
 import (
 	"errors"
 	"log"
+	"time"
 
 	"github.com/golang/protobuf/ptypes/empty"
 
 	"github.com/uniris/uniris-core/datamining/pkg/account"
+	"github.com/uniris/uniris-core/datamining/pkg/account/creating"
 	"github.com/uniris/uniris-core/datamining/pkg/mining"
 	"github.com/uniris/uniris-core/datamining/pkg/system"
+	"github.com/uniris/uniris-core/shared/pkg/errcode"
+	"github.com/uniris/uniris-core/shared/pkg/nonce"
 	"golang.org/x/net/context"
 
 	api "github.com/uniris/uniris-core/datamining/api/protobuf-spec"
 
+	emAdding "github.com/uniris/uniris-core/datamining/pkg/emitter/adding"
 	emListing "github.com/uniris/uniris-core/datamining/pkg/emitter/listing"
 )
 
+//ErrExpiredRequest is returned when the timestamp of a request signed by an ID is outside the freshness window
+var ErrExpiredRequest = errors.New("Request expired")
+
+//ErrKeychainNotOwned is returned when a keychain update does not target the address of the ID
+var ErrKeychainNotOwned = errors.New("Keychain does not belong to the account")
+
+//ErrUnauthorizedAuthority is returned when an emitter authorization is not signed by an authority
+var ErrUnauthorizedAuthority = errors.New("Authorization not signed by an authority")
+
+//idRequestFreshness is the maximum clock drift accepted for the requests signed by an ID, aligned with the API service
+const idRequestFreshness = 5 * time.Minute
+
+//statusWatchTimeout is the maximum duration of a transaction status stream, as a transaction rejected before its storage is never notified by the storage peers
+const statusWatchTimeout = 10 * time.Minute
+
 type internalSrvHandler struct {
 	pR       account.PoolRequester
 	aiClient AIClient
@@ -23,25 +43,37 @@ type internalSrvHandler struct {
 	crypto   Crypto
 	conf     system.UnirisConfig
 	emLister emListing.Service
+	emAdder  emAdding.Service
 	poolF    mining.PoolFinder
+	robot    robotKeys
+	launcher accountTxLauncher
+	creator  creating.Service
+	nonces   nonce.Cache
+	api      apiBuilder
 }
 
 //NewInternalServerHandler create a new GRPC server handler for account
-func NewInternalServerHandler(emLister emListing.Service, pR PoolRequester, pF mining.PoolFinder, aiClient AIClient, extCli ExternalClient, crypto Crypto, conf system.UnirisConfig) api.InternalServer {
+func NewInternalServerHandler(emLister emListing.Service, emAdder emAdding.Service, pR PoolRequester, pF mining.PoolFinder, aiClient AIClient, extCli ExternalClient, creator creating.Service, crypto Crypto, conf system.UnirisConfig) api.InternalServer {
 	return internalSrvHandler{
 		emLister: emLister,
+		emAdder:  emAdder,
 		pR:       pR,
 		poolF:    pF,
 		aiClient: aiClient,
 		extCli:   extCli,
 		crypto:   crypto,
 		conf:     conf,
+		robot:    robotKeys{conf.SharedKeys},
+		launcher: newAccountTxLauncher(aiClient, extCli, pF, crypto, conf),
+		creator:  creator,
+		nonces:   nonce.NewCache(),
+		api:      apiBuilder{},
 	}
 }
 
 //GetAccount implements the protobuf GetAccount request handler
 func (s internalSrvHandler) GetAccount(ctx context.Context, req *api.AccountSearchRequest) (*api.AccountSearchResult, error) {
-	idHash, err := s.crypto.decrypter.DecryptHash(req.EncryptedIDHash, s.conf.SharedKeys.Robot.PrivateKey)
+	idHash, err := s.robot.decryptHash(s.crypto.decrypter, req.EncryptedIDHash)
 	if err != nil {
 		return nil, ErrInvalidEncryption
 	}
@@ -56,7 +88,7 @@ func (s internalSrvHandler) GetAccount(ctx context.Context, req *api.AccountSear
 		return nil, err
 	}
 
-	clearAddr, err := s.crypto.decrypter.DecryptHash(id.EncryptedAddrByRobot(), s.conf.SharedKeys.Robot.PrivateKey)
+	clearAddr, err := s.robot.decryptHash(s.crypto.decrypter, id.EncryptedAddrByRobot())
 	if err != nil {
 		return nil, ErrInvalidEncryption
 	}
@@ -72,7 +104,7 @@ func (s internalSrvHandler) GetAccount(ctx context.Context, req *api.AccountSear
 	}
 
 	if keychain == nil {
-		return nil, errors.New(s.conf.Services.Datamining.Errors.AccountNotExist)
+		return nil, errcode.New(errcode.AccountNotExist, s.conf.Services.Datamining.Errors.AccountNotExist)
 	}
 
 	res := &api.AccountSearchResult{
@@ -81,7 +113,7 @@ func (s internalSrvHandler) GetAccount(ctx context.Context, req *api.AccountSear
 		EncryptedAddress: id.EncryptedAddrByID(),
 	}
 
-	if err := s.crypto.signer.SignAccountSearchResult(res, s.conf.SharedKeys.Robot.PrivateKey); err != nil {
+	if err := s.crypto.signer.SignAccountSearchResult(res, s.robot.privateKey()); err != nil {
 		return nil, err
 	}
 
@@ -89,76 +121,240 @@ func (s internalSrvHandler) GetAccount(ctx context.Context, req *api.AccountSear
 }
 
 func (s internalSrvHandler) CreateKeychain(ctx context.Context, req *api.KeychainCreationRequest) (*api.CreationResult, error) {
-	keychain, err := s.crypto.decrypter.DecryptKeychain(req.EncryptedKeychain, s.conf.SharedKeys.Robot.PrivateKey)
+	res, err := s.launcher.LaunchKeychain(req.EncryptedKeychain)
 	if err != nil {
 		return nil, err
 	}
+	return formatCreationResult(res), nil
+}
 
-	txHash, err := s.crypto.hasher.HashKeychain(keychain)
+func (s internalSrvHandler) CreateID(ctx context.Context, req *api.IDCreationRequest) (*api.CreationResult, error) {
+	res, err := s.launcher.LaunchID(req.EncryptedID)
 	if err != nil {
 		return nil, err
 	}
+	return formatCreationResult(res), nil
+}
 
-	master, err := s.aiClient.GetMasterPeer(txHash)
+func (s internalSrvHandler) CreateAccount(ctx context.Context, req *api.AccountCreationRequest) (*api.AccountCreationResult, error) {
+	res, err := s.creator.CreateAccount(req.EncryptedID, req.EncryptedKeychain)
 	if err != nil {
 		return nil, err
 	}
-	validPool, err := s.aiClient.GetValidationPool(txHash)
+
+	return &api.AccountCreationResult{
+		ID:       formatCreationResult(res.ID),
+		Keychain: formatCreationResult(res.Keychain),
+	}, nil
+}
+
+func (s internalSrvHandler) CreateAccounts(ctx context.Context, req *api.AccountCreationBatchRequest) (*api.AccountCreationBatchResult, error) {
+	reqs := make([]creating.AccountCreationRequest, 0)
+	for _, r := range req.Requests {
+		reqs = append(reqs, creating.AccountCreationRequest{
+			EncryptedID:       r.EncryptedID,
+			EncryptedKeychain: r.EncryptedKeychain,
+		})
+	}
+
+	results := make([]*api.AccountCreationBatchItem, 0)
+	for _, o := range s.creator.CreateAccounts(reqs) {
+		if o.Err != nil {
+			results = append(results, &api.AccountCreationBatchItem{
+				Error:     o.Err.Error(),
+				ErrorCode: string(errcode.FromGRPC(catalogueError(o.Err)).Code),
+			})
+			continue
+		}
+		results = append(results, &api.AccountCreationBatchItem{
+			Result: &api.AccountCreationResult{
+				ID:       formatCreationResult(o.Creation.ID),
+				Keychain: formatCreationResult(o.Creation.Keychain),
+			},
+		})
+	}
+
+	return &api.AccountCreationBatchResult{
+		Results: results,
+	}, nil
+}
+
+func (s internalSrvHandler) GetAccountCreationStatus(ctx context.Context, req *api.AccountCreationStatusRequest) (*api.AccountCreationStatusResponse, error) {
+	state, err := s.creator.GetAccountCreationStatus(req.IDTransactionHash)
+	if err != nil {
+		return nil, err
+	}
+	return &api.AccountCreationStatusResponse{
+		Status:         api.AccountCreationStatusResponse_AccountCreationStatus(state.Status),
+		IDStatus:       api.TransactionStatusResponse_TransactionStatus(state.IDStatus),
+		KeychainStatus: api.TransactionStatusResponse_TransactionStatus(state.KeychainStatus),
+		Keychain:       formatCreationResult(state.Keychain),
+	}, nil
+}
+
+func (s internalSrvHandler) UpdateKeychain(ctx context.Context, req *api.KeychainUpdateRequest) (*api.CreationResult, error) {
+	id, err := s.requestID(req.EncryptedIDHash)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.crypto.signer.VerifyKeychainUpdateRequestSignature(req, id.PublicKey()); err != nil {
+		return nil, ErrInvalidSignature
+	}
+
+	signedAt := time.Unix(req.Timestamp, 0)
+	if drift := time.Since(signedAt); drift > idRequestFreshness || drift < -idRequestFreshness {
+		return nil, ErrExpiredRequest
+	}
+	if !s.nonces.Add(req.EncryptedIDHash, req.Nonce, signedAt.Add(idRequestFreshness)) {
+		return nil, ErrReplayedRequest
+	}
+
+	//The new keychain version must be chained to the address of the ID
+	keychain, err := s.robot.decryptKeychain(s.crypto.decrypter, req.EncryptedKeychain)
+	if err != nil {
+		return nil, ErrInvalidEncryption
+	}
+	idAddr, err := s.robot.decryptHash(s.crypto.decrypter, id.EncryptedAddrByRobot())
+	if err != nil {
+		return nil, ErrInvalidEncryption
+	}
+	keychainAddr, err := s.robot.decryptHash(s.crypto.decrypter, keychain.EncryptedAddrByRobot())
+	if err != nil {
+		return nil, ErrInvalidEncryption
+	}
+	if idAddr != keychainAddr {
+		return nil, ErrKeychainNotOwned
+	}
+
+	res, err := s.launcher.LaunchKeychain(req.EncryptedKeychain)
 	if err != nil {
 		return nil, err
 	}
+	return formatCreationResult(res), nil
+}
 
-	if err := s.extCli.LeadKeychainMining(master.IP.String(), txHash, req.EncryptedKeychain, validPool.Peers().IPs()); err != nil {
-		log.Print(err.Error())
+func (s internalSrvHandler) GetIDDetails(ctx context.Context, req *api.AccountSearchRequest) (*api.IDResponse, error) {
+	id, err := s.requestID(req.EncryptedIDHash)
+	if err != nil {
 		return nil, err
 	}
 
-	res := &api.CreationResult{
-		TransactionHash: txHash,
-		MasterPeerIP:    master.IP.String(),
+	res := &api.IDResponse{
+		Data:        s.api.buildID(id),
+		Endorsement: s.api.buildEndorsement(id.Endorsement()),
 	}
 
-	if err := s.crypto.signer.SignCreationResult(res, s.conf.SharedKeys.Robot.PrivateKey); err != nil {
+	if err := s.crypto.signer.SignIDResponse(res, s.robot.privateKey()); err != nil {
 		return nil, err
 	}
 
 	return res, nil
 }
 
-func (s internalSrvHandler) CreateID(ctx context.Context, req *api.IDCreationRequest) (*api.CreationResult, error) {
-	id, err := s.crypto.decrypter.DecryptID(req.EncryptedID, s.conf.SharedKeys.Robot.PrivateKey)
+func (s internalSrvHandler) GetKeychainDetails(ctx context.Context, req *api.AccountSearchRequest) (*api.KeychainResponse, error) {
+	id, err := s.requestID(req.EncryptedIDHash)
 	if err != nil {
 		return nil, err
 	}
 
-	txHash, err := s.crypto.hasher.HashID(id)
+	keychain, err := s.requestKeychain(id)
 	if err != nil {
 		return nil, err
 	}
 
-	master, err := s.aiClient.GetMasterPeer(txHash)
+	res := &api.KeychainResponse{
+		Data:        s.api.buildKeychain(keychain),
+		Endorsement: s.api.buildEndorsement(keychain.Endorsement()),
+	}
+
+	if err := s.crypto.signer.SignKeychainResponse(res, s.robot.privateKey()); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (s internalSrvHandler) GetAccountProof(ctx context.Context, req *api.AccountSearchRequest) (*api.AccountProof, error) {
+	id, err := s.requestID(req.EncryptedIDHash)
 	if err != nil {
 		return nil, err
 	}
-	validPool, err := s.aiClient.GetValidationPool(txHash)
+
+	keychain, err := s.requestKeychain(id)
 	if err != nil {
 		return nil, err
 	}
 
-	if err := s.extCli.LeadIDMining(master.IP.String(), txHash, req.EncryptedID, validPool.Peers().IPs()); err != nil {
+	//The proof is not signed with the robot key, as it must be verified without trusting the peer
+	return &api.AccountProof{
+		ID:            s.api.buildID(id),
+		IDProof:       s.buildTransactionProof(id.Endorsement()),
+		Keychain:      s.api.buildKeychain(keychain),
+		KeychainProof: s.buildTransactionProof(keychain.Endorsement()),
+	}, nil
+}
+
+//buildTransactionProof wraps the endorsement, whose master validation carries the signed validation pool
+func (s internalSrvHandler) buildTransactionProof(end mining.Endorsement) *api.TransactionProof {
+	return &api.TransactionProof{
+		Endorsement: s.api.buildEndorsement(end),
+	}
+}
+
+//requestKeychain retrieves the last endorsed keychain of an ID from its storage pool
+func (s internalSrvHandler) requestKeychain(id account.EndorsedID) (account.EndorsedKeychain, error) {
+	clearAddr, err := s.robot.decryptHash(s.crypto.decrypter, id.EncryptedAddrByRobot())
+	if err != nil {
+		return nil, ErrInvalidEncryption
+	}
+
+	keychainPool, err := s.aiClient.GetStoragePool(clearAddr)
+	if err != nil {
 		return nil, err
 	}
 
-	res := &api.CreationResult{
-		TransactionHash: txHash,
-		MasterPeerIP:    master.IP.String(),
+	keychain, err := s.pR.RequestKeychain(keychainPool, id.EncryptedAddrByRobot())
+	if err != nil {
+		return nil, err
+	}
+
+	if keychain == nil {
+		return nil, errcode.New(errcode.AccountNotExist, s.conf.Services.Datamining.Errors.AccountNotExist)
+	}
+	return keychain, nil
+}
+
+//requestID retrieves the endorsed ID from its storage pool
+func (s internalSrvHandler) requestID(encIDHash string) (account.EndorsedID, error) {
+	idHash, err := s.robot.decryptHash(s.crypto.decrypter, encIDHash)
+	if err != nil {
+		return nil, ErrInvalidEncryption
 	}
 
-	if err := s.crypto.signer.SignCreationResult(res, s.conf.SharedKeys.Robot.PrivateKey); err != nil {
+	idPool, err := s.aiClient.GetStoragePool(idHash)
+	if err != nil {
 		return nil, err
 	}
 
-	return res, nil
+	id, err := s.pR.RequestID(idPool, encIDHash)
+	if err != nil {
+		return nil, err
+	}
+
+	if id == nil {
+		return nil, errcode.New(errcode.AccountNotExist, s.conf.Services.Datamining.Errors.AccountNotExist)
+	}
+	return id, nil
+}
+
+func formatCreationResult(res creating.TransactionResult) *api.CreationResult {
+	return &api.CreationResult{
+		TransactionHash:  res.TransactionHash,
+		MasterPeerIP:     res.MasterPeerIP,
+		EncryptedAddress: res.EncryptedAddress,
+		Signature:        res.Signature,
+	}
 }
 
 func (s internalSrvHandler) IsEmitterAuthorized(ctx context.Context, req *api.AuthorizationRequest) (*api.AuthorizationResponse, error) {
@@ -168,6 +364,7 @@ func (s internalSrvHandler) IsEmitterAuthorized(ctx context.Context, req *api.Au
 				Status: false,
 			}, nil
 		}
+		return nil, err
 	}
 
 	return &api.AuthorizationResponse{
@@ -175,6 +372,53 @@ func (s internalSrvHandler) IsEmitterAuthorized(ctx context.Context, req *api.Au
 	}, nil
 }
 
+func (s internalSrvHandler) AuthorizeEmitter(ctx context.Context, req *api.EmitterAuthorizationRequest) (*empty.Empty, error) {
+	if err := s.verifyAuthority(req, func(pubKey string) error {
+		return s.crypto.signer.VerifyEmitterAuthorizationRequestSignature(req, pubKey)
+	}); err != nil {
+		return nil, err
+	}
+	if err := s.emAdder.AuthorizeEmitter(req.PublicKey); err != nil {
+		return nil, err
+	}
+	return &empty.Empty{}, nil
+}
+
+func (s internalSrvHandler) RevokeEmitter(ctx context.Context, req *api.EmitterAuthorizationRequest) (*empty.Empty, error) {
+	if err := s.verifyAuthority(req, func(pubKey string) error {
+		return s.crypto.signer.VerifyEmitterRevocationRequestSignature(req, pubKey)
+	}); err != nil {
+		return nil, err
+	}
+	if err := s.emAdder.RevokeEmitter(req.PublicKey); err != nil {
+		return nil, err
+	}
+	return &empty.Empty{}, nil
+}
+
+//verifyAuthority checks an emitter authorization request is signed by one of the configured authorities and is not replayed
+func (s internalSrvHandler) verifyAuthority(req *api.EmitterAuthorizationRequest, verify func(pubKey string) error) error {
+	var authority string
+	for _, pubKey := range s.conf.Emitters.Authorities {
+		if verify(pubKey) == nil {
+			authority = pubKey
+			break
+		}
+	}
+	if authority == "" {
+		return ErrUnauthorizedAuthority
+	}
+
+	signedAt := time.Unix(req.Timestamp, 0)
+	if drift := time.Since(signedAt); drift > idRequestFreshness || drift < -idRequestFreshness {
+		return ErrExpiredRequest
+	}
+	if !s.nonces.Add(authority, req.Nonce, signedAt.Add(idRequestFreshness)) {
+		return ErrReplayedRequest
+	}
+	return nil
+}
+
 func (s internalSrvHandler) GetSharedKeys(ctx context.Context, req *empty.Empty) (*api.SharedKeysResult, error) {
 	kps, err := s.emLister.ListSharedEmitterKeyPairs()
 	if err != nil {
@@ -189,15 +433,54 @@ func (s internalSrvHandler) GetSharedKeys(ctx context.Context, req *empty.Empty)
 		})
 	}
 
+	prevKps, err := s.emLister.ListPreviousSharedEmitterKeyPairs()
+	if err != nil {
+		return nil, err
+	}
+
+	prevEmitterKeys := make([]*api.SharedKeyPair, 0)
+	for _, kp := range prevKps {
+		prevEmitterKeys = append(prevEmitterKeys, &api.SharedKeyPair{
+			EncryptedPrivateKey: kp.KeyPair.EncryptedPrivateKey,
+			PublicKey:           kp.KeyPair.PublicKey,
+			ExpirationDate:      kp.ExpirationDate.Unix(),
+		})
+	}
+
+	robotKeys := make([]*api.RobotKeyPair, 0)
+	for _, kp := range s.conf.SharedKeys.RobotKeyPairs() {
+		rkp := &api.RobotKeyPair{
+			Version:   int32(kp.Version),
+			PublicKey: kp.PublicKey,
+		}
+
+		//The robot keypair without activation date is always activated
+		if !kp.ActivationDate.IsZero() {
+			rkp.ActivationDate = kp.ActivationDate.Unix()
+		}
+		robotKeys = append(robotKeys, rkp)
+	}
+
 	return &api.SharedKeysResult{
-		EmitterKeys:     emiterKeys,
-		RobotPublicKey:  s.conf.SharedKeys.Robot.PublicKey,
-		RobotPrivateKey: s.conf.SharedKeys.Robot.PrivateKey,
+		EmitterKeys:         emiterKeys,
+		PreviousEmitterKeys: prevEmitterKeys,
+		RobotPublicKey:      s.robot.publicKey(),
+		RobotKeys:           robotKeys,
+	}, nil
+}
+
+func (s internalSrvHandler) SignPayload(ctx context.Context, req *api.PayloadSignatureRequest) (*api.PayloadSignatureResponse, error) {
+	sig, err := s.crypto.signer.SignPayload(req.Payload, s.robot.privateKey())
+	if err != nil {
+		return nil, err
+	}
+	return &api.PayloadSignatureResponse{
+		Signature: sig,
 	}, nil
 }
 
 func (s internalSrvHandler) GetTransactionStatus(ctx context.Context, req *api.TransactionStatusRequest) (*api.TransactionStatusResponse, error) {
-	addr, err := s.crypto.decrypter.DecryptHash(req.Address, s.conf.SharedKeys.Robot.PrivateKey)
+	addr, err := s.robot.decryptHash(s.crypto.decrypter, req.Address)
 	if err != nil {
 		return nil, err
 	}
@@ -226,3 +509,49 @@ func (s internalSrvHandler) GetTransactionStatus(ctx context.Context, req *api.T
 	return nil, nil
 
 }
+
+//GetStoragePeers implements the protobuf GetStoragePeers request handler
+//
+//It lists the storage pool of the address, to let the clients know the peers holding the account data
+func (s internalSrvHandler) GetStoragePeers(ctx context.Context, req *api.StoragePeersRequest) (*api.StoragePeersResult, error) {
+	addr, err := s.robot.decryptHash(s.crypto.decrypter, req.Address)
+	if err != nil {
+		return nil, ErrInvalidEncryption
+	}
+
+	storagePool, err := s.poolF.FindStoragePool(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	peers := make([]*api.Peer, 0)
+	for _, p := range storagePool.Peers() {
+		peers = append(peers, &api.Peer{
+			IP:        p.IP.String(),
+			PublicKey: p.PublicKey,
+		})
+	}
+	return &api.StoragePeersResult{
+		Peers: peers,
+	}, nil
+}
+
+func (s internalSrvHandler) WatchTransactionStatus(req *api.TransactionStatusRequest, stream api.Internal_WatchTransactionStatusServer) error {
+	ctx, cancel := context.WithTimeout(stream.Context(), statusWatchTimeout)
+	defer cancel()
+
+	statuses, err := s.launcher.WatchStatus(ctx, req.Address, req.Hash)
+	if err != nil {
+		return err
+	}
+
+	for status := range statuses {
+		if err := stream.Send(&api.TransactionStatusResponse{
+			Status: api.TransactionStatusResponse_TransactionStatus(status),
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
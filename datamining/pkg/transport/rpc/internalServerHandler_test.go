@@ -2,7 +2,9 @@ package rpc
 
 import (
 	"context"
+	"net"
 	"testing"
+	"time"
 
 	"github.com/golang/protobuf/ptypes/empty"
 
@@ -14,10 +16,13 @@ import (
 	datamining "github.com/uniris/uniris-core/datamining/pkg"
 	"github.com/uniris/uniris-core/datamining/pkg/account"
 	mockcrypto "github.com/uniris/uniris-core/datamining/pkg/crypto/mock"
+	emadding "github.com/uniris/uniris-core/datamining/pkg/emitter/adding"
 	emlisting "github.com/uniris/uniris-core/datamining/pkg/emitter/listing"
+	"github.com/uniris/uniris-core/datamining/pkg/mining"
 	mockstorage "github.com/uniris/uniris-core/datamining/pkg/storage/mock"
 	"github.com/uniris/uniris-core/datamining/pkg/system"
 	mocktransport "github.com/uniris/uniris-core/datamining/pkg/transport/mock"
+	"google.golang.org/grpc"
 )
 
 /*
@@ -34,7 +39,7 @@ func TestGetAccount(t *testing.T) {
 		hasher:    mockcrypto.NewHasher(),
 	}
 	prop := datamining.NewProposal(
-		datamining.NewProposedKeyPair("enc pv key", "pub key"),
+		datamining.NewProposedKeyPair("enc pv key", "pub key", time.Time{}),
 	)
 
 	db := mockstorage.NewDatabase()
@@ -58,7 +63,7 @@ func TestGetAccount(t *testing.T) {
 
 	emLister := emlisting.NewService(db)
 	extCli := mocktransport.NewExternalClient(db)
-	srvHandler := NewInternalServerHandler(emLister, poolR, nil, mocktransport.NewAIClient(), extCli, crypto, conf)
+	srvHandler := NewInternalServerHandler(emLister, nil, poolR, nil, mocktransport.NewAIClient(), extCli, nil, crypto, conf)
 
 	res, err := srvHandler.GetAccount(context.TODO(), &api.AccountSearchRequest{
 		EncryptedIDHash: "enc id hash",
@@ -88,7 +93,7 @@ func TestCreateKeychain(t *testing.T) {
 	poolR := mocktransport.NewPoolRequester(extCli)
 	aiCli := mocktransport.NewAIClient()
 	emLister := emlisting.NewService(db)
-	srvHandler := NewInternalServerHandler(emLister, poolR, nil, aiCli, extCli, crypto, conf)
+	srvHandler := NewInternalServerHandler(emLister, nil, poolR, nil, aiCli, extCli, nil, crypto, conf)
 
 	res, err := srvHandler.CreateKeychain(context.TODO(), &api.KeychainCreationRequest{
 		EncryptedKeychain: "cipher data",
@@ -119,7 +124,7 @@ func TestCreateID(t *testing.T) {
 	aiCli := mocktransport.NewAIClient()
 
 	emLister := emlisting.NewService(db)
-	srvHandler := NewInternalServerHandler(emLister, poolR, nil, aiCli, extCli, crypto, conf)
+	srvHandler := NewInternalServerHandler(emLister, nil, poolR, nil, aiCli, extCli, nil, crypto, conf)
 
 	res, err := srvHandler.CreateID(context.TODO(), &api.IDCreationRequest{
 		EncryptedID: "cipher data",
@@ -130,15 +135,240 @@ func TestCreateID(t *testing.T) {
 	assert.Equal(t, "sig", res.Signature)
 }
 
+/*
+Scenario: Update a keychain
+	Given an existing account and a keychain update request signed by the ID key
+	When I want to update the keychain
+	Then the mining process started for the new keychain version
+*/
+func TestUpdateKeychain(t *testing.T) {
+	srvHandler := newKeychainUpdateHandler(true)
+
+	res, err := srvHandler.UpdateKeychain(context.TODO(), &api.KeychainUpdateRequest{
+		EncryptedIDHash:   "enc id hash",
+		EncryptedKeychain: "cipher data",
+		Timestamp:         time.Now().Unix(),
+		Nonce:             "nonce",
+		Signature:         "sig",
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "hash", res.TransactionHash)
+	assert.Equal(t, "127.0.0.1", res.MasterPeerIP)
+	assert.Equal(t, "sig", res.Signature)
+}
+
+/*
+Scenario: Update a keychain with an invalid request
+	Given a keychain update request with an invalid signature, an expired timestamp or a nonce already used
+	When I want to update the keychain
+	Then I get an error
+*/
+func TestUpdateKeychainInvalidRequest(t *testing.T) {
+	srvHandler := newKeychainUpdateHandler(true)
+
+	_, err := srvHandler.UpdateKeychain(context.TODO(), &api.KeychainUpdateRequest{
+		EncryptedIDHash:   "enc id hash",
+		EncryptedKeychain: "cipher data",
+		Timestamp:         time.Now().Unix(),
+		Nonce:             "nonce",
+		Signature:         "other sig",
+	})
+	assert.Equal(t, ErrInvalidSignature, err)
+
+	_, err = srvHandler.UpdateKeychain(context.TODO(), &api.KeychainUpdateRequest{
+		EncryptedIDHash:   "enc id hash",
+		EncryptedKeychain: "cipher data",
+		Timestamp:         time.Now().Add(-10 * time.Minute).Unix(),
+		Nonce:             "nonce",
+		Signature:         "sig",
+	})
+	assert.Equal(t, ErrExpiredRequest, err)
+
+	req := &api.KeychainUpdateRequest{
+		EncryptedIDHash:   "enc id hash",
+		EncryptedKeychain: "cipher data",
+		Timestamp:         time.Now().Unix(),
+		Nonce:             "nonce",
+		Signature:         "sig",
+	}
+	_, err = srvHandler.UpdateKeychain(context.TODO(), req)
+	assert.Nil(t, err)
+	_, err = srvHandler.UpdateKeychain(context.TODO(), req)
+	assert.Equal(t, ErrReplayedRequest, err)
+}
+
+/*
+Scenario: Update the keychain of an unknown account
+	Given no ID stored
+	When I want to update the keychain
+	Then I get an account not exist error
+*/
+func TestUpdateKeychainUnknownAccount(t *testing.T) {
+	srvHandler := newKeychainUpdateHandler(false)
+
+	_, err := srvHandler.UpdateKeychain(context.TODO(), &api.KeychainUpdateRequest{
+		EncryptedIDHash:   "enc id hash",
+		EncryptedKeychain: "cipher data",
+		Timestamp:         time.Now().Unix(),
+		Nonce:             "nonce",
+		Signature:         "sig",
+	})
+	assert.Equal(t, "Account doesn't exist", err.Error())
+}
+
+func newKeychainUpdateHandler(withID bool) api.InternalServer {
+	conf := system.UnirisConfig{}
+	conf.Services.Datamining.Errors.AccountNotExist = "Account doesn't exist"
+	crypto := Crypto{
+		decrypter: mockcrypto.NewDecrypter(),
+		signer:    mockcrypto.NewSigner(),
+		hasher:    mockcrypto.NewHasher(),
+	}
+	db := mockstorage.NewDatabase()
+	if withID {
+		prop := datamining.NewProposal(
+			datamining.NewProposedKeyPair("enc pv key", "pub key", time.Time{}),
+		)
+		db.StoreID(
+			account.NewEndorsedID(
+				account.NewID("hash", "enc addr", "enc addr", "enc aes key", "id pub", prop, "id sig", "em sig"),
+				nil,
+			),
+		)
+	}
+	extCli := mocktransport.NewExternalClient(db)
+	poolR := mocktransport.NewPoolRequester(extCli)
+	return NewInternalServerHandler(emlisting.NewService(db), nil, poolR, nil, mocktransport.NewAIClient(), extCli, nil, crypto, conf)
+}
+
+/*
+Scenario: Get the ID details
+	Given a stored ID with its endorsement
+	When I want to get the ID details
+	Then I get the ID and the endorsement signed by the robot
+*/
+func TestGetIDDetails(t *testing.T) {
+	srvHandler := newAccountDetailsHandler()
+
+	res, err := srvHandler.GetIDDetails(context.TODO(), &api.AccountSearchRequest{
+		EncryptedIDHash: "enc id hash",
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "hash", res.Data.Hash)
+	assert.Equal(t, "id pub", res.Data.PublicKey)
+	assert.Equal(t, "last hash", res.Endorsement.LastTransactionHash)
+	assert.Equal(t, "pow key", res.Endorsement.MasterValidation.ProofOfWorkKey)
+	assert.Len(t, res.Endorsement.Validations, 1)
+	assert.Equal(t, "validator pub", res.Endorsement.Validations[0].PublicKey)
+	assert.Equal(t, "sig", res.Signature)
+}
+
+/*
+Scenario: Get the keychain details
+	Given a stored ID and keychain with their endorsements
+	When I want to get the keychain details
+	Then I get the last keychain and its endorsement signed by the robot
+*/
+func TestGetKeychainDetails(t *testing.T) {
+	srvHandler := newAccountDetailsHandler()
+
+	res, err := srvHandler.GetKeychainDetails(context.TODO(), &api.AccountSearchRequest{
+		EncryptedIDHash: "enc id hash",
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "enc wallet", res.Data.EncryptedWallet)
+	assert.Equal(t, "last hash", res.Endorsement.LastTransactionHash)
+	assert.Equal(t, []string{"miner"}, res.Endorsement.MasterValidation.LastTransactionMiners)
+	assert.Equal(t, "sig", res.Signature)
+}
+
+/*
+Scenario: Get the proof of an account
+	Given a stored ID and keychain with their endorsements
+	When I want to get the account proof
+	Then I get the data and the endorsements with their signed validation pools without robot signature
+*/
+func TestGetAccountProof(t *testing.T) {
+	srvHandler := newAccountDetailsHandler()
+
+	res, err := srvHandler.GetAccountProof(context.TODO(), &api.AccountSearchRequest{
+		EncryptedIDHash: "enc id hash",
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "id pub", res.ID.PublicKey)
+	assert.Equal(t, "enc wallet", res.Keychain.EncryptedWallet)
+	assert.Equal(t, "hash", res.IDProof.Endorsement.TransactionHash)
+	assert.Equal(t, "validator pub", res.KeychainProof.Endorsement.Validations[0].PublicKey)
+	assert.Equal(t, []string{"validator pub"}, res.IDProof.Endorsement.MasterValidation.ValidationPool)
+	assert.Equal(t, []string{"validator pub"}, res.KeychainProof.Endorsement.MasterValidation.ValidationPool)
+}
+
+/*
+Scenario: Get the details of an unknown account
+	Given no ID stored
+	When I want to get the ID details
+	Then I get an account not exist error
+*/
+func TestGetIDDetailsUnknownAccount(t *testing.T) {
+	conf := system.UnirisConfig{}
+	conf.Services.Datamining.Errors.AccountNotExist = "Account doesn't exist"
+	db := mockstorage.NewDatabase()
+	extCli := mocktransport.NewExternalClient(db)
+	crypto := Crypto{
+		decrypter: mockcrypto.NewDecrypter(),
+		signer:    mockcrypto.NewSigner(),
+		hasher:    mockcrypto.NewHasher(),
+	}
+	srvHandler := NewInternalServerHandler(emlisting.NewService(db), nil, mocktransport.NewPoolRequester(extCli), nil, mocktransport.NewAIClient(), extCli, nil, crypto, conf)
+
+	_, err := srvHandler.GetIDDetails(context.TODO(), &api.AccountSearchRequest{
+		EncryptedIDHash: "enc id hash",
+	})
+	assert.Equal(t, "Account doesn't exist", err.Error())
+}
+
+func newAccountDetailsHandler() api.InternalServer {
+	crypto := Crypto{
+		decrypter: mockcrypto.NewDecrypter(),
+		signer:    mockcrypto.NewSigner(),
+		hasher:    mockcrypto.NewHasher(),
+	}
+	prop := datamining.NewProposal(
+		datamining.NewProposedKeyPair("enc pv key", "pub key", time.Time{}),
+	)
+	end := mining.NewEndorsement("last hash", "hash",
+		mining.NewMasterValidation([]string{"miner"}, "pow key", mining.NewValidation(mining.ValidationOK, time.Now(), "pow pub", "pow sig"), []string{"validator pub"}),
+		[]mining.Validation{mining.NewValidation(mining.ValidationOK, time.Now(), "validator pub", "validator sig")},
+	)
+
+	db := mockstorage.NewDatabase()
+	db.StoreID(
+		account.NewEndorsedID(
+			account.NewID("hash", "enc addr", "enc addr", "enc aes key", "id pub", prop, "id sig", "em sig"),
+			end,
+		),
+	)
+	db.StoreKeychain(
+		account.NewEndorsedKeychain(
+			"hash",
+			account.NewKeychain("enc addr", "enc wallet", "id pub", prop, "id sig", "em sig"),
+			end,
+		),
+	)
+
+	extCli := mocktransport.NewExternalClient(db)
+	return NewInternalServerHandler(emlisting.NewService(db), nil, mocktransport.NewPoolRequester(extCli), nil, mocktransport.NewAIClient(), extCli, nil, crypto, system.UnirisConfig{})
+}
+
 /*
 Scenario: Check if emitter is authorized
-	Given a emitter public key
+	Given a emitter public key authorized
 	When I want to check if it's authorized
-	Then I get no error
+	Then I get a positive status
 */
 func TestIsAuthorized(t *testing.T) {
 
-	conf := system.UnirisConfig{}
+	conf := system.UnirisConfig{Emitters: system.EmittersConfiguration{Authorities: []string{"authority"}}}
 	crypto := Crypto{
 		decrypter: mockcrypto.NewDecrypter(),
 		signer:    mockcrypto.NewSigner(),
@@ -147,18 +377,121 @@ func TestIsAuthorized(t *testing.T) {
 
 	db := mockstorage.NewDatabase()
 	emLister := emlisting.NewService(db)
+	emAdder := emadding.NewService(db, emLister, conf)
 	extCli := mocktransport.NewExternalClient(db)
 	poolR := mocktransport.NewPoolRequester(extCli)
 	aiCli := mocktransport.NewAIClient()
 
-	srvHandler := NewInternalServerHandler(emLister, poolR, nil, aiCli, extCli, crypto, conf)
+	srvHandler := NewInternalServerHandler(emLister, emAdder, poolR, nil, aiCli, extCli, nil, crypto, conf)
+
+	_, err := srvHandler.AuthorizeEmitter(context.TODO(), newEmitterAuthorizationRequest("pubkey", "nonce1", "sig"))
+	assert.Nil(t, err)
 
-	_, err := srvHandler.IsEmitterAuthorized(context.TODO(), &api.AuthorizationRequest{
+	res, err := srvHandler.IsEmitterAuthorized(context.TODO(), &api.AuthorizationRequest{
 		PublicKey: "pubkey",
 	})
+	assert.Nil(t, err)
+	assert.True(t, res.Status)
+}
+
+/*
+Scenario: Check if an unknown emitter is authorized
+	Given a emitter public key never authorized
+	When I want to check if it's authorized
+	Then I get a negative status
+*/
+func TestIsAuthorizedUnknownEmitter(t *testing.T) {
+	db := mockstorage.NewDatabase()
+	extCli := mocktransport.NewExternalClient(db)
+	srvHandler := NewInternalServerHandler(emlisting.NewService(db), nil, mocktransport.NewPoolRequester(extCli), nil, mocktransport.NewAIClient(), extCli, nil, Crypto{}, system.UnirisConfig{})
+
+	res, err := srvHandler.IsEmitterAuthorized(context.TODO(), &api.AuthorizationRequest{
+		PublicKey: "pubkey",
+	})
+	assert.Nil(t, err)
+	assert.False(t, res.Status)
+}
+
+/*
+Scenario: Check if a revoked emitter is authorized
+	Given a emitter public key authorized then revoked
+	When I want to check if it's authorized
+	Then I get a negative status
+*/
+func TestIsAuthorizedRevokedEmitter(t *testing.T) {
+	db := mockstorage.NewDatabase()
+	emLister := emlisting.NewService(db)
+	extCli := mocktransport.NewExternalClient(db)
+	conf := system.UnirisConfig{Emitters: system.EmittersConfiguration{Authorities: []string{"authority"}}}
+	srvHandler := NewInternalServerHandler(emLister, emadding.NewService(db, emLister, conf), mocktransport.NewPoolRequester(extCli), nil, mocktransport.NewAIClient(), extCli, nil, Crypto{signer: mockcrypto.NewSigner()}, conf)
+
+	_, err := srvHandler.AuthorizeEmitter(context.TODO(), newEmitterAuthorizationRequest("pubkey", "nonce2", "sig"))
+	assert.Nil(t, err)
+	_, err = srvHandler.RevokeEmitter(context.TODO(), newEmitterAuthorizationRequest("pubkey", "nonce3", "sig"))
+	assert.Nil(t, err)
+
+	res, err := srvHandler.IsEmitterAuthorized(context.TODO(), &api.AuthorizationRequest{
+		PublicKey: "pubkey",
+	})
+	assert.Nil(t, err)
+	assert.False(t, res.Status)
+}
+
+/*
+Scenario: Authorize an emitter without an authority signature
+	Given an authorization request not signed by an authority
+	When I want to authorize the emitter
+	Then I get an error and the emitter is not authorized
+*/
+func TestAuthorizeEmitterWithoutAuthority(t *testing.T) {
+	db := mockstorage.NewDatabase()
+	emLister := emlisting.NewService(db)
+	extCli := mocktransport.NewExternalClient(db)
+	conf := system.UnirisConfig{Emitters: system.EmittersConfiguration{Authorities: []string{"authority"}}}
+	srvHandler := NewInternalServerHandler(emLister, emadding.NewService(db, emLister, conf), mocktransport.NewPoolRequester(extCli), nil, mocktransport.NewAIClient(), extCli, nil, Crypto{signer: mockcrypto.NewSigner()}, conf)
+
+	_, err := srvHandler.AuthorizeEmitter(context.TODO(), newEmitterAuthorizationRequest("pubkey", "nonce4", "bad sig"))
+	assert.Equal(t, ErrUnauthorizedAuthority, err)
+	assert.Equal(t, emlisting.ErrUnauthorizedEmitter, emLister.IsEmitterAuthorized("pubkey"))
+
+	srvHandler = NewInternalServerHandler(emLister, emadding.NewService(db, emLister, system.UnirisConfig{}), mocktransport.NewPoolRequester(extCli), nil, mocktransport.NewAIClient(), extCli, nil, Crypto{signer: mockcrypto.NewSigner()}, system.UnirisConfig{})
+	_, err = srvHandler.AuthorizeEmitter(context.TODO(), newEmitterAuthorizationRequest("pubkey", "nonce5", "sig"))
+	assert.Equal(t, ErrUnauthorizedAuthority, err)
+}
+
+/*
+Scenario: Replay an emitter authorization
+	Given an emitter authorization signed by an authority already received
+	When I send the same request again
+	Then I get a replay error
+*/
+func TestAuthorizeEmitterReplayed(t *testing.T) {
+	db := mockstorage.NewDatabase()
+	emLister := emlisting.NewService(db)
+	extCli := mocktransport.NewExternalClient(db)
+	conf := system.UnirisConfig{Emitters: system.EmittersConfiguration{Authorities: []string{"authority"}}}
+	srvHandler := NewInternalServerHandler(emLister, emadding.NewService(db, emLister, conf), mocktransport.NewPoolRequester(extCli), nil, mocktransport.NewAIClient(), extCli, nil, Crypto{signer: mockcrypto.NewSigner()}, conf)
 
+	req := newEmitterAuthorizationRequest("pubkey", "nonce6", "sig")
+	_, err := srvHandler.AuthorizeEmitter(context.TODO(), req)
 	assert.Nil(t, err)
+	_, err = srvHandler.AuthorizeEmitter(context.TODO(), req)
+	assert.Equal(t, ErrReplayedRequest, err)
+
+	req = newEmitterAuthorizationRequest("pubkey", "nonce7", "sig")
+	req.Timestamp = time.Now().Add(-time.Hour).Unix()
+	_, err = srvHandler.RevokeEmitter(context.TODO(), req)
+	assert.Equal(t, ErrExpiredRequest, err)
+	assert.Nil(t, emLister.IsEmitterAuthorized("pubkey"))
+}
 
+func newEmitterAuthorizationRequest(pubKey string, nonce string, sig string) *api.EmitterAuthorizationRequest {
+	return &api.EmitterAuthorizationRequest{
+		PublicKey: pubKey,
+		Timestamp: time.Now().Unix(),
+		Nonce:     nonce,
+		Signature: sig,
+	}
 }
 
 /*
@@ -194,17 +527,110 @@ func TestGetSharedKeys(t *testing.T) {
 		EncryptedPrivateKey: "enc pv key",
 	})
 
-	srvHandler := NewInternalServerHandler(emLister, poolR, nil, aiCli, extCli, crypto, conf)
+	srvHandler := NewInternalServerHandler(emLister, nil, poolR, nil, aiCli, extCli, nil, crypto, conf)
 
 	res, err := srvHandler.GetSharedKeys(context.TODO(), &empty.Empty{})
 	assert.Nil(t, err)
 
-	assert.Equal(t, "pv key", res.RobotPrivateKey)
 	assert.Equal(t, "pub key", res.RobotPublicKey)
 	assert.Equal(t, "enc pv key", res.EmitterKeys[0].EncryptedPrivateKey)
 	assert.Equal(t, "pub key", res.EmitterKeys[0].PublicKey)
 }
 
+/*
+Scenario: Get shared keys after a rotation
+	Given a shared emitter key retired for a grace period and a new shared emitter key
+	When I want get the shared keys
+	Then I get the new key as current key and the retired key as previous key
+*/
+func TestGetSharedKeysAfterRotation(t *testing.T) {
+	crypto := Crypto{
+		decrypter: mockcrypto.NewDecrypter(),
+		signer:    mockcrypto.NewSigner(),
+		hasher:    mockcrypto.NewHasher(),
+	}
+
+	db := mockstorage.NewDatabase()
+	extCli := mocktransport.NewExternalClient(db)
+
+	db.StoreSharedEmitterKeyPair(emitter.SharedKeyPair{PublicKey: "old key"})
+	activation := time.Now().Add(-time.Minute)
+	expiration := time.Now().Add(time.Hour)
+	db.RetireSharedEmitterKeyPairs(activation, expiration)
+	db.StoreSharedEmitterKeyPair(emitter.SharedKeyPair{PublicKey: "new key", ActivationDate: activation})
+
+	srvHandler := NewInternalServerHandler(emlisting.NewService(db), nil, mocktransport.NewPoolRequester(extCli), nil, mocktransport.NewAIClient(), extCli, nil, crypto, system.UnirisConfig{})
+
+	res, err := srvHandler.GetSharedKeys(context.TODO(), &empty.Empty{})
+	assert.Nil(t, err)
+	assert.Len(t, res.EmitterKeys, 1)
+	assert.Equal(t, "new key", res.EmitterKeys[0].PublicKey)
+	assert.Len(t, res.PreviousEmitterKeys, 1)
+	assert.Equal(t, "old key", res.PreviousEmitterKeys[0].PublicKey)
+	assert.Equal(t, expiration.Unix(), res.PreviousEmitterKeys[0].ExpirationDate)
+}
+
+/*
+Scenario: Get shared keys with versioned robot keys
+	Given a robot key and a new version activated
+	When I want get the shared keys
+	Then I get the new version as current robot key and all the versions of the robot key
+*/
+func TestGetSharedKeysWithRobotVersions(t *testing.T) {
+	crypto := Crypto{
+		decrypter: mockcrypto.NewDecrypter(),
+		signer:    mockcrypto.NewSigner(),
+		hasher:    mockcrypto.NewHasher(),
+	}
+
+	db := mockstorage.NewDatabase()
+	extCli := mocktransport.NewExternalClient(db)
+	db.StoreSharedEmitterKeyPair(emitter.SharedKeyPair{PublicKey: "em key"})
+
+	activation := time.Now().Add(-time.Minute)
+	conf := system.UnirisConfig{
+		SharedKeys: system.SharedKeys{
+			Robot: system.KeyPair{PublicKey: "pub v0", PrivateKey: "pv v0"},
+			RobotVersions: []system.RobotKeyPair{
+				system.RobotKeyPair{Version: 1, PublicKey: "pub v1", PrivateKey: "pv v1", ActivationDate: activation},
+			},
+		},
+	}
+
+	srvHandler := NewInternalServerHandler(emlisting.NewService(db), nil, mocktransport.NewPoolRequester(extCli), nil, mocktransport.NewAIClient(), extCli, nil, crypto, conf)
+
+	res, err := srvHandler.GetSharedKeys(context.TODO(), &empty.Empty{})
+	assert.Nil(t, err)
+	assert.Equal(t, "pub v1", res.RobotPublicKey)
+	assert.Len(t, res.RobotKeys, 2)
+	assert.Equal(t, int32(1), res.RobotKeys[0].Version)
+	assert.Equal(t, activation.Unix(), res.RobotKeys[0].ActivationDate)
+	assert.Equal(t, "pub v0", res.RobotKeys[1].PublicKey)
+	assert.Equal(t, int64(0), res.RobotKeys[1].ActivationDate)
+}
+
+/*
+Scenario: Sign a payload for the API service
+	Given a payload built by the API service
+	When I want to sign it
+	Then I get the signature made with the robot key
+*/
+func TestSignPayload(t *testing.T) {
+	crypto := Crypto{
+		decrypter: mockcrypto.NewDecrypter(),
+		signer:    mockcrypto.NewSigner(),
+		hasher:    mockcrypto.NewHasher(),
+	}
+
+	db := mockstorage.NewDatabase()
+	extCli := mocktransport.NewExternalClient(db)
+	srvHandler := NewInternalServerHandler(emlisting.NewService(db), nil, mocktransport.NewPoolRequester(extCli), nil, mocktransport.NewAIClient(), extCli, nil, crypto, system.UnirisConfig{})
+
+	res, err := srvHandler.SignPayload(context.TODO(), &api.PayloadSignatureRequest{Payload: []byte("payload")})
+	assert.Nil(t, err)
+	assert.Equal(t, "sig", res.Signature)
+}
+
 /*
 Scenario: Get transaction status from a keychain transaction
 	Given a keychain transaction
@@ -227,7 +653,7 @@ func TestGetTransactionStatus(t *testing.T) {
 	poolF := mocktransport.NewPoolFinder()
 	aiCli := mocktransport.NewAIClient()
 
-	srvHandler := NewInternalServerHandler(nil, poolR, poolF, aiCli, extCli, crypto, conf)
+	srvHandler := NewInternalServerHandler(nil, nil, poolR, poolF, aiCli, extCli, nil, crypto, conf)
 	res, err := srvHandler.GetTransactionStatus(context.TODO(), &api.TransactionStatusRequest{
 		Address: "addr",
 		Hash:    "txHash",
@@ -235,3 +661,155 @@ func TestGetTransactionStatus(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, api.TransactionStatusResponse_Success, res.Status)
 }
+
+/*
+Scenario: Get the storage peers of an address
+	Given an encrypted address
+	When I want to get its storage peers
+	Then I get the peers of the storage pool
+*/
+func TestGetStoragePeers(t *testing.T) {
+
+	conf := system.UnirisConfig{}
+	crypto := Crypto{
+		decrypter: mockcrypto.NewDecrypter(),
+		signer:    mockcrypto.NewSigner(),
+		hasher:    mockcrypto.NewHasher(),
+	}
+
+	db := mockstorage.NewDatabase()
+
+	extCli := mocktransport.NewExternalClient(db)
+	poolR := mocktransport.NewPoolRequester(extCli)
+	poolF := mocktransport.NewPoolFinder()
+	aiCli := mocktransport.NewAIClient()
+
+	srvHandler := NewInternalServerHandler(nil, nil, poolR, poolF, aiCli, extCli, nil, crypto, conf)
+	res, err := srvHandler.GetStoragePeers(context.TODO(), &api.StoragePeersRequest{
+		Address: "addr",
+	})
+	assert.Nil(t, err)
+	assert.Len(t, res.Peers, 1)
+	assert.Equal(t, "127.0.0.1", res.Peers[0].IP)
+	assert.Equal(t, "key", res.Peers[0].PublicKey)
+}
+
+/*
+Scenario: Watch the transaction status from a keychain transaction
+	Given a keychain transaction
+	When I want to watch its status
+	Then I get the transitions streamed by the storage pool until the final status
+*/
+func TestWatchTransactionStatus(t *testing.T) {
+
+	conf := system.UnirisConfig{}
+	crypto := Crypto{
+		decrypter: mockcrypto.NewDecrypter(),
+		signer:    mockcrypto.NewSigner(),
+		hasher:    mockcrypto.NewHasher(),
+	}
+
+	db := mockstorage.NewDatabase()
+
+	extCli := mocktransport.NewExternalClient(db)
+	poolR := mocktransport.NewPoolRequester(extCli)
+	poolF := mocktransport.NewPoolFinder()
+	aiCli := mocktransport.NewAIClient()
+
+	srvHandler := NewInternalServerHandler(nil, nil, poolR, poolF, aiCli, extCli, nil, crypto, conf)
+
+	stream := &mockStatusStream{ctx: context.Background()}
+	err := srvHandler.WatchTransactionStatus(&api.TransactionStatusRequest{
+		Address: "addr",
+		Hash:    "txHash",
+	}, stream)
+	assert.Nil(t, err)
+	assert.Len(t, stream.statuses, 1)
+	assert.Equal(t, api.TransactionStatusResponse_Success, stream.statuses[0].Status)
+}
+
+/*
+Scenario: Watch the transaction status of a failed mining
+	Given a transaction whose mining failed on the master peer
+	When I want to watch its status
+	Then I get the failure streamed by the master peer
+*/
+func TestWatchTransactionStatusFailure(t *testing.T) {
+
+	conf := system.UnirisConfig{}
+	crypto := Crypto{
+		decrypter: mockcrypto.NewDecrypter(),
+		signer:    mockcrypto.NewSigner(),
+		hasher:    mockcrypto.NewHasher(),
+	}
+
+	db := mockstorage.NewDatabase()
+	mockExtCli := mocktransport.NewExternalClient(db)
+	extCli := mockFailedMiningClient{
+		ExternalClient: mockExtCli,
+		master:         "10.0.0.1",
+	}
+	poolR := mocktransport.NewPoolRequester(mockExtCli)
+	poolF := mocktransport.NewPoolFinder()
+	aiCli := mockMasterAIClient{
+		AIClient: mocktransport.NewAIClient(),
+		master:   datamining.Peer{IP: net.ParseIP("10.0.0.1")},
+	}
+
+	srvHandler := NewInternalServerHandler(nil, nil, poolR, poolF, aiCli, extCli, nil, crypto, conf)
+
+	stream := &mockStatusStream{ctx: context.Background()}
+	err := srvHandler.WatchTransactionStatus(&api.TransactionStatusRequest{
+		Address: "addr",
+		Hash:    "txHash",
+	}, stream)
+	assert.Nil(t, err)
+	assert.NotEmpty(t, stream.statuses)
+	assert.Equal(t, api.TransactionStatusResponse_Failure, stream.statuses[len(stream.statuses)-1].Status)
+}
+
+type mockMasterAIClient struct {
+	AIClient
+	master datamining.Peer
+}
+
+func (c mockMasterAIClient) GetMasterPeer(txHash string) (datamining.Peer, error) {
+	return c.master, nil
+}
+
+//mockFailedMiningClient streams a failure from the master peer while the storage peers never receive the transaction
+type mockFailedMiningClient struct {
+	ExternalClient
+	master string
+}
+
+func (c mockFailedMiningClient) WatchTransactionStatus(ctx context.Context, ip string, addr string, txHash string) (<-chan mining.TransactionStatus, error) {
+	statuses := make(chan mining.TransactionStatus, 2)
+	if ip == c.master {
+		statuses <- mining.TransactionPending
+		statuses <- mining.TransactionFailure
+	} else {
+		statuses <- mining.TransactionUnknown
+	}
+	close(statuses)
+	return statuses, nil
+}
+
+type mockStatusStream struct {
+	grpc.ServerStream
+	ctx      context.Context
+	statuses []*api.TransactionStatusResponse
+}
+
+func (s *mockStatusStream) Send(res *api.TransactionStatusResponse) error {
+	s.statuses = append(s.statuses, res)
+	return nil
+}
+
+func (s *mockStatusStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *mockStatusStream) RecvMsg(m interface{}) error {
+	return nil
+}
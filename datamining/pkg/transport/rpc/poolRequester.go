@@ -11,6 +11,7 @@ import (
 	"github.com/uniris/uniris-core/datamining/pkg/lock"
 	"github.com/uniris/uniris-core/datamining/pkg/mining"
 	"github.com/uniris/uniris-core/datamining/pkg/system"
+	"github.com/uniris/uniris-core/shared/pkg/errcode"
 )
 
 //PoolRequester define methods for pool requesting
@@ -71,7 +72,7 @@ func (pR poolR) RequestID(sPool datamining.Pool, idHash string) (account.Endorse
 	}
 
 	if len(ids) == 0 {
-		return nil, errors.New(pR.conf.Services.Datamining.Errors.AccountNotExist)
+		return nil, errcode.New(errcode.AccountNotExist, pR.conf.Services.Datamining.Errors.AccountNotExist)
 	}
 
 	//Checks the consistency of the retrieved results
@@ -125,7 +126,7 @@ func (pR poolR) RequestKeychain(sPool datamining.Pool, encAddress string) (accou
 	}
 
 	if len(keychains) == 0 {
-		return nil, errors.New(pR.conf.Services.Datamining.Errors.AccountNotExist)
+		return nil, errcode.New(errcode.AccountNotExist, pR.conf.Services.Datamining.Errors.AccountNotExist)
 	}
 
 	//Checks the consistency of the retrieved results
@@ -216,11 +217,12 @@ func (pR poolR) RequestValidations(minValids int, validPool datamining.Pool, txH
 	wg.Add(len(validPool.Peers()))
 
 	vChan := make(chan mining.Validation)
+	vPoolKeys := validPool.Peers().PublicKeys()
 
 	for _, p := range validPool.Peers() {
 		go func(p datamining.Peer) {
 			defer wg.Done()
-			v, err := pR.cli.RequestValidation(p.IP.String(), txType, txHash, data)
+			v, err := pR.cli.RequestValidation(p.IP.String(), txType, txHash, data, vPoolKeys)
 			if err != nil {
 				log.Printf("Unexpected error during validation requesting for the peer %s\n", p.IP.String())
 				log.Printf("Details: %s\n", err.Error())
@@ -35,7 +35,7 @@ func TestRequestID(t *testing.T) {
 	db := mockstorage.NewDatabase()
 
 	prop := datamining.NewProposal(
-		datamining.NewProposedKeyPair("enc pv key", "pub key"),
+		datamining.NewProposedKeyPair("enc pv key", "pub key", time.Time{}),
 	)
 
 	db.StoreID(
@@ -76,7 +76,7 @@ func TestRequestKeychain(t *testing.T) {
 	db := mockstorage.NewDatabase()
 
 	prop := datamining.NewProposal(
-		datamining.NewProposedKeyPair("enc pv key", "pub key"),
+		datamining.NewProposedKeyPair("enc pv key", "pub key", time.Time{}),
 	)
 
 	db.StoreKeychain(
@@ -205,7 +205,7 @@ func TestRequestValidations(t *testing.T) {
 		datamining.Peer{IP: net.ParseIP("127.0.0.1")})
 
 	prop := datamining.NewProposal(
-		datamining.NewProposedKeyPair("enc pv key", "pub key"),
+		datamining.NewProposedKeyPair("enc pv key", "pub key", time.Time{}),
 	)
 
 	keychain := account.NewKeychain("enc addr", "enc wallet", "id pub", prop, "id sig", "em sig")
@@ -240,7 +240,7 @@ func TestRequestValidationsWithLessThanMinimumValidations(t *testing.T) {
 		datamining.Peer{IP: net.ParseIP("127.0.0.1")})
 
 	prop := datamining.NewProposal(
-		datamining.NewProposedKeyPair("enc pv key", "pub key"),
+		datamining.NewProposedKeyPair("enc pv key", "pub key", time.Time{}),
 	)
 	keychain := account.NewKeychain("enc addr", "enc wallet", "id pub", prop, "id sig", "em sig")
 
@@ -267,11 +267,11 @@ func TestRequestStorage(t *testing.T) {
 	cli := mock.NewExternalClient(db)
 
 	prop := datamining.NewProposal(
-		datamining.NewProposedKeyPair("enc pv key", "pub key"),
+		datamining.NewProposedKeyPair("enc pv key", "pub key", time.Time{}),
 	)
 	keychain := account.NewKeychain("enc addr", "enc wallet", "id pub", prop, "id sig", "em sig")
 	end := mining.NewEndorsement("", "hash",
-		mining.NewMasterValidation([]string{""}, "key", mining.NewValidation(mining.ValidationOK, time.Now(), "pub", "sig")),
+		mining.NewMasterValidation([]string{""}, "key", mining.NewValidation(mining.ValidationOK, time.Now(), "pub", "sig"), []string{"validator key"}),
 		[]mining.Validation{mining.NewValidation(mining.ValidationOK, time.Now(), "pub", "sig")},
 	)
 
@@ -0,0 +1,240 @@
+package rpc
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"net"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	api "github.com/uniris/uniris-core/datamining/api/protobuf-spec"
+	"github.com/uniris/uniris-core/datamining/pkg/system"
+	"github.com/uniris/uniris-core/shared/pkg/nonce"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+//ErrMissingRequestEnvelope is returned when a request is not wrapped in a signed envelope
+var ErrMissingRequestEnvelope = errors.New("Missing request envelope")
+
+//ErrExpiredRequestEnvelope is returned when the timestamp of a request envelope is outside the freshness window
+var ErrExpiredRequestEnvelope = errors.New("Expired request envelope")
+
+//ErrReplayedRequest is returned when the nonce of a request envelope has already been received
+var ErrReplayedRequest = errors.New("Request already received")
+
+//ErrUnknownCaller is returned when the signer of a request envelope is not the peer known by the network
+var ErrUnknownCaller = errors.New("Unknown caller")
+
+//requestEnvelopeKey is the metadata key carrying the request envelope.
+//The "-bin" suffix lets GRPC transport the marshalled envelope as binary data
+const requestEnvelopeKey = "uniris-envelope-bin"
+
+//requestFreshness is the maximum clock drift accepted between the signature of a request and its reception
+const requestFreshness = 30 * time.Second
+
+//Caller identifies the peer which sent an authenticated request
+type Caller struct {
+	PublicKey string
+	IP        net.IP
+}
+
+type callerContextKey struct{}
+
+//CallerFromContext returns the caller authenticated by the request envelope
+func CallerFromContext(ctx context.Context) (Caller, bool) {
+	c, ok := ctx.Value(callerContextKey{}).(Caller)
+	return c, ok
+}
+
+//envelopeSigner wraps the outgoing requests in an envelope signed with the node keys
+type envelopeSigner struct {
+	signer Signer
+	pubKey string
+	pvKey  string
+}
+
+func newEnvelopeSigner(s Signer, conf system.UnirisConfig) envelopeSigner {
+	return envelopeSigner{
+		signer: s,
+		pubKey: conf.PublicKey,
+		pvKey:  conf.PrivateKey,
+	}
+}
+
+func (s envelopeSigner) intercept(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	ctx, err := s.sign(ctx, method, req)
+	if err != nil {
+		return err
+	}
+	return invoker(ctx, method, req, reply, cc, opts...)
+}
+
+//sign returns a context carrying the envelope of the request signed for the method
+//
+//The streams must be signed explicitly, as their request is not known when the stream is opened
+func (s envelopeSigner) sign(ctx context.Context, method string, req interface{}) (context.Context, error) {
+	nonce := make([]byte, 16)
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	env := &api.RequestEnvelope{
+		Method:    method,
+		Signer:    s.pubKey,
+		Timestamp: time.Now().Unix(),
+		Nonce:     hex.EncodeToString(nonce),
+	}
+	if err := s.signer.SignRequestEnvelope(env, req, s.pvKey); err != nil {
+		return nil, err
+	}
+
+	b, err := proto.Marshal(env)
+	if err != nil {
+		return nil, err
+	}
+
+	return metadata.AppendToOutgoingContext(ctx, requestEnvelopeKey, string(b)), nil
+}
+
+type requestAuthenticator struct {
+	signer   Signer
+	resolver PeerKeyResolver
+	nonces   nonce.Cache
+}
+
+//NewRequestAuthenticator creates a GRPC interceptor authenticating the requests of the External service
+//
+//Each request must be wrapped in an envelope signed by the peer known by the network at the caller address.
+//Envelopes outside the freshness window or with a nonce already received are rejected.
+//The authenticated caller is available to the handlers via CallerFromContext
+func NewRequestAuthenticator(crypto Crypto, resolver PeerKeyResolver) grpc.UnaryServerInterceptor {
+	a := requestAuthenticator{
+		signer:   crypto.signer,
+		resolver: resolver,
+		nonces:   nonce.NewCache(),
+	}
+	return a.intercept
+}
+
+//NewStreamRequestAuthenticator creates a GRPC interceptor authenticating the streams of the External service
+//
+//The envelope of a stream is verified against its first request, with the same rules as NewRequestAuthenticator
+func NewStreamRequestAuthenticator(crypto Crypto, resolver PeerKeyResolver) grpc.StreamServerInterceptor {
+	a := requestAuthenticator{
+		signer:   crypto.signer,
+		resolver: resolver,
+		nonces:   nonce.NewCache(),
+	}
+	return a.interceptStream
+}
+
+func (a requestAuthenticator) intercept(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	caller, err := a.authenticate(ctx, info.FullMethod, req)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	return handler(context.WithValue(ctx, callerContextKey{}, caller), req)
+}
+
+func (a requestAuthenticator) interceptStream(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	return handler(srv, &authenticatedStream{
+		ServerStream: ss,
+		auth:         a,
+		method:       info.FullMethod,
+	})
+}
+
+//authenticatedStream authenticates the first request received on a stream
+type authenticatedStream struct {
+	grpc.ServerStream
+	auth   requestAuthenticator
+	method string
+	ctx    context.Context
+}
+
+func (s *authenticatedStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	if s.ctx != nil {
+		return nil
+	}
+
+	caller, err := s.auth.authenticate(s.ServerStream.Context(), s.method, m)
+	if err != nil {
+		return status.Error(codes.Unauthenticated, err.Error())
+	}
+	s.ctx = context.WithValue(s.ServerStream.Context(), callerContextKey{}, caller)
+	return nil
+}
+
+func (s *authenticatedStream) Context() context.Context {
+	if s.ctx != nil {
+		return s.ctx
+	}
+	return s.ServerStream.Context()
+}
+
+func (a requestAuthenticator) authenticate(ctx context.Context, method string, req interface{}) (Caller, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get(requestEnvelopeKey)
+	if len(values) == 0 {
+		return Caller{}, ErrMissingRequestEnvelope
+	}
+
+	env := &api.RequestEnvelope{}
+	if err := proto.Unmarshal([]byte(values[0]), env); err != nil {
+		return Caller{}, ErrMissingRequestEnvelope
+	}
+
+	//The method is part of the signed payload, so a request cannot be replayed on another method
+	if env.Method != method {
+		return Caller{}, ErrInvalidSignature
+	}
+
+	signedAt := time.Unix(env.Timestamp, 0)
+	if drift := time.Since(signedAt); drift > requestFreshness || drift < -requestFreshness {
+		return Caller{}, ErrExpiredRequestEnvelope
+	}
+
+	ip, err := callerIP(ctx)
+	if err != nil {
+		return Caller{}, err
+	}
+	pubKey, err := a.resolver.PeerPublicKey(ip)
+	if err != nil || pubKey != env.Signer {
+		return Caller{}, ErrUnknownCaller
+	}
+
+	if err := a.signer.VerifyRequestEnvelopeSignature(env, req); err != nil {
+		return Caller{}, ErrInvalidSignature
+	}
+
+	//The nonce is kept until the envelope expires, as older envelopes are rejected by the freshness check
+	if !a.nonces.Add(env.Signer, env.Nonce, signedAt.Add(requestFreshness)) {
+		return Caller{}, ErrReplayedRequest
+	}
+
+	return Caller{
+		PublicKey: env.Signer,
+		IP:        ip,
+	}, nil
+}
+
+func callerIP(ctx context.Context) (net.IP, error) {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return nil, ErrUnknownCaller
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return nil, ErrUnknownCaller
+	}
+	return net.ParseIP(host), nil
+}
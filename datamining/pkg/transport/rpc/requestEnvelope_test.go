@@ -0,0 +1,186 @@
+package rpc
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	api "github.com/uniris/uniris-core/datamining/api/protobuf-spec"
+	mockcrypto "github.com/uniris/uniris-core/datamining/pkg/crypto/mock"
+	"github.com/uniris/uniris-core/datamining/pkg/system"
+	mocktransport "github.com/uniris/uniris-core/datamining/pkg/transport/mock"
+	"github.com/uniris/uniris-core/shared/pkg/nonce"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+const lockMethod = "/api.External/LockTransaction"
+
+/*
+Scenario: Authenticate a request wrapped in a signed envelope
+	Given a request signed by a peer known by the network
+	When the request is received
+	Then the handler is called with the caller identity
+*/
+func TestAuthenticateRequest(t *testing.T) {
+	md := signTestEnvelope(t, "node pub", &api.LockRequest{Address: "address"})
+
+	var caller Caller
+	_, err := authenticateTestRequest(md, "node pub", lockMethod, func(ctx context.Context, req interface{}) (interface{}, error) {
+		caller, _ = CallerFromContext(ctx)
+		return nil, nil
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "node pub", caller.PublicKey)
+	assert.Equal(t, "127.0.0.1", caller.IP.String())
+}
+
+/*
+Scenario: Reject a request without envelope
+	Given a request without envelope
+	When the request is received
+	Then the request is rejected
+*/
+func TestAuthenticateRequestWithoutEnvelope(t *testing.T) {
+	_, err := authenticateTestRequest(metadata.MD{}, "node pub", lockMethod, nil)
+	assertUnauthenticated(t, err, ErrMissingRequestEnvelope)
+}
+
+/*
+Scenario: Reject a replayed request
+	Given a signed request already received
+	When the same request is received again
+	Then the request is rejected
+*/
+func TestAuthenticateReplayedRequest(t *testing.T) {
+	md := signTestEnvelope(t, "node pub", &api.LockRequest{Address: "address"})
+
+	a := requestAuthenticator{
+		signer:   mockcrypto.NewSigner(),
+		resolver: mocktransport.NewPeerKeyResolver("node pub"),
+		nonces:   nonce.NewCache(),
+	}
+
+	_, err := a.authenticate(testIncomingContext(md), lockMethod, &api.LockRequest{Address: "address"})
+	assert.Nil(t, err)
+
+	_, err = a.authenticate(testIncomingContext(md), lockMethod, &api.LockRequest{Address: "address"})
+	assert.Equal(t, ErrReplayedRequest, err)
+}
+
+/*
+Scenario: Reject an expired request
+	Given a request signed before the freshness window
+	When the request is received
+	Then the request is rejected
+*/
+func TestAuthenticateExpiredRequest(t *testing.T) {
+	env := &api.RequestEnvelope{
+		Method:    lockMethod,
+		Signer:    "node pub",
+		Timestamp: time.Now().Add(-2 * requestFreshness).Unix(),
+		Nonce:     "nonce",
+		Signature: "sig",
+	}
+	b, _ := proto.Marshal(env)
+
+	_, err := authenticateTestRequest(metadata.Pairs(requestEnvelopeKey, string(b)), "node pub", lockMethod, nil)
+	assertUnauthenticated(t, err, ErrExpiredRequestEnvelope)
+}
+
+/*
+Scenario: Reject a request signed by another key than the peer known by the network
+	Given a request signed by an unknown key
+	When the request is received
+	Then the request is rejected
+*/
+func TestAuthenticateUnknownCaller(t *testing.T) {
+	md := signTestEnvelope(t, "other pub", &api.LockRequest{Address: "address"})
+
+	_, err := authenticateTestRequest(md, "node pub", lockMethod, nil)
+	assertUnauthenticated(t, err, ErrUnknownCaller)
+}
+
+/*
+Scenario: Reject a request sent to another method than the signed one
+	Given a request signed for a method
+	When the request is received on another method
+	Then the request is rejected
+*/
+func TestAuthenticateRequestOtherMethod(t *testing.T) {
+	md := signTestEnvelope(t, "node pub", &api.LockRequest{Address: "address"})
+
+	_, err := authenticateTestRequest(md, "node pub", "/api.External/UnlockTransaction", nil)
+	assertUnauthenticated(t, err, ErrInvalidSignature)
+}
+
+/*
+Scenario: Reject a request with an invalid signature
+	Given an envelope with a forged signature
+	When the request is received
+	Then the request is rejected
+*/
+func TestAuthenticateForgedRequest(t *testing.T) {
+	env := &api.RequestEnvelope{
+		Method:    lockMethod,
+		Signer:    "node pub",
+		Timestamp: time.Now().Unix(),
+		Nonce:     "nonce",
+		Signature: "forged",
+	}
+	b, _ := proto.Marshal(env)
+
+	_, err := authenticateTestRequest(metadata.Pairs(requestEnvelopeKey, string(b)), "node pub", lockMethod, nil)
+	assertUnauthenticated(t, err, ErrInvalidSignature)
+}
+
+/*
+Scenario: Reject a stream without envelope
+	Given a stream opened without envelope
+	When the first request is received
+	Then the stream is rejected
+*/
+func TestAuthenticateStreamWithoutEnvelope(t *testing.T) {
+	interceptor := NewStreamRequestAuthenticator(Crypto{signer: mockcrypto.NewSigner()}, mocktransport.NewPeerKeyResolver("node pub"))
+
+	ss := &mockStatusStream{ctx: testIncomingContext(metadata.MD{})}
+	err := interceptor(nil, ss, &grpc.StreamServerInfo{FullMethod: watchTransactionStatusMethod}, func(srv interface{}, stream grpc.ServerStream) error {
+		return stream.RecvMsg(&api.TransactionStatusRequest{})
+	})
+	assertUnauthenticated(t, err, ErrMissingRequestEnvelope)
+}
+
+//signTestEnvelope signs a request using the client interceptor and returns the metadata sent
+func signTestEnvelope(t *testing.T, pubKey string, req interface{}) metadata.MD {
+	s := newEnvelopeSigner(mockcrypto.NewSigner(), system.UnirisConfig{PublicKey: pubKey, PrivateKey: "pv"})
+
+	var md metadata.MD
+	err := s.intercept(context.Background(), lockMethod, req, nil, nil, func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		md, _ = metadata.FromOutgoingContext(ctx)
+		return nil
+	})
+	assert.Nil(t, err)
+	return md
+}
+
+func authenticateTestRequest(md metadata.MD, knownKey string, method string, handler grpc.UnaryHandler) (interface{}, error) {
+	interceptor := NewRequestAuthenticator(Crypto{signer: mockcrypto.NewSigner()}, mocktransport.NewPeerKeyResolver(knownKey))
+	return interceptor(testIncomingContext(md), &api.LockRequest{Address: "address"}, &grpc.UnaryServerInfo{FullMethod: method}, handler)
+}
+
+func testIncomingContext(md metadata.MD) context.Context {
+	ctx := metadata.NewIncomingContext(context.Background(), md)
+	return peer.NewContext(ctx, &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("127.0.0.1"), Port: 4000}})
+}
+
+func assertUnauthenticated(t *testing.T, err error, expected error) {
+	s, _ := status.FromError(err)
+	assert.Equal(t, codes.Unauthenticated, s.Code())
+	assert.Equal(t, expected.Error(), s.Message())
+}
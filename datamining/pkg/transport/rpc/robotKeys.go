@@ -0,0 +1,95 @@
+package rpc
+
+import (
+	"sync"
+	"time"
+
+	"github.com/uniris/uniris-core/datamining/pkg/account"
+	"github.com/uniris/uniris-core/datamining/pkg/system"
+)
+
+//robotKeys handles the versions of the shared robot keypair
+//
+//Data is signed with the current version, while signatures and ciphers are checked with the current version then with the previous ones
+type robotKeys struct {
+	keys system.SharedKeys
+}
+
+func (rk robotKeys) current() system.RobotKeyPair {
+	return rk.keys.CurrentRobotKeyPair(time.Now())
+}
+
+func (rk robotKeys) privateKey() string {
+	return rk.current().PrivateKey
+}
+
+func (rk robotKeys) publicKey() string {
+	return rk.current().PublicKey
+}
+
+func (rk robotKeys) verify(check func(pubKey string) error) error {
+	for _, kp := range rk.keys.ActiveRobotKeyPairs(time.Now()) {
+		if err := check(kp.PublicKey); err == nil {
+			return nil
+		}
+	}
+	return ErrInvalidSignature
+}
+
+func (rk robotKeys) decryptHash(d Decrypter, hash string) (string, error) {
+	for _, kp := range rk.keys.ActiveRobotKeyPairs(time.Now()) {
+		if clear, err := d.DecryptHash(hash, kp.PrivateKey); err == nil {
+			return clear, nil
+		}
+	}
+	return "", ErrInvalidEncryption
+}
+
+func (rk robotKeys) decryptKeychain(d Decrypter, data string) (account.Keychain, error) {
+	for _, kp := range rk.keys.ActiveRobotKeyPairs(time.Now()) {
+		if kc, err := d.DecryptKeychain(data, kp.PrivateKey); err == nil {
+			return kc, nil
+		}
+	}
+	return nil, ErrInvalidEncryption
+}
+
+func (rk robotKeys) decryptID(d Decrypter, data string) (account.ID, error) {
+	for _, kp := range rk.keys.ActiveRobotKeyPairs(time.Now()) {
+		if id, err := d.DecryptID(data, kp.PrivateKey); err == nil {
+			return id, nil
+		}
+	}
+	return nil, ErrInvalidEncryption
+}
+
+//forBatch resolves the active key pairs once for the decryption of a batch
+func (rk robotKeys) forBatch() *batchKeys {
+	return &batchKeys{pairs: rk.keys.ActiveRobotKeyPairs(time.Now())}
+}
+
+//batchKeys decrypts the data of a batch with the key pairs active when the batch started
+//
+//The data of a batch are usually encrypted with the same version, so the key pair which decrypted the previous data is tried first
+type batchKeys struct {
+	pairs []system.RobotKeyPair
+	mu    sync.Mutex
+	last  int
+}
+
+func (bk *batchKeys) decrypt(decrypt func(pvKey string) error) error {
+	bk.mu.Lock()
+	first := bk.last
+	bk.mu.Unlock()
+
+	for n := 0; n < len(bk.pairs); n++ {
+		i := (first + n) % len(bk.pairs)
+		if err := decrypt(bk.pairs[i].PrivateKey); err == nil {
+			bk.mu.Lock()
+			bk.last = i
+			bk.mu.Unlock()
+			return nil
+		}
+	}
+	return ErrInvalidEncryption
+}
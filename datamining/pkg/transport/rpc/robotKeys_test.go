@@ -0,0 +1,117 @@
+package rpc
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/uniris/uniris-core/datamining/pkg/account"
+	"github.com/uniris/uniris-core/datamining/pkg/system"
+)
+
+/*
+Scenario: Decrypt data encrypted with a previous robot key
+	Given a robot key rotated to a new version
+	When I decrypt a hash encrypted with the previous version
+	Then the previous version is used after the current one fails
+*/
+func TestDecryptWithPreviousRobotKey(t *testing.T) {
+	rk := robotKeys{rotatedRobotKeys(time.Now().Add(-time.Minute))}
+
+	clear, err := rk.decryptHash(mockKeyDecrypter{key: "pv v0"}, "enc hash")
+	assert.Nil(t, err)
+	assert.Equal(t, "hash", clear)
+	assert.Equal(t, "pv v1", rk.privateKey())
+}
+
+/*
+Scenario: Decrypt data encrypted with a robot key not activated yet
+	Given a new version of the robot key activated in the future
+	When I decrypt a hash encrypted with the new version
+	Then I get an error and the previous version is still used to sign
+*/
+func TestDecryptWithNotActivatedRobotKey(t *testing.T) {
+	rk := robotKeys{rotatedRobotKeys(time.Now().Add(time.Hour))}
+
+	_, err := rk.decryptHash(mockKeyDecrypter{key: "pv v1"}, "enc hash")
+	assert.Equal(t, ErrInvalidEncryption, err)
+	assert.Equal(t, "pv v0", rk.privateKey())
+}
+
+/*
+Scenario: Verify a signature made with a previous robot key
+	Given a robot key rotated to a new version
+	When I verify a signature made with the previous version
+	Then the signature is valid, and a signature made with an unknown key is not
+*/
+func TestVerifyWithPreviousRobotKey(t *testing.T) {
+	rk := robotKeys{rotatedRobotKeys(time.Now().Add(-time.Minute))}
+
+	checkWith := func(key string) func(pubKey string) error {
+		return func(pubKey string) error {
+			if pubKey != key {
+				return errors.New("Invalid signature")
+			}
+			return nil
+		}
+	}
+
+	assert.Nil(t, rk.verify(checkWith("pub v0")))
+	assert.Equal(t, ErrInvalidSignature, rk.verify(checkWith("other key")))
+}
+
+/*
+Scenario: Decrypt the data of a batch
+	Given a robot key rotated to a new version and a batch encrypted with the previous version
+	When I decrypt the data of the batch
+	Then the previous version is tried first once it decrypted a data
+*/
+func TestDecryptBatchWithPreviousRobotKey(t *testing.T) {
+	bk := robotKeys{rotatedRobotKeys(time.Now().Add(-time.Minute))}.forBatch()
+
+	tried := make([]string, 0)
+	decrypt := func(pvKey string) error {
+		tried = append(tried, pvKey)
+		if pvKey != "pv v0" {
+			return errors.New("Invalid key")
+		}
+		return nil
+	}
+
+	assert.Nil(t, bk.decrypt(decrypt))
+	assert.Nil(t, bk.decrypt(decrypt))
+	assert.Equal(t, []string{"pv v1", "pv v0", "pv v0"}, tried)
+
+	assert.Equal(t, ErrInvalidEncryption, bk.decrypt(func(pvKey string) error {
+		return errors.New("Invalid key")
+	}))
+}
+
+func rotatedRobotKeys(activation time.Time) system.SharedKeys {
+	return system.SharedKeys{
+		Robot: system.KeyPair{PublicKey: "pub v0", PrivateKey: "pv v0"},
+		RobotVersions: []system.RobotKeyPair{
+			system.RobotKeyPair{Version: 1, PublicKey: "pub v1", PrivateKey: "pv v1", ActivationDate: activation},
+		},
+	}
+}
+
+type mockKeyDecrypter struct {
+	key string
+}
+
+func (d mockKeyDecrypter) DecryptHash(hash string, pvKey string) (string, error) {
+	if pvKey != d.key {
+		return "", errors.New("Invalid key")
+	}
+	return "hash", nil
+}
+
+func (d mockKeyDecrypter) DecryptKeychain(data string, pvKey string) (account.Keychain, error) {
+	return nil, errors.New("Invalid key")
+}
+
+func (d mockKeyDecrypter) DecryptID(data string, pvKey string) (account.ID, error) {
+	return nil, errors.New("Invalid key")
+}
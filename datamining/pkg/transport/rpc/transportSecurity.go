@@ -0,0 +1,138 @@
+package rpc
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"net"
+
+	"github.com/uniris/uniris-core/datamining/pkg/system"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+//ErrMissingPeerCertificate is returned when a peer does not present a certificate
+var ErrMissingPeerCertificate = errors.New("Missing peer certificate")
+
+//Certifier defines methods to handle the certificates of the peers
+type Certifier interface {
+
+	//NewNodeCertificate creates a TLS certificate bound to the node public key
+	NewNodeCertificate(pubKey string, pvKey string) (tls.Certificate, error)
+
+	//VerifyCertificateKey checks if the certificate is bound to the given public key
+	VerifyCertificateKey(cert *x509.Certificate, pubKey string) error
+}
+
+//PeerKeyResolver defines methods to retrieve the public keys of the peers known by the network
+type PeerKeyResolver interface {
+
+	//PeerPublicKey returns the public key of the peer known by the gossip at the given IP
+	PeerPublicKey(ip net.IP) (string, error)
+}
+
+//TransportSecurity defines the security of the GRPC connections between the peers
+type TransportSecurity interface {
+
+	//ServerOptions returns the options to secure a GRPC server
+	ServerOptions() []grpc.ServerOption
+
+	//DialOption returns the option to secure a GRPC connection to the given peer
+	DialOption(ip string) grpc.DialOption
+
+	//Credentials identifies the credentials of the dial options, so the connections are not shared between credentials
+	Credentials() string
+}
+
+type insecureTransport struct{}
+
+type mutualTLSTransport struct {
+	cert      tls.Certificate
+	certifier Certifier
+	resolver  PeerKeyResolver
+}
+
+//NewTransportSecurity creates the security of the GRPC connections between the peers
+//
+//When the mutual TLS is enabled, each peer presents a certificate bound to its public key
+//and the certificate of the remote peer is verified against the public key known by the network
+func NewTransportSecurity(conf system.UnirisConfig, certifier Certifier, resolver PeerKeyResolver) (TransportSecurity, error) {
+	if !conf.Services.Datamining.MutualTLS {
+		return insecureTransport{}, nil
+	}
+
+	cert, err := certifier.NewNodeCertificate(conf.PublicKey, conf.PrivateKey)
+	if err != nil {
+		return nil, err
+	}
+	return mutualTLSTransport{cert, certifier, resolver}, nil
+}
+
+func (t insecureTransport) ServerOptions() []grpc.ServerOption {
+	return []grpc.ServerOption{}
+}
+
+func (t insecureTransport) DialOption(ip string) grpc.DialOption {
+	return grpc.WithInsecure()
+}
+
+func (t insecureTransport) Credentials() string {
+	return "insecure"
+}
+
+func (t mutualTLSTransport) ServerOptions() []grpc.ServerOption {
+	conf := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetConfigForClient: func(hello *tls.ClientHelloInfo) (*tls.Config, error) {
+			host, _, err := net.SplitHostPort(hello.Conn.RemoteAddr().String())
+			if err != nil {
+				return nil, err
+			}
+			return &tls.Config{
+				MinVersion:            tls.VersionTLS12,
+				Certificates:          []tls.Certificate{t.cert},
+				ClientAuth:            tls.RequireAnyClientCert,
+				VerifyPeerCertificate: t.verifyPeer(host),
+			}, nil
+		},
+	}
+	return []grpc.ServerOption{grpc.Creds(credentials.NewTLS(conf))}
+}
+
+func (t mutualTLSTransport) DialOption(ip string) grpc.DialOption {
+	conf := &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		Certificates: []tls.Certificate{t.cert},
+
+		//The certificates are not issued by an authority, but bound to the peer public key
+		InsecureSkipVerify:    true,
+		VerifyPeerCertificate: t.verifyPeer(ip),
+	}
+	return grpc.WithTransportCredentials(credentials.NewTLS(conf))
+}
+
+func (t mutualTLSTransport) Credentials() string {
+	return "mutual TLS"
+}
+
+//verifyPeer checks if the certificate presented by the peer is bound to its public key known by the network
+func (t mutualTLSTransport) verifyPeer(ip string) func([][]byte, [][]*x509.Certificate) error {
+	return func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+		if len(rawCerts) == 0 {
+			return ErrMissingPeerCertificate
+		}
+		cert, err := x509.ParseCertificate(rawCerts[0])
+		if err != nil {
+			return err
+		}
+		if err := cert.CheckSignature(cert.SignatureAlgorithm, cert.RawTBSCertificate, cert.Signature); err != nil {
+			return err
+		}
+
+		pubKey, err := t.resolver.PeerPublicKey(net.ParseIP(ip))
+		if err != nil {
+			return err
+		}
+		return t.certifier.VerifyCertificateKey(cert, pubKey)
+	}
+}
@@ -0,0 +1,103 @@
+package rpc
+
+import (
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/uniris/uniris-core/datamining/pkg/account"
+	accountListing "github.com/uniris/uniris-core/datamining/pkg/account/listing"
+	mockcrypto "github.com/uniris/uniris-core/datamining/pkg/crypto/mock"
+	"github.com/uniris/uniris-core/datamining/pkg/mining"
+	mockstorage "github.com/uniris/uniris-core/datamining/pkg/storage/mock"
+	"github.com/uniris/uniris-core/datamining/pkg/system"
+	mocktransport "github.com/uniris/uniris-core/datamining/pkg/transport/mock"
+
+	api "github.com/uniris/uniris-core/datamining/api/protobuf-spec"
+	datamining "github.com/uniris/uniris-core/datamining/pkg"
+	"google.golang.org/grpc"
+)
+
+/*
+Scenario: Call an External GRPC endpoint with mutual TLS
+	Given two peers presenting certificates bound to their public keys
+	When the client calls the server
+	Then the certificates are verified against the known public keys and the call succeeds
+*/
+func TestMutualTLSTransport(t *testing.T) {
+	cli := startMutualTLSServer(t, 2008)
+
+	id, err := cli.RequestID("127.0.0.1", "hash")
+	assert.Nil(t, err)
+	assert.Equal(t, "enc aes key", id.EncryptedAESKey())
+}
+
+/*
+Scenario: Reject a peer with a certificate which does not match its known public key
+	Given a client presenting a certificate bound to another key than the known one
+	When the client calls the server
+	Then the call is rejected
+*/
+func TestMutualTLSTransportUnknownKey(t *testing.T) {
+	startMutualTLSServer(t, 2009)
+
+	conf := mutualTLSConf("client pub", "client pv", 2009)
+
+	sec, err := NewTransportSecurity(conf, mockcrypto.NewCertifier(), mocktransport.NewPeerKeyResolver("other pub"))
+	assert.Nil(t, err)
+
+	cli := NewExternalClient(Crypto{decrypter: mockcrypto.NewDecrypter(), signer: mockcrypto.NewSigner()}, sec, newTestPool(t), conf)
+	_, err = cli.RequestID("127.0.0.1", "hash")
+	assert.NotNil(t, err)
+}
+
+func mutualTLSConf(pub string, pv string, port int) system.UnirisConfig {
+	return system.UnirisConfig{
+		PublicKey:  pub,
+		PrivateKey: pv,
+		Services: system.ServicesConfiguration{
+			Datamining: system.DataMiningConfiguration{
+				ExternalPort: port,
+				MutualTLS:    true,
+			},
+		},
+	}
+}
+
+//startMutualTLSServer starts an authenticated External server with mutual TLS and returns a client of the same node
+func startMutualTLSServer(t *testing.T, port int) ExternalClient {
+	conf := mutualTLSConf("node pub", "node pv", port)
+
+	resolver := mocktransport.NewPeerKeyResolver("node pub")
+	sec, err := NewTransportSecurity(conf, mockcrypto.NewCertifier(), resolver)
+	assert.Nil(t, err)
+
+	rpcCrypto := Crypto{
+		decrypter: mockcrypto.NewDecrypter(),
+		signer:    mockcrypto.NewSigner(),
+	}
+
+	db := mockstorage.NewDatabase()
+	db.StoreID(
+		account.NewEndorsedID(
+			account.NewID("hash", "enc addr", "enc addr", "enc aes key", "id pub",
+				datamining.NewProposal(datamining.NewProposedKeyPair("enc pv key", "pub key", time.Time{})), "id sig", "em sig"),
+			mining.NewEndorsement("", "hash",
+				mining.NewMasterValidation([]string{"hash"}, "key", mining.NewValidation(mining.ValidationOK, time.Now(), "pub", "sig"), []string{"validator key"}),
+				[]mining.Validation{mining.NewValidation(mining.ValidationOK, time.Now(), "pub", "sig")}),
+		),
+	)
+
+	opts := append(sec.ServerOptions(), grpc.UnaryInterceptor(NewRequestAuthenticator(rpcCrypto, resolver)))
+	grpcServer := grpc.NewServer(opts...)
+	lis, err := net.Listen("tcp", fmt.Sprintf("127.0.0.1:%d", port))
+	assert.Nil(t, err)
+
+	api.RegisterExternalServer(grpcServer, NewExternalServerHandler(Services{accLister: accountListing.NewService(db)}, rpcCrypto, conf))
+	go grpcServer.Serve(lis)
+	t.Cleanup(grpcServer.Stop)
+
+	return NewExternalClient(rpcCrypto, sec, newTestPool(t), conf)
+}
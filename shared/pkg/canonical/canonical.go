@@ -0,0 +1,111 @@
+package canonical
+
+import (
+	"bytes"
+	"encoding/binary"
+	"time"
+)
+
+//Canonical encoding
+//
+//Every signed or hashed payload is serialized with a deterministic binary encoding,
+//shared by the datamining, the API and the discovery services, instead of a JSON representation.
+//
+//A payload starts with the encoding version (1 byte) and its payload type (1 byte),
+//followed by its fields in the order of the protobuf field numbers:
+// - string: length as uint32 big endian, then the UTF-8 bytes
+// - integer: int64 big endian
+// - timestamp: unix seconds as int64 big endian
+// - status: 1 byte
+// - list: items count as uint32 big endian, then the items
+// - nested message: its fields without version nor payload type
+// - nested payload: length as uint32 big endian, then the encoded payload
+//
+//The optional fields are written after the other ones and only when they are set,
+//so the payloads without them keep their encoding.
+//
+//The signatures are written after the data they sign.
+
+//Version is the version of the canonical encoding written at the start of each payload
+const Version byte = 1
+
+//Encoder defines methods to write the fields of a payload with the canonical encoding
+type Encoder interface {
+
+	//WriteLength writes the length of a string, a list or a nested payload
+	WriteLength(l int)
+
+	//WriteString writes a string prefixed by its length
+	WriteString(s string)
+
+	//WriteStrings writes a list of strings prefixed by its items count
+	WriteStrings(ss []string)
+
+	//WriteInt writes an integer
+	WriteInt(i int64)
+
+	//WriteTimestamp writes a time as unix seconds
+	WriteTimestamp(t time.Time)
+
+	//WriteStatus writes a status on a single byte
+	WriteStatus(s byte)
+
+	//WritePayload writes an encoded payload prefixed by its length
+	WritePayload(p []byte)
+
+	//Bytes returns the encoded payload
+	Bytes() []byte
+}
+
+type encoder struct {
+	buf bytes.Buffer
+}
+
+//NewEncoder creates an encoder starting a payload of the type
+func NewEncoder(t PayloadType) Encoder {
+	e := &encoder{}
+	e.buf.WriteByte(Version)
+	e.buf.WriteByte(byte(t))
+	return e
+}
+
+func (e *encoder) Bytes() []byte {
+	return e.buf.Bytes()
+}
+
+func (e *encoder) WriteLength(l int) {
+	b := make([]byte, 4)
+	binary.BigEndian.PutUint32(b, uint32(l))
+	e.buf.Write(b)
+}
+
+func (e *encoder) WriteString(s string) {
+	e.WriteLength(len(s))
+	e.buf.WriteString(s)
+}
+
+func (e *encoder) WriteStrings(ss []string) {
+	e.WriteLength(len(ss))
+	for _, s := range ss {
+		e.WriteString(s)
+	}
+}
+
+func (e *encoder) WriteInt(i int64) {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, uint64(i))
+	e.buf.Write(b)
+}
+
+func (e *encoder) WriteTimestamp(t time.Time) {
+	e.WriteInt(t.Unix())
+}
+
+func (e *encoder) WriteStatus(s byte) {
+	e.buf.WriteByte(s)
+}
+
+func (e *encoder) WritePayload(p []byte) {
+	e.WriteLength(len(p))
+	e.buf.Write(p)
+}
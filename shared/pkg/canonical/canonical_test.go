@@ -0,0 +1,53 @@
+package canonical
+
+import (
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+/*
+Scenario: Encode the fields of a payload
+	Given an encoder of a lock payload
+	When I write each kind of field
+	Then I get the test vector starting with the version and the payload type
+*/
+func TestEncoderVector(t *testing.T) {
+	e := NewEncoder(LockPayload)
+	e.WriteString("abc")
+	e.WriteStrings([]string{"a"})
+	e.WriteInt(-1)
+	e.WriteTimestamp(time.Unix(1, 0))
+	e.WriteStatus(2)
+	e.WritePayload([]byte{0xff})
+
+	assert.Equal(t, "0108"+
+		"00000003616263"+
+		"00000001"+"0000000161"+
+		"ffffffffffffffff"+
+		"0000000000000001"+
+		"02"+
+		"00000001ff", hex.EncodeToString(e.Bytes()))
+}
+
+/*
+Scenario: Identify the type of a payload
+	Given encoded payloads
+	When I get their type
+	Then I get the registered type, and false for an unknown version or type
+*/
+func TestTypeOf(t *testing.T) {
+	pt, ok := TypeOf(NewEncoder(TransactionCallbackPayload).Bytes())
+	assert.True(t, ok)
+	assert.Equal(t, TransactionCallbackPayload, pt)
+	assert.Equal(t, "transaction callback", pt.String())
+
+	_, ok = TypeOf([]byte{Version, 0xff})
+	assert.False(t, ok)
+	_, ok = TypeOf([]byte{Version + 1, byte(LockPayload)})
+	assert.False(t, ok)
+	_, ok = TypeOf([]byte{Version})
+	assert.False(t, ok)
+}
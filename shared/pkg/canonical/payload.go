@@ -0,0 +1,132 @@
+package canonical
+
+import "fmt"
+
+//PayloadType identifies an encoded payload, so a signature cannot be reused for another kind of payload
+//
+//The types are registered once for all the services: a payload signed by a service
+//and verified by another one uses the same type on both sides
+type PayloadType byte
+
+//Payloads of the datamining service
+const (
+	IDPayload                        PayloadType = 1
+	IDDataPayload                    PayloadType = 2
+	KeychainPayload                  PayloadType = 3
+	KeychainDataPayload              PayloadType = 4
+	ValidationDataPayload            PayloadType = 5
+	EndorsedIDPayload                PayloadType = 6
+	EndorsedKeychainPayload          PayloadType = 7
+	LockPayload                      PayloadType = 8
+	KeychainLeadRequestPayload       PayloadType = 9
+	IDLeadRequestPayload             PayloadType = 10
+	KeychainValidationRequestPayload PayloadType = 11
+	IDValidationRequestPayload       PayloadType = 12
+	KeychainStorageRequestPayload    PayloadType = 13
+	IDStorageRequestPayload          PayloadType = 14
+	ValidationResponsePayload        PayloadType = 15
+	LockAckPayload                   PayloadType = 16
+	StorageAckPayload                PayloadType = 17
+
+	//Responses signed by the datamining service and verified by the API service
+	KeychainResponsePayload    PayloadType = 18
+	IDResponsePayload          PayloadType = 19
+	AccountSearchResultPayload PayloadType = 20
+	CreationResultPayload      PayloadType = 21
+
+	//Requests of the External service, signed within an envelope
+	IDRequestPayload                PayloadType = 24
+	KeychainRequestPayload          PayloadType = 25
+	TransactionStatusRequestPayload PayloadType = 26
+	RequestEnvelopePayload          PayloadType = 27
+
+	//Requests of the Internal service signed by the ID key
+	KeychainUpdateRequestPayload PayloadType = 32
+
+	//Requests of the Internal service signed by an authority key
+	EmitterAuthorizationRequestPayload PayloadType = 33
+	EmitterRevocationRequestPayload    PayloadType = 34
+)
+
+//Payloads of the API service
+const (
+
+	//Requests signed by the emitters
+	AccountCreationRequestPayload PayloadType = 22
+	AccountRequestPayload         PayloadType = 28
+	SharedKeysRequestPayload      PayloadType = 29
+	WebhookRegistrationPayload    PayloadType = 30
+	AccountCreationEmitterPayload PayloadType = 37
+
+	//Payloads built by the API service and signed remotely by the datamining service with the shared robot key
+	AccountCreationResultPayload PayloadType = 23
+	TransactionCallbackPayload   PayloadType = 31
+)
+
+//Payloads of the discovery service, signed with the node key also used by the datamining service
+const (
+	PeerDigestPayload PayloadType = 35
+	PeerStatePayload  PayloadType = 36
+)
+
+//payloadNames registers the payload types: a type registered twice does not compile
+var payloadNames = map[PayloadType]string{
+	IDPayload:                          "id",
+	IDDataPayload:                      "id data",
+	KeychainPayload:                    "keychain",
+	KeychainDataPayload:                "keychain data",
+	ValidationDataPayload:              "validation data",
+	EndorsedIDPayload:                  "endorsed id",
+	EndorsedKeychainPayload:            "endorsed keychain",
+	LockPayload:                        "lock",
+	KeychainLeadRequestPayload:         "keychain lead request",
+	IDLeadRequestPayload:               "id lead request",
+	KeychainValidationRequestPayload:   "keychain validation request",
+	IDValidationRequestPayload:         "id validation request",
+	KeychainStorageRequestPayload:      "keychain storage request",
+	IDStorageRequestPayload:            "id storage request",
+	ValidationResponsePayload:          "validation response",
+	LockAckPayload:                     "lock ack",
+	StorageAckPayload:                  "storage ack",
+	KeychainResponsePayload:            "keychain response",
+	IDResponsePayload:                  "id response",
+	AccountSearchResultPayload:         "account search result",
+	CreationResultPayload:              "creation result",
+	AccountCreationRequestPayload:      "account creation request",
+	AccountCreationResultPayload:       "account creation result",
+	IDRequestPayload:                   "id request",
+	KeychainRequestPayload:             "keychain request",
+	TransactionStatusRequestPayload:    "transaction status request",
+	RequestEnvelopePayload:             "request envelope",
+	AccountRequestPayload:              "account request",
+	SharedKeysRequestPayload:           "shared keys request",
+	WebhookRegistrationPayload:         "webhook registration",
+	TransactionCallbackPayload:         "transaction callback",
+	KeychainUpdateRequestPayload:       "keychain update request",
+	EmitterAuthorizationRequestPayload: "emitter authorization request",
+	EmitterRevocationRequestPayload:    "emitter revocation request",
+	PeerDigestPayload:                  "peer digest",
+	PeerStatePayload:                   "peer state",
+	AccountCreationEmitterPayload:      "account creation emitter",
+}
+
+func (t PayloadType) String() string {
+	if name, exist := payloadNames[t]; exist {
+		return name
+	}
+	return fmt.Sprintf("unknown payload %d", byte(t))
+}
+
+//TypeOf returns the type of an encoded payload
+//
+//It returns false when the payload is not encoded with the current version or its type is not registered
+func TypeOf(payload []byte) (PayloadType, bool) {
+	if len(payload) < 2 || payload[0] != Version {
+		return 0, false
+	}
+	t := PayloadType(payload[1])
+	if _, exist := payloadNames[t]; !exist {
+		return 0, false
+	}
+	return t, true
+}
@@ -0,0 +1,239 @@
+package connpool
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+)
+
+//ErrPoolExhausted is returned when no call slot is released for an endpoint before the acquire timeout
+var ErrPoolExhausted = errors.New("Too many concurrent calls to the endpoint")
+
+//ErrPoolClosed is returned when a connection is requested from a closed pool
+var ErrPoolClosed = errors.New("Connection pool closed")
+
+const (
+
+	//DefaultMaxCalls is the default number of concurrent calls allowed per endpoint
+	DefaultMaxCalls = 32
+
+	//DefaultIdleTimeout is the default duration after which an unused connection is closed
+	DefaultIdleTimeout = 2 * time.Minute
+
+	//defaultAcquireTimeout is the maximum duration a call waits for a slot on a busy endpoint
+	defaultAcquireTimeout = 5 * time.Second
+)
+
+//Pool shares the GRPC connections to the endpoints between the calls
+type Pool interface {
+
+	//Get returns a connection to the endpoint and the function to release it once the call is done
+	//
+	//The credentials identify the security of the dial options, such as the public key expected from the peer:
+	//the calls share a connection only when they use the same endpoint and credentials.
+	//The connection is dialed with the given options when the endpoint is not connected yet or when its connection has been shut down.
+	//Get waits while the endpoint has reached the maximum number of concurrent calls
+	Get(endpoint string, credentials string, opts ...grpc.DialOption) (*grpc.ClientConn, func(), error)
+
+	//GetStream returns a connection to the endpoint for a long lived stream and the function to release it once the stream is done
+	//
+	//Unlike Get, the streams are not bounded by the concurrent calls limit, so they cannot starve the calls of the endpoint.
+	//The connection is not evicted until the stream is released
+	GetStream(endpoint string, credentials string, opts ...grpc.DialOption) (*grpc.ClientConn, func(), error)
+
+	//Close closes all the connections of the pool
+	Close()
+}
+
+//connKey identifies the connections shared between the calls
+type connKey struct {
+	endpoint    string
+	credentials string
+}
+
+type endpointConn struct {
+	conn     *grpc.ClientConn
+	slots    chan struct{}
+	refs     int
+	lastUsed time.Time
+}
+
+type pool struct {
+	mu             sync.Mutex
+	conns          map[connKey]*endpointConn
+	maxCalls       int
+	idleTimeout    time.Duration
+	acquireTimeout time.Duration
+	closed         bool
+	stop           chan struct{}
+}
+
+//NewPool creates a connection pool allowing maxCalls concurrent calls per endpoint
+//and closing the connections unused for the idle timeout
+func NewPool(maxCalls int, idleTimeout time.Duration) Pool {
+	p := &pool{
+		conns:          make(map[connKey]*endpointConn),
+		maxCalls:       maxCalls,
+		idleTimeout:    idleTimeout,
+		acquireTimeout: defaultAcquireTimeout,
+		stop:           make(chan struct{}),
+	}
+	go p.evictIdleConns()
+	return p
+}
+
+func (p *pool) Get(endpoint string, credentials string, opts ...grpc.DialOption) (*grpc.ClientConn, func(), error) {
+	e, err := p.retain(connKey{endpoint, credentials})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	timer := time.NewTimer(p.acquireTimeout)
+	defer timer.Stop()
+
+	select {
+	case e.slots <- struct{}{}:
+	case <-timer.C:
+		p.unretain(e)
+		return nil, nil, ErrPoolExhausted
+	}
+
+	conn, err := p.connect(e, endpoint, opts)
+	if err != nil {
+		<-e.slots
+		p.unretain(e)
+		return nil, nil, err
+	}
+
+	var once sync.Once
+	release := func() {
+		once.Do(func() {
+			<-e.slots
+			p.unretain(e)
+		})
+	}
+	return conn, release, nil
+}
+
+func (p *pool) GetStream(endpoint string, credentials string, opts ...grpc.DialOption) (*grpc.ClientConn, func(), error) {
+	e, err := p.retain(connKey{endpoint, credentials})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	conn, err := p.connect(e, endpoint, opts)
+	if err != nil {
+		p.unretain(e)
+		return nil, nil, err
+	}
+
+	var once sync.Once
+	release := func() {
+		once.Do(func() {
+			p.unretain(e)
+		})
+	}
+	return conn, release, nil
+}
+
+//retain returns the endpoint entry and prevents its eviction until it is unretained
+func (p *pool) retain(key connKey) (*endpointConn, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.closed {
+		return nil, ErrPoolClosed
+	}
+
+	e, exist := p.conns[key]
+	if !exist {
+		e = &endpointConn{
+			slots: make(chan struct{}, p.maxCalls),
+		}
+		p.conns[key] = e
+	}
+	e.refs++
+	return e, nil
+}
+
+func (p *pool) unretain(e *endpointConn) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	e.refs--
+	e.lastUsed = time.Now()
+}
+
+//connect returns the connection of the endpoint, dialing it again if the previous one has been shut down
+//
+//A connection in transient failure is still held by other calls: GRPC reconnects it by itself, so it is not closed
+func (p *pool) connect(e *endpointConn, endpoint string, opts []grpc.DialOption) (*grpc.ClientConn, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.closed {
+		return nil, ErrPoolClosed
+	}
+
+	if e.conn != nil && e.conn.GetState() != connectivity.Shutdown {
+		return e.conn, nil
+	}
+
+	//The dial is not blocking, so the lock is not held during the connection handshake
+	conn, err := grpc.Dial(endpoint, opts...)
+	if err != nil {
+		return nil, err
+	}
+	e.conn = conn
+	return conn, nil
+}
+
+func (p *pool) evictIdleConns() {
+	ticker := time.NewTicker(p.idleTimeout)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-p.stop:
+			return
+		case now := <-ticker.C:
+			p.evict(now)
+		}
+	}
+}
+
+//evict closes the connections without pending calls and unused since the idle timeout
+func (p *pool) evict(now time.Time) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for key, e := range p.conns {
+		if e.refs == 0 && now.Sub(e.lastUsed) >= p.idleTimeout {
+			if e.conn != nil {
+				e.conn.Close()
+			}
+			delete(p.conns, key)
+		}
+	}
+}
+
+func (p *pool) Close() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.closed {
+		return
+	}
+	p.closed = true
+	close(p.stop)
+
+	for key, e := range p.conns {
+		if e.conn != nil {
+			e.conn.Close()
+		}
+		delete(p.conns, key)
+	}
+}
@@ -0,0 +1,203 @@
+package connpool
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+)
+
+/*
+Scenario: Share the connection of an endpoint
+	Given a connection retrieved for an endpoint and released
+	When a connection is requested again for the same endpoint
+	Then the same connection is returned
+*/
+func TestReuseConnection(t *testing.T) {
+	p := newTestPool(2)
+	defer p.Close()
+
+	conn, release, err := p.Get("127.0.0.1:4000", "insecure", grpc.WithInsecure())
+	assert.Nil(t, err)
+	release()
+
+	conn2, release2, err := p.Get("127.0.0.1:4000", "insecure", grpc.WithInsecure())
+	assert.Nil(t, err)
+	defer release2()
+	assert.True(t, conn == conn2)
+
+	conn3, release3, err := p.Get("127.0.0.1:4001", "insecure", grpc.WithInsecure())
+	assert.Nil(t, err)
+	defer release3()
+	assert.False(t, conn == conn3)
+}
+
+/*
+Scenario: Separate the connections of an endpoint dialed with other credentials
+	Given a connection retrieved for an endpoint with credentials
+	When a connection is requested for the same endpoint with other credentials
+	Then another connection is returned
+*/
+func TestSeparateConnectionsByCredentials(t *testing.T) {
+	p := newTestPool(2)
+	defer p.Close()
+
+	conn, release, err := p.Get("127.0.0.1:4000", "peer key", grpc.WithInsecure())
+	assert.Nil(t, err)
+	defer release()
+
+	conn2, release2, err := p.Get("127.0.0.1:4000", "other peer key", grpc.WithInsecure())
+	assert.Nil(t, err)
+	defer release2()
+	assert.False(t, conn == conn2)
+	assert.Len(t, p.conns, 2)
+}
+
+/*
+Scenario: Keep a connection in transient failure shared
+	Given a connection to an unreachable endpoint held by a call
+	When another call requests a connection to the endpoint
+	Then the same connection is returned and not closed, so GRPC reconnects it
+*/
+func TestKeepFailedConnection(t *testing.T) {
+	p := newTestPool(2)
+	defer p.Close()
+
+	conn, release, err := p.Get("127.0.0.1:1", "insecure", grpc.WithInsecure())
+	assert.Nil(t, err)
+	defer release()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	for conn.GetState() != connectivity.TransientFailure && conn.WaitForStateChange(ctx, conn.GetState()) {
+	}
+
+	conn2, release2, err := p.Get("127.0.0.1:1", "insecure", grpc.WithInsecure())
+	assert.Nil(t, err)
+	defer release2()
+	assert.True(t, conn == conn2)
+	assert.NotEqual(t, connectivity.Shutdown, conn.GetState())
+}
+
+/*
+Scenario: Bound the concurrent calls to an endpoint
+	Given an endpoint with all its call slots taken
+	When a connection is requested for the endpoint
+	Then an error is returned until a slot is released
+*/
+func TestBoundConcurrentCalls(t *testing.T) {
+	p := newTestPool(1)
+	defer p.Close()
+
+	_, release, err := p.Get("127.0.0.1:4000", "insecure", grpc.WithInsecure())
+	assert.Nil(t, err)
+
+	_, _, err = p.Get("127.0.0.1:4000", "insecure", grpc.WithInsecure())
+	assert.Equal(t, ErrPoolExhausted, err)
+
+	release()
+	release()
+
+	_, release, err = p.Get("127.0.0.1:4000", "insecure", grpc.WithInsecure())
+	assert.Nil(t, err)
+	release()
+}
+
+/*
+Scenario: Open streams beyond the concurrent calls limit
+	Given an endpoint with all its call slots taken
+	When a connection is requested for a stream
+	Then the connection is returned and kept until the stream is released
+*/
+func TestGetStream(t *testing.T) {
+	p := newTestPool(1)
+	defer p.Close()
+
+	conn, release, err := p.Get("127.0.0.1:4000", "insecure", grpc.WithInsecure())
+	assert.Nil(t, err)
+	release()
+
+	_, release, err = p.Get("127.0.0.1:4000", "insecure", grpc.WithInsecure())
+	assert.Nil(t, err)
+
+	streamConn, releaseStream, err := p.GetStream("127.0.0.1:4000", "insecure", grpc.WithInsecure())
+	assert.Nil(t, err)
+	assert.True(t, conn == streamConn)
+	release()
+
+	p.evict(time.Now().Add(time.Hour))
+	assert.Len(t, p.conns, 1)
+
+	releaseStream()
+	p.evict(time.Now().Add(time.Hour))
+	assert.Empty(t, p.conns)
+}
+
+/*
+Scenario: Evict the idle connections
+	Given a connection released and another one still used
+	When the idle timeout is reached
+	Then only the released connection is closed
+*/
+func TestEvictIdleConnections(t *testing.T) {
+	p := newTestPool(2)
+	defer p.Close()
+
+	idle, release, err := p.Get("127.0.0.1:4000", "insecure", grpc.WithInsecure())
+	assert.Nil(t, err)
+	release()
+
+	used, releaseUsed, err := p.Get("127.0.0.1:4001", "insecure", grpc.WithInsecure())
+	assert.Nil(t, err)
+	defer releaseUsed()
+
+	p.evict(time.Now().Add(time.Hour))
+
+	assert.Len(t, p.conns, 1)
+	assert.Equal(t, "SHUTDOWN", idle.GetState().String())
+	assert.NotEqual(t, "SHUTDOWN", used.GetState().String())
+}
+
+/*
+Scenario: Reconnect an endpoint when its connection is closed
+	Given a connection shutdown
+	When a connection is requested for the endpoint
+	Then a new connection is dialed
+*/
+func TestReconnectFailedConnection(t *testing.T) {
+	p := newTestPool(2)
+	defer p.Close()
+
+	conn, release, err := p.Get("127.0.0.1:4000", "insecure", grpc.WithInsecure())
+	assert.Nil(t, err)
+	release()
+	conn.Close()
+
+	conn2, release, err := p.Get("127.0.0.1:4000", "insecure", grpc.WithInsecure())
+	assert.Nil(t, err)
+	release()
+	assert.False(t, conn == conn2)
+}
+
+/*
+Scenario: Refuse the connections of a closed pool
+	Given a closed pool
+	When a connection is requested
+	Then an error is returned
+*/
+func TestGetFromClosedPool(t *testing.T) {
+	p := newTestPool(2)
+	p.Close()
+
+	_, _, err := p.Get("127.0.0.1:4000", "insecure", grpc.WithInsecure())
+	assert.Equal(t, ErrPoolClosed, err)
+}
+
+func newTestPool(maxCalls int) *pool {
+	p := NewPool(maxCalls, time.Minute).(*pool)
+	p.acquireTimeout = 10 * time.Millisecond
+	return p
+}
@@ -0,0 +1,143 @@
+package errcode
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+//Code represents a stable machine-readable error code shared by the API and the datamining services
+//
+//The catalogue holds the codes of both services, as the errors of the datamining services are forwarded by the API
+type Code string
+
+const (
+
+	//InvalidRequest is returned when a request is malformed
+	InvalidRequest Code = "invalid_request"
+
+	//InvalidSignature is returned when the signature of a request does not match its data
+	InvalidSignature Code = "invalid_signature"
+
+	//ExpiredRequest is returned when a signed request is outside the freshness window
+	ExpiredRequest Code = "expired_request"
+
+	//ReplayedRequest is returned when a signed request has already been received
+	ReplayedRequest Code = "replayed_request"
+
+	//Unauthorized is returned when the emitter is unknown or revoked
+	Unauthorized Code = "unauthorized"
+
+	//AccountNotExist is returned when no account is related to the encrypted ID hash
+	AccountNotExist Code = "account_not_exist"
+
+	//KeychainNotOwned is returned when a keychain update does not target the address of the account
+	KeychainNotOwned Code = "keychain_not_owned"
+
+	//RequestInProgress is returned when a request with the same idempotency key is still processed
+	RequestInProgress Code = "request_in_progress"
+
+	//IdempotencyKeyReused is returned when an idempotency key is sent again with another request
+	IdempotencyKeyReused Code = "idempotency_key_reused"
+
+	//RateLimited is returned when a client or an emitter sent more requests than allowed
+	RateLimited Code = "rate_limited"
+
+	//Unavailable is returned when a peer cannot be reached
+	Unavailable Code = "unavailable"
+
+	//Internal is returned for the unexpected errors
+	Internal Code = "internal_error"
+)
+
+type mapping struct {
+	grpc codes.Code
+	http int
+}
+
+var catalogue = map[Code]mapping{
+	InvalidRequest:       {codes.InvalidArgument, http.StatusBadRequest},
+	InvalidSignature:     {codes.Unauthenticated, http.StatusBadRequest},
+	ExpiredRequest:       {codes.FailedPrecondition, http.StatusBadRequest},
+	ReplayedRequest:      {codes.AlreadyExists, http.StatusConflict},
+	Unauthorized:         {codes.PermissionDenied, http.StatusUnauthorized},
+	AccountNotExist:      {codes.NotFound, http.StatusNotFound},
+	KeychainNotOwned:     {codes.PermissionDenied, http.StatusConflict},
+	RequestInProgress:    {codes.Aborted, http.StatusConflict},
+	IdempotencyKeyReused: {codes.FailedPrecondition, http.StatusUnprocessableEntity},
+	RateLimited:          {codes.ResourceExhausted, http.StatusTooManyRequests},
+	Unavailable:          {codes.Unavailable, http.StatusServiceUnavailable},
+	Internal:             {codes.Internal, http.StatusInternalServerError},
+}
+
+//GRPCCode returns the GRPC status code of the error code
+func (c Code) GRPCCode() codes.Code {
+	if m, exist := catalogue[c]; exist {
+		return m.grpc
+	}
+	return codes.Internal
+}
+
+//HTTPStatus returns the HTTP status of the error code
+func (c Code) HTTPStatus() int {
+	if m, exist := catalogue[c]; exist {
+		return m.http
+	}
+	return http.StatusInternalServerError
+}
+
+//Error represents an error of the catalogue
+//
+//It is sent as a GRPC status with the error code in its details
+type Error struct {
+	Code    Code
+	Message string
+}
+
+//New creates an error of the catalogue
+func New(c Code, msg string) Error {
+	return Error{
+		Code:    c,
+		Message: msg,
+	}
+}
+
+func (e Error) Error() string {
+	return e.Message
+}
+
+//GRPCStatus returns the GRPC status of the error, used by the GRPC servers to send it
+func (e Error) GRPCStatus() *status.Status {
+	st := status.New(e.Code.GRPCCode(), e.Message)
+	detailed, err := st.WithDetails(&ErrorDetail{Code: string(e.Code)})
+	if err != nil {
+		return st
+	}
+	return detailed
+}
+
+//FromGRPC retrieves the error of the catalogue from an error returned by a GRPC call
+//
+//The code of the status is used when the status does not contain an error code, as the errors raised by GRPC itself
+func FromGRPC(err error) Error {
+	st, _ := status.FromError(err)
+
+	//The details are decoded from their raw value, as the generated messages are not always resolved by the GRPC status
+	for _, d := range st.Proto().GetDetails() {
+		var detail ErrorDetail
+		if strings.HasSuffix(d.GetTypeUrl(), "/"+proto.MessageName(&detail)) && proto.Unmarshal(d.GetValue(), &detail) == nil {
+			return New(Code(detail.Code), st.Message())
+		}
+	}
+
+	switch st.Code() {
+	case codes.Unavailable, codes.DeadlineExceeded:
+		return New(Unavailable, st.Message())
+	case codes.InvalidArgument:
+		return New(InvalidRequest, st.Message())
+	}
+	return New(Internal, st.Message())
+}
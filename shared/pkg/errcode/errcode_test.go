@@ -0,0 +1,52 @@
+package errcode
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+/*
+Scenario: Send an error of the catalogue through GRPC
+	Given an error of the catalogue
+	When I convert it into a GRPC status and I retrieve it from the status
+	Then I get the same code and message
+*/
+func TestErrorThroughGRPC(t *testing.T) {
+	err := New(AccountNotExist, "Account does not exist")
+
+	st := err.GRPCStatus()
+	assert.Equal(t, codes.NotFound, st.Code())
+
+	res := FromGRPC(st.Err())
+	assert.Equal(t, AccountNotExist, res.Code)
+	assert.Equal(t, "Account does not exist", res.Message)
+	assert.Equal(t, http.StatusNotFound, res.Code.HTTPStatus())
+}
+
+/*
+Scenario: Retrieve an error without error code from GRPC
+	Given GRPC errors without details
+	When I retrieve the error of the catalogue
+	Then I get a code matching the GRPC status code
+*/
+func TestFromGRPCWithoutDetails(t *testing.T) {
+	assert.Equal(t, Unavailable, FromGRPC(status.Error(codes.Unavailable, "connection refused")).Code)
+	assert.Equal(t, Internal, FromGRPC(status.Error(codes.Unknown, "unexpected")).Code)
+	assert.Equal(t, Internal, FromGRPC(errors.New("unexpected")).Code)
+}
+
+/*
+Scenario: Get the statuses of an unknown code
+	Given a code not defined in the catalogue
+	When I get its statuses
+	Then I get the internal error statuses
+*/
+func TestUnknownCode(t *testing.T) {
+	assert.Equal(t, codes.Internal, Code("unknown").GRPCCode())
+	assert.Equal(t, http.StatusInternalServerError, Code("unknown").HTTPStatus())
+}
@@ -0,0 +1,504 @@
+package keys
+
+import (
+	"crypto"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/hex"
+	"errors"
+	"math/big"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	secp256k1ecdsa "github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+	"github.com/uniris/ecies/pkg"
+	"golang.org/x/crypto/curve25519"
+)
+
+//Key encoding
+//
+//The keys are shared by the datamining, the API and the discovery services.
+//
+//A key is hex encoded and starts with its algorithm identifier (1 byte), followed by:
+// - ECDSA P-256: x509 PKIX public key or x509 EC private key
+// - Ed25519: 32 bytes public key or 32 bytes private key seed
+// - ECDSA secp256k1: 33 bytes compressed public key or 32 bytes private key scalar
+//
+//Keys without identifier are x509 ECDSA P-256 keys, as issued before the multi-algorithm support.
+//
+//Encryption uses ECIES for P-256 keys. For the other algorithms, an ephemeral key is generated
+//and the cipher is composed of the ephemeral public key, the nonce and the AES-256-GCM ciphertext
+//encrypted with the SHA-256 of the ECDH shared secret (X25519 for Ed25519 keys).
+
+//Algorithm identifies the algorithm of a key
+type Algorithm byte
+
+const (
+	//ECDSAP256 identifies ECDSA keys on the NIST P-256 curve
+	ECDSAP256 Algorithm = 0
+
+	//Ed25519 identifies Ed25519 keys
+	Ed25519 Algorithm = 1
+
+	//ECDSASecp256k1 identifies ECDSA keys on the secp256k1 curve
+	ECDSASecp256k1 Algorithm = 2
+)
+
+//ErrUnsupportedAlgorithm is returned when a key algorithm is not supported
+var ErrUnsupportedAlgorithm = errors.New("Unsupported key algorithm")
+
+//ErrInvalidKey is returned when a key cannot be parsed
+var ErrInvalidKey = errors.New("Invalid key")
+
+//ErrInvalidCipher is returned when a cipher cannot be decrypted
+var ErrInvalidCipher = errors.New("Invalid cipher")
+
+//ErrInvalidSignature is returned when a signature does not match the data and the public key
+var ErrInvalidSignature = errors.New("Invalid signature")
+
+//derSequence is the first byte of the x509 keys issued without algorithm identifier
+const derSequence = 0x30
+
+type publicKey interface {
+	verify(data []byte, sig []byte) bool
+	encrypt(data []byte) ([]byte, error)
+}
+
+type privateKey interface {
+	sign(data []byte) ([]byte, error)
+	decrypt(cipher []byte) ([]byte, error)
+}
+
+//GenerateKeyPair creates a new key pair for the given algorithm, encoded with its algorithm identifier
+func GenerateKeyPair(algo Algorithm) (pubKey string, pvKey string, err error) {
+	var pub, pv []byte
+
+	switch algo {
+	case ECDSAP256:
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			return "", "", err
+		}
+		if pub, err = x509.MarshalPKIXPublicKey(key.Public()); err != nil {
+			return "", "", err
+		}
+		if pv, err = x509.MarshalECPrivateKey(key); err != nil {
+			return "", "", err
+		}
+	case Ed25519:
+		edPub, edPv, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return "", "", err
+		}
+		pub = edPub
+		pv = edPv.Seed()
+	case ECDSASecp256k1:
+		key, err := secp256k1.GeneratePrivateKey()
+		if err != nil {
+			return "", "", err
+		}
+		pub = key.PubKey().SerializeCompressed()
+		pv = key.Serialize()
+	default:
+		return "", "", ErrUnsupportedAlgorithm
+	}
+
+	return encodeKey(algo, pub), encodeKey(algo, pv), nil
+}
+
+//Sign creates the hex encoded signature of the data with the private key
+func Sign(pvKey string, data []byte) (string, error) {
+	pv, err := parsePrivateKey(pvKey)
+	if err != nil {
+		return "", err
+	}
+	sig, err := pv.sign(data)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(sig), nil
+}
+
+//Verify checks the hex encoded signature of the data with the public key
+func Verify(pubKey string, data []byte, sig string) error {
+	pub, err := parsePublicKey(pubKey)
+	if err != nil {
+		return err
+	}
+	decodedSig, err := hex.DecodeString(sig)
+	if err != nil {
+		return err
+	}
+	if !pub.verify(data, decodedSig) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+//Encrypt creates the hex encoded cipher of the data for the public key
+func Encrypt(pubKey string, data []byte) (string, error) {
+	pub, err := parsePublicKey(pubKey)
+	if err != nil {
+		return "", err
+	}
+	cipher, err := pub.encrypt(data)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(cipher), nil
+}
+
+//Decrypt opens the hex encoded cipher with the private key
+func Decrypt(pvKey string, cipher string) ([]byte, error) {
+	pv, err := parsePrivateKey(pvKey)
+	if err != nil {
+		return nil, err
+	}
+	decodedCipher, err := hex.DecodeString(cipher)
+	if err != nil {
+		return nil, err
+	}
+	return pv.decrypt(decodedCipher)
+}
+
+//TLSSigner returns the private key as a signer of the TLS handshakes
+//
+//Only ECDSA P-256 and Ed25519 keys can be used in TLS.
+func TLSSigner(pvKey string) (crypto.Signer, error) {
+	pv, err := parsePrivateKey(pvKey)
+	if err != nil {
+		return nil, err
+	}
+	switch k := pv.(type) {
+	case p256PrivateKey:
+		return k.key, nil
+	case ed25519PrivateKey:
+		return k.key, nil
+	default:
+		return nil, ErrUnsupportedAlgorithm
+	}
+}
+
+//MatchPublicKey checks if a public key parsed by the standard library, as the one of a certificate, is the given public key
+//
+//Only ECDSA P-256 and Ed25519 keys can be used in TLS.
+func MatchPublicKey(pubKey string, key crypto.PublicKey) (bool, error) {
+	pub, err := parsePublicKey(pubKey)
+	if err != nil {
+		return false, err
+	}
+
+	type equaler interface {
+		Equal(crypto.PublicKey) bool
+	}
+
+	var k equaler
+	switch p := pub.(type) {
+	case p256PublicKey:
+		k = p.key
+	case ed25519PublicKey:
+		k = p.key
+	default:
+		return false, ErrUnsupportedAlgorithm
+	}
+	return k.Equal(key), nil
+}
+
+func encodeKey(algo Algorithm, key []byte) string {
+	return hex.EncodeToString(append([]byte{byte(algo)}, key...))
+}
+
+func decodeKey(key string) (Algorithm, []byte, error) {
+	b, err := hex.DecodeString(key)
+	if err != nil {
+		return 0, nil, err
+	}
+	if len(b) == 0 {
+		return 0, nil, ErrInvalidKey
+	}
+	if b[0] == derSequence {
+		return ECDSAP256, b, nil
+	}
+	return Algorithm(b[0]), b[1:], nil
+}
+
+func parsePublicKey(key string) (publicKey, error) {
+	algo, b, err := decodeKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	switch algo {
+	case ECDSAP256:
+		pu, err := x509.ParsePKIXPublicKey(b)
+		if err != nil {
+			return nil, err
+		}
+		ecPub, ok := pu.(*ecdsa.PublicKey)
+		if !ok {
+			return nil, ErrInvalidKey
+		}
+		return p256PublicKey{ecPub}, nil
+	case Ed25519:
+		if len(b) != ed25519.PublicKeySize {
+			return nil, ErrInvalidKey
+		}
+		return ed25519PublicKey{ed25519.PublicKey(b)}, nil
+	case ECDSASecp256k1:
+		pub, err := secp256k1.ParsePubKey(b)
+		if err != nil {
+			return nil, err
+		}
+		return secp256k1PublicKey{pub}, nil
+	default:
+		return nil, ErrUnsupportedAlgorithm
+	}
+}
+
+func parsePrivateKey(key string) (privateKey, error) {
+	algo, b, err := decodeKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	switch algo {
+	case ECDSAP256:
+		pv, err := x509.ParseECPrivateKey(b)
+		if err != nil {
+			return nil, err
+		}
+		return p256PrivateKey{pv}, nil
+	case Ed25519:
+		if len(b) != ed25519.SeedSize {
+			return nil, ErrInvalidKey
+		}
+		return ed25519PrivateKey{ed25519.NewKeyFromSeed(b)}, nil
+	case ECDSASecp256k1:
+		if len(b) != secp256k1.PrivKeyBytesLen {
+			return nil, ErrInvalidKey
+		}
+		return secp256k1PrivateKey{secp256k1.PrivKeyFromBytes(b)}, nil
+	default:
+		return nil, ErrUnsupportedAlgorithm
+	}
+}
+
+//ecdsaSignature is the ASN.1 representation of a P-256 signature
+type ecdsaSignature struct {
+	R, S *big.Int
+}
+
+//hashBytes is the hex encoded SHA-256 of the data, signed by the P-256 keys
+func hashBytes(data []byte) string {
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:])
+}
+
+type p256PublicKey struct {
+	key *ecdsa.PublicKey
+}
+
+func (k p256PublicKey) verify(data []byte, sig []byte) bool {
+	var signature ecdsaSignature
+	if _, err := asn1.Unmarshal(sig, &signature); err != nil {
+		return false
+	}
+	return ecdsa.Verify(k.key, []byte(hashBytes(data)), signature.R, signature.S)
+}
+
+func (k p256PublicKey) encrypt(data []byte) ([]byte, error) {
+	return ecies.Encrypt(rand.Reader, ecies.ImportECDSAPublic(k.key), data, nil, nil)
+}
+
+type p256PrivateKey struct {
+	key *ecdsa.PrivateKey
+}
+
+func (k p256PrivateKey) sign(data []byte) ([]byte, error) {
+	r, s, err := ecdsa.Sign(rand.Reader, k.key, []byte(hashBytes(data)))
+	if err != nil {
+		return nil, err
+	}
+	return asn1.Marshal(ecdsaSignature{r, s})
+}
+
+func (k p256PrivateKey) decrypt(cipher []byte) ([]byte, error) {
+	return ecies.ImportECDSA(k.key).Decrypt(cipher, nil, nil)
+}
+
+type ed25519PublicKey struct {
+	key ed25519.PublicKey
+}
+
+func (k ed25519PublicKey) verify(data []byte, sig []byte) bool {
+	return ed25519.Verify(k.key, data, sig)
+}
+
+func (k ed25519PublicKey) encrypt(data []byte) ([]byte, error) {
+	point, err := edwardsToMontgomery(k.key)
+	if err != nil {
+		return nil, err
+	}
+
+	ephemeral := make([]byte, curve25519.ScalarSize)
+	if _, err := rand.Read(ephemeral); err != nil {
+		return nil, err
+	}
+	ephemeralPub, err := curve25519.X25519(ephemeral, curve25519.Basepoint)
+	if err != nil {
+		return nil, err
+	}
+	secret, err := curve25519.X25519(ephemeral, point)
+	if err != nil {
+		return nil, err
+	}
+	return sealWithSecret(ephemeralPub, secret, data)
+}
+
+type ed25519PrivateKey struct {
+	key ed25519.PrivateKey
+}
+
+func (k ed25519PrivateKey) sign(data []byte) ([]byte, error) {
+	return ed25519.Sign(k.key, data), nil
+}
+
+func (k ed25519PrivateKey) decrypt(cipher []byte) ([]byte, error) {
+	if len(cipher) < curve25519.PointSize {
+		return nil, ErrInvalidCipher
+	}
+
+	//The X25519 scalar of an Ed25519 key is the first half of the SHA-512 of its seed
+	h := sha512.Sum512(k.key.Seed())
+	secret, err := curve25519.X25519(h[:curve25519.ScalarSize], cipher[:curve25519.PointSize])
+	if err != nil {
+		return nil, err
+	}
+	return openWithSecret(cipher[:curve25519.PointSize], secret, cipher[curve25519.PointSize:])
+}
+
+type secp256k1PublicKey struct {
+	key *secp256k1.PublicKey
+}
+
+func (k secp256k1PublicKey) verify(data []byte, sig []byte) bool {
+	signature, err := secp256k1ecdsa.ParseDERSignature(sig)
+	if err != nil {
+		return false
+	}
+	hash := sha256.Sum256(data)
+	return signature.Verify(hash[:], k.key)
+}
+
+func (k secp256k1PublicKey) encrypt(data []byte) ([]byte, error) {
+	ephemeral, err := secp256k1.GeneratePrivateKey()
+	if err != nil {
+		return nil, err
+	}
+	secret := secp256k1.GenerateSharedSecret(ephemeral, k.key)
+	return sealWithSecret(ephemeral.PubKey().SerializeCompressed(), secret, data)
+}
+
+type secp256k1PrivateKey struct {
+	key *secp256k1.PrivateKey
+}
+
+func (k secp256k1PrivateKey) sign(data []byte) ([]byte, error) {
+	hash := sha256.Sum256(data)
+	return secp256k1ecdsa.Sign(k.key, hash[:]).Serialize(), nil
+}
+
+func (k secp256k1PrivateKey) decrypt(cipher []byte) ([]byte, error) {
+	if len(cipher) < secp256k1.PubKeyBytesLenCompressed {
+		return nil, ErrInvalidCipher
+	}
+	ephemeralPub, err := secp256k1.ParsePubKey(cipher[:secp256k1.PubKeyBytesLenCompressed])
+	if err != nil {
+		return nil, err
+	}
+	secret := secp256k1.GenerateSharedSecret(k.key, ephemeralPub)
+	return openWithSecret(cipher[:secp256k1.PubKeyBytesLenCompressed], secret, cipher[secp256k1.PubKeyBytesLenCompressed:])
+}
+
+func sealWithSecret(ephemeralPub []byte, secret []byte, data []byte) ([]byte, error) {
+	gcm, err := newSecretGCM(secret)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	out := append([]byte{}, ephemeralPub...)
+	out = append(out, nonce...)
+	return gcm.Seal(out, nonce, data, ephemeralPub), nil
+}
+
+func openWithSecret(ephemeralPub []byte, secret []byte, cipher []byte) ([]byte, error) {
+	gcm, err := newSecretGCM(secret)
+	if err != nil {
+		return nil, err
+	}
+	if len(cipher) < gcm.NonceSize() {
+		return nil, ErrInvalidCipher
+	}
+	clear, err := gcm.Open(nil, cipher[:gcm.NonceSize()], cipher[gcm.NonceSize():], ephemeralPub)
+	if err != nil {
+		return nil, ErrInvalidCipher
+	}
+	return clear, nil
+}
+
+func newSecretGCM(secret []byte) (cipher.AEAD, error) {
+	key := sha256.Sum256(secret)
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+//curve25519P is the prime 2^255 - 19 of the Curve25519 field
+var curve25519P, _ = new(big.Int).SetString("7fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffed", 16)
+
+//edwardsToMontgomery converts an Ed25519 public key into its X25519 public key: u = (1 + y) / (1 - y)
+func edwardsToMontgomery(pub ed25519.PublicKey) ([]byte, error) {
+	le := make([]byte, len(pub))
+	copy(le, pub)
+	le[31] &= 0x7f
+
+	y := new(big.Int).SetBytes(reverseBytes(le))
+	if y.Cmp(curve25519P) >= 0 {
+		return nil, ErrInvalidKey
+	}
+
+	num := new(big.Int).Add(big.NewInt(1), y)
+	den := new(big.Int).Sub(big.NewInt(1), y)
+	den.Mod(den, curve25519P)
+	if den.Sign() == 0 {
+		return nil, ErrInvalidKey
+	}
+	den.ModInverse(den, curve25519P)
+
+	u := num.Mul(num, den)
+	u.Mod(u, curve25519P)
+
+	out := make([]byte, curve25519.PointSize)
+	u.FillBytes(out)
+	return reverseBytes(out), nil
+}
+
+func reverseBytes(b []byte) []byte {
+	for i, j := 0, len(b)-1; i < j; i, j = i+1, j-1 {
+		b[i], b[j] = b[j], b[i]
+	}
+	return b
+}
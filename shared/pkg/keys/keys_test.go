@@ -0,0 +1,124 @@
+package keys
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var algorithms = []Algorithm{ECDSAP256, Ed25519, ECDSASecp256k1}
+
+/*
+Scenario: Sign and verify with each algorithm
+	Given a key pair for each supported algorithm
+	When I sign data and verify the signature
+	Then the signature is valid for the data and invalid for other data
+*/
+func TestSignAndVerifyAlgorithms(t *testing.T) {
+	for _, algo := range algorithms {
+		pub, pv, err := GenerateKeyPair(algo)
+		assert.Nil(t, err)
+
+		sig, err := Sign(pv, []byte("uniris"))
+		assert.Nil(t, err)
+		assert.Nil(t, Verify(pub, []byte("uniris"), sig), "algorithm %d", algo)
+		assert.Equal(t, ErrInvalidSignature, Verify(pub, []byte("other"), sig), "algorithm %d", algo)
+	}
+}
+
+/*
+Scenario: Encrypt and decrypt with each algorithm
+	Given a key pair for each supported algorithm
+	When I encrypt data with the public key and decrypt it with the private key
+	Then I get the clear data
+*/
+func TestEncryptAndDecryptAlgorithms(t *testing.T) {
+	for _, algo := range algorithms {
+		pub, pv, err := GenerateKeyPair(algo)
+		assert.Nil(t, err)
+
+		cipher, err := Encrypt(pub, []byte("uniris"))
+		assert.Nil(t, err)
+
+		clear, err := Decrypt(pv, cipher)
+		assert.Nil(t, err, "algorithm %d", algo)
+		assert.Equal(t, "uniris", string(clear))
+	}
+}
+
+/*
+Scenario: Decrypt with the wrong key
+	Given a cipher encrypted for an Ed25519 key
+	When I decrypt it with another Ed25519 key
+	Then I get an error
+*/
+func TestDecryptWithWrongKey(t *testing.T) {
+	pub, _, _ := GenerateKeyPair(Ed25519)
+	_, otherPv, _ := GenerateKeyPair(Ed25519)
+
+	cipher, _ := Encrypt(pub, []byte("uniris"))
+
+	_, err := Decrypt(otherPv, cipher)
+	assert.Equal(t, ErrInvalidCipher, err)
+}
+
+/*
+Scenario: Use a key without algorithm identifier
+	Given a x509 ECDSA P-256 key pair without algorithm identifier
+	When I sign data and verify the signature
+	Then the key is handled as a P-256 key
+*/
+func TestLegacyP256Key(t *testing.T) {
+	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	pvKey, _ := x509.MarshalECPrivateKey(key)
+	pubKey, _ := x509.MarshalPKIXPublicKey(key.Public())
+
+	sig, err := Sign(hex.EncodeToString(pvKey), []byte("uniris"))
+	assert.Nil(t, err)
+	assert.Nil(t, Verify(hex.EncodeToString(pubKey), []byte("uniris"), sig))
+	assert.Nil(t, Verify(encodeKey(ECDSAP256, pubKey), []byte("uniris"), sig))
+}
+
+/*
+Scenario: Use a key with an unknown algorithm
+	Given a key with an unknown algorithm identifier
+	When I want to parse it
+	Then I get an error
+*/
+func TestUnsupportedAlgorithm(t *testing.T) {
+	_, err := parsePublicKey(encodeKey(Algorithm(10), []byte("key")))
+	assert.Equal(t, ErrUnsupportedAlgorithm, err)
+
+	_, _, err = GenerateKeyPair(Algorithm(10))
+	assert.Equal(t, ErrUnsupportedAlgorithm, err)
+}
+
+/*
+Scenario: Use the keys in TLS
+	Given a key pair for each supported algorithm
+	When I want the TLS signer of the private key and match its public key
+	Then the P-256 and Ed25519 keys are supported and the secp256k1 keys are not
+*/
+func TestTLSSigner(t *testing.T) {
+	for _, algo := range []Algorithm{ECDSAP256, Ed25519} {
+		pub, pv, _ := GenerateKeyPair(algo)
+		otherPub, _, _ := GenerateKeyPair(algo)
+
+		signer, err := TLSSigner(pv)
+		assert.Nil(t, err)
+		match, err := MatchPublicKey(pub, signer.Public())
+		assert.Nil(t, err)
+		assert.True(t, match, "algorithm %d", algo)
+		match, _ = MatchPublicKey(otherPub, signer.Public())
+		assert.False(t, match, "algorithm %d", algo)
+	}
+
+	_, pv, _ := GenerateKeyPair(ECDSASecp256k1)
+	_, err := TLSSigner(pv)
+	assert.Equal(t, ErrUnsupportedAlgorithm, err)
+}
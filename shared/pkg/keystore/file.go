@@ -0,0 +1,230 @@
+package keystore
+
+import (
+	"crypto"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"sync"
+
+	"github.com/uniris/uniris-core/shared/pkg/keys"
+	"golang.org/x/crypto/scrypt"
+)
+
+//scrypt parameters used to derive the encryption key from the passphrase
+const (
+	scryptN      = 1 << 15
+	scryptR      = 8
+	scryptP      = 1
+	scryptKeyLen = 32
+	saltLen      = 16
+)
+
+type fileStore struct {
+	path       string
+	passphrase string
+
+	//The private keys are decrypted once, as the key derivation is slow on purpose
+	mu     sync.Mutex
+	pvKeys map[string]string
+}
+
+//fileEntry is the representation of a keypair on disk
+//
+//The private key is encrypted with AES-GCM using a key derived from the passphrase with scrypt
+type fileEntry struct {
+	PublicKey           string `json:"public_key"`
+	Salt                string `json:"salt"`
+	Nonce               string `json:"nonce"`
+	EncryptedPrivateKey string `json:"encrypted_private_key"`
+}
+
+//NewFileStore creates a key store persisted in a file and encrypted with a passphrase
+func NewFileStore(path string, passphrase string) (KeyStore, error) {
+	if passphrase == "" {
+		return nil, ErrEmptyPassphrase
+	}
+	return &fileStore{
+		path:       path,
+		passphrase: passphrase,
+		pvKeys:     make(map[string]string),
+	}, nil
+}
+
+func (s *fileStore) PublicKey(name string) (string, error) {
+	e, err := s.entry(name)
+	if err != nil {
+		return "", err
+	}
+	return e.PublicKey, nil
+}
+
+func (s *fileStore) Sign(name string, data []byte) (string, error) {
+	pv, err := s.privateKey(name)
+	if err != nil {
+		return "", err
+	}
+	return keys.Sign(pv, data)
+}
+
+func (s *fileStore) Decrypt(name string, cipher string) ([]byte, error) {
+	pv, err := s.privateKey(name)
+	if err != nil {
+		return nil, err
+	}
+	return keys.Decrypt(pv, cipher)
+}
+
+func (s *fileStore) TLSSigner(name string) (crypto.Signer, error) {
+	pv, err := s.privateKey(name)
+	if err != nil {
+		return nil, err
+	}
+	return keys.TLSSigner(pv)
+}
+
+func (s *fileStore) entry(name string) (fileEntry, error) {
+	entries, err := s.readEntries()
+	if err != nil {
+		return fileEntry{}, err
+	}
+
+	e, exist := entries[name]
+	if !exist {
+		return fileEntry{}, ErrKeyNotFound
+	}
+	return e, nil
+}
+
+func (s *fileStore) privateKey(name string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if pv, exist := s.pvKeys[name]; exist {
+		return pv, nil
+	}
+
+	e, err := s.entry(name)
+	if err != nil {
+		return "", err
+	}
+	pv, err := s.decrypt(e)
+	if err != nil {
+		return "", err
+	}
+	s.pvKeys[name] = pv
+	return pv, nil
+}
+
+func (s *fileStore) StoreKeyPair(name string, kp KeyPair) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entries, err := s.readEntries()
+	if err != nil {
+		return err
+	}
+
+	e, err := s.encrypt(kp)
+	if err != nil {
+		return err
+	}
+	entries[name] = e
+	delete(s.pvKeys, name)
+
+	b, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(s.path, b, 0600)
+}
+
+func (s *fileStore) readEntries() (map[string]fileEntry, error) {
+	entries := make(map[string]fileEntry)
+
+	b, err := ioutil.ReadFile(s.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return entries, nil
+		}
+		return nil, err
+	}
+
+	if err := json.Unmarshal(b, &entries); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+func (s *fileStore) encrypt(kp KeyPair) (fileEntry, error) {
+	salt := make([]byte, saltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return fileEntry{}, err
+	}
+
+	aead, err := s.cipher(salt)
+	if err != nil {
+		return fileEntry{}, err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return fileEntry{}, err
+	}
+
+	//The public key is authenticated along with the private key
+	cipherText := aead.Seal(nil, nonce, []byte(kp.PrivateKey), []byte(kp.PublicKey))
+
+	return fileEntry{
+		PublicKey:           kp.PublicKey,
+		Salt:                hex.EncodeToString(salt),
+		Nonce:               hex.EncodeToString(nonce),
+		EncryptedPrivateKey: hex.EncodeToString(cipherText),
+	}, nil
+}
+
+func (s *fileStore) decrypt(e fileEntry) (string, error) {
+	salt, err := hex.DecodeString(e.Salt)
+	if err != nil {
+		return "", err
+	}
+	nonce, err := hex.DecodeString(e.Nonce)
+	if err != nil {
+		return "", err
+	}
+	cipherText, err := hex.DecodeString(e.EncryptedPrivateKey)
+	if err != nil {
+		return "", err
+	}
+
+	aead, err := s.cipher(salt)
+	if err != nil {
+		return "", err
+	}
+	if len(nonce) != aead.NonceSize() {
+		return "", ErrInvalidPassphrase
+	}
+
+	pv, err := aead.Open(nil, nonce, cipherText, []byte(e.PublicKey))
+	if err != nil {
+		return "", ErrInvalidPassphrase
+	}
+	return string(pv), nil
+}
+
+func (s *fileStore) cipher(salt []byte) (cipher.AEAD, error) {
+	key, err := scrypt.Key([]byte(s.passphrase), salt, scryptN, scryptR, scryptP, scryptKeyLen)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
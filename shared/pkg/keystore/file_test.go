@@ -0,0 +1,110 @@
+package keystore
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/uniris/uniris-core/shared/pkg/keys"
+)
+
+/*
+Scenario: Sign and decrypt with a keypair of the file key store
+	Given a keypair stored with a passphrase
+	When I want to sign and decrypt with it using the same passphrase
+	Then the signature and the clear data are valid and the private key is not in clear in the file
+*/
+func TestFileStoreRoundTrip(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "keystore")
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "keystore.json")
+
+	pub, pv, _ := keys.GenerateKeyPair(keys.Ed25519)
+	s, err := NewFileStore(path, "passphrase")
+	assert.Nil(t, err)
+	assert.Nil(t, s.StoreKeyPair(NodeKey, KeyPair{PublicKey: pub, PrivateKey: pv}))
+
+	s, _ = NewFileStore(path, "passphrase")
+	storedPub, err := s.PublicKey(NodeKey)
+	assert.Nil(t, err)
+	assert.Equal(t, pub, storedPub)
+
+	sig, err := s.Sign(NodeKey, []byte("uniris"))
+	assert.Nil(t, err)
+	assert.Nil(t, keys.Verify(pub, []byte("uniris"), sig))
+
+	cipher, _ := keys.Encrypt(pub, []byte("uniris"))
+	clear, err := s.Decrypt(NodeKey, cipher)
+	assert.Nil(t, err)
+	assert.Equal(t, "uniris", string(clear))
+
+	b, _ := ioutil.ReadFile(path)
+	assert.NotContains(t, string(b), pv)
+
+	info, _ := os.Stat(path)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+}
+
+/*
+Scenario: Open the file key store with a wrong passphrase
+	Given a keypair stored with a passphrase
+	When I want to sign with another passphrase
+	Then I get an error
+*/
+func TestFileStoreInvalidPassphrase(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "keystore")
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "keystore.json")
+
+	pub, pv, _ := keys.GenerateKeyPair(keys.Ed25519)
+	s, _ := NewFileStore(path, "passphrase")
+	assert.Nil(t, s.StoreKeyPair(NodeKey, KeyPair{PublicKey: pub, PrivateKey: pv}))
+
+	s, _ = NewFileStore(path, "other")
+	_, err := s.Sign(NodeKey, []byte("uniris"))
+	assert.Equal(t, ErrInvalidPassphrase, err)
+}
+
+/*
+Scenario: Open the file key store without passphrase
+	Given an empty passphrase
+	When I want to open the key store
+	Then I get an error
+*/
+func TestFileStoreEmptyPassphrase(t *testing.T) {
+	_, err := NewFileStore("keystore.json", "")
+	assert.Equal(t, ErrEmptyPassphrase, err)
+}
+
+/*
+Scenario: Retrieve an unknown key from the file key store
+	Given an empty key store
+	When I want to sign with a keypair
+	Then I get an error
+*/
+func TestFileStoreKeyNotFound(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "keystore")
+	defer os.RemoveAll(dir)
+
+	s, _ := NewFileStore(filepath.Join(dir, "keystore.json"), "passphrase")
+	_, err := s.Sign(RobotKey, []byte("uniris"))
+	assert.Equal(t, ErrKeyNotFound, err)
+}
+
+/*
+Scenario: Reference a key of the key store in the configuration
+	Given the name of a key
+	When I create its reference and parse it
+	Then I get the name of the key, and a private key is not a reference
+*/
+func TestReference(t *testing.T) {
+	name, ok := ParseReference(Reference(NodeKey))
+	assert.True(t, ok)
+	assert.Equal(t, NodeKey, name)
+
+	_, pv, _ := keys.GenerateKeyPair(keys.ECDSAP256)
+	_, ok = ParseReference(pv)
+	assert.False(t, ok)
+}
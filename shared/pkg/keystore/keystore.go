@@ -0,0 +1,73 @@
+package keystore
+
+import (
+	"crypto"
+	"errors"
+	"strings"
+)
+
+//ErrKeyNotFound is returned when the key store does not contain the requested key
+var ErrKeyNotFound = errors.New("Key not found")
+
+//ErrInvalidPassphrase is returned when the key store cannot be opened with the passphrase
+var ErrInvalidPassphrase = errors.New("Invalid passphrase")
+
+//ErrEmptyPassphrase is returned when a key store is opened without passphrase
+var ErrEmptyPassphrase = errors.New("The key store passphrase must be defined")
+
+//ErrKeyMismatch is returned when the public key of the key store does not match the configured one
+var ErrKeyMismatch = errors.New("Key mismatch")
+
+//Key names used by the services
+const (
+	//NodeKey identifies the node's keypair
+	NodeKey = "node"
+
+	//RobotKey identifies the shared robot keypair without version
+	RobotKey = "robot"
+)
+
+//referencePrefix starts the references to the keys of a key store, a hex encoded key cannot contain it
+const referencePrefix = "keystore:"
+
+//KeyPair represents a keypair imported into a key store
+type KeyPair struct {
+	PublicKey  string
+	PrivateKey string
+}
+
+//KeyStore defines methods to use the private keys of the node without exposing them
+//
+//The keys are kept out of the configuration file and never leave the key store:
+//the services ask the key store to sign or to decrypt with them.
+//A file, a PKCS#11 module or an external signer can back the key store.
+type KeyStore interface {
+
+	//PublicKey retrieves the public key of the keypair identified by the name
+	PublicKey(name string) (string, error)
+
+	//Sign creates the hex encoded signature of the data with the private key identified by the name
+	Sign(name string, data []byte) (string, error)
+
+	//Decrypt opens the hex encoded cipher with the private key identified by the name
+	Decrypt(name string, cipher string) ([]byte, error)
+
+	//TLSSigner returns a signer of the TLS handshakes using the private key identified by the name
+	TLSSigner(name string) (crypto.Signer, error)
+
+	//StoreKeyPair saves the keypair under the name
+	StoreKeyPair(name string, kp KeyPair) error
+}
+
+//Reference creates a reference to a key of a key store, used in place of a private key in the configuration
+func Reference(name string) string {
+	return referencePrefix + name
+}
+
+//ParseReference retrieves the name of the key referenced by a private key of the configuration
+func ParseReference(pvKey string) (string, bool) {
+	if !strings.HasPrefix(pvKey, referencePrefix) {
+		return "", false
+	}
+	return strings.TrimPrefix(pvKey, referencePrefix), true
+}
@@ -0,0 +1,73 @@
+package keystore
+
+import (
+	"crypto"
+	"sync"
+
+	"github.com/uniris/uniris-core/shared/pkg/keys"
+)
+
+type memoryStore struct {
+	mu  sync.RWMutex
+	kps map[string]KeyPair
+}
+
+//NewMemoryStore creates a key store keeping the keypairs in memory
+//
+//It holds the keys read from the configuration when no key store file is configured
+func NewMemoryStore() KeyStore {
+	return &memoryStore{
+		kps: make(map[string]KeyPair),
+	}
+}
+
+func (s *memoryStore) keyPair(name string) (KeyPair, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	kp, exist := s.kps[name]
+	if !exist {
+		return KeyPair{}, ErrKeyNotFound
+	}
+	return kp, nil
+}
+
+func (s *memoryStore) PublicKey(name string) (string, error) {
+	kp, err := s.keyPair(name)
+	if err != nil {
+		return "", err
+	}
+	return kp.PublicKey, nil
+}
+
+func (s *memoryStore) Sign(name string, data []byte) (string, error) {
+	kp, err := s.keyPair(name)
+	if err != nil {
+		return "", err
+	}
+	return keys.Sign(kp.PrivateKey, data)
+}
+
+func (s *memoryStore) Decrypt(name string, cipher string) ([]byte, error) {
+	kp, err := s.keyPair(name)
+	if err != nil {
+		return nil, err
+	}
+	return keys.Decrypt(kp.PrivateKey, cipher)
+}
+
+func (s *memoryStore) TLSSigner(name string) (crypto.Signer, error) {
+	kp, err := s.keyPair(name)
+	if err != nil {
+		return nil, err
+	}
+	return keys.TLSSigner(kp.PrivateKey)
+}
+
+func (s *memoryStore) StoreKeyPair(name string, kp KeyPair) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.kps[name] = kp
+	return nil
+}
@@ -0,0 +1,39 @@
+package keystore
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/uniris/uniris-core/shared/pkg/keys"
+)
+
+/*
+Scenario: Sign and decrypt with a keypair of the memory key store
+	Given a keypair read from the configuration and stored in memory
+	When I want to sign and decrypt with it
+	Then the signature and the clear data are valid
+*/
+func TestMemoryStoreRoundTrip(t *testing.T) {
+	pub, pv, _ := keys.GenerateKeyPair(keys.ECDSAP256)
+	s := NewMemoryStore()
+	assert.Nil(t, s.StoreKeyPair(NodeKey, KeyPair{PublicKey: pub, PrivateKey: pv}))
+
+	storedPub, err := s.PublicKey(NodeKey)
+	assert.Nil(t, err)
+	assert.Equal(t, pub, storedPub)
+
+	sig, err := s.Sign(NodeKey, []byte("uniris"))
+	assert.Nil(t, err)
+	assert.Nil(t, keys.Verify(pub, []byte("uniris"), sig))
+
+	cipher, _ := keys.Encrypt(pub, []byte("uniris"))
+	clear, err := s.Decrypt(NodeKey, cipher)
+	assert.Nil(t, err)
+	assert.Equal(t, "uniris", string(clear))
+
+	_, err = s.TLSSigner(NodeKey)
+	assert.Nil(t, err)
+
+	_, err = s.Sign(RobotKey, []byte("uniris"))
+	assert.Equal(t, ErrKeyNotFound, err)
+}
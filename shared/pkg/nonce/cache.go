@@ -0,0 +1,79 @@
+package nonce
+
+import (
+	"container/heap"
+	"sync"
+	"time"
+)
+
+//Cache defines methods to register the nonces of the signed requests until their expiration
+type Cache interface {
+
+	//Add registers the nonce of a signer until its expiration and returns false if it is already registered
+	//
+	//The nonces of different signers do not collide
+	Add(signer string, nonce string, expiration time.Time) bool
+}
+
+type entry struct {
+	signer string
+	nonce  string
+}
+
+type cache struct {
+	mu          sync.Mutex
+	nonces      map[entry]time.Time
+	expirations nonceQueue
+}
+
+//NewCache creates a cache keeping the nonces in memory
+//
+//The nonces are queued by expiration, so only the expired ones are visited when a nonce is added
+func NewCache() Cache {
+	return &cache{
+		nonces: make(map[entry]time.Time),
+	}
+}
+
+func (c *cache) Add(signer string, nonce string, expiration time.Time) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.removeExpired(time.Now())
+
+	e := entry{signer, nonce}
+	if _, exist := c.nonces[e]; exist {
+		return false
+	}
+	c.nonces[e] = expiration
+	heap.Push(&c.expirations, nonceExpiration{e, expiration})
+	return true
+}
+
+func (c *cache) removeExpired(now time.Time) {
+	for c.expirations.Len() > 0 && c.expirations[0].at.Before(now) {
+		exp := heap.Pop(&c.expirations).(nonceExpiration)
+		if c.nonces[exp.entry].Equal(exp.at) {
+			delete(c.nonces, exp.entry)
+		}
+	}
+}
+
+type nonceExpiration struct {
+	entry entry
+	at    time.Time
+}
+
+//nonceQueue is a min-heap of the nonces ordered by expiration
+type nonceQueue []nonceExpiration
+
+func (q nonceQueue) Len() int            { return len(q) }
+func (q nonceQueue) Less(i, j int) bool  { return q[i].at.Before(q[j].at) }
+func (q nonceQueue) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *nonceQueue) Push(x interface{}) { *q = append(*q, x.(nonceExpiration)) }
+func (q *nonceQueue) Pop() interface{} {
+	old := *q
+	n := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return n
+}
@@ -0,0 +1,53 @@
+package nonce
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+/*
+Scenario: Register the nonces until their expiration
+	Given a nonce registered and expired
+	When the nonce is registered again
+	Then the nonce is accepted until its new expiration
+*/
+func TestCacheExpiration(t *testing.T) {
+	c := NewCache()
+	assert.True(t, c.Add("signer", "nonce", time.Now().Add(-time.Second)))
+	assert.True(t, c.Add("signer", "nonce", time.Now().Add(time.Minute)))
+	assert.False(t, c.Add("signer", "nonce", time.Now().Add(time.Minute)))
+}
+
+/*
+Scenario: Register the nonces by signer
+	Given a nonce registered by a signer
+	When another signer uses the same nonce
+	Then the nonce is accepted
+*/
+func TestCacheSigners(t *testing.T) {
+	c := NewCache()
+	assert.True(t, c.Add("signer1", "nonce", time.Now().Add(time.Minute)))
+	assert.True(t, c.Add("signer2", "nonce", time.Now().Add(time.Minute)))
+	assert.False(t, c.Add("signer1", "nonce", time.Now().Add(time.Minute)))
+	assert.True(t, c.Add("signer", "1nonce", time.Now().Add(time.Minute)), "The signer and the nonce are not concatenated")
+}
+
+/*
+Scenario: Forget only the expired nonces
+	Given nonces registered with several expirations
+	When a new nonce is registered
+	Then only the expired nonces are removed
+*/
+func TestCacheRemovesExpiredOnly(t *testing.T) {
+	c := NewCache().(*cache)
+	c.Add("signer", "expired1", time.Now().Add(-time.Second))
+	c.Add("signer", "valid", time.Now().Add(time.Minute))
+	c.Add("signer", "expired2", time.Now().Add(-2*time.Second))
+
+	assert.True(t, c.Add("signer", "other", time.Now().Add(time.Minute)))
+	assert.Len(t, c.nonces, 2)
+	assert.Len(t, c.expirations, 2)
+	assert.False(t, c.Add("signer", "valid", time.Now().Add(time.Minute)))
+}
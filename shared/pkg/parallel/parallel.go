@@ -0,0 +1,23 @@
+package parallel
+
+import "sync"
+
+//Each calls f for each index from 0 to n-1, with at most limit calls running at the same time
+//
+//It returns once all the calls are done
+func Each(n int, limit int, f func(i int)) {
+	sem := make(chan struct{}, limit)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			f(i)
+		}(i)
+	}
+	wg.Wait()
+}
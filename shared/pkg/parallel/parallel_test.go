@@ -0,0 +1,41 @@
+package parallel
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+/*
+Scenario: Call a function for each index with a bounded concurrency
+	Given ten indexes and a limit of three calls
+	When I call a function for each index
+	Then each index is called once and never more than three calls run at the same time
+*/
+func TestEach(t *testing.T) {
+	var mu sync.Mutex
+	running, maxRunning := 0, 0
+	called := make([]int, 10)
+
+	Each(10, 3, func(i int) {
+		mu.Lock()
+		running++
+		if running > maxRunning {
+			maxRunning = running
+		}
+		called[i]++
+		mu.Unlock()
+
+		time.Sleep(10 * time.Millisecond)
+
+		mu.Lock()
+		running--
+		mu.Unlock()
+	})
+
+	assert.Equal(t, []int{1, 1, 1, 1, 1, 1, 1, 1, 1, 1}, called)
+	assert.True(t, maxRunning <= 3)
+	assert.True(t, maxRunning > 1)
+}